@@ -0,0 +1,304 @@
+// Package descset contains the protobuf schema loading behavior shared by
+// all of the protohcl command line tools.
+//
+// Each of the tools can accept either one or more files containing compiled
+// FileDescriptorSet messages, as produced by protoc's --descriptor_set_out
+// option, or one or more .proto source files, which it will compile by
+// running protoc.
+//
+// The -doc-directives option allows using schemas that don't use the
+// options from hcl.proto, by deriving equivalent options from directives in
+// their comments as described for protohcl.ApplyDocDirectives.
+package descset
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/apparentlymart/go-protohcl/protohcl"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	// We don't use protohclext directly here, but it must be linked into
+	// the program so that the HCL extension options are in the global
+	// type registry when we unmarshal the descriptors.
+	_ "github.com/apparentlymart/go-protohcl/protohcl/protohclext"
+)
+
+// Flags represents the command line options that control how to load a
+// schema.
+type Flags struct {
+	// Protoc is the protoc executable to run when compiling .proto files.
+	Protoc string
+
+	// ImportPaths are the directories protoc should search for imported
+	// .proto files.
+	ImportPaths []string
+
+	// DocDirectives enables deriving HCL options from directives in the
+	// schema's comments, using protohcl.ApplyDocDirectives.
+	DocDirectives bool
+}
+
+// Register adds the options represented by the reciever to the given
+// flag set.
+func (f *Flags) Register(fs *flag.FlagSet) {
+	fs.StringVar(&f.Protoc, "protoc", "protoc", "protoc executable to use when compiling .proto files")
+	fs.Var((*stringsFlag)(&f.ImportPaths), "I", "directory to search for imported .proto files (can be repeated)")
+	fs.BoolVar(&f.DocDirectives, "doc-directives", false, "derive HCL options from @hcl directives in the schema's comments")
+}
+
+// Load reads the schema from the given filenames, following the settings
+// in the reciever.
+//
+// If all of the filenames have the suffix ".proto" then Load compiles them
+// by running protoc. Otherwise, each file must contain a binary-serialized
+// FileDescriptorSet.
+func (f *Flags) Load(filenames []string) (*protoregistry.Files, error) {
+	if len(filenames) == 0 {
+		return nil, fmt.Errorf("no schema files specified")
+	}
+
+	protoCount := 0
+	for _, fn := range filenames {
+		if strings.HasSuffix(fn, ".proto") {
+			protoCount++
+		}
+	}
+
+	var sets []*descriptorpb.FileDescriptorSet
+	switch protoCount {
+	case len(filenames):
+		set, err := RunProtoc(f.Protoc, f.ImportPaths, filenames)
+		if err != nil {
+			return nil, err
+		}
+		sets = append(sets, set)
+	case 0:
+		for _, fn := range filenames {
+			set, err := ReadFile(fn)
+			if err != nil {
+				return nil, err
+			}
+			sets = append(sets, set)
+		}
+	default:
+		return nil, fmt.Errorf("can't mix .proto source files with compiled descriptor set files")
+	}
+
+	if f.DocDirectives {
+		for i, set := range sets {
+			set, err := protohcl.ApplyDocDirectives(set)
+			if err != nil {
+				return nil, err
+			}
+			sets[i] = set
+		}
+	}
+
+	return NewFiles(sets...)
+}
+
+// ReadFile reads a binary-serialized FileDescriptorSet from the given file.
+func ReadFile(filename string) (*descriptorpb.FileDescriptorSet, error) {
+	src, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	set := &descriptorpb.FileDescriptorSet{}
+	err = proto.Unmarshal(src, set)
+	if err != nil {
+		return nil, fmt.Errorf("invalid descriptor set in %s: %w", filename, err)
+	}
+	return set, nil
+}
+
+// RunProtoc runs the given protoc executable to compile the given .proto
+// files into a FileDescriptorSet, including all of their imports and their
+// source code information so that callers can access the comments.
+func RunProtoc(protoc string, importPaths []string, filenames []string) (*descriptorpb.FileDescriptorSet, error) {
+	tmpDir, err := ioutil.TempDir("", "protohcl")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+	outFile := filepath.Join(tmpDir, "descriptors.pb")
+
+	args := make([]string, 0, len(importPaths)+len(filenames)+3)
+	for _, path := range importPaths {
+		args = append(args, "-I"+path)
+	}
+	args = append(args, "--include_imports", "--include_source_info", "--descriptor_set_out="+outFile)
+	args = append(args, filenames...)
+
+	var stderr bytes.Buffer
+	cmd := exec.Command(protoc, args...)
+	cmd.Stderr = &stderr
+	err = cmd.Run()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("failed to run %s: %w\n%s", protoc, err, msg)
+		}
+		return nil, fmt.Errorf("failed to run %s: %w", protoc, err)
+	}
+
+	return ReadFile(outFile)
+}
+
+// NewFiles builds a descriptor registry from the files in the given
+// descriptor sets.
+//
+// Unlike protodesc.NewFiles, the sets need not include the files they
+// depend on if those files are already in the global registry, which
+// includes hcl.proto and the protobuf well-known types. This means that
+// it's not strictly necessary to use protoc's --include_imports option
+// when generating the descriptor sets.
+func NewFiles(sets ...*descriptorpb.FileDescriptorSet) (*protoregistry.Files, error) {
+	fileProtos := make(map[string]*descriptorpb.FileDescriptorProto)
+	var names []string
+	for _, set := range sets {
+		for _, fileProto := range set.File {
+			name := fileProto.GetName()
+			if _, exists := fileProtos[name]; exists {
+				continue // we'll just use the first one we found
+			}
+			fileProtos[name] = fileProto
+			names = append(names, name)
+		}
+	}
+
+	b := &filesBuilder{
+		protos:   fileProtos,
+		files:    &protoregistry.Files{},
+		visiting: make(map[string]struct{}),
+	}
+	for _, name := range names {
+		if err := b.build(name); err != nil {
+			return nil, err
+		}
+	}
+	return b.files, nil
+}
+
+type filesBuilder struct {
+	protos   map[string]*descriptorpb.FileDescriptorProto
+	files    *protoregistry.Files
+	visiting map[string]struct{}
+}
+
+// build registers the file of the given name, after first registering any
+// of the files it depends on, so that we don't rely on the files in the
+// descriptor sets being in dependency order.
+func (b *filesBuilder) build(name string) error {
+	if _, err := b.files.FindFileByPath(name); err == nil {
+		return nil // already registered
+	}
+	if _, exists := b.visiting[name]; exists {
+		return fmt.Errorf("import cycle involving %s", name)
+	}
+	b.visiting[name] = struct{}{}
+	defer delete(b.visiting, name)
+
+	fileProto := b.protos[name]
+	for _, dep := range fileProto.Dependency {
+		if _, ok := b.protos[dep]; ok {
+			if err := b.build(dep); err != nil {
+				return err
+			}
+		}
+	}
+
+	file, err := protodesc.NewFile(fileProto, resolver{b.files})
+	if err != nil {
+		return fmt.Errorf("invalid descriptor for %s: %w", name, err)
+	}
+	return b.files.RegisterFile(file)
+}
+
+// resolver is a protodesc.Resolver that searches the files we've already
+// loaded and then falls back on the global registry.
+type resolver struct {
+	files *protoregistry.Files
+}
+
+func (r resolver) FindFileByPath(path string) (protoreflect.FileDescriptor, error) {
+	if file, err := r.files.FindFileByPath(path); err == nil {
+		return file, nil
+	}
+	return protoregistry.GlobalFiles.FindFileByPath(path)
+}
+
+func (r resolver) FindDescriptorByName(name protoreflect.FullName) (protoreflect.Descriptor, error) {
+	if desc, err := r.files.FindDescriptorByName(name); err == nil {
+		return desc, nil
+	}
+	return protoregistry.GlobalFiles.FindDescriptorByName(name)
+}
+
+// FindMessage finds the message type with the given fully-qualified name in
+// the given registry.
+func FindMessage(files *protoregistry.Files, name string) (protoreflect.MessageDescriptor, error) {
+	desc, err := files.FindDescriptorByName(protoreflect.FullName(name))
+	if err != nil {
+		return nil, fmt.Errorf("no message type named %s", name)
+	}
+	msgDesc, ok := desc.(protoreflect.MessageDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a message type", name)
+	}
+	return msgDesc, nil
+}
+
+// AllMessages returns all of the message types declared in the given
+// registry, including nested message types but excluding the synthetic
+// message types that protobuf uses to represent map entries, sorted by
+// their file paths and then by their order of declaration.
+func AllMessages(files *protoregistry.Files) []protoreflect.MessageDescriptor {
+	var fileDescs []protoreflect.FileDescriptor
+	files.RangeFiles(func(file protoreflect.FileDescriptor) bool {
+		fileDescs = append(fileDescs, file)
+		return true
+	})
+	sort.Slice(fileDescs, func(i, j int) bool {
+		return fileDescs[i].Path() < fileDescs[j].Path()
+	})
+
+	var ret []protoreflect.MessageDescriptor
+	for _, file := range fileDescs {
+		ret = appendMessages(ret, file.Messages())
+	}
+	return ret
+}
+
+func appendMessages(ret []protoreflect.MessageDescriptor, msgs protoreflect.MessageDescriptors) []protoreflect.MessageDescriptor {
+	for i := 0; i < msgs.Len(); i++ {
+		msg := msgs.Get(i)
+		if msg.IsMapEntry() {
+			continue
+		}
+		ret = append(ret, msg)
+		ret = appendMessages(ret, msg.Messages())
+	}
+	return ret
+}
+
+type stringsFlag []string
+
+func (f *stringsFlag) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *stringsFlag) Set(v string) error {
+	*f = append(*f, v)
+	return nil
+}
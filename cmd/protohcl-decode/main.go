@@ -0,0 +1,117 @@
+// Command protohcl-decode decodes an HCL configuration file into a message
+// of a given HCL-annotated protobuf message type, for testing schemas without
+// needing to write a host program.
+//
+// Usage:
+//
+//	protohcl-decode [options] -message NAME -config FILE SCHEMA-FILE...
+//
+// Each SCHEMA-FILE is either a compiled FileDescriptorSet, as produced by
+// protoc's --descriptor_set_out option, or a .proto source file to compile by
+// running protoc. The configuration file is parsed as HCL native syntax
+// unless its name has the suffix ".json", in which case it's parsed as HCL's
+// JSON syntax.
+//
+// The tool writes the decoded message to stdout in either protobuf text
+// format or protobuf JSON format, and writes any diagnostics to stderr along
+// with snippets of the configuration and the protobuf fields they relate to.
+// It exits with a non-zero status if there are any error diagnostics, but
+// still writes the partial message that the decoder returned.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/apparentlymart/go-protohcl/cmd/internal/descset"
+	"github.com/apparentlymart/go-protohcl/protohcl"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/encoding/prototext"
+)
+
+func main() {
+	ok, err := run(os.Args[1:], os.Stdout, os.Stderr)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "protohcl-decode: %s\n", err)
+		os.Exit(2)
+	}
+	if !ok {
+		os.Exit(1)
+	}
+}
+
+// run decodes the configuration described by the given arguments, writing
+// the result to stdout and any diagnostics to stderr. It returns false if
+// there were any error diagnostics, or an error if it couldn't decode at all.
+func run(args []string, stdout, stderr io.Writer) (bool, error) {
+	fs := flag.NewFlagSet("protohcl-decode", flag.ContinueOnError)
+	var schemaFlags descset.Flags
+	schemaFlags.Register(fs)
+	msgName := fs.String("message", "", "fully-qualified name of the message type to decode into (required)")
+	configFile := fs.String("config", "", "HCL configuration file to decode (required)")
+	format := fs.String("format", "text", "output format: text (protobuf text format) or json")
+	if err := fs.Parse(args); err != nil {
+		return false, err
+	}
+	if *msgName == "" {
+		return false, fmt.Errorf("must specify a message type using -message")
+	}
+	if *configFile == "" {
+		return false, fmt.Errorf("must specify a configuration file using -config")
+	}
+	if *format != "text" && *format != "json" {
+		return false, fmt.Errorf("unsupported output format %q", *format)
+	}
+
+	files, err := schemaFlags.Load(fs.Args())
+	if err != nil {
+		return false, err
+	}
+	desc, err := descset.FindMessage(files, *msgName)
+	if err != nil {
+		return false, err
+	}
+
+	parser := hclparse.NewParser()
+	var f *hcl.File
+	var diags hcl.Diagnostics
+	if strings.HasSuffix(*configFile, ".json") {
+		f, diags = parser.ParseJSONFile(*configFile)
+	} else {
+		f, diags = parser.ParseHCLFile(*configFile)
+	}
+	if diags.HasErrors() {
+		diagWr := hcl.NewDiagnosticTextWriter(stderr, parser.Files(), 78, false)
+		err := diagWr.WriteDiagnostics(diags)
+		return false, err
+	}
+
+	msg, _, moreDiags := protohcl.DecodeBodyWithTrace(f.Body, desc, nil)
+	diags = append(diags, moreDiags...)
+	diagWr := protohcl.NewDiagnosticTextWriter(stderr, parser.Files(), 78, false)
+
+	var out []byte
+	switch *format {
+	case "json":
+		out, err = protojson.MarshalOptions{Multiline: true}.Marshal(msg)
+	default:
+		out, err = prototext.MarshalOptions{Multiline: true}.Marshal(msg)
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to serialize result: %w", err)
+	}
+	if _, err := stdout.Write(out); err != nil {
+		return false, err
+	}
+	if len(diags) != 0 {
+		if err := diagWr.WriteDiagnostics(diags); err != nil {
+			return false, err
+		}
+	}
+	return !diags.HasErrors(), nil
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/apparentlymart/go-protohcl/protohcl/schemabuilder"
+	"github.com/google/go-cmp/cmp"
+	"github.com/zclconf/go-cty/cty"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+
+	f := schemabuilder.NewFile("decode_test.proto", "decode.test")
+	f.AddMessage("Config").
+		AddAttribute("name", cty.String, schemabuilder.Required).
+		AddAttribute("count", cty.Number)
+	fileProto, err := f.FileDescriptorProto()
+	if err != nil {
+		t.Fatal(err)
+	}
+	src, err := proto.Marshal(&descriptorpb.FileDescriptorSet{
+		File: []*descriptorpb.FileDescriptorProto{fileProto},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	setFile := filepath.Join(dir, "test.pb")
+	writeFile(t, setFile, src)
+
+	validFile := filepath.Join(dir, "valid.hcl")
+	writeFile(t, validFile, []byte("name  = \"Jackson\"\ncount = 2\n"))
+	invalidFile := filepath.Join(dir, "invalid.hcl")
+	writeFile(t, invalidFile, []byte("count = \"many\"\n"))
+	jsonFile := filepath.Join(dir, "valid.json")
+	writeFile(t, jsonFile, []byte(`{"name": "Jackson"}`))
+
+	tests := map[string]struct {
+		args       []string
+		wantOK     bool
+		wantStdout string
+		wantStderr string
+	}{
+		"valid": {
+			[]string{"-message=decode.test.Config", "-config=" + validFile, setFile},
+			true,
+			`name: "Jackson" count: "2"`,
+			``,
+		},
+		"valid JSON output": {
+			[]string{"-message=decode.test.Config", "-config=" + validFile, "-format=json", setFile},
+			true,
+			`{ "name": "Jackson", "count": "2" }`,
+			``,
+		},
+		"valid JSON input": {
+			[]string{"-message=decode.test.Config", "-config=" + jsonFile, setFile},
+			true,
+			`name: "Jackson"`,
+			``,
+		},
+		"invalid": {
+			[]string{"-message=decode.test.Config", "-config=" + invalidFile, setFile},
+			false,
+			``,
+			`Error: Missing required argument`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			ok, err := run(test.args, &stdout, &stderr)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if ok != test.wantOK {
+				t.Errorf("wrong result %t; want %t\n%s", ok, test.wantOK, stderr.String())
+			}
+
+			// The protobuf serializers intentionally produce unstable
+			// whitespace, so we'll normalize it before comparing.
+			gotStdout := strings.Join(strings.Fields(stdout.String()), " ")
+			if diff := cmp.Diff(test.wantStdout, gotStdout); diff != "" {
+				t.Errorf("wrong stdout\n%s", diff)
+			}
+			if test.wantStderr == "" {
+				if stderr.Len() != 0 {
+					t.Errorf("unexpected stderr\n%s", stderr.String())
+				}
+			} else if !strings.Contains(stderr.String(), test.wantStderr) {
+				t.Errorf("stderr does not contain %q\n%s", test.wantStderr, stderr.String())
+			}
+		})
+	}
+}
+
+func writeFile(t *testing.T, filename string, src []byte) {
+	t.Helper()
+	if err := ioutil.WriteFile(filename, src, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
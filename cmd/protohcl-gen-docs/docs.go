@@ -0,0 +1,337 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/apparentlymart/go-protohcl/protohcl"
+	"github.com/apparentlymart/go-protohcl/protohcl/protohclext"
+	"github.com/hashicorp/hcl/v2/ext/typeexpr"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// messageDoc is the documentation for a single HCL-annotated message type,
+// describing the body of a block or of a whole configuration file.
+//
+// The JSON serialization of this type is the tool's JSON output format, so
+// changes to it must be backward-compatible.
+type messageDoc struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description,omitempty"`
+	Labels      []labelDoc     `json:"labels,omitempty"`
+	Attributes  []attributeDoc `json:"attributes,omitempty"`
+	Blocks      []blockDoc     `json:"blocks,omitempty"`
+}
+
+type labelDoc struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+type attributeDoc struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Required    bool   `json:"required,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+type blockDoc struct {
+	TypeName string `json:"type_name"`
+
+	// Aliases are the deprecated type names that the block type also
+	// accepts.
+	Aliases []string `json:"aliases,omitempty"`
+
+	// Labels includes all of the labels for the block type, including the
+	// extra map key label for blocks represented as map fields.
+	Labels []string `json:"labels,omitempty"`
+
+	// Nesting is "single", "repeated", or "map".
+	Nesting string `json:"nesting"`
+
+	// MapKeyAttr is the argument whose value is each block's key, for a
+	// map of blocks that's keyed by an argument rather than a label.
+	MapKeyAttr string `json:"map_key_attr,omitempty"`
+
+	// AnyDiscriminator is the argument whose value selects the message type
+	// of the block's body from AnyTypes, for a block whose content depends
+	// on an argument.
+	AnyDiscriminator string `json:"any_discriminator,omitempty"`
+
+	// AnyTypes maps each value of the AnyDiscriminator argument to the name
+	// of the message type that describes the block's body in that case.
+	AnyTypes map[string]string `json:"any_types,omitempty"`
+
+	// Body is the name of the message type that describes the block's body.
+	// It's empty for a block whose body type is selected from AnyTypes.
+	Body        string `json:"body"`
+	Description string `json:"description,omitempty"`
+}
+
+// buildDocs returns documentation for each of the given message types that
+// has at least one HCL-annotated field, in the same order as given.
+func buildDocs(descs []protoreflect.MessageDescriptor) ([]messageDoc, error) {
+	var ret []messageDoc
+	for _, desc := range descs {
+		doc, err := buildMessageDoc(desc)
+		if err != nil {
+			return nil, err
+		}
+		if len(doc.Labels) == 0 && len(doc.Attributes) == 0 && len(doc.Blocks) == 0 {
+			continue // not an HCL-annotated message
+		}
+		ret = append(ret, doc)
+	}
+	return ret, nil
+}
+
+func buildMessageDoc(desc protoreflect.MessageDescriptor) (messageDoc, error) {
+	doc := messageDoc{
+		Name:        string(desc.FullName()),
+		Description: descriptionFor(desc),
+	}
+	err := addFieldDocs(desc, &doc)
+	return doc, err
+}
+
+// addFieldDocs adds documentation for the HCL-annotated fields of the given
+// message type to the given document, including the fields of any flattened
+// messages.
+func addFieldDocs(desc protoreflect.MessageDescriptor, doc *messageDoc) error {
+	fields := desc.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		elem, err := protohcl.GetFieldElem(field)
+		if err != nil {
+			return err
+		}
+
+		switch elem := elem.(type) {
+		case protohcl.FieldAttribute:
+			ty, diags := elem.TypeConstraint()
+			if diags.HasErrors() {
+				return fmt.Errorf("invalid type constraint for %s: %s", field.FullName(), diags.Error())
+			}
+			doc.Attributes = append(doc.Attributes, attributeDoc{
+				Name:        elem.Name,
+				Type:        typeexpr.TypeString(ty),
+				Required:    elem.Required,
+				Description: descriptionFor(field),
+			})
+
+		case protohcl.FieldNestedBlockType:
+			var labels []string
+			if elem.MapKeyLabel != "" {
+				labels = append(labels, elem.MapKeyLabel)
+			}
+			labels, err := appendLabelNames(labels, elem.Nested)
+			if err != nil {
+				return err
+			}
+			nesting := "single"
+			switch {
+			case elem.CollectionKind == protohclext.NestedBlock_MAP:
+				nesting = "map"
+			case elem.Repeated:
+				nesting = "repeated"
+			}
+			block := blockDoc{
+				TypeName:    elem.TypeName,
+				Aliases:     elem.Aliases,
+				Labels:      labels,
+				Nesting:     nesting,
+				MapKeyAttr:  elem.MapKeyAttr,
+				Body:        string(elem.Nested.FullName()),
+				Description: descriptionFor(field),
+			}
+			if elem.AnyDiscriminator != "" {
+				block.AnyDiscriminator = elem.AnyDiscriminator
+				block.AnyTypes = make(map[string]string, len(elem.AnyTypes))
+				for value, desc := range elem.AnyTypes {
+					block.AnyTypes[value] = string(desc.FullName())
+				}
+				block.Body = ""
+			}
+			doc.Blocks = append(doc.Blocks, block)
+
+		case protohcl.FieldBlockLabel:
+			// An explicit (hcl.label).description takes priority over the
+			// field's comments, because it's written for the same audience.
+			description := elem.Description
+			if description == "" {
+				description = descriptionFor(field)
+			}
+			doc.Labels = append(doc.Labels, labelDoc{
+				Name:        elem.Name,
+				Description: description,
+			})
+
+		case protohcl.FieldFlattened:
+			// We document the nested message's fields separately first, so
+			// that we can add the flatten prefix, if any, to their names.
+			var nested messageDoc
+			err := addFieldDocs(elem.Nested, &nested)
+			if err != nil {
+				return err
+			}
+			for _, attr := range nested.Attributes {
+				attr.Name = elem.Prefix + attr.Name
+				if _, shadowed := elem.Shadowed[attr.Name]; shadowed {
+					continue // the containing message takes this name
+				}
+				doc.Attributes = append(doc.Attributes, attr)
+			}
+			for _, block := range nested.Blocks {
+				block.TypeName = elem.Prefix + block.TypeName
+				if len(block.Aliases) != 0 {
+					aliases := make([]string, len(block.Aliases))
+					for i, alias := range block.Aliases {
+						aliases[i] = elem.Prefix + alias
+					}
+					block.Aliases = aliases
+				}
+				doc.Blocks = append(doc.Blocks, block)
+			}
+			for _, label := range nested.Labels {
+				label.Name = elem.Prefix + label.Name
+				doc.Labels = append(doc.Labels, label)
+			}
+		}
+	}
+	return nil
+}
+
+func appendLabelNames(names []string, desc protoreflect.MessageDescriptor) ([]string, error) {
+	fields := desc.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		elem, err := protohcl.GetFieldElem(fields.Get(i))
+		if err != nil {
+			return nil, err
+		}
+		switch elem := elem.(type) {
+		case protohcl.FieldBlockLabel:
+			names = append(names, elem.Name)
+		case protohcl.FieldFlattened:
+			nested, err := appendLabelNames(nil, elem.Nested)
+			if err != nil {
+				return nil, err
+			}
+			for _, name := range nested {
+				names = append(names, elem.Prefix+name)
+			}
+		}
+	}
+	return names, nil
+}
+
+// descriptionFor returns the leading comments for the given descriptor, if
+// the schema included source information. Returns an empty string if not.
+func descriptionFor(desc protoreflect.Descriptor) string {
+	loc := desc.ParentFile().SourceLocations().ByDescriptor(desc)
+	lines := strings.Split(strings.TrimSpace(loc.LeadingComments), "\n")
+	for i, line := range lines {
+		// Protobuf comments conventionally have a space after the comment
+		// marker, which protoc preserves.
+		lines[i] = strings.TrimPrefix(line, " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// writeMarkdown writes the given documentation to the given writer as a
+// Markdown document, with a second-level heading for each message type.
+func writeMarkdown(w io.Writer, docs []messageDoc) error {
+	var buf strings.Builder
+	for _, doc := range docs {
+		// Each section ends with a blank line, so there's no need to add
+		// another one between messages.
+		fmt.Fprintf(&buf, "## `%s`\n\n", doc.Name)
+		if doc.Description != "" {
+			fmt.Fprintf(&buf, "%s\n\n", doc.Description)
+		}
+
+		if len(doc.Labels) != 0 {
+			buf.WriteString("### Labels\n\n")
+			for _, label := range doc.Labels {
+				writeMarkdownItem(&buf, fmt.Sprintf("`%s`", label.Name), label.Description)
+			}
+			buf.WriteString("\n")
+		}
+
+		if len(doc.Attributes) != 0 {
+			buf.WriteString("### Arguments\n\n")
+			for _, attr := range doc.Attributes {
+				head := fmt.Sprintf("`%s` (`%s`", attr.Name, attr.Type)
+				if attr.Required {
+					head += ", required"
+				}
+				head += ")"
+				writeMarkdownItem(&buf, head, attr.Description)
+			}
+			buf.WriteString("\n")
+		}
+
+		if len(doc.Blocks) != 0 {
+			buf.WriteString("### Blocks\n\n")
+			for _, block := range doc.Blocks {
+				head := fmt.Sprintf("`%s", block.TypeName)
+				for _, label := range block.Labels {
+					head += fmt.Sprintf(" %q", label)
+				}
+				nesting := block.Nesting
+				if block.MapKeyAttr != "" {
+					nesting += fmt.Sprintf(" keyed by `%s`", block.MapKeyAttr)
+				}
+				if block.AnyDiscriminator != "" {
+					head += fmt.Sprintf("` (%s, body selected by `%s`: ", nesting, block.AnyDiscriminator)
+					values := make([]string, 0, len(block.AnyTypes))
+					for value := range block.AnyTypes {
+						values = append(values, value)
+					}
+					sort.Strings(values)
+					for i, value := range values {
+						if i != 0 {
+							head += ", "
+						}
+						body := block.AnyTypes[value]
+						head += fmt.Sprintf("`%s` see [`%s`](#%s)", value, body, markdownAnchor(body))
+					}
+				} else {
+					head += fmt.Sprintf("` (%s, see [`%s`](#%s)", nesting, block.Body, markdownAnchor(block.Body))
+				}
+				for i, alias := range block.Aliases {
+					if i == 0 {
+						head += "; deprecated aliases: "
+					} else {
+						head += ", "
+					}
+					head += fmt.Sprintf("`%s`", alias)
+				}
+				head += ")"
+				writeMarkdownItem(&buf, head, block.Description)
+			}
+			buf.WriteString("\n")
+		}
+	}
+
+	_, err := io.WriteString(w, strings.TrimRight(buf.String(), "\n")+"\n")
+	return err
+}
+
+func writeMarkdownItem(buf *strings.Builder, head, description string) {
+	if description == "" {
+		fmt.Fprintf(buf, "* %s\n", head)
+		return
+	}
+	// Any additional lines of the description must be indented so that
+	// they'll be considered part of the same list item.
+	description = strings.ReplaceAll(description, "\n", "\n  ")
+	fmt.Fprintf(buf, "* %s: %s\n", head, description)
+}
+
+// markdownAnchor returns the anchor name that the common Markdown renderers
+// generate for the heading of the message type with the given name.
+func markdownAnchor(name string) string {
+	return strings.ToLower(strings.ReplaceAll(name, ".", ""))
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/apparentlymart/go-protohcl/protohcl/protohclext"
+	"github.com/apparentlymart/go-protohcl/protohcl/schemabuilder"
+	"github.com/google/go-cmp/cmp"
+	"github.com/zclconf/go-cty/cty"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func TestRun(t *testing.T) {
+	f := schemabuilder.NewFile("docs_test.proto", "docs.test")
+	thing := f.AddMessage("Thing").
+		AddLabel("name").
+		AddAttribute("enabled", cty.Bool)
+	f.AddMessage("Config").
+		AddAttribute("name", cty.String, schemabuilder.Required).
+		AddAttribute("tags", cty.Map(cty.String)).
+		AddBlock("thing", thing, schemabuilder.Repeated, schemabuilder.BlockAliases("item"))
+	f.AddMessage("Unannotated")
+	fileProto, err := f.FileDescriptorProto()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// schemabuilder can't set label descriptions, so we'll add one to the
+	// option it generated.
+	label := proto.GetExtension(fileProto.MessageType[0].Field[0].Options, protohclext.E_Label).(*protohclext.BlockLabel)
+	label.Description = "The thing's unique name."
+
+	// schemabuilder doesn't generate any source code information, so we'll
+	// add some comments of our own to make sure we include them.
+	fileProto.SourceCodeInfo = &descriptorpb.SourceCodeInfo{
+		Location: []*descriptorpb.SourceCodeInfo_Location{
+			{
+				Path:            []int32{4, 1}, // message Config
+				Span:            []int32{0, 0, 0},
+				LeadingComments: proto.String(" The main configuration.\n"),
+			},
+			{
+				Path:            []int32{4, 1, 2, 0}, // field name in Config
+				Span:            []int32{0, 0, 0},
+				LeadingComments: proto.String(" The name of the thing\n being configured.\n"),
+			},
+		},
+	}
+
+	src, err := proto.Marshal(&descriptorpb.FileDescriptorSet{
+		File: []*descriptorpb.FileDescriptorProto{fileProto},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	setFile := filepath.Join(t.TempDir(), "test.pb")
+	err = ioutil.WriteFile(setFile, src, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("markdown", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := run([]string{setFile}, &buf)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		want := "## `docs.test.Thing`\n" +
+			"\n" +
+			"### Labels\n" +
+			"\n" +
+			"* `name`: The thing's unique name.\n" +
+			"\n" +
+			"### Arguments\n" +
+			"\n" +
+			"* `enabled` (`bool`)\n" +
+			"\n" +
+			"## `docs.test.Config`\n" +
+			"\n" +
+			"The main configuration.\n" +
+			"\n" +
+			"### Arguments\n" +
+			"\n" +
+			"* `name` (`string`, required): The name of the thing\n" +
+			"  being configured.\n" +
+			"* `tags` (`map(string)`)\n" +
+			"\n" +
+			"### Blocks\n" +
+			"\n" +
+			"* `thing \"name\"` (repeated, see [`docs.test.Thing`](#docstestthing); deprecated aliases: `item`)\n"
+		if diff := cmp.Diff(want, buf.String()); diff != "" {
+			t.Errorf("wrong output\n%s", diff)
+		}
+	})
+	t.Run("json", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := run([]string{"-format=json", "-message=docs.test.Thing", setFile}, &buf)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		want := `[
+  {
+    "name": "docs.test.Thing",
+    "labels": [
+      {
+        "name": "name",
+        "description": "The thing's unique name."
+      }
+    ],
+    "attributes": [
+      {
+        "name": "enabled",
+        "type": "bool"
+      }
+    ]
+  }
+]
+`
+		if diff := cmp.Diff(want, buf.String()); diff != "" {
+			t.Errorf("wrong output\n%s", diff)
+		}
+	})
+	t.Run("no such message", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := run([]string{"-message=docs.test.Nope", setFile}, &buf)
+		if err == nil {
+			t.Fatalf("unexpected success")
+		}
+		if got, want := err.Error(), "no message type named docs.test.Nope"; got != want {
+			t.Errorf("wrong error\ngot:  %s\nwant: %s", got, want)
+		}
+	})
+}
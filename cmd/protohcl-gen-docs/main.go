@@ -0,0 +1,88 @@
+// Command protohcl-gen-docs generates documentation for the HCL
+// configuration language described by HCL-annotated protobuf message types.
+//
+// Usage:
+//
+//	protohcl-gen-docs [options] FILE...
+//
+// Each FILE is either a compiled FileDescriptorSet, as produced by protoc's
+// --descriptor_set_out option, or a .proto source file to compile by running
+// protoc. The documentation includes the leading comments from the .proto
+// source files only if the descriptor sets include source information, which
+// protoc's --include_source_info option enables.
+//
+// The tool generates documentation for every message type that has at least
+// one HCL-annotated field, unless limited by the -message option, and writes
+// it to stdout either as Markdown or as JSON.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/apparentlymart/go-protohcl/cmd/internal/descset"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func main() {
+	err := run(os.Args[1:], os.Stdout)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "protohcl-gen-docs: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, w io.Writer) error {
+	fs := flag.NewFlagSet("protohcl-gen-docs", flag.ContinueOnError)
+	var schemaFlags descset.Flags
+	schemaFlags.Register(fs)
+	format := fs.String("format", "markdown", "output format: markdown or json")
+	var msgNames []string
+	fs.Func("message", "fully-qualified name of a message type to document (can be repeated; defaults to all)", func(v string) error {
+		msgNames = append(msgNames, v)
+		return nil
+	})
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	files, err := schemaFlags.Load(fs.Args())
+	if err != nil {
+		return err
+	}
+
+	var descs []protoreflect.MessageDescriptor
+	if len(msgNames) == 0 {
+		descs = descset.AllMessages(files)
+	} else {
+		for _, name := range msgNames {
+			desc, err := descset.FindMessage(files, name)
+			if err != nil {
+				return err
+			}
+			descs = append(descs, desc)
+		}
+	}
+
+	docs, err := buildDocs(descs)
+	if err != nil {
+		return err
+	}
+
+	switch *format {
+	case "markdown":
+		return writeMarkdown(w, docs)
+	case "json":
+		if docs == nil {
+			docs = []messageDoc{} // so we'll produce an empty array, rather than null
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(docs)
+	default:
+		return fmt.Errorf("unsupported output format %q", *format)
+	}
+}
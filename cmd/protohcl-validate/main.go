@@ -0,0 +1,198 @@
+// Command protohcl-validate checks the HCL annotations in a protobuf schema
+// and reports any problems it finds.
+//
+// Usage:
+//
+//	protohcl-validate [options] FILE...
+//
+// Each FILE is either a compiled FileDescriptorSet, as produced by protoc's
+// --descriptor_set_out option, or a .proto source file to compile by running
+// protoc.
+//
+// The tool checks every message type that has at least one HCL-annotated
+// field, unless limited by the -message option, and writes each problem to
+// stdout either as a line of text or as an element of a JSON array. It exits
+// with a non-zero status if it finds any errors, or if it finds any warnings
+// when the -strict option is set, so that it's suitable for use in
+// continuous integration checks.
+//
+// The -example option gives the path of an example configuration file, in
+// HCL native syntax, written for the message types selected by -message. The
+// tool then also checks the examples against those message types, such as
+// by checking that each block in an example has the number of labels that
+// its block type declares.
+//
+// Each warning includes the identifier of the rule that reported it, which
+// can be passed to the -ignore option to suppress all warnings from that
+// rule. Errors can't be suppressed.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/apparentlymart/go-protohcl/cmd/internal/descset"
+	"github.com/apparentlymart/go-protohcl/protohcl"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func main() {
+	ok, err := run(os.Args[1:], os.Stdout)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "protohcl-validate: %s\n", err)
+		os.Exit(2)
+	}
+	if !ok {
+		os.Exit(1)
+	}
+}
+
+// finding is the JSON representation of a protohcl.SchemaProblem.
+type finding struct {
+	Severity   string `json:"severity"`
+	Decl       string `json:"decl"`
+	Message    string `json:"message"`
+	Suggestion string `json:"suggestion,omitempty"`
+	Rule       string `json:"rule,omitempty"`
+}
+
+// run validates the schema described by the given arguments, writing the
+// findings to the given writer. It returns false if the findings should
+// cause the program to fail, or an error if it couldn't validate at all.
+func run(args []string, w io.Writer) (bool, error) {
+	fs := flag.NewFlagSet("protohcl-validate", flag.ContinueOnError)
+	var schemaFlags descset.Flags
+	schemaFlags.Register(fs)
+	format := fs.String("format", "text", "output format: text or json")
+	strict := fs.Bool("strict", false, "fail if there are any warnings, in addition to errors")
+	var msgNames []string
+	fs.Func("message", "fully-qualified name of a message type to validate (can be repeated; defaults to all)", func(v string) error {
+		msgNames = append(msgNames, v)
+		return nil
+	})
+	ignore := make(map[string]struct{})
+	fs.Func("ignore", "identifier of a warning rule to suppress (can be repeated)", func(v string) error {
+		ignore[v] = struct{}{}
+		return nil
+	})
+	var examplePaths []string
+	fs.Func("example", "path of an example configuration file for the -message types to check (can be repeated)", func(v string) error {
+		examplePaths = append(examplePaths, v)
+		return nil
+	})
+	if err := fs.Parse(args); err != nil {
+		return false, err
+	}
+	if len(examplePaths) != 0 && len(msgNames) == 0 {
+		return false, fmt.Errorf("-example requires at least one -message option, to select the message types that the examples are for")
+	}
+	if *format != "text" && *format != "json" {
+		return false, fmt.Errorf("unsupported output format %q", *format)
+	}
+
+	files, err := schemaFlags.Load(fs.Args())
+	if err != nil {
+		return false, err
+	}
+
+	var descs []protoreflect.MessageDescriptor
+	if len(msgNames) == 0 {
+		for _, desc := range descset.AllMessages(files) {
+			if hasHCLAnnotations(desc) {
+				descs = append(descs, desc)
+			}
+		}
+	} else {
+		for _, name := range msgNames {
+			desc, err := descset.FindMessage(files, name)
+			if err != nil {
+				return false, err
+			}
+			descs = append(descs, desc)
+		}
+	}
+
+	parser := hclparse.NewParser()
+	for _, path := range examplePaths {
+		if _, diags := parser.ParseHCLFile(path); diags.HasErrors() {
+			return false, fmt.Errorf("invalid example %s: %s", path, diags.Error())
+		}
+	}
+
+	// ValidateMessageDesc also checks all of the message types reachable
+	// from the one it's given, so we might find the same problem more than
+	// once when checking several messages.
+	findings := []finding{}
+	seen := make(map[protohcl.SchemaProblem]struct{})
+	ok := true
+	for _, desc := range descs {
+		problems := protohcl.ValidateMessageDesc(desc)
+		for _, path := range examplePaths {
+			problems = append(problems, protohcl.ValidateExample(desc, parser.Files()[path].Body)...)
+		}
+		for _, problem := range problems {
+			if _, exists := seen[problem]; exists {
+				continue
+			}
+			if _, ignored := ignore[problem.Rule]; ignored && problem.Rule != "" {
+				continue
+			}
+			seen[problem] = struct{}{}
+			if problem.Severity == protohcl.SchemaProblemError || *strict {
+				ok = false
+			}
+			findings = append(findings, finding{
+				Severity:   problem.Severity.String(),
+				Decl:       string(problem.Decl),
+				Message:    problem.Message,
+				Suggestion: problem.Suggestion,
+				Rule:       problem.Rule,
+			})
+		}
+	}
+
+	switch *format {
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(findings)
+	default:
+		for _, f := range findings {
+			severity := f.Severity
+			if f.Rule != "" {
+				severity += "[" + f.Rule + "]"
+			}
+			_, err = fmt.Fprintf(w, "%s: %s: %s\n", severity, f.Decl, f.Message)
+			if err == nil && f.Suggestion != "" {
+				_, err = fmt.Fprintf(w, "  suggestion: %s\n", f.Suggestion)
+			}
+			if err != nil {
+				break
+			}
+		}
+	}
+	return ok, err
+}
+
+// hasHCLAnnotations returns true if the given message type has any
+// HCL-specific options, or any fields with HCL-specific options.
+//
+// We use this to avoid checking message types that aren't intended for use
+// with HCL at all, such as those from imported files.
+func hasHCLAnnotations(desc protoreflect.MessageDescriptor) bool {
+	if protohcl.OutputForMessageDesc(desc) != "" {
+		return true
+	}
+	fields := desc.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		elem, err := protohcl.GetFieldElem(fields.Get(i))
+		if elem != nil || err != nil {
+			return true
+		}
+	}
+	return false
+}
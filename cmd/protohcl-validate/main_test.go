@@ -0,0 +1,127 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/apparentlymart/go-protohcl/protohcl/schemabuilder"
+	"github.com/google/go-cmp/cmp"
+	"github.com/zclconf/go-cty/cty"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func TestRun(t *testing.T) {
+	f := schemabuilder.NewFile("validate_test.proto", "validate.test")
+	f.AddMessage("Valid").
+		AddAttribute("name", cty.String)
+	f.AddMessage("Unconventional").
+		AddAttribute("displayName", cty.String)
+	f.AddMessage("Unannotated")
+	f.AddMessage("WithBlocks").
+		AddBlock("service", f.AddMessage("Service").AddAttribute("port", cty.Number))
+	tree := f.AddMessage("Tree").
+		AddAttribute("name", cty.String)
+	tree.AddBlock("child", tree)
+	fileProto, err := f.FileDescriptorProto()
+	if err != nil {
+		t.Fatal(err)
+	}
+	src, err := proto.Marshal(&descriptorpb.FileDescriptorSet{
+		File: []*descriptorpb.FileDescriptorProto{fileProto},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	setFile := filepath.Join(t.TempDir(), "test.pb")
+	err = ioutil.WriteFile(setFile, src, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exampleFile := filepath.Join(t.TempDir(), "example.hcl")
+	err = ioutil.WriteFile(exampleFile, []byte("service \"web\" {\n  port = 80\n}\n"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		args   []string
+		wantOK bool
+		want   string
+	}{
+		"all messages": {
+			[]string{setFile},
+			true,
+			`warning[naming-convention]: validate.test.Unconventional.displayName: attribute name "displayName" does not follow the HCL convention of using only lowercase letters, digits, and underscores
+  suggestion: Use the name "display_name" instead.
+`,
+		},
+		"strict": {
+			[]string{"-strict", setFile},
+			false,
+			`warning[naming-convention]: validate.test.Unconventional.displayName: attribute name "displayName" does not follow the HCL convention of using only lowercase letters, digits, and underscores
+  suggestion: Use the name "display_name" instead.
+`,
+		},
+		"ignored rule": {
+			[]string{"-strict", "-ignore=naming-convention", setFile},
+			true,
+			``,
+		},
+		"one valid message": {
+			[]string{"-message=validate.test.Valid", setFile},
+			true,
+			``,
+		},
+		"json": {
+			[]string{"-format=json", setFile},
+			true,
+			`[
+  {
+    "severity": "warning",
+    "decl": "validate.test.Unconventional.displayName",
+    "message": "attribute name \"displayName\" does not follow the HCL convention of using only lowercase letters, digits, and underscores",
+    "suggestion": "Use the name \"display_name\" instead.",
+    "rule": "naming-convention"
+  }
+]
+`,
+		},
+		"example": {
+			[]string{"-message=validate.test.WithBlocks", "-example=" + exampleFile, setFile},
+			true,
+			`warning[example-label-count]: validate.test.Service: example "service" block at ` + exampleFile + `:1,1-8 has 1 label, but the block type accepts 0 labels
+  suggestion: Add 1 (hcl.label) field to validate.test.Service, or remove the extra labels from the example.
+`,
+		},
+		"recursive block type": {
+			[]string{"-message=validate.test.Tree", setFile},
+			true,
+			``,
+		},
+		"json no findings": {
+			[]string{"-format=json", "-message=validate.test.Valid", setFile},
+			true,
+			"[]\n",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			ok, err := run(test.args, &buf)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if ok != test.wantOK {
+				t.Errorf("wrong result %t; want %t", ok, test.wantOK)
+			}
+			if diff := cmp.Diff(test.want, buf.String()); diff != "" {
+				t.Errorf("wrong output\n%s", diff)
+			}
+		})
+	}
+}
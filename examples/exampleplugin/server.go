@@ -0,0 +1,77 @@
+// Package exampleplugin is the implementation of the example plugin that
+// both of the plugin examples serve, using different plugin frameworks.
+//
+// It knows nothing about either framework: it only implements the RPC API
+// defined in pluginapiproto, which the framework-specific server programs
+// then register with their gRPC servers.
+package exampleplugin
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/apparentlymart/go-protohcl/examples/pluginhost"
+	"github.com/apparentlymart/go-protohcl/examples/rpcplugin/pluginapiproto"
+	"github.com/apparentlymart/go-protohcl/examples/rpcplugin/pluginproto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// Server is the server implementation of the example plugin's API.
+type Server struct {
+	Logger *log.Logger
+}
+
+// Server must implement the RPC server interface
+var _ pluginapiproto.PluginServer = (*Server)(nil)
+
+func (s *Server) Execute(ctx context.Context, req *pluginapiproto.ExecuteRequest) (*pluginapiproto.ExecuteResponse, error) {
+	config := &pluginproto.Config{}
+	err := req.Config.UnmarshalTo(config)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid config message: %s", err)
+	}
+
+	name := config.Name
+	project := config.Project
+	region := config.Region
+
+	if project == "" {
+		project = "default-project"
+	}
+	if region == "" {
+		region = "us-west"
+	}
+
+	id := fmt.Sprintf("app:%s:%s:%s", region, project, name)
+
+	result := &pluginproto.Result{
+		Id: id,
+	}
+
+	for _, serviceConfig := range config.Services {
+		result.ServiceIds = append(result.ServiceIds, fmt.Sprintf("%s/%s", id, serviceConfig.Name))
+	}
+
+	resultAny, err := anypb.New(result)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to encode reponse message: %s", err)
+	}
+
+	return &pluginapiproto.ExecuteResponse{
+		Result: resultAny,
+	}, nil
+}
+
+func (s *Server) GetConfigDescriptors(ctx context.Context, req *emptypb.Empty) (*pluginapiproto.ConfigDescriptors, error) {
+	// NOTE: This plugin happens to only need that one file, but other plugins
+	// might need to include more than one file if their main file imports
+	// others that the client wouldn't know about.
+	return pluginhost.ConfigDescriptors(
+		(&pluginproto.Config{}).ProtoReflect().Descriptor(),
+		pluginproto.File_plugin_proto,
+	), nil
+}
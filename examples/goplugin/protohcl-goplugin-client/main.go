@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/exec"
+
+	"github.com/apparentlymart/go-protohcl/examples/goplugin/shared"
+	"github.com/apparentlymart/go-protohcl/examples/pluginhost"
+	"github.com/apparentlymart/go-protohcl/examples/rpcplugin/pluginapiproto"
+	"github.com/hashicorp/go-plugin"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsimple"
+	"github.com/zclconf/go-cty-debug/ctydebug"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func main() {
+	logger := log.New(os.Stderr, "client: ", log.Flags())
+	ctx := context.Background()
+
+	if len(os.Args) < 2 {
+		log.Fatalf("Usage: protohcl-goplugin-client CONFIG-FILE")
+	}
+	configFilename := os.Args[1]
+
+	var mainConfig pluginhost.HostConfig
+	err := hclsimple.DecodeFile(configFilename, nil, &mainConfig)
+	if err != nil {
+		log.Fatalf("failed to read config file: %s", err)
+	}
+
+	// We'll start by launching the plugin server. This expects to find
+	// the executable "protohcl-goplugin-server" in your PATH, which you can
+	// achieve by "go install"ing the server package and making sure your
+	// GOBIN directory is in your PATH.
+	pluginClient := plugin.NewClient(&plugin.ClientConfig{
+		HandshakeConfig: shared.Handshake,
+		Plugins:         shared.PluginMap,
+		Cmd:             exec.Command("protohcl-goplugin-server"),
+
+		// protohcl relies on protobuf messages, so the plugin must use gRPC.
+		AllowedProtocols: []plugin.Protocol{plugin.ProtocolGRPC},
+
+		// AutoMTLS makes go-plugin generate a temporary certificate for
+		// each side, so that the client only trusts the plugin it launched
+		// and the plugin only accepts connections from that client. Unlike
+		// rpcplugin, go-plugin uses a plaintext connection if this is
+		// disabled.
+		AutoMTLS: true,
+	})
+
+	// Must be sure to kill the plugin when we're finished with it, so we
+	// don't leave an orphaned child process behind.
+	defer pluginClient.Kill()
+
+	rpcClient, err := pluginClient.Client()
+	if err != nil {
+		logger.Fatalf("failed to create plugin client: %s", err)
+	}
+	clientRaw, err := rpcClient.Dispense(shared.PluginName)
+	if err != nil {
+		logger.Fatalf("failed to request plugin API client: %s", err)
+	}
+
+	// From here on everything is the same as for the rpcplugin example,
+	// because both give us the same gRPC API client.
+	client, err := pluginhost.NewClient(ctx, clientRaw.(pluginapiproto.PluginClient))
+	if err != nil {
+		logger.Fatal(err)
+	}
+
+	configMsg, diags := client.DecodeConfig(mainConfig.Plugin.Raw, nil)
+	if diags.HasErrors() {
+		logger.Fatalf("invalid config for plugin: %s", diags.Error())
+	}
+
+	resultVal, err := client.Execute(ctx, configMsg)
+	if err != nil {
+		logger.Fatal(err)
+	}
+
+	logger.Printf("plugin result object: %s", ctydebug.ValueString(resultVal))
+
+	finalVal, diags := mainConfig.Result.Value(&hcl.EvalContext{
+		Variables: map[string]cty.Value{
+			"plugin": resultVal,
+		},
+	})
+	if diags.HasErrors() {
+		logger.Fatalf("failed to evaluate final result: %s", diags.Error())
+	}
+
+	logger.Printf("final result value: %s", ctydebug.ValueString(finalVal))
+}
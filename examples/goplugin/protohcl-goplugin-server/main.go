@@ -0,0 +1,30 @@
+package main
+
+import (
+	"log"
+	"os"
+
+	"github.com/apparentlymart/go-protohcl/examples/exampleplugin"
+	"github.com/apparentlymart/go-protohcl/examples/goplugin/shared"
+	"github.com/hashicorp/go-plugin"
+)
+
+func main() {
+	// go-plugin captures the server's stderr and logs it from the client,
+	// so we'll write our logs there.
+	logger := log.New(os.Stderr, "server: ", log.Flags())
+
+	plugin.Serve(&plugin.ServeConfig{
+		HandshakeConfig: shared.Handshake,
+		Plugins: map[string]plugin.Plugin{
+			shared.PluginName: &shared.ConfigPlugin{
+				Impl: &exampleplugin.Server{Logger: logger},
+			},
+		},
+
+		// A non-nil GRPCServer makes go-plugin serve gRPC instead of net/rpc.
+		// If the client enabled AutoMTLS then this server will use TLS
+		// with a certificate that only the client trusts.
+		GRPCServer: plugin.DefaultGRPCServer,
+	})
+}
@@ -0,0 +1,77 @@
+// Package shared contains the go-plugin definitions that the example client
+// and server must agree on.
+package shared
+
+import (
+	"context"
+
+	"github.com/apparentlymart/go-protohcl/examples/rpcplugin/pluginapiproto"
+	"github.com/hashicorp/go-plugin"
+	"google.golang.org/grpc"
+)
+
+// Handshake is the handshake configuration that the client and server must
+// both use.
+//
+// The client and server must both agree on the MagicCookieKey and
+// MagicCookieValue so that the server can detect whether it's running as a
+// child process of its expected client. If not, it will produce an error
+// message and exit immediately.
+var Handshake = plugin.HandshakeConfig{
+	ProtocolVersion:  1,
+	MagicCookieKey:   "PROTOHCL_EXAMPLE_GOPLUGIN_COOKIE",
+	MagicCookieValue: "1b7f4f0e-5b8d-5c39-a2a4-3f1c3e1e7d64",
+}
+
+// PluginName is the name the client uses to dispense the plugin's API
+// client, which must be a key in PluginMap.
+const PluginName = "config"
+
+// PluginMap is the set of plugins that this example supports, which is
+// needed by both the client and the server.
+//
+// The server sets ConfigPlugin.Impl in its own copy of the map, but the
+// client doesn't need an implementation.
+var PluginMap = map[string]plugin.Plugin{
+	PluginName: &ConfigPlugin{},
+}
+
+// ConfigPlugin is an implementation of go-plugin's GRPCPlugin interface for
+// the example plugin API defined in pluginapiproto.
+//
+// go-plugin gives both sides the same gRPC connection that rpcplugin does,
+// so the protohcl-specific parts of the client are shared with the
+// rpcplugin example in the pluginhost package.
+type ConfigPlugin struct {
+	// go-plugin also supports net/rpc plugins, but protohcl relies on
+	// sending protobuf messages and so only gRPC is useful here.
+	plugin.NetRPCUnsupportedPlugin
+
+	// Impl is the server's implementation of the plugin API. It must be
+	// set only when serving the plugin.
+	Impl pluginapiproto.PluginServer
+}
+
+var _ plugin.GRPCPlugin = (*ConfigPlugin)(nil)
+
+// GRPCServer registers the plugin API implementation with the gRPC server
+// that go-plugin started.
+//
+// The broker allows a plugin to open additional gRPC connections to the
+// client or from it, for example to pass a callback API to the plugin.
+// The example plugin API doesn't need any, because the client sends the
+// plugin its complete configuration as a single message.
+func (p *ConfigPlugin) GRPCServer(broker *plugin.GRPCBroker, s *grpc.Server) error {
+	pluginapiproto.RegisterPluginServer(s, p.Impl)
+	return nil
+}
+
+// GRPCClient returns an API client for the plugin running behind the given
+// connection.
+//
+// go-plugin has already secured the connection at this point, if the
+// client enabled AutoMTLS, and so there's nothing more to do here than
+// to wrap it.
+func (p *ConfigPlugin) GRPCClient(ctx context.Context, broker *plugin.GRPCBroker, c *grpc.ClientConn) (interface{}, error) {
+	return pluginapiproto.NewPluginClient(c), nil
+}
@@ -0,0 +1,173 @@
+// Package pluginhost contains the protohcl-related glue that both of the
+// plugin examples share, regardless of which plugin framework they use to
+// launch the plugin and connect to it.
+//
+// Both frameworks ultimately give the client a gRPC connection to the
+// plugin, and so everything in here works in terms of the gRPC API defined
+// in pluginapiproto. The framework-specific parts are only how the client
+// launches the plugin and obtains that connection, and how the server
+// registers its implementation.
+package pluginhost
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/apparentlymart/go-protohcl/examples/rpcplugin/pluginapiproto"
+	"github.com/apparentlymart/go-protohcl/protohcl"
+	"github.com/apparentlymart/go-protohcl/protohcl/protohclext"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// HostConfig is the structure of the configuration files that the example
+// clients read. The "plugin" block's content is decoded using the schema
+// that the plugin reports, and the "result" expression can then refer to
+// the plugin's result as "plugin".
+type HostConfig struct {
+	Plugin *PluginConfig  `hcl:"plugin,block"`
+	Result hcl.Expression `hcl:"result,attr"`
+}
+
+type PluginConfig struct {
+	Raw hcl.Body `hcl:",remain"`
+}
+
+// knownProtoFileDescs is a set of proto files the client just inherently
+// knows about, and so the server doesn't need to include these when it
+// sends us its own descriptors. (A real application implementation might
+// include a negotiation mechanism in its protocol where the client sends
+// the server the filenames it knows, and then the server can filter those
+// dynamically. But for simpler cases like this one, we'll just assume this
+// particular set is defined as part of the RPC protocol.)
+var knownProtoFileDescs = []*descriptorpb.FileDescriptorProto{
+	protodesc.ToFileDescriptorProto(descriptorpb.File_google_protobuf_descriptor_proto),
+	protodesc.ToFileDescriptorProto(anypb.File_google_protobuf_any_proto),
+	protodesc.ToFileDescriptorProto(protohclext.File_hcl_proto),
+}
+
+// ConfigDescriptors builds the GetConfigDescriptors response for a plugin
+// whose configuration is described by the given message type, declared in
+// the first of the given files.
+//
+// The files must include any that the configuration message type depends
+// on, except for the ones that the client already knows.
+func ConfigDescriptors(configDesc protoreflect.MessageDescriptor, files ...protoreflect.FileDescriptor) *pluginapiproto.ConfigDescriptors {
+	fileDescs := &descriptorpb.FileDescriptorSet{}
+	for _, file := range files {
+		fileDescs.File = append(fileDescs.File, protodesc.ToFileDescriptorProto(file))
+	}
+	return &pluginapiproto.ConfigDescriptors{
+		Files:             fileDescs,
+		ConfigMessageType: string(configDesc.FullName()),
+	}
+}
+
+// Client wraps a client for the plugin API with the descriptors that the
+// plugin reported, so that it can send HCL configuration to the plugin and
+// return the plugin's result as an HCL value.
+type Client struct {
+	api        pluginapiproto.PluginClient
+	dynProto   protohcl.DynamicProto
+	configType protoreflect.FullName
+}
+
+// NewClient asks the plugin behind the given API client for its
+// configuration descriptors, and returns a Client that uses them.
+func NewClient(ctx context.Context, api pluginapiproto.PluginClient) (*Client, error) {
+	descResp, err := api.GetConfigDescriptors(ctx, &emptypb.Empty{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read configuration descriptors: %w", err)
+	}
+
+	// We add some common extra files ourselves so that the server doesn't
+	// need to send us descriptors we already know.
+	descResp.Files.File = append(descResp.Files.File, knownProtoFileDescs...)
+
+	// The server might send descriptors for more than just its configuration
+	// schema, so we'll parse only the files we actually need.
+	dynProto, err := protohcl.NewLazyDynamicProto(descResp.Files)
+	if err != nil {
+		return nil, fmt.Errorf("failed to process configuration descriptors: %w", err)
+	}
+	configType := protoreflect.FullName(descResp.ConfigMessageType)
+	if !configType.IsValid() {
+		return nil, fmt.Errorf("invalid config_message_type %q", descResp.ConfigMessageType)
+	}
+	if _, err := dynProto.GetMessageDesc(configType); err != nil {
+		return nil, fmt.Errorf("failed to load config message type %s: %w", configType, err)
+	}
+
+	return &Client{
+		api:        api,
+		dynProto:   dynProto,
+		configType: configType,
+	}, nil
+}
+
+// DecodeConfig decodes the given body using the plugin's configuration
+// schema, returning the result packed ready to send to the plugin.
+func (c *Client) DecodeConfig(body hcl.Body, ctx *hcl.EvalContext) (*anypb.Any, hcl.Diagnostics) {
+	configMsg, diags := c.dynProto.DecodeBody(body, c.configType, ctx)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	configMsgAny, err := anypb.New(configMsg)
+	if err != nil {
+		diags = diags.Append(&hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid plugin configuration",
+			Detail:   fmt.Sprintf("Failed to prepare configuration message: %s.", err),
+			Subject:  body.MissingItemRange().Ptr(),
+		})
+		return nil, diags
+	}
+	return configMsgAny, diags
+}
+
+// Execute sends the given configuration, as returned by DecodeConfig, to
+// the plugin and returns the plugin's result as an HCL object value.
+func (c *Client) Execute(ctx context.Context, config *anypb.Any) (cty.Value, error) {
+	executeResp, err := c.api.Execute(ctx, &pluginapiproto.ExecuteRequest{
+		Config: config,
+	})
+	if err != nil {
+		return cty.DynamicVal, fmt.Errorf("plugin Execute failed: %w", err)
+	}
+	return c.ResultValue(executeResp.Result)
+}
+
+// ResultValue returns the HCL object value for a result message that the
+// plugin returned, using the descriptors that the plugin reported.
+func (c *Client) ResultValue(result *anypb.Any) (cty.Value, error) {
+	resultMsgTypeName := responseMessageTypeName(result)
+	resultMsgDesc, err := c.dynProto.GetMessageDesc(resultMsgTypeName)
+	if err != nil {
+		return cty.DynamicVal, fmt.Errorf("can't find descriptor for response type %s: %w", resultMsgTypeName, err)
+	}
+	resultMsg := dynamicpb.NewMessage(resultMsgDesc)
+	err = result.UnmarshalTo(resultMsg)
+	if err != nil {
+		return cty.DynamicVal, fmt.Errorf("failed to parse plugin response: %w", err)
+	}
+
+	resultVal, err := protohcl.ObjectValueForMessage(resultMsg)
+	if err != nil {
+		return cty.DynamicVal, fmt.Errorf("failed to decode plugin response: %w", err)
+	}
+	return resultVal, nil
+}
+
+func responseMessageTypeName(any *anypb.Any) protoreflect.FullName {
+	if slash := strings.LastIndexByte(any.TypeUrl, '/'); slash >= 0 {
+		return protoreflect.FullName(any.TypeUrl[slash+1:])
+	}
+	return protoreflect.FullName(any.TypeUrl)
+}
@@ -5,11 +5,9 @@ import (
 	"log"
 	"os"
 	"os/exec"
-	"strings"
 
+	"github.com/apparentlymart/go-protohcl/examples/pluginhost"
 	"github.com/apparentlymart/go-protohcl/examples/rpcplugin/pluginapiproto"
-	"github.com/apparentlymart/go-protohcl/protohcl"
-	"github.com/apparentlymart/go-protohcl/protohcl/protohclext"
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclsimple"
 	"github.com/zclconf/go-cty-debug/ctydebug"
@@ -17,28 +15,8 @@ import (
 	"go.rpcplugin.org/rpcplugin"
 	"go.rpcplugin.org/rpcplugin/plugintrace"
 	"google.golang.org/grpc"
-	"google.golang.org/protobuf/encoding/prototext"
-	"google.golang.org/protobuf/reflect/protodesc"
-	"google.golang.org/protobuf/reflect/protoreflect"
-	"google.golang.org/protobuf/types/descriptorpb"
-	"google.golang.org/protobuf/types/dynamicpb"
-	"google.golang.org/protobuf/types/known/anypb"
-	"google.golang.org/protobuf/types/known/emptypb"
 )
 
-// knownProtoFileDescs is a set of proto files the client just inherently
-// knows about, and so the server doesn't need to include these when it
-// sends us its own descriptors. (A real application implementation might
-// include a negotiation mechanism in its protocol where the client sends
-// the server the filenames it knows, and then the server can filter those
-// dynamically. But for simpler cases like this one, we'll just assume this
-// particular set is defined as part of the RPC protocol.)
-var knownProtoFileDescs = []*descriptorpb.FileDescriptorProto{
-	protodesc.ToFileDescriptorProto(descriptorpb.File_google_protobuf_descriptor_proto),
-	protodesc.ToFileDescriptorProto(anypb.File_google_protobuf_any_proto),
-	protodesc.ToFileDescriptorProto(protohclext.File_hcl_proto),
-}
-
 func main() {
 	logger := log.New(os.Stderr, "client: ", log.Flags())
 	ctx := plugintrace.WithClientTracer(context.Background(), plugintrace.ClientLogTracer(logger))
@@ -48,16 +26,16 @@ func main() {
 	}
 	configFilename := os.Args[1]
 
-	var mainConfig Config
+	var mainConfig pluginhost.HostConfig
 	err := hclsimple.DecodeFile(configFilename, nil, &mainConfig)
 	if err != nil {
 		log.Fatalf("failed to read config file: %s", err)
 	}
 
-	// The following shows all the low-level machinery of launching and
-	// interacting with a plugin, just to show clearly what the steps are.
-	// In a real application this would typically be factored out into a
-	// helper package.
+	// The following shows the machinery of launching a plugin using
+	// rpcplugin. The protohcl-specific parts of interacting with the plugin
+	// once it's running are in the pluginhost package, because they are
+	// shared with the go-plugin example in ../../goplugin.
 
 	// We'll start by launching the plugin server. This expects to find
 	// the executable "protohcl-plugin-server" in your PATH, which you can
@@ -91,73 +69,23 @@ func main() {
 	if protoVersion != 1 {
 		logger.Fatalf("server selected unsupported protocol version %d", protoVersion)
 	}
-	client := clientRaw.(pluginapiproto.PluginClient)
 
-	// "client" is now an API client for our example application's particular
-	// API, as defined in pluginapiproto.
-
-	descResp, err := client.GetConfigDescriptors(ctx, &emptypb.Empty{})
+	// clientRaw is an API client for our example application's particular
+	// API, as defined in pluginapiproto. pluginhost.NewClient asks the plugin
+	// for its configuration schema so that we can decode its block.
+	client, err := pluginhost.NewClient(ctx, clientRaw.(pluginapiproto.PluginClient))
 	if err != nil {
-		logger.Fatalf("failed to read configuration descriptors: %s", err)
+		logger.Fatal(err)
 	}
 
-	// We add some common extra files ourselves so that the server doesn't
-	// need to send us descriptors we already know.
-	descResp.Files.File = append(descResp.Files.File, knownProtoFileDescs...)
-
-	dynProto, err := protohcl.NewDynamicProto(descResp.Files)
-	if err != nil {
-		logger.Fatalf("failed to process configuration descriptors: %s", err)
-	}
-	configMsgName := protoreflect.FullName(descResp.ConfigMessageType)
-	if !configMsgName.IsValid() {
-		logger.Fatalf("invalid config_message_type")
-	}
-
-	// We don't really actually need to access the descriptor in here but
-	// we'll use this just to show how we might check that it's a valid name.
-	_, err = dynProto.GetMessageDesc(configMsgName)
-	if err != nil {
-		logger.Fatalf("failed to load config message type %s: %s", configMsgName, err)
-	}
-
-	// We should now have what we need to decode the plugin-specific
-	// configuration block.
-	configMsg, diags := dynProto.DecodeBody(mainConfig.Plugin.Raw, configMsgName, nil)
+	configMsg, diags := client.DecodeConfig(mainConfig.Plugin.Raw, nil)
 	if diags.HasErrors() {
 		logger.Fatalf("invalid config for plugin: %s", diags.Error())
 	}
 
-	log.Printf("plugin configuration message is:\n%s", prototext.Format(configMsg))
-
-	configMsgAny, err := anypb.New(configMsg)
-	if err != nil {
-		logger.Fatalf("failed to prepare configuration message: %s", err)
-	}
-	executeResp, err := client.Execute(ctx, &pluginapiproto.ExecuteRequest{
-		Config: configMsgAny,
-	})
-	if err != nil {
-		logger.Fatalf("plugin Execute failed: %s", err)
-	}
-
-	resultMsgAny := executeResp.Result
-	resultMsgTypeName := responseMessageTypeName(resultMsgAny)
-	logger.Printf("plugin's result is %s", resultMsgTypeName)
-	resultMsgDesc, err := dynProto.GetMessageDesc(resultMsgTypeName)
-	if err != nil {
-		logger.Fatalf("can't find descriptor for response type %s: %s", resultMsgTypeName, err)
-	}
-	resultMsg := dynamicpb.NewMessage(resultMsgDesc)
-	err = resultMsgAny.UnmarshalTo(resultMsg)
+	resultVal, err := client.Execute(ctx, configMsg)
 	if err != nil {
-		logger.Fatalf("failed tp parse plugin response: %s", err)
-	}
-	log.Printf("plugin result message is:\n%s", prototext.Format(resultMsg))
-
-	resultVal, err := protohcl.ObjectValueForMessage(resultMsg)
-	if err != nil {
-		logger.Fatalf("failed to decode plugin response: %s", err)
+		logger.Fatal(err)
 	}
 
 	logger.Printf("plugin result object: %s", ctydebug.ValueString(resultVal))
@@ -191,10 +119,3 @@ var _ rpcplugin.ClientVersion = protocolVersion1{}
 func (p protocolVersion1) ClientProxy(ctx context.Context, conn *grpc.ClientConn) (interface{}, error) {
 	return pluginapiproto.NewPluginClient(conn), nil
 }
-
-func responseMessageTypeName(any *anypb.Any) protoreflect.FullName {
-	if slash := strings.LastIndexByte(any.TypeUrl, '/'); slash >= 0 {
-		return protoreflect.FullName(any.TypeUrl[slash+1:])
-	}
-	return protoreflect.FullName(any.TypeUrl)
-}
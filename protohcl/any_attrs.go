@@ -0,0 +1,111 @@
+package protohcl
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// resolveAnyType finds the message type with the given name, for an
+// attribute of the given google.protobuf.Any field with
+// (hcl.attr).any_type.
+//
+// If anyTypes is nil then the message type must be declared in the field's
+// own file or in a file that it imports.
+func resolveAnyType(field protoreflect.FieldDescriptor, name protoreflect.FullName, anyTypes protoregistry.MessageTypeResolver) (protoreflect.MessageDescriptor, error) {
+	var desc protoreflect.MessageDescriptor
+	if anyTypes != nil {
+		msgType, err := anyTypes.FindMessageByName(name)
+		if err != nil && err != protoregistry.NotFound {
+			return nil, schemaErrorf(field.FullName(), "can't resolve (hcl.attr).any_type %q: %w", name, err)
+		}
+		if msgType != nil {
+			desc = msgType.Descriptor()
+		}
+	} else {
+		desc = findMessageDescInFileImports(field.ParentFile(), name, make(map[string]struct{}))
+	}
+	if desc == nil {
+		return nil, schemaErrorf(field.FullName(), "(hcl.attr).any_type refers to unknown message type %q", name)
+	}
+	if err := validateAttrMessageDesc(desc); err != nil {
+		return nil, schemaErrorf(field.FullName(), "can't decode attribute into message type %s: %w", name, err)
+	}
+	return desc, nil
+}
+
+// anyAttrMessageBuilder is the strategy for a singular google.protobuf.Any
+// field with (hcl.attr).any_type, which decodes an object value into the
+// selected message type in the same way as annotatedAttrMessageBuilder and
+// then packs the result into the Any field.
+func anyAttrMessageBuilder(desc protoreflect.FieldDescriptor, name protoreflect.FullName, anyTypes protoregistry.MessageTypeResolver) attrMessageBuilder {
+	return func(v cty.Value, path cty.Path, parentMessage protoreflect.Message) (protoreflect.Value, error) {
+		if v.IsNull() {
+			// A null value just leaves the field unset.
+			return nilProtoValue, nil
+		}
+		msgDesc, err := resolveAnyType(desc, name, anyTypes)
+		if err != nil {
+			return nilProtoValue, err
+		}
+		wantTy, err := attrObjectTypeConstraintForMessageDesc(msgDesc, nil)
+		if err != nil {
+			return nilProtoValue, schemaErrorf(desc.FullName(), "can't decode attribute into message type %s: %w", name, err)
+		}
+		v, err = convert.Convert(v, wantTy)
+		if err != nil {
+			return nilProtoValue, attrValueErrorWrap(path, err)
+		}
+		msg, err := messageForObjectValue(v, msgDesc, path, anyTypes)
+		if err != nil {
+			return nilProtoValue, err
+		}
+		raw, err := proto.MarshalOptions{Deterministic: true}.Marshal(msg.Interface())
+		if err != nil {
+			return nilProtoValue, attrValueErrorf(path, "can't encode as %s: %s", name, err)
+		}
+		anyMsg := parentMessage.NewField(desc).Message()
+		anyFields := anyMsg.Descriptor().Fields()
+		anyMsg.Set(anyFields.ByName("type_url"), protoreflect.ValueOfString(anyTypeURLPrefix+string(name)))
+		anyMsg.Set(anyFields.ByName("value"), protoreflect.ValueOfBytes(raw))
+		return protoreflect.ValueOfMessage(anyMsg), nil
+	}
+}
+
+// hclValueForAnyAttr is the opposite of anyAttrMessageBuilder, returning
+// the object value for the message that the given google.protobuf.Any
+// message contains, or a null value if the Any message is empty.
+func (opts ObjectValueOptions) hclValueForAnyAttr(anyMsg protoreflect.Message, path cty.Path, attr FieldAttribute) (cty.Value, error) {
+	anyFields := anyMsg.Descriptor().Fields()
+	typeURL := anyMsg.Get(anyFields.ByName("type_url")).String()
+	if typeURL == "" {
+		return cty.NullVal(cty.DynamicPseudoType), nil
+	}
+	name := protoreflect.FullName(typeURL)
+	if slash := strings.LastIndexByte(typeURL, '/'); slash >= 0 {
+		name = protoreflect.FullName(typeURL[slash+1:])
+	}
+	if name != attr.AnyType {
+		return cty.NilVal, path.NewErrorf("contains a %s message, but (hcl.attr).any_type is %s", name, attr.AnyType)
+	}
+	msgDesc, err := resolveAnyType(attr.TargetField, name, opts.AnyTypes)
+	if err != nil {
+		return cty.NilVal, err
+	}
+	msg := newMessageMaybeDynamic(msgDesc)
+	if opts.AnyTypes != nil {
+		// We know that the registry has this type, because we resolved the
+		// descriptor from it.
+		msgType, _ := opts.AnyTypes.FindMessageByName(name)
+		msg = msgType.New()
+	}
+	if err := proto.Unmarshal(anyMsg.Get(anyFields.ByName("value")).Bytes(), msg.Interface()); err != nil {
+		return cty.NilVal, path.NewError(fmt.Errorf("invalid %s message: %w", name, err))
+	}
+	return ObjectValueOptions{AnyTypes: opts.AnyTypes}.objectValueForMessage(msg, path)
+}
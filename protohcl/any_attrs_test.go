@@ -0,0 +1,268 @@
+package protohcl
+
+import (
+	"testing"
+
+	"github.com/apparentlymart/go-protohcl/protohcl/internal/testschema"
+	"github.com/apparentlymart/go-protohcl/protohcl/protohclext"
+	"github.com/apparentlymart/go-protohcl/protohcl/protohcltest"
+	"github.com/google/go-cmp/cmp"
+	"github.com/zclconf/go-cty/cty"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func TestDecodeBodyAnyTypeAttr(t *testing.T) {
+	mustAny := func(msg proto.Message) *anypb.Any {
+		ret, err := anypb.New(msg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return ret
+	}
+
+	tests := map[string]struct {
+		config    string
+		want      proto.Message
+		wantDiags []protohcltest.ExpectedDiagnostic
+	}{
+		"object": {
+			`
+				storage = {
+					bucket = "example"
+					region = "us-west-2"
+				}
+			`,
+			&testschema.WithAnyTypeAttr{
+				Storage: mustAny(&testschema.S3StorageConfig{
+					Bucket: "example",
+					Region: "us-west-2",
+				}),
+			},
+			nil,
+		},
+		"null": {
+			`
+				storage = null
+			`,
+			&testschema.WithAnyTypeAttr{},
+			nil,
+		},
+		"omitted": {
+			``,
+			&testschema.WithAnyTypeAttr{},
+			nil,
+		},
+		"missing required attribute": {
+			`
+				storage = {
+					region = "us-west-2"
+				}
+			`,
+			&testschema.WithAnyTypeAttr{},
+			[]protohcltest.ExpectedDiagnostic{
+				protohcltest.Error(unsuitableValueSummary).
+					WithDetail(`Inappropriate value for attribute "storage": attribute "bucket" is required.`).
+					OnLine(2),
+			},
+		},
+		"not an object": {
+			`
+				storage = "example"
+			`,
+			&testschema.WithAnyTypeAttr{},
+			[]protohcltest.ExpectedDiagnostic{
+				protohcltest.Error(unsuitableValueSummary).
+					WithDetail(`Inappropriate value for attribute "storage": object required.`).
+					OnLine(2),
+			},
+		},
+	}
+
+	desc := (&testschema.WithAnyTypeAttr{}).ProtoReflect().Descriptor()
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, diags := DecodeBody(parseTestBody(t, test.config), desc, nil)
+			protohcltest.AssertDiagnostics(t, diags, test.wantDiags...)
+			if diff := cmp.Diff(test.want, got, protoCmpOpt); diff != "" {
+				t.Errorf("wrong result\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDecodeBodyAnyTypeAttrUnknownType(t *testing.T) {
+	desc := (&testschema.WithAnyTypeAttrUnknownType{}).ProtoReflect().Descriptor()
+	_, diags := DecodeBody(parseTestBody(t, `storage = { path = "a" }`), desc, nil)
+	protohcltest.AssertDiagnostics(t, diags,
+		protohcltest.Error(schemaErrorSummary).
+			WithDetail("Invalid HCL annotations in protobuf schema for hcl.testschema.WithAnyTypeAttrUnknownType.storage: (hcl.attr).any_type refers to unknown message type \"hcl.testschema.DoesNotExist\".\n\nThis is a bug in the component that defined this schema, and not an error in the given configuration."),
+	)
+}
+
+func TestAnyTypeAttrRegistry(t *testing.T) {
+	// The host's registry provides the message type that the schema's own
+	// files don't declare, as it might for a plugin.
+	pluginType := anyTypeAttrPluginType(t)
+	anyTypes := new(protoregistry.Types)
+	if err := anyTypes.RegisterMessage(pluginType); err != nil {
+		t.Fatal(err)
+	}
+
+	desc := (&testschema.WithAnyTypeAttrUnknownType{}).ProtoReflect().Descriptor()
+	got, diags := DecodeOptions{AnyTypes: anyTypes}.DecodeBody(parseTestBody(t, `storage = { path = "/tmp" }`), desc, nil)
+	protohcltest.AssertDiagnostics(t, diags)
+
+	wantStorage := pluginType.New()
+	wantStorage.Set(pluginType.Descriptor().Fields().ByName("path"), protoreflect.ValueOfString("/tmp"))
+	storage, err := anypb.New(wantStorage.Interface())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &testschema.WithAnyTypeAttrUnknownType{Storage: storage}
+	if diff := cmp.Diff(want, got, protoCmpOpt); diff != "" {
+		t.Errorf("wrong result\n%s", diff)
+	}
+
+	gotVal, err := ObjectValueOptions{AnyTypes: anyTypes}.ObjectValueForMessage(got)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	wantVal := cty.ObjectVal(map[string]cty.Value{
+		"storage": cty.ObjectVal(map[string]cty.Value{
+			"path": cty.StringVal("/tmp"),
+		}),
+	})
+	if !wantVal.RawEquals(gotVal) {
+		t.Errorf("wrong value\ngot:  %#v\nwant: %#v", gotVal, wantVal)
+	}
+
+	// A registry replaces the schema's own files entirely, so the message
+	// types they declare are unavailable unless the registry has them too.
+	otherDesc := (&testschema.WithAnyTypeAttr{}).ProtoReflect().Descriptor()
+	_, diags = DecodeOptions{AnyTypes: anyTypes}.DecodeBody(parseTestBody(t, `storage = { bucket = "a" }`), otherDesc, nil)
+	protohcltest.AssertDiagnostics(t, diags,
+		protohcltest.Error(schemaErrorSummary).
+			WithDetail("Invalid HCL annotations in protobuf schema for hcl.testschema.WithAnyTypeAttr.storage: (hcl.attr).any_type refers to unknown message type \"hcl.testschema.S3StorageConfig\".\n\nThis is a bug in the component that defined this schema, and not an error in the given configuration."),
+	)
+}
+
+func TestObjectValueForMessageAnyTypeAttr(t *testing.T) {
+	storage, err := anypb.New(&testschema.S3StorageConfig{
+		Bucket: "example",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		msg     proto.Message
+		want    cty.Value
+		wantErr string
+	}{
+		"set": {
+			&testschema.WithAnyTypeAttr{Storage: storage},
+			cty.ObjectVal(map[string]cty.Value{
+				"storage": cty.ObjectVal(map[string]cty.Value{
+					"bucket": cty.StringVal("example"),
+					"region": cty.StringVal(""),
+				}),
+			}),
+			``,
+		},
+		"unset": {
+			&testschema.WithAnyTypeAttr{},
+			cty.ObjectVal(map[string]cty.Value{
+				"storage": cty.NullVal(cty.DynamicPseudoType),
+			}),
+			``,
+		},
+		"wrong message type": {
+			&testschema.WithAnyTypeAttr{
+				Storage: func() *anypb.Any {
+					ret, err := anypb.New(&testschema.LocalStorageConfig{Path: "a"})
+					if err != nil {
+						t.Fatal(err)
+					}
+					return ret
+				}(),
+			},
+			cty.NilVal,
+			`contains a hcl.testschema.LocalStorageConfig message, but (hcl.attr).any_type is hcl.testschema.S3StorageConfig`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := ObjectValueForMessage(test.msg)
+			if test.wantErr != "" {
+				if err == nil {
+					t.Fatalf("unexpected success\nwant error: %s", test.wantErr)
+				}
+				if got, want := err.Error(), test.wantErr; got != want {
+					t.Errorf("wrong error\ngot:  %s\nwant: %s", got, want)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !test.want.RawEquals(got) {
+				t.Errorf("wrong value\ngot:  %#v\nwant: %#v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestGetFieldElemAnyTypeNotAny(t *testing.T) {
+	desc := (&testschema.WithAnyTypeAttrNotAny{}).ProtoReflect().Descriptor()
+	_, err := GetFieldElem(desc.Fields().ByName("storage"))
+	if err == nil {
+		t.Fatalf("unexpected success")
+	}
+	if got, want := err.Error(), `unsupported protobuf schema in hcl.testschema.WithAnyTypeAttrNotAny.storage: only singular google.protobuf.Any fields can have (hcl.attr).any_type`; got != want {
+		t.Errorf("wrong error\ngot:  %s\nwant: %s", got, want)
+	}
+}
+
+// anyTypeAttrPluginType returns a message type named
+// hcl.testschema.DoesNotExist, which the test schema refers to but doesn't
+// declare, as a dynamic message type that a host could load from a plugin.
+func anyTypeAttrPluginType(t *testing.T) protoreflect.MessageType {
+	t.Helper()
+
+	fieldOpts := &descriptorpb.FieldOptions{}
+	proto.SetExtension(fieldOpts, protohclext.E_Attr, &protohclext.Attribute{
+		Name:     "path",
+		Required: true,
+	})
+	file, err := protodesc.NewFile(&descriptorpb.FileDescriptorProto{
+		Name:    proto.String("plugin.proto"),
+		Package: proto.String("hcl.testschema"),
+		Syntax:  proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{
+			{
+				Name: proto.String("DoesNotExist"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					{
+						Name:     proto.String("path"),
+						JsonName: proto.String("path"),
+						Number:   proto.Int32(1),
+						Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+						Type:     descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+						Options:  fieldOpts,
+					},
+				},
+			},
+		},
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dynamicpb.NewMessageType(file.Messages().ByName("DoesNotExist"))
+}
@@ -0,0 +1,233 @@
+package protohcl
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/apparentlymart/go-protohcl/protohcl/protohclext"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protopath"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+var anyDesc = (*anypb.Any)(nil).ProtoReflect().Descriptor()
+
+// anyTypeURLPrefix is the prefix of the type URLs that the decoder writes
+// into google.protobuf.Any fields, which is the conventional prefix that
+// the protobuf libraries also use.
+const anyTypeURLPrefix = "type.googleapis.com/"
+
+// checkAnyBlock checks the (hcl.block).any_discriminator and
+// (hcl.block).any_types options of the given nested block type field, whose
+// block body type is the given message type, returning the field of the
+// discriminator attribute and the message type for each discriminator
+// value if they are valid.
+func checkAnyBlock(field protoreflect.FieldDescriptor, nestedDesc protoreflect.MessageDescriptor, blockOpts *protohclext.NestedBlock) (protoreflect.FieldDescriptor, map[string]protoreflect.MessageDescriptor, error) {
+	if nestedDesc.FullName() != anyDesc.FullName() {
+		return nil, nil, schemaErrorf(field.FullName(), "only google.protobuf.Any fields can have (hcl.block).any_discriminator or (hcl.block).any_types")
+	}
+	if field.IsList() || field.IsMap() {
+		return nil, nil, schemaErrorf(field.FullName(), "nested block field of type google.protobuf.Any must not be repeated or a map")
+	}
+	if blockOpts.AnyDiscriminator == "" {
+		return nil, nil, schemaErrorf(field.FullName(), "nested block field of type google.protobuf.Any must have (hcl.block).any_discriminator")
+	}
+	if len(blockOpts.AnyTypes) == 0 {
+		return nil, nil, schemaErrorf(field.FullName(), "(hcl.block).any_discriminator requires at least one (hcl.block).any_types entry")
+	}
+
+	discField, err := anyDiscriminatorField(field, blockOpts.AnyDiscriminator)
+	if err != nil {
+		return nil, nil, schemaErrorf(field.FullName(), "invalid (hcl.block).any_discriminator: %w", err)
+	}
+
+	types := make(map[string]protoreflect.MessageDescriptor, len(blockOpts.AnyTypes))
+	for _, anyType := range blockOpts.AnyTypes {
+		if anyType.Value == "" || anyType.Message == "" {
+			return nil, nil, schemaErrorf(field.FullName(), "each (hcl.block).any_types entry must have both a value and a message")
+		}
+		if _, exists := types[anyType.Value]; exists {
+			return nil, nil, schemaErrorf(field.FullName(), "duplicate (hcl.block).any_types value %q", anyType.Value)
+		}
+		desc := findMessageDescInFileImports(field.ParentFile(), protoreflect.FullName(anyType.Message), make(map[string]struct{}))
+		if desc == nil {
+			return nil, nil, schemaErrorf(field.FullName(), "(hcl.block).any_types refers to unknown message type %q", anyType.Message)
+		}
+		if messageIsRootOnly(desc) {
+			return nil, nil, schemaErrorf(field.FullName(), "can't use %s in (hcl.block).any_types, because it is declared as root_only", desc.FullName())
+		}
+		if messageDeclaresLabels(desc) {
+			return nil, nil, schemaErrorf(field.FullName(), "can't use %s in (hcl.block).any_types, because it declares block labels", desc.FullName())
+		}
+		types[anyType.Value] = desc
+	}
+	return discField, types, nil
+}
+
+// anyDiscriminatorField finds the sibling of the given field that declares
+// the attribute of the given name, which must be a string attribute
+// declared before the given field.
+//
+// This works directly with the sibling's options, rather than using
+// GetFieldElem, because the sibling could itself be a google.protobuf.Any
+// block field whose own checks would then refer back to the given field.
+func anyDiscriminatorField(field protoreflect.FieldDescriptor, name string) (protoreflect.FieldDescriptor, error) {
+	fields := field.ContainingMessage().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		sibling := fields.Get(i)
+		if !protohclext.HasAttrOption(sibling) || protohclext.AttrOption(sibling).GetName() != name {
+			continue
+		}
+		attrOpts := protohclext.AttrOption(sibling)
+		if sibling.Kind() != protoreflect.StringKind || sibling.Cardinality() == protoreflect.Repeated || (attrOpts.Type != "" && attrOpts.Type != "string") || attrOpts.Raw != protohclext.Attribute_NOT_RAW {
+			return nil, fmt.Errorf("attribute %q must be of type string", name)
+		}
+		if sibling.Index() > field.Index() {
+			return nil, fmt.Errorf("attribute %q must be declared before %s", name, field.Name())
+		}
+		return sibling, nil
+	}
+	return nil, fmt.Errorf("%s does not declare an attribute named %q", field.ContainingMessage().FullName(), name)
+}
+
+// messageDeclaresLabels returns true if any field of the given message type
+// is annotated as a block label.
+func messageDeclaresLabels(desc protoreflect.MessageDescriptor) bool {
+	fields := desc.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		if protohclext.HasLabelOption(fields.Get(i)) {
+			return true
+		}
+	}
+	return false
+}
+
+// sortedAnyTypeValues returns the discriminator values of the given block
+// type, which must have AnyTypes, in lexical order.
+func sortedAnyTypeValues(elem FieldNestedBlockType) []string {
+	values := make([]string, 0, len(elem.AnyTypes))
+	for value := range elem.AnyTypes {
+		values = append(values, value)
+	}
+	sort.Strings(values)
+	return values
+}
+
+// newAnyMessageForBlock decodes the given block of a block type that has
+// AnyTypes, using the message type that the discriminator attribute of the
+// given parent message selects, and returns a google.protobuf.Any message
+// containing the result.
+//
+// The result is nil if there's no valid message type to decode the block
+// as, in which case the diagnostics explain why unless the discriminator
+// attribute's own value was invalid.
+func (d *decoder) newAnyMessageForBlock(block *hcl.Block, elem FieldNestedBlockType, parent protoreflect.Message, content *hcl.BodyContent, path protopath.Path, ctx *hcl.EvalContext) (protoreflect.Message, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+
+	attr, inBody := content.Attributes[elem.AnyDiscriminator]
+	value := parent.Get(elem.AnyDiscriminatorField).String()
+	if value == "" {
+		if inBody && d.attrIsInvalid(attr) {
+			// Decoding the attribute will have reported the problem
+			// with its value already.
+			return nil, diags
+		}
+		diags = diags.Append(&hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Missing required argument",
+			Detail:   fmt.Sprintf("The argument %q is required to select the content of the %s block.", elem.AnyDiscriminator, elem.TypeName),
+			Subject:  block.DefRange.Ptr(),
+			Extra:    diagnosticExtra{Category: DiagnosticMissingRequired},
+		})
+		return nil, diags
+	}
+
+	nestedDesc, ok := elem.AnyTypes[value]
+	if !ok {
+		subject := block.DefRange
+		if inBody {
+			subject = attr.Expr.Range()
+		}
+		diags = diags.Append(&hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  fmt.Sprintf("Unsupported %s type", elem.TypeName),
+			Detail: fmt.Sprintf(
+				"The %s block can't be used with %s = %q. The %q argument must be %s.",
+				elem.TypeName, elem.AnyDiscriminator, value, elem.AnyDiscriminator, blockTypeNamesList(sortedAnyTypeValues(elem)),
+			),
+			Subject: subject.Ptr(),
+			Context: block.DefRange.Ptr(),
+			Extra:   diagnosticExtra{Category: DiagnosticValueError},
+		})
+		return nil, diags
+	}
+
+	ctx = d.blockEvalContext(elem.TypeName, ctx)
+	nestedMsg, moreDiags := d.decodeBody(block.Body, nestedDesc, path, ctx, nil)
+	diags = append(diags, moreDiags...)
+
+	raw, err := proto.MarshalOptions{Deterministic: true}.Marshal(nestedMsg)
+	if err != nil {
+		diags = diags.Append(&hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  fmt.Sprintf("Invalid %s block", elem.TypeName),
+			Detail:   fmt.Sprintf("Can't encode the content of this block as %s: %s.", nestedDesc.FullName(), err),
+			Subject:  block.DefRange.Ptr(),
+		})
+		return nil, diags
+	}
+	anyMsg := d.newMessage(elem.Nested)
+	anyFields := elem.Nested.Fields()
+	anyMsg.Set(anyFields.ByName("type_url"), protoreflect.ValueOfString(anyTypeURLPrefix+string(nestedDesc.FullName())))
+	anyMsg.Set(anyFields.ByName("value"), protoreflect.ValueOfBytes(raw))
+	return anyMsg, diags
+}
+
+// unpackAnyBlockMessage returns the message that the given
+// google.protobuf.Any message contains, for a block type with AnyTypes,
+// or nil if the Any message is empty.
+func unpackAnyBlockMessage(anyMsg protoreflect.Message, elem FieldNestedBlockType) (protoreflect.Message, error) {
+	anyFields := anyMsg.Descriptor().Fields()
+	typeURL := anyMsg.Get(anyFields.ByName("type_url")).String()
+	if typeURL == "" {
+		return nil, nil
+	}
+	name := protoreflect.FullName(typeURL)
+	if slash := strings.LastIndexByte(typeURL, '/'); slash >= 0 {
+		name = protoreflect.FullName(typeURL[slash+1:])
+	}
+	var desc protoreflect.MessageDescriptor
+	for _, value := range sortedAnyTypeValues(elem) {
+		if candidate := elem.AnyTypes[value]; candidate.FullName() == name {
+			desc = candidate
+			break
+		}
+	}
+	if desc == nil {
+		return nil, fmt.Errorf("message type %s is not one of the (hcl.block).any_types of %s", name, elem.TargetField.FullName())
+	}
+
+	msg := newMessageMaybeDynamic(desc)
+	if err := proto.Unmarshal(anyMsg.Get(anyFields.ByName("value")).Bytes(), msg.Interface()); err != nil {
+		return nil, fmt.Errorf("invalid %s message in %s: %w", name, elem.TargetField.FullName(), err)
+	}
+	return msg, nil
+}
+
+// objectValueForAnyBlock returns the object value for the message that the
+// given google.protobuf.Any message contains, for a block type with
+// AnyTypes, or a null value if the Any message is empty.
+func (opts ObjectValueOptions) objectValueForAnyBlock(anyMsg protoreflect.Message, elem FieldNestedBlockType, path cty.Path) (cty.Value, error) {
+	msg, err := unpackAnyBlockMessage(anyMsg, elem)
+	if err != nil {
+		return cty.DynamicVal, path.NewError(err)
+	}
+	if msg == nil {
+		return cty.NullVal(cty.DynamicPseudoType), nil
+	}
+	return opts.objectValueForMessage(msg, path)
+}
@@ -0,0 +1,300 @@
+package protohcl
+
+import (
+	"testing"
+
+	"github.com/apparentlymart/go-protohcl/protohcl/internal/testschema"
+	"github.com/apparentlymart/go-protohcl/protohcl/protohcltest"
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty-debug/ctydebug"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func TestDecodeBodyAnyBlock(t *testing.T) {
+	mustAny := func(msg proto.Message) *anypb.Any {
+		ret, err := anypb.New(msg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return ret
+	}
+
+	tests := map[string]struct {
+		msgName   protoreflect.Name
+		config    string
+		want      proto.Message
+		wantDiags []protohcltest.ExpectedDiagnostic
+	}{
+		"s3": {
+			"WithAnyBlock",
+			`
+				storage_type = "s3"
+				storage {
+					bucket = "example"
+				}
+			`,
+			&testschema.WithAnyBlock{
+				StorageType: "s3",
+				Storage: mustAny(&testschema.S3StorageConfig{
+					Bucket: "example",
+				}),
+			},
+			nil,
+		},
+		"local": {
+			"WithAnyBlock",
+			`
+				storage_type = "local"
+				storage {
+					path = "/tmp"
+				}
+			`,
+			&testschema.WithAnyBlock{
+				StorageType: "local",
+				Storage: mustAny(&testschema.LocalStorageConfig{
+					Path: "/tmp",
+				}),
+			},
+			nil,
+		},
+		"no block": {
+			"WithAnyBlock",
+			`
+				storage_type = "elsewhere"
+			`,
+			&testschema.WithAnyBlock{
+				StorageType: "elsewhere",
+			},
+			nil,
+		},
+		"content for the wrong type": {
+			"WithAnyBlock",
+			`
+				storage_type = "local"
+				storage {
+					path   = "/tmp"
+					bucket = "example"
+				}
+			`,
+			&testschema.WithAnyBlock{
+				StorageType: "local",
+				Storage: mustAny(&testschema.LocalStorageConfig{
+					Path: "/tmp",
+				}),
+			},
+			[]protohcltest.ExpectedDiagnostic{
+				protohcltest.Error("Unsupported argument").OnLine(5),
+			},
+		},
+		"unsupported type": {
+			"WithAnyBlock",
+			`
+				storage_type = "gcs"
+				storage {
+					bucket = "example"
+				}
+			`,
+			&testschema.WithAnyBlock{
+				StorageType: "gcs",
+			},
+			[]protohcltest.ExpectedDiagnostic{
+				protohcltest.Error("Unsupported storage type").
+					WithDetail(`The storage block can't be used with storage_type = "gcs". The "storage_type" argument must be "local" or "s3".`).
+					OnLine(2),
+			},
+		},
+		"missing discriminator": {
+			"WithAnyBlock",
+			`
+				storage {
+					bucket = "example"
+				}
+			`,
+			&testschema.WithAnyBlock{},
+			[]protohcltest.ExpectedDiagnostic{
+				protohcltest.Error("Missing required argument").
+					WithDetail(`The argument "storage_type" is required to select the content of the storage block.`).
+					OnLine(2),
+			},
+		},
+		"invalid discriminator": {
+			"WithAnyBlock",
+			`
+				storage_type = s3
+				storage {
+					bucket = "example"
+				}
+			`,
+			&testschema.WithAnyBlock{},
+			[]protohcltest.ExpectedDiagnostic{
+				protohcltest.Error("Variables not allowed").OnLine(2),
+			},
+		},
+		"flattened with prefix": {
+			"WithFlattenedAnyBlock",
+			`
+				backend_storage_type = "local"
+				backend_storage {
+					path = "/tmp"
+				}
+			`,
+			&testschema.WithFlattenedAnyBlock{
+				Backend: &testschema.WithAnyBlock{
+					StorageType: "local",
+					Storage: mustAny(&testschema.LocalStorageConfig{
+						Path: "/tmp",
+					}),
+				},
+			},
+			nil,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			desc := testschema.File_testschema_proto.Messages().ByName(test.msgName)
+			got, diags := DecodeBody(parseTestBody(t, test.config), desc, nil)
+			protohcltest.AssertDiagnostics(t, diags, test.wantDiags...)
+			if diff := cmp.Diff(test.want, got, protoCmpOpt); diff != "" {
+				t.Errorf("wrong result\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDecodeBodyAnyBlockUnknownDiscriminator(t *testing.T) {
+	// An unknown discriminator is reported only by decoding the attribute
+	// itself, which must be the only time the decoder evaluates it.
+	calls := 0
+	ctx := &hcl.EvalContext{
+		Functions: map[string]function.Function{
+			"storage_type": function.New(&function.Spec{
+				Type: function.StaticReturnType(cty.String),
+				Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+					calls++
+					return cty.UnknownVal(cty.String), nil
+				},
+			}),
+		},
+	}
+	desc := testschema.File_testschema_proto.Messages().ByName("WithAnyBlock")
+	got, diags := DecodeBody(parseTestBody(t, `
+		storage_type = storage_type()
+		storage {
+			bucket = "example"
+		}
+	`), desc, ctx)
+	protohcltest.AssertDiagnostics(t, diags, protohcltest.Error(unsuitableValueSummary))
+	if len(diags) == 1 && DiagnosticCategoryOf(diags[0]) != DiagnosticUnknownValue {
+		t.Errorf("wrong category %s; want %s", DiagnosticCategoryOf(diags[0]), DiagnosticUnknownValue)
+	}
+	if diff := cmp.Diff(proto.Message(&testschema.WithAnyBlock{}), got, protoCmpOpt); diff != "" {
+		t.Errorf("wrong result\n%s", diff)
+	}
+	if calls != 1 {
+		t.Errorf("discriminator evaluated %d times; want 1", calls)
+	}
+}
+
+func TestObjectValueForMessageAnyBlock(t *testing.T) {
+	storage, err := anypb.New(&testschema.S3StorageConfig{
+		Bucket: "example",
+		Region: "us-west-2",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		msg  proto.Message
+		want cty.Value
+	}{
+		"set": {
+			&testschema.WithAnyBlock{
+				StorageType: "s3",
+				Storage:     storage,
+			},
+			cty.ObjectVal(map[string]cty.Value{
+				"storage_type": cty.StringVal("s3"),
+				"storage": cty.ObjectVal(map[string]cty.Value{
+					"bucket": cty.StringVal("example"),
+					"region": cty.StringVal("us-west-2"),
+				}),
+			}),
+		},
+		"unset": {
+			&testschema.WithAnyBlock{
+				StorageType: "s3",
+			},
+			cty.ObjectVal(map[string]cty.Value{
+				"storage_type": cty.StringVal("s3"),
+				"storage":      cty.NullVal(cty.DynamicPseudoType),
+			}),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := ObjectValueForMessage(test.msg)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(test.want, got, ctydebug.CmpOptions); diff != "" {
+				t.Errorf("wrong result\n%s", diff)
+			}
+
+			ty, err := ObjectTypeConstraintForMessageDesc(test.msg.ProtoReflect().Descriptor())
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if errs := got.Type().TestConformance(ty); len(errs) != 0 {
+				t.Errorf("value does not conform to type constraint\nvalue: %#v\ntype:  %#v", got.Type(), ty)
+			}
+		})
+	}
+
+	t.Run("type not in any_types", func(t *testing.T) {
+		other, err := anypb.New(&testschema.WithOptionalAttrs{Name: "example"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = ObjectValueForMessage(&testschema.WithAnyBlock{Storage: other})
+		if err == nil {
+			t.Fatalf("unexpected success")
+		}
+		want := `message type hcl.testschema.WithOptionalAttrs is not one of the (hcl.block).any_types of hcl.testschema.WithAnyBlock.storage`
+		if got := err.Error(); got != want {
+			t.Errorf("wrong error\ngot:  %s\nwant: %s", got, want)
+		}
+	})
+}
+
+func TestGetFieldElemAnyBlockErrors(t *testing.T) {
+	tests := map[protoreflect.Name]string{
+		"WithAnyBlockDiscriminatorAfter": `unsupported protobuf schema in hcl.testschema.WithAnyBlockDiscriminatorAfter.storage: invalid (hcl.block).any_discriminator: attribute "storage_type" must be declared before storage`,
+		"WithAnyBlockUnknownType":        `unsupported protobuf schema in hcl.testschema.WithAnyBlockUnknownType.storage: (hcl.block).any_types refers to unknown message type "hcl.testschema.DoesNotExist"`,
+		"WithAnyBlockNoDiscriminator":    `unsupported protobuf schema in hcl.testschema.WithAnyBlockNoDiscriminator.storage: nested block field of type google.protobuf.Any must have (hcl.block).any_discriminator`,
+	}
+
+	for msgName, want := range tests {
+		t.Run(string(msgName), func(t *testing.T) {
+			desc := testschema.File_testschema_proto.Messages().ByName(msgName)
+			_, err := GetFieldElem(desc.Fields().ByName("storage"))
+			if err == nil {
+				t.Fatalf("unexpected success")
+			}
+			if got := err.Error(); got != want {
+				t.Errorf("wrong error\ngot:  %s\nwant: %s", got, want)
+			}
+
+			if problems := ValidateMessageDesc(desc); len(problems) == 0 {
+				t.Errorf("ValidateMessageDesc reported no problems")
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package protohcl
+
+import (
+	"testing"
+
+	"github.com/apparentlymart/go-protohcl/protohcl/internal/testschema"
+	"github.com/apparentlymart/go-protohcl/protohcl/protohcltest"
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func TestDecodeBodyBlockTypeAliases(t *testing.T) {
+	tests := map[string]struct {
+		msgName   protoreflect.Name
+		config    string
+		want      proto.Message
+		wantDiags []protohcltest.ExpectedDiagnostic
+	}{
+		"main type name only": {
+			"WithBlockTypeAliases",
+			`
+				doodad "a" {}
+				thing {
+					name = "x"
+				}
+			`,
+			&testschema.WithBlockTypeAliases{
+				Doodad: []*testschema.WithOneBlockLabel{
+					{Name: "a"},
+				},
+				Thing: &testschema.WithStringAttr{Name: "x"},
+			},
+			nil,
+		},
+		"mixed with aliases": {
+			"WithBlockTypeAliases",
+			`
+				gadget "a" {}
+				doodad "b" {}
+				widget "c" {}
+				old_thing {
+					name = "x"
+				}
+			`,
+			&testschema.WithBlockTypeAliases{
+				Doodad: []*testschema.WithOneBlockLabel{
+					{Name: "a"},
+					{Name: "b"},
+					{Name: "c"},
+				},
+				Thing: &testschema.WithStringAttr{Name: "x"},
+			},
+			[]protohcltest.ExpectedDiagnostic{
+				protohcltest.Warning("Deprecated block type").
+					WithDetail(`The block type name "gadget" is deprecated. Use "doodad" instead.`).
+					OnLine(2),
+				protohcltest.Warning("Deprecated block type").
+					WithDetail(`The block type name "widget" is deprecated. Use "doodad" instead.`).
+					OnLine(4),
+				protohcltest.Warning("Deprecated block type").
+					WithDetail(`The block type name "old_thing" is deprecated. Use "thing" instead.`).
+					OnLine(5),
+			},
+		},
+		"singleton under both names": {
+			"WithBlockTypeAliases",
+			`
+				thing {}
+				old_thing {}
+			`,
+			&testschema.WithBlockTypeAliases{
+				Thing: &testschema.WithStringAttr{},
+			},
+			[]protohcltest.ExpectedDiagnostic{
+				protohcltest.Error("Duplicate thing block").OnLine(3),
+				protohcltest.Warning("Deprecated block type").OnLine(3),
+			},
+		},
+		"flattened with prefix": {
+			"WithFlattenedBlockTypeAliases",
+			`
+				base_gadget "a" {}
+			`,
+			&testschema.WithFlattenedBlockTypeAliases{
+				Base: &testschema.WithBlockTypeAliases{
+					Doodad: []*testschema.WithOneBlockLabel{
+						{Name: "a"},
+					},
+				},
+			},
+			[]protohcltest.ExpectedDiagnostic{
+				protohcltest.Warning("Deprecated block type").
+					WithDetail(`The block type name "base_gadget" is deprecated. Use "base_doodad" instead.`),
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			desc := testschema.File_testschema_proto.Messages().ByName(test.msgName)
+			f, diags := hclsyntax.ParseConfig([]byte(test.config), "", hcl.InitialPos)
+			if diags.HasErrors() {
+				t.Fatalf("unexpected syntax errors: %s", diags.Error())
+			}
+
+			got, diags := DecodeBody(f.Body, desc, nil)
+			protohcltest.AssertDiagnostics(t, diags, test.wantDiags...)
+			if diff := cmp.Diff(test.want, got, protoCmpOpt); diff != "" {
+				t.Errorf("wrong result\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDecodeBodyExceptBlockTypeAliases(t *testing.T) {
+	desc := testschema.File_testschema_proto.Messages().ByName("WithBlockTypeAliases")
+	f, diags := hclsyntax.ParseConfig([]byte(`
+		widget "a" {}
+		old_thing {}
+	`), "", hcl.InitialPos)
+	if diags.HasErrors() {
+		t.Fatalf("unexpected syntax errors: %s", diags.Error())
+	}
+
+	got, diags := DecodeBodyExcept(f.Body, desc, nil, []string{"doodad"})
+	protohcltest.AssertDiagnostics(t, diags,
+		protohcltest.Warning("Deprecated block type").OnLine(3),
+	)
+	want := &testschema.WithBlockTypeAliases{
+		Thing: &testschema.WithStringAttr{},
+	}
+	if diff := cmp.Diff(want, got, protoCmpOpt); diff != "" {
+		t.Errorf("wrong result\n%s", diff)
+	}
+}
+
+func TestBlockTypeAliasErrors(t *testing.T) {
+	tests := map[protoreflect.Name]string{
+		"WithBlockTypeAliasConflict": `unsupported protobuf schema in hcl.testschema.WithBlockTypeAliasConflict.name: declaration of attribute "name" conflicts with block type declared by hcl.testschema.WithBlockTypeAliasConflict.thing`,
+		"WithInvalidBlockTypeAlias":  `unsupported protobuf schema in hcl.testschema.WithInvalidBlockTypeAlias.thing: block type "thing" can't also be its own alias`,
+	}
+
+	for msgName, want := range tests {
+		t.Run(string(msgName), func(t *testing.T) {
+			desc := testschema.File_testschema_proto.Messages().ByName(msgName)
+			_, err := bodySchema(desc)
+			if err == nil {
+				t.Fatalf("unexpected success")
+			}
+			if got := err.Error(); got != want {
+				t.Errorf("wrong error\ngot:  %s\nwant: %s", got, want)
+			}
+		})
+	}
+}
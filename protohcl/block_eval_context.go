@@ -0,0 +1,157 @@
+package protohcl
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty/function"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// BlockEvalContextFunc is the signature of a function that returns the
+// evaluation context for the expressions in the body of a nested block, for
+// use with DecodeOptions.BlockEvalContext.
+//
+// typeName is the block's type name as declared in the schema, including
+// any flatten prefix, even if the block used one of the type's aliases.
+// parent is the evaluation context for the body that contains the block,
+// which might be nil. The function should return a child of parent that
+// defines whatever the block type needs, or return parent itself to leave
+// it unchanged.
+//
+// The decoder also calls the function while describing a call to an
+// unknown function, to find the block types where that function is
+// available, so it must not have side-effects.
+type BlockEvalContextFunc func(typeName string, parent *hcl.EvalContext) *hcl.EvalContext
+
+// BlockFunctions returns a BlockEvalContextFunc that makes additional
+// functions available inside blocks of particular types, and inside any
+// blocks nested within them.
+//
+// The keys of the given map are block type names, and each value is the
+// table of the functions to add inside blocks of that type, as for
+// hcl.EvalContext.Functions. A function of the same name in the parent
+// context is shadowed.
+func BlockFunctions(funcs map[string]map[string]function.Function) BlockEvalContextFunc {
+	return func(typeName string, parent *hcl.EvalContext) *hcl.EvalContext {
+		blockFuncs, ok := funcs[typeName]
+		if !ok {
+			return parent
+		}
+		ret := parent.NewChild()
+		ret.Functions = blockFuncs
+		return ret
+	}
+}
+
+// blockEvalContext returns the evaluation context for the body of a block
+// of the given type, which is nested in a body using the given context.
+func (d *decoder) blockEvalContext(typeName string, parent *hcl.EvalContext) *hcl.EvalContext {
+	if d.opts.BlockEvalContext == nil {
+		return parent
+	}
+	return d.opts.BlockEvalContext(typeName, parent)
+}
+
+// describeScopedFunctions improves any diagnostics in the given set that
+// are about calls to functions that DecodeOptions.BlockEvalContext makes
+// available only inside other block types, by saying where they are
+// available. The diagnostics must be from evaluating an expression in the
+// body of a message of the given type with the given context.
+//
+// Only block types nested somewhere within the given message type are
+// candidates, since those are the ones the author could move the
+// expression into.
+func (d *decoder) describeScopedFunctions(diags hcl.Diagnostics, desc protoreflect.MessageDescriptor, ctx *hcl.EvalContext) hcl.Diagnostics {
+	if d.opts.BlockEvalContext == nil {
+		return diags
+	}
+	var typeNames []string
+	for i, diag := range diags {
+		if diag.Summary != "Call to unknown function" && diag.Summary != "Function calls not allowed" {
+			continue
+		}
+		call, ok := diag.Expression.(*hclsyntax.FunctionCallExpr)
+		if !ok {
+			continue
+		}
+		if typeNames == nil {
+			typeNames = reachableBlockTypeNames(desc)
+		}
+		var avail []string
+		for _, typeName := range typeNames {
+			if hasFunction(d.opts.BlockEvalContext(typeName, ctx), call.Name) {
+				avail = append(avail, typeName)
+			}
+		}
+		if len(avail) == 0 {
+			continue
+		}
+
+		newDiag := *diag
+		newDiag.Detail = fmt.Sprintf("The function %q is available only inside %s blocks.", call.Name, blockTypeNamesList(avail))
+		diags[i] = &newDiag
+	}
+	return diags
+}
+
+// reachableBlockTypeNames returns the distinct type names of all of the
+// nested block types declared in the given message type, in messages it
+// flattens, and recursively in the message types of those nested blocks,
+// in lexical order.
+func reachableBlockTypeNames(desc protoreflect.MessageDescriptor) []string {
+	names := make(map[string]struct{})
+	visited := make(map[protoreflect.FullName]struct{})
+	var visit func(desc protoreflect.MessageDescriptor)
+	visit = func(desc protoreflect.MessageDescriptor) {
+		if _, exists := visited[desc.FullName()]; exists {
+			return
+		}
+		visited[desc.FullName()] = struct{}{}
+		blockTypes := make(map[string]FieldNestedBlockType)
+		collectNestedBlockTypes(desc, "", blockTypes)
+		for _, elem := range blockTypes {
+			names[elem.TypeName] = struct{}{}
+			visit(elem.Nested)
+		}
+	}
+	visit(desc)
+
+	ret := make([]string, 0, len(names))
+	for name := range names {
+		ret = append(ret, name)
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+// hasFunction returns true if the given evaluation context or any of its
+// parents defines a function of the given name.
+func hasFunction(ctx *hcl.EvalContext, name string) bool {
+	for ; ctx != nil; ctx = ctx.Parent() {
+		if _, exists := ctx.Functions[name]; exists {
+			return true
+		}
+	}
+	return false
+}
+
+// blockTypeNamesList returns a human-readable list of the given block type
+// names, for use in error messages.
+func blockTypeNamesList(names []string) string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = fmt.Sprintf("%q", name)
+	}
+	switch len(quoted) {
+	case 1:
+		return quoted[0]
+	case 2:
+		return quoted[0] + " or " + quoted[1]
+	default:
+		return strings.Join(quoted[:len(quoted)-1], ", ") + ", or " + quoted[len(quoted)-1]
+	}
+}
@@ -0,0 +1,162 @@
+package protohcl
+
+import (
+	"testing"
+
+	"github.com/apparentlymart/go-protohcl/protohcl/internal/testschema"
+	"github.com/apparentlymart/go-protohcl/protohcl/protohcltest"
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+	"github.com/zclconf/go-cty/cty/function/stdlib"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestBlockEvalContext(t *testing.T) {
+	secret := function.New(&function.Spec{
+		Params: []function.Parameter{
+			{Name: "name", Type: cty.String},
+		},
+		Type: function.StaticReturnType(cty.String),
+		Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+			return cty.StringVal("secret " + args[0].AsString()), nil
+		},
+	})
+	opts := DecodeOptions{
+		BlockEvalContext: BlockFunctions(map[string]map[string]function.Function{
+			"settings": {
+				"secret": secret,
+			},
+		}),
+	}
+	ctx := &hcl.EvalContext{
+		Functions: map[string]function.Function{
+			"upper": stdlib.UpperFunc,
+		},
+	}
+
+	tests := map[string]struct {
+		config    string
+		ctx       *hcl.EvalContext
+		want      proto.Message
+		wantDiags []protohcltest.ExpectedDiagnostic
+	}{
+		"inside the block": {
+			`
+				name = upper("a")
+				settings {
+					name = upper(secret("b"))
+				}
+			`,
+			ctx,
+			&testschema.WithMergeableContent{
+				Name:     "A",
+				Settings: &testschema.WithOptionalAttrs{Name: "SECRET B"},
+				Base:     &testschema.WithStringAttr{},
+			},
+			nil,
+		},
+		"outside the block": {
+			`
+				name = secret("a")
+			`,
+			ctx,
+			&testschema.WithMergeableContent{
+				Base: &testschema.WithStringAttr{},
+			},
+			[]protohcltest.ExpectedDiagnostic{
+				protohcltest.Error("Call to unknown function").
+					WithDetail(`The function "secret" is available only inside "settings" blocks.`).
+					OnLine(2),
+			},
+		},
+		"outside the block without functions": {
+			`
+				name = secret("a")
+			`,
+			nil,
+			&testschema.WithMergeableContent{
+				Base: &testschema.WithStringAttr{},
+			},
+			[]protohcltest.ExpectedDiagnostic{
+				protohcltest.Error("Function calls not allowed").
+					WithDetail(`The function "secret" is available only inside "settings" blocks.`).
+					OnLine(2),
+			},
+		},
+		"in an unrelated block": {
+			`
+				name = "a"
+				rule "b" {
+					nickname = secret("c")
+				}
+			`,
+			ctx,
+			&testschema.WithMergeableContent{
+				Name: "a",
+				Rule: []*testschema.WithOneBlockLabel{
+					{Name: "b"},
+				},
+				Base: &testschema.WithStringAttr{},
+			},
+			[]protohcltest.ExpectedDiagnostic{
+				protohcltest.Error("Call to unknown function").
+					WithDetail(`There is no function named "secret".`).
+					OnLine(4),
+			},
+		},
+	}
+
+	desc := (&testschema.WithMergeableContent{}).ProtoReflect().Descriptor()
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, diags := opts.DecodeBody(parseTestBody(t, test.config), desc, test.ctx)
+			protohcltest.AssertDiagnostics(t, diags, test.wantDiags...)
+			if diff := cmp.Diff(test.want, got, protoCmpOpt); diff != "" {
+				t.Errorf("wrong result\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestBlockEvalContextVariables(t *testing.T) {
+	var gotTypeNames []string
+	opts := DecodeOptions{
+		BlockEvalContext: func(typeName string, parent *hcl.EvalContext) *hcl.EvalContext {
+			gotTypeNames = append(gotTypeNames, typeName)
+			ret := parent.NewChild()
+			ret.Variables = map[string]cty.Value{
+				"block_type": cty.StringVal(typeName),
+			}
+			return ret
+		},
+	}
+
+	desc := (&testschema.WithMergeableContent{}).ProtoReflect().Descriptor()
+	got, diags := opts.DecodeBody(parseTestBody(t, `
+		name = "a"
+		settings {
+			name = block_type
+		}
+		rule "b" {
+			nickname = block_type
+		}
+	`), desc, nil)
+	protohcltest.AssertDiagnostics(t, diags)
+
+	want := &testschema.WithMergeableContent{
+		Name:     "a",
+		Settings: &testschema.WithOptionalAttrs{Name: "settings"},
+		Rule: []*testschema.WithOneBlockLabel{
+			{Name: "b", Nickname: "rule"},
+		},
+		Base: &testschema.WithStringAttr{},
+	}
+	if diff := cmp.Diff(want, got, protoCmpOpt); diff != "" {
+		t.Errorf("wrong result\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"settings", "rule"}, gotTypeNames); diff != "" {
+		t.Errorf("wrong type names\n%s", diff)
+	}
+}
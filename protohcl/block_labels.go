@@ -0,0 +1,91 @@
+package protohcl
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// describeBlockLabels returns a copy of the given diagnostics where any
+// that HCL produced for a block of one of the given types having the wrong
+// number of labels also explain what each of the block type's labels mean,
+// using their (hcl.label).description options.
+//
+// HCL's own messages only list the label names, which often isn't enough
+// for a configuration author to understand what they've missed.
+func describeBlockLabels(diags hcl.Diagnostics, blockTypes map[string]FieldNestedBlockType) hcl.Diagnostics {
+	if !diags.HasErrors() {
+		return diags
+	}
+
+	var ret hcl.Diagnostics
+	for _, diag := range diags {
+		typeName, ok := blockLabelDiagnosticType(diag)
+		elem, wanted := blockTypes[typeName]
+		if !ok || !wanted {
+			ret = append(ret, diag)
+			continue
+		}
+		var labels []FieldBlockLabel
+		if elem.MapKeyLabel != "" {
+			// The map key label has no description option, but it must
+			// still take its place in the numbering.
+			labels = append(labels, FieldBlockLabel{Name: elem.MapKeyLabel})
+		}
+		labels, err := blockLabels(elem.Nested, "", labels, make(map[string]protoreflect.FullName))
+		if err != nil {
+			// We wouldn't have got as far as HCL's diagnostics if the
+			// labels were invalid.
+			ret = append(ret, diag)
+			continue
+		}
+
+		var lines []string
+		for _, label := range labels {
+			if label.Description != "" {
+				lines = append(lines, fmt.Sprintf("- %s: %s", label.Name, label.Description))
+			}
+		}
+		if len(lines) == 0 {
+			ret = append(ret, diag)
+			continue
+		}
+		described := *diag
+		described.Detail = fmt.Sprintf("%s\n\nThe labels of a %s block are:\n%s", diag.Detail, typeName, strings.Join(lines, "\n"))
+		ret = append(ret, &described)
+	}
+	return ret
+}
+
+// blockLabelDiagnosticType returns the block type name from one of the
+// diagnostics that HCL produces for a block with the wrong number of labels,
+// or false if the given diagnostic isn't one of those.
+func blockLabelDiagnosticType(diag *hcl.Diagnostic) (string, bool) {
+	switch {
+	case strings.HasPrefix(diag.Summary, "Extraneous label for "):
+		return strings.TrimPrefix(diag.Summary, "Extraneous label for "), true
+	case strings.HasPrefix(diag.Summary, "Missing ") && strings.Contains(diag.Summary, " for "):
+		// The native syntax's "Missing <label> for <type>". Label names
+		// can't contain spaces, so the first " for " is the separator.
+		rest := strings.TrimPrefix(diag.Summary, "Missing ")
+		return rest[strings.Index(rest, " for ")+len(" for "):], true
+	case diag.Summary == "Missing block label":
+		// The JSON syntax's message names the block type in its detail,
+		// as "... represents the <type> block's <label>."
+		const prefix = "represents the "
+		i := strings.Index(diag.Detail, prefix)
+		if i < 0 {
+			return "", false
+		}
+		rest := diag.Detail[i+len(prefix):]
+		j := strings.Index(rest, " block's ")
+		if j < 0 {
+			return "", false
+		}
+		return rest[:j], true
+	default:
+		return "", false
+	}
+}
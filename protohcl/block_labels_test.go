@@ -0,0 +1,105 @@
+package protohcl
+
+import (
+	"testing"
+
+	"github.com/apparentlymart/go-protohcl/protohcl/internal/testschema"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/json"
+)
+
+func TestDecodeBodyDescribedBlockLabels(t *testing.T) {
+	desc := testschema.File_testschema_proto.Messages().ByName("WithNestedBlockDescribedLabels")
+	const explanation = "\n\nThe labels of a doodad block are:\n- type: The kind of doodad, such as \"widget\"."
+
+	tests := map[string]struct {
+		config      string
+		json        bool
+		wantSummary string
+		wantDetail  string
+	}{
+		"missing label": {
+			`doodad "widget" {}`,
+			false,
+			`Missing name for doodad`,
+			`All doodad blocks must have 2 labels (type, name).` + explanation,
+		},
+		"no labels": {
+			`doodad {}`,
+			false,
+			`Missing type for doodad`,
+			`All doodad blocks must have 2 labels (type, name).` + explanation,
+		},
+		"extraneous label": {
+			`doodad "widget" "a" "b" {}`,
+			false,
+			`Extraneous label for doodad`,
+			`Only 2 labels (type, name) are expected for doodad blocks.` + explanation,
+		},
+		"json missing label": {
+			`{"doodad": {"widget": {}}}`,
+			true,
+			`Missing block label`,
+			`At least one object property is required, whose name represents the doodad block's name.` + explanation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var f *hcl.File
+			var diags hcl.Diagnostics
+			if test.json {
+				f, diags = json.Parse([]byte(test.config), "")
+			} else {
+				f, diags = hclsyntax.ParseConfig([]byte(test.config), "", hcl.InitialPos)
+			}
+			if diags.HasErrors() {
+				t.Fatalf("unexpected syntax errors: %s", diags.Error())
+			}
+
+			_, diags = DecodeBody(f.Body, desc, nil)
+			if len(diags) != 1 {
+				t.Fatalf("wrong number of diagnostics %d; want 1\n%s", len(diags), diags.Error())
+			}
+			if got, want := diags[0].Summary, test.wantSummary; got != want {
+				t.Errorf("wrong summary\ngot:  %s\nwant: %s", got, want)
+			}
+			if got, want := diags[0].Detail, test.wantDetail; got != want {
+				t.Errorf("wrong detail\ngot:  %s\nwant: %s", got, want)
+			}
+		})
+	}
+}
+
+func TestDecodeBodyUndescribedBlockLabels(t *testing.T) {
+	// Without any descriptions, HCL's own message is unchanged.
+	f, diags := hclsyntax.ParseConfig([]byte(`doodad "a" {}`), "", hcl.InitialPos)
+	if diags.HasErrors() {
+		t.Fatalf("unexpected syntax errors: %s", diags.Error())
+	}
+	desc := testschema.File_testschema_proto.Messages().ByName("WithNestedBlockTwoLabelRepeated")
+	_, diags = DecodeBody(f.Body, desc, nil)
+	if len(diags) != 1 {
+		t.Fatalf("wrong number of diagnostics %d; want 1\n%s", len(diags), diags.Error())
+	}
+	if got, want := diags[0].Detail, `All doodad blocks must have 2 labels (type, name).`; got != want {
+		t.Errorf("wrong detail\ngot:  %s\nwant: %s", got, want)
+	}
+}
+
+func TestDecodeAllBlocksDescribedBlockLabels(t *testing.T) {
+	f, diags := hclsyntax.ParseConfig([]byte(`doodad {}`), "", hcl.InitialPos)
+	if diags.HasErrors() {
+		t.Fatalf("unexpected syntax errors: %s", diags.Error())
+	}
+	desc := testschema.File_testschema_proto.Messages().ByName("WithDescribedBlockLabels")
+	_, _, diags = DecodeAllBlocks(f.Body, "doodad", desc, nil)
+	if len(diags) != 1 {
+		t.Fatalf("wrong number of diagnostics %d; want 1\n%s", len(diags), diags.Error())
+	}
+	want := "All doodad blocks must have 2 labels (type, name).\n\nThe labels of a doodad block are:\n- type: The kind of doodad, such as \"widget\"."
+	if got := diags[0].Detail; got != want {
+		t.Errorf("wrong detail\ngot:  %s\nwant: %s", got, want)
+	}
+}
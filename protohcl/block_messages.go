@@ -0,0 +1,138 @@
+package protohcl
+
+import (
+	"fmt"
+	"sort"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// BlockMessage is one of the nested blocks represented in a decoded
+// message, as passed to the callback function of IterateBlockMessages.
+type BlockMessage struct {
+	// Message is the message that the block's body was decoded into.
+	//
+	// For a block type whose messages are google.protobuf.Any, chosen by
+	// (hcl.block).any_discriminator, this is the Any message itself.
+	Message proto.Message
+
+	// Labels are the block's labels in the order they appear in the
+	// configuration, including the map key label first for a map block
+	// type whose keys come from a label.
+	Labels []string
+
+	// Key is the map key of the block for a map block type, or empty for
+	// other block types.
+	Key string
+}
+
+// IterateBlockMessages calls the given function for each of the nested
+// blocks of the given type represented in the given decoded message, in
+// the order they appear in the message, until the function returns false.
+//
+// The blocks of a map block type are visited in lexical order of their
+// keys. The block type can be declared in a message flattened into the
+// given message, in which case the given type name must include any prefix
+// from (hcl.flatten_prefix).
+//
+// This is a convenience for host code that post-processes decoded
+// configuration, so that it doesn't need to locate the field for a block
+// type and deal with its protoreflect list or map directly. It returns an
+// error if the message type doesn't have a nested block type of the given
+// name.
+func IterateBlockMessages(msg proto.Message, typeName string, fn func(BlockMessage) bool) error {
+	msgR := msg.ProtoReflect()
+	container, elem, err := findBlockTypeField(msgR, typeName, "")
+	if err != nil {
+		return err
+	}
+	if container == nil {
+		return fmt.Errorf("%s has no nested block type %q", msgR.Descriptor().FullName(), typeName)
+	}
+
+	field := elem.TargetField
+	switch {
+	case field.IsMap():
+		m := container.Get(field).Map()
+		keys := make([]string, 0, m.Len())
+		m.Range(func(k protoreflect.MapKey, _ protoreflect.Value) bool {
+			keys = append(keys, k.String())
+			return true
+		})
+		sort.Strings(keys)
+		for _, key := range keys {
+			nested := m.Get(protoreflect.ValueOfString(key).MapKey()).Message()
+			var labels []string
+			if elem.MapKeyLabel != "" {
+				labels = append(labels, key)
+			}
+			labels = blockMessageLabels(nested, labels)
+			if !fn(BlockMessage{Message: nested.Interface(), Labels: labels, Key: key}) {
+				return nil
+			}
+		}
+	case field.IsList():
+		l := container.Get(field).List()
+		for i := 0; i < l.Len(); i++ {
+			nested := l.Get(i).Message()
+			if !fn(BlockMessage{Message: nested.Interface(), Labels: blockMessageLabels(nested, nil)}) {
+				return nil
+			}
+		}
+	default:
+		if container.Has(field) {
+			nested := container.Get(field).Message()
+			fn(BlockMessage{Message: nested.Interface(), Labels: blockMessageLabels(nested, nil)})
+		}
+	}
+	return nil
+}
+
+// findBlockTypeField searches the fields of the given message, and of any
+// messages flattened into it, for the nested block type of the given name.
+// It returns the message containing the block type's field along with the
+// block type, or a nil message if there's no such block type.
+func findBlockTypeField(msg protoreflect.Message, typeName string, prefix string) (protoreflect.Message, FieldNestedBlockType, error) {
+	fields := msg.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		elem, err := GetFieldElem(field)
+		if err != nil {
+			return nil, FieldNestedBlockType{}, err
+		}
+		switch elem := withNamePrefix(elem, prefix).(type) {
+		case FieldNestedBlockType:
+			if elem.hasTypeName(typeName) {
+				return msg, elem, nil
+			}
+		case FieldFlattened:
+			container, found, err := findBlockTypeField(msg.Get(field).Message(), typeName, elem.Prefix)
+			if err != nil || container != nil {
+				return container, found, err
+			}
+		}
+	}
+	return nil, FieldNestedBlockType{}, nil
+}
+
+// blockMessageLabels appends the values of the label-annotated fields of
+// the given message, including those in flattened messages, to the given
+// labels, in the same order that setBlockLabels populates them.
+func blockMessageLabels(msg protoreflect.Message, labels []string) []string {
+	fields := msg.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		elem, err := GetFieldElem(field)
+		if err != nil {
+			continue // decoding would already have failed for this one
+		}
+		switch elem.(type) {
+		case FieldBlockLabel:
+			labels = append(labels, msg.Get(field).String())
+		case FieldFlattened:
+			labels = blockMessageLabels(msg.Get(field).Message(), labels)
+		}
+	}
+	return labels
+}
@@ -0,0 +1,148 @@
+package protohcl
+
+import (
+	"testing"
+
+	"github.com/apparentlymart/go-protohcl/protohcl/internal/testschema"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func TestIterateBlockMessages(t *testing.T) {
+	tests := map[string]struct {
+		messageType string
+		config      string
+		typeName    string
+		want        []BlockMessage
+	}{
+		"singleton": {
+			"WithNestedBlockNoLabelsSingleton",
+			`
+				doodad {
+					name = "a"
+				}
+			`,
+			"doodad",
+			[]BlockMessage{
+				{Message: &testschema.WithStringAttr{Name: "a"}},
+			},
+		},
+		"absent singleton": {
+			"WithNestedBlockNoLabelsSingleton",
+			``,
+			"doodad",
+			nil,
+		},
+		"repeated with labels": {
+			"WithNestedBlockTwoLabelRepeated",
+			`
+				doodad "x" "a" {}
+				doodad "y" "b" {
+					nickname = "bee"
+				}
+			`,
+			"doodad",
+			[]BlockMessage{
+				{
+					Message: &testschema.WithTwoBlockLabels{Type: "x", Name: "a"},
+					Labels:  []string{"x", "a"},
+				},
+				{
+					Message: &testschema.WithTwoBlockLabels{Type: "y", Name: "b", Nickname: "bee"},
+					Labels:  []string{"y", "b"},
+				},
+			},
+		},
+		"labels from flattened message": {
+			"WithNestedBlockFlattenedLabels",
+			`
+				doodad "cat" "Mittens" {}
+			`,
+			"doodad",
+			[]BlockMessage{
+				{
+					Message: &testschema.WithFlattenedBlockLabel{
+						Type: "cat",
+						Base: &testschema.WithOneBlockLabel{Name: "Mittens"},
+					},
+					Labels: []string{"cat", "Mittens"},
+				},
+			},
+		},
+		"map": {
+			"WithMapOfBlocks",
+			`
+				pet "tabby" {
+					name = "Mittens"
+				}
+				pet "collie" {
+					name = "Lassie"
+				}
+			`,
+			"pet",
+			[]BlockMessage{
+				{
+					Message: &testschema.WithStringAttr{Name: "Lassie"},
+					Labels:  []string{"collie"},
+					Key:     "collie",
+				},
+				{
+					Message: &testschema.WithStringAttr{Name: "Mittens"},
+					Labels:  []string{"tabby"},
+					Key:     "tabby",
+				},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			desc := testschema.File_testschema_proto.Messages().ByName(protoreflect.Name(test.messageType))
+			msg, diags := DecodeBody(parseTestBody(t, test.config), desc, nil)
+			if diags.HasErrors() {
+				t.Fatalf("unexpected errors: %s", diags.Error())
+			}
+
+			var got []BlockMessage
+			err := IterateBlockMessages(msg, test.typeName, func(block BlockMessage) bool {
+				got = append(got, block)
+				return true
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(test.want, got, protoCmpOpt); diff != "" {
+				t.Errorf("wrong blocks\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIterateBlockMessagesStop(t *testing.T) {
+	msg := &testschema.WithNestedBlockOneLabelRepeated{
+		Doodad: []*testschema.WithOneBlockLabel{{Name: "a"}, {Name: "b"}},
+	}
+	var got []string
+	err := IterateBlockMessages(msg, "doodad", func(block BlockMessage) bool {
+		got = append(got, block.Labels[0])
+		return false
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if diff := cmp.Diff([]string{"a"}, got); diff != "" {
+		t.Errorf("wrong labels\n%s", diff)
+	}
+}
+
+func TestIterateBlockMessagesUnknownType(t *testing.T) {
+	var msg proto.Message = &testschema.WithStringAttr{}
+	err := IterateBlockMessages(msg, "doodad", func(BlockMessage) bool { return true })
+	if err == nil {
+		t.Fatal("unexpected success")
+	}
+	if got, want := err.Error(), `hcl.testschema.WithStringAttr has no nested block type "doodad"`; got != want {
+		t.Errorf("wrong error\ngot:  %s\nwant: %s", got, want)
+	}
+}
@@ -1,7 +1,9 @@
 package protohcl
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/hashicorp/hcl/v2"
 	"google.golang.org/protobuf/reflect/protoreflect"
@@ -10,19 +12,12 @@ import (
 // bodySchema constucts a HCL body schema from the given message descriptor,
 // or returns an error explaining why the descriptor is invalid for HCL use.
 func bodySchema(desc protoreflect.MessageDescriptor) (*hcl.BodySchema, error) {
-	// For the moment we don't allow "oneofs" at all, except for the synthetic
-	// ones used to represent nullable fields, because we don't yet have the
-	// logic to return an error if the input configuration tries to populate
-	// more than one oneof field at a time.
-	// TODO: Implement that extra validation logic in the body decoder, and
-	// then we can remove this restriction. When we do, we may wish to allow
-	// annotating oneofs with an HCL-specific "required", because proto oneofs
-	// are really "zero or one of" but in HCL we commonly want to require
-	// exactly one of a set of possibilities.
+	// A oneof means "at most one of these block types", so its members must
+	// all be nested block types. (Synthetic oneofs just represent the
+	// presence of proto3 "optional" fields, so they don't need checking.)
 	for i := 0; i < desc.Oneofs().Len(); i++ {
-		oneOf := desc.Oneofs().Get(i)
-		if !oneOf.IsSynthetic() {
-			return nil, schemaErrorf(oneOf.FullName(), "oneof declarations are not yet supported in messages used for HCL decoding")
+		if err := checkOneofBlockTypes(desc.Oneofs().Get(i)); err != nil {
+			return nil, err
 		}
 	}
 
@@ -58,18 +53,26 @@ func bodySchema(desc protoreflect.MessageDescriptor) (*hcl.BodySchema, error) {
 			attrs[attrS.Name] = field.FullName()
 
 		case FieldNestedBlockType:
-			blockS := blockTypeSchema(elem)
-			if existingName, exists := attrs[blockS.Type]; exists {
-				return nil, schemaErrorf(field.FullName(), "declaration of block type %q conflicts with attribute declared by %s", blockS.Type, existingName)
-			}
-			if existingName, exists := blockTypes[blockS.Type]; exists {
-				return nil, schemaErrorf(field.FullName(), "declaration of block type %q conflicts with %s", blockS.Type, existingName)
+			blockS, err := blockTypeSchema(elem)
+			if err != nil {
+				return nil, schemaErrorf(field.FullName(), "invalid block type %q: %w", elem.TypeName, err)
 			}
-			if existingName, exists := blockLabels[blockS.Type]; exists {
-				return nil, schemaErrorf(field.FullName(), "declaration of block type %q conflicts with block label name declared by %s", blockS.Type, existingName)
+			// Each alias is a separate block type as far as HCL is
+			// concerned, with the same labels as the main type name.
+			for _, typeName := range append([]string{elem.TypeName}, elem.Aliases...) {
+				blockS.Type = typeName
+				if existingName, exists := attrs[blockS.Type]; exists {
+					return nil, schemaErrorf(field.FullName(), "declaration of block type %q conflicts with attribute declared by %s", blockS.Type, existingName)
+				}
+				if existingName, exists := blockTypes[blockS.Type]; exists {
+					return nil, schemaErrorf(field.FullName(), "declaration of block type %q conflicts with %s", blockS.Type, existingName)
+				}
+				if existingName, exists := blockLabels[blockS.Type]; exists {
+					return nil, schemaErrorf(field.FullName(), "declaration of block type %q conflicts with block label name declared by %s", blockS.Type, existingName)
+				}
+				ret.Blocks = append(ret.Blocks, blockS)
+				blockTypes[blockS.Type] = field.FullName()
 			}
-			ret.Blocks = append(ret.Blocks, blockS)
-			blockTypes[blockS.Type] = field.FullName()
 
 		case FieldFlattened:
 			// For our schema-building purposes we'll deal with "flatten" by
@@ -80,6 +83,10 @@ func bodySchema(desc protoreflect.MessageDescriptor) (*hcl.BodySchema, error) {
 				return nil, schemaErrorf(desc.FullName(), "invalid message to flatten: %w", err)
 			}
 			for _, attrS := range nestSchema.Attributes {
+				attrS.Name = elem.Prefix + attrS.Name
+				if _, shadowed := elem.Shadowed[attrS.Name]; shadowed {
+					continue
+				}
 				if existingName, exists := attrs[attrS.Name]; exists {
 					return nil, schemaErrorf(field.FullName(), "flattened-in attribute %q conflicts with %s", attrS.Name, existingName)
 				}
@@ -93,6 +100,7 @@ func bodySchema(desc protoreflect.MessageDescriptor) (*hcl.BodySchema, error) {
 				attrs[attrS.Name] = field.FullName()
 			}
 			for _, blockS := range nestSchema.Blocks {
+				blockS.Type = elem.Prefix + blockS.Type
 				if existingName, exists := attrs[blockS.Type]; exists {
 					return nil, schemaErrorf(field.FullName(), "flattened-in block type %q conflicts with attribute declared by %s", blockS.Type, existingName)
 				}
@@ -105,6 +113,26 @@ func bodySchema(desc protoreflect.MessageDescriptor) (*hcl.BodySchema, error) {
 				ret.Blocks = append(ret.Blocks, blockS)
 				blockTypes[blockS.Type] = field.FullName()
 			}
+			// The nested message's block labels don't appear in its body
+			// schema, but they still share a namespace with our attributes
+			// and block types, and with any labels we declare directly.
+			nestLabels, err := blockLabelNames(elem.Nested, nil, map[string]protoreflect.FullName{})
+			if err != nil {
+				return nil, schemaErrorf(desc.FullName(), "invalid message to flatten: %w", err)
+			}
+			for _, name := range nestLabels {
+				name = elem.Prefix + name
+				if existingName, exists := attrs[name]; exists {
+					return nil, schemaErrorf(field.FullName(), "flattened-in block label name %q conflicts with attribute declared by %s", name, existingName)
+				}
+				if existingName, exists := blockTypes[name]; exists {
+					return nil, schemaErrorf(field.FullName(), "flattened-in block label name %q conflicts with block type declared by %s", name, existingName)
+				}
+				if existingName, exists := blockLabels[name]; exists {
+					return nil, schemaErrorf(field.FullName(), "flattened-in block label name %q conflicts with %s", name, existingName)
+				}
+				blockLabels[name] = field.FullName()
+			}
 
 		case FieldBlockLabel:
 			// While we're dealing with bodies we only care that the label
@@ -145,15 +173,58 @@ func attributeSchema(elem FieldAttribute) hcl.AttributeSchema {
 	}
 }
 
-func blockTypeSchema(elem FieldNestedBlockType) hcl.BlockHeaderSchema {
+func blockTypeSchema(elem FieldNestedBlockType) (hcl.BlockHeaderSchema, error) {
 	// We need to search in the nested message for any label-annotated fields,
 	// which will each in turn define one block label.
-	var labelNames []string
-	msg := elem.Nested
-	fieldCount := msg.Fields().Len()
+	var names []string
+	seen := map[string]protoreflect.FullName{}
+	if elem.MapKeyLabel != "" {
+		// The map key label always comes first.
+		names = append(names, elem.MapKeyLabel)
+		seen[elem.MapKeyLabel] = elem.TargetField.FullName()
+	}
+	labelNames, err := blockLabelNames(elem.Nested, names, seen)
+	if err != nil {
+		return hcl.BlockHeaderSchema{}, err
+	}
 
+	return hcl.BlockHeaderSchema{
+		Type:       elem.TypeName,
+		LabelNames: labelNames,
+	}, nil
+}
+
+// blockLabelNames appends to the given slice the names of all of the
+// label-annotated fields of the given message, in field declaration order,
+// and returns the result.
+//
+// Labels declared in a flattened message are treated as if they were declared
+// directly in the message that flattens it, at the position of the
+// flatten-annotated field. This allows several block types to share their
+// labels via a common base message.
+//
+// The "seen" map tracks which label names have already been declared, and
+// by which field, so that we can report conflicts between labels declared
+// at different levels of flattening. blockLabelNames adds new entries to it.
+func blockLabelNames(desc protoreflect.MessageDescriptor, names []string, seen map[string]protoreflect.FullName) ([]string, error) {
+	labels, err := blockLabels(desc, "", nil, seen)
+	if err != nil {
+		return nil, err
+	}
+	for _, label := range labels {
+		names = append(names, label.Name)
+	}
+	return names, nil
+}
+
+// blockLabels is like blockLabelNames but appends the full label elements,
+// for callers that need more than just the names. The given prefix is
+// added to the names of all of the labels, for a message that was
+// flattened in using (hcl.flatten_prefix).
+func blockLabels(desc protoreflect.MessageDescriptor, prefix string, labels []FieldBlockLabel, seen map[string]protoreflect.FullName) ([]FieldBlockLabel, error) {
+	fieldCount := desc.Fields().Len()
 	for i := 0; i < fieldCount; i++ {
-		field := msg.Fields().Get(i)
+		field := desc.Fields().Get(i)
 
 		elem, err := GetFieldElem(field)
 		if err != nil {
@@ -161,19 +232,25 @@ func blockTypeSchema(elem FieldNestedBlockType) hcl.BlockHeaderSchema {
 			// ignore it.
 			continue
 		}
+		elem = withNamePrefix(elem, prefix)
 
 		switch elem := elem.(type) {
 		case FieldBlockLabel:
-			labelNames = append(labelNames, elem.Name)
+			if existingName, exists := seen[elem.Name]; exists {
+				return nil, schemaErrorf(field.FullName(), "block label name %q conflicts with %s", elem.Name, existingName)
+			}
+			seen[elem.Name] = field.FullName()
+			labels = append(labels, elem)
+		case FieldFlattened:
+			labels, err = blockLabels(elem.Nested, elem.Prefix, labels, seen)
+			if err != nil {
+				return nil, err
+			}
 		default:
 			// Everything else is irrelevant for our purposes here.
 		}
 	}
-
-	return hcl.BlockHeaderSchema{
-		Type:       elem.TypeName,
-		LabelNames: labelNames,
-	}
+	return labels, nil
 }
 
 // schemaError is an error type used for any situation where the given message
@@ -187,6 +264,16 @@ type schemaError struct {
 	// reflects the problem being described.
 	Decl protoreflect.FullName
 
+	// BlockPath is the sequence of nested block type names leading to the
+	// body whose schema has the problem, if known. It is empty for a problem
+	// in the schema of the top-level body, or if the problem was detected
+	// outside of the context of decoding a particular body.
+	//
+	// A message type can be used for many different nested block types, so
+	// this can help distinguish which usage of a message type the error
+	// was detected for.
+	BlockPath []string
+
 	// Err is the underlying error.
 	Err error
 }
@@ -198,25 +285,51 @@ func schemaErrorf(decl protoreflect.FullName, format string, args ...interface{}
 	}
 }
 
+// schemaErrorInBlock returns a copy of the given error annotated with the
+// given block path, if it is a schemaError. Otherwise, returns the given
+// error verbatim.
+func schemaErrorInBlock(err error, blockPath []string) error {
+	if err, ok := err.(schemaError); ok && len(blockPath) != 0 {
+		err.BlockPath = blockPath
+		return err
+	}
+	return err
+}
+
 func (err schemaError) Error() string {
+	var where string
 	if err.Decl != "" && err.Decl.IsValid() {
-		return fmt.Sprintf("unsupported protobuf schema: %s", err.Err.Error())
+		where = " in " + string(err.Decl)
+	}
+	if len(err.BlockPath) != 0 {
+		where += fmt.Sprintf(" (for %s block)", strings.Join(err.BlockPath, "."))
 	}
-	return fmt.Sprintf("unsupported protobuf schema in %s: %s", err.Decl, err.Err.Error())
+	return fmt.Sprintf("unsupported protobuf schema%s: %s", where, err.Err.Error())
 }
 
 func (err schemaError) Unwrap() error {
 	return err.Err
 }
 
+const schemaErrorSummary = "Invalid configuration schema"
+
 func (err schemaError) Diagnostic() *hcl.Diagnostic {
+	decl := string(err.Decl)
+	if len(err.BlockPath) != 0 {
+		decl += fmt.Sprintf(" (for %s block)", strings.Join(err.BlockPath, "."))
+	}
+	var featureErr unsupportedFeatureError
+	if errors.As(err.Err, &featureErr) {
+		return featureErr.diagnostic(decl)
+	}
 	return &hcl.Diagnostic{
 		Severity: hcl.DiagError,
-		Summary:  "Invalid configuration schema",
+		Summary:  schemaErrorSummary,
 		Detail: fmt.Sprintf(
 			"Invalid HCL annotations in protobuf schema for %s: %s.\n\nThis is a bug in the component that defined this schema, and not an error in the given configuration.",
-			err.Decl, err.Err.Error(),
+			decl, err.Err.Error(),
 		),
+		Extra: diagnosticExtra{Category: DiagnosticSchemaError},
 	}
 }
 
@@ -227,11 +340,12 @@ func schemaErrorDiagnostic(err error) *hcl.Diagnostic {
 	default:
 		return &hcl.Diagnostic{
 			Severity: hcl.DiagError,
-			Summary:  "Invalid configuration schema",
+			Summary:  schemaErrorSummary,
 			Detail: fmt.Sprintf(
 				"Failed to construct configuration schema from protobuf schema: %s.\n\nThis is a bug in the component that defined this schema, and not an error in the given configuration.",
 				err.Error(),
 			),
+			Extra: diagnosticExtra{Category: DiagnosticSchemaError},
 		}
 	}
 }
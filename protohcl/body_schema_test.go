@@ -1,11 +1,13 @@
 package protohcl
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/apparentlymart/go-protohcl/protohcl/internal/testschema"
 	"github.com/google/go-cmp/cmp"
 	"github.com/hashicorp/hcl/v2"
+	"google.golang.org/protobuf/reflect/protoreflect"
 )
 
 func TestBodySchema(t *testing.T) {
@@ -37,4 +39,122 @@ func TestBodySchema(t *testing.T) {
 			t.Errorf("wrong schema\n%s", diff)
 		}
 	})
+	t.Run("labels from flattened message", func(t *testing.T) {
+		desc := testschema.File_testschema_proto.Messages().ByName("WithNestedBlockFlattenedLabels")
+		got, err := bodySchema(desc)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		want := &hcl.BodySchema{
+			Blocks: []hcl.BlockHeaderSchema{
+				{
+					Type:       "doodad",
+					LabelNames: []string{"type", "name"},
+				},
+			},
+		}
+
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("wrong schema\n%s", diff)
+		}
+	})
+	t.Run("conflicting labels from flattened message", func(t *testing.T) {
+		desc := testschema.File_testschema_proto.Messages().ByName("WithNestedBlockConflictingLabels")
+		_, err := bodySchema(desc)
+		if err == nil {
+			t.Fatalf("unexpected success")
+		}
+
+		var schemaErr schemaError
+		if !errors.As(err, &schemaErr) {
+			t.Fatalf("wrong error type %T; want schemaError", err)
+		}
+		if got, want := schemaErr.Decl, protoreflect.FullName("hcl.testschema.WithNestedBlockConflictingLabels.doodad"); got != want {
+			t.Errorf("wrong declaration\ngot:  %s\nwant: %s", got, want)
+		}
+		var innerErr schemaError
+		if !errors.As(schemaErr.Err, &innerErr) {
+			t.Fatalf("wrong nested error type %T; want schemaError", schemaErr.Err)
+		}
+		if got, want := innerErr.Decl, protoreflect.FullName("hcl.testschema.WithOneBlockLabel.name"); got != want {
+			t.Errorf("wrong nested declaration\ngot:  %s\nwant: %s", got, want)
+		}
+		if got, want := innerErr.Err.Error(), `block label name "name" conflicts with hcl.testschema.WithConflictingBlockLabels.name`; got != want {
+			t.Errorf("wrong nested error\ngot:  %s\nwant: %s", got, want)
+		}
+	})
+	t.Run("root-only message", func(t *testing.T) {
+		desc := testschema.File_testschema_proto.Messages().ByName("RootOnlyConfig")
+		got, err := bodySchema(desc)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		want := &hcl.BodySchema{
+			Attributes: []hcl.AttributeSchema{
+				{Name: "name"},
+			},
+		}
+
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("wrong schema\n%s", diff)
+		}
+	})
+	t.Run("root-only message as nested block type", func(t *testing.T) {
+		desc := testschema.File_testschema_proto.Messages().ByName("WithRootOnlyNestedBlock")
+		_, err := bodySchema(desc)
+		if err == nil {
+			t.Fatalf("unexpected success")
+		}
+
+		var schemaErr schemaError
+		if !errors.As(err, &schemaErr) {
+			t.Fatalf("wrong error type %T; want schemaError", err)
+		}
+		if got, want := schemaErr.Decl, protoreflect.FullName("hcl.testschema.WithRootOnlyNestedBlock.config"); got != want {
+			t.Errorf("wrong declaration\ngot:  %s\nwant: %s", got, want)
+		}
+		if got, want := schemaErr.Err.Error(), `can't use hcl.testschema.RootOnlyConfig as nested block type "config", because it is declared as root_only`; got != want {
+			t.Errorf("wrong error\ngot:  %s\nwant: %s", got, want)
+		}
+	})
+	t.Run("map of nested blocks", func(t *testing.T) {
+		desc := testschema.File_testschema_proto.Messages().ByName("WithMapOfBlocks")
+		got, err := bodySchema(desc)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		want := &hcl.BodySchema{
+			Blocks: []hcl.BlockHeaderSchema{
+				{
+					Type:       "pet",
+					LabelNames: []string{"key"},
+				},
+			},
+		}
+
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("wrong schema\n%s", diff)
+		}
+	})
+	t.Run("map of non-message values as nested blocks", func(t *testing.T) {
+		desc := testschema.File_testschema_proto.Messages().ByName("WithMapOfScalarsAsBlocks")
+		_, err := bodySchema(desc)
+		if err == nil {
+			t.Fatalf("unexpected success")
+		}
+
+		var schemaErr schemaError
+		if !errors.As(err, &schemaErr) {
+			t.Fatalf("wrong error type %T; want schemaError", err)
+		}
+		if got, want := schemaErr.Decl, protoreflect.FullName("hcl.testschema.WithMapOfScalarsAsBlocks.things"); got != want {
+			t.Errorf("wrong declaration\ngot:  %s\nwant: %s", got, want)
+		}
+		if got, want := schemaErr.Err.Error(), `map field representing nested block must have message values, not string`; got != want {
+			t.Errorf("wrong error\ngot:  %s\nwant: %s", got, want)
+		}
+	})
 }
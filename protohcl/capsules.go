@@ -0,0 +1,192 @@
+package protohcl
+
+import (
+	"fmt"
+
+	"github.com/zclconf/go-cty/cty"
+)
+
+// CapsuleEncodeFunc is the signature of a function that can convert a known,
+// non-null value of a particular capsule type into a value of some other type
+// that can be serialized for a raw-mode field.
+//
+// The result must not itself contain any capsule-typed values. If the result
+// of an encoding function might appear in a collection with other encoded
+// values, the function should always return values of the same type, because
+// collection elements must all share a single type.
+type CapsuleEncodeFunc func(val cty.Value) (cty.Value, error)
+
+// CapsuleCodecs is a registry of host-provided encoding functions for
+// capsule types, which allows capsule-typed values to appear in the values
+// assigned to raw-mode attributes.
+//
+// cty's JSON and MessagePack serializations don't support capsule types, so
+// by default a raw-mode attribute can't accept a value that includes one.
+// Including a registry in DecodeOptions.CapsuleCodecs causes the decoder to
+// replace each capsule-typed value with the result of the function registered
+// for its type before serializing.
+type CapsuleCodecs map[cty.Type]CapsuleEncodeFunc
+
+// Register adds an encoding function for the given capsule type, replacing
+// any function that was previously registered for that type.
+//
+// Register panics if the given type isn't a capsule type.
+func (c CapsuleCodecs) Register(ty cty.Type, encode CapsuleEncodeFunc) {
+	if !ty.IsCapsuleType() {
+		panic(fmt.Sprintf("can't register capsule codec for non-capsule type %#v", ty))
+	}
+	c[ty] = encode
+}
+
+// encodeCapsules returns a copy of the given value with any capsule-typed
+// values within it replaced with their encoded forms, as decided by the
+// registered encoding functions.
+//
+// The returned error, if any, is always a cty.PathError.
+func (c CapsuleCodecs) encodeCapsules(val cty.Value, path cty.Path) (cty.Value, error) {
+	ty := val.Type()
+	if !typeContainsCapsule(ty) {
+		return val, nil
+	}
+
+	switch {
+	case ty.IsCapsuleType():
+		encode, ok := c[ty]
+		if !ok {
+			return cty.NilVal, path.NewErrorf("%s values are not supported", ty.FriendlyName())
+		}
+		if val.IsNull() {
+			// We don't know what type the encoder would've produced, so
+			// we'll just use an untyped null instead.
+			return cty.NullVal(cty.DynamicPseudoType), nil
+		}
+		if !val.IsKnown() {
+			return cty.DynamicVal, nil
+		}
+		ret, err := encode(val)
+		if err != nil {
+			return cty.NilVal, path.NewError(err)
+		}
+		if typeContainsCapsule(ret.Type()) {
+			return cty.NilVal, path.NewErrorf("encoding of %s produced another capsule-typed value", ty.FriendlyName())
+		}
+		return ret, nil
+
+	case val.IsNull() || !val.IsKnown():
+		// We can't pass through a null or unknown value of a type containing
+		// capsule types, because the serializer would still reject its type.
+		// We'll use placeholders of the dynamic pseudo-type instead, losing
+		// the type information.
+		if val.IsNull() {
+			return cty.NullVal(cty.DynamicPseudoType), nil
+		}
+		return cty.DynamicVal, nil
+
+	case ty.IsListType() || ty.IsSetType():
+		elems := make([]cty.Value, 0, val.LengthInt())
+		for it := val.ElementIterator(); it.Next(); {
+			k, v := it.Element()
+			path := append(path, cty.IndexStep{Key: k})
+			v, err := c.encodeCapsules(v, path)
+			if err != nil {
+				return cty.NilVal, err
+			}
+			if len(elems) > 0 && !v.Type().Equals(elems[0].Type()) {
+				return cty.NilVal, path.NewErrorf("encoded value has type %s, but other elements have type %s", v.Type().FriendlyName(), elems[0].Type().FriendlyName())
+			}
+			elems = append(elems, v)
+		}
+		switch {
+		case len(elems) == 0:
+			// We can't know what element type the encoder would've produced,
+			// so we'll use an empty tuple, which can convert to any list
+			// or set type.
+			return cty.EmptyTupleVal, nil
+		case ty.IsSetType():
+			return cty.SetVal(elems), nil
+		default:
+			return cty.ListVal(elems), nil
+		}
+
+	case ty.IsMapType():
+		elems := make(map[string]cty.Value, val.LengthInt())
+		var ety cty.Type
+		for it := val.ElementIterator(); it.Next(); {
+			k, v := it.Element()
+			path := append(path, cty.IndexStep{Key: k})
+			v, err := c.encodeCapsules(v, path)
+			if err != nil {
+				return cty.NilVal, err
+			}
+			if ety != cty.NilType && !v.Type().Equals(ety) {
+				return cty.NilVal, path.NewErrorf("encoded value has type %s, but other elements have type %s", v.Type().FriendlyName(), ety.FriendlyName())
+			}
+			ety = v.Type()
+			elems[k.AsString()] = v
+		}
+		if len(elems) == 0 {
+			// As with lists, an empty object can convert to any map type.
+			return cty.EmptyObjectVal, nil
+		}
+		return cty.MapVal(elems), nil
+
+	case ty.IsTupleType():
+		elems := make([]cty.Value, 0, val.LengthInt())
+		for it := val.ElementIterator(); it.Next(); {
+			k, v := it.Element()
+			path := append(path, cty.IndexStep{Key: k})
+			v, err := c.encodeCapsules(v, path)
+			if err != nil {
+				return cty.NilVal, err
+			}
+			elems = append(elems, v)
+		}
+		return cty.TupleVal(elems), nil
+
+	case ty.IsObjectType():
+		attrs := make(map[string]cty.Value, len(ty.AttributeTypes()))
+		for it := val.ElementIterator(); it.Next(); {
+			k, v := it.Element()
+			path := append(path, cty.GetAttrStep{Name: k.AsString()})
+			v, err := c.encodeCapsules(v, path)
+			if err != nil {
+				return cty.NilVal, err
+			}
+			attrs[k.AsString()] = v
+		}
+		return cty.ObjectVal(attrs), nil
+
+	default:
+		// Should not get here, because the above should be exhaustive for
+		// all types that can contain capsule types.
+		return val, nil
+	}
+}
+
+// typeContainsCapsule returns true if the given type is a capsule type or
+// is a structural or collection type that has a capsule type somewhere
+// within it.
+func typeContainsCapsule(ty cty.Type) bool {
+	switch {
+	case ty.IsCapsuleType():
+		return true
+	case ty.IsListType() || ty.IsSetType() || ty.IsMapType():
+		return typeContainsCapsule(ty.ElementType())
+	case ty.IsTupleType():
+		for _, ety := range ty.TupleElementTypes() {
+			if typeContainsCapsule(ety) {
+				return true
+			}
+		}
+		return false
+	case ty.IsObjectType():
+		for _, aty := range ty.AttributeTypes() {
+			if typeContainsCapsule(aty) {
+				return true
+			}
+		}
+		return false
+	default:
+		return false
+	}
+}
@@ -0,0 +1,113 @@
+package protohcl
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/apparentlymart/go-protohcl/protohcl/internal/testschema"
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func TestDecodeOptionsCapsuleCodecs(t *testing.T) {
+	type fileHandle struct {
+		Name string
+	}
+	fileHandleType := cty.Capsule("file handle", reflect.TypeOf(fileHandle{}))
+	otherType := cty.Capsule("other thing", reflect.TypeOf(""))
+
+	codecs := CapsuleCodecs{}
+	codecs.Register(fileHandleType, func(val cty.Value) (cty.Value, error) {
+		fh := val.EncapsulatedValue().(*fileHandle)
+		return cty.StringVal(fh.Name), nil
+	})
+	opts := DecodeOptions{
+		CapsuleCodecs: codecs,
+	}
+
+	desc := testschema.File_testschema_proto.Messages().ByName(protoreflect.Name("WithRawDynamicAttr"))
+	ctx := &hcl.EvalContext{
+		Variables: map[string]cty.Value{
+			"file":  cty.CapsuleVal(fileHandleType, &fileHandle{Name: "foo.txt"}),
+			"other": cty.CapsuleVal(otherType, new(string)),
+		},
+	}
+
+	mustMarshal := func(v cty.Value) []byte {
+		t.Helper()
+		raw, err := FormatRawDynamic(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return raw
+	}
+
+	tests := map[string]struct {
+		config    string
+		want      *testschema.WithRawDynamicAttr
+		wantDiags hcl.Diagnostics
+	}{
+		"registered capsule type": {
+			`raw = { files = [file, file], count = 2 }`,
+			&testschema.WithRawDynamicAttr{
+				Raw: mustMarshal(cty.ObjectVal(map[string]cty.Value{
+					"files": cty.TupleVal([]cty.Value{
+						cty.StringVal("foo.txt"),
+						cty.StringVal("foo.txt"),
+					}),
+					"count": cty.NumberIntVal(2),
+				})),
+			},
+			nil,
+		},
+		"unregistered capsule type": {
+			`raw = { thing = other }`,
+			&testschema.WithRawDynamicAttr{},
+			hcl.Diagnostics{
+				{
+					Severity: hcl.DiagError,
+					Summary:  unsuitableValueSummary,
+					Detail:   `Inappropriate value for attribute "raw" at .thing: other thing values are not supported.`,
+					Subject: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 7, Byte: 6},
+						End:      hcl.Pos{Line: 1, Column: 24, Byte: 23},
+					},
+					Context: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+						End:      hcl.Pos{Line: 1, Column: 24, Byte: 23},
+					},
+					Extra: diagnosticExtra{Category: DiagnosticValueError},
+				},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			f, diags := hclsyntax.ParseConfig([]byte(test.config), "test.tf", hcl.InitialPos)
+			if diags.HasErrors() {
+				t.Fatalf("parse error: %s", diags)
+			}
+
+			got, diags := opts.DecodeBody(f.Body, desc, ctx)
+
+			if diff := cmp.Diff(test.want, got, protoCmpOpt); diff != "" {
+				t.Errorf("wrong result\n%s", diff)
+			}
+			for _, diag := range diags {
+				// The expression and context are not interesting for
+				// this test.
+				diag.Expression = nil
+				diag.EvalContext = nil
+			}
+			if diff := cmp.Diff(test.wantDiags, diags); diff != "" {
+				t.Errorf("wrong diagnostics\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package protohcl
+
+import (
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// ClaimedName is a name that the body described by a message descriptor
+// uses, as returned by ClaimedNames.
+type ClaimedName struct {
+	// Name is the name itself, including any prefix from
+	// (hcl.flatten_prefix).
+	Name string
+
+	// Kind is what the body uses the name for.
+	Kind ClaimedNameKind
+
+	// Field is the field that declares the name, which might belong to a
+	// message flattened into the body rather than to the body's own message.
+	Field protoreflect.FieldDescriptor
+
+	// Flattened is the chain of (hcl.flatten) fields, outermost first,
+	// through which Field contributes to the body. It's empty for the fields
+	// of the body's own message.
+	Flattened []protoreflect.FieldDescriptor
+
+	// Alias is true for a block type name that is one of the aliases from
+	// (hcl.block).aliases, rather than the block type's main name.
+	Alias bool
+}
+
+// ClaimedNameKind is the type of ClaimedName.Kind, which describes what a
+// body uses a name for.
+type ClaimedNameKind int
+
+const (
+	// ClaimedAttribute is a ClaimedNameKind for the name of an attribute.
+	ClaimedAttribute ClaimedNameKind = 1
+
+	// ClaimedBlockType is a ClaimedNameKind for the name of a nested block
+	// type, including its aliases.
+	ClaimedBlockType ClaimedNameKind = 2
+
+	// ClaimedBlockLabel is a ClaimedNameKind for the name of one of the
+	// labels of the block whose body the message describes.
+	//
+	// Block labels don't appear in the body itself, and so they can't
+	// conflict with the arguments and blocks that a host application
+	// interprets itself, but they do share a namespace with the body's own
+	// attributes and block types.
+	ClaimedBlockLabel ClaimedNameKind = 3
+)
+
+// ClaimedNames returns all of the names that the body described by the given
+// message descriptor uses for its attributes, nested block types, and block
+// labels, including those that its flattened messages contribute, along
+// with the fields that declare them.
+//
+// The result is in field declaration order, with the names from a
+// flattened message in place of the (hcl.flatten) field. ClaimedNames
+// doesn't include the names within nested blocks' own bodies, because they
+// are in separate namespaces.
+//
+// This is for hosts that want to check a schema from some other component,
+// such as a plugin, against names that the host reserves for its own use,
+// so that they can describe any collision in terms of the host's own
+// features. See also CheckReservedNames, which is a simpler way to reject a
+// schema that uses any given names.
+//
+// ClaimedNames returns an error only if the fields have invalid HCL
+// annotations. If two fields claim the same name then the result includes
+// both of them, even though decoding would fail with a schema error.
+func ClaimedNames(desc protoreflect.MessageDescriptor) ([]ClaimedName, error) {
+	return appendClaimedNames(nil, desc, "", nil, nil)
+}
+
+func appendClaimedNames(names []ClaimedName, desc protoreflect.MessageDescriptor, prefix string, shadowed map[string]struct{}, flattened []protoreflect.FieldDescriptor) ([]ClaimedName, error) {
+	fields := desc.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		elem, err := GetFieldElem(field)
+		if err != nil {
+			return nil, err // should already be a schemaError
+		}
+
+		claim := func(name string, kind ClaimedNameKind, alias bool) {
+			names = append(names, ClaimedName{
+				Name:      name,
+				Kind:      kind,
+				Field:     field,
+				Flattened: flattened,
+				Alias:     alias,
+			})
+		}
+		switch elem := withNamePrefix(elem, prefix).(type) {
+		case FieldAttribute:
+			if _, ok := shadowed[elem.Name]; ok {
+				// The containing message takes this name for itself.
+				continue
+			}
+			claim(elem.Name, ClaimedAttribute, false)
+		case FieldNestedBlockType:
+			claim(elem.TypeName, ClaimedBlockType, false)
+			for _, alias := range elem.Aliases {
+				claim(alias, ClaimedBlockType, true)
+			}
+		case FieldBlockLabel:
+			claim(elem.Name, ClaimedBlockLabel, false)
+		case FieldFlattened:
+			// We copy the chain so that the results for sibling fields
+			// don't share a backing array.
+			nestFlattened := make([]protoreflect.FieldDescriptor, len(flattened), len(flattened)+1)
+			copy(nestFlattened, flattened)
+			nestFlattened = append(nestFlattened, field)
+			nestShadowed := shadowed
+			if len(elem.Shadowed) != 0 {
+				nestShadowed = make(map[string]struct{}, len(shadowed)+len(elem.Shadowed))
+				for name := range shadowed {
+					nestShadowed[name] = struct{}{}
+				}
+				for name := range elem.Shadowed {
+					nestShadowed[name] = struct{}{}
+				}
+			}
+			names, err = appendClaimedNames(names, elem.Nested, elem.Prefix, nestShadowed, nestFlattened)
+			if err != nil {
+				return nil, err
+			}
+		default:
+			// Other fields are not relevant to HCL at all.
+		}
+	}
+	return names, nil
+}
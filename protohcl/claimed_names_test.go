@@ -0,0 +1,100 @@
+package protohcl
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/apparentlymart/go-protohcl/protohcl/internal/testschema"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func TestClaimedNames(t *testing.T) {
+	fileDesc := testschema.File_testschema_proto
+
+	// Each claimed name is summarized here as its kind, its name, the
+	// field that declares it, and the chain of flattened fields, if any.
+	tests := map[protoreflect.Name][]string{
+		"WithStringAttr": {
+			`attribute name: hcl.testschema.WithStringAttr.name`,
+		},
+		"WithFlattenedBlockLabel": {
+			`label type: hcl.testschema.WithFlattenedBlockLabel.type`,
+			`label name: hcl.testschema.WithOneBlockLabel.name via base`,
+			`attribute nickname: hcl.testschema.WithOneBlockLabel.nickname via base`,
+			`attribute species: hcl.testschema.WithFlattenedBlockLabel.species`,
+		},
+		"WithFlattenedBlockTypeAliases": {
+			`block type base_doodad: hcl.testschema.WithBlockTypeAliases.doodad via base`,
+			`block type alias base_gadget: hcl.testschema.WithBlockTypeAliases.doodad via base`,
+			`block type alias base_widget: hcl.testschema.WithBlockTypeAliases.doodad via base`,
+			`block type base_thing: hcl.testschema.WithBlockTypeAliases.thing via base`,
+			`block type alias base_old_thing: hcl.testschema.WithBlockTypeAliases.thing via base`,
+		},
+		"WithNestedFlattenConflict": {
+			// The outer message's "timeout" attribute shadows the one from
+			// the legacy message, even with the prefix.
+			`attribute outer_name: hcl.testschema.LegacySettings.name via outer.legacy`,
+			`attribute outer_timeout: hcl.testschema.WithFlattenConflictOuterWins.timeout via outer`,
+		},
+		"WithNestedBlockNoLabelsSingleton": {
+			`block type doodad: hcl.testschema.WithNestedBlockNoLabelsSingleton.doodad`,
+		},
+	}
+
+	for name, want := range tests {
+		t.Run(string(name), func(t *testing.T) {
+			desc := fileDesc.Messages().ByName(name)
+			if desc == nil {
+				t.Fatalf("no message type named %s", name)
+			}
+
+			names, err := ClaimedNames(desc)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			got := make([]string, len(names))
+			for i, claimed := range names {
+				got[i] = claimedNameSummary(claimed)
+			}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("wrong result\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestClaimedNamesInvalid(t *testing.T) {
+	desc := testschema.File_testschema_proto.Messages().ByName("WithInvalidFlattenPrefix")
+	_, err := ClaimedNames(desc)
+	if err == nil {
+		t.Fatal("unexpected success")
+	}
+}
+
+func claimedNameSummary(claimed ClaimedName) string {
+	var kind string
+	switch claimed.Kind {
+	case ClaimedAttribute:
+		kind = "attribute"
+	case ClaimedBlockType:
+		kind = "block type"
+	case ClaimedBlockLabel:
+		kind = "label"
+	default:
+		kind = fmt.Sprintf("invalid kind %d", claimed.Kind)
+	}
+	if claimed.Alias {
+		kind += " alias"
+	}
+	ret := fmt.Sprintf("%s %s: %s", kind, claimed.Name, claimed.Field.FullName())
+	if len(claimed.Flattened) != 0 {
+		via := make([]string, len(claimed.Flattened))
+		for i, field := range claimed.Flattened {
+			via[i] = string(field.Name())
+		}
+		ret += " via " + strings.Join(via, ".")
+	}
+	return ret
+}
@@ -0,0 +1,222 @@
+package protohcl
+
+import (
+	"testing"
+
+	"github.com/apparentlymart/go-protohcl/protohcl/internal/testschema"
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty-debug/ctydebug"
+	"github.com/zclconf/go-cty/cty"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func TestAttributeCollectionKind(t *testing.T) {
+	mustFormat := func(v cty.Value) []byte {
+		t.Helper()
+		raw, err := FormatRawDynamic(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return raw
+	}
+
+	tests := map[string]struct {
+		desc     protoreflect.Name
+		config   string
+		want     proto.Message
+		wantType cty.Type
+		wantVal  cty.Value
+	}{
+		"tuple": {
+			"WithTupleOfObjectsAttr",
+			`items = [{ raw = "a" }, { raw = 1 }]`,
+			&testschema.WithTupleOfObjectsAttr{
+				Items: []*testschema.WithRawDynamicAttr{
+					{Raw: mustFormat(cty.StringVal("a"))},
+					{Raw: mustFormat(cty.NumberIntVal(1))},
+				},
+			},
+			cty.Object(map[string]cty.Type{
+				"items": cty.DynamicPseudoType,
+			}),
+			cty.ObjectVal(map[string]cty.Value{
+				"items": cty.TupleVal([]cty.Value{
+					cty.ObjectVal(map[string]cty.Value{"raw": cty.StringVal("a")}),
+					cty.ObjectVal(map[string]cty.Value{"raw": cty.NumberIntVal(1)}),
+				}),
+			}),
+		},
+		"set": {
+			"WithSetOfObjectsAttr",
+			`items = [{ name = "b" }, { name = "a" }, { name = "b" }]`,
+			&testschema.WithSetOfObjectsAttr{
+				Items: []*testschema.WithStringAttr{
+					{Name: "a"},
+					{Name: "b"},
+				},
+			},
+			cty.Object(map[string]cty.Type{
+				// Attributes of message types used as attributes are
+				// always optional.
+				"items": cty.Set(cty.ObjectWithOptionalAttrs(map[string]cty.Type{
+					"name": cty.String,
+				}, []string{"name"})),
+			}),
+			cty.ObjectVal(map[string]cty.Value{
+				"items": cty.SetVal([]cty.Value{
+					cty.ObjectVal(map[string]cty.Value{"name": cty.StringVal("a")}),
+					cty.ObjectVal(map[string]cty.Value{"name": cty.StringVal("b")}),
+				}),
+			}),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			desc := testschema.File_testschema_proto.Messages().ByName(test.desc)
+			f, diags := hclsyntax.ParseConfig([]byte(test.config), "", hcl.InitialPos)
+			if diags.HasErrors() {
+				t.Fatalf("unexpected syntax errors: %s", diags.Error())
+			}
+
+			got, diags := DecodeBody(f.Body, desc, nil)
+			if diags.HasErrors() {
+				t.Fatalf("unexpected errors: %s", diags.Error())
+			}
+			if diff := cmp.Diff(test.want, got, protoCmpOpt); diff != "" {
+				t.Errorf("wrong result\n%s", diff)
+			}
+
+			gotType, err := ObjectTypeConstraintForMessageDesc(desc)
+			if err != nil {
+				t.Fatalf("unexpected error getting type constraint: %s", err)
+			}
+			if !test.wantType.Equals(gotType) {
+				t.Errorf("wrong type constraint\ngot:  %#v\nwant: %#v", gotType, test.wantType)
+			}
+
+			gotVal, err := ObjectValueForMessage(got)
+			if err != nil {
+				t.Fatalf("unexpected error getting value: %s", err)
+			}
+			if diff := cmp.Diff(test.wantVal, gotVal, ctydebug.CmpOptions); diff != "" {
+				t.Errorf("wrong value\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestAttributeCollectionKindErrors(t *testing.T) {
+	tests := map[protoreflect.Name]string{
+		"WithListOfDynamicObjectsAttr": `unsupported protobuf schema in hcl.testschema.WithListOfDynamicObjectsAttr.items: can't use (hcl.attr).kind = LIST with a message type containing an attribute with an 'any' constraint`,
+		"WithCollectionKindScalarAttr": `unsupported protobuf schema in hcl.testschema.WithCollectionKindScalarAttr.items: (hcl.attr).kind is allowed only for repeated fields of annotated message types`,
+	}
+	for name, want := range tests {
+		t.Run(string(name), func(t *testing.T) {
+			desc := testschema.File_testschema_proto.Messages().ByName(name)
+			_, err := GetFieldElem(desc.Fields().Get(0))
+			if err == nil {
+				t.Fatalf("unexpected success")
+			}
+			if got := err.Error(); got != want {
+				t.Errorf("wrong error\ngot:  %s\nwant: %s", got, want)
+			}
+		})
+	}
+}
+
+func TestBlockCollectionKindSharedBody(t *testing.T) {
+	// SharedBlockBody is the body of a LIST block type, a SET block type,
+	// and a TUPLE block type in different parents, and each of them must
+	// use its own field's kind regardless of which one we encounter first.
+	desc := testschema.File_testschema_proto.Messages().ByName("WithSharedBlockBodyKinds")
+	f, diags := hclsyntax.ParseConfig([]byte(`
+list {
+  item "b" { value = "1" }
+  item "a" { value = "2" }
+}
+set {
+  item "b" { value = "1" }
+  item "a" { value = "2" }
+}
+item "b" { value = "1" }
+`), "", hcl.InitialPos)
+	if diags.HasErrors() {
+		t.Fatalf("unexpected syntax errors: %s", diags.Error())
+	}
+
+	got, diags := DecodeBody(f.Body, desc, nil)
+	if diags.HasErrors() {
+		t.Fatalf("unexpected errors: %s", diags.Error())
+	}
+	b := &testschema.SharedBlockBody{Name: "b", Value: "1"}
+	a := &testschema.SharedBlockBody{Name: "a", Value: "2"}
+	want := &testschema.WithSharedBlockBodyKinds{
+		List: &testschema.WithSharedBlockBodyList{Item: []*testschema.SharedBlockBody{b, a}},
+		Set:  &testschema.WithSharedBlockBodySet{Item: []*testschema.SharedBlockBody{b, a}},
+		Item: []*testschema.SharedBlockBody{b},
+	}
+	if diff := cmp.Diff(want, got, protoCmpOpt); diff != "" {
+		t.Errorf("wrong result\n%s", diff)
+	}
+
+	bodyTy := cty.Object(map[string]cty.Type{
+		"name":  cty.String,
+		"value": cty.String,
+	})
+	wantTy := cty.Object(map[string]cty.Type{
+		"list": cty.Object(map[string]cty.Type{"item": cty.List(bodyTy)}),
+		"set":  cty.Object(map[string]cty.Type{"item": cty.Set(bodyTy)}),
+		"item": cty.DynamicPseudoType,
+	})
+	gotTy, err := ObjectTypeConstraintForMessageDesc(desc)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !gotTy.Equals(wantTy) {
+		t.Errorf("wrong type constraint\ngot:  %#v\nwant: %#v", gotTy, wantTy)
+	}
+
+	gotVal, err := ObjectValueForMessage(want)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	bVal := cty.ObjectVal(map[string]cty.Value{"name": cty.StringVal("b"), "value": cty.StringVal("1")})
+	aVal := cty.ObjectVal(map[string]cty.Value{"name": cty.StringVal("a"), "value": cty.StringVal("2")})
+	wantVal := cty.ObjectVal(map[string]cty.Value{
+		"list": cty.ObjectVal(map[string]cty.Value{"item": cty.ListVal([]cty.Value{bVal, aVal})}),
+		"set":  cty.ObjectVal(map[string]cty.Value{"item": cty.SetVal([]cty.Value{aVal, bVal})}),
+		"item": cty.TupleVal([]cty.Value{bVal}),
+	})
+	if diff := cmp.Diff(wantVal, gotVal, ctydebug.CmpOptions); diff != "" {
+		t.Errorf("wrong value\n%s", diff)
+	}
+}
+
+func TestBlockCollectionKindSharedBodyValidation(t *testing.T) {
+	// SharedDynamicBlockBody is valid as the body of a TUPLE block type but
+	// not a SET block type, and so we must report the problem with the SET
+	// field even though we already validated the message type for the
+	// TUPLE field.
+	desc := testschema.File_testschema_proto.Messages().ByName("WithSharedDynamicBlockBodyKinds")
+	var got []string
+	for _, problem := range ValidateMessageDesc(desc) {
+		if problem.Severity == SchemaProblemError {
+			got = append(got, string(problem.Decl)+": "+problem.Message)
+		}
+	}
+	want := []string{
+		"hcl.testschema.WithSharedDynamicBlockBodySet.item: can't use (hcl.block).kind = SET with a block type containing an attribute with an 'any' constraint",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("wrong problems\n%s", diff)
+	}
+
+	tupleDesc := testschema.File_testschema_proto.Messages().ByName("WithSharedDynamicBlockBodyTuple")
+	if problems := ValidateMessageDesc(tupleDesc); len(problems) != 0 {
+		t.Errorf("unexpected problems for the TUPLE block type: %#v", problems)
+	}
+}
@@ -0,0 +1,215 @@
+package protohcl
+
+import (
+	"sort"
+	"strings"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protopath"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// FieldComments describes the comments adjacent to the part of the
+// configuration that populated a field, with their comment markers removed.
+type FieldComments struct {
+	// Lead is the text of the comments on the lines immediately before the
+	// attribute, block, or element, with a newline between the text of each
+	// comment. A blank line ends a sequence of lead comments.
+	Lead string
+
+	// Line is the text of a comment after the attribute's value on the line
+	// where the value ends, or after the header of a block.
+	Line string
+}
+
+// CommentMap records the comments adjacent to each of the attributes and
+// nested blocks that populated the fields of a message, as returned by
+// DecodeSourceWithComments.
+//
+// This is intended for tools that work with configuration as a document,
+// such as to generate documentation from commented example configuration,
+// or to carry annotations written in comments into the decoded result.
+type CommentMap struct {
+	paths    []protopath.Path
+	comments map[string]FieldComments
+}
+
+// DecodeSourceWithComments parses the given native syntax source code and
+// decodes it as a message of the given type, like DecodeBody, but also
+// returns a CommentMap describing the comments adjacent to the attributes
+// and blocks that populated each field.
+//
+// Comments are only meaningful in the native syntax, and so the caller must
+// provide the source code rather than an already-parsed body. If the source
+// has syntax errors then DecodeSourceWithComments returns only the
+// diagnostics from parsing it, with a nil message and comment map.
+func DecodeSourceWithComments(src []byte, filename string, desc protoreflect.MessageDescriptor, ctx *hcl.EvalContext) (proto.Message, *CommentMap, hcl.Diagnostics) {
+	return DecodeOptions{}.DecodeSourceWithComments(src, filename, desc, ctx)
+}
+
+// DecodeSourceWithComments is like DecodeBodyWithTrace but parses the given
+// source code itself and returns a CommentMap, instead of a trace.
+//
+// See the package-level function DecodeSourceWithComments for more
+// information.
+func (opts DecodeOptions) DecodeSourceWithComments(src []byte, filename string, desc protoreflect.MessageDescriptor, ctx *hcl.EvalContext) (proto.Message, *CommentMap, hcl.Diagnostics) {
+	f, diags := hclsyntax.ParseConfig(src, filename, hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, nil, diags
+	}
+	// The parser already accepted this source, so lexing it can't fail.
+	toks, _ := hclsyntax.LexConfig(src, filename, hcl.InitialPos)
+
+	msg, trace, moreDiags := opts.DecodeBodyWithTrace(f.Body, desc, ctx)
+	diags = append(diags, moreDiags...)
+
+	comments := &CommentMap{
+		comments: make(map[string]FieldComments),
+	}
+	scanner := commentScanner{toks: toks}
+	for _, path := range trace.Paths() {
+		rng, _ := trace.Range(path)
+		if rng.Filename != filename {
+			continue
+		}
+		fc := FieldComments{
+			Lead: scanner.leadComments(rng),
+			Line: scanner.lineComment(rng),
+		}
+		if fc == (FieldComments{}) {
+			continue
+		}
+		comments.paths = append(comments.paths, path)
+		comments.comments[path.String()] = fc
+	}
+	return msg, comments, diags
+}
+
+// Comments returns the comments adjacent to the part of the configuration
+// that populated the field, list element, or map entry at the given path,
+// which must start with a protopath.Root step for the message type that was
+// decoded.
+//
+// For a field populated from an attribute, the comments are those adjacent
+// to the attribute. For a field populated from a nested block, they are
+// those adjacent to the block's header, and a field populated from one of
+// the block's labels has the same line comment as the block. The result is
+// the zero value of FieldComments for any path that has no adjacent
+// comments.
+func (m *CommentMap) Comments(path protopath.Path) FieldComments {
+	if m == nil {
+		return FieldComments{}
+	}
+	return m.comments[path.String()]
+}
+
+// Paths returns the paths that have at least one adjacent comment, in the
+// order that the decoder visited them.
+func (m *CommentMap) Paths() []protopath.Path {
+	if m == nil {
+		return nil
+	}
+	ret := make([]protopath.Path, len(m.paths))
+	copy(ret, m.paths)
+	return ret
+}
+
+// commentScanner finds the comments adjacent to source ranges in the tokens
+// of a single native syntax file.
+type commentScanner struct {
+	toks hclsyntax.Tokens
+}
+
+// tokenAt returns the index of the first token that starts at or after the
+// given byte offset.
+func (s commentScanner) tokenAt(offset int) int {
+	return sort.Search(len(s.toks), func(i int) bool {
+		return s.toks[i].Range.Start.Byte >= offset
+	})
+}
+
+// leadComments returns the text of the comments on the lines immediately
+// before the item starting at the given range, which is either the first
+// token on its line or the value of an attribute whose name is.
+func (s commentScanner) leadComments(rng hcl.Range) string {
+	k := s.tokenAt(rng.Start.Byte)
+	switch {
+	case k < len(s.toks) && s.startsLine(k):
+		// The item itself is at the start of the line.
+	case k >= 2 && (s.toks[k-1].Type == hclsyntax.TokenEqual || s.toks[k-1].Type == hclsyntax.TokenColon) && s.toks[k-2].Type == hclsyntax.TokenIdent && s.startsLine(k-2):
+		// The item is the value of an attribute or object element.
+		k -= 2
+	default:
+		return ""
+	}
+
+	var lines []string
+	for j := k - 1; ; {
+		c := -1
+		switch {
+		case j >= 0 && isLineComment(s.toks[j]):
+			c = j
+		case j >= 1 && s.toks[j].Type == hclsyntax.TokenNewline && s.toks[j-1].Type == hclsyntax.TokenComment && !isLineComment(s.toks[j-1]):
+			c = j - 1
+		}
+		if c < 0 || !s.startsLine(c) {
+			break
+		}
+		lines = append(lines, commentText(s.toks[c]))
+		j = c - 1
+	}
+
+	// We collected the comments in reverse order.
+	for i, j := 0, len(lines)-1; i < j; i, j = i+1, j-1 {
+		lines[i], lines[j] = lines[j], lines[i]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// lineComment returns the text of a comment immediately after the item
+// ending at the given range on the same line, allowing for a comma after
+// a collection element or the opening brace after a block header.
+func (s commentScanner) lineComment(rng hcl.Range) string {
+	i := s.tokenAt(rng.End.Byte)
+	for i < len(s.toks) && (s.toks[i].Type == hclsyntax.TokenComma || s.toks[i].Type == hclsyntax.TokenOBrace) {
+		i++
+	}
+	if i >= len(s.toks) || s.toks[i].Type != hclsyntax.TokenComment || s.toks[i].Range.Start.Line != rng.End.Line {
+		return ""
+	}
+	return commentText(s.toks[i])
+}
+
+// startsLine returns true if the token at the given index is the first
+// token on its line.
+func (s commentScanner) startsLine(i int) bool {
+	if i == 0 {
+		return true
+	}
+	prev := s.toks[i-1]
+	return prev.Type == hclsyntax.TokenNewline || isLineComment(prev) || prev.Range.End.Line < s.toks[i].Range.Start.Line
+}
+
+// isLineComment returns true if the given token is a comment that extends to
+// the end of its line, in which case the lexer includes the newline in the
+// comment token rather than producing a separate newline token.
+func isLineComment(tok hclsyntax.Token) bool {
+	return tok.Type == hclsyntax.TokenComment && len(tok.Bytes) > 0 && tok.Bytes[len(tok.Bytes)-1] == '\n'
+}
+
+// commentText returns the text of the given comment token without its
+// comment markers or surrounding whitespace.
+func commentText(tok hclsyntax.Token) string {
+	text := strings.TrimSpace(string(tok.Bytes))
+	switch {
+	case strings.HasPrefix(text, "#"):
+		text = text[1:]
+	case strings.HasPrefix(text, "//"):
+		text = text[2:]
+	case strings.HasPrefix(text, "/*"):
+		text = strings.TrimSuffix(text[2:], "*/")
+	}
+	return strings.TrimSpace(text)
+}
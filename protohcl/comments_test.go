@@ -0,0 +1,128 @@
+package protohcl
+
+import (
+	"testing"
+
+	"github.com/apparentlymart/go-protohcl/protohcl/internal/testschema"
+	"github.com/apparentlymart/go-protohcl/protohcl/protohcltest"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func TestDecodeSourceWithComments(t *testing.T) {
+	tests := map[string]struct {
+		msgName   protoreflect.Name
+		config    string
+		want      map[string]FieldComments
+		wantDiags []protohcltest.ExpectedDiagnostic
+	}{
+		"attributes and blocks": {
+			"Root",
+			`
+# The name of the thing.
+// Second line.
+name = "Jackson" # inline
+
+/* count */
+count = 2
+
+# Detached from the block below.
+
+thing "a" { # first thing
+}
+`,
+			map[string]FieldComments{
+				"(hcl.testschema.Root).name": {
+					Lead: "The name of the thing.\nSecond line.",
+					Line: "inline",
+				},
+				// A block's labels end on the same line as its header, and so
+				// they have the same line comment.
+				"(hcl.testschema.Root).things[0].name": {
+					Line: "first thing",
+				},
+				"(hcl.testschema.Root).things[0]": {
+					Line: "first thing",
+				},
+				"(hcl.testschema.Root).more.count": {
+					Lead: "count",
+				},
+			},
+			nil,
+		},
+		"list elements": {
+			"WithStringListAttr",
+			`
+names = [
+  # The first one.
+  "Jackson",
+  "Rufus", # The second one.
+  "Agnes" /* The third one. */,
+] # all of them
+`,
+			map[string]FieldComments{
+				"(hcl.testschema.WithStringListAttr).names": {
+					Line: "all of them",
+				},
+				"(hcl.testschema.WithStringListAttr).names[0]": {
+					Lead: "The first one.",
+				},
+				"(hcl.testschema.WithStringListAttr).names[1]": {
+					Line: "The second one.",
+				},
+				"(hcl.testschema.WithStringListAttr).names[2]": {
+					Line: "The third one.",
+				},
+			},
+			nil,
+		},
+		"no comments": {
+			"WithStringAttr",
+			`name = "Jackson"`,
+			map[string]FieldComments{},
+			nil,
+		},
+		"decode errors": {
+			"Root",
+			`
+# The count.
+count = 2
+`,
+			map[string]FieldComments{
+				"(hcl.testschema.Root).more.count": {
+					Lead: "The count.",
+				},
+			},
+			[]protohcltest.ExpectedDiagnostic{
+				protohcltest.Error("Missing required argument"),
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			desc := testschema.File_testschema_proto.Messages().ByName(test.msgName)
+			_, comments, diags := DecodeSourceWithComments([]byte(test.config), "test.hcl", desc, nil)
+			protohcltest.AssertDiagnostics(t, diags, test.wantDiags...)
+
+			got := make(map[string]FieldComments)
+			for _, path := range comments.Paths() {
+				got[path.String()] = comments.Comments(path)
+			}
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("wrong comments\n%s", diff)
+			}
+		})
+	}
+
+	t.Run("syntax error", func(t *testing.T) {
+		desc := testschema.File_testschema_proto.Messages().ByName("Root")
+		msg, comments, diags := DecodeSourceWithComments([]byte(`name = `), "test.hcl", desc, nil)
+		if !diags.HasErrors() {
+			t.Fatalf("unexpected success")
+		}
+		if msg != nil || comments != nil {
+			t.Errorf("unexpected results after syntax error")
+		}
+	})
+}
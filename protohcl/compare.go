@@ -0,0 +1,42 @@
+package protohcl
+
+import (
+	"google.golang.org/protobuf/proto"
+)
+
+// MessagesEquivalent returns true if the two given messages have equivalent
+// content from the perspective of HCL, meaning that ObjectValueForMessage
+// would return equal values for both.
+//
+// This disregards differences that arise only from the protobuf encoding,
+// such as the order of elements in a repeated field representing a set, the
+// exact string formatting of a number stored in a string field, and the
+// raw encoding used for a dynamic attribute. It also disregards any fields
+// that have no HCL annotations.
+//
+// Messages of different message types are never equivalent, even if their
+// HCL-visible content is the same.
+//
+// MessagesEquivalent returns an error if either message has invalid HCL
+// options or if either contains a value that can't be converted to HCL.
+func MessagesEquivalent(a, b proto.Message) (bool, error) {
+	aDesc := a.ProtoReflect().Descriptor()
+	bDesc := b.ProtoReflect().Descriptor()
+	if aDesc.FullName() != bDesc.FullName() {
+		return false, nil
+	}
+
+	aObj, err := ObjectValueForMessage(a)
+	if err != nil {
+		return false, err
+	}
+	bObj, err := ObjectValueForMessage(b)
+	if err != nil {
+		return false, err
+	}
+
+	// We use RawEquals rather than Equals here because we want to treat
+	// two unknown values of the same type as equivalent, whereas Equals
+	// would return an unknown result in that case.
+	return aObj.RawEquals(bObj), nil
+}
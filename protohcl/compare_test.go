@@ -0,0 +1,72 @@
+package protohcl
+
+import (
+	"testing"
+
+	"github.com/apparentlymart/go-protohcl/protohcl/internal/testschema"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestMessagesEquivalent(t *testing.T) {
+	tests := map[string]struct {
+		a, b proto.Message
+		want bool
+	}{
+		"identical": {
+			&testschema.WithStringAttr{Name: "Jackson"},
+			&testschema.WithStringAttr{Name: "Jackson"},
+			true,
+		},
+		"different string": {
+			&testschema.WithStringAttr{Name: "Jackson"},
+			&testschema.WithStringAttr{Name: "Snakob"},
+			false,
+		},
+		"different message types": {
+			&testschema.WithStringAttr{Name: "Jackson"},
+			&testschema.WithOneBlockLabel{Nickname: "Jackson"},
+			false,
+		},
+		"number formatting in string field": {
+			&testschema.WithNumberAttrAsString{Num: "1e3"},
+			&testschema.WithNumberAttrAsString{Num: "1000"},
+			true,
+		},
+		"set element order and duplicates": {
+			&testschema.WithStringSetAttr{Names: []string{"Jackson", "Rufus", "Jackson"}},
+			&testschema.WithStringSetAttr{Names: []string{"Rufus", "Jackson"}},
+			true,
+		},
+		"list element order": {
+			&testschema.WithStringListAttr{Names: []string{"Jackson", "Rufus"}},
+			&testschema.WithStringListAttr{Names: []string{"Rufus", "Jackson"}},
+			false,
+		},
+		"set-kind nested block order": {
+			&testschema.WithNestedBlockNoLabelsRepeated{
+				Doodad: []*testschema.WithStringAttr{{Name: "a"}, {Name: "b"}},
+			},
+			&testschema.WithNestedBlockNoLabelsRepeated{
+				Doodad: []*testschema.WithStringAttr{{Name: "b"}, {Name: "a"}},
+			},
+			true,
+		},
+		"raw encoding formatting": {
+			&testschema.WithRawDynamicAttr{Raw: []byte(`{"value":[1,2],"type":["tuple",["number","number"]]}`)},
+			&testschema.WithRawDynamicAttr{Raw: []byte(`{"type":["tuple",["number","number"]],"value":[1,2.0]}`)},
+			true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := MessagesEquivalent(test.a, test.b)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got != test.want {
+				t.Errorf("wrong result %t; want %t", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,315 @@
+// Package conformance provides a corpus of examples of decoding HCL
+// configuration into protobuf messages annotated with the options from
+// hcl.proto, along with the result that protohcl produces for each one.
+//
+// The corpus exists so that other implementations of the hcl.proto
+// annotations, including those in other languages, can check that they
+// interpret the annotations in the same way as protohcl does. The corpus
+// files are in the "corpus" subdirectory of this package's directory, and
+// its README.md file describes their format for readers that can't use this
+// package directly.
+//
+// Go implementations can instead use Default to load the copy of the corpus
+// that is embedded in this package, and then call Case.Check with the result
+// of decoding each case.
+package conformance
+
+import (
+	"bufio"
+	"bytes"
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+//go:embed corpus
+var corpusFS embed.FS
+
+// DescriptorsFilename is the name of the file in a corpus directory that
+// contains the descriptors of the message types that its cases use, as a
+// serialized google.protobuf.FileDescriptorSet message.
+const DescriptorsFilename = "descriptors.pb"
+
+// Corpus is a set of conformance test cases along with the descriptors of
+// the message types that they decode into.
+type Corpus struct {
+	// Files contains all of the file descriptors from the corpus's
+	// descriptor set.
+	Files *protoregistry.Files
+
+	// Cases are the corpus's test cases, in lexical order by name.
+	Cases []*Case
+}
+
+// Case is a single conformance test case: a configuration file to decode
+// into a particular message type, along with either the message that
+// decoding must produce or the errors that it must report.
+type Case struct {
+	// Name is the case's name, which is the name of its configuration file
+	// without the ".hcl" suffix.
+	Name string
+
+	// Filename is the name of the case's configuration file, which
+	// implementations should use as the filename when parsing Config so
+	// that any source ranges recorded in the result will match.
+	Filename string
+
+	// Message is the message type to decode Config into.
+	Message protoreflect.MessageDescriptor
+
+	// Config is the content of the configuration file, in HCL native
+	// syntax.
+	Config []byte
+
+	// Want is the message that decoding must produce, or nil if decoding
+	// must fail with the errors given in WantErrors.
+	Want proto.Message
+
+	// WantErrors are the errors that decoding must report, or nil if
+	// decoding must succeed.
+	WantErrors []Error
+}
+
+// Error describes an error that decoding must report, in terms that don't
+// depend on the exact wording of the error message.
+type Error struct {
+	// Category is the name of the error's category, as returned by
+	// protohcl.DiagnosticCategory.String.
+	Category string
+
+	// Line and Column are the position of the start of the error's subject
+	// in the configuration, using the same counting as hcl.Pos, or zero if
+	// the error has no subject.
+	Line, Column int
+}
+
+func (e Error) String() string {
+	return fmt.Sprintf("%s %d:%d", e.Category, e.Line, e.Column)
+}
+
+// Default returns the corpus that is embedded in this package.
+func Default() (*Corpus, error) {
+	fsys, err := fs.Sub(corpusFS, "corpus")
+	if err != nil {
+		return nil, err
+	}
+	return Load(fsys)
+}
+
+// Load reads a corpus from the given filesystem, which must have the layout
+// described in the README.md file of the corpus that is embedded in this
+// package.
+func Load(fsys fs.FS) (*Corpus, error) {
+	src, err := fs.ReadFile(fsys, DescriptorsFilename)
+	if err != nil {
+		return nil, err
+	}
+	var set descriptorpb.FileDescriptorSet
+	if err := proto.Unmarshal(src, &set); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", DescriptorsFilename, err)
+	}
+	files, err := protodesc.NewFiles(&set)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", DescriptorsFilename, err)
+	}
+
+	filenames, err := fs.Glob(fsys, "cases/*.hcl")
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(filenames)
+
+	corpus := &Corpus{Files: files}
+	for _, filename := range filenames {
+		c, err := loadCase(fsys, files, filename)
+		if err != nil {
+			return nil, fmt.Errorf("invalid case %s: %w", filename, err)
+		}
+		corpus.Cases = append(corpus.Cases, c)
+	}
+	return corpus, nil
+}
+
+func loadCase(fsys fs.FS, files *protoregistry.Files, filename string) (*Case, error) {
+	config, err := fs.ReadFile(fsys, filename)
+	if err != nil {
+		return nil, err
+	}
+
+	// The first line of the configuration is a comment naming the message
+	// type to decode into. It's part of the configuration too, so decoders
+	// will just ignore it.
+	header := config
+	if nl := bytes.IndexByte(header, '\n'); nl >= 0 {
+		header = header[:nl]
+	}
+	const prefix = "# message: "
+	if !bytes.HasPrefix(header, []byte(prefix)) {
+		return nil, fmt.Errorf("first line must be a %q comment", strings.TrimSpace(prefix))
+	}
+	msgName := protoreflect.FullName(strings.TrimSpace(string(header[len(prefix):])))
+	d, err := files.FindDescriptorByName(msgName)
+	if err != nil {
+		// The registry's error text is intentionally unstable, so we don't
+		// include it here. Not finding the name is the only possible error.
+		return nil, fmt.Errorf("the descriptors don't declare message type %s", msgName)
+	}
+	desc, ok := d.(protoreflect.MessageDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a message type", msgName)
+	}
+
+	base := strings.TrimSuffix(filename, ".hcl")
+	c := &Case{
+		Name:     path.Base(base),
+		Filename: path.Base(filename),
+		Message:  desc,
+		Config:   config,
+	}
+
+	wantSrc, wantErr := fs.ReadFile(fsys, base+".txtpb")
+	errorsSrc, errorsErr := fs.ReadFile(fsys, base+".errors")
+	switch {
+	case wantErr == nil && errorsErr == nil:
+		return nil, fmt.Errorf("must have either a .txtpb file or a .errors file, not both")
+	case wantErr == nil:
+		want := dynamicpb.NewMessage(desc)
+		if err := prototext.Unmarshal(wantSrc, want); err != nil {
+			return nil, fmt.Errorf("invalid %s.txtpb: %w", c.Name, err)
+		}
+		c.Want = want
+	case errorsErr == nil:
+		c.WantErrors, err = parseErrors(errorsSrc)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s.errors: %w", c.Name, err)
+		}
+		if len(c.WantErrors) == 0 {
+			return nil, fmt.Errorf("%s.errors doesn't describe any errors", c.Name)
+		}
+	default:
+		return nil, fmt.Errorf("must have either a .txtpb file or a .errors file")
+	}
+	return c, nil
+}
+
+// parseErrors parses the content of a .errors file, which has one error per
+// line in the format that Error.String produces. Empty lines and lines
+// starting with "#" are ignored.
+func parseErrors(src []byte) ([]Error, error) {
+	var ret []Error
+	sc := bufio.NewScanner(bytes.NewReader(src))
+	for lineNum := 1; sc.Scan(); lineNum++ {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("line %d: must be a category and a LINE:COLUMN position", lineNum)
+		}
+		pos := strings.SplitN(fields[1], ":", 2)
+		if len(pos) != 2 {
+			return nil, fmt.Errorf("line %d: invalid position %q", lineNum, fields[1])
+		}
+		l, lErr := strconv.Atoi(pos[0])
+		c, cErr := strconv.Atoi(pos[1])
+		if lErr != nil || cErr != nil {
+			return nil, fmt.Errorf("line %d: invalid position %q", lineNum, fields[1])
+		}
+		ret = append(ret, Error{Category: fields[0], Line: l, Column: c})
+	}
+	return ret, sc.Err()
+}
+
+// Check compares the result of decoding the case's configuration with the
+// expected result, returning an error describing any difference.
+//
+// got is the decoded message, which can be nil if decoding failed, and
+// gotErrors are the errors that decoding reported, in any order. Check
+// ignores any warnings, and so callers should not include them in
+// gotErrors.
+//
+// got doesn't need to be of the corpus's own dynamic message type, because
+// Check compares messages by their wire encoding. It can therefore be of a
+// generated message type with the same definition.
+func (c *Case) Check(got proto.Message, gotErrors []Error) error {
+	if c.WantErrors != nil {
+		if len(gotErrors) == 0 {
+			return fmt.Errorf("unexpected success; want errors:\n%s", formatErrors(c.WantErrors))
+		}
+		gotErrors = sortErrors(gotErrors)
+		wantErrors := sortErrors(c.WantErrors)
+		if !errorsEqual(gotErrors, wantErrors) {
+			return fmt.Errorf("wrong errors\ngot:\n%swant:\n%s", formatErrors(gotErrors), formatErrors(wantErrors))
+		}
+		return nil
+	}
+
+	if len(gotErrors) != 0 {
+		return fmt.Errorf("unexpected errors:\n%s", formatErrors(sortErrors(gotErrors)))
+	}
+	if got == nil {
+		return fmt.Errorf("no result message")
+	}
+	raw, err := proto.MarshalOptions{Deterministic: true}.Marshal(got)
+	if err != nil {
+		return fmt.Errorf("can't encode result: %w", err)
+	}
+	norm := dynamicpb.NewMessage(c.Message)
+	if err := proto.Unmarshal(raw, norm); err != nil {
+		return fmt.Errorf("result is not compatible with %s: %w", c.Message.FullName(), err)
+	}
+	if !proto.Equal(norm, c.Want) {
+		opts := prototext.MarshalOptions{Multiline: true}
+		return fmt.Errorf("wrong result\ngot:\n%s\nwant:\n%s", opts.Format(norm), opts.Format(c.Want))
+	}
+	return nil
+}
+
+func sortErrors(errs []Error) []Error {
+	ret := make([]Error, len(errs))
+	copy(ret, errs)
+	sort.SliceStable(ret, func(i, j int) bool {
+		a, b := ret[i], ret[j]
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		if a.Column != b.Column {
+			return a.Column < b.Column
+		}
+		return a.Category < b.Category
+	})
+	return ret
+}
+
+func errorsEqual(a, b []Error) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func formatErrors(errs []Error) string {
+	var buf strings.Builder
+	for _, e := range errs {
+		fmt.Fprintf(&buf, "  %s\n", e)
+	}
+	return buf.String()
+}
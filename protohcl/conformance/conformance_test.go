@@ -0,0 +1,172 @@
+package conformance
+
+import (
+	"io/fs"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/apparentlymart/go-protohcl/protohcl/internal/testschema"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestLoad(t *testing.T) {
+	descs, err := fs.ReadFile(corpusFS, "corpus/"+DescriptorsFilename)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		files   map[string]string
+		wantErr string
+	}{
+		"success": {
+			map[string]string{
+				"cases/a.hcl":    "# message: hcl.testschema.WithStringAttr\nname = \"a\"\n",
+				"cases/a.txtpb":  `name: "a"`,
+				"cases/b.hcl":    "# message: hcl.testschema.WithStringAttr\n",
+				"cases/b.errors": "# comment\n\nunsupported 2:1\n",
+			},
+			``,
+		},
+		"no message comment": {
+			map[string]string{
+				"cases/a.hcl":   "name = \"a\"\n",
+				"cases/a.txtpb": `name: "a"`,
+			},
+			`invalid case cases/a.hcl: first line must be a "# message:" comment`,
+		},
+		"unknown message": {
+			map[string]string{
+				"cases/a.hcl":   "# message: hcl.testschema.Nonexistent\n",
+				"cases/a.txtpb": ``,
+			},
+			`invalid case cases/a.hcl: the descriptors don't declare message type hcl.testschema.Nonexistent`,
+		},
+		"no expectation": {
+			map[string]string{
+				"cases/a.hcl": "# message: hcl.testschema.WithStringAttr\n",
+			},
+			`invalid case cases/a.hcl: must have either a .txtpb file or a .errors file`,
+		},
+		"both expectations": {
+			map[string]string{
+				"cases/a.hcl":    "# message: hcl.testschema.WithStringAttr\n",
+				"cases/a.txtpb":  ``,
+				"cases/a.errors": "unsupported 2:1\n",
+			},
+			`invalid case cases/a.hcl: must have either a .txtpb file or a .errors file, not both`,
+		},
+		"invalid error position": {
+			map[string]string{
+				"cases/a.hcl":    "# message: hcl.testschema.WithStringAttr\n",
+				"cases/a.errors": "unsupported here\n",
+			},
+			`invalid case cases/a.hcl: invalid a.errors: line 1: invalid position "here"`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			fsys := fstest.MapFS{
+				DescriptorsFilename: &fstest.MapFile{Data: descs},
+			}
+			for name, src := range test.files {
+				fsys[name] = &fstest.MapFile{Data: []byte(src)}
+			}
+
+			corpus, err := Load(fsys)
+			if test.wantErr != "" {
+				if err == nil {
+					t.Fatalf("unexpected success; want error: %s", test.wantErr)
+				}
+				if got := err.Error(); got != test.wantErr {
+					t.Fatalf("wrong error\ngot:  %s\nwant: %s", got, test.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got, want := len(corpus.Cases), 2; got != want {
+				t.Fatalf("wrong number of cases %d; want %d", got, want)
+			}
+			if got, want := corpus.Cases[1].WantErrors, []Error{{"unsupported", 2, 1}}; len(got) != 1 || got[0] != want[0] {
+				t.Errorf("wrong errors %#v; want %#v", got, want)
+			}
+		})
+	}
+}
+
+func TestCaseCheck(t *testing.T) {
+	corpus, err := Default()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cases := make(map[string]*Case)
+	for _, c := range corpus.Cases {
+		cases[c.Name] = c
+	}
+
+	tests := map[string]struct {
+		c         *Case
+		got       proto.Message
+		gotErrors []Error
+		wantErr   string
+	}{
+		"generated message type": {
+			cases["string_attr"],
+			&testschema.WithStringAttr{Name: "Jackson"},
+			nil,
+			``,
+		},
+		"wrong message": {
+			cases["string_attr"],
+			&testschema.WithStringAttr{Name: "Jack"},
+			nil,
+			`wrong result`,
+		},
+		"unexpected errors": {
+			cases["string_attr"],
+			nil,
+			[]Error{{"value-error", 2, 8}},
+			`unexpected errors:`,
+		},
+		"errors in any order": {
+			&Case{WantErrors: []Error{{"conflict", 1, 1}, {"unsupported", 1, 1}}},
+			nil,
+			[]Error{{"unsupported", 1, 1}, {"conflict", 1, 1}},
+			``,
+		},
+		"wrong errors": {
+			cases["unsupported_attr"],
+			nil,
+			[]Error{{"unsupported", 2, 1}},
+			`wrong errors`,
+		},
+		"unexpected success": {
+			cases["unsupported_attr"],
+			&testschema.WithStringAttr{Name: "a"},
+			nil,
+			`unexpected success`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := test.c.Check(test.got, test.gotErrors)
+			if test.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("unexpected success; want error starting with %q", test.wantErr)
+			}
+			if got := err.Error(); !strings.HasPrefix(got, test.wantErr) {
+				t.Errorf("wrong error\ngot:  %s\nwant prefix: %s", got, test.wantErr)
+			}
+		})
+	}
+}
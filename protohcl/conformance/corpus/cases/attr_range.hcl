@@ -0,0 +1,2 @@
+# message: hcl.testschema.WithAttrRange
+name = "a"
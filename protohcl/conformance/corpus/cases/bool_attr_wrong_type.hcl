@@ -0,0 +1,2 @@
+# message: hcl.testschema.WithBoolAttr
+do_the_thing = ["yes"]
@@ -0,0 +1,2 @@
+# message: hcl.testschema.WithEnumAttr
+level = "info"
@@ -0,0 +1,4 @@
+# message: hcl.testschema.WithNestedFlattenStringAttr
+name  = "Fido"
+species = "dog"
+breed = "beagle"
@@ -0,0 +1,5 @@
+# message: hcl.testschema.WithListOfObjectsAttr
+items = [
+  { name = "a", count = 2 },
+  { name = "b" },
+]
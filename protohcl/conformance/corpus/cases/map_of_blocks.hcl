@@ -0,0 +1,5 @@
+# message: hcl.testschema.WithMapOfBlocks
+pet "fido" {
+  name = "Fido"
+}
+pet "rex" {}
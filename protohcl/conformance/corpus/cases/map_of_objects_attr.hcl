@@ -0,0 +1,4 @@
+# message: hcl.testschema.WithMapOfObjectsAttr
+pets = {
+  fido = { name = "Fido" }
+}
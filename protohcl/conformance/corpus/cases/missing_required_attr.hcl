@@ -0,0 +1,2 @@
+# message: hcl.testschema.WithOptionalAttrs
+count = 1
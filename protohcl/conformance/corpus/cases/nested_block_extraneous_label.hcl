@@ -0,0 +1,2 @@
+# message: hcl.testschema.WithNestedBlockNoLabelsSingleton
+doodad "a" {}
@@ -0,0 +1,4 @@
+# message: hcl.testschema.WithNestedBlockNoLabelsSingleton
+doodad {
+  name = "a"
+}
@@ -0,0 +1,3 @@
+# message: hcl.testschema.WithNestedBlockNoLabelsSingleton
+doodad {}
+doodad {}
@@ -0,0 +1,5 @@
+# message: hcl.testschema.WithNestedBlockTwoLabelRepeated
+doodad "a" "b" {
+  nickname = "ab"
+}
+doodad "c" "d" {}
@@ -0,0 +1,2 @@
+# message: hcl.testschema.WithNumberAttrAsString
+num = 1.5
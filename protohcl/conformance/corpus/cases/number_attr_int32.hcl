@@ -0,0 +1,2 @@
+# message: hcl.testschema.WithNumberAttrAsInt32
+num = 12
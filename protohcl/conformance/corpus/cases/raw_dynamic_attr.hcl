@@ -0,0 +1,2 @@
+# message: hcl.testschema.WithRawDynamicAttr
+raw = { a = 1 }
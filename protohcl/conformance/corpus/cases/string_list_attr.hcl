@@ -0,0 +1,2 @@
+# message: hcl.testschema.WithStringListAttr
+names = ["a", "b", "a"]
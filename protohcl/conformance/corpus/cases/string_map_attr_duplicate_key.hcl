@@ -0,0 +1,5 @@
+# message: hcl.testschema.WithStringMapAttr
+names = {
+  a   = "x"
+  "a" = "y"
+}
@@ -0,0 +1,2 @@
+# message: hcl.testschema.WithStringSetAttr
+names = ["b", "a", "b"]
@@ -0,0 +1,2 @@
+# message: hcl.testschema.WithStructDynamicAttr
+struct = ["a", true]
@@ -0,0 +1,4 @@
+# message: hcl.testschema.WithUnitAttrs
+max_size  = "10KiB"
+timeout   = "250ms"
+intervals = [1, "1m"]
@@ -0,0 +1,3 @@
+# message: hcl.testschema.WithStringAttr
+name = "a"
+extra = true
@@ -0,0 +1,77 @@
+package protohcl
+
+import (
+	"bytes"
+	"flag"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/apparentlymart/go-protohcl/protohcl/conformance"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"google.golang.org/protobuf/proto"
+)
+
+var updateConformance = flag.Bool("update-conformance", false, "rewrite the conformance corpus descriptors from the test schema")
+
+func TestConformance(t *testing.T) {
+	corpus, err := conformance.Default()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, c := range corpus.Cases {
+		t.Run(c.Name, func(t *testing.T) {
+			f, diags := hclsyntax.ParseConfig(c.Config, c.Filename, hcl.InitialPos)
+			if diags.HasErrors() {
+				t.Fatalf("unexpected syntax errors: %s", diags.Error())
+			}
+
+			// We decode into the corpus's own dynamic message type, rather
+			// than into testschema's generated type, so that we're using
+			// the same descriptors that other implementations would use.
+			got, diags := DecodeBody(f.Body, c.Message, nil)
+			var gotErrors []conformance.Error
+			for _, diag := range diags {
+				if diag.Severity != hcl.DiagError {
+					continue
+				}
+				e := conformance.Error{Category: DiagnosticCategoryOf(diag).String()}
+				if diag.Subject != nil {
+					e.Line = diag.Subject.Start.Line
+					e.Column = diag.Subject.Start.Column
+				}
+				gotErrors = append(gotErrors, e)
+			}
+			if err := c.Check(got, gotErrors); err != nil {
+				t.Errorf("%s\ndiagnostics: %s", err, diags.Error())
+			}
+		})
+	}
+}
+
+// TestConformanceDescriptors checks that the descriptors in the conformance
+// corpus match the test schema, which is the source of the corpus's message
+// types. Run the test with -update-conformance to rewrite the file after
+// changing the test schema.
+func TestConformanceDescriptors(t *testing.T) {
+	want, err := proto.MarshalOptions{Deterministic: true}.Marshal(testDescriptorSet())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	filename := filepath.Join("conformance", "corpus", conformance.DescriptorsFilename)
+	if *updateConformance {
+		if err := ioutil.WriteFile(filename, want, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	got, err := ioutil.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("%s is out of date; run this test with -update-conformance", filename)
+	}
+}
@@ -0,0 +1,98 @@
+package protohcl
+
+import (
+	"fmt"
+	"sort"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protopath"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// DecodeAttributes decodes the given attributes into a message that conforms
+// to the given message descriptor.
+//
+// This is for callers that consume a body using hcl.Body.JustAttributes,
+// such as for map-like sections of configuration where block syntax isn't
+// meaningful. Only the attribute-annotated fields of the message, including
+// those of any flattened messages, can be populated. Any nested block type
+// fields are left unset.
+//
+// DecodeAttributes returns error diagnostics for any attributes that don't
+// correspond to an attribute-annotated field, and for any required
+// attributes that are not present.
+func DecodeAttributes(attrs hcl.Attributes, desc protoreflect.MessageDescriptor, ctx *hcl.EvalContext) (proto.Message, hcl.Diagnostics) {
+	return DecodeOptions{}.DecodeAttributes(attrs, desc, ctx)
+}
+
+func (opts DecodeOptions) decodeAttributes(attrs hcl.Attributes, desc protoreflect.MessageDescriptor, ctx *hcl.EvalContext) (proto.Message, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+
+	schema, err := bodySchema(desc)
+	if err == nil {
+		err = CheckReservedNames(desc, opts.ReservedNames)
+	}
+	if err != nil {
+		diags = diags.Append(schemaErrorDiagnostic(err))
+		return newMessageMaybeDynamic(desc).Interface(), diags
+	}
+	expected := make(map[string]struct{}, len(schema.Attributes))
+	for _, attrS := range schema.Attributes {
+		expected[attrS.Name] = struct{}{}
+	}
+
+	names := make([]string, 0, len(attrs))
+	for name := range attrs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var missingRange hcl.Range
+	var traversals []hcl.Traversal
+	for i, name := range names {
+		attr := attrs[name]
+		if _, ok := expected[name]; !ok {
+			diags = diags.Append(&hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Unsupported argument",
+				Detail:   fmt.Sprintf("An argument named %q is not expected here.", name),
+				Subject:  attr.NameRange.Ptr(),
+				Extra:    diagnosticExtra{Category: DiagnosticUnsupported},
+			})
+		}
+		// There's no body to report missing attributes against, so we'll
+		// use a range covering all of the attributes we were given.
+		if i == 0 {
+			missingRange = attr.Range
+		} else {
+			missingRange = hcl.RangeOver(missingRange, attr.Range)
+		}
+		traversals = append(traversals, attr.Expr.Variables()...)
+	}
+
+	if opts.ConsolidateMissingVariables {
+		moreDiags := missingVariablesDiagnostics(traversals, ctx)
+		diags = append(diags, moreDiags...)
+		if moreDiags.HasErrors() {
+			return newMessageMaybeDynamic(desc).Interface(), diags
+		}
+	}
+
+	d := &decoder{
+		opts:          opts,
+		checkRequired: true,
+	}
+	msg := newMessageMaybeDynamic(desc)
+	content := &hcl.BodyContent{
+		Attributes:       attrs,
+		MissingItemRange: missingRange,
+	}
+	recovering := diags.HasErrors()
+	defaults := newAttrDefaults(content, missingRange)
+	moreDiags := d.fillMessageFromContent(content, missingRange, msg, FieldFlattened{}, protopath.Path{protopath.Root(desc)}, ctx, nil, defaults, recovering)
+	diags = append(diags, moreDiags...)
+	moreDiags = d.applyAttrDefaults(defaults, ctx, recovering)
+	diags = append(diags, moreDiags...)
+
+	return msg.Interface(), diags
+}
@@ -0,0 +1,119 @@
+package protohcl
+
+import (
+	"testing"
+
+	"github.com/apparentlymart/go-protohcl/protohcl/internal/testschema"
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/json"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func TestDecodeAttributes(t *testing.T) {
+	rootDesc := testschema.File_testschema_proto.Messages().ByName(protoreflect.Name("Root"))
+
+	tests := map[string]struct {
+		config      string
+		desc        protoreflect.MessageDescriptor
+		want        proto.Message
+		wantDiagErr string
+	}{
+		"with flattened attribute": {
+			`
+name  = "Jackson"
+count = 2
+`,
+			rootDesc,
+			&testschema.Root{
+				Name: "Jackson",
+				More: &testschema.MoreRoot{
+					Count: 2,
+				},
+			},
+			``,
+		},
+		"missing required attribute": {
+			`
+count = 2
+`,
+			rootDesc,
+			&testschema.Root{
+				More: &testschema.MoreRoot{
+					Count: 2,
+				},
+			},
+			`test.tf:2,1-10: Missing required argument; The argument "name" is required, but no definition was found.`,
+		},
+		"unsupported attribute": {
+			`
+name  = "Jackson"
+thing = "Snakob"
+`,
+			rootDesc,
+			&testschema.Root{
+				Name: "Jackson",
+				More: &testschema.MoreRoot{},
+			},
+			`test.tf:3,1-6: Unsupported argument; An argument named "thing" is not expected here.`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			f, diags := hclsyntax.ParseConfig([]byte(test.config), "test.tf", hcl.InitialPos)
+			if diags.HasErrors() {
+				t.Fatalf("parse error: %s", diags)
+			}
+			attrs, diags := f.Body.JustAttributes()
+			if diags.HasErrors() {
+				t.Fatalf("unexpected errors: %s", diags.Error())
+			}
+
+			got, diags := DecodeAttributes(attrs, test.desc, nil)
+
+			if test.wantDiagErr != "" {
+				if !diags.HasErrors() {
+					t.Fatalf("unexpected success\nwant error: %s", test.wantDiagErr)
+				}
+				if got := diags.Error(); got != test.wantDiagErr {
+					t.Errorf("wrong error\ngot error:  %s\nwant error: %s", got, test.wantDiagErr)
+				}
+			} else if diags.HasErrors() {
+				t.Fatalf("unexpected errors: %s", diags.Error())
+			}
+
+			if diff := cmp.Diff(test.want, got, protoCmpOpt); diff != "" {
+				t.Errorf("wrong result\n%s", diff)
+			}
+		})
+	}
+
+	t.Run("JSON object", func(t *testing.T) {
+		f, diags := json.Parse([]byte(`{"name": "Jackson", "count": 2}`), "test.json")
+		if diags.HasErrors() {
+			t.Fatalf("parse error: %s", diags)
+		}
+		attrs, diags := f.Body.JustAttributes()
+		if diags.HasErrors() {
+			t.Fatalf("unexpected errors: %s", diags.Error())
+		}
+
+		got, diags := DecodeAttributes(attrs, rootDesc, nil)
+		if diags.HasErrors() {
+			t.Fatalf("unexpected errors: %s", diags.Error())
+		}
+
+		want := &testschema.Root{
+			Name: "Jackson",
+			More: &testschema.MoreRoot{
+				Count: 2,
+			},
+		}
+		if diff := cmp.Diff(want, got, protoCmpOpt); diff != "" {
+			t.Errorf("wrong result\n%s", diff)
+		}
+	})
+}
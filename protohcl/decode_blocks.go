@@ -0,0 +1,79 @@
+package protohcl
+
+import (
+	hcl "github.com/hashicorp/hcl/v2"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protopath"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// DecodeAllBlocks decodes each of the blocks of the given type at the top
+// level of the given body into a separate message that conforms to the given
+// message descriptor, returning the messages in the same order as the blocks
+// appear in the body.
+//
+// This is for hosts where a plugin defines a block type that can appear
+// any number of times at the top level of a file, alongside other content
+// that the host itself decodes. The given message type describes the
+// body of one block, with its label-annotated fields defining the labels
+// that each block must have, just as for a nested block type.
+//
+// DecodeAllBlocks also returns a body representing the remaining content of
+// the given body, excluding the blocks it decoded, so that the caller can
+// decode that content separately.
+//
+// A nil body is treated as an empty one, for which the result has no
+// messages.
+func DecodeAllBlocks(body hcl.Body, typeName string, desc protoreflect.MessageDescriptor, ctx *hcl.EvalContext) ([]proto.Message, hcl.Body, hcl.Diagnostics) {
+	return DecodeOptions{}.DecodeAllBlocks(body, typeName, desc, ctx)
+}
+
+func (opts DecodeOptions) decodeAllBlocks(body hcl.Body, typeName string, desc protoreflect.MessageDescriptor, ctx *hcl.EvalContext) ([]proto.Message, hcl.Body, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+	if body == nil {
+		body = hcl.EmptyBody()
+	}
+
+	elem := FieldNestedBlockType{
+		TypeName: typeName,
+		Nested:   desc,
+		Repeated: true,
+	}
+	blockS, err := blockTypeSchema(elem)
+	if err == nil {
+		err = CheckReservedNames(desc, opts.ReservedNames)
+	}
+	if err != nil {
+		err = schemaErrorInBlock(err, []string{typeName})
+		diags = diags.Append(schemaErrorDiagnostic(err))
+		return nil, body, diags
+	}
+	content, remain, moreDiags := body.PartialContent(&hcl.BodySchema{
+		Blocks: []hcl.BlockHeaderSchema{blockS},
+	})
+	moreDiags = describeBlockLabels(moreDiags, map[string]FieldNestedBlockType{typeName: elem})
+	diags = append(diags, moreDiags...)
+
+	if opts.ConsolidateMissingVariables {
+		var traversals []hcl.Traversal
+		for _, block := range content.Blocks {
+			traversals = append(traversals, bodyVariables(block.Body, desc)...)
+		}
+		moreDiags := missingVariablesDiagnostics(traversals, ctx)
+		diags = append(diags, moreDiags...)
+		if moreDiags.HasErrors() {
+			return nil, remain, diags
+		}
+	}
+
+	d := &decoder{
+		opts: opts,
+	}
+	ret := make([]proto.Message, 0, len(content.Blocks))
+	for _, block := range content.Blocks {
+		msg, moreDiags := d.newMessageForBlock(block, elem, protopath.Path{protopath.Root(desc)}, ctx, nil)
+		diags = append(diags, moreDiags...)
+		ret = append(ret, msg.Interface())
+	}
+	return ret, remain, diags
+}
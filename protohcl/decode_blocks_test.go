@@ -0,0 +1,117 @@
+package protohcl
+
+import (
+	"testing"
+
+	"github.com/apparentlymart/go-protohcl/protohcl/internal/testschema"
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func TestDecodeAllBlocks(t *testing.T) {
+	desc := testschema.File_testschema_proto.Messages().ByName(protoreflect.Name("WithOneBlockLabel"))
+
+	tests := map[string]struct {
+		config      string
+		want        []proto.Message
+		wantRemain  []string
+		wantDiagErr string
+	}{
+		"none": {
+			``,
+			[]proto.Message{},
+			nil,
+			``,
+		},
+		"several": {
+			`
+doodad "Jackson" {
+  nickname = "doofus"
+}
+other = true
+doodad "Snakob" {
+}
+`,
+			[]proto.Message{
+				&testschema.WithOneBlockLabel{
+					Name:     "Jackson",
+					Nickname: "doofus",
+				},
+				&testschema.WithOneBlockLabel{
+					Name: "Snakob",
+				},
+			},
+			[]string{"other"},
+			``,
+		},
+		"invalid block content": {
+			`
+doodad "Jackson" {
+  nickname = ["doofus"]
+}
+`,
+			[]proto.Message{
+				&testschema.WithOneBlockLabel{
+					Name: "Jackson",
+				},
+			},
+			nil,
+			`test.tf:3,14-24: Unsuitable attribute value; Inappropriate value for attribute "nickname": string required.`,
+		},
+		"missing label": {
+			`
+doodad {
+}
+`,
+			[]proto.Message{},
+			nil,
+			`test.tf:2,8-9: Missing name for doodad; All doodad blocks must have 1 labels (name).`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			f, diags := hclsyntax.ParseConfig([]byte(test.config), "test.tf", hcl.InitialPos)
+			if diags.HasErrors() {
+				t.Fatalf("parse error: %s", diags)
+			}
+
+			got, remain, diags := DecodeAllBlocks(f.Body, "doodad", desc, nil)
+
+			if test.wantDiagErr != "" {
+				if !diags.HasErrors() {
+					t.Fatalf("unexpected success\nwant error: %s", test.wantDiagErr)
+				}
+				if got := diags.Error(); got != test.wantDiagErr {
+					t.Errorf("wrong error\ngot error:  %s\nwant error: %s", got, test.wantDiagErr)
+				}
+			} else if diags.HasErrors() {
+				t.Fatalf("unexpected errors: %s", diags.Error())
+			}
+
+			if diff := cmp.Diff(test.want, got, protoCmpOpt); diff != "" {
+				t.Errorf("wrong result\n%s", diff)
+			}
+
+			// The remaining body must not include the blocks we already
+			// decoded, and so any other content should be decodable
+			// without them.
+			content, diags := remain.Content(&hcl.BodySchema{
+				Attributes: []hcl.AttributeSchema{{Name: "other"}},
+			})
+			if diags.HasErrors() {
+				t.Fatalf("remaining body has errors: %s", diags.Error())
+			}
+			var gotRemain []string
+			for name := range content.Attributes {
+				gotRemain = append(gotRemain, name)
+			}
+			if diff := cmp.Diff(test.wantRemain, gotRemain); diff != "" {
+				t.Errorf("wrong remaining attributes\n%s", diff)
+			}
+		})
+	}
+}
@@ -3,12 +3,20 @@ package protohcl
 import (
 	"fmt"
 
+	"github.com/apparentlymart/go-protohcl/protohcl/protohclext"
 	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
 	"github.com/zclconf/go-cty/cty/convert"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protopath"
 	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
 )
 
+// deprecatedBlockTypeSummary is the summary of the warning for a block that
+// uses one of the (hcl.block).aliases of its block type.
+const deprecatedBlockTypeSummary = "Deprecated block type"
+
 // DecodeBody decodes the content of the given body into a message that
 // conforms to the given message descriptor.
 //
@@ -16,30 +24,159 @@ import (
 // stub code for the relevant protobuf schema. If you need to work with
 // schemas loaded only at runtime, such as over a plugin wire protocol, use
 // DynamicProto instead.
+//
+// A nil body is treated the same as hcl.EmptyBody(), so that a host can pass
+// the body of an optional block that wasn't present in the configuration
+// without first checking for it. The result is then a message with all of
+// its fields unset, along with an error for each required argument. The
+// other decoding functions that accept a body all behave the same way.
 func DecodeBody(body hcl.Body, desc protoreflect.MessageDescriptor, ctx *hcl.EvalContext) (proto.Message, hcl.Diagnostics) {
+	return DecodeOptions{}.DecodeBody(body, desc, ctx)
+}
+
+// decoder holds the settings and state for a single call to
+// DecodeOptions.DecodeBody, shared by all of the nested bodies that the
+// call visits.
+type decoder struct {
+	opts DecodeOptions
+
+	// trace is where we record the source locations of the fields we
+	// populate, or nil if the caller doesn't need a trace.
+	trace *DecodeTrace
+
+	// presence is where we record which attributes and blocks were present
+	// in the configuration, or nil if the caller doesn't need that.
+	presence *PresenceMap
+
+	// reuse decides which field values we can reuse from a previous decode
+	// instead of evaluating their expressions, or is nil if we must
+	// evaluate everything.
+	reuse *fieldReuser
+
+	// checkRequired is set when the content we're decoding didn't come from
+	// hcl.Body.Content, and so HCL hasn't already reported any missing
+	// required attributes.
+	checkRequired bool
+
+	// dynamic forces the decoder to always populate dynamic messages, for
+	// message types that share their names with a different generated type,
+	// as for DecodeOptions.InferAttributesFromJSONNames.
+	dynamic bool
+
+	// merge is set when we're decoding into a message that might already
+	// have fields populated, for DecodeBodyInto, in which case the content
+	// of the body is layered over the existing fields instead of replacing
+	// them all.
+	merge bool
+
+	// cache remembers schema information derived from descriptors across
+	// all of the bodies in a batch, for DecodeMany, or is nil if the
+	// decoder derives it each time.
+	cache *schemaCache
+
+	// mapKeyVals remembers the results of evaluating the map_key_attr
+	// attributes of blocks, keyed by the source range of each expression,
+	// so that decoding each block can reuse the result instead of
+	// evaluating the expression again.
+	mapKeyVals map[hcl.Range]evalResult
+
+	// invalidAttrs records the source ranges of the expressions of the
+	// attributes whose values couldn't be assigned to their fields, either
+	// because evaluation failed or because the value was unsuitable or not
+	// known, so that decoding a google.protobuf.Any block can tell whether
+	// its discriminator attribute already reported a problem.
+	invalidAttrs map[hcl.Range]struct{}
+}
+
+// evalResult is the result of evaluating an expression.
+type evalResult struct {
+	val   cty.Value
+	diags hcl.Diagnostics
+}
+
+// newMessage returns a new message of the given type, which is dynamic if
+// the decoder requires it.
+func (d *decoder) newMessage(desc protoreflect.MessageDescriptor) protoreflect.Message {
+	if d.dynamic {
+		return dynamicpb.NewMessage(desc)
+	}
+	return newMessageMaybeDynamic(desc)
+}
+
+// decodeBody decodes the given body into a new message of the given type.
+//
+// If except is non-empty then any attributes or block types it names are
+// treated as if the message type didn't declare them. The body must already
+// have any content for those names hidden, using hideBodyNames.
+func (d *decoder) decodeBody(body hcl.Body, desc protoreflect.MessageDescriptor, path protopath.Path, ctx *hcl.EvalContext, except map[string]struct{}) (proto.Message, hcl.Diagnostics) {
 	var diags hcl.Diagnostics
 
-	schema, err := bodySchema(desc)
+	schema, err := d.bodySchema(desc)
 	if err != nil {
+		err = schemaErrorInBlock(err, blockPathForProtoPath(path))
 		// If the schema isn't valid at all then this is really a bug in
 		// whatever software defined the schema, but we'll just bundle it
 		// up as a diagnostic here so that callers don't need to deal with
 		// two different ways to handle errors.
 		diags = diags.Append(schemaErrorDiagnostic(err))
+		// We can't go any further without a schema, though.
+		return newMessageMaybeDynamic(desc).Interface(), diags
+	}
+
+	msg := d.newMessage(desc)
+	moreDiags := d.fillMessageFromBody(body, schema, msg, path, ctx, except)
+	diags = append(diags, moreDiags...)
+	return msg.Interface(), diags
+}
+
+// fillMessageFromBody is the main part of decodeBody, populating the given
+// message from the given body, which must conform to the given schema that
+// was derived from the message type.
+func (d *decoder) fillMessageFromBody(body hcl.Body, schema *hcl.BodySchema, msg protoreflect.Message, path protopath.Path, ctx *hcl.EvalContext, except map[string]struct{}) hcl.Diagnostics {
+	var diags hcl.Diagnostics
+	desc := msg.Descriptor()
+
+	if len(except) != 0 {
+		schema = schemaWithoutNames(schema, except)
+	}
+	if d.merge {
+		// A required attribute might already have a value from an
+		// earlier layer, so fillMessageFromContent checks for them
+		// instead of HCL.
+		schema = schemaWithoutRequired(schema)
 	}
 
 	content, moreDiags := body.Content(schema)
+	if moreDiags.HasErrors() {
+		blockTypes := make(map[string]FieldNestedBlockType)
+		collectNestedBlockTypes(desc, "", blockTypes)
+		moreDiags = describeBlockLabels(moreDiags, blockTypes)
+	}
 	diags = append(diags, moreDiags...)
 	// Even if there were errors, we'll try a partial decode anyway.
 
-	msg := newMessageMaybeDynamic(desc)
-	moreDiags = fillMessageFromContent(content, body.MissingItemRange(), msg, ctx, diags.HasErrors())
+	recovering := diags.HasErrors()
+	defaults := newAttrDefaults(content, body.MissingItemRange())
+	moreDiags = d.fillMessageFromContent(content, body.MissingItemRange(), msg, FieldFlattened{}, path, ctx, except, defaults, recovering)
 	diags = append(diags, moreDiags...)
-
-	return msg.Interface(), diags
+	moreDiags = d.applyAttrDefaults(defaults, ctx, recovering)
+	diags = append(diags, moreDiags...)
+	return diags
 }
 
-func fillMessageFromContent(content *hcl.BodyContent, missingRange hcl.Range, msg protoreflect.Message, ctx *hcl.EvalContext, recovering bool) hcl.Diagnostics {
+// fillMessageFromContent populates the HCL-annotated fields of the given
+// message from the given body content. For a message flattened into another,
+// outer is the element it was flattened in through, which decides the
+// names that the message's own elements use; it's the zero value otherwise.
+//
+// fillMessageFromContent records the body's attributes in defaults, but
+// leaves the caller to apply their default expressions once it has
+// populated all of the messages that share the body content.
+//
+// recovering is true if HCL already reported errors about the content, in
+// which case DecodeOptions.Recovery decides whether to evaluate the
+// attributes it does include.
+func (d *decoder) fillMessageFromContent(content *hcl.BodyContent, missingRange hcl.Range, msg protoreflect.Message, outer FieldFlattened, path protopath.Path, ctx *hcl.EvalContext, except map[string]struct{}, defaults *attrDefaults, recovering bool) hcl.Diagnostics {
 	var diags hcl.Diagnostics
 
 	// Our task here is to walk the message descriptor graph associated with
@@ -47,168 +184,229 @@ func fillMessageFromContent(content *hcl.BodyContent, missingRange hcl.Range, ms
 	// each annotated field from.
 
 	fields := msg.Descriptor().Fields()
+	var fieldPath protopath.Path
+	mark := 0
 	for i := 0; i < fields.Len(); i++ {
+		// Any diagnostics we generated while working on the previous field
+		// relate to that field.
+		d.trace.recordDiagnostics(fieldPath, diags[mark:])
+		mark = len(diags)
+
 		field := fields.Get(i)
-		elem, err := GetFieldElem(field)
+		fieldPath = appendPath(path, protopath.FieldAccess(field))
+		elem, err := d.fieldElem(field)
 		if err != nil {
+			err = schemaErrorInBlock(err, blockPathForProtoPath(path))
 			diags = diags.Append(schemaErrorDiagnostic(err))
 		}
+		elem = flattenedElem(elem, outer)
 
 		switch elem := elem.(type) {
 		case FieldAttribute:
+			if _, excluded := except[elem.Name]; excluded {
+				continue
+			}
+
+			defaults.declare(elem)
+			attr, exists := content.Attributes[elem.Name]
+			if !exists && d.merge {
+				// When merging, an attribute that the body doesn't define
+				// keeps whatever value the message already had.
+				if elem.Required && !msg.Has(field) {
+					diags = append(diags, missingRequiredDiagnostic(elem, missingRange))
+				}
+				if elem.DefaultExprString != "" && !msg.Has(field) {
+					defaults.addPending(elem, msg, fieldPath)
+				}
+				continue
+			}
+
 			// We'll always at least _clear_ the field, but we might then
 			// populate it with a new value below, if we can find a suitable
 			// value.
 			msg.Clear(field)
+			if elem.RangeField != nil {
+				msg.Clear(elem.RangeField)
+			}
 
-			attr, exists := content.Attributes[elem.Name]
 			if !exists {
-				if elem.Required {
-					// We shouldn't get here because the body should already
-					// have enforced "Required" during decoding, but we'll
-					// handle it here anyway to be robust.
-					diags = append(diags, &hcl.Diagnostic{
-						Severity: hcl.DiagError,
-						Summary:  "Missing required argument",
-						Detail:   fmt.Sprintf("The argument %q is required, but no definition was found.", elem.Name),
-						Subject:  missingRange.Ptr(),
-					})
+				if elem.Required && d.checkRequired {
+					diags = append(diags, missingRequiredDiagnostic(elem, missingRange))
+				}
+				d.presence.record(fieldPath, PresenceAbsent)
+				if elem.DefaultExprString != "" {
+					defaults.addPending(elem, msg, fieldPath)
 				}
 				continue
 			}
+			// We'll refine this further below if we find that the value is
+			// null or the field's default value.
+			d.presence.record(fieldPath, PresenceSet)
+			if elem.RangeField != nil {
+				setSourceRangeField(msg, elem.RangeField, attr.Expr.Range())
+			}
 
-			val, moreDiags := attr.Expr.Value(ctx)
-			diags = append(diags, moreDiags...)
-			if moreDiags.HasErrors() {
+			if d.skipEvaluation(recovering) {
+				continue
+			}
+			if d.reuse.reuse(msg, field, fieldPath, attr.Expr.Range(), d.trace) {
+				d.presence.recordFieldValue(fieldPath, msg, field)
 				continue
 			}
 
-			wantTy, moreDiags := elem.TypeConstraint()
+			val, moreDiags := d.evalAttrExpr(attr.Expr, ctx)
+			moreDiags = d.describeScopedFunctions(moreDiags, msg.Descriptor(), ctx)
 			diags = append(diags, moreDiags...)
 			if moreDiags.HasErrors() {
+				d.recordInvalidAttr(attr)
+				defaults.setInvalid(elem)
 				continue
 			}
-
-			// We have two stages of conversion: the first deals with the
-			// HCL-specific type constraint that might've been set using the
-			// (hcl.attr).type option, but then we also impose any constraints
-			// implied by the protobuf field's own type. Specifying these
-			// separately allows for some special situations, such as declaring
-			// (hcl.attr).type = "number" for a protobuf string field, which
-			// allows capturing a decimal representation of the full precision
-			// of the given number, rather than limiting it to one of the
-			// protobuf number types.
-			val, err = convert.Convert(val, wantTy)
-			if err != nil {
-				diags = append(diags, &hcl.Diagnostic{
-					Severity: hcl.DiagError,
-					Summary:  unsuitableValueSummary,
-					Detail: fmt.Sprintf(
-						"Inappropriate value for attribute %q: %s.",
-						elem.Name, err.Error(),
-					),
-					Subject:     attr.Expr.Range().Ptr(),
-					Context:     hcl.RangeBetween(attr.NameRange, attr.Expr.Range()).Ptr(),
-					Expression:  attr.Expr,
-					EvalContext: ctx,
-				})
-				continue
+			if wantTy, tyDiags := d.typeConstraint(elem); tyDiags.HasErrors() {
+				defaults.setInvalid(elem)
+			} else {
+				defaults.setValue(elem, val, wantTy)
 			}
-
-			if val.IsNull() {
-				if elem.Required {
-					// We can get here if the attribute was defined but ended
-					// up having a null value. We treat that the same as having
-					// omitted it entirely, but the HCL low-level API doesn't
-					// do that automatically.
-					diags = append(diags, &hcl.Diagnostic{
-						Severity: hcl.DiagError,
-						Summary:  unsuitableValueSummary,
-						Detail: fmt.Sprintf(
-							"Attribute %q is required, so must not be null.",
-							elem.Name,
-						),
-						Subject:     attr.Expr.Range().Ptr(),
-						Context:     hcl.RangeBetween(attr.NameRange, attr.Expr.Range()).Ptr(),
-						Expression:  attr.Expr,
-						EvalContext: ctx,
-					})
-				}
-				// We'll just leave the field cleared, then.
+			moreDiags = d.assignAttrValue(val, attr, elem, field, msg, fieldPath, ctx)
+			diags = append(diags, moreDiags...)
+			if moreDiags.HasErrors() {
+				d.recordInvalidAttr(attr)
+			}
+		case FieldNestedBlockType:
+			if _, excluded := except[elem.TypeName]; excluded {
 				continue
 			}
 
-			// If we're decoding into a message-typed field then we treat that
-			// as special so that our message-type-specific decoding strategy
-			// can handle it.
-			if isMessageField(elem) {
-				protoVal, err := valueForMessageField(val, elem, msg)
-				if err != nil {
-					diags = diags.Append(attrErrorDiagnostic(err))
+			if d.merge {
+				// When merging, a block type that the body doesn't use
+				// keeps whatever the message already had, and a singleton
+				// or map element block is merged into any existing
+				// message, but the blocks of a repeated block type are
+				// appended unless the options say to replace them.
+				if !hasBlockOfType(content, elem) {
 					continue
 				}
-				if !protoValueIsSet(protoVal) {
-					// We already cleared the field above, so nothing more to do
-					continue
+				if (elem.Repeated || elem.CollectionKind == protohclext.NestedBlock_MAP) && d.opts.RepeatedBlocks == ReplaceRepeatedBlocks {
+					msg.Clear(field)
 				}
-				msg.Set(field, protoVal)
-				continue
+			} else {
+				// We'll always at least _clear_ the field, but we might then
+				// populate it with a new value below, if we can find a
+				// suitable value.
+				msg.Clear(field)
 			}
 
-			needTy, err := valuePhysicalConstraintForFieldKind(val.Type(), field)
-			if err != nil {
-				diags = diags.Append(schemaErrorDiagnostic(err))
-			}
-			val, err = convert.Convert(val, needTy)
-			if err != nil {
-				diags = append(diags, &hcl.Diagnostic{
-					Severity: hcl.DiagError,
-					Summary:  unsuitableValueSummary,
-					Detail: fmt.Sprintf(
-						"Inappropriate value for attribute %q: %s.",
-						elem.Name, err.Error(),
-					),
-					Subject:     attr.Expr.Range().Ptr(),
-					Context:     hcl.RangeBetween(attr.NameRange, attr.Expr.Range()).Ptr(),
-					Expression:  attr.Expr,
-					EvalContext: ctx,
-				})
-				continue
+			if d.presence != nil {
+				blockPresence := PresenceAbsent
+				for _, block := range content.Blocks {
+					if elem.hasTypeName(block.Type) {
+						blockPresence = PresenceSet
+						break
+					}
+				}
+				d.presence.record(fieldPath, blockPresence)
 			}
 
-			protoVal, moreDiags := protoValueForField(val, attr.Expr.Range(), msg, field)
-			diags = append(diags, moreDiags...)
-			if moreDiags.HasErrors() {
-				continue
+			for _, block := range content.Blocks {
+				if block.Type != elem.TypeName && elem.hasTypeName(block.Type) {
+					diags = append(diags, &hcl.Diagnostic{
+						Severity: hcl.DiagWarning,
+						Summary:  deprecatedBlockTypeSummary,
+						Detail:   fmt.Sprintf("The block type name %q is deprecated. Use %q instead.", block.Type, elem.TypeName),
+						Subject:  block.TypeRange.Ptr(),
+						Context:  block.DefRange.Ptr(),
+						Extra:    diagnosticExtra{Category: DiagnosticDeprecated},
+					})
+				}
 			}
 
-			msg.Set(field, protoVal)
-		case FieldNestedBlockType:
-			// We'll always at least _clear_ the field, but we might then
-			// populate it with a new value below, if we can find a suitable
-			// value.
-			msg.Clear(field)
-
-			if elem.Repeated {
+			if elem.CollectionKind == protohclext.NestedBlock_MAP {
+				// For a map block type we'll write in all of the blocks of
+				// the associated type, using either their first labels or
+				// the values of their map_key_attr attributes as keys.
+				m := msg.Mutable(field).Map()
+				seen := make(map[string]*hcl.Block)
+				for _, block := range content.Blocks {
+					if !elem.hasTypeName(block.Type) {
+						continue
+					}
+					var key, keyName string
+					var subject hcl.Range
+					if elem.MapKeyAttr != "" {
+						var ok bool
+						key, subject, ok = d.mapKeyFromAttr(block, elem, ctx)
+						if !ok {
+							// Decoding the block will report why it has
+							// no valid key, but we can't keep the result.
+							_, moreDiags := d.newMessageForBlock(block, elem, fieldPath, ctx, nil)
+							diags = append(diags, moreDiags...)
+							continue
+						}
+						keyName = elem.MapKeyAttr
+					} else {
+						if len(block.Labels) == 0 {
+							continue
+						}
+						key = block.Labels[0]
+						keyName = elem.MapKeyLabel
+						subject = block.DefRange
+						if len(block.LabelRanges) != 0 {
+							subject = block.LabelRanges[0]
+						}
+					}
+					if prev, exists := seen[key]; exists {
+						diags = append(diags, &hcl.Diagnostic{
+							Severity: hcl.DiagError,
+							Summary:  fmt.Sprintf("Duplicate %s block", elem.TypeName),
+							Detail: fmt.Sprintf(
+								"There may be no more than one %s block with %s %q. Previous block declared at %s.",
+								elem.TypeName, keyName, key, prev.DefRange.Ptr(),
+							),
+							Subject: subject.Ptr(),
+							Context: block.DefRange.Ptr(),
+							Extra:   diagnosticExtra{Category: DiagnosticConflict},
+						})
+						continue
+					}
+					seen[key] = block
+					mapKey := protoreflect.ValueOfString(key).MapKey()
+					elemPath := appendPath(fieldPath, protopath.MapIndex(mapKey))
+					var into protoreflect.Message
+					if d.merge && m.Has(mapKey) {
+						into = m.Mutable(mapKey).Message()
+					}
+					nestedMsg, moreDiags := d.newMessageForBlock(block, elem, elemPath, ctx, into)
+					diags = append(diags, moreDiags...)
+					m.Set(mapKey, protoreflect.ValueOfMessage(nestedMsg))
+					d.trace.record(elemPath, block.DefRange)
+				}
+			} else if elem.Repeated {
 				// For a repeated block type we'll write in all of the blocks
 				// of the associated type.
-				list := msg.NewField(field).List()
+				list := msg.Mutable(field).List()
 				for _, block := range content.Blocks {
-					if block.Type != elem.TypeName {
+					if !elem.hasTypeName(block.Type) {
 						continue
 					}
-					nestedMsg, moreDiags := newMessageForBlock(block, elem, ctx)
+					elemPath := appendPath(fieldPath, protopath.ListIndex(list.Len()))
+					nestedMsg, moreDiags := d.newMessageForBlock(block, elem, elemPath, ctx, nil)
 					diags = append(diags, moreDiags...)
 					list.Append(protoreflect.ValueOfMessage(nestedMsg))
+					d.trace.record(elemPath, block.DefRange)
 				}
 			} else {
 				// For a singleton block there should be at most one block
 				// of the associated type.
 				var found *hcl.Block
+				oneofMembers := oneofBlockTypes(field, outer)
 				for _, block := range content.Blocks {
-					if block.Type != elem.TypeName {
+					if !elem.hasTypeName(block.Type) {
 						continue
 					}
+					if diag := oneofConflictDiagnostic(block, elem, oneofMembers, content); diag != nil {
+						diags = append(diags, diag)
+						break
+					}
 					if found != nil {
 						diags = append(diags, &hcl.Diagnostic{
 							Severity: hcl.DiagError,
@@ -219,51 +417,411 @@ func fillMessageFromContent(content *hcl.BodyContent, missingRange hcl.Range, ms
 							),
 							Subject: block.TypeRange.Ptr(),
 							Context: block.DefRange.Ptr(),
+							Extra:   diagnosticExtra{Category: DiagnosticConflict},
 						})
 						break
 					}
 					found = block
-					nestedMsg, moreDiags := newMessageForBlock(block, elem, ctx)
+					if elem.AnyDiscriminator != "" {
+						// The block's content replaces any existing
+						// message, even when merging, because it might
+						// now be of a different type.
+						anyMsg, moreDiags := d.newAnyMessageForBlock(block, elem, msg, content, fieldPath, ctx)
+						diags = append(diags, moreDiags...)
+						if anyMsg != nil {
+							msg.Set(field, protoreflect.ValueOfMessage(anyMsg))
+						}
+						d.trace.record(fieldPath, block.DefRange)
+						continue
+					}
+					var into protoreflect.Message
+					if d.merge && msg.Has(field) {
+						into = msg.Mutable(field).Message()
+					}
+					nestedMsg, moreDiags := d.newMessageForBlock(block, elem, fieldPath, ctx, into)
 					diags = append(diags, moreDiags...)
 					msg.Set(field, protoreflect.ValueOfMessage(nestedMsg))
+					d.trace.record(fieldPath, block.DefRange)
 				}
 			}
 
 		case FieldFlattened:
 			// For a "flattened" message we keep working with the same
 			// hcl.BodyContent but we must start a new message with the
-			// child descriptor.
-			msg.Clear(field)
-			nestedMsg := newMessageMaybeDynamic(elem.Nested)
-			moreDiags := fillMessageFromContent(content, missingRange, nestedMsg, ctx, recovering)
+			// child descriptor, unless we're merging into the existing one.
+			var nestedMsg protoreflect.Message
+			if d.merge {
+				nestedMsg = msg.Mutable(field).Message()
+			} else {
+				msg.Clear(field)
+				nestedMsg = d.newMessage(elem.Nested)
+			}
+			moreDiags := d.fillMessageFromContent(content, missingRange, nestedMsg, elem, fieldPath, ctx, except, defaults, recovering)
 			diags = append(diags, moreDiags...)
 			msg.Set(field, protoreflect.ValueOfMessage(nestedMsg))
 		}
 	}
+	d.trace.recordDiagnostics(fieldPath, diags[mark:])
 
 	return diags
 }
 
-func newMessageForBlock(block *hcl.Block, elem FieldNestedBlockType, ctx *hcl.EvalContext) (protoreflect.Message, hcl.Diagnostics) {
+// assignAttrValue converts the given value, which is the result of
+// evaluating the expression of the given attribute, to suit the field of
+// the given attribute element and then assigns it to that field of the given
+// message.
+func (d *decoder) assignAttrValue(val cty.Value, attr *hcl.Attribute, elem FieldAttribute, field protoreflect.FieldDescriptor, msg protoreflect.Message, fieldPath protopath.Path, ctx *hcl.EvalContext) hcl.Diagnostics {
 	var diags hcl.Diagnostics
+	var moreDiags hcl.Diagnostics
+	var err error
+
+	keys := d.objectKeyValues(ctx)
+	if field.IsMap() || isMessageKind(field.Kind()) {
+		// Evaluation would've silently discarded any duplicate keys
+		// in an object constructor, so we check for those in the
+		// expression itself.
+		moreDiags = checkDuplicateObjectKeys(attr.Expr, keys)
+		diags = append(diags, moreDiags...)
+		if moreDiags.HasErrors() {
+			return diags
+		}
+	}
+	allowCapsules := elem.RawMode != protohclext.Attribute_NOT_RAW && d.opts.CapsuleCodecs != nil
+	if err := checkStorableValue(val, allowCapsules); err != nil {
+		diags = diags.Append(attrErrorDiagnostic(attrValueErrorWrap(nil, err), attr, ctx, d.opts))
+		return diags
+	}
+	if elem.AllowScalarForList {
+		val = wrapScalarForList(val)
+	}
 
-	nestedMsg, moreDiags := DecodeBody(block.Body, elem.Nested, ctx)
+	wantTy, moreDiags := d.typeConstraint(elem)
 	diags = append(diags, moreDiags...)
-	nestedMsgR := nestedMsg.ProtoReflect()
+	if moreDiags.HasErrors() {
+		return diags
+	}
 
-	nestedFields := elem.Nested.Fields()
-	nextLabel := 0
-	for i := 0; i < nestedFields.Len(); i++ {
-		nestedField := nestedFields.Get(i)
-		elem, err := GetFieldElem(nestedField)
+	val, err = parseNumberSyntax(val, elem.NumberSyntax)
+	if err != nil {
+		diags = diags.Append(attrErrorDiagnostic(attrValueErrorWrap(nil, err), attr, ctx, d.opts))
+		return diags
+	}
+
+	// We have two stages of conversion: the first deals with the
+	// HCL-specific type constraint that might've been set using the
+	// (hcl.attr).type option, but then we also impose any constraints
+	// implied by the protobuf field's own type. Specifying these
+	// separately allows for some special situations, such as declaring
+	// (hcl.attr).type = "number" for a protobuf string field, which
+	// allows capturing a decimal representation of the full precision
+	// of the given number, rather than limiting it to one of the
+	// protobuf number types.
+	val, err = convert.Convert(val, wantTy)
+	if err != nil {
+		diags = diags.Append(attrErrorDiagnostic(attrValueErrorWrap(nil, err), attr, ctx, d.opts))
+		return diags
+	}
+
+	val = emptyAsNull(val, elem)
+	if val.IsNull() {
+		d.presence.record(fieldPath, PresenceNull)
+		if elem.Required {
+			// We can get here if the attribute was defined but ended
+			// up having a null value. We treat that the same as having
+			// omitted it entirely, but the HCL low-level API doesn't
+			// do that automatically.
+			detail := fmt.Sprintf("Attribute %q is required, so must not be null.", elem.Name)
+			if elem.EmptyAsNull {
+				detail = fmt.Sprintf("Attribute %q is required, so must not be null or an empty string.", elem.Name)
+			}
+			diags = append(diags, &hcl.Diagnostic{
+				Severity:    hcl.DiagError,
+				Summary:     unsuitableValueSummary,
+				Detail:      detail,
+				Subject:     attr.Expr.Range().Ptr(),
+				Context:     hcl.RangeBetween(attr.NameRange, attr.Expr.Range()).Ptr(),
+				Expression:  attr.Expr,
+				EvalContext: ctx,
+				Extra:       diagnosticExtra{Category: DiagnosticValueError},
+			})
+		} else if raw := rawTypedNull(val, elem); raw != nil {
+			// A raw field can still record the null value's type.
+			msg.Set(field, protoreflect.ValueOfBytes(raw))
+			d.trace.record(fieldPath, attr.Expr.Range())
+		}
+		// Otherwise we'll just leave the field cleared.
+		return diags
+	}
+
+	if elem.Unit != "" {
+		val, err = parseUnitValue(val, elem.Unit)
 		if err != nil {
-			continue // we handle these errors during schema construction
+			diags = diags.Append(attrErrorDiagnostic(attrValueErrorWrap(nil, err), attr, ctx, d.opts))
+			return diags
+		}
+	}
+
+	if elem.RawMode != protohclext.Attribute_NOT_RAW && d.opts.CapsuleCodecs != nil {
+		val, err = d.opts.CapsuleCodecs.encodeCapsules(val, nil)
+		if err != nil {
+			diags = diags.Append(attrErrorDiagnostic(attrValueErrorWrap(nil, err), attr, ctx, d.opts))
+			return diags
 		}
-		if _, ok := elem.(FieldBlockLabel); ok {
-			nestedMsgR.Set(nestedField, protoreflect.ValueOfString(block.Labels[nextLabel]))
-			nextLabel++
+	}
+
+	// If we're decoding into a message-typed field then we treat that
+	// as special so that our message-type-specific decoding strategy
+	// can handle it.
+	if isMessageField(elem) {
+		protoVal, err := d.valueForMessageField(val, elem, msg)
+		if err != nil {
+			diags = diags.Append(attrErrorDiagnostic(err, attr, ctx, d.opts))
+			return diags
+		}
+		if !protoValueIsSet(protoVal) {
+			// We already cleared the field above, so nothing more to do
+			d.presence.record(fieldPath, PresenceDefault)
+			return diags
+		}
+		msg.Set(field, protoVal)
+		d.presence.recordFieldValue(fieldPath, msg, field)
+		d.trace.record(fieldPath, attr.Expr.Range())
+		d.trace.recordVariables(fieldPath, attr.Expr)
+		return diags
+	}
+
+	// We must capture the element source ranges before the physical
+	// type conversion below, because that conversion can change a
+	// set into a list and thus lose the information that the element
+	// order doesn't correspond with the source expression.
+	rngs := sourceRangesForValue(attr.Expr, val, keys)
+
+	if field.IsList() && val.Type().IsSetType() && val.IsKnown() && !val.IsNull() {
+		// The conversion below would lose the set type, so we must
+		// put the elements in their canonical order first.
+		elems, err := OrderedSetEncoding(val)
+		if err != nil {
+			diags = diags.Append(attrErrorDiagnostic(attrValueErrorWrap(nil, err), attr, ctx, d.opts))
+			return diags
+		}
+		val = cty.TupleVal(elems)
+	}
+	if field.IsList() {
+		val, rngs, err = discardNullElements(val, rngs, elem)
+		if err != nil {
+			diag := attrErrorDiagnostic(attrValueErrorWrap(nil, err), attr, ctx, d.opts)
+			if pathErr, ok := err.(cty.PathError); ok && len(pathErr.Path) != 0 {
+				// The problem is with one specific element, so we'll
+				// report that element's own range where possible.
+				if step, ok := pathErr.Path[0].(cty.IndexStep); ok {
+					idx, _ := step.Key.AsBigFloat().Int64()
+					diag.Subject = rngs.Elem(int(idx)).Ptr()
+				}
+			}
+			diags = diags.Append(diag)
+			return diags
 		}
 	}
 
+	needTy, err := valuePhysicalConstraintForFieldKind(val.Type(), field)
+	if err != nil {
+		diags = diags.Append(schemaErrorDiagnostic(err))
+	}
+	val, err = convert.Convert(val, needTy)
+	if err != nil {
+		diags = diags.Append(attrErrorDiagnostic(attrValueErrorWrap(nil, err), attr, ctx, d.opts))
+		return diags
+	}
+
+	protoVal, moreDiags := protoValueForField(val, rngs, msg, field, d.opts)
+	diags = append(diags, moreDiags...)
+	if moreDiags.HasErrors() {
+		return diags
+	}
+
+	msg.Set(field, protoVal)
+	d.presence.recordFieldValue(fieldPath, msg, field)
+	d.trace.record(fieldPath, attr.Expr.Range())
+	d.trace.recordVariables(fieldPath, attr.Expr)
+	d.trace.recordFieldElems(fieldPath, msg, field, rngs)
+	return diags
+}
+
+// newMessageForBlock decodes the given block into a message for the given
+// nested block type. If into is non-nil then newMessageForBlock merges the
+// block into that existing message and returns it, as for DecodeBodyInto.
+func (d *decoder) newMessageForBlock(block *hcl.Block, elem FieldNestedBlockType, path protopath.Path, ctx *hcl.EvalContext, into protoreflect.Message) (protoreflect.Message, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+
+	ctx = d.blockEvalContext(elem.TypeName, ctx)
+	var nestedMsgR protoreflect.Message
+	if into != nil {
+		nestedMsgR = into
+		moreDiags := d.decodeBodyInto(block.Body, into, path, ctx)
+		diags = append(diags, moreDiags...)
+	} else {
+		nestedMsg, moreDiags := d.decodeBody(block.Body, elem.Nested, path, ctx, nil)
+		diags = append(diags, moreDiags...)
+		nestedMsgR = nestedMsg.ProtoReflect()
+	}
+
+	firstLabel := 0
+	if elem.MapKeyLabel != "" {
+		firstLabel = 1 // the first label is the map key
+	}
+	d.setBlockLabels(nestedMsgR, path, block, firstLabel)
+
 	return nestedMsgR, diags
 }
+
+// blockPathForProtoPath returns the sequence of nested block type names
+// that the given path traverses through, ignoring any other steps such as
+// flattened fields and indices into repeated block types.
+func blockPathForProtoPath(path protopath.Path) []string {
+	var ret []string
+	prefix := ""
+	for _, step := range path {
+		if step.Kind() != protopath.FieldAccessStep {
+			continue
+		}
+		elem, err := GetFieldElem(step.FieldDescriptor())
+		if err != nil {
+			continue
+		}
+		switch elem := withNamePrefix(elem, prefix).(type) {
+		case FieldNestedBlockType:
+			ret = append(ret, elem.TypeName)
+			prefix = ""
+		case FieldFlattened:
+			prefix = elem.Prefix
+		}
+	}
+	return ret
+}
+
+// setBlockLabels writes the labels of the given block, starting at the
+// given index, into the label-annotated fields of the given message, in the
+// same order that blockLabelNames would return their names. It returns the
+// index of the first label that remains unused.
+//
+// Labels for fields in flattened messages are written into the
+// corresponding nested message, which therefore must already be populated.
+func (d *decoder) setBlockLabels(msg protoreflect.Message, path protopath.Path, block *hcl.Block, next int) int {
+	fields := msg.Descriptor().Fields()
+	for i := 0; i < fields.Len() && next < len(block.Labels); i++ {
+		field := fields.Get(i)
+		elem, err := d.fieldElem(field)
+		if err != nil {
+			continue // we handle these errors during schema construction
+		}
+		fieldPath := appendPath(path, protopath.FieldAccess(field))
+		switch elem.(type) {
+		case FieldBlockLabel:
+			msg.Set(field, protoreflect.ValueOfString(block.Labels[next]))
+			if next < len(block.LabelRanges) {
+				d.trace.record(fieldPath, block.LabelRanges[next])
+			}
+			next++
+		case FieldFlattened:
+			next = d.setBlockLabels(msg.Mutable(field).Message(), fieldPath, block, next)
+		}
+	}
+	return next
+}
+
+// wrapScalarForList returns a single-element tuple containing the given
+// value if it is of a type that can't possibly convert to a list, or returns
+// the given value verbatim otherwise.
+//
+// This is for attributes using the "allow_scalar_for_list" option.
+func wrapScalarForList(val cty.Value) cty.Value {
+	ty := val.Type()
+	if val.IsNull() || ty == cty.DynamicPseudoType {
+		// We can't decide for null or totally-unknown values, so we'll just
+		// let them pass through and be handled in the usual way.
+		return val
+	}
+	if ty.IsListType() || ty.IsSetType() || ty.IsTupleType() {
+		return val
+	}
+	return cty.TupleVal([]cty.Value{val})
+}
+
+// mapKeyFromAttr returns the map key for the given block of a map block type
+// whose keys come from (hcl.block).map_key_attr, along with the source range
+// of the attribute's expression. The final result is false if the block
+// doesn't have a valid key, in which case decoding the block reports why.
+func (d *decoder) mapKeyFromAttr(block *hcl.Block, elem FieldNestedBlockType, ctx *hcl.EvalContext) (string, hcl.Range, bool) {
+	content, _, _ := block.Body.PartialContent(&hcl.BodySchema{
+		Attributes: []hcl.AttributeSchema{{Name: elem.MapKeyAttr}},
+	})
+	if content == nil {
+		return "", hcl.Range{}, false
+	}
+	attr, exists := content.Attributes[elem.MapKeyAttr]
+	if !exists {
+		return "", hcl.Range{}, false
+	}
+	val, diags := d.evalExpr(attr.Expr, d.blockEvalContext(elem.TypeName, ctx))
+	if d.mapKeyVals == nil {
+		d.mapKeyVals = make(map[hcl.Range]evalResult)
+	}
+	d.mapKeyVals[attr.Expr.Range()] = evalResult{val, diags}
+	if diags.HasErrors() {
+		return "", hcl.Range{}, false
+	}
+	val, err := convert.Convert(val, cty.String)
+	if err != nil || val.IsNull() || !val.IsWhollyKnown() {
+		return "", hcl.Range{}, false
+	}
+	return val.AsString(), attr.Expr.Range(), true
+}
+
+// evalAttrExpr is like evalExpr but returns the result that mapKeyFromAttr
+// already produced for the same expression, if any, so that each expression
+// is evaluated only once.
+func (d *decoder) evalAttrExpr(expr hcl.Expression, ctx *hcl.EvalContext) (cty.Value, hcl.Diagnostics) {
+	rng := expr.Range()
+	if result, ok := d.mapKeyVals[rng]; ok {
+		delete(d.mapKeyVals, rng)
+		return result.val, result.diags
+	}
+	return d.evalExpr(expr, ctx)
+}
+
+// recordInvalidAttr records that the value of the given attribute couldn't
+// be assigned to its field, for attrIsInvalid.
+func (d *decoder) recordInvalidAttr(attr *hcl.Attribute) {
+	if d.invalidAttrs == nil {
+		d.invalidAttrs = make(map[hcl.Range]struct{})
+	}
+	d.invalidAttrs[attr.Expr.Range()] = struct{}{}
+}
+
+// attrIsInvalid returns true if decoding already reported a problem with
+// the value of the given attribute.
+func (d *decoder) attrIsInvalid(attr *hcl.Attribute) bool {
+	_, invalid := d.invalidAttrs[attr.Expr.Range()]
+	return invalid
+}
+
+// hasBlockOfType returns true if the given content includes at least one
+// block of the given nested block type.
+func hasBlockOfType(content *hcl.BodyContent, elem FieldNestedBlockType) bool {
+	for _, block := range content.Blocks {
+		if elem.hasTypeName(block.Type) {
+			return true
+		}
+	}
+	return false
+}
+
+func missingRequiredDiagnostic(elem FieldAttribute, missingRange hcl.Range) *hcl.Diagnostic {
+	return &hcl.Diagnostic{
+		Severity: hcl.DiagError,
+		Summary:  "Missing required argument",
+		Detail:   fmt.Sprintf("The argument %q is required, but no definition was found.", elem.Name),
+		Subject:  missingRange.Ptr(),
+		Extra:    diagnosticExtra{Category: DiagnosticMissingRequired},
+	}
+}
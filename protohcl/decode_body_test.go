@@ -1,12 +1,14 @@
 package protohcl
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/apparentlymart/go-protohcl/protohcl/internal/testschema"
 	"github.com/google/go-cmp/cmp"
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/reflect/protoreflect"
 	"google.golang.org/protobuf/testing/protocmp"
@@ -25,14 +27,29 @@ func TestDecodeBody(t *testing.T) {
 	withStructListAttrDesc := fileDesc.Messages().ByName(protoreflect.Name("WithStructListAttr"))
 	withNestedBlockNoLabelsSingletonDesc := fileDesc.Messages().ByName(protoreflect.Name("WithNestedBlockNoLabelsSingleton"))
 	withNestedBlockOneLabelSingletonDesc := fileDesc.Messages().ByName(protoreflect.Name("WithNestedBlockOneLabelSingleton"))
+	withNestedBlockOneLabelRepeatedDesc := fileDesc.Messages().ByName(protoreflect.Name("WithNestedBlockOneLabelRepeated"))
 	withFlattenStringAttrDesc := fileDesc.Messages().ByName(protoreflect.Name("WithFlattenStringAttr"))
 	withNestedFlattenStringAttrDesc := fileDesc.Messages().ByName(protoreflect.Name("WithNestedFlattenStringAttr"))
 	withBoolAttrDesc := fileDesc.Messages().ByName(protoreflect.Name("WithBoolAttr"))
 	withNumberAttrAsInt32Desc := fileDesc.Messages().ByName(protoreflect.Name("WithNumberAttrAsInt32"))
+	withNumberAttrsAsFloatDesc := fileDesc.Messages().ByName(protoreflect.Name("WithNumberAttrsAsFloat"))
 	withNumberAttrAsStringDesc := fileDesc.Messages().ByName(protoreflect.Name("WithNumberAttrAsString"))
 	withStringListAttrDesc := fileDesc.Messages().ByName(protoreflect.Name("WithStringListAttr"))
+	withSameBlockTypeNestedDesc := fileDesc.Messages().ByName(protoreflect.Name("WithSameBlockTypeNested"))
+	withInvalidNestedBlocksDesc := fileDesc.Messages().ByName(protoreflect.Name("WithInvalidNestedBlocks"))
+	withNestedBlockFlattenedLabelsDesc := fileDesc.Messages().ByName(protoreflect.Name("WithNestedBlockFlattenedLabels"))
+	withStringListAttrAllowScalarDesc := fileDesc.Messages().ByName(protoreflect.Name("WithStringListAttrAllowScalar"))
+	withNumberListAttrAsInt32Desc := fileDesc.Messages().ByName(protoreflect.Name("WithNumberListAttrAsInt32"))
 	withStringSetAttrDesc := fileDesc.Messages().ByName(protoreflect.Name("WithStringSetAttr"))
 	withStringMapAttrDesc := fileDesc.Messages().ByName(protoreflect.Name("WithStringMapAttr"))
+	withEnumAttrDesc := fileDesc.Messages().ByName(protoreflect.Name("WithEnumAttr"))
+	withEnumMapAttrDesc := fileDesc.Messages().ByName(protoreflect.Name("WithEnumMapAttr"))
+	withNumberMapAttrAsInt32Desc := fileDesc.Messages().ByName(protoreflect.Name("WithNumberMapAttrAsInt32"))
+	withMapOfBlocksDesc := fileDesc.Messages().ByName(protoreflect.Name("WithMapOfBlocks"))
+	withMapOfObjectsAttrDesc := fileDesc.Messages().ByName(protoreflect.Name("WithMapOfObjectsAttr"))
+	withListOfObjectsAttrDesc := fileDesc.Messages().ByName(protoreflect.Name("WithListOfObjectsAttr"))
+	withObjectAttrDesc := fileDesc.Messages().ByName(protoreflect.Name("WithObjectAttr"))
+	withNestedObjectsAttrDesc := fileDesc.Messages().ByName(protoreflect.Name("WithNestedObjectsAttr"))
 
 	tests := map[string]struct {
 		config    string
@@ -107,6 +124,49 @@ func TestDecodeBody(t *testing.T) {
 						Start:    hcl.Pos{Line: 2, Column: 11, Byte: 11},
 						End:      hcl.Pos{Line: 2, Column: 75, Byte: 75},
 					},
+					Extra: diagnosticExtra{Category: DiagnosticValueError},
+				},
+			},
+		},
+		"number attribute as int32 positive infinity": {
+			`
+				num = 1/0
+			`,
+			withNumberAttrAsInt32Desc,
+			nil,
+			&testschema.WithNumberAttrAsInt32{},
+			hcl.Diagnostics{
+				{
+					Severity: hcl.DiagError,
+					Summary:  "Unsuitable attribute value",
+					Detail:   "The value must be a whole number.",
+					Subject: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 2, Column: 11, Byte: 11},
+						End:      hcl.Pos{Line: 2, Column: 14, Byte: 14},
+					},
+					Extra: diagnosticExtra{Category: DiagnosticValueError},
+				},
+			},
+		},
+		"number attribute as int32 negative infinity": {
+			`
+				num = -1/0
+			`,
+			withNumberAttrAsInt32Desc,
+			nil,
+			&testschema.WithNumberAttrAsInt32{},
+			hcl.Diagnostics{
+				{
+					Severity: hcl.DiagError,
+					Summary:  "Unsuitable attribute value",
+					Detail:   "The value must be a whole number.",
+					Subject: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 2, Column: 11, Byte: 11},
+						End:      hcl.Pos{Line: 2, Column: 15, Byte: 15},
+					},
+					Extra: diagnosticExtra{Category: DiagnosticValueError},
 				},
 			},
 		},
@@ -127,6 +187,41 @@ func TestDecodeBody(t *testing.T) {
 						Start:    hcl.Pos{Line: 2, Column: 11, Byte: 11},
 						End:      hcl.Pos{Line: 2, Column: 74, Byte: 74},
 					},
+					Extra: diagnosticExtra{Category: DiagnosticValueError},
+				},
+			},
+		},
+		"number attributes as float and double": {
+			`
+				single = 0.5
+				double = -0
+			`,
+			withNumberAttrsAsFloatDesc,
+			nil,
+			&testschema.WithNumberAttrsAsFloat{
+				Single: 0.5,
+				Double: 0,
+			},
+			nil,
+		},
+		"number attribute as float out of range": {
+			`
+				single = 1e39
+			`,
+			withNumberAttrsAsFloatDesc,
+			nil,
+			&testschema.WithNumberAttrsAsFloat{},
+			hcl.Diagnostics{
+				{
+					Severity: hcl.DiagError,
+					Summary:  "Unsuitable attribute value",
+					Detail:   "The value must be between -3.4028234663852886e+38 and 3.4028234663852886e+38.",
+					Subject: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 2, Column: 14, Byte: 14},
+						End:      hcl.Pos{Line: 2, Column: 18, Byte: 18},
+					},
+					Extra: diagnosticExtra{Category: DiagnosticValueError},
 				},
 			},
 		},
@@ -169,6 +264,86 @@ func TestDecodeBody(t *testing.T) {
 			},
 			nil,
 		},
+		"list of strings attribute allowing scalar, with list": {
+			`
+				names = ["Jackson", "Snakob"]
+			`,
+			withStringListAttrAllowScalarDesc,
+			nil,
+			&testschema.WithStringListAttrAllowScalar{
+				Names: []string{"Jackson", "Snakob"},
+			},
+			nil,
+		},
+		"list of strings attribute allowing scalar, with scalar": {
+			`
+				names = "Jackson"
+			`,
+			withStringListAttrAllowScalarDesc,
+			nil,
+			&testschema.WithStringListAttrAllowScalar{
+				Names: []string{"Jackson"},
+			},
+			nil,
+		},
+		"list of int32 attribute": {
+			`
+				nums = [1, 2, 3]
+			`,
+			withNumberListAttrAsInt32Desc,
+			nil,
+			&testschema.WithNumberListAttrAsInt32{
+				Nums: []int32{1, 2, 3},
+			},
+			nil,
+		},
+		"list of int32 attribute with invalid element": {
+			`
+				nums = [1, 2.5, 3]
+			`,
+			withNumberListAttrAsInt32Desc,
+			nil,
+			&testschema.WithNumberListAttrAsInt32{},
+			hcl.Diagnostics{
+				{
+					Severity: hcl.DiagError,
+					Summary:  "Unsuitable attribute value",
+					Detail:   "The value must be a whole number.",
+					// The diagnostic refers only to the invalid element,
+					// rather than to the entire list.
+					Subject: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 2, Column: 16, Byte: 16},
+						End:      hcl.Pos{Line: 2, Column: 19, Byte: 19},
+					},
+					Extra: diagnosticExtra{Category: DiagnosticValueError},
+				},
+			},
+		},
+		"list of int32 attribute from for expression with invalid element": {
+			`
+				nums = [for n in [1, 2.5] : n]
+			`,
+			withNumberListAttrAsInt32Desc,
+			nil,
+			&testschema.WithNumberListAttrAsInt32{},
+			hcl.Diagnostics{
+				{
+					Severity: hcl.DiagError,
+					Summary:  "Unsuitable attribute value",
+					Detail:   "The value must be a whole number.",
+					// A "for" expression doesn't have a static element
+					// for each result, so we can only report the whole
+					// expression.
+					Subject: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 2, Column: 12, Byte: 12},
+						End:      hcl.Pos{Line: 2, Column: 35, Byte: 35},
+					},
+					Extra: diagnosticExtra{Category: DiagnosticValueError},
+				},
+			},
+		},
 		"set of strings attribute": {
 			`
 				names = ["Jackson", "Snakob", "Rufus", "Agnes", "Jackson"]
@@ -202,6 +377,103 @@ func TestDecodeBody(t *testing.T) {
 			},
 			nil,
 		},
+		"map of int32 attribute with invalid element": {
+			`
+				nums = {
+					martin = 1
+					kay    = 2.5
+				}
+			`,
+			withNumberMapAttrAsInt32Desc,
+			nil,
+			&testschema.WithNumberMapAttrAsInt32{},
+			hcl.Diagnostics{
+				{
+					Severity: hcl.DiagError,
+					Summary:  "Unsuitable attribute value",
+					Detail:   `The value for key "kay" must be a whole number.`,
+					Subject: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 4, Column: 15, Byte: 44},
+						End:      hcl.Pos{Line: 4, Column: 18, Byte: 47},
+					},
+					Extra: diagnosticExtra{Category: DiagnosticValueError},
+				},
+			},
+		},
+		"enum attribute": {
+			`
+				level = "debug"
+			`,
+			withEnumAttrDesc,
+			nil,
+			&testschema.WithEnumAttr{
+				Level: testschema.Level_LEVEL_DEBUG,
+			},
+			nil,
+		},
+		"enum attribute with invalid value": {
+			`
+				level = "verbose"
+			`,
+			withEnumAttrDesc,
+			nil,
+			&testschema.WithEnumAttr{},
+			hcl.Diagnostics{
+				{
+					Severity: hcl.DiagError,
+					Summary:  "Unsuitable attribute value",
+					Detail:   `The value must be "LEVEL_UNSPECIFIED", "debug", or "info".`,
+					Subject: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 2, Column: 13, Byte: 13},
+						End:      hcl.Pos{Line: 2, Column: 22, Byte: 22},
+					},
+					Extra: diagnosticExtra{Category: DiagnosticValueError},
+				},
+			},
+		},
+		"map of enum attribute": {
+			`
+				levels = {
+					martin = "debug"
+					kay    = "info"
+				}
+			`,
+			withEnumMapAttrDesc,
+			nil,
+			&testschema.WithEnumMapAttr{
+				Levels: map[string]testschema.Level{
+					"martin": testschema.Level_LEVEL_DEBUG,
+					"kay":    testschema.Level_LEVEL_INFO,
+				},
+			},
+			nil,
+		},
+		"map of enum attribute with invalid element": {
+			`
+				levels = {
+					martin = "debug"
+					kay    = "verbose"
+				}
+			`,
+			withEnumMapAttrDesc,
+			nil,
+			&testschema.WithEnumMapAttr{},
+			hcl.Diagnostics{
+				{
+					Severity: hcl.DiagError,
+					Summary:  "Unsuitable attribute value",
+					Detail:   `The value for key "kay" must be "LEVEL_UNSPECIFIED", "debug", or "info".`,
+					Subject: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 4, Column: 15, Byte: 52},
+						End:      hcl.Pos{Line: 4, Column: 24, Byte: 61},
+					},
+					Extra: diagnosticExtra{Category: DiagnosticValueError},
+				},
+			},
+		},
 		"raw dynamic attribute as string": {
 			`
 				raw = "Hello"
@@ -209,7 +481,7 @@ func TestDecodeBody(t *testing.T) {
 			simpleRawRootDesc,
 			nil,
 			&testschema.WithRawDynamicAttr{
-				Raw: []byte(`{"value":"Hello","type":"string"}`),
+				Raw: []byte(`{"version":1,"type":"string","value":"Hello"}`),
 			},
 			nil,
 		},
@@ -220,7 +492,7 @@ func TestDecodeBody(t *testing.T) {
 			simpleRawRootDesc,
 			nil,
 			&testschema.WithRawDynamicAttr{
-				Raw: []byte(`{"value":2,"type":"number"}`),
+				Raw: []byte(`{"version":1,"type":"number","value":2}`),
 			},
 			nil,
 		},
@@ -235,6 +507,22 @@ func TestDecodeBody(t *testing.T) {
 			},
 			nil,
 		},
+		"raw dynamic attribute as typed null": {
+			`
+				raw = typed_null
+			`,
+			simpleRawRootDesc,
+			&hcl.EvalContext{
+				Variables: map[string]cty.Value{
+					"typed_null": cty.NullVal(cty.List(cty.String)),
+				},
+			},
+			&testschema.WithRawDynamicAttr{
+				// A typed null is recorded so that its type isn't lost.
+				Raw: []byte(`{"version":1,"type":["list","string"],"value":null}`),
+			},
+			nil,
+		},
 		"struct dynamic attribute as null": {
 			`
 				struct = null
@@ -432,6 +720,7 @@ func TestDecodeBody(t *testing.T) {
 						Start:    hcl.Pos{Line: 5, Column: 4, Byte: 42},
 						End:      hcl.Pos{Line: 5, Column: 10, Byte: 48},
 					},
+					Extra: diagnosticExtra{Category: DiagnosticConflict},
 				},
 			},
 		},
@@ -451,6 +740,232 @@ func TestDecodeBody(t *testing.T) {
 			},
 			nil,
 		},
+		"repeated block type with one label": {
+			`
+				doodad "Jackson" {
+					nickname = "doofus"
+				}
+				doodad "Snakob" {
+				}
+			`,
+			withNestedBlockOneLabelRepeatedDesc,
+			nil,
+			&testschema.WithNestedBlockOneLabelRepeated{
+				Doodad: []*testschema.WithOneBlockLabel{
+					{
+						Name:     "Jackson",
+						Nickname: "doofus",
+					},
+					{
+						Name: "Snakob",
+					},
+				},
+			},
+			nil,
+		},
+		"repeated block type with label from flattened message": {
+			`
+				doodad "bird" "Jackson" {
+					nickname = "doofus"
+					species  = "budgerigar"
+				}
+			`,
+			withNestedBlockFlattenedLabelsDesc,
+			nil,
+			&testschema.WithNestedBlockFlattenedLabels{
+				Doodad: []*testschema.WithFlattenedBlockLabel{
+					{
+						Type: "bird",
+						Base: &testschema.WithOneBlockLabel{
+							Name:     "Jackson",
+							Nickname: "doofus",
+						},
+						Species: "budgerigar",
+					},
+				},
+			},
+			nil,
+		},
+		"same block type name at different nesting levels": {
+			`
+				item {
+					name = "outer"
+					item {
+						size = 2
+					}
+				}
+			`,
+			withSameBlockTypeNestedDesc,
+			nil,
+			&testschema.WithSameBlockTypeNested{
+				Item: &testschema.SameBlockTypeOuter{
+					Name: "outer",
+					Item: &testschema.SameBlockTypeInner{
+						Size: 2,
+					},
+				},
+			},
+			nil,
+		},
+		"invalid schema in nested block types": {
+			`
+				a {}
+				b {}
+			`,
+			withInvalidNestedBlocksDesc,
+			nil,
+			&testschema.WithInvalidNestedBlocks{
+				A: &testschema.InvalidBlockBody{},
+				B: &testschema.InvalidBlockBody{},
+			},
+			hcl.Diagnostics{
+				{
+					Severity: hcl.DiagError,
+					Summary:  "Invalid configuration schema",
+					Detail:   "Invalid HCL annotations in protobuf schema for hcl.testschema.InvalidBlockBody.other_name (for a block): declaration of attribute \"name\" conflicts with hcl.testschema.InvalidBlockBody.name.\n\nThis is a bug in the component that defined this schema, and not an error in the given configuration.",
+					Extra:    diagnosticExtra{Category: DiagnosticSchemaError},
+				},
+				{
+					Severity: hcl.DiagError,
+					Summary:  "Invalid configuration schema",
+					Detail:   "Invalid HCL annotations in protobuf schema for hcl.testschema.InvalidBlockBody.other_name (for b block): declaration of attribute \"name\" conflicts with hcl.testschema.InvalidBlockBody.name.\n\nThis is a bug in the component that defined this schema, and not an error in the given configuration.",
+					Extra:    diagnosticExtra{Category: DiagnosticSchemaError},
+				},
+			},
+		},
+		"map of nested blocks": {
+			`
+				pet "jackson" {
+					name = "Jackson"
+				}
+				pet "snakob" {
+					name = "Snakob"
+				}
+			`,
+			withMapOfBlocksDesc,
+			nil,
+			&testschema.WithMapOfBlocks{
+				Pets: map[string]*testschema.WithStringAttr{
+					"jackson": {Name: "Jackson"},
+					"snakob":  {Name: "Snakob"},
+				},
+			},
+			nil,
+		},
+		"map of nested blocks with duplicate key": {
+			`
+				pet "jackson" {
+					name = "Jackson"
+				}
+				pet "jackson" {
+					name = "Rufus"
+				}
+			`,
+			withMapOfBlocksDesc,
+			nil,
+			&testschema.WithMapOfBlocks{
+				Pets: map[string]*testschema.WithStringAttr{
+					"jackson": {Name: "Jackson"},
+				},
+			},
+			hcl.Diagnostics{
+				{
+					Severity: hcl.DiagError,
+					Summary:  "Duplicate pet block",
+					Detail:   `There may be no more than one pet block with key "jackson". Previous block declared at test.tf:2,5-18.`,
+					Subject: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 5, Column: 9, Byte: 57},
+						End:      hcl.Pos{Line: 5, Column: 18, Byte: 66},
+					},
+					Context: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 5, Column: 5, Byte: 53},
+						End:      hcl.Pos{Line: 5, Column: 18, Byte: 66},
+					},
+					Extra: diagnosticExtra{Category: DiagnosticConflict},
+				},
+			},
+		},
+		"map of objects attribute": {
+			`
+				pets = {
+					jackson = { name = "Jackson" }
+					snakob  = { name = "Snakob" }
+				}
+			`,
+			withMapOfObjectsAttrDesc,
+			nil,
+			&testschema.WithMapOfObjectsAttr{
+				Pets: map[string]*testschema.WithStringAttr{
+					"jackson": {Name: "Jackson"},
+					"snakob":  {Name: "Snakob"},
+				},
+			},
+			nil,
+		},
+		"list of objects attribute with optional attribute": {
+			`
+				items = [
+					{ name = "a", count = 2 },
+					{ name = "b" },
+				]
+			`,
+			withListOfObjectsAttrDesc,
+			nil,
+			&testschema.WithListOfObjectsAttr{
+				Items: []*testschema.WithOptionalAttrs{
+					{Name: "a", Count: 2},
+					{Name: "b"},
+				},
+			},
+			nil,
+		},
+		"object attribute": {
+			`
+				settings = { name = "a", count = 2 }
+			`,
+			withObjectAttrDesc,
+			nil,
+			&testschema.WithObjectAttr{
+				Settings: &testschema.WithOptionalAttrs{Name: "a", Count: 2},
+			},
+			nil,
+		},
+		"object attribute null": {
+			`
+				settings = null
+			`,
+			withObjectAttrDesc,
+			nil,
+			&testschema.WithObjectAttr{},
+			nil,
+		},
+		"list of objects containing maps of objects": {
+			`
+				groups = [
+					{
+						pets = {
+							jackson = { name = "Jackson" }
+						}
+					},
+					{ pets = {} },
+				]
+			`,
+			withNestedObjectsAttrDesc,
+			nil,
+			&testschema.WithNestedObjectsAttr{
+				Groups: []*testschema.WithMapOfObjectsAttr{
+					{
+						Pets: map[string]*testschema.WithStringAttr{
+							"jackson": {Name: "Jackson"},
+						},
+					},
+					{},
+				},
+			},
+			nil,
+		},
 		"flattened message with string attribute": {
 			`
 				name    = "Joey"
@@ -507,6 +1022,136 @@ func TestDecodeBody(t *testing.T) {
 
 }
 
+func TestDecodeBodyUnstorableValues(t *testing.T) {
+	type thing struct{}
+	thingTy := cty.Capsule("thing", reflect.TypeOf(thing{}))
+	thingVal := cty.CapsuleVal(thingTy, &thing{})
+	ctx := &hcl.EvalContext{
+		Variables: map[string]cty.Value{
+			"thing":  thingVal,
+			"things": cty.ListVal([]cty.Value{thingVal}),
+			"secret": cty.StringVal("debug").Mark("sensitive"),
+			"secrets": cty.MapVal(map[string]cty.Value{
+				"a": cty.StringVal("debug").Mark("sensitive"),
+			}),
+			"secret_obj": cty.ObjectVal(map[string]cty.Value{
+				"name": cty.StringVal("Jackson").Mark("sensitive"),
+			}),
+		},
+	}
+
+	tests := map[string]struct {
+		msg        protoreflect.Name
+		config     string
+		wantDetail string
+	}{
+		"capsule for string": {
+			"WithStringAttr",
+			`name = thing`,
+			`Inappropriate value for attribute "name": thing values can't be stored in protobuf fields.`,
+		},
+		"capsule for number": {
+			"WithNumberAttrAsInt32",
+			`num = thing`,
+			`Inappropriate value for attribute "num": thing values can't be stored in protobuf fields.`,
+		},
+		"capsule for bool": {
+			"WithBoolAttr",
+			`do_the_thing = thing`,
+			`Inappropriate value for attribute "do_the_thing": thing values can't be stored in protobuf fields.`,
+		},
+		"capsule for enum": {
+			"WithEnumAttr",
+			`level = thing`,
+			`Inappropriate value for attribute "level": thing values can't be stored in protobuf fields.`,
+		},
+		"capsule in list": {
+			"WithStringListAttr",
+			`names = things`,
+			`Inappropriate value for attribute "names" at [0]: thing values can't be stored in protobuf fields.`,
+		},
+		"capsule in tuple": {
+			"WithStringListAttr",
+			`names = ["a", thing]`,
+			`Inappropriate value for attribute "names" at [1]: thing values can't be stored in protobuf fields.`,
+		},
+		"capsule for struct": {
+			"WithStructDynamicAttr",
+			`struct = thing`,
+			`Inappropriate value for attribute "struct": thing values can't be stored in protobuf fields.`,
+		},
+		"capsule for raw": {
+			"WithRawDynamicAttr",
+			`raw = { a = thing }`,
+			`Inappropriate value for attribute "raw" at .a: thing values can't be stored in protobuf fields.`,
+		},
+		"marked for string": {
+			"WithStringAttr",
+			`name = secret`,
+			`Inappropriate value for attribute "name": marked values, such as sensitive values, can't be stored in protobuf fields.`,
+		},
+		"marked for number": {
+			"WithNumberAttrAsInt32",
+			`num = secret`,
+			`Inappropriate value for attribute "num": marked values, such as sensitive values, can't be stored in protobuf fields.`,
+		},
+		"marked for bool": {
+			"WithBoolAttr",
+			`do_the_thing = secret`,
+			`Inappropriate value for attribute "do_the_thing": marked values, such as sensitive values, can't be stored in protobuf fields.`,
+		},
+		"marked for enum": {
+			"WithEnumAttr",
+			`level = secret`,
+			`Inappropriate value for attribute "level": marked values, such as sensitive values, can't be stored in protobuf fields.`,
+		},
+		"marked in map": {
+			"WithStringMapAttr",
+			`names = secrets`,
+			`Inappropriate value for attribute "names" at ["a"]: marked values, such as sensitive values, can't be stored in protobuf fields.`,
+		},
+		"marked in object": {
+			"WithMapOfObjectsAttr",
+			`pets = { a = secret_obj }`,
+			`Inappropriate value for attribute "pets" at .a.name: marked values, such as sensitive values, can't be stored in protobuf fields.`,
+		},
+		"marked for struct": {
+			"WithStructDynamicAttr",
+			`struct = secret_obj`,
+			`Inappropriate value for attribute "struct" at .name: marked values, such as sensitive values, can't be stored in protobuf fields.`,
+		},
+		"marked for raw": {
+			"WithRawDynamicAttr",
+			`raw = secret`,
+			`Inappropriate value for attribute "raw": marked values, such as sensitive values, can't be stored in protobuf fields.`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			f, diags := hclsyntax.ParseConfig([]byte(test.config), "test.tf", hcl.InitialPos)
+			if diags.HasErrors() {
+				t.Fatalf("parse error: %s", diags)
+			}
+			desc := testschema.File_testschema_proto.Messages().ByName(test.msg)
+
+			_, diags = DecodeBody(f.Body, desc, ctx)
+			if len(diags) != 1 {
+				t.Fatalf("wrong number of diagnostics %d; want 1\n%s", len(diags), diags.Error())
+			}
+			if got, want := diags[0].Summary, unsuitableValueSummary; got != want {
+				t.Errorf("wrong summary\ngot:  %s\nwant: %s", got, want)
+			}
+			if got, want := diags[0].Detail, test.wantDetail; got != want {
+				t.Errorf("wrong detail\ngot:  %s\nwant: %s", got, want)
+			}
+			if diags[0].Subject == nil {
+				t.Errorf("diagnostic has no subject")
+			}
+		})
+	}
+}
+
 func mustStructpbValue(raw interface{}) *structpb.Value {
 	ret, err := structpb.NewValue(raw)
 	if err != nil {
@@ -514,3 +1159,69 @@ func mustStructpbValue(raw interface{}) *structpb.Value {
 	}
 	return ret
 }
+
+func TestDecodeBodyEmpty(t *testing.T) {
+	tests := map[string]struct {
+		msg       protoreflect.Name
+		want      proto.Message
+		wantDiags []string
+	}{
+		"optional attributes": {
+			"WithStringAttr",
+			&testschema.WithStringAttr{},
+			nil,
+		},
+		"required attribute": {
+			"WithOptionalAttrs",
+			&testschema.WithOptionalAttrs{},
+			[]string{`The argument "name" is required, but was not set.`},
+		},
+		"flattened and nested blocks": {
+			"WithFlattenPrefix",
+			&testschema.WithFlattenPrefix{
+				Client: &testschema.TLSSettings{},
+				Server: &testschema.TLSSettings{},
+			},
+			nil,
+		},
+	}
+
+	bodies := map[string]hcl.Body{
+		"nil":   nil,
+		"empty": hcl.EmptyBody(),
+	}
+
+	for name, test := range tests {
+		for bodyName, body := range bodies {
+			t.Run(name+"/"+bodyName, func(t *testing.T) {
+				desc := testschema.File_testschema_proto.Messages().ByName(test.msg)
+				got, diags := DecodeBody(body, desc, nil)
+				var gotDiags []string
+				for _, diag := range diags {
+					gotDiags = append(gotDiags, diag.Detail)
+				}
+				if diff := cmp.Diff(test.wantDiags, gotDiags); diff != "" {
+					t.Errorf("wrong diagnostics\n%s", diff)
+				}
+				if diff := cmp.Diff(test.want, got, protoCmpOpt); diff != "" {
+					t.Errorf("wrong result\n%s", diff)
+				}
+
+				// The other decoding entry points share the same handling.
+				gotBlocks, remain, diags := DecodeAllBlocks(body, "thing", desc, nil)
+				if diags.HasErrors() {
+					t.Errorf("unexpected errors from DecodeAllBlocks: %s", diags.Error())
+				}
+				if len(gotBlocks) != 0 {
+					t.Errorf("DecodeAllBlocks returned %d messages; want none", len(gotBlocks))
+				}
+				if remain == nil {
+					t.Errorf("DecodeAllBlocks returned nil remaining body")
+				}
+				if _, _, diags := DecodeBodyWithTrace(body, desc, nil); len(diags) != len(test.wantDiags) {
+					t.Errorf("wrong number of diagnostics from DecodeBodyWithTrace %d; want %d", len(diags), len(test.wantDiags))
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package protohcl
+
+import (
+	hcl "github.com/hashicorp/hcl/v2"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// DecodeBodyExcept is like DecodeBody except that it ignores any attributes
+// or block types at the top level of the given body that have the given
+// names, treating them as if the message type didn't declare them at all.
+//
+// This is for hosts that need to temporarily take over the handling of some
+// arguments or blocks that a schema declares, such as while rolling out a
+// change to which component is responsible for them. DecodeBodyExcept leaves
+// the corresponding fields unset, doesn't report them as missing even if
+// they are required, and doesn't validate their content in any way, so the
+// host is responsible for decoding and validating them itself.
+//
+// The names apply only to the declarations in the given message type and any
+// messages it flattens, and not to the content of nested blocks. Names that
+// the message type doesn't declare have no effect, and so content using them
+// is still reported as unexpected.
+//
+// Naming a block type also ignores blocks that use any of its
+// (hcl.block).aliases.
+func DecodeBodyExcept(body hcl.Body, desc protoreflect.MessageDescriptor, ctx *hcl.EvalContext, names []string) (proto.Message, hcl.Diagnostics) {
+	return DecodeOptions{}.DecodeBodyExcept(body, desc, ctx, names)
+}
+
+// hideBodyNames returns a body that behaves like the given body except that
+// any attributes or blocks whose names are in except are hidden, as long as
+// the given message type declares them.
+//
+// If the message type's schema is invalid then hideBodyNames returns the
+// given body verbatim, so that decoding can report the schema problem.
+func hideBodyNames(body hcl.Body, desc protoreflect.MessageDescriptor, except map[string]struct{}) hcl.Body {
+	schema, err := bodySchema(desc)
+	if err != nil {
+		return body
+	}
+
+	var hide hcl.BodySchema
+	for _, attrS := range schema.Attributes {
+		if _, excluded := except[attrS.Name]; excluded {
+			attrS.Required = false
+			hide.Attributes = append(hide.Attributes, attrS)
+		}
+	}
+	for _, blockS := range schema.Blocks {
+		if _, excluded := except[blockS.Type]; excluded {
+			hide.Blocks = append(hide.Blocks, blockS)
+		}
+	}
+
+	// The content for the hidden names belongs to the caller, so we ignore
+	// any problems with it here.
+	_, remain, _ := body.PartialContent(&hide)
+	return remain
+}
+
+// schemaWithoutNames returns a copy of the given schema without any
+// attributes or block types whose names are in except.
+func schemaWithoutNames(schema *hcl.BodySchema, except map[string]struct{}) *hcl.BodySchema {
+	ret := &hcl.BodySchema{}
+	for _, attrS := range schema.Attributes {
+		if _, excluded := except[attrS.Name]; !excluded {
+			ret.Attributes = append(ret.Attributes, attrS)
+		}
+	}
+	for _, blockS := range schema.Blocks {
+		if _, excluded := except[blockS.Type]; !excluded {
+			ret.Blocks = append(ret.Blocks, blockS)
+		}
+	}
+	return ret
+}
+
+// addExceptBlockTypeAliases adds to except the aliases of any block types
+// declared by the given message type, or messages it flattens, whose main
+// type names are already in except.
+//
+// Schema errors are ignored here so that decoding can report them.
+func addExceptBlockTypeAliases(except map[string]struct{}, desc protoreflect.MessageDescriptor, outer FieldFlattened) {
+	fields := desc.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		elem, err := GetFieldElem(fields.Get(i))
+		if err != nil {
+			continue
+		}
+		elem = flattenedElem(elem, outer)
+		switch elem := elem.(type) {
+		case FieldNestedBlockType:
+			if _, excluded := except[elem.TypeName]; excluded {
+				for _, alias := range elem.Aliases {
+					except[alias] = struct{}{}
+				}
+			}
+		case FieldFlattened:
+			addExceptBlockTypeAliases(except, elem.Nested, elem)
+		}
+	}
+}
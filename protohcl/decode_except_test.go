@@ -0,0 +1,156 @@
+package protohcl
+
+import (
+	"testing"
+
+	"github.com/apparentlymart/go-protohcl/protohcl/internal/testschema"
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func TestDecodeBodyExcept(t *testing.T) {
+	fileDesc := testschema.File_testschema_proto
+
+	tests := map[string]struct {
+		config      string
+		desc        protoreflect.MessageDescriptor
+		except      []string
+		want        proto.Message
+		wantDiagErr string
+	}{
+		"no names": {
+			`name = "Jackson"`,
+			fileDesc.Messages().ByName(protoreflect.Name("WithStringAttr")),
+			nil,
+			&testschema.WithStringAttr{
+				Name: "Jackson",
+			},
+			``,
+		},
+		"excluded attribute": {
+			`
+name    = "Jackson"
+species = not_a_variable
+`,
+			fileDesc.Messages().ByName(protoreflect.Name("WithFlattenStringAttr")),
+			[]string{"species"},
+			&testschema.WithFlattenStringAttr{
+				Base: &testschema.WithStringAttr{
+					Name: "Jackson",
+				},
+			},
+			``,
+		},
+		"excluded attribute from flattened message": {
+			`
+name    = ["not", "a", "string"]
+species = "snake"
+`,
+			fileDesc.Messages().ByName(protoreflect.Name("WithFlattenStringAttr")),
+			[]string{"name"},
+			&testschema.WithFlattenStringAttr{
+				Base:    &testschema.WithStringAttr{},
+				Species: "snake",
+			},
+			``,
+		},
+		"excluded required attribute": {
+			`count = 2`,
+			fileDesc.Messages().ByName(protoreflect.Name("WithOptionalAttrs")),
+			[]string{"name"},
+			&testschema.WithOptionalAttrs{
+				Count: 2,
+			},
+			``,
+		},
+		"excluded block type": {
+			`
+doodad {
+  unexpected = true
+}
+doodad {
+}
+`,
+			fileDesc.Messages().ByName(protoreflect.Name("WithNestedBlockNoLabelsSingleton")),
+			[]string{"doodad"},
+			&testschema.WithNestedBlockNoLabelsSingleton{},
+			``,
+		},
+		"undeclared name": {
+			`count = 1`,
+			fileDesc.Messages().ByName(protoreflect.Name("WithStringAttr")),
+			[]string{"count"},
+			&testschema.WithStringAttr{},
+			`test.tf:1,1-6: Unsupported argument; An argument named "count" is not expected here.`,
+		},
+		"names in nested blocks": {
+			`
+doodad {
+  name = "Jackson"
+}
+`,
+			fileDesc.Messages().ByName(protoreflect.Name("WithNestedBlockNoLabelsSingleton")),
+			[]string{"name"},
+			&testschema.WithNestedBlockNoLabelsSingleton{
+				Doodad: &testschema.WithStringAttr{
+					Name: "Jackson",
+				},
+			},
+			``,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			f, diags := hclsyntax.ParseConfig([]byte(test.config), "test.tf", hcl.InitialPos)
+			if diags.HasErrors() {
+				t.Fatalf("parse error: %s", diags)
+			}
+
+			got, diags := DecodeBodyExcept(f.Body, test.desc, nil, test.except)
+
+			if diff := cmp.Diff(test.want, got, protoCmpOpt); diff != "" {
+				t.Errorf("wrong result\n%s", diff)
+			}
+			if test.wantDiagErr == "" {
+				if diags.HasErrors() {
+					t.Errorf("unexpected errors: %s", diags.Error())
+				}
+			} else if got, want := diags.Error(), test.wantDiagErr; got != want {
+				t.Errorf("wrong error\ngot:  %s\nwant: %s", got, want)
+			}
+		})
+	}
+}
+
+func TestDecodeOptionsDecodeBodyExceptConsolidateMissingVariables(t *testing.T) {
+	desc := testschema.File_testschema_proto.Messages().ByName(protoreflect.Name("WithFlattenStringAttr"))
+	opts := DecodeOptions{
+		ConsolidateMissingVariables: true,
+	}
+	f, diags := hclsyntax.ParseConfig([]byte(`
+name    = "Jackson"
+species = host_only
+`), "test.tf", hcl.InitialPos)
+	if diags.HasErrors() {
+		t.Fatalf("parse error: %s", diags)
+	}
+
+	// The reference to host_only must not count as a missing variable,
+	// because the host is responsible for evaluating that expression.
+	got, diags := opts.DecodeBodyExcept(f.Body, desc, &hcl.EvalContext{}, []string{"species"})
+	if diags.HasErrors() {
+		t.Fatalf("unexpected errors: %s", diags.Error())
+	}
+	want := &testschema.WithFlattenStringAttr{
+		Base: &testschema.WithStringAttr{
+			Name: "Jackson",
+		},
+	}
+	if diff := cmp.Diff(want, got, protoCmpOpt); diff != "" {
+		t.Errorf("wrong result\n%s", diff)
+	}
+}
@@ -0,0 +1,214 @@
+package protohcl
+
+import (
+	"bytes"
+	"strings"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protopath"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// PreviousDecode describes the result of an earlier call to
+// DecodeBodyWithTrace, or to DecodeBodyIncremental, whose results
+// DecodeBodyIncremental can reuse.
+type PreviousDecode struct {
+	// Message and Trace are the message and trace that the earlier call
+	// returned.
+	Message proto.Message
+	Trace   *DecodeTrace
+
+	// Files are the source files that the earlier body was parsed from,
+	// which DecodeBodyIncremental uses to recognize unchanged expressions.
+	Files map[string]*hcl.File
+}
+
+// DecodeBodyIncremental is like DecodeBodyWithTrace but reuses the values of
+// fields from a previous decode of an earlier version of the same
+// configuration, instead of evaluating their expressions again, wherever an
+// attribute's expression is textually identical to before.
+//
+// This is intended for interactive tools, such as text editors, that decode
+// the same configuration again after each small change. The given files
+// must be the source files that the new body was parsed from.
+//
+// A field is reused only if the previous decode populated it from an
+// attribute at the same path in the message without any diagnostics.
+// Elements of repeated nested blocks are matched by their index, and so
+// adding or removing a block causes the following blocks to be decoded again
+// in full. The result is correct only if the previous decode was of the same
+// message type using the same evaluation context and options, because
+// DecodeBodyIncremental can't detect changes to anything other than the
+// configuration source code.
+//
+// If prev doesn't describe a previous decode of the given message type then
+// DecodeBodyIncremental behaves like DecodeBodyWithTrace.
+func DecodeBodyIncremental(body hcl.Body, files map[string]*hcl.File, desc protoreflect.MessageDescriptor, ctx *hcl.EvalContext, prev PreviousDecode) (proto.Message, *DecodeTrace, hcl.Diagnostics) {
+	return DecodeOptions{}.DecodeBodyIncremental(body, files, desc, ctx, prev)
+}
+
+// fieldReuser decides which fields of a previous decode can be reused in
+// a new decode, for DecodeBodyIncremental.
+type fieldReuser struct {
+	prevMsg   protoreflect.Message
+	prevTrace *DecodeTrace
+	prevFiles map[string]*hcl.File
+	files     map[string]*hcl.File
+
+	// failed is the set of path strings that had diagnostics in the
+	// previous decode, which we must always decode again so that we'll
+	// return the diagnostics again.
+	failed map[string]struct{}
+}
+
+// newFieldReuser returns a fieldReuser for the given previous decode, or nil
+// if nothing from it is reusable when decoding the given message type.
+func newFieldReuser(prev PreviousDecode, files map[string]*hcl.File, desc protoreflect.MessageDescriptor) *fieldReuser {
+	if prev.Message == nil || prev.Trace == nil {
+		return nil
+	}
+	if prev.Message.ProtoReflect().Descriptor().FullName() != desc.FullName() {
+		return nil
+	}
+
+	return &fieldReuser{
+		// We'll take values from a copy of the previous message, so that
+		// the new message won't share any mutable data with it.
+		prevMsg:   proto.Clone(prev.Message).ProtoReflect(),
+		prevTrace: prev.Trace,
+		prevFiles: prev.Files,
+		files:     files,
+		failed:    prev.Trace.failed,
+	}
+}
+
+// reuse tries to populate the given field of the given message from the
+// previous decode, if the previous decode populated the field at the given
+// path from an expression with the same source code as the one now at the
+// given range. If so, it also records the reused field in the given trace,
+// adjusting the previous source ranges to match the new location.
+//
+// The result is true if reuse populated the field.
+func (r *fieldReuser) reuse(msg protoreflect.Message, field protoreflect.FieldDescriptor, path protopath.Path, rng hcl.Range, trace *DecodeTrace) bool {
+	if r == nil {
+		return false
+	}
+	key := path.String()
+	if _, failed := r.failed[key]; failed {
+		return false
+	}
+	prevRng, ok := r.prevTrace.Range(path)
+	if !ok {
+		return false
+	}
+	prevSrc := rangeSource(r.prevFiles, prevRng)
+	src := rangeSource(r.files, rng)
+	if prevSrc == nil || src == nil || !bytes.Equal(prevSrc, src) {
+		return false
+	}
+	val, ok := messageValueAtPath(r.prevMsg, path)
+	if !ok {
+		return false
+	}
+
+	msg.Set(field, val)
+	if trace != nil {
+		for _, prevPath := range r.prevTrace.paths {
+			prevKey := prevPath.String()
+			if prevKey != key && !strings.HasPrefix(prevKey, key+"[") {
+				continue
+			}
+			trace.record(prevPath, shiftRange(r.prevTrace.ranges[prevKey], prevRng, rng))
+			if names, ok := r.prevTrace.variables[prevKey]; ok {
+				trace.variables[prevKey] = names
+			}
+		}
+	}
+	return true
+}
+
+// rangeSource returns the source code covered by the given range, or nil if
+// the given files don't include the range's file.
+func rangeSource(files map[string]*hcl.File, rng hcl.Range) []byte {
+	f, ok := files[rng.Filename]
+	if !ok || f == nil || rng.End.Byte > len(f.Bytes) || rng.Start.Byte > rng.End.Byte {
+		return nil
+	}
+	return rng.SliceBytes(f.Bytes)
+}
+
+// messageValueAtPath returns the value of the field that the given path
+// refers to, starting at the given message, or false if any of the messages
+// along the path are not populated.
+//
+// The path must start with a protopath.Root step and end with a
+// protopath.FieldAccess step.
+func messageValueAtPath(msg protoreflect.Message, path protopath.Path) (protoreflect.Value, bool) {
+	var val protoreflect.Value
+	for i, step := range path {
+		switch step.Kind() {
+		case protopath.RootStep:
+			if i != 0 || step.MessageDescriptor().FullName() != msg.Descriptor().FullName() {
+				return protoreflect.Value{}, false
+			}
+			val = protoreflect.ValueOfMessage(msg)
+		case protopath.FieldAccessStep:
+			m, ok := val.Interface().(protoreflect.Message)
+			if !ok {
+				return protoreflect.Value{}, false
+			}
+			field := step.FieldDescriptor()
+			if field.ContainingMessage().FullName() != m.Descriptor().FullName() || !m.Has(field) {
+				return protoreflect.Value{}, false
+			}
+			val = m.Get(field)
+		case protopath.ListIndexStep:
+			l, ok := val.Interface().(protoreflect.List)
+			if !ok || step.ListIndex() >= l.Len() {
+				return protoreflect.Value{}, false
+			}
+			val = l.Get(step.ListIndex())
+		case protopath.MapIndexStep:
+			m, ok := val.Interface().(protoreflect.Map)
+			if !ok || !m.Has(step.MapIndex()) {
+				return protoreflect.Value{}, false
+			}
+			val = m.Get(step.MapIndex())
+		default:
+			return protoreflect.Value{}, false
+		}
+	}
+	if len(path) < 2 || path[len(path)-1].Kind() != protopath.FieldAccessStep {
+		return protoreflect.Value{}, false
+	}
+	return val, true
+}
+
+// shiftRange returns the given range moved by the same amount as the
+// difference between the start positions of "from" and "to".
+//
+// This is valid only for ranges within "from", and only if the source code
+// covered by "from" and "to" is identical, so that the only effect of
+// the change is to move the whole expression.
+func shiftRange(rng, from, to hcl.Range) hcl.Range {
+	return hcl.Range{
+		Filename: to.Filename,
+		Start:    shiftPos(rng.Start, from.Start, to.Start),
+		End:      shiftPos(rng.End, from.Start, to.Start),
+	}
+}
+
+func shiftPos(pos, from, to hcl.Pos) hcl.Pos {
+	ret := hcl.Pos{
+		Line:   pos.Line + (to.Line - from.Line),
+		Column: pos.Column,
+		Byte:   pos.Byte + (to.Byte - from.Byte),
+	}
+	// Only positions on the first line of the expression move horizontally,
+	// because later lines start at the same column regardless.
+	if pos.Line == from.Line {
+		ret.Column += to.Column - from.Column
+	}
+	return ret
+}
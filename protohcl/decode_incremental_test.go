@@ -0,0 +1,167 @@
+package protohcl
+
+import (
+	"testing"
+
+	"github.com/apparentlymart/go-protohcl/protohcl/internal/testschema"
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func TestDecodeBodyIncremental(t *testing.T) {
+	fileDesc := testschema.File_testschema_proto
+
+	tests := map[string]struct {
+		desc      protoreflect.MessageDescriptor
+		before    string
+		after     string
+		wantCalls []string
+	}{
+		"unchanged": {
+			fileDesc.Messages().ByName(protoreflect.Name("WithStringAttr")),
+			`name = echo("a")`,
+			`name = echo("a")`,
+			nil,
+		},
+		"changed": {
+			fileDesc.Messages().ByName(protoreflect.Name("WithStringAttr")),
+			`name = echo("a")`,
+			`name = echo("b")`,
+			[]string{"b"},
+		},
+		"moved list elements": {
+			fileDesc.Messages().ByName(protoreflect.Name("WithNumberListAttrAsInt32")),
+			"nums = [echo(\"1\"),\n  2]",
+			"\n\nnums   =   [echo(\"1\"),\n  2]",
+			nil,
+		},
+		"nested blocks": {
+			fileDesc.Messages().ByName(protoreflect.Name("WithNestedBlockFlattenedLabels")),
+			`
+doodad "bird" "Jackson" {
+  nickname = echo("doofus")
+  species  = echo("budgerigar")
+}
+`,
+			`
+# A new comment moves everything down
+doodad "bird" "Jackson" {
+  nickname = echo("doofus")
+  species  = echo("cockatiel")
+}
+doodad "bird" "Snakob" {
+  nickname = echo("sneaky")
+}
+`,
+			[]string{"cockatiel", "sneaky"},
+		},
+		"previous error": {
+			fileDesc.Messages().ByName(protoreflect.Name("WithNumberListAttrAsInt32")),
+			`nums = [echo("1"), 3000000000]`,
+			`nums = [echo("1"), 3000000000]`,
+			[]string{"1"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var calls []string
+			ctx := &hcl.EvalContext{
+				Functions: map[string]function.Function{
+					"echo": function.New(&function.Spec{
+						Params: []function.Parameter{{Name: "v", Type: cty.String}},
+						Type:   function.StaticReturnType(cty.String),
+						Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+							calls = append(calls, args[0].AsString())
+							return args[0], nil
+						},
+					}),
+				},
+			}
+
+			beforeFile, diags := hclsyntax.ParseConfig([]byte(test.before), "test.tf", hcl.InitialPos)
+			if diags.HasErrors() {
+				t.Fatalf("parse error: %s", diags)
+			}
+			prevMsg, prevTrace, _ := DecodeBodyWithTrace(beforeFile.Body, test.desc, ctx)
+			afterFile, diags := hclsyntax.ParseConfig([]byte(test.after), "test.tf", hcl.InitialPos)
+			if diags.HasErrors() {
+				t.Fatalf("parse error: %s", diags)
+			}
+
+			// The incremental result should be indistinguishable from a full
+			// decode of the new body, aside from which expressions we
+			// evaluated to produce it.
+			wantMsg, wantTrace, wantDiags := DecodeBodyWithTrace(afterFile.Body, test.desc, ctx)
+			calls = nil
+
+			gotMsg, gotTrace, gotDiags := DecodeBodyIncremental(
+				afterFile.Body,
+				map[string]*hcl.File{"test.tf": afterFile},
+				test.desc, ctx,
+				PreviousDecode{
+					Message: prevMsg,
+					Trace:   prevTrace,
+					Files:   map[string]*hcl.File{"test.tf": beforeFile},
+				},
+			)
+
+			if diff := cmp.Diff(test.wantCalls, calls); diff != "" {
+				t.Errorf("wrong expressions evaluated\n%s", diff)
+			}
+			if diff := cmp.Diff(wantMsg, gotMsg, protoCmpOpt); diff != "" {
+				t.Errorf("wrong result\n%s", diff)
+			}
+			if got, want := gotDiags.Error(), wantDiags.Error(); got != want {
+				t.Errorf("wrong diagnostics\ngot:  %s\nwant: %s", got, want)
+			}
+			if diff := cmp.Diff(traceRanges(wantTrace), traceRanges(gotTrace)); diff != "" {
+				t.Errorf("wrong trace\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDecodeBodyIncrementalNoPrevious(t *testing.T) {
+	desc := testschema.File_testschema_proto.Messages().ByName(protoreflect.Name("WithStringAttr"))
+	f, diags := hclsyntax.ParseConfig([]byte(`name = "Jackson"`), "test.tf", hcl.InitialPos)
+	if diags.HasErrors() {
+		t.Fatalf("parse error: %s", diags)
+	}
+	files := map[string]*hcl.File{"test.tf": f}
+
+	// A previous decode of a different message type is not reusable.
+	otherDesc := testschema.File_testschema_proto.Messages().ByName(protoreflect.Name("WithFlattenStringAttr"))
+	otherMsg, otherTrace, _ := DecodeBodyWithTrace(f.Body, otherDesc, nil)
+
+	for name, prev := range map[string]PreviousDecode{
+		"none":       {},
+		"other type": {Message: otherMsg, Trace: otherTrace, Files: files},
+	} {
+		t.Run(name, func(t *testing.T) {
+			got, _, diags := DecodeBodyIncremental(f.Body, files, desc, nil, prev)
+			if diags.HasErrors() {
+				t.Fatalf("unexpected errors: %s", diags)
+			}
+			want := &testschema.WithStringAttr{
+				Name: "Jackson",
+			}
+			if diff := cmp.Diff(proto.Message(want), got, protoCmpOpt); diff != "" {
+				t.Errorf("wrong result\n%s", diff)
+			}
+		})
+	}
+}
+
+func traceRanges(trace *DecodeTrace) map[string]hcl.Range {
+	ret := make(map[string]hcl.Range)
+	for _, path := range trace.Paths() {
+		ret[path.String()], _ = trace.Range(path)
+	}
+	return ret
+}
@@ -0,0 +1,101 @@
+package protohcl
+
+import (
+	hcl "github.com/hashicorp/hcl/v2"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protopath"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// DecodeBodyInto is like DecodeBody except that it decodes into the given
+// existing message, layering the content of the body over whatever the
+// message already contains. This allows a host to build a single message
+// from several layers of configuration, such as a system-wide file
+// followed by a per-user file, by decoding each of them in turn into the
+// same message.
+//
+// The content of the body affects the existing message as follows:
+//
+//   - An attribute that the body defines overwrites the field, and so an
+//     attribute explicitly set to null clears it, including the presence
+//     of a proto3 "optional" field. An attribute that the body doesn't
+//     define leaves the field unchanged, and a required attribute is
+//     reported as missing only if the field isn't already set.
+//   - A block of a singleton block type is merged into the existing nested
+//     message, if any, using these same rules.
+//   - The blocks of a repeated block type are appended to the existing
+//     elements by default, with each block of a block type declared as a
+//     map field merged into any existing element with the same key. Set
+//     DecodeOptions.RepeatedBlocks to ReplaceRepeatedBlocks to instead
+//     replace all of the existing elements with the blocks from the body.
+//   - A block type that the body doesn't use leaves the field unchanged.
+//
+// The message must be mutable. If the diagnostics include errors then the
+// message may be left partially updated.
+//
+// DecodeBodyInto doesn't support DecodeOptions.InferAttributesFromJSONNames,
+// and so a message with no HCL annotations at all is left unchanged.
+func DecodeBodyInto(body hcl.Body, msg proto.Message, ctx *hcl.EvalContext) hcl.Diagnostics {
+	return DecodeOptions{}.DecodeBodyInto(body, msg, ctx)
+}
+
+// DecodeBodyInto is like DecodeBody but decodes into the given existing
+// message, using the receiving options.
+//
+// See the package-level function DecodeBodyInto for more information.
+func (opts DecodeOptions) DecodeBodyInto(body hcl.Body, msg proto.Message, ctx *hcl.EvalContext) hcl.Diagnostics {
+	if body == nil {
+		body = hcl.EmptyBody()
+	}
+	msgR := msg.ProtoReflect()
+	desc := msgR.Descriptor()
+	if diags := opts.precheck(body, desc, ctx); diags.HasErrors() {
+		return diags
+	}
+
+	d := decoder{opts: opts, merge: true}
+	diags := d.decodeBodyInto(body, msgR, protopath.Path{protopath.Root(desc)}, ctx)
+	SortDiagnostics(diags)
+	return DeduplicateDiagnostics(diags)
+}
+
+// RepeatedBlocksMode is the type of DecodeOptions.RepeatedBlocks.
+type RepeatedBlocksMode int
+
+const (
+	// AppendRepeatedBlocks is the default RepeatedBlocksMode, which appends
+	// the blocks from each layer to those from earlier layers.
+	AppendRepeatedBlocks RepeatedBlocksMode = 0
+
+	// ReplaceRepeatedBlocks is a RepeatedBlocksMode in which the blocks of a
+	// repeated block type in one layer replace all of those from earlier
+	// layers.
+	ReplaceRepeatedBlocks RepeatedBlocksMode = 1
+)
+
+// decodeBodyInto is like decodeBody but populates the given existing
+// message instead of a new one.
+func (d *decoder) decodeBodyInto(body hcl.Body, msg protoreflect.Message, path protopath.Path, ctx *hcl.EvalContext) hcl.Diagnostics {
+	var diags hcl.Diagnostics
+
+	schema, err := bodySchema(msg.Descriptor())
+	if err != nil {
+		err = schemaErrorInBlock(err, blockPathForProtoPath(path))
+		return diags.Append(schemaErrorDiagnostic(err))
+	}
+	moreDiags := d.fillMessageFromBody(body, schema, msg, path, ctx, nil)
+	return append(diags, moreDiags...)
+}
+
+// schemaWithoutRequired returns a copy of the given schema in which none of
+// the attributes are required.
+func schemaWithoutRequired(schema *hcl.BodySchema) *hcl.BodySchema {
+	ret := &hcl.BodySchema{
+		Blocks: schema.Blocks,
+	}
+	for _, attrS := range schema.Attributes {
+		attrS.Required = false
+		ret.Attributes = append(ret.Attributes, attrS)
+	}
+	return ret
+}
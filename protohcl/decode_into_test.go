@@ -0,0 +1,292 @@
+package protohcl
+
+import (
+	"testing"
+
+	"github.com/apparentlymart/go-protohcl/protohcl/internal/testschema"
+	"github.com/apparentlymart/go-protohcl/protohcl/protohcltest"
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestDecodeBodyInto(t *testing.T) {
+	base := `
+		name        = "base"
+		description = "from base"
+		count       = 2
+		base_name   = "flattened"
+		settings {
+			name  = "settings"
+			count = 1
+		}
+		rule "a" {}
+		pet "fido" {
+			name = "Fido"
+		}
+	`
+
+	tests := map[string]struct {
+		opts      DecodeOptions
+		overlay   string
+		want      *testschema.WithMergeableContent
+		wantDiags []protohcltest.ExpectedDiagnostic
+	}{
+		"empty overlay": {
+			DecodeOptions{},
+			``,
+			&testschema.WithMergeableContent{
+				Name:        "base",
+				Description: proto.String("from base"),
+				Count:       2,
+				Settings:    &testschema.WithOptionalAttrs{Name: "settings", Count: 1},
+				Rule: []*testschema.WithOneBlockLabel{
+					{Name: "a"},
+				},
+				Pets: map[string]*testschema.WithStringAttr{
+					"fido": {Name: "Fido"},
+				},
+				Base: &testschema.WithStringAttr{Name: "flattened"},
+			},
+			nil,
+		},
+		"attributes overwrite": {
+			DecodeOptions{},
+			`
+				count     = 3
+				base_name = "changed"
+			`,
+			&testschema.WithMergeableContent{
+				Name:        "base",
+				Description: proto.String("from base"),
+				Count:       3,
+				Settings:    &testschema.WithOptionalAttrs{Name: "settings", Count: 1},
+				Rule: []*testschema.WithOneBlockLabel{
+					{Name: "a"},
+				},
+				Pets: map[string]*testschema.WithStringAttr{
+					"fido": {Name: "Fido"},
+				},
+				Base: &testschema.WithStringAttr{Name: "changed"},
+			},
+			nil,
+		},
+		"null clears optional field presence": {
+			DecodeOptions{},
+			`
+				description = null
+			`,
+			&testschema.WithMergeableContent{
+				Name:     "base",
+				Count:    2,
+				Settings: &testschema.WithOptionalAttrs{Name: "settings", Count: 1},
+				Rule: []*testschema.WithOneBlockLabel{
+					{Name: "a"},
+				},
+				Pets: map[string]*testschema.WithStringAttr{
+					"fido": {Name: "Fido"},
+				},
+				Base: &testschema.WithStringAttr{Name: "flattened"},
+			},
+			nil,
+		},
+		"empty string keeps optional field presence": {
+			DecodeOptions{},
+			`
+				description = ""
+			`,
+			&testschema.WithMergeableContent{
+				Name:        "base",
+				Description: proto.String(""),
+				Count:       2,
+				Settings:    &testschema.WithOptionalAttrs{Name: "settings", Count: 1},
+				Rule: []*testschema.WithOneBlockLabel{
+					{Name: "a"},
+				},
+				Pets: map[string]*testschema.WithStringAttr{
+					"fido": {Name: "Fido"},
+				},
+				Base: &testschema.WithStringAttr{Name: "flattened"},
+			},
+			nil,
+		},
+		"singleton block merges": {
+			DecodeOptions{},
+			`
+				settings {
+					count = 5
+				}
+			`,
+			&testschema.WithMergeableContent{
+				Name:        "base",
+				Description: proto.String("from base"),
+				Count:       2,
+				Settings:    &testschema.WithOptionalAttrs{Name: "settings", Count: 5},
+				Rule: []*testschema.WithOneBlockLabel{
+					{Name: "a"},
+				},
+				Pets: map[string]*testschema.WithStringAttr{
+					"fido": {Name: "Fido"},
+				},
+				Base: &testschema.WithStringAttr{Name: "flattened"},
+			},
+			nil,
+		},
+		"repeated blocks append": {
+			DecodeOptions{},
+			`
+				rule "b" {}
+				pet "fido" {
+					name = "Fido II"
+				}
+				pet "rex" {
+					name = "Rex"
+				}
+			`,
+			&testschema.WithMergeableContent{
+				Name:        "base",
+				Description: proto.String("from base"),
+				Count:       2,
+				Settings:    &testschema.WithOptionalAttrs{Name: "settings", Count: 1},
+				Rule: []*testschema.WithOneBlockLabel{
+					{Name: "a"},
+					{Name: "b"},
+				},
+				Pets: map[string]*testschema.WithStringAttr{
+					"fido": {Name: "Fido II"},
+					"rex":  {Name: "Rex"},
+				},
+				Base: &testschema.WithStringAttr{Name: "flattened"},
+			},
+			nil,
+		},
+		"repeated blocks replace": {
+			DecodeOptions{RepeatedBlocks: ReplaceRepeatedBlocks},
+			`
+				rule "b" {}
+				pet "rex" {
+					name = "Rex"
+				}
+			`,
+			&testschema.WithMergeableContent{
+				Name:        "base",
+				Description: proto.String("from base"),
+				Count:       2,
+				Settings:    &testschema.WithOptionalAttrs{Name: "settings", Count: 1},
+				Rule: []*testschema.WithOneBlockLabel{
+					{Name: "b"},
+				},
+				Pets: map[string]*testschema.WithStringAttr{
+					"rex": {Name: "Rex"},
+				},
+				Base: &testschema.WithStringAttr{Name: "flattened"},
+			},
+			nil,
+		},
+		"repeated blocks replace only when used": {
+			DecodeOptions{RepeatedBlocks: ReplaceRepeatedBlocks},
+			`
+				count = 0
+			`,
+			&testschema.WithMergeableContent{
+				Name:        "base",
+				Description: proto.String("from base"),
+				Settings:    &testschema.WithOptionalAttrs{Name: "settings", Count: 1},
+				Rule: []*testschema.WithOneBlockLabel{
+					{Name: "a"},
+				},
+				Pets: map[string]*testschema.WithStringAttr{
+					"fido": {Name: "Fido"},
+				},
+				Base: &testschema.WithStringAttr{Name: "flattened"},
+			},
+			nil,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			msg := &testschema.WithMergeableContent{}
+			diags := DecodeBodyInto(parseTestBody(t, base), msg, nil)
+			protohcltest.AssertDiagnostics(t, diags)
+
+			diags = test.opts.DecodeBodyInto(parseTestBody(t, test.overlay), msg, nil)
+			protohcltest.AssertDiagnostics(t, diags, test.wantDiags...)
+			if diff := cmp.Diff(test.want, msg, protoCmpOpt); diff != "" {
+				t.Errorf("wrong result\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDecodeBodyIntoRequired(t *testing.T) {
+	t.Run("missing from all layers", func(t *testing.T) {
+		msg := &testschema.WithMergeableContent{}
+		diags := DecodeBodyInto(parseTestBody(t, `count = 1`), msg, nil)
+		protohcltest.AssertDiagnostics(t, diags,
+			protohcltest.Error("Missing required argument").
+				WithDetail(`The argument "name" is required, but no definition was found.`),
+		)
+	})
+	t.Run("missing from nested block", func(t *testing.T) {
+		msg := &testschema.WithMergeableContent{Name: "base"}
+		diags := DecodeBodyInto(parseTestBody(t, "settings {\n}"), msg, nil)
+		protohcltest.AssertDiagnostics(t, diags,
+			protohcltest.Error("Missing required argument").
+				WithDetail(`The argument "name" is required, but no definition was found.`),
+		)
+	})
+	t.Run("set by an earlier layer", func(t *testing.T) {
+		msg := &testschema.WithMergeableContent{Name: "base"}
+		diags := DecodeBodyInto(parseTestBody(t, `count = 1`), msg, nil)
+		protohcltest.AssertDiagnostics(t, diags)
+		want := &testschema.WithMergeableContent{
+			Name:  "base",
+			Count: 1,
+			Base:  &testschema.WithStringAttr{}, // flattened messages are always present
+		}
+		if diff := cmp.Diff(want, msg, protoCmpOpt); diff != "" {
+			t.Errorf("wrong result\n%s", diff)
+		}
+	})
+	t.Run("cleared by null", func(t *testing.T) {
+		msg := &testschema.WithMergeableContent{Name: "base"}
+		diags := DecodeBodyInto(parseTestBody(t, `name = null`), msg, nil)
+		protohcltest.AssertDiagnostics(t, diags,
+			protohcltest.Error("Unsuitable attribute value").
+				WithDetail(`Attribute "name" is required, so must not be null.`),
+		)
+	})
+}
+
+func TestDecodeBodyMatchesDecodeBodyIntoEmpty(t *testing.T) {
+	// Decoding into an empty message must give the same result as decoding
+	// into a new one.
+	src := `
+		name = "example"
+		settings {
+			name = "settings"
+		}
+		rule "a" {}
+		rule "b" {}
+	`
+	want, diags := DecodeBody(parseTestBody(t, src), (&testschema.WithMergeableContent{}).ProtoReflect().Descriptor(), nil)
+	protohcltest.AssertDiagnostics(t, diags)
+
+	got := &testschema.WithMergeableContent{}
+	diags = DecodeBodyInto(parseTestBody(t, src), got, nil)
+	protohcltest.AssertDiagnostics(t, diags)
+	if diff := cmp.Diff(want, got, protoCmpOpt); diff != "" {
+		t.Errorf("wrong result\n%s", diff)
+	}
+}
+
+func parseTestBody(t *testing.T, src string) hcl.Body {
+	t.Helper()
+	f, diags := hclsyntax.ParseConfig([]byte(src), "", hcl.InitialPos)
+	if diags.HasErrors() {
+		t.Fatalf("unexpected syntax errors: %s", diags.Error())
+	}
+	return f.Body
+}
@@ -0,0 +1,167 @@
+package protohcl
+
+import (
+	"time"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// DecodeMany decodes each of the given bodies into a message that conforms
+// to the given message descriptor, returning the messages and the
+// diagnostics for each body at the same indices as the bodies.
+//
+// The result is the same as calling DecodeBody separately for each body,
+// but DecodeMany derives the HCL schema, field elements, and type
+// constraints for the message type only once for the whole batch, so it's
+// considerably faster for a host that decodes many small bodies of the same
+// type, such as one block for each of many plugin instances.
+func DecodeMany(bodies []hcl.Body, desc protoreflect.MessageDescriptor, ctx *hcl.EvalContext) ([]proto.Message, []hcl.Diagnostics) {
+	return DecodeOptions{}.DecodeMany(bodies, desc, ctx)
+}
+
+// DecodeManyUntil is like DecodeMany but stops decoding once the given
+// deadline has passed, returning the number of bodies it decoded.
+//
+// See the DecodeManyUntil method of DecodeOptions for more information.
+func DecodeManyUntil(bodies []hcl.Body, desc protoreflect.MessageDescriptor, ctx *hcl.EvalContext, deadline time.Time) ([]proto.Message, []hcl.Diagnostics, int) {
+	return DecodeOptions{}.DecodeManyUntil(bodies, desc, ctx, deadline)
+}
+
+// DecodeMany is like DecodeBody but decodes a batch of bodies, using the
+// receiving options.
+//
+// See the package-level function DecodeMany for more information.
+func (opts DecodeOptions) DecodeMany(bodies []hcl.Body, desc protoreflect.MessageDescriptor, ctx *hcl.EvalContext) ([]proto.Message, []hcl.Diagnostics) {
+	msgs, diags, _ := opts.DecodeManyUntil(bodies, desc, ctx, time.Time{})
+	return msgs, diags
+}
+
+// DecodeManyUntil is like DecodeMany but stops decoding once the given
+// deadline has passed, so that an interactive tool can show the results it
+// has so far and then continue with the remaining bodies later.
+//
+// DecodeManyUntil decodes the bodies in order and checks the deadline only
+// between bodies, so it never returns a partial result for a single body.
+// The third return value is the number of bodies it decoded, which are the
+// first bodies in the given slice; the messages and diagnostics of any
+// bodies after those are nil. A zero deadline means that there is no
+// deadline, and so DecodeManyUntil decodes all of the bodies.
+//
+// To continue after a deadline, call DecodeManyUntil again with only the
+// remaining bodies.
+func (opts DecodeOptions) DecodeManyUntil(bodies []hcl.Body, desc protoreflect.MessageDescriptor, ctx *hcl.EvalContext, deadline time.Time) ([]proto.Message, []hcl.Diagnostics, int) {
+	msgs := make([]proto.Message, len(bodies))
+	diags := make([]hcl.Diagnostics, len(bodies))
+	cache := newSchemaCache()
+	for i, body := range bodies {
+		if !deadline.IsZero() && !timeNow().Before(deadline) {
+			return msgs, diags, i
+		}
+		msgs[i], diags[i] = opts.decode(body, desc, ctx, nil, decoder{cache: cache})
+	}
+	return msgs, diags, len(bodies)
+}
+
+// timeNow is time.Now, except when tests replace it to simulate the passing
+// of time.
+var timeNow = time.Now
+
+// schemaCache remembers the schema information that the decoder derives
+// from message and field descriptors, so that decoding a batch of bodies
+// can derive it only once.
+//
+// It remembers only successful results, so that each body that
+// encounters a schema error gets its own diagnostics for it.
+type schemaCache struct {
+	bodySchemas     map[protoreflect.MessageDescriptor]*hcl.BodySchema
+	fieldElems      map[protoreflect.FieldDescriptor]FieldElem
+	typeConstraints map[protoreflect.FieldDescriptor]cty.Type
+	msgBuilders     map[protoreflect.FieldDescriptor]attrMessageBuilder
+}
+
+func newSchemaCache() *schemaCache {
+	return &schemaCache{
+		bodySchemas:     make(map[protoreflect.MessageDescriptor]*hcl.BodySchema),
+		fieldElems:      make(map[protoreflect.FieldDescriptor]FieldElem),
+		typeConstraints: make(map[protoreflect.FieldDescriptor]cty.Type),
+		msgBuilders:     make(map[protoreflect.FieldDescriptor]attrMessageBuilder),
+	}
+}
+
+// bodySchema is like the package-level bodySchema but uses the decoder's
+// schema cache, if any.
+func (d *decoder) bodySchema(desc protoreflect.MessageDescriptor) (*hcl.BodySchema, error) {
+	if d.cache == nil {
+		return bodySchema(desc)
+	}
+	if schema, ok := d.cache.bodySchemas[desc]; ok {
+		return schema, nil
+	}
+	schema, err := bodySchema(desc)
+	if err == nil {
+		d.cache.bodySchemas[desc] = schema
+	}
+	return schema, err
+}
+
+// fieldElem is like GetFieldElem but uses the decoder's schema cache, if
+// any.
+func (d *decoder) fieldElem(field protoreflect.FieldDescriptor) (FieldElem, error) {
+	if d.cache == nil {
+		return GetFieldElem(field)
+	}
+	if elem, ok := d.cache.fieldElems[field]; ok {
+		return elem, nil
+	}
+	elem, err := GetFieldElem(field)
+	if err == nil {
+		d.cache.fieldElems[field] = elem
+	}
+	return elem, err
+}
+
+// typeConstraint is like the TypeConstraint method of the given attribute
+// but uses the decoder's schema cache, if any.
+//
+// An attribute's type constraint depends only on the options of its target
+// field, and so the cache is keyed by that field even though a flattened
+// attribute might have a different name.
+func (d *decoder) typeConstraint(attr FieldAttribute) (cty.Type, hcl.Diagnostics) {
+	if d.cache == nil {
+		return attr.TypeConstraint()
+	}
+	if ty, ok := d.cache.typeConstraints[attr.TargetField]; ok {
+		return ty, nil
+	}
+	ty, diags := attr.TypeConstraint()
+	if !diags.HasErrors() {
+		d.cache.typeConstraints[attr.TargetField] = ty
+	}
+	return ty, diags
+}
+
+// valueForMessageField is like the package-level valueForMessageField but
+// uses the decoder's schema cache, if any.
+func (d *decoder) valueForMessageField(v cty.Value, attr FieldAttribute, parentMessage protoreflect.Message) (protoreflect.Value, error) {
+	if d.cache == nil {
+		return valueForMessageField(v, attr, parentMessage, d.opts.AnyTypes)
+	}
+	builder, ok := d.cache.msgBuilders[attr.TargetField]
+	if !ok {
+		wantTy, diags := d.typeConstraint(attr)
+		if diags.HasErrors() {
+			return nilProtoValue, schemaErrorf(attr.TargetField.FullName(), "invalid HCL type constraint")
+		}
+		var err error
+		builder, err = getFieldAttrMessageBuilder(attr, wantTy, d.opts.AnyTypes)
+		if err != nil {
+			return nilProtoValue, err
+		}
+		d.cache.msgBuilders[attr.TargetField] = builder
+	}
+	path := make(cty.Path, 0, 4) // some capacity to grow
+	return builder(v, path, parentMessage)
+}
@@ -0,0 +1,142 @@
+package protohcl
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apparentlymart/go-protohcl/protohcl/internal/testschema"
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl/v2"
+)
+
+func TestDecodeMany(t *testing.T) {
+	desc := (&testschema.WithNestedBlockOneLabelRepeated{}).ProtoReflect().Descriptor()
+	bodies := []hcl.Body{
+		parseTestBody(t, `
+			doodad "a" {
+				nickname = "A"
+			}
+		`),
+		parseTestBody(t, `
+			doodad "b" {
+				nickname = ["B"]
+			}
+			doodad "c" {
+				nickname = "C"
+			}
+		`),
+		nil,
+		parseTestBody(t, `
+			doodad {
+			}
+		`),
+	}
+
+	gotMsgs, gotDiags := DecodeMany(bodies, desc, nil)
+	if got, want := len(gotMsgs), len(bodies); got != want {
+		t.Fatalf("wrong number of messages %d; want %d", got, want)
+	}
+	if got, want := len(gotDiags), len(bodies); got != want {
+		t.Fatalf("wrong number of diagnostics slices %d; want %d", got, want)
+	}
+
+	// Each result must be the same as decoding the body on its own.
+	for i, body := range bodies {
+		wantMsg, wantDiags := DecodeBody(body, desc, nil)
+		if diff := cmp.Diff(wantMsg, gotMsgs[i], protoCmpOpt); diff != "" {
+			t.Errorf("wrong message %d\n%s", i, diff)
+		}
+		if got, want := gotDiags[i].Error(), wantDiags.Error(); got != want {
+			t.Errorf("wrong diagnostics %d\ngot:  %s\nwant: %s", i, got, want)
+		}
+	}
+	if gotDiags[0].HasErrors() || gotDiags[2].HasErrors() {
+		t.Errorf("unexpected errors for valid bodies\n0: %s\n2: %s", gotDiags[0].Error(), gotDiags[2].Error())
+	}
+	if !gotDiags[1].HasErrors() || !gotDiags[3].HasErrors() {
+		t.Errorf("missing errors for invalid bodies\n1: %s\n3: %s", gotDiags[1].Error(), gotDiags[3].Error())
+	}
+}
+
+func TestDecodeManySchemaError(t *testing.T) {
+	// A schema error is reported separately for each body, rather than
+	// only for the first one that encounters it.
+	desc := (&testschema.WithDefaultExprRequired{}).ProtoReflect().Descriptor()
+	bodies := []hcl.Body{
+		parseTestBody(t, `name = "a"`),
+		parseTestBody(t, `name = "b"`),
+	}
+
+	_, gotDiags := DecodeMany(bodies, desc, nil)
+	for i, diags := range gotDiags {
+		if len(diags) != 1 || diags[0].Summary != schemaErrorSummary {
+			t.Errorf("wrong diagnostics %d\n%s", i, diags.Error())
+		}
+	}
+}
+
+func TestDecodeManyUntil(t *testing.T) {
+	desc := (&testschema.WithNestedBlockOneLabelRepeated{}).ProtoReflect().Descriptor()
+	bodies := []hcl.Body{
+		parseTestBody(t, `doodad "a" {}`),
+		parseTestBody(t, `doodad "b" {}`),
+		parseTestBody(t, `doodad "c" {}`),
+	}
+	start := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		deadline time.Time
+		want     int
+	}{
+		"no deadline": {
+			time.Time{},
+			3,
+		},
+		"already passed": {
+			start,
+			0,
+		},
+		"passes during second body": {
+			start.Add(90 * time.Second),
+			2,
+		},
+		"after all bodies": {
+			start.Add(time.Hour),
+			3,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Each call to timeNow advances the clock by a minute, so
+			// decoding each body seems to take a minute.
+			now := start
+			timeNow = func() time.Time {
+				ret := now
+				now = now.Add(time.Minute)
+				return ret
+			}
+			defer func() { timeNow = time.Now }()
+
+			gotMsgs, gotDiags, got := DecodeManyUntil(bodies, desc, nil, test.deadline)
+			if got != test.want {
+				t.Fatalf("wrong number of completed bodies %d; want %d", got, test.want)
+			}
+			for i, body := range bodies {
+				if i >= got {
+					if gotMsgs[i] != nil || gotDiags[i] != nil {
+						t.Errorf("unexpected result for incomplete body %d", i)
+					}
+					continue
+				}
+				wantMsg, _ := DecodeBody(body, desc, nil)
+				if diff := cmp.Diff(wantMsg, gotMsgs[i], protoCmpOpt); diff != "" {
+					t.Errorf("wrong message %d\n%s", i, diff)
+				}
+				if gotDiags[i].HasErrors() {
+					t.Errorf("unexpected errors for body %d\n%s", i, gotDiags[i].Error())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,245 @@
+package protohcl
+
+import (
+	hcl "github.com/hashicorp/hcl/v2"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protopath"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// DecodeOptions represents optional settings that modify the behavior of
+// decoding.
+//
+// The zero value of DecodeOptions selects the default behavior, and so
+// DecodeOptions{}.DecodeBody is equivalent to the package-level function
+// DecodeBody.
+type DecodeOptions struct {
+	// ConsolidateMissingVariables, if set, causes the decoder to first
+	// collect all of the variable references in the body and check them
+	// against the given evaluation context before evaluating any
+	// expressions.
+	//
+	// If any references are to root variables that the context doesn't
+	// define then decoding fails early with only a single error diagnostic
+	// per missing variable, listing all of the locations where it was used.
+	// Without this option, the decoder would instead report a separate
+	// error for each expression that refers to a missing variable, which
+	// can be very noisy for a large body that was evaluated with an
+	// incomplete context.
+	ConsolidateMissingVariables bool
+
+	// CapsuleCodecs, if set, provides encoding functions for any capsule
+	// types that might appear in values assigned to raw-mode attributes.
+	//
+	// Without a suitable encoding function, a capsule-typed value in a
+	// raw-mode attribute causes an error diagnostic.
+	CapsuleCodecs CapsuleCodecs
+
+	// ClampIntegers, if set, causes the decoder to accept whole numbers that
+	// are outside of the range of a fixed-size integer field, replacing them
+	// with the nearest value that is in range and returning a warning
+	// diagnostic. This can be useful for tolerant tools such as importers,
+	// which would rather make a best effort than reject the input.
+	//
+	// By default, values out of range cause error diagnostics.
+	ClampIntegers bool
+
+	// ReservedNames, if set, is a set of attribute and block type names that
+	// the host application uses for its own purposes in the bodies it
+	// decodes, and so which the schema must not declare.
+	//
+	// If the given message type declares any of these names at the top
+	// level of its body then decoding fails with an error diagnostic
+	// describing the schema problem. See CheckReservedNames for more
+	// information.
+	ReservedNames []string
+
+	// EvaluateExpression, if set, is called to evaluate each attribute
+	// expression instead of calling the expression's Value method directly.
+	//
+	// This allows a host to limit the cost of evaluating expressions from
+	// untrusted configuration, such as by using EvaluationTimeLimit, and
+	// to report overruns as diagnostics.
+	EvaluateExpression ExpressionEvaluator
+
+	// FormatPath, if set, is called to describe the location of a nested
+	// value within an attribute's value in error diagnostics, instead of
+	// FormatCtyPath.
+	//
+	// This allows a host whose expression syntax has different conventions
+	// to describe those locations in a way that its users will recognize.
+	FormatPath PathFormatter
+
+	// BlockEvalContext, if set, is called before decoding the body of each
+	// nested block to get the evaluation context for the expressions in
+	// that body. This allows a host to make some functions or variables
+	// available only inside blocks of particular types, such as a function
+	// for retrieving secrets that's only valid inside credentials blocks.
+	// BlockFunctions returns a suitable function for the common case of
+	// adding functions.
+	//
+	// An expression that calls a function that's available only inside
+	// some other block types causes an error diagnostic that says which
+	// block types those are.
+	BlockEvalContext BlockEvalContextFunc
+
+	// RepeatedBlocks decides how DecodeBodyInto treats the blocks of a
+	// repeated nested block type, including one declared as a map field,
+	// when the message already has some: by default it appends the new
+	// blocks, merging any map element blocks into existing elements with
+	// the same key.
+	//
+	// The option only affects block types that the body uses at least
+	// once, so a layer that doesn't mention a block type always keeps the
+	// existing blocks. It has no effect on the other decoding functions,
+	// which always start with an empty message.
+	RepeatedBlocks RepeatedBlocksMode
+
+	// InferAttributesFromJSONNames, if set, allows DecodeBody and its
+	// variants to decode into a message type that has no HCL annotations at
+	// all, by treating each of its fields as an optional attribute named
+	// after the field's JSON name. This is intended only for prototyping
+	// against existing message types before annotating them, and so the
+	// decoder also returns a warning diagnostic whenever it uses this
+	// mapping.
+	//
+	// Only fields whose types don't need any HCL options are mapped, so
+	// fields of message or bytes types can't be set. The option has no
+	// effect on message types that use any HCL annotations, even if some of
+	// their fields are unannotated.
+	InferAttributesFromJSONNames bool
+
+	// Recovery decides what the decoder does with the attributes of a body
+	// whose content HCL already reported errors about. By default the
+	// decoder still decodes all of the attributes that it can; see
+	// RecoveryMode for the alternatives.
+	Recovery RecoveryMode
+
+	// AnyTypes, if set, is the registry that the decoder uses to find the
+	// message types named by (hcl.attr).any_type options. By default the
+	// decoder looks for each message type among the files that the Any
+	// field's own file imports.
+	//
+	// A host can use this to decide which message type each name refers
+	// to, such as when each plugin provides the message type for its own
+	// section of a shared configuration format. Set
+	// ObjectValueOptions.AnyTypes to the same registry to convert the
+	// resulting messages back into HCL values.
+	AnyTypes protoregistry.MessageTypeResolver
+}
+
+// DecodeBody decodes the content of the given body into a message that
+// conforms to the given message descriptor, using the receiving options.
+//
+// See the package-level function DecodeBody for more information.
+func (opts DecodeOptions) DecodeBody(body hcl.Body, desc protoreflect.MessageDescriptor, ctx *hcl.EvalContext) (proto.Message, hcl.Diagnostics) {
+	return opts.BodyDecoder(body, ctx).Decode(desc)
+}
+
+// DecodeBodyWithTrace is like DecodeBody but also returns a trace recording
+// the source range that populated each of the fields in the result.
+//
+// See the package-level function DecodeBodyWithTrace for more information.
+func (opts DecodeOptions) DecodeBodyWithTrace(body hcl.Body, desc protoreflect.MessageDescriptor, ctx *hcl.EvalContext) (proto.Message, *DecodeTrace, hcl.Diagnostics) {
+	trace := newDecodeTrace()
+	msg, diags := opts.decode(body, desc, ctx, nil, decoder{trace: trace})
+	return msg, trace, diags
+}
+
+// DecodeBodyWithPresence is like DecodeBody but also returns a PresenceMap
+// describing which of the attributes and blocks the body defined.
+//
+// See the package-level function DecodeBodyWithPresence for more
+// information.
+func (opts DecodeOptions) DecodeBodyWithPresence(body hcl.Body, desc protoreflect.MessageDescriptor, ctx *hcl.EvalContext) (proto.Message, *PresenceMap, hcl.Diagnostics) {
+	presence := newPresenceMap()
+	msg, diags := opts.decode(body, desc, ctx, nil, decoder{presence: presence})
+	return msg, presence, diags
+}
+
+// DecodeBodyIncremental is like DecodeBodyWithTrace but reuses field values
+// from a previous decode where possible, using the receiving options.
+//
+// See the package-level function DecodeBodyIncremental for more information.
+func (opts DecodeOptions) DecodeBodyIncremental(body hcl.Body, files map[string]*hcl.File, desc protoreflect.MessageDescriptor, ctx *hcl.EvalContext, prev PreviousDecode) (proto.Message, *DecodeTrace, hcl.Diagnostics) {
+	trace := newDecodeTrace()
+	msg, diags := opts.decode(body, desc, ctx, nil, decoder{
+		trace: trace,
+		reuse: newFieldReuser(prev, files, desc),
+	})
+	return msg, trace, diags
+}
+
+// DecodeBodyExcept is like DecodeBody but ignores any attributes or block
+// types with the given names, using the receiving options.
+//
+// See the package-level function DecodeBodyExcept for more information.
+func (opts DecodeOptions) DecodeBodyExcept(body hcl.Body, desc protoreflect.MessageDescriptor, ctx *hcl.EvalContext, names []string) (proto.Message, hcl.Diagnostics) {
+	except := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		except[name] = struct{}{}
+	}
+	addExceptBlockTypeAliases(except, desc, FieldFlattened{})
+	return opts.decode(body, desc, ctx, except, decoder{})
+}
+
+// DecodeAllBlocks decodes each of the blocks of the given type at the top
+// level of the given body into a separate message, using the receiving
+// options.
+//
+// See the package-level function DecodeAllBlocks for more information.
+func (opts DecodeOptions) DecodeAllBlocks(body hcl.Body, typeName string, desc protoreflect.MessageDescriptor, ctx *hcl.EvalContext) ([]proto.Message, hcl.Body, hcl.Diagnostics) {
+	msgs, remain, diags := opts.decodeAllBlocks(body, typeName, desc, ctx)
+	SortDiagnostics(diags)
+	return msgs, remain, DeduplicateDiagnostics(diags)
+}
+
+// DecodeAttributes decodes the given attributes into a message that conforms
+// to the given message descriptor, using the receiving options.
+//
+// See the package-level function DecodeAttributes for more information.
+func (opts DecodeOptions) DecodeAttributes(attrs hcl.Attributes, desc protoreflect.MessageDescriptor, ctx *hcl.EvalContext) (proto.Message, hcl.Diagnostics) {
+	return opts.AttributesDecoder(attrs, ctx).Decode(desc)
+}
+
+// decode is the common implementation of the various body decoding methods.
+// The given decoder carries any additional state that the caller needs the
+// decoder to record or use, and decode sets its options.
+func (opts DecodeOptions) decode(body hcl.Body, desc protoreflect.MessageDescriptor, ctx *hcl.EvalContext, except map[string]struct{}, d decoder) (proto.Message, hcl.Diagnostics) {
+	if body == nil {
+		body = hcl.EmptyBody()
+	}
+	if opts.InferAttributesFromJSONNames && !hasHCLAnnotations(desc) {
+		return opts.decodeInferredAttributes(body, desc, ctx, except, d)
+	}
+	if len(except) != 0 {
+		body = hideBodyNames(body, desc, except)
+	}
+	if diags := opts.precheck(body, desc, ctx); diags.HasErrors() {
+		return newMessageMaybeDynamic(desc).Interface(), diags
+	}
+
+	d.opts = opts
+	msg, diags := d.decodeBody(body, desc, protopath.Path{protopath.Root(desc)}, ctx, except)
+	SortDiagnostics(diags)
+	return msg, DeduplicateDiagnostics(diags)
+}
+
+// precheck returns error diagnostics for any problems that prevent decoding
+// the given body into a message of the given type at all, due to the
+// receiving options, or no diagnostics otherwise.
+func (opts DecodeOptions) precheck(body hcl.Body, desc protoreflect.MessageDescriptor, ctx *hcl.EvalContext) hcl.Diagnostics {
+	var diags hcl.Diagnostics
+	if err := CheckReservedNames(desc, opts.ReservedNames); err != nil {
+		return diags.Append(schemaErrorDiagnostic(err))
+	}
+	if opts.ConsolidateMissingVariables {
+		diags = missingVariablesDiagnostics(bodyVariables(body, desc), ctx)
+		if diags.HasErrors() {
+			SortDiagnostics(diags)
+			return DeduplicateDiagnostics(diags)
+		}
+	}
+	return nil
+}
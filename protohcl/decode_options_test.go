@@ -0,0 +1,324 @@
+package protohcl
+
+import (
+	"testing"
+
+	"github.com/apparentlymart/go-protohcl/protohcl/internal/testschema"
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func TestDecodeOptionsConsolidateMissingVariables(t *testing.T) {
+	fileDesc := testschema.File_testschema_proto
+	desc := fileDesc.Messages().ByName(protoreflect.Name("WithNestedBlockFlattenedLabels"))
+	opts := DecodeOptions{
+		ConsolidateMissingVariables: true,
+	}
+
+	config := `
+doodad "bird" "Jackson" {
+  nickname = foo.nick
+  species  = foo.species
+}
+doodad "bird" "Snakob" {
+  nickname = bar
+  species  = baz
+}
+`
+
+	tests := map[string]struct {
+		ctx       *hcl.EvalContext
+		want      *testschema.WithNestedBlockFlattenedLabels
+		wantDiags hcl.Diagnostics
+	}{
+		"all defined": {
+			(&hcl.EvalContext{
+				Variables: map[string]cty.Value{
+					"baz": cty.StringVal("cockatiel"),
+					"foo": cty.ObjectVal(map[string]cty.Value{
+						"nick":    cty.StringVal("doofus"),
+						"species": cty.StringVal("budgerigar"),
+					}),
+					"bar": cty.StringVal("sneaky"),
+				},
+			}).NewChild(), // variables in parent contexts are also okay
+			&testschema.WithNestedBlockFlattenedLabels{
+				Doodad: []*testschema.WithFlattenedBlockLabel{
+					{
+						Type: "bird",
+						Base: &testschema.WithOneBlockLabel{
+							Name:     "Jackson",
+							Nickname: "doofus",
+						},
+						Species: "budgerigar",
+					},
+					{
+						Type: "bird",
+						Base: &testschema.WithOneBlockLabel{
+							Name:     "Snakob",
+							Nickname: "sneaky",
+						},
+						Species: "cockatiel",
+					},
+				},
+			},
+			nil,
+		},
+		"some missing": {
+			&hcl.EvalContext{
+				Variables: map[string]cty.Value{
+					"baz": cty.StringVal("budgerigar"),
+				},
+			},
+			&testschema.WithNestedBlockFlattenedLabels{},
+			hcl.Diagnostics{
+				{
+					Severity: hcl.DiagError,
+					Summary:  "Unknown variable",
+					Detail:   `There is no variable named "foo". It is referenced at the following locations:` + "\n  - test.tf:3,14-22\n  - test.tf:4,14-25",
+					Subject: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 3, Column: 14, Byte: 40},
+						End:      hcl.Pos{Line: 3, Column: 22, Byte: 48},
+					},
+				},
+				{
+					Severity: hcl.DiagError,
+					Summary:  "Unknown variable",
+					Detail:   `There is no variable named "bar". It is referenced at the following locations:` + "\n  - test.tf:7,14-17",
+					Subject: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 7, Column: 14, Byte: 114},
+						End:      hcl.Pos{Line: 7, Column: 17, Byte: 117},
+					},
+				},
+			},
+		},
+		"no context": {
+			nil,
+			&testschema.WithNestedBlockFlattenedLabels{},
+			hcl.Diagnostics{
+				{
+					Severity: hcl.DiagError,
+					Summary:  "Unknown variable",
+					Detail:   `There is no variable named "foo". It is referenced at the following locations:` + "\n  - test.tf:3,14-22\n  - test.tf:4,14-25",
+					Subject: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 3, Column: 14, Byte: 40},
+						End:      hcl.Pos{Line: 3, Column: 22, Byte: 48},
+					},
+				},
+				{
+					Severity: hcl.DiagError,
+					Summary:  "Unknown variable",
+					Detail:   `There is no variable named "bar". It is referenced at the following locations:` + "\n  - test.tf:7,14-17",
+					Subject: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 7, Column: 14, Byte: 114},
+						End:      hcl.Pos{Line: 7, Column: 17, Byte: 117},
+					},
+				},
+				{
+					Severity: hcl.DiagError,
+					Summary:  "Unknown variable",
+					Detail:   `There is no variable named "baz". It is referenced at the following locations:` + "\n  - test.tf:8,14-17",
+					Subject: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 8, Column: 14, Byte: 131},
+						End:      hcl.Pos{Line: 8, Column: 17, Byte: 134},
+					},
+				},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			f, diags := hclsyntax.ParseConfig([]byte(config), "test.tf", hcl.InitialPos)
+			if diags.HasErrors() {
+				t.Fatalf("parse error: %s", diags)
+			}
+
+			got, diags := opts.DecodeBody(f.Body, desc, test.ctx)
+
+			if diff := cmp.Diff(test.want, got, protoCmpOpt); diff != "" {
+				t.Errorf("wrong result\n%s", diff)
+			}
+			if diff := cmp.Diff(test.wantDiags, diags); diff != "" {
+				t.Errorf("wrong diagnostics\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDecodeOptionsClampIntegers(t *testing.T) {
+	fileDesc := testschema.File_testschema_proto
+	opts := DecodeOptions{
+		ClampIntegers: true,
+	}
+
+	tests := map[string]struct {
+		config    string
+		desc      protoreflect.MessageDescriptor
+		want      proto.Message
+		wantDiags hcl.Diagnostics
+	}{
+		"in range": {
+			`num = 5`,
+			fileDesc.Messages().ByName(protoreflect.Name("WithNumberAttrAsInt32")),
+			&testschema.WithNumberAttrAsInt32{
+				Num: 5,
+			},
+			nil,
+		},
+		"too large": {
+			`num = 3000000000`,
+			fileDesc.Messages().ByName(protoreflect.Name("WithNumberAttrAsInt32")),
+			&testschema.WithNumberAttrAsInt32{
+				Num: 2147483647,
+			},
+			hcl.Diagnostics{
+				{
+					Severity: hcl.DiagWarning,
+					Summary:  "Integer value out of range",
+					Detail:   "The value must be less than or equal to 2147483647, so it has been changed to 2147483647.",
+					Subject: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 7, Byte: 6},
+						End:      hcl.Pos{Line: 1, Column: 17, Byte: 16},
+					},
+					Extra: diagnosticExtra{Category: DiagnosticValueError},
+				},
+			},
+		},
+		"too small in map": {
+			`nums = { a = -3000000000 }`,
+			fileDesc.Messages().ByName(protoreflect.Name("WithNumberMapAttrAsInt32")),
+			&testschema.WithNumberMapAttrAsInt32{
+				Nums: map[string]int32{
+					"a": -2147483648,
+				},
+			},
+			hcl.Diagnostics{
+				{
+					Severity: hcl.DiagWarning,
+					Summary:  "Integer value out of range",
+					Detail:   `The value for key "a" must be greater than or equal to -2147483648, so it has been changed to -2147483648.`,
+					Subject: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 14, Byte: 13},
+						End:      hcl.Pos{Line: 1, Column: 25, Byte: 24},
+					},
+					Extra: diagnosticExtra{Category: DiagnosticValueError},
+				},
+			},
+		},
+		"not a whole number": {
+			`num = 1.5`,
+			fileDesc.Messages().ByName(protoreflect.Name("WithNumberAttrAsInt32")),
+			&testschema.WithNumberAttrAsInt32{},
+			hcl.Diagnostics{
+				{
+					Severity: hcl.DiagError,
+					Summary:  "Unsuitable attribute value",
+					Detail:   "The value must be a whole number.",
+					Subject: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 7, Byte: 6},
+						End:      hcl.Pos{Line: 1, Column: 10, Byte: 9},
+					},
+					Extra: diagnosticExtra{Category: DiagnosticValueError},
+				},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			f, diags := hclsyntax.ParseConfig([]byte(test.config), "test.tf", hcl.InitialPos)
+			if diags.HasErrors() {
+				t.Fatalf("parse error: %s", diags)
+			}
+
+			got, diags := opts.DecodeBody(f.Body, test.desc, nil)
+
+			if diff := cmp.Diff(test.want, got, protoCmpOpt); diff != "" {
+				t.Errorf("wrong result\n%s", diff)
+			}
+			if diff := cmp.Diff(test.wantDiags, diags); diff != "" {
+				t.Errorf("wrong diagnostics\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDecodeOptionsReservedNames(t *testing.T) {
+	fileDesc := testschema.File_testschema_proto
+	opts := DecodeOptions{
+		ReservedNames: []string{"count", "name"},
+	}
+
+	tests := map[string]struct {
+		config    string
+		desc      protoreflect.MessageDescriptor
+		want      proto.Message
+		wantDiags hcl.Diagnostics
+	}{
+		"no reserved names": {
+			`num = 5`,
+			fileDesc.Messages().ByName(protoreflect.Name("WithNumberAttrAsInt32")),
+			&testschema.WithNumberAttrAsInt32{
+				Num: 5,
+			},
+			nil,
+		},
+		"reserved attribute name": {
+			`name = "Jackson"`,
+			fileDesc.Messages().ByName(protoreflect.Name("WithStringAttr")),
+			&testschema.WithStringAttr{},
+			hcl.Diagnostics{
+				{
+					Severity: hcl.DiagError,
+					Summary:  "Invalid configuration schema",
+					Detail:   "Invalid HCL annotations in protobuf schema for hcl.testschema.WithStringAttr.name: attribute name \"name\" is reserved by the host application.\n\nThis is a bug in the component that defined this schema, and not an error in the given configuration.",
+					Extra:    diagnosticExtra{Category: DiagnosticSchemaError},
+				},
+			},
+		},
+		"reserved name in nested block": {
+			// Only the top-level body shares its namespace with the host.
+			`doodad {
+  name = "Jackson"
+}`,
+			fileDesc.Messages().ByName(protoreflect.Name("WithNestedBlockNoLabelsSingleton")),
+			&testschema.WithNestedBlockNoLabelsSingleton{
+				Doodad: &testschema.WithStringAttr{
+					Name: "Jackson",
+				},
+			},
+			nil,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			f, diags := hclsyntax.ParseConfig([]byte(test.config), "test.tf", hcl.InitialPos)
+			if diags.HasErrors() {
+				t.Fatalf("parse error: %s", diags)
+			}
+
+			got, diags := opts.DecodeBody(f.Body, test.desc, nil)
+
+			if diff := cmp.Diff(test.want, got, protoCmpOpt); diff != "" {
+				t.Errorf("wrong result\n%s", diff)
+			}
+			if diff := cmp.Diff(test.wantDiags, diags); diff != "" {
+				t.Errorf("wrong diagnostics\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package protohcl
+
+import (
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Decoder is the interface implemented by the front-ends that populate a
+// message from some source of configuration, using the HCL annotations in
+// the message type's schema.
+//
+// Decoding a body of HCL configuration is only one way to populate a
+// message: a host application might also accept settings as command line
+// flags, or as structured data over an RPC interface. Using the same
+// annotation-driven logic for all of those means that they all apply the
+// same type conversions, defaults, and validation rules, and the host can
+// treat them all the same way by accepting a Decoder.
+//
+// The decoders in this package are BodyDecoder, AttributesDecoder, and
+// ValueDecoder. Configuration in other syntaxes that HCL supports, such as
+// JSON, or YAML translated to JSON, can use BodyDecoder with the body
+// returned by the relevant parser.
+type Decoder interface {
+	// Decode returns a new message of the type that the given descriptor
+	// describes, populated from the decoder's source.
+	//
+	// Decode may return a partially-populated message along with error
+	// diagnostics, in the same way as DecodeBody.
+	Decode(desc protoreflect.MessageDescriptor) (proto.Message, hcl.Diagnostics)
+}
+
+// BodyDecoder returns a Decoder that decodes the given body, evaluating its
+// expressions in the given evaluation context, using the receiving options.
+//
+// Calling Decode on the result is equivalent to calling DecodeBody.
+func (opts DecodeOptions) BodyDecoder(body hcl.Body, ctx *hcl.EvalContext) Decoder {
+	return bodyDecoder{opts: opts, body: body, ctx: ctx}
+}
+
+// AttributesDecoder returns a Decoder that decodes the given attributes,
+// evaluating their expressions in the given evaluation context, using the
+// receiving options.
+//
+// Calling Decode on the result is equivalent to calling DecodeAttributes.
+func (opts DecodeOptions) AttributesDecoder(attrs hcl.Attributes, ctx *hcl.EvalContext) Decoder {
+	return attributesDecoder{opts: opts, attrs: attrs, ctx: ctx}
+}
+
+// ValueDecoder returns a Decoder that populates a message from the given
+// object value, using the receiving options.
+//
+// This is for front-ends that produce values directly rather than
+// expressions, such as those parsing command line flags or converting
+// structured data from another protocol. The value must have the shape that
+// ObjectValueForMessage, with its default options, would produce for the
+// message type, except that it need not have all of the attributes: each
+// nested block type is represented by an attribute whose value is an object
+// for a singleton block type, a map of objects keyed by the map key label
+// for a block type declared as a map field, or a list, set, or tuple of
+// objects otherwise, and the labels of each block are string attributes of
+// its object.
+//
+// There's no source code to refer to, so all of the diagnostics that Decode
+// returns have the given range as their subject. A front-end might use a
+// range whose filename describes the source, such as "command line".
+func (opts DecodeOptions) ValueDecoder(val cty.Value, rng hcl.Range) Decoder {
+	return valueDecoder{opts: opts, val: val, rng: rng}
+}
+
+type bodyDecoder struct {
+	opts DecodeOptions
+	body hcl.Body
+	ctx  *hcl.EvalContext
+}
+
+func (d bodyDecoder) Decode(desc protoreflect.MessageDescriptor) (proto.Message, hcl.Diagnostics) {
+	return d.opts.decode(d.body, desc, d.ctx, nil, decoder{})
+}
+
+type attributesDecoder struct {
+	opts  DecodeOptions
+	attrs hcl.Attributes
+	ctx   *hcl.EvalContext
+}
+
+func (d attributesDecoder) Decode(desc protoreflect.MessageDescriptor) (proto.Message, hcl.Diagnostics) {
+	msg, diags := d.opts.decodeAttributes(d.attrs, desc, d.ctx)
+	SortDiagnostics(diags)
+	return msg, DeduplicateDiagnostics(diags)
+}
+
+type valueDecoder struct {
+	opts DecodeOptions
+	val  cty.Value
+	rng  hcl.Range
+}
+
+func (d valueDecoder) Decode(desc protoreflect.MessageDescriptor) (proto.Message, hcl.Diagnostics) {
+	// We present the value as a body so that we can use exactly the same
+	// decoding logic as for configuration source code. The expressions in
+	// the body are all static, so there's no need for an evaluation context.
+	return d.opts.decode(newValueBody(d.val, d.rng, desc), desc, nil, nil, decoder{})
+}
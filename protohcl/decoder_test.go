@@ -0,0 +1,231 @@
+package protohcl
+
+import (
+	"testing"
+
+	"github.com/apparentlymart/go-protohcl/protohcl/internal/testschema"
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func TestValueDecoderRoundTrip(t *testing.T) {
+	// For each of these, we decode the configuration as a body, then
+	// convert the result to a value and decode that using ValueDecoder,
+	// which should produce an equal message.
+	tests := map[string]struct {
+		desc   protoreflect.Name
+		config string
+	}{
+		"attributes": {
+			"WithOptionalAttrs",
+			`
+				name  = "Jackson"
+				count = 3
+			`,
+		},
+		"singleton block": {
+			"WithNestedBlockTwoLabelSingleton",
+			`
+				doodad "a" "b" {
+				  nickname = "c"
+				}
+			`,
+		},
+		"set of blocks": {
+			"WithNestedBlockNoLabelsRepeated",
+			`
+				doodad {
+				  name = "a"
+				}
+				doodad {
+				  name = "b"
+				}
+			`,
+		},
+		"flattened labels": {
+			"WithNestedBlockFlattenedLabels",
+			`
+				doodad "cat" "Honey" {
+				  species  = "Felis catus"
+				  nickname = "Bear"
+				}
+			`,
+		},
+		"map of blocks": {
+			"WithMapOfBlocks",
+			`
+				pet "a" {
+				  name = "Jackson"
+				}
+				pet "b" {
+				  name = "Rufus"
+				}
+			`,
+		},
+		"nested object attribute": {
+			"WithMapOfObjectsAttr",
+			`
+				pets = {
+				  a = { name = "Jackson" }
+				}
+			`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			desc := testschema.File_testschema_proto.Messages().ByName(test.desc)
+			f, diags := hclsyntax.ParseConfig([]byte(test.config), "", hcl.InitialPos)
+			if diags.HasErrors() {
+				t.Fatalf("unexpected syntax errors: %s", diags.Error())
+			}
+
+			want, diags := DecodeOptions{}.BodyDecoder(f.Body, nil).Decode(desc)
+			if diags.HasErrors() {
+				t.Fatalf("unexpected errors decoding body: %s", diags.Error())
+			}
+			val, err := ObjectValueForMessage(want)
+			if err != nil {
+				t.Fatalf("unexpected error converting to value: %s", err)
+			}
+
+			got, diags := DecodeOptions{}.ValueDecoder(val, hcl.Range{Filename: "value"}).Decode(desc)
+			if diags.HasErrors() {
+				t.Fatalf("unexpected errors decoding value: %s", diags.Error())
+			}
+			if diff := cmp.Diff(want, got, protoCmpOpt); diff != "" {
+				t.Errorf("wrong result\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValueDecoderErrors(t *testing.T) {
+	tests := map[string]struct {
+		desc        protoreflect.Name
+		val         cty.Value
+		wantSummary string
+		wantDetail  string
+	}{
+		"missing required attribute": {
+			"WithOptionalAttrs",
+			cty.ObjectVal(map[string]cty.Value{
+				"count": cty.NumberIntVal(1),
+			}),
+			"Missing required argument",
+			`The argument "name" is required, but no definition was found.`,
+		},
+		"unsupported attribute": {
+			"WithStringAttr",
+			cty.ObjectVal(map[string]cty.Value{
+				"name":  cty.StringVal("a"),
+				"extra": cty.True,
+			}),
+			"Unsupported argument",
+			`An argument named "extra" is not expected here.`,
+		},
+		"wrong attribute type": {
+			"WithStringAttr",
+			cty.ObjectVal(map[string]cty.Value{
+				"name": cty.EmptyObjectVal,
+			}),
+			unsuitableValueSummary,
+			`Inappropriate value for attribute "name": string required.`,
+		},
+		"not an object": {
+			"WithStringAttr",
+			cty.StringVal("a"),
+			unsuitableValueSummary,
+			`The value for this body must be an object, not string.`,
+		},
+		"missing label": {
+			"WithNestedBlockTwoLabelSingleton",
+			cty.ObjectVal(map[string]cty.Value{
+				"doodad": cty.ObjectVal(map[string]cty.Value{
+					"type": cty.StringVal("a"),
+				}),
+			}),
+			"Missing name for doodad",
+			`All doodad blocks must have 2 labels (type, name).`,
+		},
+		"blocks not a collection": {
+			"WithNestedBlockNoLabelsRepeated",
+			cty.ObjectVal(map[string]cty.Value{
+				"doodad": cty.ObjectVal(map[string]cty.Value{
+					"name": cty.StringVal("a"),
+				}),
+			}),
+			unsuitableValueSummary,
+			`The value for the doodad blocks must be a collection of objects, not object.`,
+		},
+		"unknown blocks": {
+			"WithNestedBlockNoLabelsRepeated",
+			cty.ObjectVal(map[string]cty.Value{
+				"doodad": cty.UnknownVal(cty.List(cty.EmptyObject)),
+			}),
+			unsuitableValueSummary,
+			`The value for the doodad blocks must be known.`,
+		},
+	}
+
+	rng := hcl.Range{Filename: "command line"}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			desc := testschema.File_testschema_proto.Messages().ByName(test.desc)
+			_, diags := DecodeOptions{}.ValueDecoder(test.val, rng).Decode(desc)
+			if len(diags) != 1 {
+				t.Fatalf("wrong number of diagnostics %d; want 1\n%s", len(diags), diags.Error())
+			}
+			if got, want := diags[0].Summary, test.wantSummary; got != want {
+				t.Errorf("wrong summary\ngot:  %s\nwant: %s", got, want)
+			}
+			if got, want := diags[0].Detail, test.wantDetail; got != want {
+				t.Errorf("wrong detail\ngot:  %s\nwant: %s", got, want)
+			}
+			if diags[0].Subject == nil || *diags[0].Subject != rng {
+				t.Errorf("wrong subject %#v; want %#v", diags[0].Subject, rng)
+			}
+		})
+	}
+}
+
+func TestDecoders(t *testing.T) {
+	// All of the decoders populate messages in the same way, so the same
+	// configuration from different sources produces the same message.
+	desc := testschema.File_testschema_proto.Messages().ByName("WithOptionalAttrs")
+	f, diags := hclsyntax.ParseConfig([]byte(`
+		name  = "Jackson"
+		count = 3
+	`), "", hcl.InitialPos)
+	if diags.HasErrors() {
+		t.Fatalf("unexpected syntax errors: %s", diags.Error())
+	}
+	attrs, diags := f.Body.JustAttributes()
+	if diags.HasErrors() {
+		t.Fatalf("unexpected errors: %s", diags.Error())
+	}
+
+	decoders := map[string]Decoder{
+		"body":       DecodeOptions{}.BodyDecoder(f.Body, nil),
+		"attributes": DecodeOptions{}.AttributesDecoder(attrs, nil),
+		"value": DecodeOptions{}.ValueDecoder(cty.ObjectVal(map[string]cty.Value{
+			"name":  cty.StringVal("Jackson"),
+			"count": cty.StringVal("3"), // converted in the same way as an expression result
+		}), hcl.Range{}),
+	}
+	want := &testschema.WithOptionalAttrs{Name: "Jackson", Count: 3}
+	for name, dec := range decoders {
+		t.Run(name, func(t *testing.T) {
+			got, diags := dec.Decode(desc)
+			if diags.HasErrors() {
+				t.Fatalf("unexpected errors: %s", diags.Error())
+			}
+			if diff := cmp.Diff(want, got, protoCmpOpt); diff != "" {
+				t.Errorf("wrong result\n%s", diff)
+			}
+		})
+	}
+}
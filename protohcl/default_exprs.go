@@ -0,0 +1,335 @@
+package protohcl
+
+import (
+	"fmt"
+	"strings"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+	"google.golang.org/protobuf/reflect/protopath"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// attrDefaults collects the attributes of a single body while the decoder
+// populates a message from it, so that once it has decoded all of the
+// attributes that the body defines it can evaluate the default expressions
+// of those that the body omitted.
+//
+// A body's attributes include those of any messages flattened into it, and
+// so the decoder shares one attrDefaults between a message and the
+// messages flattened into it.
+type attrDefaults struct {
+	content      *hcl.BodyContent
+	missingRange hcl.Range
+
+	attrs   map[string]FieldAttribute
+	pending map[string]pendingDefault
+
+	// values are the values of the attributes that the body defines, as
+	// the decoder already evaluated and converted them, for use in the
+	// default expressions of other attributes. An attribute that the
+	// decoder didn't evaluate, because DecodeBodyIncremental reused its
+	// field from a previous decode, has no value recorded.
+	values map[string]resolvedDefault
+
+	// order is the names of the pending attributes in the order the decoder
+	// visited them, so that we evaluate their defaults in a predictable
+	// order.
+	order []string
+}
+
+// pendingDefault is an attribute that the body omitted and which has a
+// default expression to evaluate.
+type pendingDefault struct {
+	elem      FieldAttribute
+	msg       protoreflect.Message
+	fieldPath protopath.Path
+}
+
+func newAttrDefaults(content *hcl.BodyContent, missingRange hcl.Range) *attrDefaults {
+	return &attrDefaults{
+		content:      content,
+		missingRange: missingRange,
+		attrs:        make(map[string]FieldAttribute),
+	}
+}
+
+// declare records that the body can define the given attribute, and so a
+// default expression elsewhere in the body can refer to it.
+func (ds *attrDefaults) declare(elem FieldAttribute) {
+	ds.attrs[elem.Name] = elem
+}
+
+// setValue records the result of evaluating the expression of the given
+// attribute that the body defines, converted to the given type constraint
+// in the same way as for the default expressions of other attributes.
+func (ds *attrDefaults) setValue(elem FieldAttribute, val cty.Value, wantTy cty.Type) {
+	val, ok := siblingAttrValue(val, elem, wantTy)
+	ds.setResolved(elem, resolvedDefault{val: val, ok: ok})
+}
+
+// setInvalid records that the given attribute that the body defines has an
+// invalid value, which the decoder has already reported.
+func (ds *attrDefaults) setInvalid(elem FieldAttribute) {
+	ds.setResolved(elem, resolvedDefault{})
+}
+
+func (ds *attrDefaults) setResolved(elem FieldAttribute, val resolvedDefault) {
+	if ds.values == nil {
+		ds.values = make(map[string]resolvedDefault)
+	}
+	ds.values[elem.Name] = val
+}
+
+// addPending records that the body omitted the given attribute, so its
+// field of the given message must be populated from its default expression.
+func (ds *attrDefaults) addPending(elem FieldAttribute, msg protoreflect.Message, fieldPath protopath.Path) {
+	if ds.pending == nil {
+		ds.pending = make(map[string]pendingDefault)
+	}
+	ds.pending[elem.Name] = pendingDefault{
+		elem:      elem,
+		msg:       msg,
+		fieldPath: fieldPath,
+	}
+	ds.order = append(ds.order, elem.Name)
+}
+
+const defaultCycleSummary = "Cycle in attribute defaults"
+
+// applyAttrDefaults populates the fields of the attributes that the given
+// defaults recorded as pending, by evaluating their default expressions.
+//
+// recovering has the same meaning as for fillMessageFromContent.
+func (d *decoder) applyAttrDefaults(defaults *attrDefaults, ctx *hcl.EvalContext, recovering bool) hcl.Diagnostics {
+	if len(defaults.pending) == 0 || d.skipEvaluation(recovering) {
+		return nil
+	}
+	var diags hcl.Diagnostics
+
+	r := &defaultResolver{
+		d:        d,
+		defaults: defaults,
+		ctx:      ctx,
+		values:   make(map[string]resolvedDefault),
+		visiting: make(map[string]int),
+	}
+	for _, name := range defaults.order {
+		p := defaults.pending[name]
+		mark := len(r.diags)
+		val := r.resolve(name)
+		moreDiags := r.diags[mark:]
+		if val.expr != nil {
+			attr := &hcl.Attribute{
+				Name:      name,
+				Expr:      defaultExpr{Expression: val.expr, rng: defaults.missingRange},
+				Range:     defaults.missingRange,
+				NameRange: defaults.missingRange,
+			}
+			moreDiags = append(moreDiags, d.assignDefaultAttrValue(val.raw, attr, p, val.ctx)...)
+		}
+		d.trace.recordDiagnostics(p.fieldPath, moreDiags)
+		diags = append(diags, moreDiags...)
+	}
+	return diags
+}
+
+// assignDefaultAttrValue is like assignAttrValue but for a value that came
+// from a default expression, which the configuration didn't define and so
+// doesn't affect the trace or presence of the field.
+func (d *decoder) assignDefaultAttrValue(val cty.Value, attr *hcl.Attribute, p pendingDefault, ctx *hcl.EvalContext) hcl.Diagnostics {
+	trace, presence := d.trace, d.presence
+	d.trace, d.presence = nil, nil
+	diags := d.assignAttrValue(val, attr, p.elem, p.elem.TargetField, p.msg, p.fieldPath, ctx)
+	d.trace, d.presence = trace, presence
+	return diags
+}
+
+// defaultResolver evaluates the default expressions of the pending
+// attributes of a body, along with the values of the attributes they refer
+// to, detecting any cycles between them.
+type defaultResolver struct {
+	d        *decoder
+	defaults *attrDefaults
+	ctx      *hcl.EvalContext
+
+	values map[string]resolvedDefault
+	diags  hcl.Diagnostics
+
+	// visiting maps the names of the attributes whose values we're currently
+	// resolving to their positions in stack.
+	visiting map[string]int
+	stack    []string
+}
+
+// resolvedDefault is the result of resolving the value of an attribute
+// for use in the default expressions of others.
+type resolvedDefault struct {
+	// val is the attribute's value, converted to its type constraint. It's
+	// valid only if ok is true.
+	val cty.Value
+	ok  bool
+
+	// raw is the unconverted result of the attribute's default expression,
+	// along with the expression and the context it was evaluated in. The
+	// expression is nil if the attribute didn't use its default expression.
+	raw  cty.Value
+	expr hcl.Expression
+	ctx  *hcl.EvalContext
+}
+
+// resolve returns the value of the attribute with the given name. The
+// result isn't ok if the value can't be decided, because of an error that
+// has either already been reported or that resolve adds to r.diags.
+func (r *defaultResolver) resolve(name string) resolvedDefault {
+	if ret, exists := r.values[name]; exists {
+		return ret
+	}
+	if i, ok := r.visiting[name]; ok {
+		r.reportCycle(r.stack[i:])
+		return resolvedDefault{}
+	}
+
+	ret := r.resolveUncached(name)
+	if _, failed := r.values[name]; failed {
+		// We found a cycle that included this attribute while resolving it.
+		return resolvedDefault{}
+	}
+	r.values[name] = ret
+	return ret
+}
+
+func (r *defaultResolver) resolveUncached(name string) resolvedDefault {
+	elem := r.defaults.attrs[name]
+	wantTy, diags := r.d.typeConstraint(elem)
+	if diags.HasErrors() {
+		// The decoder already reported the invalid type constraint.
+		return resolvedDefault{}
+	}
+
+	if attr, exists := r.defaults.content.Attributes[name]; exists {
+		// The decoder already reported any errors in the attribute's own
+		// value, so we just ignore the attribute if its value is invalid.
+		if ret, recorded := r.defaults.values[name]; recorded {
+			return ret
+		}
+		// The decoder reused the attribute's field without evaluating its
+		// expression, so we must evaluate it here.
+		val, diags := r.d.evalExpr(attr.Expr, r.ctx)
+		if diags.HasErrors() {
+			return resolvedDefault{}
+		}
+		val, ok := siblingAttrValue(val, elem, wantTy)
+		return resolvedDefault{val: val, ok: ok}
+	}
+	if _, pending := r.defaults.pending[name]; !pending {
+		return resolvedDefault{val: cty.NullVal(wantTy), ok: true}
+	}
+
+	// GetFieldElem already checked that the expression is valid.
+	expr, _ := hclsyntax.ParseExpression([]byte(elem.DefaultExprString), "", hcl.InitialPos)
+
+	r.visiting[name] = len(r.stack)
+	r.stack = append(r.stack, name)
+	vars := make(map[string]cty.Value)
+	ok := true
+	for _, traversal := range expr.Variables() {
+		ref := traversal.RootName()
+		if _, declared := r.defaults.attrs[ref]; !declared {
+			continue // refers to a variable from the evaluation context
+		}
+		resolved := r.resolve(ref)
+		if !resolved.ok {
+			ok = false
+			continue
+		}
+		vars[ref] = resolved.val
+	}
+	r.stack = r.stack[:len(r.stack)-1]
+	delete(r.visiting, name)
+	if !ok {
+		return resolvedDefault{}
+	}
+
+	ctx := r.ctx.NewChild()
+	ctx.Variables = vars
+	raw, diags := r.d.evalExpr(expr, ctx)
+	for _, diag := range diags {
+		diag.Detail = fmt.Sprintf("%s\n\nThis problem is in the default value for attribute %q, which applies because the configuration doesn't define that attribute.", diag.Detail, name)
+		diag.Subject = r.defaults.missingRange.Ptr()
+		diag.Context = nil
+		diag.Expression = nil
+		r.diags = append(r.diags, diag)
+	}
+	if diags.HasErrors() {
+		return resolvedDefault{}
+	}
+	// If the value isn't suitable for the attribute then other attributes
+	// can't use it, but the decoder will still try to assign raw so that it
+	// can report the problem.
+	val, ok := siblingAttrValue(raw, elem, wantTy)
+	return resolvedDefault{
+		val:  val,
+		ok:   ok,
+		raw:  raw,
+		expr: expr,
+		ctx:  ctx,
+	}
+}
+
+// reportCycle records a diagnostic about a cycle between the default
+// expressions of the given attributes, and marks them all as unresolvable.
+func (r *defaultResolver) reportCycle(names []string) {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = fmt.Sprintf("%q", name)
+		r.values[name] = resolvedDefault{}
+	}
+	r.diags = append(r.diags, &hcl.Diagnostic{
+		Severity: hcl.DiagError,
+		Summary:  defaultCycleSummary,
+		Detail: fmt.Sprintf(
+			"The default values for the attributes %s refer to each other, so none of them can be decided. Set at least one of these attributes explicitly.",
+			strings.Join(quoted, ", "),
+		),
+		Subject: r.defaults.missingRange.Ptr(),
+		Extra:   diagnosticExtra{Category: DiagnosticMissingRequired},
+	})
+}
+
+// siblingAttrValue converts the given value for the given attribute to the
+// attribute's type constraint in the same way as the decoder would, for use
+// in the default expression of another attribute. The result is false if
+// the value isn't suitable for the attribute.
+func siblingAttrValue(val cty.Value, elem FieldAttribute, wantTy cty.Type) (cty.Value, bool) {
+	if elem.AllowScalarForList {
+		val = wrapScalarForList(val)
+	}
+	val, err := parseNumberSyntax(val, elem.NumberSyntax)
+	if err != nil {
+		return cty.NilVal, false
+	}
+	val, err = convert.Convert(val, wantTy)
+	if err != nil {
+		return cty.NilVal, false
+	}
+	return emptyAsNull(val, elem), true
+}
+
+// defaultExpr is the expression for an attribute populated from its
+// default_expr option, which reports the range where the attribute is
+// missing as its own range, because it isn't in the configuration.
+type defaultExpr struct {
+	hcl.Expression
+	rng hcl.Range
+}
+
+func (e defaultExpr) Range() hcl.Range {
+	return e.rng
+}
+
+func (e defaultExpr) StartRange() hcl.Range {
+	return e.rng
+}
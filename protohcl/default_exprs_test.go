@@ -0,0 +1,159 @@
+package protohcl
+
+import (
+	"testing"
+
+	"github.com/apparentlymart/go-protohcl/protohcl/internal/testschema"
+	"github.com/apparentlymart/go-protohcl/protohcl/protohcltest"
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protopath"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func TestDecodeBodyDefaultExprs(t *testing.T) {
+	tests := map[string]struct {
+		messageType string
+		config      string
+		want        proto.Message
+		wantDiags   []protohcltest.ExpectedDiagnostic
+	}{
+		"all defaults": {
+			"WithDefaultExprs",
+			`name = "web"`,
+			&testschema.WithDefaultExprs{
+				Name:        "web",
+				DisplayName: "web",
+				Greeting:    "Hello, web!",
+			},
+			nil,
+		},
+		"overridden default": {
+			"WithDefaultExprs",
+			`
+				name         = "web"
+				display_name = "Web Server"
+			`,
+			&testschema.WithDefaultExprs{
+				Name:        "web",
+				DisplayName: "Web Server",
+				Greeting:    "Hello, Web Server!",
+			},
+			nil,
+		},
+		"referring to null": {
+			"WithDefaultExprs",
+			`greeting = "Hi"`,
+			&testschema.WithDefaultExprs{
+				Greeting: "Hi",
+			},
+			nil,
+		},
+		"referring to invalid attribute": {
+			"WithDefaultExprs",
+			`name = ["web"]`,
+			&testschema.WithDefaultExprs{},
+			[]protohcltest.ExpectedDiagnostic{
+				protohcltest.Error(unsuitableValueSummary).
+					WithDetail(`Inappropriate value for attribute "name": string required.`),
+			},
+		},
+		"cycle": {
+			"WithDefaultExprCycle",
+			``,
+			&testschema.WithDefaultExprCycle{},
+			[]protohcltest.ExpectedDiagnostic{
+				protohcltest.Error(defaultCycleSummary).WithDetail(
+					`The default values for the attributes "a", "b" refer to each other, so none of them can be decided. Set at least one of these attributes explicitly.`,
+				),
+			},
+		},
+		"cycle broken": {
+			"WithDefaultExprCycle",
+			`b = "x"`,
+			&testschema.WithDefaultExprCycle{
+				A: "x",
+				B: "x",
+			},
+			nil,
+		},
+		"required": {
+			"WithDefaultExprRequired",
+			`name = "a"`,
+			&testschema.WithDefaultExprRequired{},
+			[]protohcltest.ExpectedDiagnostic{
+				protohcltest.Error(schemaErrorSummary).WithDetail(
+					"Invalid HCL annotations in protobuf schema for hcl.testschema.WithDefaultExprRequired.name: a required attribute can't have default_expr.\n\nThis is a bug in the component that defined this schema, and not an error in the given configuration.",
+				),
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			desc := testschema.File_testschema_proto.Messages().ByName(protoreflect.Name(test.messageType))
+			got, diags := DecodeBody(parseTestBody(t, test.config), desc, nil)
+			protohcltest.AssertDiagnostics(t, diags, test.wantDiags...)
+			if diff := cmp.Diff(test.want, got, protoCmpOpt); diff != "" {
+				t.Errorf("wrong result\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDecodeBodyDefaultExprPresence(t *testing.T) {
+	desc := (&testschema.WithDefaultExprs{}).ProtoReflect().Descriptor()
+	_, presence, diags := DecodeBodyWithPresence(parseTestBody(t, `name = "web"`), desc, nil)
+	protohcltest.AssertDiagnostics(t, diags)
+
+	path := protopath.Path{
+		protopath.Root(desc),
+		protopath.FieldAccess(desc.Fields().ByName("display_name")),
+	}
+	if got, want := presence.Presence(path), PresenceAbsent; got != want {
+		t.Errorf("wrong presence for display_name\ngot:  %s\nwant: %s", got, want)
+	}
+}
+
+func TestDecodeBodyDefaultExprsEvaluateOnce(t *testing.T) {
+	// The default expressions refer to the attribute that the configuration
+	// defines, whose value must come from the decoder's own evaluation of
+	// the attribute rather than from evaluating its expression again.
+	evals := make(map[string]int)
+	opts := DecodeOptions{
+		EvaluateExpression: func(expr hcl.Expression, ctx *hcl.EvalContext) (cty.Value, hcl.Diagnostics) {
+			evals[expr.Range().String()]++
+			return expr.Value(ctx)
+		},
+	}
+	desc := (&testschema.WithDefaultExprs{}).ProtoReflect().Descriptor()
+	got, diags := opts.DecodeBody(parseTestBody(t, `name = "web"`), desc, nil)
+	protohcltest.AssertDiagnostics(t, diags)
+
+	want := &testschema.WithDefaultExprs{
+		Name:        "web",
+		DisplayName: "web",
+		Greeting:    "Hello, web!",
+	}
+	if diff := cmp.Diff(proto.Message(want), got, protoCmpOpt); diff != "" {
+		t.Errorf("wrong result\n%s", diff)
+	}
+	for rng, count := range evals {
+		if count != 1 {
+			t.Errorf("expression at %s evaluated %d times; want 1", rng, count)
+		}
+	}
+}
+
+func TestValidateMessageDescDefaultExprInvalid(t *testing.T) {
+	desc := (&testschema.WithDefaultExprInvalid{}).ProtoReflect().Descriptor()
+	problems := ValidateMessageDesc(desc)
+	if len(problems) != 1 {
+		t.Fatalf("wrong number of problems %d; want 1\n%#v", len(problems), problems)
+	}
+	if got, want := problems[0].Message, "invalid default_expr: :1,2-2: Missing expression; Expected the start of an expression, but found the end of the file."; got != want {
+		t.Errorf("wrong problem\ngot:  %s\nwant: %s", got, want)
+	}
+}
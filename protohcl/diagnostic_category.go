@@ -0,0 +1,145 @@
+package protohcl
+
+import (
+	"fmt"
+	"strings"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"google.golang.org/protobuf/reflect/protopath"
+)
+
+// DiagnosticCategory is a broad classification of the problem that a
+// diagnostic describes, which allows host applications to route or suppress
+// classes of problems without matching on diagnostic messages.
+type DiagnosticCategory int
+
+const (
+	// DiagnosticUncategorized is the category of any diagnostic that
+	// doesn't belong to one of the other categories, including all
+	// diagnostics that protohcl didn't produce.
+	DiagnosticUncategorized DiagnosticCategory = 0
+
+	// DiagnosticSchemaError means that the protobuf schema has invalid HCL
+	// annotations, which is a bug in the software that defined the schema
+	// rather than a problem with the configuration.
+	DiagnosticSchemaError DiagnosticCategory = 1
+
+	// DiagnosticValueError means that an attribute's value isn't suitable
+	// for the field it would be decoded into.
+	DiagnosticValueError DiagnosticCategory = 2
+
+	// DiagnosticUnknownValue means that an attribute's value, or part of
+	// it, isn't known yet, and so it can't be decoded into a non-raw field.
+	// A host that evaluates configuration in multiple passes might choose
+	// to suppress these during early passes.
+	DiagnosticUnknownValue DiagnosticCategory = 3
+
+	// DiagnosticMissingRequired means that the configuration doesn't define
+	// a required attribute, or doesn't define any of a set of attributes
+	// whose default expressions refer to each other in a cycle.
+	DiagnosticMissingRequired DiagnosticCategory = 4
+
+	// DiagnosticConflict means that the configuration defines something
+	// more than once where only one definition is allowed, such as two
+	// blocks of a singleton block type or two blocks with the same map key.
+	DiagnosticConflict DiagnosticCategory = 5
+
+	// DiagnosticUnsupported means that the configuration includes an
+	// attribute, block, or block label that the schema doesn't expect.
+	DiagnosticUnsupported DiagnosticCategory = 6
+
+	// DiagnosticDeprecated means that the configuration uses a name that
+	// the schema still accepts but has replaced, such as one of the
+	// aliases of a block type. Diagnostics in this category are warnings.
+	DiagnosticDeprecated DiagnosticCategory = 7
+
+	// DiagnosticUnsupportedFeature means that the protobuf schema uses an
+	// HCL annotation from a newer version of protohcl than the one that is
+	// decoding, and so the application must be upgraded to use the schema.
+	DiagnosticUnsupportedFeature DiagnosticCategory = 8
+)
+
+func (c DiagnosticCategory) String() string {
+	switch c {
+	case DiagnosticUncategorized:
+		return "uncategorized"
+	case DiagnosticSchemaError:
+		return "schema-error"
+	case DiagnosticValueError:
+		return "value-error"
+	case DiagnosticUnknownValue:
+		return "unknown-value"
+	case DiagnosticMissingRequired:
+		return "missing-required"
+	case DiagnosticConflict:
+		return "conflict"
+	case DiagnosticUnsupported:
+		return "unsupported"
+	case DiagnosticDeprecated:
+		return "deprecated"
+	case DiagnosticUnsupportedFeature:
+		return "unsupported-feature"
+	default:
+		return fmt.Sprintf("DiagnosticCategory(%d)", int(c))
+	}
+}
+
+// diagnosticCategoryNamed returns the category whose String result is the
+// given name, or false if there is no such category. The uncategorized
+// category has no name for this purpose.
+func diagnosticCategoryNamed(name string) (DiagnosticCategory, bool) {
+	for c := DiagnosticSchemaError; c <= DiagnosticUnsupportedFeature; c++ {
+		if c.String() == name {
+			return c, true
+		}
+	}
+	return DiagnosticUncategorized, false
+}
+
+// DiagnosticCategoryOf returns the category of the given diagnostic, which
+// should be one returned by one of the decoding functions in this package.
+//
+// The diagnostics that protohcl returns also include some produced by HCL
+// itself while checking a body against its schema, and DiagnosticCategoryOf
+// also classifies those.
+func DiagnosticCategoryOf(diag *hcl.Diagnostic) DiagnosticCategory {
+	if diag == nil {
+		return DiagnosticUncategorized
+	}
+	if extra, ok := hcl.DiagnosticExtra[diagnosticExtra](diag); ok {
+		return extra.Category
+	}
+
+	// HCL's own diagnostics don't carry any extra information, so we
+	// recognize the ones that HCL produces when checking a body against
+	// its schema, or when its JSON syntax finds a duplicate object key,
+	// by their summaries.
+	switch summary := diag.Summary; {
+	case summary == "Missing required argument":
+		return DiagnosticMissingRequired
+	case summary == "Duplicate argument", summary == duplicateObjectKeySummary:
+		return DiagnosticConflict
+	case summary == "Unsupported argument", summary == "Unsupported block type", strings.HasPrefix(summary, "Extraneous label for "):
+		return DiagnosticUnsupported
+	default:
+		return DiagnosticUncategorized
+	}
+}
+
+// diagnosticExtra is the value of the Extra field of each categorized
+// diagnostic that protohcl produces.
+type diagnosticExtra struct {
+	Category DiagnosticCategory
+
+	// FieldPath is the path of the field that the diagnostic relates to,
+	// which the decoder records only while building a DecodeTrace.
+	FieldPath protopath.Path
+
+	// Wrapped is the original Extra value of a diagnostic that protohcl
+	// didn't produce but has recorded a field path for.
+	Wrapped interface{}
+}
+
+func (extra diagnosticExtra) UnwrapDiagnosticExtra() interface{} {
+	return extra.Wrapped
+}
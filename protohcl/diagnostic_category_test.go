@@ -0,0 +1,121 @@
+package protohcl
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/apparentlymart/go-protohcl/protohcl/internal/testschema"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func TestDiagnosticCategoryOf(t *testing.T) {
+	ctx := &hcl.EvalContext{
+		Variables: map[string]cty.Value{
+			"unknown": cty.UnknownVal(cty.String),
+		},
+	}
+
+	tests := map[string]struct {
+		desc   protoreflect.Name
+		config string
+		want   DiagnosticCategory
+	}{
+		"schema error": {
+			"WithInvalidNestedBlocks",
+			`a {}`,
+			DiagnosticSchemaError,
+		},
+		"value error": {
+			"WithStringAttr",
+			`name = ["a"]`,
+			DiagnosticValueError,
+		},
+		"integer out of range": {
+			"WithNumberAttrAsInt32",
+			`num = 5000000000`,
+			DiagnosticValueError,
+		},
+		"unknown value": {
+			"WithStringAttr",
+			`name = unknown`,
+			DiagnosticUnknownValue,
+		},
+		"unknown value in object": {
+			"WithListOfObjectsAttr",
+			`items = [{ name = unknown }]`,
+			DiagnosticUnknownValue,
+		},
+		"missing required": {
+			"WithOptionalAttrs",
+			``,
+			DiagnosticMissingRequired,
+		},
+		"duplicate argument": {
+			// The second definition is in a separate file, so that HCL
+			// detects the conflict while merging the bodies rather than
+			// while parsing.
+			"WithStringAttr",
+			"name = \"a\"\n---\nname = \"b\"",
+			DiagnosticConflict,
+		},
+		"duplicate block": {
+			"WithNestedBlockNoLabelsSingleton",
+			"doodad {}\ndoodad {}",
+			DiagnosticConflict,
+		},
+		"duplicate object key": {
+			"WithStringMapAttr",
+			`names = { a = "x", a = "y" }`,
+			DiagnosticConflict,
+		},
+		"unsupported argument": {
+			"WithStringAttr",
+			`extra = true`,
+			DiagnosticUnsupported,
+		},
+		"unsupported block type": {
+			"WithStringAttr",
+			`extra {}`,
+			DiagnosticUnsupported,
+		},
+		"extraneous label": {
+			"WithNestedBlockNoLabelsSingleton",
+			`doodad "x" {}`,
+			DiagnosticUnsupported,
+		},
+		"deprecated block type": {
+			"WithBlockTypeAliases",
+			`old_thing {}`,
+			DiagnosticDeprecated,
+		},
+		"unknown variable": {
+			"WithStringAttr",
+			`name = nonexistent`,
+			DiagnosticUncategorized,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var bodies []hcl.Body
+			for _, src := range strings.Split(test.config, "\n---\n") {
+				f, diags := hclsyntax.ParseConfig([]byte(src), "", hcl.InitialPos)
+				if diags.HasErrors() {
+					t.Fatalf("unexpected syntax errors: %s", diags.Error())
+				}
+				bodies = append(bodies, f.Body)
+			}
+			desc := testschema.File_testschema_proto.Messages().ByName(test.desc)
+			_, diags := DecodeBody(hcl.MergeBodies(bodies), desc, ctx)
+			if len(diags) == 0 {
+				t.Fatalf("unexpected success")
+			}
+			if got := DiagnosticCategoryOf(diags[0]); got != test.want {
+				t.Errorf("wrong category %s; want %s\ndiagnostic: %s", got, test.want, diags[0].Error())
+			}
+		})
+	}
+}
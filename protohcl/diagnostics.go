@@ -0,0 +1,157 @@
+package protohcl
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	hcl "github.com/hashicorp/hcl/v2"
+)
+
+// NewDiagnosticTextWriter returns a diagnostic writer that renders
+// diagnostics in the same way as hcl.NewDiagnosticTextWriter, including
+// snippets of the given source files, but which also mentions the protobuf
+// field that each diagnostic relates to, if known.
+//
+// The field information comes from the Extra field of each diagnostic, as
+// returned by DiagnosticFieldPath, and so only diagnostics returned by
+// DecodeBodyWithTrace mention fields. Other diagnostics are written in the
+// same way as by hcl.NewDiagnosticTextWriter.
+//
+// Field paths are most useful to the developers of the software that defined
+// the schema, rather than to configuration authors, and so hosts will
+// typically use this only in debugging or schema development tools.
+func NewDiagnosticTextWriter(wr io.Writer, files map[string]*hcl.File, width uint, color bool) hcl.DiagnosticWriter {
+	return &diagnosticTextWriter{
+		wrapped: hcl.NewDiagnosticTextWriter(wr, files, width, color),
+	}
+}
+
+type diagnosticTextWriter struct {
+	wrapped hcl.DiagnosticWriter
+}
+
+func (w *diagnosticTextWriter) WriteDiagnostic(diag *hcl.Diagnostic) error {
+	path, ok := DiagnosticFieldPath(diag)
+	if !ok {
+		return w.wrapped.WriteDiagnostic(diag)
+	}
+
+	// We'll write a modified copy of the diagnostic, so that the field
+	// information is wrapped along with the rest of the detail text.
+	withField := *diag
+	if withField.Detail != "" {
+		withField.Detail += "\n\n"
+	}
+	withField.Detail += fmt.Sprintf("Protobuf field: %s", path)
+	return w.wrapped.WriteDiagnostic(&withField)
+}
+
+func (w *diagnosticTextWriter) WriteDiagnostics(diags hcl.Diagnostics) error {
+	for _, diag := range diags {
+		err := w.WriteDiagnostic(diag)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SortDiagnostics sorts the given diagnostics in place into a deterministic
+// order that depends only on their source locations and severities, and not
+// on the order of the fields in the schema that produced them.
+//
+// Diagnostics without a subject range come first, followed by the others
+// ordered by filename and then by the start position of their subject
+// ranges. Errors come before warnings at the same position, and
+// diagnostics that are otherwise equal keep their original relative order.
+//
+// The decoding functions in this package all sort their diagnostics in this
+// way before returning them, so callers need to use this only to order
+// diagnostics that they have combined from multiple sources.
+func SortDiagnostics(diags hcl.Diagnostics) {
+	sort.SliceStable(diags, func(i, j int) bool {
+		a, b := diags[i], diags[j]
+		switch {
+		case a.Subject == nil || b.Subject == nil:
+			if (a.Subject == nil) != (b.Subject == nil) {
+				return a.Subject == nil
+			}
+		case a.Subject.Filename != b.Subject.Filename:
+			return a.Subject.Filename < b.Subject.Filename
+		case a.Subject.Start.Byte != b.Subject.Start.Byte:
+			return a.Subject.Start.Byte < b.Subject.Start.Byte
+		}
+		return diagSeverityOrder(a.Severity) < diagSeverityOrder(b.Severity)
+	})
+}
+
+// DeduplicateDiagnostics returns the given diagnostics with any that are
+// identical to an earlier one removed, keeping the rest in their original
+// order.
+//
+// Diagnostics are identical if they have the same severity, summary,
+// detail, subject range, and context range. The same problem in a schema
+// can otherwise be reported once for each block that uses the affected
+// message type, for example.
+//
+// The decoding functions in this package all deduplicate their diagnostics
+// in this way before returning them, so callers need to use this only for
+// diagnostics that they have combined from multiple sources.
+func DeduplicateDiagnostics(diags hcl.Diagnostics) hcl.Diagnostics {
+	if len(diags) < 2 {
+		return diags
+	}
+	seen := make(map[diagnosticKey]struct{}, len(diags))
+	ret := make(hcl.Diagnostics, 0, len(diags))
+	for _, diag := range diags {
+		key := diagnosticKeyFor(diag)
+		if _, exists := seen[key]; exists {
+			continue
+		}
+		seen[key] = struct{}{}
+		ret = append(ret, diag)
+	}
+	return ret
+}
+
+// diagnosticKey is the subset of a diagnostic's fields that
+// DeduplicateDiagnostics compares, in a form that can be a map key.
+type diagnosticKey struct {
+	severity        hcl.DiagnosticSeverity
+	summary, detail string
+	subject         hcl.Range
+	hasSubject      bool
+	context         hcl.Range
+	hasContext      bool
+}
+
+func diagnosticKeyFor(diag *hcl.Diagnostic) diagnosticKey {
+	key := diagnosticKey{
+		severity: diag.Severity,
+		summary:  diag.Summary,
+		detail:   diag.Detail,
+	}
+	if diag.Subject != nil {
+		key.subject = *diag.Subject
+		key.hasSubject = true
+	}
+	if diag.Context != nil {
+		key.context = *diag.Context
+		key.hasContext = true
+	}
+	return key
+}
+
+// diagSeverityOrder returns a sort key for the given severity, which puts
+// errors before warnings.
+func diagSeverityOrder(severity hcl.DiagnosticSeverity) int {
+	switch severity {
+	case hcl.DiagError:
+		return 0
+	case hcl.DiagWarning:
+		return 1
+	default:
+		return 2
+	}
+}
@@ -0,0 +1,162 @@
+package protohcl
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/apparentlymart/go-protohcl/protohcl/internal/testschema"
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func TestNewDiagnosticTextWriter(t *testing.T) {
+	desc := testschema.File_testschema_proto.Messages().ByName(protoreflect.Name("WithNestedBlockOneLabelSingleton"))
+	parser := hclparse.NewParser()
+	f, diags := parser.ParseHCL([]byte(`
+doodad "Jackson" {
+  nickname = ["doofus"]
+}
+extra = true
+`), "test.tf")
+	if diags.HasErrors() {
+		t.Fatalf("parse error: %s", diags)
+	}
+
+	_, _, diags = DecodeBodyWithTrace(f.Body, desc, nil)
+	if len(diags) != 2 {
+		t.Fatalf("wrong number of diagnostics %d; want 2\n%s", len(diags), diags.Error())
+	}
+
+	var buf bytes.Buffer
+	wr := NewDiagnosticTextWriter(&buf, parser.Files(), 0, false)
+	err := wr.WriteDiagnostics(diags)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	want := `Error: Unsuitable attribute value
+
+  on test.tf line 3, in doodad "Jackson":
+   3:   nickname = ["doofus"]
+
+Inappropriate value for attribute "nickname": string required.
+
+Protobuf field: (hcl.testschema.WithNestedBlockOneLabelSingleton).doodad.nickname
+
+Error: Unsupported argument
+
+  on test.tf line 5:
+   5: extra = true
+
+An argument named "extra" is not expected here.
+
+`
+	if diff := cmp.Diff(want, buf.String()); diff != "" {
+		t.Errorf("wrong output\n%s", diff)
+	}
+}
+
+func TestSortDiagnostics(t *testing.T) {
+	rng := func(filename string, line, byteOffset int) *hcl.Range {
+		return &hcl.Range{
+			Filename: filename,
+			Start:    hcl.Pos{Line: line, Column: 1, Byte: byteOffset},
+			End:      hcl.Pos{Line: line, Column: 2, Byte: byteOffset + 1},
+		}
+	}
+	diags := hcl.Diagnostics{
+		{Severity: hcl.DiagWarning, Summary: "b.tf warning", Subject: rng("b.tf", 1, 0)},
+		{Severity: hcl.DiagError, Summary: "a.tf line 3", Subject: rng("a.tf", 3, 20)},
+		{Severity: hcl.DiagWarning, Summary: "a.tf line 1 warning", Subject: rng("a.tf", 1, 0)},
+		{Severity: hcl.DiagError, Summary: "no subject 1"},
+		{Severity: hcl.DiagError, Summary: "a.tf line 1 error", Subject: rng("a.tf", 1, 0)},
+		{Severity: hcl.DiagError, Summary: "no subject 2"},
+		{Severity: hcl.DiagError, Summary: "a.tf line 1 second error", Subject: rng("a.tf", 1, 0)},
+	}
+	SortDiagnostics(diags)
+
+	var got []string
+	for _, diag := range diags {
+		got = append(got, diag.Summary)
+	}
+	want := []string{
+		"no subject 1",
+		"no subject 2",
+		"a.tf line 1 error",
+		"a.tf line 1 second error",
+		"a.tf line 1 warning",
+		"a.tf line 3",
+		"b.tf warning",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("wrong order\n%s", diff)
+	}
+}
+
+func TestDeduplicateDiagnostics(t *testing.T) {
+	rng := func(line int) *hcl.Range {
+		return &hcl.Range{
+			Filename: "a.tf",
+			Start:    hcl.Pos{Line: line, Column: 1, Byte: line * 10},
+			End:      hcl.Pos{Line: line, Column: 2, Byte: line*10 + 1},
+		}
+	}
+	diags := hcl.Diagnostics{
+		{Severity: hcl.DiagError, Summary: "schema", Detail: "first"},
+		{Severity: hcl.DiagError, Summary: "value", Subject: rng(1)},
+		{Severity: hcl.DiagError, Summary: "schema", Detail: "first"},
+		{Severity: hcl.DiagError, Summary: "schema", Detail: "second"},
+		{Severity: hcl.DiagWarning, Summary: "schema", Detail: "first"},
+		{Severity: hcl.DiagError, Summary: "value", Subject: rng(2)},
+		{Severity: hcl.DiagError, Summary: "value", Subject: rng(1)},
+		{Severity: hcl.DiagError, Summary: "value", Subject: rng(1), Context: rng(1)},
+	}
+	got := DeduplicateDiagnostics(diags)
+
+	want := hcl.Diagnostics{diags[0], diags[1], diags[3], diags[4], diags[5], diags[7]}
+	if len(got) != len(want) {
+		t.Fatalf("wrong number of diagnostics %d; want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("wrong diagnostic %d: %s (%s)", i, got[i].Summary, got[i].Detail)
+		}
+	}
+}
+
+func TestDecodeBodyDeduplicatesDiagnostics(t *testing.T) {
+	desc := testschema.File_testschema_proto.Messages().ByName("WithRepeatedInvalidBlocks")
+	f, diags := hclsyntax.ParseConfig([]byte(`
+		item {}
+		item {}
+		mismatched {
+			name = ["a"]
+		}
+		mismatched {
+			name = ["b"]
+		}
+	`), "", hcl.InitialPos)
+	if diags.HasErrors() {
+		t.Fatalf("unexpected syntax errors: %s", diags.Error())
+	}
+
+	// Both "item" blocks have the same problem with their schema, which
+	// we report only once, but the two value errors have different
+	// subjects and so we report both.
+	_, diags = DecodeBody(f.Body, desc, nil)
+	var got []string
+	for _, diag := range diags {
+		got = append(got, diag.Summary)
+	}
+	want := []string{
+		schemaErrorSummary,
+		unsuitableValueSummary,
+		unsuitableValueSummary,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("wrong diagnostics\n%s", diff)
+	}
+}
@@ -10,9 +10,14 @@
 // language-agnostic representation of its configuration. In such a system,
 // only the client which decodes the configuration need be written in Go,
 // whereas the plugins can be written in any protobuf-supporting language.
+// The examples directory of this module's repository includes a working
+// example of such a system for each of those two plugin frameworks.
 //
 // protohcl works by defining a small set of protobuf extension options,
 // defined in the associated protobuf schema hcl.proto. protohcl then
 // detects those options in the given message descriptors and uses them
 // to derive an equivalent HCL schema for decoding.
+//
+// The same annotations can also populate messages from sources other than
+// HCL configuration, such as command line flags; see Decoder.
 package protohcl
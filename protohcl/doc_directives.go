@@ -0,0 +1,337 @@
+package protohcl
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/apparentlymart/go-protohcl/protohcl/protohclext"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// ApplyDocDirectives returns a copy of the given descriptor set with HCL
+// options derived from directives in the comments of its messages, fields,
+// and enum values.
+//
+// This is for schemas that can't be recompiled to use the options from
+// hcl.proto, such as those from third-party libraries. The comments are
+// available only if the descriptor set includes source code information,
+// such as by running protoc with its --include_source_info option.
+//
+// A directive is a line in the leading or trailing comment of a declaration
+// that starts with "@hcl", followed by the name of one of the options from
+// hcl.proto and then any number of arguments setting the fields of that
+// option's message, separated by spaces:
+//
+//	// @hcl attr name=display_name required type="list(string)"
+//	repeated string display_names = 1;
+//
+// The directives for fields are "attr", "block", "label", and "flatten", the
+// directive for messages is "message", and the directive for enum values is
+// "enumval". Each argument is either key=value, where the value can be in
+// double quotes if it contains spaces, or just the key of a bool field to
+// set it to true. Enum values are case-insensitive, such as kind=list.
+//
+// ApplyDocDirectives ignores any directive for an option that the
+// declaration already sets explicitly. It returns an error if any directive
+// is malformed, but checking whether the resulting options are valid is
+// left to the same functions that check explicitly-set options, such as
+// ValidateMessageDesc.
+func ApplyDocDirectives(set *descriptorpb.FileDescriptorSet) (*descriptorpb.FileDescriptorSet, error) {
+	ret := proto.Clone(set).(*descriptorpb.FileDescriptorSet)
+	for _, file := range ret.File {
+		if err := applyFileDocDirectives(file); err != nil {
+			return nil, err
+		}
+	}
+	return ret, nil
+}
+
+// Field numbers from descriptor.proto, which appear in the paths of
+// SourceCodeInfo locations.
+const (
+	fileMessageTypeNum   = 4
+	fileEnumTypeNum      = 5
+	messageFieldNum      = 2
+	messageNestedTypeNum = 3
+	messageEnumTypeNum   = 4
+	enumValueNum         = 2
+)
+
+type docDirectiveApplier struct {
+	file     *descriptorpb.FileDescriptorProto
+	comments map[string]string
+}
+
+func applyFileDocDirectives(file *descriptorpb.FileDescriptorProto) error {
+	a := &docDirectiveApplier{
+		file:     file,
+		comments: make(map[string]string),
+	}
+	for _, loc := range file.GetSourceCodeInfo().GetLocation() {
+		comment := loc.GetLeadingComments()
+		if trailing := loc.GetTrailingComments(); trailing != "" {
+			comment += "\n" + trailing
+		}
+		if comment != "" {
+			a.comments[sourcePathKey(loc.Path)] += comment
+		}
+	}
+	if len(a.comments) == 0 {
+		return nil
+	}
+
+	prefix := protoreflect.FullName(file.GetPackage())
+	for i, msg := range file.MessageType {
+		if err := a.applyMessage(msg, appendName(prefix, msg.GetName()), []int32{fileMessageTypeNum, int32(i)}); err != nil {
+			return err
+		}
+	}
+	for i, enum := range file.EnumType {
+		if err := a.applyEnum(enum, appendName(prefix, enum.GetName()), []int32{fileEnumTypeNum, int32(i)}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (a *docDirectiveApplier) applyMessage(msg *descriptorpb.DescriptorProto, name protoreflect.FullName, path []int32) error {
+	for _, directive := range a.directives(path) {
+		if directive.kind != "message" {
+			return a.errorf(name, "unsupported directive %q for a message; must be \"message\"", directive.kind)
+		}
+		if msg.Options == nil {
+			msg.Options = &descriptorpb.MessageOptions{}
+		}
+		if err := a.setOption(msg.Options, protohclext.E_Message, directive, name); err != nil {
+			return err
+		}
+	}
+
+	for i, field := range msg.Field {
+		fieldName := name.Append(protoreflect.Name(field.GetName()))
+		for _, directive := range a.directives(appendSourcePath(path, messageFieldNum, i)) {
+			if field.Options == nil {
+				field.Options = &descriptorpb.FieldOptions{}
+			}
+			var err error
+			switch directive.kind {
+			case "attr":
+				err = a.setOption(field.Options, protohclext.E_Attr, directive, fieldName)
+			case "block":
+				err = a.setOption(field.Options, protohclext.E_Block, directive, fieldName)
+			case "label":
+				err = a.setOption(field.Options, protohclext.E_Label, directive, fieldName)
+			case "flatten":
+				if len(directive.args) != 0 {
+					return a.errorf(fieldName, "the \"flatten\" directive doesn't accept any arguments")
+				}
+				if !proto.HasExtension(field.Options, protohclext.E_Flatten) {
+					proto.SetExtension(field.Options, protohclext.E_Flatten, true)
+				}
+			default:
+				return a.errorf(fieldName, "unsupported directive %q for a field; must be \"attr\", \"block\", \"label\", or \"flatten\"", directive.kind)
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+	for i, nested := range msg.NestedType {
+		if err := a.applyMessage(nested, name.Append(protoreflect.Name(nested.GetName())), appendSourcePath(path, messageNestedTypeNum, i)); err != nil {
+			return err
+		}
+	}
+	for i, enum := range msg.EnumType {
+		if err := a.applyEnum(enum, name.Append(protoreflect.Name(enum.GetName())), appendSourcePath(path, messageEnumTypeNum, i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (a *docDirectiveApplier) applyEnum(enum *descriptorpb.EnumDescriptorProto, name protoreflect.FullName, path []int32) error {
+	for i, value := range enum.Value {
+		// Enum values are siblings of their enum type in the namespace.
+		valueName := name.Parent().Append(protoreflect.Name(value.GetName()))
+		if name.Parent() == "" {
+			valueName = protoreflect.FullName(value.GetName())
+		}
+		for _, directive := range a.directives(appendSourcePath(path, enumValueNum, i)) {
+			if directive.kind != "enumval" {
+				return a.errorf(valueName, "unsupported directive %q for an enum value; must be \"enumval\"", directive.kind)
+			}
+			if value.Options == nil {
+				value.Options = &descriptorpb.EnumValueOptions{}
+			}
+			if err := a.setOption(value.Options, protohclext.E_Enumval, directive, valueName); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// setOption sets the given message-typed extension on the given options
+// message from the arguments of the given directive, unless the options
+// already have that extension.
+func (a *docDirectiveApplier) setOption(opts proto.Message, ext protoreflect.ExtensionType, directive docDirective, name protoreflect.FullName) error {
+	if proto.HasExtension(opts, ext) {
+		return nil
+	}
+	val := ext.New()
+	msg := val.Message()
+	for _, arg := range directive.args {
+		if err := setDocDirectiveArg(msg, arg); err != nil {
+			return a.errorf(name, "invalid %q directive: %s", directive.kind, err)
+		}
+	}
+	opts.ProtoReflect().Set(ext.TypeDescriptor(), val)
+	return nil
+}
+
+func (a *docDirectiveApplier) directives(path []int32) []docDirective {
+	comment, ok := a.comments[sourcePathKey(path)]
+	if !ok {
+		return nil
+	}
+	var ret []docDirective
+	for _, line := range strings.Split(comment, "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "@hcl ") && line != "@hcl" {
+			continue
+		}
+		words := splitDocDirective(strings.TrimPrefix(line, "@hcl"))
+		if len(words) == 0 {
+			// An empty directive would be an error, but we'll let the
+			// caller report it as an unsupported kind.
+			words = []string{""}
+		}
+		ret = append(ret, docDirective{kind: words[0], args: words[1:]})
+	}
+	return ret
+}
+
+func (a *docDirectiveApplier) errorf(name protoreflect.FullName, format string, args ...interface{}) error {
+	return fmt.Errorf("%s: invalid @hcl directive for %s: %s", a.file.GetName(), name, fmt.Sprintf(format, args...))
+}
+
+type docDirective struct {
+	kind string
+	args []string
+}
+
+// setDocDirectiveArg sets a field of the given options message from a single
+// directive argument.
+func setDocDirectiveArg(msg protoreflect.Message, arg string) error {
+	key, raw := arg, ""
+	hasValue := false
+	if eq := strings.IndexByte(arg, '='); eq >= 0 {
+		key, raw, hasValue = arg[:eq], arg[eq+1:], true
+	}
+	field := msg.Descriptor().Fields().ByName(protoreflect.Name(key))
+	if field == nil || field.Cardinality() == protoreflect.Repeated {
+		return fmt.Errorf("unsupported argument %q", key)
+	}
+	if msg.Has(field) {
+		return fmt.Errorf("argument %q is set more than once", key)
+	}
+	if hasValue && strings.HasPrefix(raw, `"`) {
+		unquoted, err := strconv.Unquote(raw)
+		if err != nil {
+			return fmt.Errorf("invalid quoted value for argument %q", key)
+		}
+		raw = unquoted
+	}
+
+	switch field.Kind() {
+	case protoreflect.BoolKind:
+		v := true
+		if hasValue {
+			var err error
+			v, err = strconv.ParseBool(raw)
+			if err != nil {
+				return fmt.Errorf("argument %q must be true or false", key)
+			}
+		}
+		msg.Set(field, protoreflect.ValueOfBool(v))
+	case protoreflect.StringKind:
+		if !hasValue {
+			return fmt.Errorf("argument %q requires a value", key)
+		}
+		msg.Set(field, protoreflect.ValueOfString(raw))
+	case protoreflect.EnumKind:
+		if !hasValue {
+			return fmt.Errorf("argument %q requires a value", key)
+		}
+		values := field.Enum().Values()
+		value := values.ByName(protoreflect.Name(strings.ToUpper(raw)))
+		if value == nil {
+			names := make([]string, values.Len())
+			for i := range names {
+				names[i] = strings.ToLower(string(values.Get(i).Name()))
+			}
+			return fmt.Errorf("argument %q must be one of %s", key, strings.Join(names, ", "))
+		}
+		msg.Set(field, protoreflect.ValueOfEnum(value.Number()))
+	default:
+		// Should not get here, because hcl.proto has no other types of
+		// scalar option fields.
+		return fmt.Errorf("unsupported argument %q", key)
+	}
+	return nil
+}
+
+// splitDocDirective splits the given directive text into words separated by
+// spaces, except for spaces inside double-quoted strings.
+func splitDocDirective(s string) []string {
+	var words []string
+	var current strings.Builder
+	inWord, inQuotes, escaped := false, false, false
+	for _, r := range s {
+		switch {
+		case escaped:
+			escaped = false
+		case inQuotes && r == '\\':
+			escaped = true
+		case r == '"':
+			inQuotes = !inQuotes
+		case !inQuotes && (r == ' ' || r == '\t'):
+			if inWord {
+				words = append(words, current.String())
+				current.Reset()
+				inWord = false
+			}
+			continue
+		}
+		current.WriteRune(r)
+		inWord = true
+	}
+	if inWord {
+		words = append(words, current.String())
+	}
+	return words
+}
+
+func appendName(prefix protoreflect.FullName, name string) protoreflect.FullName {
+	if prefix == "" {
+		return protoreflect.FullName(name)
+	}
+	return prefix.Append(protoreflect.Name(name))
+}
+
+func appendSourcePath(path []int32, fieldNum int32, index int) []int32 {
+	ret := make([]int32, len(path), len(path)+2)
+	copy(ret, path)
+	return append(ret, fieldNum, int32(index))
+}
+
+func sourcePathKey(path []int32) string {
+	var buf strings.Builder
+	for _, step := range path {
+		fmt.Fprintf(&buf, "%d.", step)
+	}
+	return buf.String()
+}
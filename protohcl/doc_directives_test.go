@@ -0,0 +1,249 @@
+package protohcl
+
+import (
+	"testing"
+
+	"github.com/apparentlymart/go-protohcl/protohcl/protohclext"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+func TestApplyDocDirectives(t *testing.T) {
+	set := &descriptorpb.FileDescriptorSet{
+		File: []*descriptorpb.FileDescriptorProto{
+			docDirectivesTestFile(map[string]string{
+				"4.0.2.0.": " @hcl attr name=name required\n",
+				"4.0.2.1.": " Rules to apply.\n @hcl block type_name=rule kind=list\n",
+				"4.0.2.2.": " @hcl attr name=level\n",
+				"4.1.2.0.": " @hcl label name=id\n",
+				"4.1.2.1.": ` @hcl attr name=comment type="string"`,
+				"5.0.2.1.": " @hcl enumval name=high\n",
+			}),
+		},
+	}
+	// The second rule field already has an explicit option, which the
+	// directive must not override.
+	proto.SetExtension(set.File[0].MessageType[1].Field[1].Options, protohclext.E_Attr, &protohclext.Attribute{Name: "note"})
+
+	got, err := ApplyDocDirectives(set)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if proto.HasExtension(set.File[0].MessageType[0].Field[0].Options, protohclext.E_Attr) {
+		t.Errorf("ApplyDocDirectives modified its argument")
+	}
+
+	files, err := protodesc.NewFiles(got)
+	if err != nil {
+		t.Fatalf("invalid result: %s", err)
+	}
+	d, err := files.FindDescriptorByName("thirdparty.Config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	desc := d.(protoreflect.MessageDescriptor)
+	for _, problem := range ValidateMessageDesc(desc) {
+		if problem.Severity == SchemaProblemError {
+			t.Errorf("unexpected schema problem: %s", problem.Message)
+		}
+	}
+
+	f, diags := hclsyntax.ParseConfig([]byte(`
+name  = "example"
+level = "high"
+
+rule "a" {
+  note = "first"
+}
+rule "b" {}
+`), "", hcl.InitialPos)
+	if diags.HasErrors() {
+		t.Fatalf("unexpected syntax errors: %s", diags.Error())
+	}
+	gotMsg, diags := DecodeBody(f.Body, desc, nil)
+	if diags.HasErrors() {
+		t.Fatalf("unexpected errors: %s", diags.Error())
+	}
+	wantMsg := dynamicpb.NewMessage(desc)
+	err = prototext.Unmarshal([]byte(`
+		name: "example"
+		rules: { id: "a", comment: "first" }
+		rules: { id: "b" }
+		level: LEVEL_HIGH
+	`), wantMsg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !proto.Equal(gotMsg, wantMsg) {
+		t.Errorf("wrong result\ngot:  %s\nwant: %s", prototext.Format(gotMsg), prototext.Format(wantMsg))
+	}
+}
+
+func TestApplyDocDirectivesErrors(t *testing.T) {
+	tests := map[string]struct {
+		comments map[string]string
+		want     string
+	}{
+		"unsupported field directive": {
+			map[string]string{"4.0.2.0.": " @hcl attribute name=name"},
+			`thirdparty.proto: invalid @hcl directive for thirdparty.Config.name: unsupported directive "attribute" for a field; must be "attr", "block", "label", or "flatten"`,
+		},
+		"empty directive": {
+			map[string]string{"4.0.2.0.": " @hcl"},
+			`thirdparty.proto: invalid @hcl directive for thirdparty.Config.name: unsupported directive "" for a field; must be "attr", "block", "label", or "flatten"`,
+		},
+		"unsupported message directive": {
+			map[string]string{"4.0.": " @hcl attr name=config"},
+			`thirdparty.proto: invalid @hcl directive for thirdparty.Config: unsupported directive "attr" for a message; must be "message"`,
+		},
+		"unsupported enum value directive": {
+			map[string]string{"5.0.2.1.": " @hcl attr name=high"},
+			`thirdparty.proto: invalid @hcl directive for thirdparty.LEVEL_HIGH: unsupported directive "attr" for an enum value; must be "enumval"`,
+		},
+		"unsupported argument": {
+			map[string]string{"4.0.2.0.": " @hcl attr title=name"},
+			`thirdparty.proto: invalid @hcl directive for thirdparty.Config.name: invalid "attr" directive: unsupported argument "title"`,
+		},
+		"duplicate argument": {
+			map[string]string{"4.0.2.0.": " @hcl attr name=a name=b"},
+			`thirdparty.proto: invalid @hcl directive for thirdparty.Config.name: invalid "attr" directive: argument "name" is set more than once`,
+		},
+		"missing value": {
+			map[string]string{"4.0.2.0.": " @hcl attr name"},
+			`thirdparty.proto: invalid @hcl directive for thirdparty.Config.name: invalid "attr" directive: argument "name" requires a value`,
+		},
+		"invalid bool": {
+			map[string]string{"4.0.2.0.": " @hcl attr name=name required=maybe"},
+			`thirdparty.proto: invalid @hcl directive for thirdparty.Config.name: invalid "attr" directive: argument "required" must be true or false`,
+		},
+		"invalid enum": {
+			map[string]string{"4.0.2.1.": " @hcl block type_name=rule kind=bag"},
+			`thirdparty.proto: invalid @hcl directive for thirdparty.Config.rules: invalid "block" directive: argument "kind" must be one of auto, tuple, list, set, map`,
+		},
+		"invalid quoted value": {
+			map[string]string{"4.0.2.0.": ` @hcl attr name="name`},
+			`thirdparty.proto: invalid @hcl directive for thirdparty.Config.name: invalid "attr" directive: invalid quoted value for argument "name"`,
+		},
+		"flatten with arguments": {
+			map[string]string{"4.0.2.1.": " @hcl flatten true"},
+			`thirdparty.proto: invalid @hcl directive for thirdparty.Config.rules: the "flatten" directive doesn't accept any arguments`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			set := &descriptorpb.FileDescriptorSet{
+				File: []*descriptorpb.FileDescriptorProto{docDirectivesTestFile(test.comments)},
+			}
+			_, err := ApplyDocDirectives(set)
+			if err == nil {
+				t.Fatalf("unexpected success")
+			}
+			if got := err.Error(); got != test.want {
+				t.Errorf("wrong error\ngot:  %s\nwant: %s", got, test.want)
+			}
+		})
+	}
+}
+
+func TestSplitDocDirective(t *testing.T) {
+	got := splitDocDirective(` attr  name=a	type="map(string)" doc="a \"quoted\" word"`)
+	want := []string{"attr", "name=a", `type="map(string)"`, `doc="a \"quoted\" word"`}
+	if len(got) != len(want) {
+		t.Fatalf("wrong result %q; want %q", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("wrong word %d %q; want %q", i, got[i], want[i])
+		}
+	}
+}
+
+// docDirectivesTestFile returns a file descriptor for a schema without any
+// HCL options, with the given comments keyed by source path in the format
+// that sourcePathKey produces.
+func docDirectivesTestFile(comments map[string]string) *descriptorpb.FileDescriptorProto {
+	field := func(name string, num int32, typ descriptorpb.FieldDescriptorProto_Type, typeName string, label descriptorpb.FieldDescriptorProto_Label) *descriptorpb.FieldDescriptorProto {
+		f := &descriptorpb.FieldDescriptorProto{
+			Name:     proto.String(name),
+			JsonName: proto.String(name),
+			Number:   proto.Int32(num),
+			Type:     typ.Enum(),
+			Label:    label.Enum(),
+			Options:  &descriptorpb.FieldOptions{},
+		}
+		if typeName != "" {
+			f.TypeName = proto.String(typeName)
+		}
+		return f
+	}
+	const (
+		optional = descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL
+		repeated = descriptorpb.FieldDescriptorProto_LABEL_REPEATED
+	)
+
+	file := &descriptorpb.FileDescriptorProto{
+		Name:    proto.String("thirdparty.proto"),
+		Package: proto.String("thirdparty"),
+		Syntax:  proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{
+			{
+				Name: proto.String("Config"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					field("name", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, "", optional),
+					field("rules", 2, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, ".thirdparty.Rule", repeated),
+					field("level", 3, descriptorpb.FieldDescriptorProto_TYPE_ENUM, ".thirdparty.Level", optional),
+				},
+			},
+			{
+				Name: proto.String("Rule"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					field("id", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, "", optional),
+					field("comment", 2, descriptorpb.FieldDescriptorProto_TYPE_STRING, "", optional),
+				},
+			},
+		},
+		EnumType: []*descriptorpb.EnumDescriptorProto{
+			{
+				Name: proto.String("Level"),
+				Value: []*descriptorpb.EnumValueDescriptorProto{
+					{Name: proto.String("LEVEL_UNSPECIFIED"), Number: proto.Int32(0)},
+					{Name: proto.String("LEVEL_HIGH"), Number: proto.Int32(1)},
+				},
+			},
+		},
+		SourceCodeInfo: &descriptorpb.SourceCodeInfo{},
+	}
+
+	for key, comment := range comments {
+		var path []int32
+		var step int32
+		for _, c := range key {
+			if c == '.' {
+				path = append(path, step)
+				step = 0
+				continue
+			}
+			step = step*10 + (c - '0')
+		}
+		file.SourceCodeInfo.Location = append(file.SourceCodeInfo.Location, &descriptorpb.SourceCodeInfo_Location{
+			Path:            path,
+			Span:            []int32{0, 0, 0},
+			LeadingComments: proto.String(comment),
+		})
+	}
+	// The level field's directive is in a trailing comment instead, to
+	// check that we use those too.
+	for _, loc := range file.SourceCodeInfo.Location {
+		if sourcePathKey(loc.Path) == "4.0.2.2." {
+			loc.TrailingComments, loc.LeadingComments = loc.LeadingComments, nil
+		}
+	}
+	return file
+}
@@ -0,0 +1,123 @@
+package protohcl
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+var durationDesc = (*durationpb.Duration)(nil).ProtoReflect().Descriptor()
+
+// isDurationMessage returns true if the given message type is
+// google.protobuf.Duration, which an attribute represents as either a
+// duration string like "1h30m" or a number of seconds.
+func isDurationMessage(desc protoreflect.MessageDescriptor) bool {
+	return desc.FullName() == durationDesc.FullName()
+}
+
+// durationAttrMessageBuilder is the strategy for a singular field of type
+// google.protobuf.Duration, which accepts either a duration string in the
+// syntax of Go's time.ParseDuration or a number of seconds.
+//
+// The attribute's type constraint is string by default, so a number of
+// seconds arrives here already converted to a string.
+func durationAttrMessageBuilder(desc protoreflect.FieldDescriptor) attrMessageBuilder {
+	return func(v cty.Value, path cty.Path, parentMessage protoreflect.Message) (protoreflect.Value, error) {
+		if v.IsNull() {
+			// A null value just leaves the field unset.
+			return nilProtoValue, nil
+		}
+		if !v.IsKnown() {
+			return nilProtoValue, attrValueErrorUnknown(path)
+		}
+		v, err := convert.Convert(v, cty.String)
+		if err != nil {
+			return nilProtoValue, attrValueErrorWrap(path, err)
+		}
+		dur, ok := parseDuration(v.AsString())
+		if !ok {
+			return nilProtoValue, attrValueErrorf(path, "must be a duration like \"1h30m\", or a number of seconds")
+		}
+		if dur == nil || dur.CheckValid() != nil {
+			return nilProtoValue, attrValueErrorf(path, "must be a duration of no more than 10000 years")
+		}
+		msg := parentMessage.NewField(desc).Message()
+		fields := msg.Descriptor().Fields()
+		msg.Set(fields.ByName("seconds"), protoreflect.ValueOfInt64(dur.Seconds))
+		msg.Set(fields.ByName("nanos"), protoreflect.ValueOfInt32(dur.Nanos))
+		return protoreflect.ValueOfMessage(msg), nil
+	}
+}
+
+var nanosPerSecond = big.NewInt(int64(time.Second))
+
+// parseDuration parses either a duration string in the syntax of Go's
+// time.ParseDuration or a decimal number of seconds, returning false if
+// the string is neither. The result is nil if the string is valid syntax
+// but its duration is far outside of the range of google.protobuf.Duration.
+//
+// A number of seconds can represent longer durations than Go's
+// time.Duration can, but must not be more precise than a nanosecond.
+func parseDuration(s string) (*durationpb.Duration, bool) {
+	if d, err := time.ParseDuration(s); err == nil {
+		return durationpb.New(d), true
+	}
+
+	secs, _, err := big.ParseFloat(s, 10, 512, big.ToNearestEven)
+	if err != nil || secs.IsInf() {
+		return nil, false
+	}
+	nanos, acc := new(big.Float).Mul(secs, new(big.Float).SetInt(nanosPerSecond)).Int(nil)
+	if acc != big.Exact {
+		return nil, false
+	}
+	wholeSecs, remNanos := new(big.Int).QuoRem(nanos, nanosPerSecond, new(big.Int))
+	if !wholeSecs.IsInt64() {
+		return nil, true
+	}
+	return &durationpb.Duration{
+		Seconds: wholeSecs.Int64(),
+		Nanos:   int32(remNanos.Int64()),
+	}, true
+}
+
+// hclValueForDuration returns the string representation of the given
+// google.protobuf.Duration message.
+//
+// The result uses the syntax of Go's time.Duration.String where possible,
+// but a duration too long for time.Duration is a number of seconds instead.
+// Both forms are valid to assign to the attribute again.
+func hclValueForDuration(msg protoreflect.Message, path cty.Path) (cty.Value, error) {
+	fields := msg.Descriptor().Fields()
+	seconds := msg.Get(fields.ByName("seconds")).Int()
+	nanos := msg.Get(fields.ByName("nanos")).Int()
+	dur := &durationpb.Duration{Seconds: seconds, Nanos: int32(nanos)}
+	if err := dur.CheckValid(); err != nil {
+		return cty.NilVal, path.NewErrorf("invalid google.protobuf.Duration: %s", err)
+	}
+	if d := dur.AsDuration(); durationpb.New(d).Seconds == seconds {
+		return cty.StringVal(d.String()), nil
+	}
+	return cty.StringVal(formatDurationSeconds(seconds, int32(nanos))), nil
+}
+
+// formatDurationSeconds returns the decimal number of seconds that the
+// given valid google.protobuf.Duration fields represent.
+func formatDurationSeconds(seconds int64, nanos int32) string {
+	sign := ""
+	if seconds < 0 || nanos < 0 {
+		sign = "-"
+		seconds, nanos = -seconds, -nanos
+	}
+	if nanos == 0 {
+		return fmt.Sprintf("%s%d", sign, seconds)
+	}
+	frac := strings.TrimRight(fmt.Sprintf("%09d", nanos), "0")
+	return fmt.Sprintf("%s%d.%s", sign, seconds, frac)
+}
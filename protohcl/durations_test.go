@@ -0,0 +1,168 @@
+package protohcl
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apparentlymart/go-protohcl/protohcl/internal/testschema"
+	"github.com/apparentlymart/go-protohcl/protohcl/protohcltest"
+	"github.com/google/go-cmp/cmp"
+	"github.com/zclconf/go-cty/cty"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func TestDecodeBodyDurationAttr(t *testing.T) {
+	tests := map[string]struct {
+		config    string
+		want      proto.Message
+		wantDiags []protohcltest.ExpectedDiagnostic
+	}{
+		"duration string": {
+			`timeout = "1h30m"`,
+			&testschema.WithDurationAttr{
+				Timeout: durationpb.New(90 * time.Minute),
+			},
+			nil,
+		},
+		"negative duration string": {
+			`timeout = "-1.5s"`,
+			&testschema.WithDurationAttr{
+				Timeout: durationpb.New(-1500 * time.Millisecond),
+			},
+			nil,
+		},
+		"whole number of seconds": {
+			`timeout = 90`,
+			&testschema.WithDurationAttr{
+				Timeout: durationpb.New(90 * time.Second),
+			},
+			nil,
+		},
+		"fractional number of seconds": {
+			`timeout = 0.25`,
+			&testschema.WithDurationAttr{
+				Timeout: durationpb.New(250 * time.Millisecond),
+			},
+			nil,
+		},
+		"number of seconds as a string": {
+			`timeout = "-2.5"`,
+			&testschema.WithDurationAttr{
+				Timeout: durationpb.New(-2500 * time.Millisecond),
+			},
+			nil,
+		},
+		"number of seconds beyond time.Duration": {
+			// This is about 3171 years, which is valid for
+			// google.protobuf.Duration but too long for Go's time.Duration.
+			`timeout = 100000000000`,
+			&testschema.WithDurationAttr{
+				Timeout: &durationpb.Duration{Seconds: 100000000000},
+			},
+			nil,
+		},
+		"null": {
+			`timeout = null`,
+			&testschema.WithDurationAttr{},
+			nil,
+		},
+		"invalid syntax": {
+			`
+				timeout = "soon"
+			`,
+			&testschema.WithDurationAttr{},
+			[]protohcltest.ExpectedDiagnostic{
+				protohcltest.Error(unsuitableValueSummary).
+					WithDetail(`Inappropriate value for attribute "timeout": must be a duration like "1h30m", or a number of seconds.`).
+					OnLine(2),
+			},
+		},
+		"too precise": {
+			`timeout = 0.0000000001`,
+			&testschema.WithDurationAttr{},
+			[]protohcltest.ExpectedDiagnostic{
+				protohcltest.Error(unsuitableValueSummary).
+					WithDetail(`Inappropriate value for attribute "timeout": must be a duration like "1h30m", or a number of seconds.`),
+			},
+		},
+		"too long": {
+			`timeout = 1e12`,
+			&testschema.WithDurationAttr{},
+			[]protohcltest.ExpectedDiagnostic{
+				protohcltest.Error(unsuitableValueSummary).
+					WithDetail(`Inappropriate value for attribute "timeout": must be a duration of no more than 10000 years.`),
+			},
+		},
+		"wrong type": {
+			`timeout = ["1s"]`,
+			&testschema.WithDurationAttr{},
+			[]protohcltest.ExpectedDiagnostic{
+				protohcltest.Error(unsuitableValueSummary).
+					WithDetail(`Inappropriate value for attribute "timeout": string required.`),
+			},
+		},
+	}
+
+	desc := (&testschema.WithDurationAttr{}).ProtoReflect().Descriptor()
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, diags := DecodeBody(parseTestBody(t, test.config), desc, nil)
+			protohcltest.AssertDiagnostics(t, diags, test.wantDiags...)
+			if diff := cmp.Diff(test.want, got, protoCmpOpt); diff != "" {
+				t.Errorf("wrong result\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestObjectValueForMessageDurationAttr(t *testing.T) {
+	tests := map[string]struct {
+		msg  proto.Message
+		want cty.Value
+	}{
+		"set": {
+			&testschema.WithDurationAttr{
+				Timeout: durationpb.New(90*time.Minute + 250*time.Millisecond),
+			},
+			cty.ObjectVal(map[string]cty.Value{
+				"timeout": cty.StringVal("1h30m0.25s"),
+			}),
+		},
+		"beyond time.Duration": {
+			&testschema.WithDurationAttr{
+				Timeout: &durationpb.Duration{Seconds: -100000000000, Nanos: -500000000},
+			},
+			cty.ObjectVal(map[string]cty.Value{
+				"timeout": cty.StringVal("-100000000000.5"),
+			}),
+		},
+		"unset": {
+			&testschema.WithDurationAttr{},
+			cty.ObjectVal(map[string]cty.Value{
+				"timeout": cty.NullVal(cty.String),
+			}),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := ObjectValueForMessage(test.msg)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !got.RawEquals(test.want) {
+				t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, test.want)
+			}
+
+			// The result must decode back into the same message.
+			msg, err := MessageForObjectValue(got, test.msg.ProtoReflect().Descriptor())
+			if err != nil {
+				t.Fatalf("unexpected error converting back to a message: %s", err)
+			}
+			if diff := cmp.Diff(test.msg, msg, protoCmpOpt); diff != "" {
+				t.Errorf("wrong round-trip result\n%s", diff)
+			}
+		})
+	}
+}
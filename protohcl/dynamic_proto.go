@@ -2,6 +2,7 @@ package protohcl
 
 import (
 	"fmt"
+	"sync"
 
 	hcl "github.com/hashicorp/hcl/v2"
 	"google.golang.org/protobuf/proto"
@@ -14,6 +15,10 @@ import (
 
 type DynamicProto struct {
 	files *protoregistry.Files
+
+	// lazy is set instead of files for a DynamicProto created by
+	// NewLazyDynamicProto.
+	lazy *lazyFiles
 }
 
 // NewDynamicProto parses a protobuf file descriptor set discovered at runtime
@@ -37,7 +42,41 @@ func NewDynamicProto(descs *descriptorpb.FileDescriptorSet) (DynamicProto, error
 	if err != nil {
 		return DynamicProto{}, fmt.Errorf("invalid descriptors: %w", err)
 	}
-	return DynamicProto{files}, nil
+	return DynamicProto{files: files}, nil
+}
+
+// NewLazyDynamicProto is like NewDynamicProto except that it delays parsing
+// each file in the given descriptor set until the first time a caller asks
+// for one of the message types it declares, and then parses only that file
+// and the files it imports.
+//
+// This is helpful when the descriptor set is large but only a few of its
+// message types are needed for configuration, such as when a plugin sends
+// the descriptors for its whole RPC API. The cost, aside from the slower
+// first lookup of each message type, is that NewLazyDynamicProto can detect
+// only structural problems with the set, such as duplicate file paths or
+// message type names, and so other problems with a file are reported only
+// when decoding into one of its message types.
+//
+// The given descriptor set must not be modified while the result is in use.
+func NewLazyDynamicProto(descs *descriptorpb.FileDescriptorSet) (DynamicProto, error) {
+	lazy := &lazyFiles{
+		protos:   make(map[string]*descriptorpb.FileDescriptorProto, len(descs.File)),
+		messages: make(map[protoreflect.FullName]string),
+		files:    new(protoregistry.Files),
+	}
+	for _, fdp := range descs.File {
+		path := fdp.GetName()
+		if _, exists := lazy.protos[path]; exists {
+			return DynamicProto{}, fmt.Errorf("invalid descriptors: file %q appears more than once", path)
+		}
+		lazy.protos[path] = fdp
+		err := lazy.indexMessages(protoreflect.FullName(fdp.GetPackage()), fdp.MessageType, path)
+		if err != nil {
+			return DynamicProto{}, fmt.Errorf("invalid descriptors: %w", err)
+		}
+	}
+	return DynamicProto{lazy: lazy}, nil
 }
 
 // DecodeBody decodes the content of a given HCL body into a protobuf message
@@ -66,6 +105,10 @@ func (dp DynamicProto) DecodeBody(body hcl.Body, msgName protoreflect.FullName,
 // message of that type, but for most cases it'll be easier to use method
 // DynamicProto.DecodeBody, which is a convenience wrapper around these two.
 func (dp DynamicProto) GetMessageDesc(name protoreflect.FullName) (protoreflect.MessageDescriptor, error) {
+	if dp.lazy != nil {
+		return dp.lazy.findMessage(name)
+	}
+
 	desc, err := dp.files.FindDescriptorByName(name)
 	if err != nil {
 		return nil, err
@@ -79,6 +122,92 @@ func (dp DynamicProto) GetMessageDesc(name protoreflect.FullName) (protoreflect.
 	return msgDesc, nil
 }
 
+// lazyFiles is the state of a DynamicProto created by NewLazyDynamicProto,
+// which parses each file only when needed.
+type lazyFiles struct {
+	// protos are the unparsed files from the descriptor set, by path, and
+	// messages are the paths of the files declaring each message type.
+	// These don't change after construction.
+	protos   map[string]*descriptorpb.FileDescriptorProto
+	messages map[protoreflect.FullName]string
+
+	// mu guards files, which contains the files we've parsed so far.
+	mu    sync.Mutex
+	files *protoregistry.Files
+}
+
+func (l *lazyFiles) indexMessages(prefix protoreflect.FullName, msgs []*descriptorpb.DescriptorProto, path string) error {
+	for _, msg := range msgs {
+		name := prefix.Append(protoreflect.Name(msg.GetName()))
+		if prefix == "" {
+			name = protoreflect.FullName(msg.GetName())
+		}
+		if other, exists := l.messages[name]; exists {
+			return fmt.Errorf("message type %s is declared in both %q and %q", name, other, path)
+		}
+		l.messages[name] = path
+		if err := l.indexMessages(name, msg.NestedType, path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (l *lazyFiles) findMessage(name protoreflect.FullName) (protoreflect.MessageDescriptor, error) {
+	path, ok := l.messages[name]
+	if !ok {
+		return nil, protoregistry.NotFound
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	file, err := l.file(path, nil)
+	if err != nil {
+		return nil, err
+	}
+	desc, err := l.files.FindDescriptorByName(name)
+	if err != nil {
+		// Should not get here, because we found the file by this name.
+		return nil, fmt.Errorf("file %q doesn't declare %s", file.Path(), name)
+	}
+	return desc.(protoreflect.MessageDescriptor), nil
+}
+
+// file returns the parsed descriptor for the file with the given path,
+// parsing it and its imports first if necessary. The caller must hold l.mu.
+//
+// importedBy is the chain of files that led to this one, for detecting
+// import cycles and for use in error messages.
+func (l *lazyFiles) file(path string, importedBy []string) (protoreflect.FileDescriptor, error) {
+	if file, err := l.files.FindFileByPath(path); err == nil {
+		return file, nil
+	}
+	for _, other := range importedBy {
+		if other == path {
+			return nil, fmt.Errorf("invalid descriptors: import cycle involving %q", path)
+		}
+	}
+	fdp, ok := l.protos[path]
+	if !ok {
+		return nil, fmt.Errorf("invalid descriptors: %q imports %q, which is not in the descriptor set", importedBy[len(importedBy)-1], path)
+	}
+
+	importedBy = append(importedBy, path)
+	for _, dep := range fdp.Dependency {
+		if _, err := l.file(dep, importedBy); err != nil {
+			return nil, err
+		}
+	}
+	file, err := protodesc.NewFile(fdp, l.files)
+	if err != nil {
+		return nil, fmt.Errorf("invalid descriptors: %w", err)
+	}
+	if err := l.files.RegisterFile(file); err != nil {
+		return nil, fmt.Errorf("invalid descriptors: %w", err)
+	}
+	return file, nil
+}
+
 // newMessageMaybeDynamic is a helper which always produces a new message
 // conforming to the given descriptor, but will be of a real generated Go
 // type if one is known to the global registry, or will be a totally-dynamic
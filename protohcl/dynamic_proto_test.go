@@ -0,0 +1,162 @@
+package protohcl
+
+import (
+	"testing"
+
+	"github.com/apparentlymart/go-protohcl/protohcl/internal/testschema"
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func TestDynamicProtoDecodeBody(t *testing.T) {
+	f, diags := hclsyntax.ParseConfig([]byte(`name = "Jackson"`), "", hcl.InitialPos)
+	if diags.HasErrors() {
+		t.Fatalf("unexpected syntax errors: %s", diags.Error())
+	}
+
+	constructors := map[string]func(*descriptorpb.FileDescriptorSet) (DynamicProto, error){
+		"eager": NewDynamicProto,
+		"lazy":  NewLazyDynamicProto,
+	}
+	for name, construct := range constructors {
+		t.Run(name, func(t *testing.T) {
+			dp, err := construct(testDescriptorSet())
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			got, diags := dp.DecodeBody(f.Body, "hcl.testschema.WithStringAttr", nil)
+			if diags.HasErrors() {
+				t.Fatalf("unexpected errors: %s", diags.Error())
+			}
+			// The descriptors are the same as the generated ones, so we
+			// get the generated message type regardless of how we loaded
+			// them.
+			if diff := cmp.Diff(&testschema.WithStringAttr{Name: "Jackson"}, got, protoCmpOpt); diff != "" {
+				t.Errorf("wrong result\n%s", diff)
+			}
+
+			if _, err := dp.GetMessageDesc("hcl.testschema.Nonexistent"); err == nil {
+				t.Errorf("unexpected success for nonexistent message type")
+			}
+			if _, err := dp.GetMessageDesc("hcl.testschema.Level"); err == nil {
+				t.Errorf("unexpected success for enum type")
+			}
+		})
+	}
+}
+
+func TestNewLazyDynamicProto(t *testing.T) {
+	set := testDescriptorSet()
+	// This file is invalid because it imports a file that isn't in the
+	// set, but we only notice that if we need one of its message types.
+	set.File = append(set.File, &descriptorpb.FileDescriptorProto{
+		Name:        proto.String("broken.proto"),
+		Package:     proto.String("broken"),
+		Syntax:      proto.String("proto3"),
+		Dependency:  []string{"nonexistent.proto"},
+		MessageType: []*descriptorpb.DescriptorProto{{Name: proto.String("Broken")}},
+	})
+
+	if _, err := NewDynamicProto(set); err == nil {
+		t.Fatalf("NewDynamicProto succeeded with a broken file")
+	}
+
+	dp, err := NewLazyDynamicProto(set)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	desc, err := dp.GetMessageDesc("hcl.testschema.WithNestedBlockOneLabelRepeated")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	// The message type refers to another message in the same file, and
+	// to options from an imported file.
+	if _, err := GetFieldElem(desc.Fields().Get(0)); err != nil {
+		t.Errorf("unexpected error getting field element: %s", err)
+	}
+	if _, err := dp.lazy.files.FindFileByPath("broken.proto"); err == nil {
+		t.Errorf("broken.proto was parsed, but nothing needed it")
+	}
+
+	_, err = dp.GetMessageDesc("broken.Broken")
+	if err == nil {
+		t.Fatalf("unexpected success for message in broken file")
+	}
+	if got, want := err.Error(), `invalid descriptors: "broken.proto" imports "nonexistent.proto", which is not in the descriptor set`; got != want {
+		t.Errorf("wrong error\ngot:  %s\nwant: %s", got, want)
+	}
+}
+
+func TestNewLazyDynamicProtoErrors(t *testing.T) {
+	tests := map[string]struct {
+		files []*descriptorpb.FileDescriptorProto
+		want  string
+	}{
+		"duplicate file": {
+			[]*descriptorpb.FileDescriptorProto{
+				{Name: proto.String("a.proto")},
+				{Name: proto.String("a.proto")},
+			},
+			`invalid descriptors: file "a.proto" appears more than once`,
+		},
+		"duplicate message": {
+			[]*descriptorpb.FileDescriptorProto{
+				{
+					Name:    proto.String("a.proto"),
+					Package: proto.String("example"),
+					MessageType: []*descriptorpb.DescriptorProto{
+						{
+							Name:       proto.String("Outer"),
+							NestedType: []*descriptorpb.DescriptorProto{{Name: proto.String("Inner")}},
+						},
+					},
+				},
+				{
+					Name:        proto.String("b.proto"),
+					Package:     proto.String("example.Outer"),
+					MessageType: []*descriptorpb.DescriptorProto{{Name: proto.String("Inner")}},
+				},
+			},
+			`invalid descriptors: message type example.Outer.Inner is declared in both "a.proto" and "b.proto"`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewLazyDynamicProto(&descriptorpb.FileDescriptorSet{File: test.files})
+			if err == nil {
+				t.Fatalf("unexpected success")
+			}
+			if got := err.Error(); got != test.want {
+				t.Errorf("wrong error\ngot:  %s\nwant: %s", got, test.want)
+			}
+		})
+	}
+}
+
+// testDescriptorSet returns a descriptor set containing the test schema and
+// all of the files it depends on, in dependency order.
+func testDescriptorSet() *descriptorpb.FileDescriptorSet {
+	var set descriptorpb.FileDescriptorSet
+	seen := make(map[string]struct{})
+	var add func(protoreflect.FileDescriptor)
+	add = func(file protoreflect.FileDescriptor) {
+		if _, exists := seen[file.Path()]; exists {
+			return
+		}
+		seen[file.Path()] = struct{}{}
+		imports := file.Imports()
+		for i := 0; i < imports.Len(); i++ {
+			add(imports.Get(i).FileDescriptor)
+		}
+		set.File = append(set.File, protodesc.ToFileDescriptorProto(file))
+	}
+	add(testschema.File_testschema_proto)
+	return &set
+}
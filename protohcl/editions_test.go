@@ -0,0 +1,170 @@
+package protohcl
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/apparentlymart/go-protohcl/protohcl/protohcltest"
+	"github.com/google/go-cmp/cmp"
+	"github.com/zclconf/go-cty/cty"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// editionsMessageDesc returns the descriptor of the message type with the
+// given name from testdata/editions.pb, which declares its types using
+// protobuf editions.
+func editionsMessageDesc(t *testing.T, name protoreflect.FullName) protoreflect.MessageDescriptor {
+	t.Helper()
+
+	src, err := os.ReadFile(filepath.Join("testdata", "editions.pb"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	set := &descriptorpb.FileDescriptorSet{}
+	if err := proto.Unmarshal(src, set); err != nil {
+		t.Fatal(err)
+	}
+	files, err := protodesc.NewFiles(set)
+	if err != nil {
+		t.Fatal(err)
+	}
+	desc, err := files.FindDescriptorByName(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return desc.(protoreflect.MessageDescriptor)
+}
+
+// editionsMessage returns a message of the given type that has the content
+// of the given message in protobuf text format.
+func editionsMessage(t *testing.T, desc protoreflect.MessageDescriptor, text string) proto.Message {
+	t.Helper()
+
+	msg := dynamicpb.NewMessage(desc)
+	// Some of the tests produce messages without their required fields.
+	opts := prototext.UnmarshalOptions{AllowPartial: true}
+	if err := opts.Unmarshal([]byte(text), msg); err != nil {
+		t.Fatal(err)
+	}
+	return msg
+}
+
+func TestDecodeBodyEditions(t *testing.T) {
+	desc := editionsMessageDesc(t, "hcl.testschema.editions.Editions")
+
+	tests := map[string]struct {
+		config    string
+		want      string
+		wantDiags []protohcltest.ExpectedDiagnostic
+	}{
+		"attributes": {
+			`
+				id    = "a"
+				name  = "b"
+				label = "c"
+				count = 2
+			`,
+			`id: "a" name: "b" label: "c" count: 2`,
+			nil,
+		},
+		"explicit presence of zero values": {
+			`
+				id    = "a"
+				name  = ""
+				label = ""
+				count = 0
+			`,
+			// name and count have explicit presence, so they are set even
+			// though they have zero values, but label has implicit
+			// presence.
+			`id: "a" name: "" count: 0`,
+			nil,
+		},
+		"delimited messages": {
+			`
+				id = "a"
+				settings = {
+					value = "s"
+				}
+				child {
+					value = "c"
+				}
+				item {
+					value = "i1"
+				}
+				item {
+					value = "i2"
+				}
+			`,
+			`id: "a" settings { value: "s" } child { value: "c" } items { value: "i1" } items { value: "i2" }`,
+			nil,
+		},
+		"missing legacy required": {
+			`name = "b"`,
+			`name: "b"`,
+			[]protohcltest.ExpectedDiagnostic{
+				protohcltest.Error("Missing required argument"),
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, diags := DecodeBody(parseTestBody(t, test.config), desc, nil)
+			protohcltest.AssertDiagnostics(t, diags, test.wantDiags...)
+			want := editionsMessage(t, desc, test.want)
+			if diff := cmp.Diff(want, got, protoCmpOpt); diff != "" {
+				t.Errorf("wrong result\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestObjectValueForMessageEditions(t *testing.T) {
+	desc := editionsMessageDesc(t, "hcl.testschema.editions.Editions")
+	msg := editionsMessage(t, desc, `id: "a" items { value: "i" }`)
+
+	got, err := ObjectValueForMessage(msg)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	childTy := cty.Object(map[string]cty.Type{
+		"value": cty.String,
+	})
+	want := cty.ObjectVal(map[string]cty.Value{
+		"id": cty.StringVal("a"),
+		// An unset field with explicit presence is null, but one with
+		// implicit presence has its zero value, and one with a default
+		// has its default value.
+		"name":     cty.NullVal(cty.String),
+		"label":    cty.StringVal(""),
+		"count":    cty.NumberIntVal(5),
+		"settings": cty.NullVal(childTy),
+		"child": cty.ObjectVal(map[string]cty.Value{
+			"value": cty.NullVal(cty.String),
+		}),
+		"item": cty.TupleVal([]cty.Value{
+			cty.ObjectVal(map[string]cty.Value{
+				"value": cty.StringVal("i"),
+			}),
+		}),
+	})
+	if !got.RawEquals(want) {
+		t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, want)
+	}
+}
+
+func TestValidateMessageDescEditions(t *testing.T) {
+	desc := editionsMessageDesc(t, "hcl.testschema.editions.Editions")
+	if problems := ValidateMessageDesc(desc); len(problems) != 0 {
+		for _, problem := range problems {
+			t.Errorf("unexpected problem: %s", problem.Message)
+		}
+	}
+}
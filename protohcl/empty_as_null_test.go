@@ -0,0 +1,154 @@
+package protohcl
+
+import (
+	"testing"
+
+	"github.com/apparentlymart/go-protohcl/protohcl/internal/testschema"
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty-debug/ctydebug"
+	"github.com/zclconf/go-cty/cty"
+	"google.golang.org/protobuf/reflect/protopath"
+)
+
+func TestDecodeBodyEmptyAsNull(t *testing.T) {
+	desc := testschema.File_testschema_proto.Messages().ByName("WithEmptyAsNullAttrs")
+	namePath := protopath.Path{protopath.Root(desc), protopath.FieldAccess(desc.Fields().ByName("name"))}
+
+	tests := map[string]struct {
+		config       string
+		want         *testschema.WithEmptyAsNullAttrs
+		wantPresence Presence
+		wantDetail   string
+	}{
+		"non-empty": {
+			`
+				name     = "Jackson"
+				nickname = "Jack"
+			`,
+			&testschema.WithEmptyAsNullAttrs{
+				Name:     "Jackson",
+				Nickname: "Jack",
+			},
+			PresenceSet,
+			``,
+		},
+		"empty": {
+			`
+				name     = ""
+				nickname = "Jack"
+			`,
+			&testschema.WithEmptyAsNullAttrs{
+				Nickname: "Jack",
+			},
+			PresenceNull,
+			``,
+		},
+		"null": {
+			`
+				name     = null
+				nickname = "Jack"
+			`,
+			&testschema.WithEmptyAsNullAttrs{
+				Nickname: "Jack",
+			},
+			PresenceNull,
+			``,
+		},
+		"empty required": {
+			`
+				name     = "Jackson"
+				nickname = ""
+			`,
+			&testschema.WithEmptyAsNullAttrs{
+				Name: "Jackson",
+			},
+			PresenceSet,
+			`Attribute "nickname" is required, so must not be null or an empty string.`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			f, diags := hclsyntax.ParseConfig([]byte(test.config), "", hcl.InitialPos)
+			if diags.HasErrors() {
+				t.Fatalf("unexpected syntax errors: %s", diags.Error())
+			}
+
+			got, presence, diags := DecodeBodyWithPresence(f.Body, desc, nil)
+			if test.wantDetail == "" {
+				if diags.HasErrors() {
+					t.Fatalf("unexpected errors: %s", diags.Error())
+				}
+			} else {
+				if !diags.HasErrors() {
+					t.Fatalf("unexpected success")
+				}
+				if got, want := diags[0].Detail, test.wantDetail; got != want {
+					t.Errorf("wrong error detail\ngot:  %s\nwant: %s", got, want)
+				}
+			}
+			if diff := cmp.Diff(test.want, got, protoCmpOpt); diff != "" {
+				t.Errorf("wrong result\n%s", diff)
+			}
+			if got, want := presence.Presence(namePath), test.wantPresence; got != want {
+				t.Errorf("wrong presence for name %s; want %s", got, want)
+			}
+		})
+	}
+}
+
+func TestMessageForObjectValueEmptyAsNull(t *testing.T) {
+	desc := testschema.File_testschema_proto.Messages().ByName("WithEmptyAsNullAttrs")
+
+	got, err := messageForObjectValue(cty.ObjectVal(map[string]cty.Value{
+		"name":     cty.StringVal(""),
+		"nickname": cty.StringVal("Jack"),
+	}), desc, nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := &testschema.WithEmptyAsNullAttrs{Nickname: "Jack"}
+	if diff := cmp.Diff(want, got.Interface(), protoCmpOpt); diff != "" {
+		t.Errorf("wrong result\n%s", diff)
+	}
+
+	_, err = messageForObjectValue(cty.ObjectVal(map[string]cty.Value{
+		"nickname": cty.StringVal(""),
+	}), desc, nil, nil)
+	if err == nil {
+		t.Fatalf("unexpected success with empty required attribute")
+	}
+	if got, want := err.Error(), `attribute "nickname" is required`; got != want {
+		t.Errorf("wrong error\ngot:  %s\nwant: %s", got, want)
+	}
+}
+
+func TestObjectValueForMessageEmptyAsNull(t *testing.T) {
+	got, err := ObjectValueForMessage(&testschema.WithEmptyAsNullAttrs{
+		Nickname: "Jack",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := cty.ObjectVal(map[string]cty.Value{
+		"name":     cty.NullVal(cty.String),
+		"nickname": cty.StringVal("Jack"),
+	})
+	if diff := cmp.Diff(want, got, ctydebug.CmpOptions); diff != "" {
+		t.Errorf("wrong result\n%s", diff)
+	}
+}
+
+func TestGetFieldElemEmptyAsNullErrors(t *testing.T) {
+	desc := testschema.File_testschema_proto.Messages().ByName("WithEmptyAsNullList")
+	_, err := GetFieldElem(desc.Fields().Get(0))
+	if err == nil {
+		t.Fatalf("unexpected success")
+	}
+	want := `unsupported protobuf schema in hcl.testschema.WithEmptyAsNullList.names: empty_as_null is allowed only for singular string fields without explicit presence`
+	if got := err.Error(); got != want {
+		t.Errorf("wrong error\ngot:  %s\nwant: %s", got, want)
+	}
+}
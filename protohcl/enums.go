@@ -0,0 +1,22 @@
+package protohcl
+
+import (
+	"github.com/apparentlymart/go-protohcl/protohcl/protohclcty"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// validateEnumHCLNames returns a schemaError if any two values of the given
+// enum type are selected by the same HCL string.
+func validateEnumHCLNames(desc protoreflect.EnumDescriptor) error {
+	vals := desc.Values()
+	seen := make(map[string]protoreflect.FullName, vals.Len())
+	for i := 0; i < vals.Len(); i++ {
+		val := vals.Get(i)
+		name := protohclcty.EnumValueName(val)
+		if existing, exists := seen[name]; exists {
+			return schemaErrorf(val.FullName(), "enum value name %q conflicts with %s", name, existing)
+		}
+		seen[name] = val.FullName()
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package protohcl
+
+import (
+	"fmt"
+	"time"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// ExpressionEvaluator is the signature of a function that evaluates an
+// attribute's expression on behalf of the decoder, for use with
+// DecodeOptions.EvaluateExpression.
+//
+// An evaluator must either return the result of calling expr.Value(ctx) or
+// return error diagnostics explaining why it didn't. It may also return
+// additional diagnostics of its own, such as warnings about expressions that
+// were expensive to evaluate.
+type ExpressionEvaluator func(expr hcl.Expression, ctx *hcl.EvalContext) (cty.Value, hcl.Diagnostics)
+
+// EvaluationTimeLimit returns an ExpressionEvaluator that abandons the
+// evaluation of any expression that takes longer than the given duration,
+// returning an error diagnostic instead.
+//
+// HCL has no way to interrupt an expression evaluation, so an abandoned
+// evaluation keeps running in the background until it completes. The
+// evaluation context and any functions it defines must therefore be safe to
+// use concurrently with whatever the caller does after decoding returns.
+// Hosts that need a firmer limit should instead impose a budget within
+// their functions, which can return an error once it is exhausted.
+func EvaluationTimeLimit(limit time.Duration) ExpressionEvaluator {
+	return func(expr hcl.Expression, ctx *hcl.EvalContext) (cty.Value, hcl.Diagnostics) {
+		type result struct {
+			val      cty.Value
+			diags    hcl.Diagnostics
+			panicked interface{}
+		}
+		ch := make(chan result, 1) // buffered so an abandoned evaluation can still complete
+		go func() {
+			var ret result
+			defer func() {
+				if r := recover(); r != nil {
+					ret.panicked = r
+				}
+				ch <- ret
+			}()
+			ret.val, ret.diags = expr.Value(ctx)
+		}()
+
+		timer := time.NewTimer(limit)
+		defer timer.Stop()
+		select {
+		case ret := <-ch:
+			if ret.panicked != nil {
+				// We'll re-panic in the caller's goroutine, so that the
+				// effect is the same as if we'd evaluated there.
+				panic(ret.panicked)
+			}
+			return ret.val, ret.diags
+		case <-timer.C:
+			var diags hcl.Diagnostics
+			diags = diags.Append(&hcl.Diagnostic{
+				Severity:    hcl.DiagError,
+				Summary:     "Expression evaluation took too long",
+				Detail:      fmt.Sprintf("This expression did not produce a result within %s, so evaluation was abandoned. Simplify the expression so that it can be evaluated more quickly.", limit),
+				Subject:     expr.Range().Ptr(),
+				Expression:  expr,
+				EvalContext: ctx,
+			})
+			return cty.DynamicVal, diags
+		}
+	}
+}
+
+// evalExpr evaluates the given expression using the evaluator from the
+// decoder's options, if any, or directly otherwise.
+func (d *decoder) evalExpr(expr hcl.Expression, ctx *hcl.EvalContext) (cty.Value, hcl.Diagnostics) {
+	if d.opts.EvaluateExpression != nil {
+		return d.opts.EvaluateExpression(expr, ctx)
+	}
+	return expr.Value(ctx)
+}
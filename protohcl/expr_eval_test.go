@@ -0,0 +1,183 @@
+package protohcl
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apparentlymart/go-protohcl/protohcl/internal/testschema"
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func TestDecodeOptionsEvaluateExpression(t *testing.T) {
+	desc := testschema.File_testschema_proto.Messages().ByName(protoreflect.Name("WithFlattenStringAttr"))
+	f, diags := hclsyntax.ParseConfig([]byte(`
+name    = "Jackson"
+species = upper("budgerigar")
+`), "test.tf", hcl.InitialPos)
+	if diags.HasErrors() {
+		t.Fatalf("parse error: %s", diags)
+	}
+
+	// This evaluator rejects any function calls, as a simple stand-in for a
+	// real cost estimate.
+	opts := DecodeOptions{
+		EvaluateExpression: func(expr hcl.Expression, ctx *hcl.EvalContext) (cty.Value, hcl.Diagnostics) {
+			if _, isCall := expr.(*hclsyntax.FunctionCallExpr); isCall {
+				var diags hcl.Diagnostics
+				diags = diags.Append(&hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Expression too expensive",
+					Subject:  expr.Range().Ptr(),
+				})
+				return cty.DynamicVal, diags
+			}
+			return expr.Value(ctx)
+		},
+	}
+	got, diags := opts.DecodeBody(f.Body, desc, nil)
+
+	want := &testschema.WithFlattenStringAttr{
+		Base: &testschema.WithStringAttr{
+			Name: "Jackson",
+		},
+	}
+	if diff := cmp.Diff(proto.Message(want), got, protoCmpOpt); diff != "" {
+		t.Errorf("wrong result\n%s", diff)
+	}
+	if got, want := diags.Error(), `test.tf:3,11-30: Expression too expensive; `; got != want {
+		t.Errorf("wrong diagnostics\ngot:  %s\nwant: %s", got, want)
+	}
+}
+
+func TestEvaluationTimeLimit(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	ctx := &hcl.EvalContext{
+		Functions: map[string]function.Function{
+			"hang": function.New(&function.Spec{
+				Type: function.StaticReturnType(cty.String),
+				Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+					<-release
+					return cty.StringVal("too late"), nil
+				},
+			}),
+		},
+	}
+	desc := testschema.File_testschema_proto.Messages().ByName(protoreflect.Name("WithStringAttr"))
+	opts := DecodeOptions{
+		EvaluateExpression: EvaluationTimeLimit(10 * time.Millisecond),
+	}
+
+	decode := func(t *testing.T, config string) (proto.Message, hcl.Diagnostics) {
+		t.Helper()
+		f, diags := hclsyntax.ParseConfig([]byte(config), "test.tf", hcl.InitialPos)
+		if diags.HasErrors() {
+			t.Fatalf("parse error: %s", diags)
+		}
+		return opts.DecodeBody(f.Body, desc, ctx)
+	}
+
+	t.Run("fast enough", func(t *testing.T) {
+		got, diags := decode(t, `name = "Jackson"`)
+		if diags.HasErrors() {
+			t.Fatalf("unexpected errors: %s", diags)
+		}
+		want := &testschema.WithStringAttr{
+			Name: "Jackson",
+		}
+		if diff := cmp.Diff(proto.Message(want), got, protoCmpOpt); diff != "" {
+			t.Errorf("wrong result\n%s", diff)
+		}
+	})
+	t.Run("too slow", func(t *testing.T) {
+		got, diags := decode(t, `name = hang()`)
+		want := &testschema.WithStringAttr{}
+		if diff := cmp.Diff(proto.Message(want), got, protoCmpOpt); diff != "" {
+			t.Errorf("wrong result\n%s", diff)
+		}
+		wantErr := `test.tf:1,8-14: Expression evaluation took too long; This expression did not produce a result within 10ms, so evaluation was abandoned. Simplify the expression so that it can be evaluated more quickly.`
+		if got := diags.Error(); got != wantErr {
+			t.Errorf("wrong diagnostics\ngot:  %s\nwant: %s", got, wantErr)
+		}
+	})
+	t.Run("panic", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r != "boom" {
+				t.Errorf("wrong panic %#v; want \"boom\"", r)
+			}
+		}()
+		opts.EvaluateExpression(panicExpr{}, nil)
+	})
+}
+
+// panicExpr is an hcl.Expression that panics when evaluated.
+type panicExpr struct {
+	hcl.Expression
+}
+
+func (panicExpr) Value(ctx *hcl.EvalContext) (cty.Value, hcl.Diagnostics) {
+	panic("boom")
+}
+
+func TestDecodeOptionsEvaluateExpressionObjectKeys(t *testing.T) {
+	// The decoder evaluates the key expressions of an object constructor
+	// itself to check for duplicate keys and to find the source range of
+	// each map element, and must do so through the evaluator and only once
+	// per key.
+	desc := testschema.File_testschema_proto.Messages().ByName(protoreflect.Name("WithStringMapAttr"))
+	f, diags := hclsyntax.ParseConfig([]byte(`
+names = { (k()) = "x" }
+`), "test.tf", hcl.InitialPos)
+	if diags.HasErrors() {
+		t.Fatalf("parse error: %s", diags)
+	}
+	calls := 0
+	ctx := &hcl.EvalContext{
+		Functions: map[string]function.Function{
+			"k": function.New(&function.Spec{
+				Type: function.StaticReturnType(cty.String),
+				Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+					calls++
+					return cty.StringVal("a"), nil
+				},
+			}),
+		},
+	}
+
+	evals := make(map[string]int)
+	opts := DecodeOptions{
+		EvaluateExpression: func(expr hcl.Expression, ctx *hcl.EvalContext) (cty.Value, hcl.Diagnostics) {
+			evals[expr.Range().String()]++
+			return expr.Value(ctx)
+		},
+	}
+	got, diags := opts.DecodeBody(f.Body, desc, ctx)
+	if diags.HasErrors() {
+		t.Fatalf("unexpected errors: %s", diags)
+	}
+
+	want := &testschema.WithStringMapAttr{
+		Names: map[string]string{"a": "x"},
+	}
+	if diff := cmp.Diff(proto.Message(want), got, protoCmpOpt); diff != "" {
+		t.Errorf("wrong result\n%s", diff)
+	}
+	wantEvals := map[string]int{
+		"test.tf:2,9-24":  1, // the whole attribute expression
+		"test.tf:2,11-16": 1, // the key expression
+	}
+	if diff := cmp.Diff(wantEvals, evals); diff != "" {
+		t.Errorf("wrong evaluations\n%s", diff)
+	}
+	// HCL calls k once while evaluating the whole expression, and the
+	// decoder calls it once more while evaluating the key by itself.
+	if calls != 2 {
+		t.Errorf("k called %d times; want 2", calls)
+	}
+}
@@ -0,0 +1,240 @@
+package protohcl
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/apparentlymart/go-protohcl/protohcl/protohclext"
+	hcl "github.com/hashicorp/hcl/v2"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Feature names one of the HCL annotations that a protobuf schema can use,
+// such as "(hcl.attr).type" for an attribute's explicit type constraint, or
+// "(hcl.attr).raw = JSON" for a particular value of an enum-valued option.
+//
+// An annotation from a newer version of hcl.proto than the one this
+// version of protohcl was built with has a name that uses field and enum
+// value numbers instead, such as "(hcl.block).kind = 7" or
+// "(hcl.attr) field 12".
+type Feature string
+
+// RequiredFeatures returns the HCL annotation features that the given
+// message type uses, along with all of the message types reachable from it
+// through nested block types, flattened messages, and message-typed
+// attributes, sorted by name.
+//
+// This is intended for a host application that receives a schema from a
+// plugin and wants to explain, or record, which capabilities the schema
+// depends on. Use UnsupportedFeatures to find any of them that this version
+// of protohcl can't handle.
+func RequiredFeatures(desc protoreflect.MessageDescriptor) []Feature {
+	return collectFeatures(desc, func(featureUse) bool { return true })
+}
+
+// UnsupportedFeatures returns the subset of RequiredFeatures for the given
+// message type that this version of protohcl doesn't support, sorted by
+// name. The result is empty if the schema uses only supported annotations.
+//
+// Decoding reports an error for an unrecognized value of an enum-valued
+// annotation, such as a raw mode or block collection kind, because there's
+// no reasonable way to decode the field without understanding it. It
+// ignores annotation fields that it doesn't recognize at all, like any
+// other unknown protobuf field, and so a host that wants to reject schemas
+// that might depend on newer behavior must check UnsupportedFeatures itself.
+func UnsupportedFeatures(desc protoreflect.MessageDescriptor) []Feature {
+	return collectFeatures(desc, func(use featureUse) bool { return !use.Supported })
+}
+
+// featureUse is one use of a feature in the options of a declaration.
+type featureUse struct {
+	Feature Feature
+
+	// Supported is false if this version of protohcl doesn't recognize the
+	// feature.
+	Supported bool
+
+	// EnumValue is true if the feature is a particular value of an
+	// enum-valued annotation.
+	EnumValue bool
+}
+
+func collectFeatures(desc protoreflect.MessageDescriptor, include func(featureUse) bool) []Feature {
+	found := make(map[Feature]struct{})
+	visited := make(map[protoreflect.FullName]struct{})
+	var visitEnum func(protoreflect.EnumDescriptor)
+	var visitMessage func(protoreflect.MessageDescriptor)
+	record := func(uses []featureUse) bool {
+		for _, use := range uses {
+			if include(use) {
+				found[use.Feature] = struct{}{}
+			}
+		}
+		return len(uses) != 0
+	}
+	visitEnum = func(desc protoreflect.EnumDescriptor) {
+		if _, exists := visited[desc.FullName()]; exists {
+			return
+		}
+		visited[desc.FullName()] = struct{}{}
+		values := desc.Values()
+		for i := 0; i < values.Len(); i++ {
+			record(optionFeatures(values.Get(i).Options()))
+		}
+	}
+	visitMessage = func(desc protoreflect.MessageDescriptor) {
+		if _, exists := visited[desc.FullName()]; exists {
+			return
+		}
+		visited[desc.FullName()] = struct{}{}
+		record(optionFeatures(desc.Options()))
+		fields := desc.Fields()
+		for i := 0; i < fields.Len(); i++ {
+			field := fields.Get(i)
+			if !record(optionFeatures(field.Options())) {
+				// Fields without HCL annotations don't contribute to the
+				// HCL schema, so we don't look at their types.
+				continue
+			}
+			elem := field
+			if field.IsMap() {
+				elem = field.MapValue()
+			}
+			switch elem.Kind() {
+			case protoreflect.MessageKind, protoreflect.GroupKind:
+				visitMessage(elem.Message())
+			case protoreflect.EnumKind:
+				visitEnum(elem.Enum())
+			}
+		}
+	}
+	visitMessage(desc)
+
+	ret := make([]Feature, 0, len(found))
+	for feature := range found {
+		ret = append(ret, feature)
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i] < ret[j] })
+	return ret
+}
+
+// optionFeatures returns the HCL annotation features used in the given
+// options message, which is one of the descriptorpb options message types.
+func optionFeatures(opts protoreflect.ProtoMessage) []featureUse {
+	if opts == nil {
+		return nil
+	}
+	var ret []featureUse
+	opts.ProtoReflect().Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		if !fd.IsExtension() || fd.ParentFile().Package() != protohclext.File_hcl_proto.Package() {
+			return true
+		}
+		ret = appendFeatures(ret, Feature(fmt.Sprintf("(hcl.%s)", fd.Name())), fd, v)
+		return true
+	})
+	return ret
+}
+
+func appendFeatures(uses []featureUse, name Feature, fd protoreflect.FieldDescriptor, v protoreflect.Value) []featureUse {
+	if !fd.IsList() {
+		return appendValueFeatures(uses, name, fd, v)
+	}
+	l := v.List()
+	if fd.Kind() != protoreflect.EnumKind && fd.Kind() != protoreflect.MessageKind {
+		return append(uses, featureUse{Feature: name, Supported: true})
+	}
+	for i := 0; i < l.Len(); i++ {
+		uses = appendValueFeatures(uses, name, fd, l.Get(i))
+	}
+	return uses
+}
+
+// appendValueFeatures is like appendFeatures but treats the given value as
+// a single value of the field's kind, even if the field is repeated.
+func appendValueFeatures(uses []featureUse, name Feature, fd protoreflect.FieldDescriptor, v protoreflect.Value) []featureUse {
+	switch fd.Kind() {
+	case protoreflect.EnumKind:
+		num := v.Enum()
+		if value := fd.Enum().Values().ByNumber(num); value != nil {
+			return append(uses, featureUse{
+				Feature:   Feature(fmt.Sprintf("%s = %s", name, value.Name())),
+				Supported: true,
+				EnumValue: true,
+			})
+		}
+		supported := false
+		if fd.FullName() == "hcl.Attribute.raw" {
+			// An application can register its own codec for a raw mode
+			// that protohcl doesn't know about.
+			_, supported = rawCodecForMode(protohclext.Attribute_RawMode(num))
+		}
+		return append(uses, featureUse{
+			Feature:   Feature(fmt.Sprintf("%s = %d", name, num)),
+			Supported: supported,
+			EnumValue: true,
+		})
+	case protoreflect.MessageKind:
+		msg := v.Message()
+		uses = append(uses, featureUse{Feature: name, Supported: true})
+		msg.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+			uses = appendFeatures(uses, Feature(fmt.Sprintf("%s.%s", name, fd.Name())), fd, v)
+			return true
+		})
+		for b := msg.GetUnknown(); len(b) > 0; {
+			num, typ, n := protowire.ConsumeTag(b)
+			if n < 0 {
+				break
+			}
+			b = b[n:]
+			n = protowire.ConsumeFieldValue(num, typ, b)
+			if n < 0 {
+				break
+			}
+			b = b[n:]
+			uses = append(uses, featureUse{
+				Feature: Feature(fmt.Sprintf("%s field %d", name, num)),
+			})
+		}
+		return uses
+	default:
+		return append(uses, featureUse{Feature: name, Supported: true})
+	}
+}
+
+// checkFeaturesSupported returns an unsupported feature error if the given
+// field options use a value of an enum-valued HCL annotation that this
+// version of protohcl doesn't recognize.
+func checkFeaturesSupported(decl protoreflect.FullName, opts protoreflect.ProtoMessage) error {
+	for _, use := range optionFeatures(opts) {
+		if use.EnumValue && !use.Supported {
+			return schemaError{Decl: decl, Err: unsupportedFeatureError{Feature: use.Feature}}
+		}
+	}
+	return nil
+}
+
+// unsupportedFeatureError is the underlying error of a schemaError for a
+// schema that uses an HCL annotation from a newer version of hcl.proto than
+// this version of protohcl supports.
+type unsupportedFeatureError struct {
+	Feature Feature
+}
+
+func (err unsupportedFeatureError) Error() string {
+	return fmt.Sprintf("uses %s, which requires a newer version of protohcl", err.Feature)
+}
+
+const unsupportedFeatureSummary = "Unsupported configuration schema feature"
+
+func (err unsupportedFeatureError) diagnostic(decl string) *hcl.Diagnostic {
+	return &hcl.Diagnostic{
+		Severity: hcl.DiagError,
+		Summary:  unsupportedFeatureSummary,
+		Detail: fmt.Sprintf(
+			"The protobuf schema for %s uses %s, which this version of protohcl doesn't support.\n\nThe component that defined this schema was built with a newer version of protohcl than this application. To use it, upgrade the application to a version built with a newer version of protohcl.",
+			decl, err.Feature,
+		),
+		Extra: diagnosticExtra{Category: DiagnosticUnsupportedFeature},
+	}
+}
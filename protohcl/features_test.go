@@ -0,0 +1,144 @@
+package protohcl
+
+import (
+	"testing"
+
+	"github.com/apparentlymart/go-protohcl/protohcl/internal/testschema"
+	"github.com/apparentlymart/go-protohcl/protohcl/protohclext"
+	"github.com/apparentlymart/go-protohcl/protohcl/protohcltest"
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl/v2"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func TestRequiredFeatures(t *testing.T) {
+	tests := map[string][]Feature{
+		"WithRawDynamicAttr": {
+			"(hcl.attr)",
+			"(hcl.attr).name",
+			"(hcl.attr).raw = JSON",
+			"(hcl.attr).type",
+		},
+		"WithNestedBlockOneLabelRepeated": {
+			"(hcl.attr)",
+			"(hcl.attr).name",
+			"(hcl.attr).type",
+			"(hcl.block)",
+			"(hcl.block).kind = LIST",
+			"(hcl.block).type_name",
+			"(hcl.label)",
+			"(hcl.label).name",
+		},
+	}
+
+	for name, want := range tests {
+		t.Run(name, func(t *testing.T) {
+			desc := testschema.File_testschema_proto.Messages().ByName(protoreflect.Name(name))
+			got := RequiredFeatures(desc)
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("wrong features\n%s", diff)
+			}
+			if got := UnsupportedFeatures(desc); len(got) != 0 {
+				t.Errorf("unexpected unsupported features: %#v", got)
+			}
+		})
+	}
+}
+
+func TestUnsupportedFeatures(t *testing.T) {
+	desc := futureFeaturesTestDesc(t)
+
+	got := UnsupportedFeatures(desc)
+	want := []Feature{
+		"(hcl.attr) field 99",
+		"(hcl.attr).raw = 42",
+		"(hcl.block).kind = 12",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("wrong features\n%s", diff)
+	}
+}
+
+func TestDecodeBodyUnsupportedFeature(t *testing.T) {
+	desc := futureFeaturesTestDesc(t)
+
+	_, diags := DecodeBody(hcl.EmptyBody(), desc, nil)
+	protohcltest.AssertDiagnostics(t, diags,
+		protohcltest.Error(unsupportedFeatureSummary).WithDetail(
+			"The protobuf schema for features.test.Future.raw uses (hcl.attr).raw = 42, which this version of protohcl doesn't support.\n\nThe component that defined this schema was built with a newer version of protohcl than this application. To use it, upgrade the application to a version built with a newer version of protohcl.",
+		),
+	)
+	if got, want := DiagnosticCategoryOf(diags[0]), DiagnosticUnsupportedFeature; got != want {
+		t.Errorf("wrong category\ngot:  %s\nwant: %s", got, want)
+	}
+
+	problems := ValidateMessageDesc(desc)
+	if len(problems) == 0 {
+		t.Fatal("no problems from ValidateMessageDesc")
+	}
+	if got, want := problems[0].Message, "uses (hcl.attr).raw = 42, which requires a newer version of protohcl"; got != want {
+		t.Errorf("wrong validation problem\ngot:  %s\nwant: %s", got, want)
+	}
+}
+
+// futureFeaturesTestDesc returns a message descriptor whose annotations
+// include values and fields that aren't in this version of hcl.proto, as
+// if it had been compiled against a newer version.
+func futureFeaturesTestDesc(t *testing.T) protoreflect.MessageDescriptor {
+	t.Helper()
+
+	attr := &protohclext.Attribute{
+		Name: "raw",
+		Type: "any",
+		Raw:  protohclext.Attribute_RawMode(42),
+	}
+	attr.ProtoReflect().SetUnknown(protowire.AppendVarint(protowire.AppendTag(nil, 99, protowire.VarintType), 1))
+	attrOpts := &descriptorpb.FieldOptions{}
+	proto.SetExtension(attrOpts, protohclext.E_Attr, attr)
+	blockOpts := &descriptorpb.FieldOptions{}
+	proto.SetExtension(blockOpts, protohclext.E_Block, &protohclext.NestedBlock{
+		TypeName: "thing",
+		Kind:     protohclext.NestedBlock_CollectionKind(12),
+	})
+
+	fileDesc, err := protodesc.NewFile(&descriptorpb.FileDescriptorProto{
+		Name:       proto.String("features_test.proto"),
+		Package:    proto.String("features.test"),
+		Syntax:     proto.String("proto3"),
+		Dependency: []string{protohclext.File_hcl_proto.Path()},
+		MessageType: []*descriptorpb.DescriptorProto{
+			{
+				Name: proto.String("Future"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					{
+						Name:    proto.String("raw"),
+						Number:  proto.Int32(1),
+						Label:   descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+						Type:    descriptorpb.FieldDescriptorProto_TYPE_BYTES.Enum(),
+						Options: attrOpts,
+					},
+					{
+						Name:     proto.String("things"),
+						Number:   proto.Int32(2),
+						Label:    descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum(),
+						Type:     descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum(),
+						TypeName: proto.String(".features.test.Thing"),
+						Options:  blockOpts,
+					},
+				},
+			},
+			{
+				Name: proto.String("Thing"),
+			},
+		},
+	}, protoregistry.GlobalFiles)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return fileDesc.Messages().ByName("Future")
+}
@@ -1,9 +1,10 @@
 package protohcl
 
 import (
+	"fmt"
+
 	"github.com/apparentlymart/go-protohcl/protohcl/protohclext"
 	"github.com/hashicorp/hcl/v2"
-	"github.com/hashicorp/hcl/v2/ext/typeexpr"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/zclconf/go-cty/cty"
 	"google.golang.org/protobuf/proto"
@@ -17,6 +18,11 @@ import (
 // Returns a nil FieldElem if there is no valid HCL annotation at all.
 //
 // Returns an error if the field has invalid or contradictory HCL options.
+//
+// The field can belong to a file using any protobuf syntax, including
+// protobuf editions. For editions, the field's resolved features decide its
+// presence, and a message-typed field that uses the delimited encoding
+// behaves the same as any other message-typed field.
 func GetFieldElem(field protoreflect.FieldDescriptor) (FieldElem, error) {
 	opts, ok := field.Options().(*descriptorpb.FieldOptions)
 	if !ok {
@@ -25,14 +31,23 @@ func GetFieldElem(field protoreflect.FieldDescriptor) (FieldElem, error) {
 		// to HCL processing yet.
 		return nil, nil
 	}
+	if err := checkFeaturesSupported(field.FullName(), opts); err != nil {
+		return nil, err
+	}
 
 	// These extensions are all mutually-exclusive with one another,
 	// because each proto field must map to zero or one HCL schema
 	// constructs.
 	attrOpts := proto.GetExtension(opts, protohclext.E_Attr).(*protohclext.Attribute)
 	blockOpts := proto.GetExtension(opts, protohclext.E_Block).(*protohclext.NestedBlock)
-	flatten := proto.GetExtension(opts, protohclext.E_Flatten).(bool)
+	flattenPrefix := proto.GetExtension(opts, protohclext.E_FlattenPrefix).(string)
+	flatten := proto.GetExtension(opts, protohclext.E_Flatten).(bool) || flattenPrefix != ""
 	labelOpts := proto.GetExtension(opts, protohclext.E_Label).(*protohclext.BlockLabel)
+	flattenConflict := proto.GetExtension(opts, protohclext.E_FlattenConflict).(protohclext.FlattenConflict)
+
+	if flattenConflict != protohclext.FlattenConflict_CONFLICT_ERROR && !flatten {
+		return nil, schemaErrorf(field.FullName(), "flatten_conflict is allowed only with flatten or flatten_prefix")
+	}
 
 	switch {
 	case attrOpts != nil && attrOpts.Name != "":
@@ -71,24 +86,125 @@ func GetFieldElem(field protoreflect.FieldDescriptor) (FieldElem, error) {
 		} else if field.Kind() == protoreflect.BytesKind {
 			return nil, schemaErrorf(field.FullName(), "'bytes' fields must have raw mode enabled")
 		}
+		if attrOpts.AllowScalarForList && !field.IsList() {
+			return nil, schemaErrorf(field.FullName(), "can use allow_scalar_for_list only with 'repeated' field")
+		}
+		if attrOpts.IgnoreNullInLists && !field.IsList() {
+			return nil, schemaErrorf(field.FullName(), "can use ignore_null_in_lists only with 'repeated' field")
+		}
+		if attrOpts.DefaultExpr != "" {
+			if attrOpts.Required {
+				return nil, schemaErrorf(field.FullName(), "a required attribute can't have default_expr")
+			}
+			if _, diags := hclsyntax.ParseExpression([]byte(attrOpts.DefaultExpr), "", hcl.InitialPos); diags.HasErrors() {
+				return nil, schemaErrorf(field.FullName(), "invalid default_expr: %s", diags.Error())
+			}
+		}
 		elemDesc := field
 		if field.IsMap() {
 			elemDesc = field.MapValue()
 		}
-		if elemDesc.Kind() == protoreflect.MessageKind {
-			if elemDesc.Message().FullName() == structpbValueDesc.FullName() {
+		if attrOpts.Unit != "" {
+			if _, ok := attrUnits[attrOpts.Unit]; !ok {
+				return nil, schemaErrorf(field.FullName(), "unsupported unit %q", attrOpts.Unit)
+			}
+			if !isNumericKind(elemDesc.Kind()) {
+				return nil, schemaErrorf(field.FullName(), "unit is allowed only for numeric fields")
+			}
+			if attrOpts.Type != "" {
+				return nil, schemaErrorf(field.FullName(), "cannot specify both (hcl.attr).type and (hcl.attr).unit")
+			}
+		}
+		if attrOpts.Kind != protohclext.NestedBlock_AUTO {
+			if !field.IsList() || !isMessageKind(field.Kind()) || field.Message().FullName() == structpbValueDesc.FullName() {
+				return nil, schemaErrorf(field.FullName(), "(hcl.attr).kind is allowed only for repeated fields of annotated message types")
+			}
+			if attrOpts.Type != "" {
+				return nil, schemaErrorf(field.FullName(), "cannot specify both (hcl.attr).type and (hcl.attr).kind")
+			}
+			if attrOpts.Kind == protohclext.NestedBlock_MAP {
+				return nil, schemaErrorf(field.FullName(), "attributes can't have collection kind MAP; use a map field instead")
+			}
+			if ety := autoTypeConstraintForFieldElement(field, nil); ety != cty.NilType {
+				if _, err := collectionTypeConstraint(field.FullName(), attrOpts.Kind, ety, "(hcl.attr).kind", "a message type"); err != nil {
+					return nil, err
+				}
+			}
+		}
+		if attrOpts.EmptyAsNull && (field.Cardinality() == protoreflect.Repeated || field.Kind() != protoreflect.StringKind || field.HasPresence()) {
+			return nil, schemaErrorf(field.FullName(), "empty_as_null is allowed only for singular string fields without explicit presence")
+		}
+		if attrOpts.NumberSyntax != nil {
+			numberType := attrOpts.Type == "number" || (attrOpts.Type == "" && isNumericKind(field.Kind()))
+			if field.Cardinality() == protoreflect.Repeated || attrOpts.Unit != "" || !numberType {
+				return nil, schemaErrorf(field.FullName(), "number_syntax is allowed only for singular attributes whose type constraint is number")
+			}
+		}
+		var rangeField protoreflect.FieldDescriptor
+		if attrOpts.IncludeRangeInto != "" {
+			rangeField = field.ContainingMessage().Fields().ByName(protoreflect.Name(attrOpts.IncludeRangeInto))
+			if rangeField == nil {
+				return nil, schemaErrorf(field.FullName(), "include_range_into refers to %q, which is not a field of %s", attrOpts.IncludeRangeInto, field.ContainingMessage().FullName())
+			}
+			if !isMessageKind(rangeField.Kind()) || rangeField.Cardinality() == protoreflect.Repeated || rangeField.Message().FullName() != sourceRangeDesc.FullName() {
+				return nil, schemaErrorf(field.FullName(), "include_range_into field %s must be a singleton %s field", rangeField.Name(), sourceRangeDesc.FullName())
+			}
+			if elem, err := GetFieldElem(rangeField); err != nil || elem != nil {
+				return nil, schemaErrorf(field.FullName(), "include_range_into field %s must not have any HCL annotations", rangeField.Name())
+			}
+		}
+		if elemDesc.Kind() == protoreflect.EnumKind {
+			if err := validateEnumHCLNames(elemDesc.Enum()); err != nil {
+				return nil, err
+			}
+		}
+		if attrOpts.AnyType != "" {
+			if !isMessageKind(elemDesc.Kind()) || elemDesc.Message().FullName() != anyDesc.FullName() || field.IsList() || field.IsMap() {
+				return nil, schemaErrorf(field.FullName(), "only singular google.protobuf.Any fields can have (hcl.attr).any_type")
+			}
+			if !protoreflect.FullName(attrOpts.AnyType).IsValid() {
+				return nil, schemaErrorf(field.FullName(), "invalid (hcl.attr).any_type %q: must be the full name of a message type", attrOpts.AnyType)
+			}
+		}
+		if isMessageKind(elemDesc.Kind()) {
+			if attrOpts.AnyType != "" {
+				// The message type for any_type might come from the host's
+				// own registry, and so we can only check it while decoding.
+			} else if elemDesc.Message().FullName() == structpbValueDesc.FullName() {
 				if attrOpts.Type == "" {
 					return nil, schemaErrorf(field.FullName(), "must specify (hcl.attr).type for google.protobuf.Struct field")
 				}
+			} else if wrapperValueField(elemDesc.Message()) != nil || isTimestampMessage(elemDesc.Message()) || isDurationMessage(elemDesc.Message()) || isStructpbContainerMessage(elemDesc.Message()) {
+				if field.IsList() || field.IsMap() {
+					return nil, schemaErrorf(field.FullName(), "can use %s only for a singular attribute", elemDesc.Message().FullName())
+				}
+			} else if err := validateAttrMessageDesc(elemDesc.Message()); err != nil {
+				return nil, schemaErrorf(field.FullName(), "can't use %s as the type of an attribute: %w", elemDesc.Message().FullName(), err)
 			}
 		}
+		if attrShadowedByFlatten(field, attrOpts.Name) {
+			// A flattened message takes this attribute's name, and so this
+			// field isn't relevant to HCL in this message.
+			return nil, nil
+		}
 
 		return FieldAttribute{
 			Name:           attrOpts.Name,
 			Required:       attrOpts.Required,
 			TypeExprString: attrOpts.Type,
 			RawMode:        attrOpts.Raw,
+			Unit:           attrOpts.Unit,
 			TargetField:    field,
+			RangeField:     rangeField,
+			CollectionKind: attrOpts.Kind,
+			EmptyAsNull:    attrOpts.EmptyAsNull,
+			NumberSyntax:   attrOpts.NumberSyntax,
+
+			AllowScalarForList: attrOpts.AllowScalarForList,
+			IgnoreNullInLists:  attrOpts.IgnoreNullInLists,
+
+			DefaultExprString: attrOpts.DefaultExpr,
+			AnyType:           protoreflect.FullName(attrOpts.AnyType),
 		}, nil
 
 	case blockOpts != nil && blockOpts.TypeName != "":
@@ -98,20 +214,53 @@ func GetFieldElem(field protoreflect.FieldDescriptor) (FieldElem, error) {
 		if labelOpts != nil && labelOpts.Name != "" {
 			return nil, schemaErrorf(field.FullName(), "cannot be both nested block type %q and block label %q", attrOpts.Name, labelOpts.Name)
 		}
-		if field.Kind() != protoreflect.MessageKind {
+		if !isMessageKind(field.Kind()) {
 			return nil, schemaErrorf(field.FullName(), "field representing nested block must have message type, not %s", field.Kind())
 		}
+		nestedDesc := field.Message()
 		if field.IsMap() {
-			// Maybe we'll support this later, perhaps by just adding a
-			// synthetic extra block label for the map key? Will wait to see
-			// whether there's a strong need to do that first, because callers
-			// can get a similar effect by collecting up block messages by
-			// the labels themselves.
-			return nil, schemaErrorf(field.FullName(), "field representing nested block must not be a map")
+			// For a map, each block has an extra synthetic label to select
+			// its key, and so the map value type is the block body.
+			if field.MapKey().Kind() != protoreflect.StringKind {
+				return nil, schemaErrorf(field.FullName(), "HCL only supports maps with string keys")
+			}
+			if !isMessageKind(field.MapValue().Kind()) {
+				return nil, schemaErrorf(field.FullName(), "map field representing nested block must have message values, not %s", field.MapValue().Kind())
+			}
+			nestedDesc = field.MapValue().Message()
+		} else if blockOpts.MapKeyLabel != "" {
+			return nil, schemaErrorf(field.FullName(), "only map fields can have (hcl.block).map_key_label")
+		} else if blockOpts.MapKeyAttr != "" {
+			return nil, schemaErrorf(field.FullName(), "only map fields can have (hcl.block).map_key_attr")
+		}
+
+		if messageIsRootOnly(nestedDesc) {
+			return nil, schemaErrorf(field.FullName(), "can't use %s as nested block type %q, because it is declared as root_only", nestedDesc.FullName(), blockOpts.TypeName)
 		}
 
 		collectionKind := blockOpts.Kind
-		if field.IsList() {
+		var mapKeyLabel string
+		if field.IsMap() {
+			if collectionKind == protohclext.NestedBlock_AUTO {
+				collectionKind = protohclext.NestedBlock_MAP
+			}
+			if collectionKind != protohclext.NestedBlock_MAP {
+				return nil, schemaErrorf(field.FullName(), "map fields can't have block collection mode %s", collectionKind)
+			}
+			if blockOpts.MapKeyAttr != "" {
+				if blockOpts.MapKeyLabel != "" {
+					return nil, schemaErrorf(field.FullName(), "can't have both (hcl.block).map_key_label and (hcl.block).map_key_attr")
+				}
+				if err := checkMapKeyAttr(nestedDesc, blockOpts.MapKeyAttr); err != nil {
+					return nil, schemaErrorf(field.FullName(), "invalid (hcl.block).map_key_attr: %w", err)
+				}
+			} else {
+				mapKeyLabel = blockOpts.MapKeyLabel
+				if mapKeyLabel == "" {
+					mapKeyLabel = "name"
+				}
+			}
+		} else if field.IsList() {
 			if collectionKind == protohclext.NestedBlock_AUTO {
 				collectionKind = protohclext.NestedBlock_TUPLE
 			}
@@ -124,31 +273,82 @@ func GetFieldElem(field protoreflect.FieldDescriptor) (FieldElem, error) {
 			}
 		}
 
+		var anyDiscriminatorField protoreflect.FieldDescriptor
+		var anyTypes map[string]protoreflect.MessageDescriptor
+		if nestedDesc.FullName() == anyDesc.FullName() || blockOpts.AnyDiscriminator != "" || len(blockOpts.AnyTypes) != 0 {
+			var err error
+			anyDiscriminatorField, anyTypes, err = checkAnyBlock(field, nestedDesc, blockOpts)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		seenAliases := make(map[string]struct{}, len(blockOpts.Aliases))
+		for _, alias := range blockOpts.Aliases {
+			if !hclsyntax.ValidIdentifier(alias) {
+				return nil, schemaErrorf(field.FullName(), "block type alias %q is not a valid HCL identifier", alias)
+			}
+			if alias == blockOpts.TypeName {
+				return nil, schemaErrorf(field.FullName(), "block type %q can't also be its own alias", alias)
+			}
+			if _, exists := seenAliases[alias]; exists {
+				return nil, schemaErrorf(field.FullName(), "duplicate block type alias %q", alias)
+			}
+			seenAliases[alias] = struct{}{}
+		}
+
 		return FieldNestedBlockType{
 			TypeName:       blockOpts.TypeName,
-			Nested:         field.Message(),
+			Aliases:        blockOpts.Aliases,
+			Nested:         nestedDesc,
 			Repeated:       field.IsList(),
 			CollectionKind: collectionKind,
+			MapKeyLabel:    mapKeyLabel,
+			MapKeyAttr:     blockOpts.MapKeyAttr,
+			TargetField:    field,
+
+			AnyDiscriminator:      blockOpts.AnyDiscriminator,
+			AnyDiscriminatorField: anyDiscriminatorField,
+			AnyTypes:              anyTypes,
 		}, nil
 
 	case flatten:
 		if labelOpts != nil && labelOpts.Name != "" {
 			return nil, schemaErrorf(field.FullName(), "cannot be block label %q and also flatten into the current body", labelOpts.Name)
 		}
-		if field.Kind() != protoreflect.MessageKind {
+		if !isMessageKind(field.Kind()) {
 			return nil, schemaErrorf(field.FullName(), "field to be flattened must have message type, not %s", field.Kind())
 		}
 		if field.Cardinality() == protoreflect.Repeated {
 			return nil, schemaErrorf(field.FullName(), "field to be flattened must not be 'repeated'")
 		}
+		if flattenPrefix != "" && !hclsyntax.ValidIdentifier(flattenPrefix) {
+			return nil, schemaErrorf(field.FullName(), "flatten_prefix %q is not a valid HCL identifier", flattenPrefix)
+		}
+
+		var shadowed map[string]struct{}
+		if flattenConflict == protohclext.FlattenConflict_OUTER_WINS {
+			direct := directAttrNames(field.ContainingMessage())
+			for _, name := range flattenedAttrNames(field, flattenPrefix) {
+				if _, exists := direct[name]; exists {
+					if shadowed == nil {
+						shadowed = make(map[string]struct{})
+					}
+					shadowed[name] = struct{}{}
+				}
+			}
+		}
 
 		return FieldFlattened{
-			Nested: field.Message(),
+			Nested:   field.Message(),
+			Prefix:   flattenPrefix,
+			Shadowed: shadowed,
 		}, nil
 
 	case labelOpts != nil && labelOpts.Name != "":
 		return FieldBlockLabel{
-			Name: labelOpts.Name,
+			Name:        labelOpts.Name,
+			Description: labelOpts.Description,
 		}, nil
 
 	default:
@@ -159,6 +359,132 @@ func GetFieldElem(field protoreflect.FieldDescriptor) (FieldElem, error) {
 
 }
 
+// attrShadowedByFlatten returns true if the given attribute field's name is
+// also the name of an attribute from a sibling field that is flattened with
+// (hcl.flatten_conflict) = INNER_WINS, and so the attribute belongs to that
+// flattened message instead.
+func attrShadowedByFlatten(field protoreflect.FieldDescriptor, name string) bool {
+	fields := field.ContainingMessage().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		sibling := fields.Get(i)
+		opts, ok := sibling.Options().(*descriptorpb.FieldOptions)
+		if !ok || !isMessageKind(sibling.Kind()) {
+			continue
+		}
+		if proto.GetExtension(opts, protohclext.E_FlattenConflict).(protohclext.FlattenConflict) != protohclext.FlattenConflict_INNER_WINS {
+			continue
+		}
+		prefix := proto.GetExtension(opts, protohclext.E_FlattenPrefix).(string)
+		for _, flatName := range flattenedAttrNames(sibling, prefix) {
+			if flatName == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// directAttrNames returns the names of the attributes declared directly in
+// the given message, not including any from flattened messages.
+//
+// This reads the field options directly rather than using GetFieldElem,
+// because GetFieldElem for an attribute field in turn checks its flattened
+// siblings.
+func directAttrNames(desc protoreflect.MessageDescriptor) map[string]struct{} {
+	ret := make(map[string]struct{})
+	fields := desc.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		opts, ok := fields.Get(i).Options().(*descriptorpb.FieldOptions)
+		if !ok {
+			continue
+		}
+		if attrOpts := proto.GetExtension(opts, protohclext.E_Attr).(*protohclext.Attribute); attrOpts.GetName() != "" {
+			ret[attrOpts.Name] = struct{}{}
+		}
+	}
+	return ret
+}
+
+// flattenedAttrNames returns the names of the attributes that the given
+// flattened field contributes to its containing message, with the given
+// prefix already added.
+//
+// If the nested message is invalid then the result is empty, because
+// GetFieldElem for the flattened field will report the problem.
+func flattenedAttrNames(field protoreflect.FieldDescriptor, prefix string) []string {
+	schema, err := bodySchema(field.Message())
+	if err != nil {
+		return nil
+	}
+	ret := make([]string, len(schema.Attributes))
+	for i, attrS := range schema.Attributes {
+		ret[i] = prefix + attrS.Name
+	}
+	return ret
+}
+
+// validateAttrMessageDesc checks whether the given message type, used as the
+// type of an attribute-annotated field other than google.protobuf.Value, is
+// suitable for decoding from an object value. Such a message may declare
+// only attributes, possibly via flattened messages.
+func validateAttrMessageDesc(desc protoreflect.MessageDescriptor) error {
+	count, err := countAttrMessageAttrs(desc)
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return fmt.Errorf("%s has no HCL attribute fields", desc.FullName())
+	}
+	return nil
+}
+
+func countAttrMessageAttrs(desc protoreflect.MessageDescriptor) (int, error) {
+	count := 0
+	fields := desc.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		opts, ok := field.Options().(*descriptorpb.FieldOptions)
+		if !ok {
+			continue
+		}
+
+		// We intentionally don't use GetFieldElem here, because that would
+		// recursively validate the types of any message-typed attributes,
+		// which would never terminate for a recursive message type.
+		if blockOpts := proto.GetExtension(opts, protohclext.E_Block).(*protohclext.NestedBlock); blockOpts.GetTypeName() != "" {
+			return count, fmt.Errorf("%s declares nested block type %q, but nested blocks can't appear inside an attribute", field.FullName(), blockOpts.TypeName)
+		}
+		if labelOpts := proto.GetExtension(opts, protohclext.E_Label).(*protohclext.BlockLabel); labelOpts.GetName() != "" {
+			return count, fmt.Errorf("%s declares block label %q, but block labels can't appear inside an attribute", field.FullName(), labelOpts.Name)
+		}
+		if attrOpts := proto.GetExtension(opts, protohclext.E_Attr).(*protohclext.Attribute); attrOpts.GetName() != "" {
+			count++
+		}
+		flatten := proto.GetExtension(opts, protohclext.E_Flatten).(bool) || proto.GetExtension(opts, protohclext.E_FlattenPrefix).(string) != ""
+		if flatten && isMessageKind(field.Kind()) {
+			nestedCount, err := countAttrMessageAttrs(field.Message())
+			count += nestedCount
+			if err != nil {
+				return count, err
+			}
+		}
+	}
+	return count, nil
+}
+
+// messageOptions returns the (hcl.message) options for the given message
+// type, or nil if it doesn't have any.
+func messageOptions(desc protoreflect.MessageDescriptor) *protohclext.Message {
+	return protohclext.MessageOption(desc)
+}
+
+// messageIsRootOnly returns true if the given message type is declared
+// with (hcl.message).root_only, and so can't be used as the body of a nested
+// block type.
+func messageIsRootOnly(desc protoreflect.MessageDescriptor) bool {
+	return messageOptions(desc).GetRootOnly()
+}
+
 // FieldElem represents a HCL-specific behavior associated with a protobuf
 // message field.
 //
@@ -176,16 +502,75 @@ type FieldAttribute struct {
 	TypeExprString string
 	RawMode        protohclext.Attribute_RawMode
 
+	// Unit is the name of the unit that a numeric field is measured in, if
+	// the attribute accepts strings with unit suffixes. It's empty for
+	// other attributes.
+	Unit string
+
 	TargetField protoreflect.FieldDescriptor
+
+	// RangeField is a sibling of TargetField, of type hcl.SourceRange, where
+	// the decoder records the source range of the attribute's value
+	// expression. It's nil if the attribute doesn't use include_range_into.
+	RangeField protoreflect.FieldDescriptor
+
+	// CollectionKind is the collection kind selected for a repeated field
+	// of a message type, or NestedBlock_AUTO to select one automatically.
+	CollectionKind protohclext.NestedBlock_CollectionKind
+
+	// EmptyAsNull is true if an empty string should be treated as null,
+	// both when decoding and when building an object value from a message.
+	EmptyAsNull bool
+
+	// NumberSyntax describes the extensions to HCL's number syntax that the
+	// attribute accepts in strings, or is nil if it accepts only HCL's
+	// usual number syntax.
+	NumberSyntax *protohclext.Attribute_NumberSyntax
+
+	// AllowScalarForList is true if a non-collection value should be
+	// accepted for this repeated field, treating it as a single-element
+	// list.
+	AllowScalarForList bool
+
+	// IgnoreNullInLists is true if null elements of a value for this
+	// repeated field should be discarded, rather than causing an error.
+	IgnoreNullInLists bool
+
+	// DefaultExprString is the source code of the attribute's default
+	// expression, from (hcl.attr).default_expr, or empty if it has none.
+	DefaultExprString string
+
+	// AnyType is the full name of the message type that a value for this
+	// google.protobuf.Any field decodes as, from (hcl.attr).any_type, or
+	// empty if it has none.
+	AnyType protoreflect.FullName
 }
 
 // TypeConstraint attempts to interpret field TypeExprString as an HCL type
 // constraint expression, and then if successful returns the type constraint
 // that it represents.
 //
+// An attribute with a Unit instead always has a string-based type
+// constraint, because it accepts strings that include a unit suffix.
+//
+// In addition to HCL's usual type expression syntax, the expression may use
+// msg("full.Name") to refer to the object type constraint of another
+// annotated message type declared in the same file as the field or in one
+// of the files it imports.
+//
 // If the field doesn't contain a valid type constraint expression then
 // TypeConstraint returns error diagnostics and an invalid type.
 func (fa FieldAttribute) TypeConstraint() (cty.Type, hcl.Diagnostics) {
+	return fa.typeConstraint(nil)
+}
+
+// typeConstraint is the implementation of TypeConstraint, which also takes
+// the "visiting" map used by attrObjectTypeConstraintForMessageDesc to
+// detect recursion through msg type constructors.
+func (fa FieldAttribute) typeConstraint(visiting map[protoreflect.FullName]struct{}) (cty.Type, hcl.Diagnostics) {
+	if fa.Unit != "" {
+		return unitTypeConstraintForField(fa.TargetField), nil
+	}
 	if fa.TypeExprString == "" {
 		ty, err := fa.autoTypeConstraint()
 		if err != nil {
@@ -199,7 +584,7 @@ func (fa FieldAttribute) TypeConstraint() (cty.Type, hcl.Diagnostics) {
 		return cty.DynamicPseudoType, diags
 	}
 
-	ty, moreDiags := typeexpr.TypeConstraint(expr)
+	ty, moreDiags := typeConstraintForExpr(expr, fa.TargetField, visiting)
 	diags = append(diags, moreDiags...)
 	return ty, diags
 }
@@ -209,7 +594,15 @@ func (fa FieldAttribute) autoTypeConstraint() (cty.Type, error) {
 		return cty.DynamicPseudoType, schemaErrorf(fa.TargetField.FullName(), "must set explicit HCL type constraint for this raw-mode attribute")
 	}
 
-	ty := autoTypeConstraintForField(fa.TargetField)
+	if fa.CollectionKind != protohclext.NestedBlock_AUTO {
+		ety := autoTypeConstraintForFieldElement(fa.TargetField, nil)
+		if ety == cty.NilType {
+			return cty.DynamicPseudoType, schemaErrorf(fa.TargetField.FullName(), "can't infer HCL type constraint for this field's elements")
+		}
+		return collectionTypeConstraint(fa.TargetField.FullName(), fa.CollectionKind, ety, "(hcl.attr).kind", "a message type")
+	}
+
+	ty := autoTypeConstraintForField(fa.TargetField, nil)
 	if ty == cty.NilType {
 		return cty.DynamicPseudoType, schemaErrorf(fa.TargetField.FullName(), "can't infer HCL type constraint for this field; must specify (hcl.attr).type option explicitly")
 	}
@@ -219,22 +612,203 @@ func (fa FieldAttribute) autoTypeConstraint() (cty.Type, error) {
 func (fa FieldAttribute) fieldElem() {}
 
 type FieldNestedBlockType struct {
-	TypeName       string
-	Nested         protoreflect.MessageDescriptor
-	Repeated       bool
+	TypeName string
+	Nested   protoreflect.MessageDescriptor
+	Repeated bool
+
+	// Aliases are the values of (hcl.block).aliases: other type names that
+	// blocks of this type may use, with a deprecation warning.
+	Aliases []string
+
+	// CollectionKind comes from the field rather than from the Nested
+	// message type, which can be the body of several block types with
+	// different kinds. Anything derived from it must therefore be keyed by
+	// the field, and not only by the nested message type.
 	CollectionKind protohclext.NestedBlock_CollectionKind
+
+	// MapKeyLabel is the name of the extra label that selects each block's
+	// key, if the field is a map. It is always empty for other fields.
+	//
+	// For a map field, exactly one of MapKeyLabel and MapKeyAttr is set.
+	MapKeyLabel string
+
+	// MapKeyAttr is the value of (hcl.block).map_key_attr, if set: the name
+	// of a required string attribute of the Nested message whose value is
+	// each block's key in the map, instead of an extra label.
+	MapKeyAttr string
+
+	TargetField protoreflect.FieldDescriptor
+
+	// AnyDiscriminator is the value of (hcl.block).any_discriminator, if
+	// set: the name of the attribute whose value selects which of AnyTypes
+	// the block's content decodes as, for a google.protobuf.Any field. In
+	// that case Nested is the descriptor of google.protobuf.Any itself.
+	AnyDiscriminator string
+
+	// AnyDiscriminatorField is the field of the AnyDiscriminator attribute,
+	// a sibling of TargetField declared before it. It's nil if
+	// AnyDiscriminator is empty.
+	AnyDiscriminatorField protoreflect.FieldDescriptor
+
+	// AnyTypes maps each value of the AnyDiscriminator attribute that
+	// (hcl.block).any_types lists to the message type that it selects.
+	// It's nil if AnyDiscriminator is empty.
+	AnyTypes map[string]protoreflect.MessageDescriptor
 }
 
 func (fa FieldNestedBlockType) fieldElem() {}
 
+// checkMapKeyAttr returns an error unless the given message type declares
+// an attribute of the given name that's suitable for use as the key of a
+// map block type.
+func checkMapKeyAttr(desc protoreflect.MessageDescriptor, name string) error {
+	fields := desc.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		elem, err := GetFieldElem(field)
+		if err != nil {
+			continue // we'll report this when building the nested schema
+		}
+		attr, ok := elem.(FieldAttribute)
+		if !ok || attr.Name != name {
+			continue
+		}
+		if field.Kind() != protoreflect.StringKind || field.Cardinality() == protoreflect.Repeated || attr.RawMode != protohclext.Attribute_NOT_RAW {
+			return fmt.Errorf("attribute %q must be of type string", name)
+		}
+		if !attr.Required {
+			return fmt.Errorf("attribute %q must be required", name)
+		}
+		return nil
+	}
+	return fmt.Errorf("%s does not declare an attribute named %q", desc.FullName(), name)
+}
+
+// hasTypeName returns true if the given block type name selects this block
+// type, either as its main type name or as one of its aliases.
+func (fa FieldNestedBlockType) hasTypeName(name string) bool {
+	if name == fa.TypeName {
+		return true
+	}
+	for _, alias := range fa.Aliases {
+		if name == alias {
+			return true
+		}
+	}
+	return false
+}
+
 type FieldFlattened struct {
 	Nested protoreflect.MessageDescriptor
+
+	// Prefix is the value of (hcl.flatten_prefix), if set. It's added to
+	// the start of the name of every attribute, block type, and block label
+	// that the Nested message contributes, including any that it in turn
+	// flattens in from other messages.
+	Prefix string
+
+	// Shadowed is the set of names of attributes from the Nested message
+	// that the containing message takes for its own attributes, due to
+	// (hcl.flatten_conflict) = OUTER_WINS. The names include the prefix.
+	// The fields for these attributes are ignored for HCL purposes when
+	// reached through this element.
+	Shadowed map[string]struct{}
 }
 
 func (fa FieldFlattened) fieldElem() {}
 
+// withNamePrefix returns a copy of the given element with the given prefix
+// added to its HCL name, for an element declared in a message that was
+// flattened in using (hcl.flatten_prefix). The prefix of a nested
+// FieldFlattened accumulates, so that the elements it contributes can
+// in turn have both prefixes.
+func withNamePrefix(elem FieldElem, prefix string) FieldElem {
+	if prefix == "" {
+		return elem
+	}
+	switch elem := elem.(type) {
+	case FieldAttribute:
+		elem.Name = prefix + elem.Name
+		return elem
+	case FieldNestedBlockType:
+		elem.TypeName = prefix + elem.TypeName
+		if len(elem.Aliases) != 0 {
+			aliases := make([]string, len(elem.Aliases))
+			for i, alias := range elem.Aliases {
+				aliases[i] = prefix + alias
+			}
+			elem.Aliases = aliases
+		}
+		if elem.AnyDiscriminator != "" {
+			elem.AnyDiscriminator = prefix + elem.AnyDiscriminator
+		}
+		return elem
+	case FieldFlattened:
+		elem.Prefix = prefix + elem.Prefix
+		if len(elem.Shadowed) != 0 {
+			shadowed := make(map[string]struct{}, len(elem.Shadowed))
+			for name := range elem.Shadowed {
+				shadowed[prefix+name] = struct{}{}
+			}
+			elem.Shadowed = shadowed
+		}
+		return elem
+	case FieldBlockLabel:
+		elem.Name = prefix + elem.Name
+		return elem
+	default:
+		return elem
+	}
+}
+
+// flattenedElem is like withNamePrefix, but also accounts for the attributes
+// that outer's containing message takes for itself: the result is nil for
+// an attribute that outer shadows, and a nested FieldFlattened inherits all
+// of outer's shadowed names so that they also apply to the attributes it
+// contributes.
+//
+// Functions that visit the fields of a flattened message and care about
+// which field each attribute belongs to use this instead of withNamePrefix.
+func flattenedElem(elem FieldElem, outer FieldFlattened) FieldElem {
+	elem = withNamePrefix(elem, outer.Prefix)
+	if len(outer.Shadowed) == 0 {
+		return elem
+	}
+	switch elem := elem.(type) {
+	case FieldAttribute:
+		if _, shadowed := outer.Shadowed[elem.Name]; shadowed {
+			return nil
+		}
+		return elem
+	case FieldFlattened:
+		shadowed := make(map[string]struct{}, len(outer.Shadowed)+len(elem.Shadowed))
+		for name := range outer.Shadowed {
+			shadowed[name] = struct{}{}
+		}
+		for name := range elem.Shadowed {
+			shadowed[name] = struct{}{}
+		}
+		elem.Shadowed = shadowed
+		return elem
+	default:
+		return elem
+	}
+}
+
 type FieldBlockLabel struct {
 	Name string
+
+	// Description is the value of (hcl.label).description, if set.
+	Description string
 }
 
 func (fa FieldBlockLabel) fieldElem() {}
+
+// isMessageKind returns true if the given kind is for a message-typed field.
+//
+// That includes the group kind, used both for proto2 groups and for fields
+// with the protobuf editions feature message_encoding = DELIMITED, which
+// differ from other message-typed fields only in their wire encoding.
+func isMessageKind(kind protoreflect.Kind) bool {
+	return kind == protoreflect.MessageKind || kind == protoreflect.GroupKind
+}
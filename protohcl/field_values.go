@@ -1,58 +1,59 @@
 package protohcl
 
 import (
-	"bytes"
 	"fmt"
-	"math"
-	"math/big"
-	"strconv"
 
+	"github.com/apparentlymart/go-protohcl/protohcl/protohclcty"
 	"github.com/apparentlymart/go-protohcl/protohcl/protohclext"
 	"github.com/hashicorp/hcl/v2"
 	"github.com/zclconf/go-cty/cty"
-	ctyjson "github.com/zclconf/go-cty/cty/json"
-	ctymsgpack "github.com/zclconf/go-cty/cty/msgpack"
 	"google.golang.org/protobuf/reflect/protoreflect"
 )
 
-const unsuitableValueSummary = "Unsuitable attribute value"
+const (
+	unsuitableValueSummary   = "Unsuitable attribute value"
+	integerOutOfRangeSummary = "Integer value out of range"
+)
 
-func protoValueForField(val cty.Value, rng hcl.Range, msg protoreflect.Message, field protoreflect.FieldDescriptor) (protoreflect.Value, hcl.Diagnostics) {
+func protoValueForField(val cty.Value, rngs valueSourceRanges, msg protoreflect.Message, field protoreflect.FieldDescriptor, opts DecodeOptions) (protoreflect.Value, hcl.Diagnostics) {
 	var diags hcl.Diagnostics
+	rng := rngs.Whole
 
 	ty := val.Type()
 
 	switch {
 	case field.IsList():
 		if ty.IsListType() || ty.IsSetType() || ty.IsTupleType() {
-			return protoValueForListField(val.AsValueSlice(), rng, msg, field)
+			return protoValueForListField(val.AsValueSlice(), rngs, msg, field, opts)
 		} else {
 			diags = diags.Append(&hcl.Diagnostic{
 				Severity: hcl.DiagError,
 				Summary:  unsuitableValueSummary,
 				Detail:   "This argument requires a sequence of values.",
 				Subject:  &rng,
+				Extra:    diagnosticExtra{Category: DiagnosticValueError},
 			})
 		}
 	case field.IsMap():
 		if ty.IsMapType() || ty.IsObjectType() {
-			return protoValueForMapField(val.AsValueMap(), rng, msg, field)
+			return protoValueForMapField(val.AsValueMap(), rngs, msg, field, opts)
 		} else {
 			diags = diags.Append(&hcl.Diagnostic{
 				Severity: hcl.DiagError,
 				Summary:  unsuitableValueSummary,
 				Detail:   "This argument requires a mapping from strings to values.",
 				Subject:  &rng,
+				Extra:    diagnosticExtra{Category: DiagnosticValueError},
 			})
 		}
 	default:
-		return protoValueForSingletonField(val, rng, msg, field)
+		return protoValueForSingletonField(val, rng, msg, field, opts)
 	}
 
 	return msg.NewField(field), diags
 }
 
-func protoValueForSingletonField(val cty.Value, rng hcl.Range, msg protoreflect.Message, field protoreflect.FieldDescriptor) (protoreflect.Value, hcl.Diagnostics) {
+func protoValueForSingletonField(val cty.Value, rng hcl.Range, msg protoreflect.Message, field protoreflect.FieldDescriptor, opts DecodeOptions) (protoreflect.Value, hcl.Diagnostics) {
 	var diags hcl.Diagnostics
 
 	// NOTE: Our logic here assumes that val was already constrained by
@@ -93,6 +94,7 @@ func protoValueForSingletonField(val cty.Value, rng hcl.Range, msg protoreflect.
 			Summary:  unsuitableValueSummary,
 			Detail:   "Unknown values are not allowed here.",
 			Context:  rng.Ptr(),
+			Extra:    diagnosticExtra{Category: DiagnosticUnknownValue},
 		})
 		return msg.NewField(field), diags
 	}
@@ -100,171 +102,131 @@ func protoValueForSingletonField(val cty.Value, rng hcl.Range, msg protoreflect.
 	// By the time we get here, we know that the top-level value is known
 	// (because we checked that above) and non-null (because callers should
 	// check that before they call, and just skip setting the field if so.)
-	ret, moreDiags := protoValueForSingletonFieldKind(val, rng, msg, field)
+	ret, moreDiags := protoValueForSingletonFieldKind(val, rng, "The value", msg, field, opts)
 	diags = append(diags, moreDiags...)
 	return ret, diags
 }
 
-func protoValueForSingletonFieldKind(val cty.Value, rng hcl.Range, msg protoreflect.Message, field protoreflect.FieldDescriptor) (protoreflect.Value, hcl.Diagnostics) {
+func protoValueForSingletonFieldKind(val cty.Value, rng hcl.Range, valDesc string, msg protoreflect.Message, field protoreflect.FieldDescriptor, opts DecodeOptions) (protoreflect.Value, hcl.Diagnostics) {
 	// This function makes its selections based only on the field's kind and
 	// not on its HCL-specific options. By the time we get here the caller
 	// should already have rejected any null or unknown values and know it's
 	// not supposed to be decoding in raw mode.
+	//
+	// valDesc is a phrase describing the value for use as the subject of
+	// a sentence in error messages, such as "The value".
 
 	var diags hcl.Diagnostics
 
 	switch field.Kind() {
-	case protoreflect.BoolKind:
-		return protoreflect.ValueOfBool(val.True()), diags
-	case protoreflect.EnumKind:
-		// TODO: Need some more work here to allow annotating proto enum
-		// values with the strings that will select them in config.
-		diags = diags.Append(&hcl.Diagnostic{
-			Severity: hcl.DiagError,
-			Summary:  unsuitableValueSummary,
-			Detail:   "Decoding enum-typed fields isn't supported yet.",
-			Context:  rng.Ptr(),
-		})
-		return msg.NewField(field), diags
-	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
-		bi, moreDiags := intValueForFixedIntegerField(val, rng, math.MinInt32, math.MaxInt32)
-		diags = append(diags, moreDiags...)
-		return protoreflect.ValueOfInt32(int32(bi.Int64())), diags
-	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
-		bi, moreDiags := intValueForFixedIntegerField(val, rng, math.MinInt64, math.MaxInt64)
-		diags = append(diags, moreDiags...)
-		return protoreflect.ValueOfInt64(bi.Int64()), diags
-	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
-		bi, moreDiags := intValueForFixedIntegerField(val, rng, 0, math.MaxUint32)
-		diags = append(diags, moreDiags...)
-		return protoreflect.ValueOfUint32(uint32(bi.Uint64())), diags
-	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
-		bi, moreDiags := intValueForFixedIntegerField(val, rng, 0, math.MaxUint64)
-		diags = append(diags, moreDiags...)
-		return protoreflect.ValueOfUint64(bi.Uint64()), diags
-	case protoreflect.StringKind:
-		return protoreflect.ValueOfString(val.AsString()), diags
-	case protoreflect.MessageKind:
+	case protoreflect.MessageKind, protoreflect.GroupKind:
 		diags = diags.Append(&hcl.Diagnostic{
 			Severity: hcl.DiagError,
 			Summary:  unsuitableValueSummary,
 			Detail:   "Decoding message-typed fields isn't supported yet.",
 			Context:  rng.Ptr(),
+			Extra:    diagnosticExtra{Category: DiagnosticValueError},
 		})
 		return msg.NewField(field), diags
 	default:
-		// physicalConstraintForFieldKindSingle rejects all other kinds,
-		// so if we get here then it's always a bug.
-		panic(fmt.Sprintf("unhandled %s for field %s", field.Kind(), field.FullName()))
+		ret, err := protohclcty.ToProto(val, field)
+		if valErr, ok := err.(protohclcty.ValueError); ok && opts.ClampIntegers {
+			if clamped, ok := protohclcty.ClampInteger(val, field); ok {
+				diags = diags.Append(&hcl.Diagnostic{
+					Severity: hcl.DiagWarning,
+					Summary:  integerOutOfRangeSummary,
+					Detail:   fmt.Sprintf("%s must be %s, so it has been changed to %s.", valDesc, valErr.Requirement, clamped.AsBigFloat().Text('f', -1)),
+					Subject:  rng.Ptr(),
+					Extra:    diagnosticExtra{Category: DiagnosticValueError},
+				})
+				ret, err = protohclcty.ToProto(clamped, field)
+			}
+		}
+		if err != nil {
+			valErr, ok := err.(protohclcty.ValueError)
+			if !ok {
+				// physicalConstraintForFieldKindSingle rejects all kinds
+				// that protohclcty doesn't support, so if we get here then
+				// it's always a bug.
+				panic(fmt.Sprintf("unhandled %s for field %s: %s", field.Kind(), field.FullName(), err))
+			}
+			diags = diags.Append(&hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  unsuitableValueSummary,
+				Detail:   fmt.Sprintf("%s must be %s.", valDesc, valErr.Requirement),
+				Subject:  rng.Ptr(),
+				Extra:    diagnosticExtra{Category: DiagnosticValueError},
+			})
+		}
+		return ret, diags
 	}
-
 }
 
 func protoValueForSingletonRawField(val cty.Value, rng hcl.Range, attr FieldAttribute) (protoreflect.Value, hcl.Diagnostics) {
 	var diags hcl.Diagnostics
 
 	ty, moreDiags := attr.TypeConstraint()
-	if diags.HasErrors() {
+	if moreDiags.HasErrors() {
 		diags = append(diags, moreDiags...)
 		return protoreflect.ValueOfBytes(nil), diags
 	}
 
-	var rawVal []byte
-	var err error
-	switch attr.RawMode {
-	case protohclext.Attribute_MESSAGEPACK:
-		rawVal, err = ctymsgpack.Marshal(val, ty)
-		if err != nil {
-			// This is a weird situation because we're reporting what must be
-			// a bug in the calling program, but with a message directed at
-			// the configuration author.
-			diags = diags.Append(&hcl.Diagnostic{
-				Severity: hcl.DiagError,
-				Summary:  "Internal error while decoding configuration",
-				Detail:   fmt.Sprintf("This attribute value is not compatible with the MessagePack field where it'll be stored internally: %s.\n\nThis is a bug in the configuration schema.", err),
-			})
-			return protoreflect.ValueOfBytes(nil), diags
-		}
-	case protohclext.Attribute_JSON:
-		rawVal, err = ctyjson.Marshal(val, ty)
-		if err != nil {
-			// This is a weird situation because we're reporting what must be
-			// a bug in the calling program, but with a message directed at
-			// the configuration author.
-			diags = diags.Append(&hcl.Diagnostic{
-				Severity: hcl.DiagError,
-				Summary:  "Internal error while decoding configuration",
-				Detail:   fmt.Sprintf("This attribute value is not compatible with the JSON field where it'll be stored internally: %s.\n\nThis is a bug in the configuration schema.", err),
-			})
-			return protoreflect.ValueOfBytes(nil), diags
-		}
-
-	case protohclext.Attribute_NOT_RAW:
+	if attr.RawMode == protohclext.Attribute_NOT_RAW {
 		// Caller shouldn't call this function if not in raw mode.
 		panic("attempting raw encoding into a non-raw field")
+	}
+	codec, err := rawCodecFor(attr)
+	if err != nil {
+		diags = diags.Append(schemaErrorDiagnostic(err))
+		return protoreflect.ValueOfBytes(nil), diags
+	}
 
-	default:
-		diags = diags.Append(schemaErrorDiagnostic(
-			schemaErrorf(attr.TargetField.FullName(), "invalid raw mode %s", attr.RawMode),
-		))
+	rawVal, err := codec.Marshal(val, ty)
+	if err != nil {
+		// This is a weird situation because we're reporting what must be
+		// a bug in the calling program, but with a message directed at
+		// the configuration author.
+		diags = diags.Append(&hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Internal error while decoding configuration",
+			Detail:   fmt.Sprintf("This attribute value is not compatible with the %s field where it'll be stored internally: %s.\n\nThis is a bug in the configuration schema.", rawModeName(attr.RawMode), err),
+		})
 		return protoreflect.ValueOfBytes(nil), diags
 	}
 
 	return protoreflect.ValueOfBytes(rawVal), diags
 }
 
-// intValueForFixedIntegerField checks that the value is an integer within
-// the given range, and if so returns it as a *big.Int so that the caller
-// can then convert it from there to a suitable fixed-size integer type.
+// rawTypedNull returns the raw encoding of the given null value for the
+// given attribute, or nil if the attribute's field should instead be left
+// unset.
 //
-// This function always returns a non-nil *big.Int, but if it also returns
-// error diagnostics then that integer might not be in range.
-func intValueForFixedIntegerField(val cty.Value, rng hcl.Range, min int64, max uint64) (*big.Int, hcl.Diagnostics) {
-	var diags hcl.Diagnostics
-
-	bf := val.AsBigFloat()
-	bi, _ := bf.Int(nil)
-	if !bf.IsInt() {
-		diags = diags.Append(&hcl.Diagnostic{
-			Severity: hcl.DiagError,
-			Summary:  unsuitableValueSummary,
-			Detail:   fmt.Sprintf("The value must be a whole number."),
-			Subject:  rng.Ptr(),
-		})
-		return bi, diags
+// An unset raw field represents a null value of the attribute's type
+// constraint, which is all we need unless the type constraint is "any". In
+// that case the encoding can also record the type of a null value, which
+// would otherwise be lost.
+func rawTypedNull(val cty.Value, attr FieldAttribute) []byte {
+	if attr.RawMode == protohclext.Attribute_NOT_RAW || val.Type() == cty.DynamicPseudoType {
+		return nil
 	}
-
-	bigMin := big.NewInt(min)
-	if cmpMin := bi.Cmp(bigMin); cmpMin < 0 {
-		diags = diags.Append(&hcl.Diagnostic{
-			Severity: hcl.DiagError,
-			Summary:  unsuitableValueSummary,
-			Detail:   fmt.Sprintf("The value must be greater than or equal to %d.", min),
-			Subject:  rng.Ptr(),
-		})
-		return bi, diags
+	if ty, diags := attr.TypeConstraint(); diags.HasErrors() || ty != cty.DynamicPseudoType {
+		return nil
 	}
-	bigMax := big.NewInt(0)
-	bigMax.SetUint64(max)
-	if cmpMax := bi.Cmp(bigMax); cmpMax > 0 {
-		diags = diags.Append(&hcl.Diagnostic{
-			Severity: hcl.DiagError,
-			Summary:  unsuitableValueSummary,
-			Detail:   fmt.Sprintf("The value must be less than or equal to %d.", max),
-			Subject:  rng.Ptr(),
-		})
-		return bi, diags
+	protoVal, diags := protoValueForSingletonRawField(val, hcl.Range{}, attr)
+	if diags.HasErrors() {
+		// We'll just lose the type, then, as we would've done before we
+		// started recording it.
+		return nil
 	}
-
-	return bi, diags
+	return protoVal.Bytes()
 }
 
-func protoValueForListField(vals []cty.Value, rng hcl.Range, msg protoreflect.Message, field protoreflect.FieldDescriptor) (protoreflect.Value, hcl.Diagnostics) {
+func protoValueForListField(vals []cty.Value, rngs valueSourceRanges, msg protoreflect.Message, field protoreflect.FieldDescriptor, opts DecodeOptions) (protoreflect.Value, hcl.Diagnostics) {
 	var diags hcl.Diagnostics
 	list := msg.NewField(field).List()
 
-	for _, v := range vals {
-		protoVal, moreDiags := protoValueForSingletonField(v, rng, msg, field)
+	for i, v := range vals {
+		protoVal, moreDiags := protoValueForSingletonField(v, rngs.Elem(i), msg, field, opts)
 		diags = append(diags, moreDiags...)
 		if moreDiags.HasErrors() {
 			continue
@@ -275,22 +237,36 @@ func protoValueForListField(vals []cty.Value, rng hcl.Range, msg protoreflect.Me
 	return protoreflect.ValueOfList(list), diags
 }
 
-func protoValueForMapField(vals map[string]cty.Value, rng hcl.Range, msg protoreflect.Message, field protoreflect.FieldDescriptor) (protoreflect.Value, hcl.Diagnostics) {
+func protoValueForMapField(vals map[string]cty.Value, rngs valueSourceRanges, msg protoreflect.Message, field protoreflect.FieldDescriptor, opts DecodeOptions) (protoreflect.Value, hcl.Diagnostics) {
 	var diags hcl.Diagnostics
 	protoMap := msg.NewField(field).Map()
 
 	for k, v := range vals {
+		rng := rngs.MapElem(k)
 		if !v.IsKnown() {
 			// Only raw-mode fields can accept unknown values, and we don't
 			// allow maps of raw so we can't get here in that case.
 			diags = diags.Append(&hcl.Diagnostic{
 				Severity: hcl.DiagError,
 				Summary:  unsuitableValueSummary,
-				Detail:   "Unknown values are not allowed here.",
-				Context:  rng.Ptr(), // NOTE: Non-ideal because we're reporting the overall map range, not the individual element
+				Detail:   fmt.Sprintf("The value for key %q must be known.", k),
+				Context:  rng.Ptr(),
+				Extra:    diagnosticExtra{Category: DiagnosticUnknownValue},
 			})
 			return msg.NewField(field), diags
 		}
+		if v.IsNull() {
+			// A protobuf map can't represent a null element, and there's
+			// no option to discard them as there is for lists.
+			diags = diags.Append(&hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  unsuitableValueSummary,
+				Detail:   fmt.Sprintf("The value for key %q must not be null.", k),
+				Subject:  rng.Ptr(),
+				Extra:    diagnosticExtra{Category: DiagnosticValueError},
+			})
+			continue
+		}
 
 		// In protobuf a map is really just a repeated message of a special
 		// generated message type with key and value fields, so the values
@@ -298,7 +274,7 @@ func protoValueForMapField(vals map[string]cty.Value, rng hcl.Range, msg protore
 		// message type, not directly for what "field" is describing.
 		mapValField := field.MapValue()
 		mapElemMsg := newMessageMaybeDynamic(mapValField.ContainingMessage())
-		protoVal, moreDiags := protoValueForSingletonFieldKind(v, rng, mapElemMsg, mapValField)
+		protoVal, moreDiags := protoValueForSingletonFieldKind(v, rng, fmt.Sprintf("The value for key %q", k), mapElemMsg, mapValField, opts)
 		diags = append(diags, moreDiags...)
 		if moreDiags.HasErrors() {
 			continue
@@ -375,15 +351,7 @@ func valuePhysicalConstraintForFieldKind(ty cty.Type, field protoreflect.FieldDe
 
 func physicalConstraintForFieldKindSingle(field protoreflect.FieldDescriptor) (cty.Type, error) {
 	switch field.Kind() {
-	case protoreflect.BoolKind:
-		return cty.Bool, nil
-	case protoreflect.EnumKind:
-		return cty.String, nil
-	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Uint32Kind, protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Uint64Kind, protoreflect.Sfixed32Kind, protoreflect.Fixed32Kind, protoreflect.Sfixed64Kind, protoreflect.Fixed64Kind, protoreflect.FloatKind, protoreflect.DoubleKind:
-		return cty.Number, nil
-	case protoreflect.StringKind:
-		return cty.String, nil
-	case protoreflect.MessageKind:
+	case protoreflect.MessageKind, protoreflect.GroupKind:
 		// We delay constraining values destined for message-typed fields
 		// because we have various different strategies for these, which
 		// we'll decide later.
@@ -394,7 +362,11 @@ func physicalConstraintForFieldKindSingle(field protoreflect.FieldDescriptor) (c
 		// whatever the HCL result directly.
 		return cty.DynamicPseudoType, nil
 	default:
-		return cty.DynamicPseudoType, schemaErrorf(field.FullName(), "cannot decode a HCL value into a %s field", field.Kind())
+		ty, err := protohclcty.Type(field)
+		if err != nil {
+			return cty.DynamicPseudoType, schemaErrorf(field.FullName(), "cannot decode a HCL value into a %s field", field.Kind())
+		}
+		return ty, nil
 	}
 }
 
@@ -404,13 +376,17 @@ func physicalConstraintForFieldKindSingle(field protoreflect.FieldDescriptor) (c
 //
 // Returns cty.NilType if there is no suitable corresponding type, in which
 // case the schema author _must_ specify one.
-func autoTypeConstraintForField(field protoreflect.FieldDescriptor) cty.Type {
+//
+// "visiting" is for detecting recursive message types, as described for
+// attrObjectTypeConstraintForMessageDesc, and may be nil when not already
+// building the type constraint for a message.
+func autoTypeConstraintForField(field protoreflect.FieldDescriptor, visiting map[protoreflect.FullName]struct{}) cty.Type {
 	elemField := field
 	if field.IsMap() {
 		elemField = field.MapValue()
 	}
 
-	ety := autoTypeConstraintForFieldElement(elemField)
+	ety := autoTypeConstraintForFieldElement(elemField, visiting)
 	if ety == cty.NilType {
 		return ety
 	}
@@ -435,7 +411,7 @@ func autoTypeConstraintForField(field protoreflect.FieldDescriptor) cty.Type {
 // which ignores the list-ness or map-ness of the field and just returns its
 // element type, under the assumption that autoTypeConstraintForField will
 // then wrap it in a collection type if needed.
-func autoTypeConstraintForFieldElement(field protoreflect.FieldDescriptor) cty.Type {
+func autoTypeConstraintForFieldElement(field protoreflect.FieldDescriptor, visiting map[protoreflect.FullName]struct{}) cty.Type {
 	switch field.Kind() {
 	case protoreflect.BoolKind:
 		return cty.Bool
@@ -445,11 +421,34 @@ func autoTypeConstraintForFieldElement(field protoreflect.FieldDescriptor) cty.T
 		return cty.Number
 	case protoreflect.StringKind:
 		return cty.String
-	case protoreflect.MessageKind:
-		// TODO: Support this by inferring an object type constraint from
-		// the message type, once we have a "type constraint from message
-		// descriptor" helper function.
-		return cty.NilType
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		if field.Message().FullName() == structpbValueDesc.FullName() {
+			// google.protobuf.Value can represent values of any type, so
+			// the schema author must choose one.
+			return cty.NilType
+		}
+		if valueField := wrapperValueField(field.Message()); valueField != nil {
+			// A wrapper type represents its scalar value directly.
+			return autoTypeConstraintForFieldElement(valueField, visiting)
+		}
+		if isTimestampMessage(field.Message()) || isDurationMessage(field.Message()) {
+			return cty.String
+		}
+		if field.Message().FullName() == anyDesc.FullName() && protohclext.AttrOption(field).GetAnyType() != "" {
+			// The message type for any_type might come from the host's own
+			// registry, and so the builder decides the object type.
+			return cty.DynamicPseudoType
+		}
+		if isStructpbContainerMessage(field.Message()) {
+			// The builder checks that the value is an object or a list, as
+			// appropriate, but allows any element types.
+			return cty.DynamicPseudoType
+		}
+		ty, err := attrObjectTypeConstraintForMessageDesc(field.Message(), visiting)
+		if err != nil {
+			return cty.NilType
+		}
+		return ty
 	case protoreflect.BytesKind:
 		// We use "bytes" fields for our raw mode, which always requires
 		// an explicit type constraint.
@@ -459,31 +458,50 @@ func autoTypeConstraintForFieldElement(field protoreflect.FieldDescriptor) cty.T
 	}
 }
 
-func formatCtyPath(path cty.Path) string {
-	var buf bytes.Buffer
-	for _, step := range path {
-		switch ts := step.(type) {
-		case cty.GetAttrStep:
-			fmt.Fprintf(&buf, ".%s", ts.Name)
-		case cty.IndexStep:
-			buf.WriteByte('[')
-			key := ts.Key
-			keyTy := key.Type()
-			switch {
-			case key.IsNull():
-				buf.WriteString("null")
-			case !key.IsKnown():
-				buf.WriteString("(not yet known)")
-			case keyTy == cty.Number:
-				bf := key.AsBigFloat()
-				buf.WriteString(bf.Text('g', -1))
-			case keyTy == cty.String:
-				buf.WriteString(strconv.Quote(key.AsString()))
-			default:
-				buf.WriteString("...")
+// checkStorableValue returns an error if the given value contains anything
+// that can't possibly be stored in a protobuf field, regardless of the
+// field's type: marked values, and capsule-typed values unless allowCapsules
+// is set.
+//
+// We check this before attempting any type conversions, because otherwise
+// these would either cause a panic or an error message that doesn't
+// mention the real problem.
+//
+// The returned error, if any, is always a cty.PathError.
+func checkStorableValue(val cty.Value, allowCapsules bool) error {
+	if val.ContainsMarked() {
+		_, pvm := val.UnmarkDeepWithPaths()
+		var path cty.Path
+		if len(pvm) != 0 {
+			// We only report the first marked value we find, which is
+			// enough to explain the problem.
+			path = pvm[0].Path
+		}
+		return path.NewErrorf("marked values, such as sensitive values, can't be stored in protobuf fields")
+	}
+	if allowCapsules || !typeContainsCapsule(val.Type()) {
+		return nil
+	}
+	return cty.Walk(val, func(path cty.Path, v cty.Value) (bool, error) {
+		ty := v.Type()
+		switch {
+		case ty.IsCapsuleType():
+			return false, path.NewErrorf("%s values can't be stored in protobuf fields", ty.FriendlyName())
+		case !v.IsKnown() || v.IsNull():
+			if typeContainsCapsule(ty) {
+				return false, path.NewErrorf("values of type %s can't be stored in protobuf fields", ty.FriendlyName())
 			}
-			buf.WriteByte(']')
 		}
+		return true, nil
+	})
+}
+
+// emptyAsNull returns a null string if the given attribute uses
+// (hcl.attr).empty_as_null and the given value is an empty string, or the
+// given value unchanged otherwise.
+func emptyAsNull(val cty.Value, attr FieldAttribute) cty.Value {
+	if attr.EmptyAsNull && val.RawEquals(cty.StringVal("")) {
+		return cty.NullVal(cty.String)
 	}
-	return buf.String()
+	return val
 }
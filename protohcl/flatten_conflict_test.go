@@ -0,0 +1,179 @@
+package protohcl
+
+import (
+	"testing"
+
+	"github.com/apparentlymart/go-protohcl/protohcl/internal/testschema"
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty-debug/ctydebug"
+	"github.com/zclconf/go-cty/cty"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func TestDecodeBodyFlattenConflict(t *testing.T) {
+	tests := map[string]struct {
+		msgName   protoreflect.Name
+		config    string
+		want      proto.Message
+		wantDiags []string
+	}{
+		"outer wins": {
+			"WithFlattenConflictOuterWins",
+			`
+				name    = "example"
+				timeout = 5
+			`,
+			&testschema.WithFlattenConflictOuterWins{
+				Legacy: &testschema.LegacySettings{
+					Name: "example",
+				},
+				Timeout: 5,
+			},
+			nil,
+		},
+		"outer wins with inner type": {
+			"WithFlattenConflictOuterWins",
+			`
+				timeout = "5s"
+			`,
+			&testschema.WithFlattenConflictOuterWins{
+				Legacy: &testschema.LegacySettings{},
+			},
+			[]string{
+				`Unsuitable attribute value`,
+			},
+		},
+		"inner wins": {
+			"WithFlattenConflictInnerWins",
+			`
+				name    = "example"
+				timeout = "5s"
+			`,
+			&testschema.WithFlattenConflictInnerWins{
+				Legacy: &testschema.LegacySettings{
+					Name:    "example",
+					Timeout: "5s",
+				},
+			},
+			nil,
+		},
+		"nested with prefix": {
+			"WithNestedFlattenConflict",
+			`
+				outer_timeout = 3
+			`,
+			&testschema.WithNestedFlattenConflict{
+				Outer: &testschema.WithFlattenConflictOuterWins{
+					Legacy:  &testschema.LegacySettings{},
+					Timeout: 3,
+				},
+			},
+			nil,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			desc := testschema.File_testschema_proto.Messages().ByName(test.msgName)
+			f, diags := hclsyntax.ParseConfig([]byte(test.config), "", hcl.InitialPos)
+			if diags.HasErrors() {
+				t.Fatalf("unexpected syntax errors: %s", diags.Error())
+			}
+
+			got, diags := DecodeBody(f.Body, desc, nil)
+			var gotDiags []string
+			for _, diag := range diags {
+				gotDiags = append(gotDiags, diag.Summary)
+			}
+			if diff := cmp.Diff(test.wantDiags, gotDiags); diff != "" {
+				t.Errorf("wrong diagnostics\n%s", diff)
+			}
+			if diff := cmp.Diff(test.want, got, protoCmpOpt); diff != "" {
+				t.Errorf("wrong result\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestObjectValueForMessageFlattenConflict(t *testing.T) {
+	tests := map[string]struct {
+		msg  proto.Message
+		want cty.Value
+	}{
+		"outer wins": {
+			&testschema.WithFlattenConflictOuterWins{
+				Legacy: &testschema.LegacySettings{
+					Name:    "example",
+					Timeout: "ignored",
+				},
+				Timeout: 5,
+			},
+			cty.ObjectVal(map[string]cty.Value{
+				"name":    cty.StringVal("example"),
+				"timeout": cty.NumberIntVal(5),
+			}),
+		},
+		"inner wins": {
+			&testschema.WithFlattenConflictInnerWins{
+				Timeout: 5,
+				Legacy: &testschema.LegacySettings{
+					Name:    "example",
+					Timeout: "5s",
+				},
+			},
+			cty.ObjectVal(map[string]cty.Value{
+				"name":    cty.StringVal("example"),
+				"timeout": cty.StringVal("5s"),
+			}),
+		},
+		"nested with prefix": {
+			&testschema.WithNestedFlattenConflict{
+				Outer: &testschema.WithFlattenConflictOuterWins{
+					Legacy: &testschema.LegacySettings{
+						Timeout: "ignored",
+					},
+					Timeout: 3,
+				},
+			},
+			cty.ObjectVal(map[string]cty.Value{
+				"outer_name":    cty.StringVal(""),
+				"outer_timeout": cty.NumberIntVal(3),
+			}),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := ObjectValueForMessage(test.msg)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(test.want, got, ctydebug.CmpOptions); diff != "" {
+				t.Errorf("wrong result\n%s", diff)
+			}
+
+			ty, err := ObjectTypeConstraintForMessageDesc(test.msg.ProtoReflect().Descriptor())
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !got.Type().Equals(ty) {
+				t.Errorf("value does not conform to type constraint\nvalue: %#v\ntype:  %#v", got.Type(), ty)
+			}
+		})
+	}
+}
+
+func TestGetFieldElemFlattenConflictErrors(t *testing.T) {
+	desc := testschema.File_testschema_proto.Messages().ByName("WithInvalidFlattenConflict")
+	_, err := GetFieldElem(desc.Fields().Get(0))
+	if err == nil {
+		t.Fatalf("unexpected success")
+	}
+	want := `unsupported protobuf schema in hcl.testschema.WithInvalidFlattenConflict.base: flatten_conflict is allowed only with flatten or flatten_prefix`
+	if got := err.Error(); got != want {
+		t.Errorf("wrong error\ngot:  %s\nwant: %s", got, want)
+	}
+}
@@ -0,0 +1,169 @@
+package protohcl
+
+import (
+	"testing"
+
+	"github.com/apparentlymart/go-protohcl/protohcl/internal/testschema"
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty-debug/ctydebug"
+	"github.com/zclconf/go-cty/cty"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func TestDecodeBodyFlattenPrefix(t *testing.T) {
+	tests := map[string]struct {
+		msgName   protoreflect.Name
+		config    string
+		want      proto.Message
+		wantDiags []string
+	}{
+		"both prefixes": {
+			"WithFlattenPrefix",
+			`
+				name                 = "proxy"
+				client_tls_cert_file = "client.pem"
+				server_tls_insecure  = true
+
+				server_tls_ca {
+					name = "root"
+				}
+			`,
+			&testschema.WithFlattenPrefix{
+				Name: "proxy",
+				Client: &testschema.TLSSettings{
+					CertFile: "client.pem",
+				},
+				Server: &testschema.TLSSettings{
+					Insecure: true,
+					Ca:       &testschema.WithStringAttr{Name: "root"},
+				},
+			},
+			nil,
+		},
+		"unprefixed name": {
+			"WithFlattenPrefix",
+			`
+				name      = "proxy"
+				cert_file = "client.pem"
+			`,
+			&testschema.WithFlattenPrefix{
+				Name:   "proxy",
+				Client: &testschema.TLSSettings{},
+				Server: &testschema.TLSSettings{},
+			},
+			[]string{
+				`Unsupported argument`,
+			},
+		},
+		"nested prefixes": {
+			"WithNestedFlattenPrefix",
+			`
+				proxy_name                = "proxy"
+				proxy_client_tls_insecure = true
+			`,
+			&testschema.WithNestedFlattenPrefix{
+				Proxy: &testschema.WithFlattenPrefix{
+					Name: "proxy",
+					Client: &testschema.TLSSettings{
+						Insecure: true,
+					},
+					Server: &testschema.TLSSettings{},
+				},
+			},
+			nil,
+		},
+		"prefixed block label": {
+			"WithNestedBlockFlattenPrefixLabel",
+			`
+				pet "Fido" {
+					pet_nickname = "Fi"
+				}
+			`,
+			&testschema.WithNestedBlockFlattenPrefixLabel{
+				Pet: []*testschema.WithFlattenPrefixLabel{
+					{
+						Base: &testschema.WithOneBlockLabel{
+							Name:     "Fido",
+							Nickname: "Fi",
+						},
+					},
+				},
+			},
+			nil,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			desc := testschema.File_testschema_proto.Messages().ByName(test.msgName)
+			f, diags := hclsyntax.ParseConfig([]byte(test.config), "", hcl.InitialPos)
+			if diags.HasErrors() {
+				t.Fatalf("unexpected syntax errors: %s", diags.Error())
+			}
+
+			got, diags := DecodeBody(f.Body, desc, nil)
+			var gotDiags []string
+			for _, diag := range diags {
+				gotDiags = append(gotDiags, diag.Summary)
+			}
+			if diff := cmp.Diff(test.wantDiags, gotDiags); diff != "" {
+				t.Errorf("wrong diagnostics\n%s", diff)
+			}
+			if diff := cmp.Diff(test.want, got, protoCmpOpt); diff != "" {
+				t.Errorf("wrong result\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestObjectValueForMessageFlattenPrefix(t *testing.T) {
+	msg := &testschema.WithFlattenPrefix{
+		Name: "proxy",
+		Client: &testschema.TLSSettings{
+			CertFile: "client.pem",
+		},
+		Server: &testschema.TLSSettings{
+			Insecure: true,
+		},
+	}
+	got, err := ObjectValueForMessage(msg)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	emptyCA := cty.ObjectVal(map[string]cty.Value{"name": cty.StringVal("")})
+	want := cty.ObjectVal(map[string]cty.Value{
+		"name":                 cty.StringVal("proxy"),
+		"client_tls_cert_file": cty.StringVal("client.pem"),
+		"client_tls_insecure":  cty.False,
+		"client_tls_ca":        emptyCA,
+		"server_tls_cert_file": cty.StringVal(""),
+		"server_tls_insecure":  cty.True,
+		"server_tls_ca":        emptyCA,
+	})
+	if diff := cmp.Diff(want, got, ctydebug.CmpOptions); diff != "" {
+		t.Errorf("wrong result\n%s", diff)
+	}
+
+	ty, err := ObjectTypeConstraintForMessageDesc(msg.ProtoReflect().Descriptor())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !got.Type().Equals(ty) {
+		t.Errorf("value does not conform to type constraint\nvalue: %#v\ntype:  %#v", got.Type(), ty)
+	}
+}
+
+func TestGetFieldElemFlattenPrefixErrors(t *testing.T) {
+	desc := testschema.File_testschema_proto.Messages().ByName("WithInvalidFlattenPrefix")
+	_, err := GetFieldElem(desc.Fields().Get(0))
+	if err == nil {
+		t.Fatalf("unexpected success")
+	}
+	want := `unsupported protobuf schema in hcl.testschema.WithInvalidFlattenPrefix.base: flatten_prefix "not valid" is not a valid HCL identifier`
+	if got := err.Error(); got != want {
+		t.Errorf("wrong error\ngot:  %s\nwant: %s", got, want)
+	}
+}
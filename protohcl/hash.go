@@ -0,0 +1,43 @@
+package protohcl
+
+import (
+	"crypto/sha256"
+	"fmt"
+
+	"github.com/zclconf/go-cty/cty"
+	ctymsgpack "github.com/zclconf/go-cty/cty/msgpack"
+	"google.golang.org/protobuf/proto"
+)
+
+// HashMessage returns a SHA-256 digest of the HCL-visible content of the
+// given message, which is suitable for detecting whether a configuration has
+// changed between runs.
+//
+// The hash is derived from the same object value that ObjectValueForMessage
+// would return, and so it disregards any fields that have no HCL annotations,
+// and also disregards details that arise only from the protobuf encoding,
+// such as the raw encoding used for a dynamic attribute or the exact string
+// formatting of a number stored in a string field. Because the input is a
+// decoded message, the result is also naturally insensitive to the
+// formatting and attribute ordering of its source configuration.
+//
+// The hash algorithm is an implementation detail that may change in future
+// versions of protohcl, so callers should not persist the result for long
+// periods or compare hashes produced by different versions of this library.
+func HashMessage(msg proto.Message) ([]byte, error) {
+	obj, err := ObjectValueForMessage(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	// The MessagePack encoding of a value of dynamic type includes its type
+	// along with its value, so values that differ only in type will also
+	// hash differently. The encoding uses a canonical element order for
+	// objects, maps, and sets.
+	raw, err := ctymsgpack.Marshal(obj, cty.DynamicPseudoType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize value for hashing: %w", err)
+	}
+	sum := sha256.Sum256(raw)
+	return sum[:], nil
+}
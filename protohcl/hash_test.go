@@ -0,0 +1,69 @@
+package protohcl
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/apparentlymart/go-protohcl/protohcl/internal/testschema"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestHashMessage(t *testing.T) {
+	tests := map[string]struct {
+		a, b      proto.Message
+		wantEqual bool
+	}{
+		"identical": {
+			&testschema.WithStringAttr{Name: "Jackson"},
+			&testschema.WithStringAttr{Name: "Jackson"},
+			true,
+		},
+		"different string": {
+			&testschema.WithStringAttr{Name: "Jackson"},
+			&testschema.WithStringAttr{Name: "Snakob"},
+			false,
+		},
+		"number formatting in string field": {
+			&testschema.WithNumberAttrAsString{Num: "1.50"},
+			&testschema.WithNumberAttrAsString{Num: "1.5"},
+			true,
+		},
+		"set element order": {
+			&testschema.WithStringSetAttr{Names: []string{"Jackson", "Rufus"}},
+			&testschema.WithStringSetAttr{Names: []string{"Rufus", "Jackson"}},
+			true,
+		},
+		"list element order": {
+			&testschema.WithStringListAttr{Names: []string{"Jackson", "Rufus"}},
+			&testschema.WithStringListAttr{Names: []string{"Rufus", "Jackson"}},
+			false,
+		},
+		"raw encoding formatting": {
+			&testschema.WithRawDynamicAttr{Raw: []byte(`{"value":"hello","type":"string"}`)},
+			&testschema.WithRawDynamicAttr{Raw: []byte(`{"type": "string", "value": "hello"}`)},
+			true,
+		},
+		"raw value types": {
+			&testschema.WithRawDynamicAttr{Raw: []byte(`{"value":"1","type":"string"}`)},
+			&testschema.WithRawDynamicAttr{Raw: []byte(`{"value":1,"type":"number"}`)},
+			false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			hashA, err := HashMessage(test.a)
+			if err != nil {
+				t.Fatalf("unexpected error for a: %s", err)
+			}
+			hashB, err := HashMessage(test.b)
+			if err != nil {
+				t.Fatalf("unexpected error for b: %s", err)
+			}
+
+			if got := bytes.Equal(hashA, hashB); got != test.wantEqual {
+				t.Errorf("wrong result\na: %x\nb: %x\nwant equal: %t", hashA, hashB, test.wantEqual)
+			}
+		})
+	}
+}
@@ -0,0 +1,215 @@
+// Package hcldecspec converts between HCL-annotated protobuf message
+// descriptors and the spec file format used by the hcldec command line tool
+// that is distributed with HCL.
+//
+// This allows tools built around hcldec spec files to consume schemas
+// defined using protobuf annotations, and allows existing spec files to be
+// used as a starting point for protobuf schemas via package schemabuilder.
+//
+// The two formats don't have exactly the same capabilities, so neither
+// direction of conversion is possible for all inputs. The conversion
+// functions return errors for any constructs they can't represent.
+package hcldecspec
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/apparentlymart/go-protohcl/protohcl"
+	"github.com/apparentlymart/go-protohcl/protohcl/protohclext"
+	"github.com/hashicorp/hcl/v2/ext/typeexpr"
+	"github.com/zclconf/go-cty/cty"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// SpecJSON returns an hcldec spec file, in HCL's JSON syntax, that
+// describes the body represented by the given message descriptor.
+//
+// The result is an "object" spec whose properties correspond to the
+// attributes and nested block types of the message, including those of any
+// flattened messages. Nested block types with labels are represented as
+// "block_map" specs, which produce nested objects keyed by the label values
+// rather than a list of objects. Attributes whose type constraints are
+// object types with optional attributes have the type "any" in the result,
+// because the spec format can't represent optional attributes. Any
+// (hcl.block).aliases are left out, because a spec can't merge blocks of
+// several types into one property, and so the result accepts only the main
+// type name of each nested block type.
+//
+// SpecJSON returns an error if the message descriptor has invalid HCL
+// annotations, if it's recursive, or if it declares a label-annotated field
+// for a non-repeated nested block type, because hcldec has no way to
+// represent labels for a singleton block. It also returns an error for a map
+// field that uses (hcl.block).map_key_attr, or for a google.protobuf.Any
+// field that uses (hcl.block).any_discriminator, for which hcldec has no
+// equivalents.
+func SpecJSON(desc protoreflect.MessageDescriptor) ([]byte, error) {
+	obj, err := objectSpec(desc, map[protoreflect.FullName]struct{}{})
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(map[string]interface{}{
+		"object": obj,
+	}, "", "  ")
+}
+
+// objectSpec returns the content of an "object" spec block, as a value
+// suitable for serializing as HCL JSON, that describes the given message.
+func objectSpec(desc protoreflect.MessageDescriptor, visiting map[protoreflect.FullName]struct{}) (map[string]interface{}, error) {
+	if _, exists := visiting[desc.FullName()]; exists {
+		return nil, fmt.Errorf("%s: recursive message types can't be represented in an hcldec spec", desc.FullName())
+	}
+	visiting[desc.FullName()] = struct{}{}
+	defer delete(visiting, desc.FullName())
+
+	ret := make(map[string]interface{})
+	err := addObjectSpecContent(ret, desc, visiting)
+	return ret, err
+}
+
+func addObjectSpecContent(into map[string]interface{}, desc protoreflect.MessageDescriptor, visiting map[protoreflect.FullName]struct{}) error {
+	fields := desc.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		elem, err := protohcl.GetFieldElem(field)
+		if err != nil {
+			return err
+		}
+
+		switch elem := elem.(type) {
+		case protohcl.FieldAttribute:
+			ty, diags := elem.TypeConstraint()
+			if diags.HasErrors() {
+				return fmt.Errorf("%s: invalid type constraint: %s", field.FullName(), diags.Error())
+			}
+			if typeHasOptionalAttrs(ty) {
+				ty = cty.DynamicPseudoType
+			}
+			spec := map[string]interface{}{
+				// In HCL's JSON syntax a string is a template, so we must
+				// use an interpolation sequence to write a type expression.
+				"type": "${" + typeexpr.TypeString(ty) + "}",
+			}
+			if elem.Required {
+				spec["required"] = true
+			}
+			addLabeledSpec(into, "attr", elem.Name, spec)
+
+		case protohcl.FieldNestedBlockType:
+			if elem.AnyDiscriminator != "" {
+				return fmt.Errorf("%s: hcldec spec can't represent a block type whose content depends on an attribute", field.FullName())
+			}
+			obj, err := objectSpec(elem.Nested, visiting)
+			if err != nil {
+				return err
+			}
+			labels, err := labelNames(elem.Nested)
+			if err != nil {
+				return err
+			}
+			if elem.MapKeyLabel != "" {
+				labels = append([]string{elem.MapKeyLabel}, labels...)
+			}
+			spec := map[string]interface{}{
+				"object": obj,
+			}
+			switch {
+			case elem.MapKeyAttr != "":
+				return fmt.Errorf("%s: hcldec spec can't represent a block map keyed by an attribute", field.FullName())
+			case len(labels) != 0 && !elem.Repeated:
+				return fmt.Errorf("%s: hcldec spec can't represent labels for a non-repeated block type", field.FullName())
+			case len(labels) != 0:
+				spec["labels"] = labels
+				addLabeledSpec(into, "block_map", elem.TypeName, spec)
+			case elem.Repeated && elem.CollectionKind == protohclext.NestedBlock_SET:
+				addLabeledSpec(into, "block_set", elem.TypeName, spec)
+			case elem.Repeated:
+				addLabeledSpec(into, "block_list", elem.TypeName, spec)
+			default:
+				addLabeledSpec(into, "block", elem.TypeName, spec)
+			}
+
+		case protohcl.FieldFlattened:
+			// We build the nested message's content separately so that we
+			// can add the flatten prefix, if any, to the names it declares.
+			nested := make(map[string]interface{})
+			err := addObjectSpecContent(nested, elem.Nested, visiting)
+			if err != nil {
+				return err
+			}
+			for blockType, byLabel := range nested {
+				for label, spec := range byLabel.(map[string]interface{}) {
+					name := elem.Prefix + label
+					if _, shadowed := elem.Shadowed[name]; shadowed && blockType == "attr" {
+						continue // the containing message takes this name
+					}
+					addLabeledSpec(into, blockType, name, spec.(map[string]interface{}))
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// addLabeledSpec adds a labeled spec block of the given type to the given
+// HCL JSON object, which represents the body of an "object" spec.
+func addLabeledSpec(into map[string]interface{}, blockType, label string, spec map[string]interface{}) {
+	byLabel, ok := into[blockType].(map[string]interface{})
+	if !ok {
+		byLabel = make(map[string]interface{})
+		into[blockType] = byLabel
+	}
+	byLabel[label] = spec
+}
+
+// labelNames returns the names of the labels declared by the given message,
+// including those of any flattened messages, in declaration order.
+func labelNames(desc protoreflect.MessageDescriptor) ([]string, error) {
+	var ret []string
+	fields := desc.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		elem, err := protohcl.GetFieldElem(fields.Get(i))
+		if err != nil {
+			return nil, err
+		}
+		switch elem := elem.(type) {
+		case protohcl.FieldBlockLabel:
+			ret = append(ret, elem.Name)
+		case protohcl.FieldFlattened:
+			names, err := labelNames(elem.Nested)
+			if err != nil {
+				return nil, err
+			}
+			for _, name := range names {
+				ret = append(ret, elem.Prefix+name)
+			}
+		}
+	}
+	return ret, nil
+}
+
+func typeHasOptionalAttrs(ty cty.Type) bool {
+	switch {
+	case ty.IsObjectType():
+		if len(ty.OptionalAttributes()) != 0 {
+			return true
+		}
+		for _, aty := range ty.AttributeTypes() {
+			if typeHasOptionalAttrs(aty) {
+				return true
+			}
+		}
+		return false
+	case ty.IsTupleType():
+		for _, ety := range ty.TupleElementTypes() {
+			if typeHasOptionalAttrs(ety) {
+				return true
+			}
+		}
+		return false
+	case ty.IsCollectionType():
+		return typeHasOptionalAttrs(ty.ElementType())
+	default:
+		return false
+	}
+}
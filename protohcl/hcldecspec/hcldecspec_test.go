@@ -0,0 +1,438 @@
+package hcldecspec
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/apparentlymart/go-protohcl/protohcl/internal/testschema"
+	"github.com/apparentlymart/go-protohcl/protohcl/protohclext"
+	"github.com/apparentlymart/go-protohcl/protohcl/schemabuilder"
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/zclconf/go-cty/cty"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func TestSpecJSON(t *testing.T) {
+	f := schemabuilder.NewFile("hcldecspec_test.proto", "hcldecspec.test")
+	common := f.AddMessage("Common").
+		AddAttribute("description", cty.String)
+	thing := f.AddMessage("Thing").
+		AddLabel("name").
+		AddAttribute("enabled", cty.Bool)
+	tag := f.AddMessage("Tag").
+		AddAttribute("value", cty.String, schemabuilder.Required)
+	f.AddMessage("Config").
+		AddAttribute("name", cty.String, schemabuilder.Required).
+		AddAttribute("ports", cty.List(cty.Number)).
+		AddAttribute("extra", cty.DynamicPseudoType).
+		AddFlatten("common", common).
+		AddBlock("thing", thing, schemabuilder.Repeated).
+		AddBlock("tag", tag, schemabuilder.CollectionKind(protohclext.NestedBlock_SET)).
+		AddBlock("common_settings", common)
+	fileDesc, err := f.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := SpecJSON(fileDesc.Messages().ByName("Config"))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := `{
+  "object": {
+    "attr": {
+      "description": {
+        "type": "${string}"
+      },
+      "extra": {
+        "type": "${any}"
+      },
+      "name": {
+        "required": true,
+        "type": "${string}"
+      },
+      "ports": {
+        "type": "${list(number)}"
+      }
+    },
+    "block": {
+      "common_settings": {
+        "object": {
+          "attr": {
+            "description": {
+              "type": "${string}"
+            }
+          }
+        }
+      }
+    },
+    "block_map": {
+      "thing": {
+        "labels": [
+          "name"
+        ],
+        "object": {
+          "attr": {
+            "enabled": {
+              "type": "${bool}"
+            }
+          }
+        }
+      }
+    },
+    "block_set": {
+      "tag": {
+        "object": {
+          "attr": {
+            "value": {
+              "required": true,
+              "type": "${string}"
+            }
+          }
+        }
+      }
+    }
+  }
+}`
+	if diff := cmp.Diff(want, string(got)); diff != "" {
+		t.Errorf("wrong result\n%s", diff)
+	}
+
+	// The spec format can't represent optional object attributes, so an
+	// attribute whose type includes them can only be described as "any".
+	got, err = SpecJSON(testschema.File_testschema_proto.Messages().ByName("WithListOfObjectsAttr"))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want = `{
+  "object": {
+    "attr": {
+      "items": {
+        "type": "${any}"
+      }
+    }
+  }
+}`
+	if diff := cmp.Diff(want, string(got)); diff != "" {
+		t.Errorf("wrong result for optional attributes\n%s", diff)
+	}
+}
+
+func TestSpecJSONFlattenPrefix(t *testing.T) {
+	f := schemabuilder.NewFile("hcldecspec_prefix_test.proto", "hcldecspec.prefix")
+	ca := f.AddMessage("CA").
+		AddAttribute("file", cty.String)
+	tls := f.AddMessage("TLS").
+		AddAttribute("insecure", cty.Bool).
+		AddBlock("ca", ca)
+	f.AddMessage("Config").
+		AddFlattenPrefix("client", tls, "client_").
+		AddFlattenPrefix("server", tls, "server_")
+	fileDesc, err := f.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := SpecJSON(fileDesc.Messages().ByName("Config"))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := `{
+  "object": {
+    "attr": {
+      "client_insecure": {
+        "type": "${bool}"
+      },
+      "server_insecure": {
+        "type": "${bool}"
+      }
+    },
+    "block": {
+      "client_ca": {
+        "object": {
+          "attr": {
+            "file": {
+              "type": "${string}"
+            }
+          }
+        }
+      },
+      "server_ca": {
+        "object": {
+          "attr": {
+            "file": {
+              "type": "${string}"
+            }
+          }
+        }
+      }
+    }
+  }
+}`
+	if diff := cmp.Diff(want, string(got)); diff != "" {
+		t.Errorf("wrong result\n%s", diff)
+	}
+}
+
+func TestSpecJSONErrors(t *testing.T) {
+	f := schemabuilder.NewFile("hcldecspec_errors_test.proto", "hcldecspec.errors")
+	labeled := f.AddMessage("Labeled").
+		AddLabel("name")
+	f.AddMessage("LabeledSingleton").
+		AddBlock("thing", labeled)
+	recursive := f.AddMessage("Recursive")
+	recursive.AddBlock("child", recursive, schemabuilder.Repeated)
+	fileDesc, err := f.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[protoreflect.Name]string{
+		"LabeledSingleton": `hcldecspec.errors.LabeledSingleton.thing: hcldec spec can't represent labels for a non-repeated block type`,
+		"Recursive":        `hcldecspec.errors.Recursive: recursive message types can't be represented in an hcldec spec`,
+	}
+
+	for name, want := range tests {
+		t.Run(string(name), func(t *testing.T) {
+			_, err := SpecJSON(fileDesc.Messages().ByName(name))
+			if err == nil {
+				t.Fatalf("unexpected success")
+			}
+			if got := err.Error(); got != want {
+				t.Errorf("wrong error\ngot:  %s\nwant: %s", got, want)
+			}
+		})
+	}
+
+	t.Run("map keyed by attribute", func(t *testing.T) {
+		_, err := SpecJSON(testschema.File_testschema_proto.Messages().ByName("WithMapOfBlocksKeyedByAttr"))
+		if err == nil {
+			t.Fatalf("unexpected success")
+		}
+		want := `hcl.testschema.WithMapOfBlocksKeyedByAttr.pets: hcldec spec can't represent a block map keyed by an attribute`
+		if got := err.Error(); got != want {
+			t.Errorf("wrong error\ngot:  %s\nwant: %s", got, want)
+		}
+	})
+
+	t.Run("block content selected by attribute", func(t *testing.T) {
+		_, err := SpecJSON(testschema.File_testschema_proto.Messages().ByName("WithAnyBlock"))
+		if err == nil {
+			t.Fatalf("unexpected success")
+		}
+		want := `hcl.testschema.WithAnyBlock.storage: hcldec spec can't represent a block type whose content depends on an attribute`
+		if got := err.Error(); got != want {
+			t.Errorf("wrong error\ngot:  %s\nwant: %s", got, want)
+		}
+	})
+}
+
+func TestAddMessage(t *testing.T) {
+	// The same spec in both HCL syntaxes should produce the same schema,
+	// which should then convert back into an equivalent spec.
+	native := `
+object {
+  attr "name" {
+    type     = string
+    required = true
+  }
+  attr "ports" {
+    type = list(number)
+  }
+  attr "labels" {
+    name = "tags"
+    type = map(string)
+  }
+  block_map "thing" {
+    labels = ["name"]
+    object {
+      attr "enabled" {
+        type = bool
+      }
+    }
+  }
+  block_set "tag_value" {
+    block_type = "tag"
+    object {
+      attr "value" {
+        type = string
+      }
+    }
+  }
+}
+`
+	json := `{
+  "object": {
+    "attr": {
+      "name": {"type": "${string}", "required": true},
+      "ports": {"type": "${list(number)}"},
+      "labels": {"name": "tags", "type": "${map(string)}"}
+    },
+    "block_map": {
+      "thing": {
+        "labels": ["name"],
+        "object": {"attr": {"enabled": {"type": "${bool}"}}}
+      }
+    },
+    "block_set": {
+      "tag_value": {
+        "block_type": "tag",
+        "object": {"attr": {"value": {"type": "${string}"}}}
+      }
+    }
+  }
+}`
+	want := `{
+  "object": {
+    "attr": {
+      "name": {
+        "required": true,
+        "type": "${string}"
+      },
+      "ports": {
+        "type": "${list(number)}"
+      },
+      "tags": {
+        "type": "${map(string)}"
+      }
+    },
+    "block_map": {
+      "thing": {
+        "labels": [
+          "name"
+        ],
+        "object": {
+          "attr": {
+            "enabled": {
+              "type": "${bool}"
+            }
+          }
+        }
+      }
+    },
+    "block_set": {
+      "tag": {
+        "object": {
+          "attr": {
+            "value": {
+              "type": "${string}"
+            }
+          }
+        }
+      }
+    }
+  }
+}`
+
+	for filename, src := range map[string]string{"spec.hcldec": native, "spec.json": json} {
+		t.Run(filename, func(t *testing.T) {
+			body := parseSpec(t, filename, src)
+			f := schemabuilder.NewFile("hcldecspec_add_test.proto", "hcldecspec.add")
+			msg, diags := AddMessage(f, "Config", body)
+			if diags.HasErrors() {
+				t.Fatalf("unexpected errors: %s", diags.Error())
+			}
+			if got, want := msg.FullName(), protoreflect.FullName("hcldecspec.add.Config"); got != want {
+				t.Errorf("wrong message name %s; want %s", got, want)
+			}
+			fileDesc, err := f.Build()
+			if err != nil {
+				t.Fatalf("invalid schema: %s", err)
+			}
+			if fileDesc.Messages().ByName("ConfigTag") == nil {
+				t.Errorf("no ConfigTag message in result")
+			}
+
+			got, err := SpecJSON(fileDesc.Messages().ByName("Config"))
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(want, string(got)); diff != "" {
+				t.Errorf("wrong result\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestAddMessageErrors(t *testing.T) {
+	tests := map[string]struct {
+		src  string
+		want string
+	}{
+		"root not object": {
+			`attr {
+  name = "a"
+  type = string
+}`,
+			`Unsupported root spec`,
+		},
+		"literal": {
+			`object {
+  literal "a" {
+    value = "hello"
+  }
+}`,
+			`Unsupported spec type`,
+		},
+		"default": {
+			`object {
+  attr "a" {
+    type    = string
+    default = "x"
+  }
+}`,
+			`Unsupported argument`,
+		},
+		"block without object": {
+			`object {
+  block "a" {
+    attr {
+      name = "b"
+      type = string
+    }
+  }
+}`,
+			`Unsupported nested spec`,
+		},
+		"invalid type": {
+			`object {
+  attr "a" {
+    type = "string"
+  }
+}`,
+			`Invalid type expression`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			body := parseSpec(t, "spec.hcldec", test.src)
+			f := schemabuilder.NewFile("hcldecspec_errors_test.proto", "hcldecspec.errors")
+			_, diags := AddMessage(f, "Config", body)
+			if !diags.HasErrors() {
+				t.Fatalf("unexpected success")
+			}
+			if got := diags[0].Summary; got != test.want {
+				t.Errorf("wrong error summary %q; want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func parseSpec(t *testing.T, filename, src string) hcl.Body {
+	t.Helper()
+	parser := hclparse.NewParser()
+	var f *hcl.File
+	var diags hcl.Diagnostics
+	if strings.HasSuffix(filename, ".json") {
+		f, diags = parser.ParseJSON([]byte(src), filename)
+	} else {
+		f, diags = parser.ParseHCL([]byte(src), filename)
+	}
+	if diags.HasErrors() {
+		t.Fatalf("invalid spec: %s", diags.Error())
+	}
+	return f.Body
+}
@@ -0,0 +1,289 @@
+package hcldecspec
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/apparentlymart/go-protohcl/protohcl/protohclext"
+	"github.com/apparentlymart/go-protohcl/protohcl/schemabuilder"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/ext/typeexpr"
+	"github.com/hashicorp/hcl/v2/gohcl"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// AddMessage declares a new message with the given name in the given file,
+// with fields derived from the given hcldec spec file body, which may be
+// written in either HCL native syntax or HCL's JSON syntax.
+//
+// The root of the spec must be a single "object" spec. Each nested block
+// spec inside it becomes an additional message in the same file, whose name
+// is the name of its parent message followed by the block type name in
+// camel case.
+//
+// Only the subset of the spec file language that has an equivalent in the
+// protohcl annotations is supported: "attr", "block", "block_list",
+// "block_set", and "block_map" specs whose nested spec is an "object" spec.
+// AddMessage returns error diagnostics for any other constructs, in which
+// case the file may contain some incomplete messages and should be
+// discarded.
+func AddMessage(f *schemabuilder.File, name protoreflect.Name, body hcl.Body) (*schemabuilder.Message, hcl.Diagnostics) {
+	content, diags := body.Content(specSchema)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	if len(content.Blocks) != 1 || content.Blocks[0].Type != "object" {
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Unsupported root spec",
+			Detail:   "The root of the spec must be a single object spec, describing the top-level body.",
+			Subject:  body.MissingItemRange().Ptr(),
+		})
+		return nil, diags
+	}
+
+	msg := f.AddMessage(name)
+	moreDiags := addObjectSpecFields(f, msg, name, content.Blocks[0].Body)
+	diags = append(diags, moreDiags...)
+	return msg, diags
+}
+
+func addObjectSpecFields(f *schemabuilder.File, msg *schemabuilder.Message, msgName protoreflect.Name, body hcl.Body) hcl.Diagnostics {
+	content, diags := body.Content(specSchemaLabelled)
+	for _, block := range content.Blocks {
+		switch block.Type {
+		case "attr":
+			diags = append(diags, addAttrSpecField(msg, block)...)
+		case "block", "block_list", "block_set", "block_map":
+			diags = append(diags, addBlockSpecField(f, msg, msgName, block)...)
+		default:
+			diags = append(diags, unsupportedSpecDiag(block))
+		}
+	}
+	return diags
+}
+
+func addAttrSpecField(msg *schemabuilder.Message, block *hcl.Block) hcl.Diagnostics {
+	type content struct {
+		Name     *string        `hcl:"name"`
+		Type     hcl.Expression `hcl:"type"`
+		Required *bool          `hcl:"required"`
+	}
+	var args content
+	diags := gohcl.DecodeBody(block.Body, nil, &args)
+	if diags.HasErrors() {
+		return diags
+	}
+
+	name := block.Labels[0]
+	if args.Name != nil {
+		name = *args.Name
+	}
+	ty, moreDiags := evalTypeExpr(args.Type)
+	diags = append(diags, moreDiags...)
+	if moreDiags.HasErrors() {
+		return diags
+	}
+
+	var opts []schemabuilder.AttributeOption
+	if args.Required != nil && *args.Required {
+		opts = append(opts, schemabuilder.Required)
+	}
+	msg.AddAttribute(name, ty, opts...)
+	return diags
+}
+
+func addBlockSpecField(f *schemabuilder.File, msg *schemabuilder.Message, msgName protoreflect.Name, block *hcl.Block) hcl.Diagnostics {
+	type content struct {
+		TypeName *string   `hcl:"block_type"`
+		Labels   *[]string `hcl:"labels"`
+		Nested   hcl.Body  `hcl:",remain"`
+	}
+	var args content
+	diags := gohcl.DecodeBody(block.Body, nil, &args)
+	if diags.HasErrors() {
+		return diags
+	}
+	if args.Labels != nil && block.Type != "block_map" {
+		// gohcl would've rejected this already if we were using a separate
+		// struct for each spec type, so we'll mimic its error message.
+		return append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Unsupported argument",
+			Detail:   fmt.Sprintf("An argument named \"labels\" is not expected in a %s spec.", block.Type),
+			Subject:  block.Body.MissingItemRange().Ptr(),
+		})
+	}
+
+	typeName := block.Labels[0]
+	if args.TypeName != nil {
+		typeName = *args.TypeName
+	}
+
+	nestedContent, moreDiags := args.Nested.Content(specSchema)
+	diags = append(diags, moreDiags...)
+	if moreDiags.HasErrors() {
+		return diags
+	}
+	if len(nestedContent.Blocks) != 1 || nestedContent.Blocks[0].Type != "object" {
+		return append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Unsupported nested spec",
+			Detail:   "A block spec must have exactly one nested object spec, describing the content of each block.",
+			Subject:  block.DefRange.Ptr(),
+		})
+	}
+
+	nestedName := msgName + protoreflect.Name(camelCase(typeName))
+	nested := f.AddMessage(nestedName)
+	var labels []string
+	if args.Labels != nil {
+		labels = *args.Labels
+	}
+	for _, label := range labels {
+		nested.AddLabel(label)
+	}
+	diags = append(diags, addObjectSpecFields(f, nested, nestedName, nestedContent.Blocks[0].Body)...)
+
+	switch block.Type {
+	case "block":
+		msg.AddBlock(typeName, nested)
+	case "block_set":
+		msg.AddBlock(typeName, nested, schemabuilder.CollectionKind(protohclext.NestedBlock_SET))
+	default:
+		msg.AddBlock(typeName, nested, schemabuilder.Repeated)
+	}
+	return diags
+}
+
+func unsupportedSpecDiag(block *hcl.Block) *hcl.Diagnostic {
+	return &hcl.Diagnostic{
+		Severity: hcl.DiagError,
+		Summary:  "Unsupported spec type",
+		Detail:   fmt.Sprintf("There is no protobuf schema equivalent for a %s spec.", block.Type),
+		Subject:  block.DefRange.Ptr(),
+	}
+}
+
+func evalTypeExpr(expr hcl.Expression) (cty.Type, hcl.Diagnostics) {
+	result, diags := expr.Value(typeEvalCtx)
+	if diags.HasErrors() {
+		return cty.DynamicPseudoType, diags
+	}
+	if result.IsNull() {
+		return cty.DynamicPseudoType, diags
+	}
+	if !result.Type().Equals(typeexpr.TypeConstraintType) {
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid type expression",
+			Detail:   fmt.Sprintf("A type is required, not %s.", result.Type().FriendlyName()),
+			Subject:  expr.Range().Ptr(),
+		})
+		return cty.DynamicPseudoType, diags
+	}
+	return typeexpr.TypeConstraintFromVal(result), diags
+}
+
+// camelCase converts an HCL-style name, using underscores to separate
+// words, into a protobuf-style message name.
+func camelCase(name string) string {
+	var buf strings.Builder
+	for _, word := range strings.Split(name, "_") {
+		if word == "" {
+			continue
+		}
+		buf.WriteString(strings.ToUpper(word[:1]))
+		buf.WriteString(word[1:])
+	}
+	return buf.String()
+}
+
+// specBlockTypes are all of the spec types that the hcldec spec file
+// format supports, including those that have no protobuf equivalent, so
+// that we can return a more specific error for the unsupported ones.
+var specBlockTypes = []string{
+	"object",
+	"array",
+	"literal",
+	"attr",
+	"block",
+	"block_list",
+	"block_map",
+	"block_set",
+	"block_attrs",
+	"default",
+	"transform",
+}
+
+var specSchema, specSchemaLabelled *hcl.BodySchema
+
+func init() {
+	specSchema = &hcl.BodySchema{}
+	specSchemaLabelled = &hcl.BodySchema{}
+	for _, name := range specBlockTypes {
+		specSchema.Blocks = append(specSchema.Blocks, hcl.BlockHeaderSchema{
+			Type: name,
+		})
+		specSchemaLabelled.Blocks = append(specSchemaLabelled.Blocks, hcl.BlockHeaderSchema{
+			Type:       name,
+			LabelNames: []string{"key"},
+		})
+	}
+}
+
+var typeEvalCtx = &hcl.EvalContext{
+	Variables: map[string]cty.Value{
+		"string": typeexpr.TypeConstraintVal(cty.String),
+		"bool":   typeexpr.TypeConstraintVal(cty.Bool),
+		"number": typeexpr.TypeConstraintVal(cty.Number),
+		"any":    typeexpr.TypeConstraintVal(cty.DynamicPseudoType),
+	},
+	Functions: map[string]function.Function{
+		"list": collectionTypeFunc(cty.List),
+		"set":  collectionTypeFunc(cty.Set),
+		"map":  collectionTypeFunc(cty.Map),
+		"tuple": function.New(&function.Spec{
+			Params: []function.Parameter{
+				{Name: "element_types", Type: cty.List(typeexpr.TypeConstraintType)},
+			},
+			Type: function.StaticReturnType(typeexpr.TypeConstraintType),
+			Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+				var etys []cty.Type
+				for it := args[0].ElementIterator(); it.Next(); {
+					_, v := it.Element()
+					etys = append(etys, typeexpr.TypeConstraintFromVal(v))
+				}
+				return typeexpr.TypeConstraintVal(cty.Tuple(etys)), nil
+			},
+		}),
+		"object": function.New(&function.Spec{
+			Params: []function.Parameter{
+				{Name: "attribute_types", Type: cty.Map(typeexpr.TypeConstraintType)},
+			},
+			Type: function.StaticReturnType(typeexpr.TypeConstraintType),
+			Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+				atys := make(map[string]cty.Type)
+				for it := args[0].ElementIterator(); it.Next(); {
+					k, v := it.Element()
+					atys[k.AsString()] = typeexpr.TypeConstraintFromVal(v)
+				}
+				return typeexpr.TypeConstraintVal(cty.Object(atys)), nil
+			},
+		}),
+	},
+}
+
+func collectionTypeFunc(ctor func(cty.Type) cty.Type) function.Function {
+	return function.New(&function.Spec{
+		Params: []function.Parameter{
+			{Name: "element_type", Type: typeexpr.TypeConstraintType},
+		},
+		Type: function.StaticReturnType(typeexpr.TypeConstraintType),
+		Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+			return typeexpr.TypeConstraintVal(ctor(typeexpr.TypeConstraintFromVal(args[0]))), nil
+		},
+	})
+}
@@ -0,0 +1,201 @@
+package protohcl
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/apparentlymart/go-protohcl/protohcl/protohclext"
+	hcl "github.com/hashicorp/hcl/v2"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+const inferredAttributesSummary = "Schema has no HCL annotations"
+
+// hasHCLAnnotations returns true if the given message type or any of its
+// fields use any of the HCL extension options at all.
+func hasHCLAnnotations(desc protoreflect.MessageDescriptor) bool {
+	if opts, ok := desc.Options().(*descriptorpb.MessageOptions); ok && proto.HasExtension(opts, protohclext.E_Message) {
+		return true
+	}
+	fields := desc.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		opts, ok := fields.Get(i).Options().(*descriptorpb.FieldOptions)
+		if !ok {
+			continue
+		}
+		for _, ext := range []protoreflect.ExtensionType{protohclext.E_Attr, protohclext.E_Block, protohclext.E_Label, protohclext.E_Flatten, protohclext.E_FlattenPrefix} {
+			if proto.HasExtension(opts, ext) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// canInferAttribute returns true if the given field has a type that
+// protohcl can decode from an attribute without any other HCL options.
+func canInferAttribute(field protoreflect.FieldDescriptor) bool {
+	if field.IsMap() {
+		if field.MapKey().Kind() != protoreflect.StringKind {
+			return false
+		}
+		field = field.MapValue()
+	}
+	switch field.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind, protoreflect.BytesKind:
+		// Message types would need annotations of their own, and bytes
+		// fields need a raw mode.
+		return false
+	default:
+		return true
+	}
+}
+
+// inferredAttributesDesc returns a copy of the given message type where each
+// field that protohcl can decode without any other options is annotated as
+// an optional attribute named after the field's JSON name, along with the
+// names of any fields that have no equivalent attribute.
+//
+// The copy has the same full names as the original, so a message of the
+// original type can be populated from a message of the copy by round-trip
+// through the protobuf wire format.
+func inferredAttributesDesc(desc protoreflect.MessageDescriptor) (protoreflect.MessageDescriptor, []protoreflect.Name, error) {
+	fileProto := protodesc.ToFileDescriptorProto(desc.ParentFile())
+	msgProto := findMessageProto(fileProto, desc.FullName())
+	if msgProto == nil {
+		// Should never happen, because we just generated fileProto from
+		// the file containing desc.
+		return nil, nil, fmt.Errorf("can't find %s in its own file", desc.FullName())
+	}
+
+	var skipped []protoreflect.Name
+	for _, fieldProto := range msgProto.Field {
+		field := desc.Fields().ByNumber(protoreflect.FieldNumber(fieldProto.GetNumber()))
+		if !canInferAttribute(field) {
+			skipped = append(skipped, field.Name())
+			continue
+		}
+		if fieldProto.Options == nil {
+			fieldProto.Options = &descriptorpb.FieldOptions{}
+		}
+		proto.SetExtension(fieldProto.Options, protohclext.E_Attr, &protohclext.Attribute{
+			Name: field.JSONName(),
+		})
+	}
+
+	deps := new(protoregistry.Files)
+	registerFileImports(deps, desc.ParentFile())
+	file, err := protodesc.NewFile(fileProto, deps)
+	if err != nil {
+		return nil, nil, err
+	}
+	return findMessageDesc(file.Messages(), desc.FullName()), skipped, nil
+}
+
+// registerFileImports registers all of the files that the given file imports
+// into the given registry, directly or indirectly.
+func registerFileImports(files *protoregistry.Files, file protoreflect.FileDescriptor) {
+	imports := file.Imports()
+	for i := 0; i < imports.Len(); i++ {
+		imp := imports.Get(i).FileDescriptor
+		if _, err := files.FindFileByPath(imp.Path()); err == nil {
+			continue
+		}
+		// The only possible error is a conflict with a file we already
+		// registered, in which case we'll let protodesc.NewFile report
+		// whatever problem that causes.
+		_ = files.RegisterFile(imp)
+		registerFileImports(files, imp)
+	}
+}
+
+func findMessageProto(file *descriptorpb.FileDescriptorProto, name protoreflect.FullName) *descriptorpb.DescriptorProto {
+	prefix := file.GetPackage()
+	var find func(msgs []*descriptorpb.DescriptorProto, prefix string) *descriptorpb.DescriptorProto
+	find = func(msgs []*descriptorpb.DescriptorProto, prefix string) *descriptorpb.DescriptorProto {
+		for _, msg := range msgs {
+			fullName := msg.GetName()
+			if prefix != "" {
+				fullName = prefix + "." + fullName
+			}
+			if protoreflect.FullName(fullName) == name {
+				return msg
+			}
+			if strings.HasPrefix(string(name), fullName+".") {
+				return find(msg.NestedType, fullName)
+			}
+		}
+		return nil
+	}
+	return find(file.MessageType, prefix)
+}
+
+func findMessageDesc(msgs protoreflect.MessageDescriptors, name protoreflect.FullName) protoreflect.MessageDescriptor {
+	for i := 0; i < msgs.Len(); i++ {
+		msg := msgs.Get(i)
+		if msg.FullName() == name {
+			return msg
+		}
+		if strings.HasPrefix(string(name), string(msg.FullName())+".") {
+			return findMessageDesc(msg.Messages(), name)
+		}
+	}
+	return nil
+}
+
+// decodeInferredAttributes decodes the given body into a message of the given
+// type, which must have no HCL annotations at all, by treating each of its
+// fields as an optional attribute named after the field's JSON name, for
+// DecodeOptions.InferAttributesFromJSONNames.
+func (opts DecodeOptions) decodeInferredAttributes(body hcl.Body, desc protoreflect.MessageDescriptor, ctx *hcl.EvalContext, except map[string]struct{}, d decoder) (proto.Message, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+	inferred, skipped, err := inferredAttributesDesc(desc)
+	if err != nil {
+		diags = diags.Append(schemaErrorDiagnostic(schemaError{Decl: desc.FullName(), Err: err}))
+		return newMessageMaybeDynamic(desc).Interface(), diags
+	}
+
+	detail := fmt.Sprintf(
+		"The message type %s has no HCL annotations, so protohcl matched the attributes in this body to its fields by their JSON names. This mapping is not strict and is intended only for prototyping: the expected configuration may change once the message type is annotated.",
+		desc.FullName(),
+	)
+	if len(skipped) != 0 {
+		names := make([]string, len(skipped))
+		for i, name := range skipped {
+			names[i] = string(name)
+		}
+		detail += fmt.Sprintf("\n\nThese fields have types that need HCL annotations, and so can't be set: %s.", strings.Join(names, ", "))
+	}
+	diags = diags.Append(&hcl.Diagnostic{
+		Severity: hcl.DiagWarning,
+		Summary:  inferredAttributesSummary,
+		Detail:   detail,
+		Subject:  body.MissingItemRange().Ptr(),
+	})
+
+	// The inferred message type has annotations, but might have no fields
+	// at all if none of the fields are suitable, in which case we must not
+	// try to infer them again.
+	opts.InferAttributesFromJSONNames = false
+	d.dynamic = true
+	msg, moreDiags := opts.decode(body, inferred, ctx, except, d)
+	diags = append(diags, moreDiags...)
+	SortDiagnostics(diags)
+
+	// The result is a dynamic message of the inferred type, so we'll copy
+	// it into a message of the original type, which has the same encoding.
+	raw, err := proto.MarshalOptions{AllowPartial: true}.Marshal(msg)
+	if err != nil {
+		diags = diags.Append(schemaErrorDiagnostic(schemaError{Decl: desc.FullName(), Err: err}))
+		return newMessageMaybeDynamic(desc).Interface(), diags
+	}
+	ret := newMessageMaybeDynamic(desc).Interface()
+	if err := (proto.UnmarshalOptions{AllowPartial: true}).Unmarshal(raw, ret); err != nil {
+		diags = diags.Append(schemaErrorDiagnostic(schemaError{Decl: desc.FullName(), Err: err}))
+	}
+	return ret, diags
+}
@@ -0,0 +1,186 @@
+package protohcl
+
+import (
+	"testing"
+
+	"github.com/apparentlymart/go-protohcl/protohcl/internal/testschema"
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+func TestDecodeBodyInferAttributesFromJSONNames(t *testing.T) {
+	desc := testschema.File_testschema_proto.Messages().ByName("WithoutAnnotations")
+	opts := DecodeOptions{InferAttributesFromJSONNames: true}
+
+	tests := map[string]struct {
+		config    string
+		want      *testschema.WithoutAnnotations
+		wantDiags []string
+	}{
+		"empty": {
+			``,
+			&testschema.WithoutAnnotations{},
+			[]string{
+				`Schema has no HCL annotations`,
+			},
+		},
+		"all attributes": {
+			`
+				name     = "Jackson"
+				maxCount = 5
+				tags     = ["a", "b"]
+				labels   = { env = "prod" }
+			`,
+			&testschema.WithoutAnnotations{
+				Name:     "Jackson",
+				MaxCount: 5,
+				Tags:     []string{"a", "b"},
+				Labels:   map[string]string{"env": "prod"},
+			},
+			[]string{
+				`Schema has no HCL annotations`,
+			},
+		},
+		"field name rather than JSON name": {
+			`
+				max_count = 5
+			`,
+			&testschema.WithoutAnnotations{},
+			[]string{
+				`Schema has no HCL annotations`,
+				`Unsupported argument`,
+			},
+		},
+		"unsuitable value": {
+			`
+				maxCount = "many"
+			`,
+			&testschema.WithoutAnnotations{},
+			[]string{
+				`Schema has no HCL annotations`,
+				`Unsuitable attribute value`,
+			},
+		},
+		"message-typed field": {
+			`
+				nested = { name = "Jackson" }
+			`,
+			&testschema.WithoutAnnotations{},
+			[]string{
+				`Schema has no HCL annotations`,
+				`Unsupported argument`,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			f, diags := hclsyntax.ParseConfig([]byte(test.config), "", hcl.InitialPos)
+			if diags.HasErrors() {
+				t.Fatalf("unexpected syntax errors: %s", diags.Error())
+			}
+
+			got, diags := opts.DecodeBody(f.Body, desc, nil)
+			var gotDiags []string
+			for _, diag := range diags {
+				gotDiags = append(gotDiags, diag.Summary)
+			}
+			if diff := cmp.Diff(test.wantDiags, gotDiags); diff != "" {
+				t.Errorf("wrong diagnostics\n%s", diff)
+			}
+			if diags[0].Severity != hcl.DiagWarning {
+				t.Errorf("first diagnostic is not a warning")
+			}
+			if diff := cmp.Diff(test.want, got, protoCmpOpt); diff != "" {
+				t.Errorf("wrong result\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDecodeBodyInferAttributesFromJSONNamesDetail(t *testing.T) {
+	desc := testschema.File_testschema_proto.Messages().ByName("WithoutAnnotations")
+	opts := DecodeOptions{InferAttributesFromJSONNames: true}
+
+	_, diags := opts.DecodeBody(hcl.EmptyBody(), desc, nil)
+	if len(diags) != 1 {
+		t.Fatalf("wrong number of diagnostics %d; want 1\n%s", len(diags), diags.Error())
+	}
+	want := "The message type hcl.testschema.WithoutAnnotations has no HCL annotations, so protohcl matched the attributes in this body to its fields by their JSON names. This mapping is not strict and is intended only for prototyping: the expected configuration may change once the message type is annotated.\n\nThese fields have types that need HCL annotations, and so can't be set: nested, data."
+	if got := diags[0].Detail; got != want {
+		t.Errorf("wrong detail\ngot:  %s\nwant: %s", got, want)
+	}
+}
+
+func TestDecodeBodyInferAttributesFromJSONNamesAnnotated(t *testing.T) {
+	// The option has no effect on a message type with annotations.
+	desc := testschema.File_testschema_proto.Messages().ByName("WithStringAttr")
+	opts := DecodeOptions{InferAttributesFromJSONNames: true}
+
+	f, diags := hclsyntax.ParseConfig([]byte(`name = "Jackson"`), "", hcl.InitialPos)
+	if diags.HasErrors() {
+		t.Fatalf("unexpected syntax errors: %s", diags.Error())
+	}
+	got, diags := opts.DecodeBody(f.Body, desc, nil)
+	if len(diags) != 0 {
+		t.Fatalf("unexpected diagnostics: %s", diags.Error())
+	}
+	want := &testschema.WithStringAttr{Name: "Jackson"}
+	if diff := cmp.Diff(want, got, protoCmpOpt); diff != "" {
+		t.Errorf("wrong result\n%s", diff)
+	}
+}
+
+func TestDecodeBodyInferAttributesFromJSONNamesDynamic(t *testing.T) {
+	// A message type that isn't in the global registry decodes into a
+	// dynamic message of that same type.
+	fileProto := &descriptorpb.FileDescriptorProto{
+		Name:    proto.String("prototype.proto"),
+		Package: proto.String("prototype"),
+		Syntax:  proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{
+			{
+				Name: proto.String("Config"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					{
+						Name:     proto.String("listen_addr"),
+						JsonName: proto.String("listenAddr"),
+						Number:   proto.Int32(1),
+						Type:     descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+						Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+					},
+				},
+			},
+		},
+	}
+	file, err := protodesc.NewFile(fileProto, protoregistry.GlobalFiles)
+	if err != nil {
+		t.Fatal(err)
+	}
+	desc := file.Messages().ByName("Config")
+	opts := DecodeOptions{InferAttributesFromJSONNames: true}
+
+	f, diags := hclsyntax.ParseConfig([]byte(`listenAddr = ":8080"`), "", hcl.InitialPos)
+	if diags.HasErrors() {
+		t.Fatalf("unexpected syntax errors: %s", diags.Error())
+	}
+	got, diags := opts.DecodeBody(f.Body, desc, nil)
+	if diags.HasErrors() {
+		t.Fatalf("unexpected errors: %s", diags.Error())
+	}
+	if got.ProtoReflect().Descriptor() != desc {
+		t.Errorf("result has the wrong message descriptor")
+	}
+	want := dynamicpb.NewMessage(desc)
+	want.Set(desc.Fields().ByName("listen_addr"), protoreflect.ValueOfString(":8080"))
+	if diff := cmp.Diff(want, got, protoCmpOpt); diff != "" {
+		t.Errorf("wrong result\n%s", diff)
+	}
+}
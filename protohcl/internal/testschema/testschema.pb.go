@@ -7,10 +7,14 @@
 package testschema
 
 import (
-	_ "github.com/apparentlymart/go-protohcl/protohcl/protohclext"
+	protohclext "github.com/apparentlymart/go-protohcl/protohcl/protohclext"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	anypb "google.golang.org/protobuf/types/known/anypb"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
 	structpb "google.golang.org/protobuf/types/known/structpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	wrapperspb "google.golang.org/protobuf/types/known/wrapperspb"
 	reflect "reflect"
 	sync "sync"
 )
@@ -22,6 +26,104 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type Level int32
+
+const (
+	Level_LEVEL_UNSPECIFIED Level = 0
+	Level_LEVEL_DEBUG       Level = 1
+	Level_LEVEL_INFO        Level = 2
+)
+
+// Enum value maps for Level.
+var (
+	Level_name = map[int32]string{
+		0: "LEVEL_UNSPECIFIED",
+		1: "LEVEL_DEBUG",
+		2: "LEVEL_INFO",
+	}
+	Level_value = map[string]int32{
+		"LEVEL_UNSPECIFIED": 0,
+		"LEVEL_DEBUG":       1,
+		"LEVEL_INFO":        2,
+	}
+)
+
+func (x Level) Enum() *Level {
+	p := new(Level)
+	*p = x
+	return p
+}
+
+func (x Level) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Level) Descriptor() protoreflect.EnumDescriptor {
+	return file_testschema_proto_enumTypes[0].Descriptor()
+}
+
+func (Level) Type() protoreflect.EnumType {
+	return &file_testschema_proto_enumTypes[0]
+}
+
+func (x Level) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Level.Descriptor instead.
+func (Level) EnumDescriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{0}
+}
+
+type Color int32
+
+const (
+	Color_COLOR_UNSPECIFIED Color = 0
+	Color_COLOR_RED         Color = 1
+	Color_COLOR_DARK_BLUE   Color = 2
+)
+
+// Enum value maps for Color.
+var (
+	Color_name = map[int32]string{
+		0: "COLOR_UNSPECIFIED",
+		1: "COLOR_RED",
+		2: "COLOR_DARK_BLUE",
+	}
+	Color_value = map[string]int32{
+		"COLOR_UNSPECIFIED": 0,
+		"COLOR_RED":         1,
+		"COLOR_DARK_BLUE":   2,
+	}
+)
+
+func (x Color) Enum() *Color {
+	p := new(Color)
+	*p = x
+	return p
+}
+
+func (x Color) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Color) Descriptor() protoreflect.EnumDescriptor {
+	return file_testschema_proto_enumTypes[1].Descriptor()
+}
+
+func (Color) Type() protoreflect.EnumType {
+	return &file_testschema_proto_enumTypes[1]
+}
+
+func (x Color) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Color.Descriptor instead.
+func (Color) EnumDescriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{1}
+}
+
 type Root struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -193,6 +295,103 @@ func (x *MoreRoot) GetOtherThing() *Thing {
 	return nil
 }
 
+type RootOutput struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// ID represents an attribute that only becomes known once Root has been
+	// processed.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *RootOutput) Reset() {
+	*x = RootOutput{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RootOutput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RootOutput) ProtoMessage() {}
+
+func (x *RootOutput) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RootOutput.ProtoReflect.Descriptor instead.
+func (*RootOutput) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *RootOutput) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ConflictingRootOutput struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Invalid: Root already has an attribute called "name".
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *ConflictingRootOutput) Reset() {
+	*x = ConflictingRootOutput{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConflictingRootOutput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConflictingRootOutput) ProtoMessage() {}
+
+func (x *ConflictingRootOutput) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConflictingRootOutput.ProtoReflect.Descriptor instead.
+func (*ConflictingRootOutput) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ConflictingRootOutput) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
 type WithStringAttr struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -205,7 +404,7 @@ type WithStringAttr struct {
 func (x *WithStringAttr) Reset() {
 	*x = WithStringAttr{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_testschema_proto_msgTypes[3]
+		mi := &file_testschema_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -218,7 +417,7 @@ func (x *WithStringAttr) String() string {
 func (*WithStringAttr) ProtoMessage() {}
 
 func (x *WithStringAttr) ProtoReflect() protoreflect.Message {
-	mi := &file_testschema_proto_msgTypes[3]
+	mi := &file_testschema_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -231,7 +430,7 @@ func (x *WithStringAttr) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use WithStringAttr.ProtoReflect.Descriptor instead.
 func (*WithStringAttr) Descriptor() ([]byte, []int) {
-	return file_testschema_proto_rawDescGZIP(), []int{3}
+	return file_testschema_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *WithStringAttr) GetName() string {
@@ -253,7 +452,7 @@ type WithRawDynamicAttr struct {
 func (x *WithRawDynamicAttr) Reset() {
 	*x = WithRawDynamicAttr{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_testschema_proto_msgTypes[4]
+		mi := &file_testschema_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -266,7 +465,7 @@ func (x *WithRawDynamicAttr) String() string {
 func (*WithRawDynamicAttr) ProtoMessage() {}
 
 func (x *WithRawDynamicAttr) ProtoReflect() protoreflect.Message {
-	mi := &file_testschema_proto_msgTypes[4]
+	mi := &file_testschema_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -279,7 +478,7 @@ func (x *WithRawDynamicAttr) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use WithRawDynamicAttr.ProtoReflect.Descriptor instead.
 func (*WithRawDynamicAttr) Descriptor() ([]byte, []int) {
-	return file_testschema_proto_rawDescGZIP(), []int{4}
+	return file_testschema_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *WithRawDynamicAttr) GetRaw() []byte {
@@ -302,7 +501,7 @@ type WithStructDynamicAttr struct {
 func (x *WithStructDynamicAttr) Reset() {
 	*x = WithStructDynamicAttr{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_testschema_proto_msgTypes[5]
+		mi := &file_testschema_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -315,7 +514,7 @@ func (x *WithStructDynamicAttr) String() string {
 func (*WithStructDynamicAttr) ProtoMessage() {}
 
 func (x *WithStructDynamicAttr) ProtoReflect() protoreflect.Message {
-	mi := &file_testschema_proto_msgTypes[5]
+	mi := &file_testschema_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -328,7 +527,7 @@ func (x *WithStructDynamicAttr) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use WithStructDynamicAttr.ProtoReflect.Descriptor instead.
 func (*WithStructDynamicAttr) Descriptor() ([]byte, []int) {
-	return file_testschema_proto_rawDescGZIP(), []int{5}
+	return file_testschema_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *WithStructDynamicAttr) GetStruct() *structpb.Value {
@@ -351,7 +550,7 @@ type WithStructStringAttr struct {
 func (x *WithStructStringAttr) Reset() {
 	*x = WithStructStringAttr{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_testschema_proto_msgTypes[6]
+		mi := &file_testschema_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -364,7 +563,7 @@ func (x *WithStructStringAttr) String() string {
 func (*WithStructStringAttr) ProtoMessage() {}
 
 func (x *WithStructStringAttr) ProtoReflect() protoreflect.Message {
-	mi := &file_testschema_proto_msgTypes[6]
+	mi := &file_testschema_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -377,7 +576,7 @@ func (x *WithStructStringAttr) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use WithStructStringAttr.ProtoReflect.Descriptor instead.
 func (*WithStructStringAttr) Descriptor() ([]byte, []int) {
-	return file_testschema_proto_rawDescGZIP(), []int{6}
+	return file_testschema_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *WithStructStringAttr) GetStruct() *structpb.Value {
@@ -400,7 +599,7 @@ type WithStructListAttr struct {
 func (x *WithStructListAttr) Reset() {
 	*x = WithStructListAttr{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_testschema_proto_msgTypes[7]
+		mi := &file_testschema_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -413,7 +612,7 @@ func (x *WithStructListAttr) String() string {
 func (*WithStructListAttr) ProtoMessage() {}
 
 func (x *WithStructListAttr) ProtoReflect() protoreflect.Message {
-	mi := &file_testschema_proto_msgTypes[7]
+	mi := &file_testschema_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -426,7 +625,7 @@ func (x *WithStructListAttr) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use WithStructListAttr.ProtoReflect.Descriptor instead.
 func (*WithStructListAttr) Descriptor() ([]byte, []int) {
-	return file_testschema_proto_rawDescGZIP(), []int{7}
+	return file_testschema_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *WithStructListAttr) GetStructs() []*structpb.Value {
@@ -449,7 +648,7 @@ type WithStructMapAttr struct {
 func (x *WithStructMapAttr) Reset() {
 	*x = WithStructMapAttr{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_testschema_proto_msgTypes[8]
+		mi := &file_testschema_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -462,7 +661,7 @@ func (x *WithStructMapAttr) String() string {
 func (*WithStructMapAttr) ProtoMessage() {}
 
 func (x *WithStructMapAttr) ProtoReflect() protoreflect.Message {
-	mi := &file_testschema_proto_msgTypes[8]
+	mi := &file_testschema_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -475,7 +674,7 @@ func (x *WithStructMapAttr) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use WithStructMapAttr.ProtoReflect.Descriptor instead.
 func (*WithStructMapAttr) Descriptor() ([]byte, []int) {
-	return file_testschema_proto_rawDescGZIP(), []int{8}
+	return file_testschema_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *WithStructMapAttr) GetStructs() map[string]*structpb.Value {
@@ -485,33 +684,34 @@ func (x *WithStructMapAttr) GetStructs() map[string]*structpb.Value {
 	return nil
 }
 
-type WithNumberAttrAsInt32 struct {
+type StructHolder struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Here the protobuf type has a narrower range than the HCL type, so
-	// protohcl must apply additional validation rules.
-	Num int32 `protobuf:"varint,1,opt,name=num,proto3" json:"num,omitempty"`
+	List   []*structpb.Value          `protobuf:"bytes,1,rep,name=list,proto3" json:"list,omitempty"`
+	Map    map[string]*structpb.Value `protobuf:"bytes,2,rep,name=map,proto3" json:"map,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Single *structpb.Value            `protobuf:"bytes,3,opt,name=single,proto3" json:"single,omitempty"`
+	Tuple  []*structpb.Value          `protobuf:"bytes,4,rep,name=tuple,proto3" json:"tuple,omitempty"`
 }
 
-func (x *WithNumberAttrAsInt32) Reset() {
-	*x = WithNumberAttrAsInt32{}
+func (x *StructHolder) Reset() {
+	*x = StructHolder{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_testschema_proto_msgTypes[9]
+		mi := &file_testschema_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *WithNumberAttrAsInt32) String() string {
+func (x *StructHolder) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*WithNumberAttrAsInt32) ProtoMessage() {}
+func (*StructHolder) ProtoMessage() {}
 
-func (x *WithNumberAttrAsInt32) ProtoReflect() protoreflect.Message {
-	mi := &file_testschema_proto_msgTypes[9]
+func (x *StructHolder) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -522,46 +722,66 @@ func (x *WithNumberAttrAsInt32) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use WithNumberAttrAsInt32.ProtoReflect.Descriptor instead.
-func (*WithNumberAttrAsInt32) Descriptor() ([]byte, []int) {
-	return file_testschema_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use StructHolder.ProtoReflect.Descriptor instead.
+func (*StructHolder) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *WithNumberAttrAsInt32) GetNum() int32 {
+func (x *StructHolder) GetList() []*structpb.Value {
 	if x != nil {
-		return x.Num
+		return x.List
 	}
-	return 0
+	return nil
 }
 
-type WithNumberAttrAsString struct {
+func (x *StructHolder) GetMap() map[string]*structpb.Value {
+	if x != nil {
+		return x.Map
+	}
+	return nil
+}
+
+func (x *StructHolder) GetSingle() *structpb.Value {
+	if x != nil {
+		return x.Single
+	}
+	return nil
+}
+
+func (x *StructHolder) GetTuple() []*structpb.Value {
+	if x != nil {
+		return x.Tuple
+	}
+	return nil
+}
+
+type WithStructsInNestedMessages struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// This is an example of the HCL type disagreeing with the proto type in
-	// a valid way, because it can be resolved by an extra round of type
-	// conversion.
-	Num string `protobuf:"bytes,1,opt,name=num,proto3" json:"num,omitempty"`
+	// google.protobuf.Value fields nested inside message-typed attributes.
+	ByKey map[string]*StructHolder `protobuf:"bytes,1,rep,name=by_key,json=byKey,proto3" json:"by_key,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	List  []*StructHolder          `protobuf:"bytes,2,rep,name=list,proto3" json:"list,omitempty"`
 }
 
-func (x *WithNumberAttrAsString) Reset() {
-	*x = WithNumberAttrAsString{}
+func (x *WithStructsInNestedMessages) Reset() {
+	*x = WithStructsInNestedMessages{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_testschema_proto_msgTypes[10]
+		mi := &file_testschema_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *WithNumberAttrAsString) String() string {
+func (x *WithStructsInNestedMessages) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*WithNumberAttrAsString) ProtoMessage() {}
+func (*WithStructsInNestedMessages) ProtoMessage() {}
 
-func (x *WithNumberAttrAsString) ProtoReflect() protoreflect.Message {
-	mi := &file_testschema_proto_msgTypes[10]
+func (x *WithStructsInNestedMessages) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -572,47 +792,52 @@ func (x *WithNumberAttrAsString) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use WithNumberAttrAsString.ProtoReflect.Descriptor instead.
-func (*WithNumberAttrAsString) Descriptor() ([]byte, []int) {
-	return file_testschema_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use WithStructsInNestedMessages.ProtoReflect.Descriptor instead.
+func (*WithStructsInNestedMessages) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *WithNumberAttrAsString) GetNum() string {
+func (x *WithStructsInNestedMessages) GetByKey() map[string]*StructHolder {
 	if x != nil {
-		return x.Num
+		return x.ByKey
 	}
-	return ""
+	return nil
 }
 
-type WithBoolAttr struct {
+func (x *WithStructsInNestedMessages) GetList() []*StructHolder {
+	if x != nil {
+		return x.List
+	}
+	return nil
+}
+
+type WithNumberAttrAsInt32 struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// This one is also testing automatic inference that the HCL type is bool
-	// based on the field type. Those inferences won't always be correct because
-	// the type systems are not one-to-one, but it's helpful for simple cases
-	// like this.
-	DoTheThing bool `protobuf:"varint,1,opt,name=do_the_thing,json=doTheThing,proto3" json:"do_the_thing,omitempty"`
+	// Here the protobuf type has a narrower range than the HCL type, so
+	// protohcl must apply additional validation rules.
+	Num int32 `protobuf:"varint,1,opt,name=num,proto3" json:"num,omitempty"`
 }
 
-func (x *WithBoolAttr) Reset() {
-	*x = WithBoolAttr{}
+func (x *WithNumberAttrAsInt32) Reset() {
+	*x = WithNumberAttrAsInt32{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_testschema_proto_msgTypes[11]
+		mi := &file_testschema_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *WithBoolAttr) String() string {
+func (x *WithNumberAttrAsInt32) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*WithBoolAttr) ProtoMessage() {}
+func (*WithNumberAttrAsInt32) ProtoMessage() {}
 
-func (x *WithBoolAttr) ProtoReflect() protoreflect.Message {
-	mi := &file_testschema_proto_msgTypes[11]
+func (x *WithNumberAttrAsInt32) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -623,44 +848,46 @@ func (x *WithBoolAttr) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use WithBoolAttr.ProtoReflect.Descriptor instead.
-func (*WithBoolAttr) Descriptor() ([]byte, []int) {
-	return file_testschema_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use WithNumberAttrAsInt32.ProtoReflect.Descriptor instead.
+func (*WithNumberAttrAsInt32) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *WithBoolAttr) GetDoTheThing() bool {
+func (x *WithNumberAttrAsInt32) GetNum() int32 {
 	if x != nil {
-		return x.DoTheThing
+		return x.Num
 	}
-	return false
+	return 0
 }
 
-type WithStringListAttr struct {
+type WithNumberAttrsAsFloat struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Automatic HCL type selection.
-	Names []string `protobuf:"bytes,1,rep,name=names,proto3" json:"names,omitempty"`
+	// Floating point types can represent infinities and NaN, which cty numbers
+	// can't all represent, and float in particular has a narrower range.
+	Single float32 `protobuf:"fixed32,1,opt,name=single,proto3" json:"single,omitempty"`
+	Double float64 `protobuf:"fixed64,2,opt,name=double,proto3" json:"double,omitempty"`
 }
 
-func (x *WithStringListAttr) Reset() {
-	*x = WithStringListAttr{}
+func (x *WithNumberAttrsAsFloat) Reset() {
+	*x = WithNumberAttrsAsFloat{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_testschema_proto_msgTypes[12]
+		mi := &file_testschema_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *WithStringListAttr) String() string {
+func (x *WithNumberAttrsAsFloat) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*WithStringListAttr) ProtoMessage() {}
+func (*WithNumberAttrsAsFloat) ProtoMessage() {}
 
-func (x *WithStringListAttr) ProtoReflect() protoreflect.Message {
-	mi := &file_testschema_proto_msgTypes[12]
+func (x *WithNumberAttrsAsFloat) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -671,45 +898,53 @@ func (x *WithStringListAttr) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use WithStringListAttr.ProtoReflect.Descriptor instead.
-func (*WithStringListAttr) Descriptor() ([]byte, []int) {
-	return file_testschema_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use WithNumberAttrsAsFloat.ProtoReflect.Descriptor instead.
+func (*WithNumberAttrsAsFloat) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *WithStringListAttr) GetNames() []string {
+func (x *WithNumberAttrsAsFloat) GetSingle() float32 {
 	if x != nil {
-		return x.Names
+		return x.Single
 	}
-	return nil
+	return 0
 }
 
-type WithStringSetAttr struct {
+func (x *WithNumberAttrsAsFloat) GetDouble() float64 {
+	if x != nil {
+		return x.Double
+	}
+	return 0
+}
+
+type WithNumberAttrAsString struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Need to override the automatic type selection, which would choose
-	// list(string).
-	Names []string `protobuf:"bytes,1,rep,name=names,proto3" json:"names,omitempty"`
+	// This is an example of the HCL type disagreeing with the proto type in
+	// a valid way, because it can be resolved by an extra round of type
+	// conversion.
+	Num string `protobuf:"bytes,1,opt,name=num,proto3" json:"num,omitempty"`
 }
 
-func (x *WithStringSetAttr) Reset() {
-	*x = WithStringSetAttr{}
+func (x *WithNumberAttrAsString) Reset() {
+	*x = WithNumberAttrAsString{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_testschema_proto_msgTypes[13]
+		mi := &file_testschema_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *WithStringSetAttr) String() string {
+func (x *WithNumberAttrAsString) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*WithStringSetAttr) ProtoMessage() {}
+func (*WithNumberAttrAsString) ProtoMessage() {}
 
-func (x *WithStringSetAttr) ProtoReflect() protoreflect.Message {
-	mi := &file_testschema_proto_msgTypes[13]
+func (x *WithNumberAttrAsString) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -720,44 +955,47 @@ func (x *WithStringSetAttr) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use WithStringSetAttr.ProtoReflect.Descriptor instead.
-func (*WithStringSetAttr) Descriptor() ([]byte, []int) {
-	return file_testschema_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use WithNumberAttrAsString.ProtoReflect.Descriptor instead.
+func (*WithNumberAttrAsString) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *WithStringSetAttr) GetNames() []string {
+func (x *WithNumberAttrAsString) GetNum() string {
 	if x != nil {
-		return x.Names
+		return x.Num
 	}
-	return nil
+	return ""
 }
 
-type WithStringMapAttr struct {
+type WithBoolAttr struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Automatic HCL type selection.
-	Names map[string]string `protobuf:"bytes,1,rep,name=names,proto3" json:"names,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// This one is also testing automatic inference that the HCL type is bool
+	// based on the field type. Those inferences won't always be correct because
+	// the type systems are not one-to-one, but it's helpful for simple cases
+	// like this.
+	DoTheThing bool `protobuf:"varint,1,opt,name=do_the_thing,json=doTheThing,proto3" json:"do_the_thing,omitempty"`
 }
 
-func (x *WithStringMapAttr) Reset() {
-	*x = WithStringMapAttr{}
+func (x *WithBoolAttr) Reset() {
+	*x = WithBoolAttr{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_testschema_proto_msgTypes[14]
+		mi := &file_testschema_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *WithStringMapAttr) String() string {
+func (x *WithBoolAttr) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*WithStringMapAttr) ProtoMessage() {}
+func (*WithBoolAttr) ProtoMessage() {}
 
-func (x *WithStringMapAttr) ProtoReflect() protoreflect.Message {
-	mi := &file_testschema_proto_msgTypes[14]
+func (x *WithBoolAttr) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -768,44 +1006,44 @@ func (x *WithStringMapAttr) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use WithStringMapAttr.ProtoReflect.Descriptor instead.
-func (*WithStringMapAttr) Descriptor() ([]byte, []int) {
-	return file_testschema_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use WithBoolAttr.ProtoReflect.Descriptor instead.
+func (*WithBoolAttr) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *WithStringMapAttr) GetNames() map[string]string {
+func (x *WithBoolAttr) GetDoTheThing() bool {
 	if x != nil {
-		return x.Names
+		return x.DoTheThing
 	}
-	return nil
+	return false
 }
 
-type WithFlattenStringAttr struct {
+type WithStringListAttr struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Base    *WithStringAttr `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
-	Species string          `protobuf:"bytes,2,opt,name=species,proto3" json:"species,omitempty"`
+	// Automatic HCL type selection.
+	Names []string `protobuf:"bytes,1,rep,name=names,proto3" json:"names,omitempty"`
 }
 
-func (x *WithFlattenStringAttr) Reset() {
-	*x = WithFlattenStringAttr{}
+func (x *WithStringListAttr) Reset() {
+	*x = WithStringListAttr{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_testschema_proto_msgTypes[15]
+		mi := &file_testschema_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *WithFlattenStringAttr) String() string {
+func (x *WithStringListAttr) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*WithFlattenStringAttr) ProtoMessage() {}
+func (*WithStringListAttr) ProtoMessage() {}
 
-func (x *WithFlattenStringAttr) ProtoReflect() protoreflect.Message {
-	mi := &file_testschema_proto_msgTypes[15]
+func (x *WithStringListAttr) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -816,51 +1054,45 @@ func (x *WithFlattenStringAttr) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use WithFlattenStringAttr.ProtoReflect.Descriptor instead.
-func (*WithFlattenStringAttr) Descriptor() ([]byte, []int) {
-	return file_testschema_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use WithStringListAttr.ProtoReflect.Descriptor instead.
+func (*WithStringListAttr) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *WithFlattenStringAttr) GetBase() *WithStringAttr {
+func (x *WithStringListAttr) GetNames() []string {
 	if x != nil {
-		return x.Base
+		return x.Names
 	}
 	return nil
 }
 
-func (x *WithFlattenStringAttr) GetSpecies() string {
-	if x != nil {
-		return x.Species
-	}
-	return ""
-}
-
-type WithNestedFlattenStringAttr struct {
+type WithNumberListAttrAsInt32 struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Base  *WithFlattenStringAttr `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
-	Breed string                 `protobuf:"bytes,3,opt,name=breed,proto3" json:"breed,omitempty"`
+	// Automatic HCL type selection, with each element also constrained to
+	// the range of int32.
+	Nums []int32 `protobuf:"varint,1,rep,packed,name=nums,proto3" json:"nums,omitempty"`
 }
 
-func (x *WithNestedFlattenStringAttr) Reset() {
-	*x = WithNestedFlattenStringAttr{}
+func (x *WithNumberListAttrAsInt32) Reset() {
+	*x = WithNumberListAttrAsInt32{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_testschema_proto_msgTypes[16]
+		mi := &file_testschema_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *WithNestedFlattenStringAttr) String() string {
+func (x *WithNumberListAttrAsInt32) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*WithNestedFlattenStringAttr) ProtoMessage() {}
+func (*WithNumberListAttrAsInt32) ProtoMessage() {}
 
-func (x *WithNestedFlattenStringAttr) ProtoReflect() protoreflect.Message {
-	mi := &file_testschema_proto_msgTypes[16]
+func (x *WithNumberListAttrAsInt32) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -871,51 +1103,44 @@ func (x *WithNestedFlattenStringAttr) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use WithNestedFlattenStringAttr.ProtoReflect.Descriptor instead.
-func (*WithNestedFlattenStringAttr) Descriptor() ([]byte, []int) {
-	return file_testschema_proto_rawDescGZIP(), []int{16}
+// Deprecated: Use WithNumberListAttrAsInt32.ProtoReflect.Descriptor instead.
+func (*WithNumberListAttrAsInt32) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *WithNestedFlattenStringAttr) GetBase() *WithFlattenStringAttr {
+func (x *WithNumberListAttrAsInt32) GetNums() []int32 {
 	if x != nil {
-		return x.Base
+		return x.Nums
 	}
 	return nil
 }
 
-func (x *WithNestedFlattenStringAttr) GetBreed() string {
-	if x != nil {
-		return x.Breed
-	}
-	return ""
-}
-
-type WithNestedBlockNoLabelsSingleton struct {
+type WithStringListAttrAllowScalar struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// A nested block type no labels
-	Doodad *WithStringAttr `protobuf:"bytes,1,opt,name=doodad,proto3" json:"doodad,omitempty"`
+	// Accepts either a single string or a list of strings.
+	Names []string `protobuf:"bytes,1,rep,name=names,proto3" json:"names,omitempty"`
 }
 
-func (x *WithNestedBlockNoLabelsSingleton) Reset() {
-	*x = WithNestedBlockNoLabelsSingleton{}
+func (x *WithStringListAttrAllowScalar) Reset() {
+	*x = WithStringListAttrAllowScalar{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_testschema_proto_msgTypes[17]
+		mi := &file_testschema_proto_msgTypes[19]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *WithNestedBlockNoLabelsSingleton) String() string {
+func (x *WithStringListAttrAllowScalar) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*WithNestedBlockNoLabelsSingleton) ProtoMessage() {}
+func (*WithStringListAttrAllowScalar) ProtoMessage() {}
 
-func (x *WithNestedBlockNoLabelsSingleton) ProtoReflect() protoreflect.Message {
-	mi := &file_testschema_proto_msgTypes[17]
+func (x *WithStringListAttrAllowScalar) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[19]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -926,44 +1151,45 @@ func (x *WithNestedBlockNoLabelsSingleton) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use WithNestedBlockNoLabelsSingleton.ProtoReflect.Descriptor instead.
-func (*WithNestedBlockNoLabelsSingleton) Descriptor() ([]byte, []int) {
-	return file_testschema_proto_rawDescGZIP(), []int{17}
+// Deprecated: Use WithStringListAttrAllowScalar.ProtoReflect.Descriptor instead.
+func (*WithStringListAttrAllowScalar) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{19}
 }
 
-func (x *WithNestedBlockNoLabelsSingleton) GetDoodad() *WithStringAttr {
+func (x *WithStringListAttrAllowScalar) GetNames() []string {
 	if x != nil {
-		return x.Doodad
+		return x.Names
 	}
 	return nil
 }
 
-type WithNestedBlockOneLabelSingleton struct {
+type WithUnitAttrs struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// A nested block type with one label
-	Doodad *WithOneBlockLabel `protobuf:"bytes,1,opt,name=doodad,proto3" json:"doodad,omitempty"`
+	MaxSize   int64    `protobuf:"varint,1,opt,name=max_size,json=maxSize,proto3" json:"max_size,omitempty"`
+	Timeout   float64  `protobuf:"fixed64,2,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	Intervals []uint32 `protobuf:"varint,3,rep,packed,name=intervals,proto3" json:"intervals,omitempty"`
 }
 
-func (x *WithNestedBlockOneLabelSingleton) Reset() {
-	*x = WithNestedBlockOneLabelSingleton{}
+func (x *WithUnitAttrs) Reset() {
+	*x = WithUnitAttrs{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_testschema_proto_msgTypes[18]
+		mi := &file_testschema_proto_msgTypes[20]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *WithNestedBlockOneLabelSingleton) String() string {
+func (x *WithUnitAttrs) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*WithNestedBlockOneLabelSingleton) ProtoMessage() {}
+func (*WithUnitAttrs) ProtoMessage() {}
 
-func (x *WithNestedBlockOneLabelSingleton) ProtoReflect() protoreflect.Message {
-	mi := &file_testschema_proto_msgTypes[18]
+func (x *WithUnitAttrs) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[20]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -974,44 +1200,57 @@ func (x *WithNestedBlockOneLabelSingleton) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use WithNestedBlockOneLabelSingleton.ProtoReflect.Descriptor instead.
-func (*WithNestedBlockOneLabelSingleton) Descriptor() ([]byte, []int) {
-	return file_testschema_proto_rawDescGZIP(), []int{18}
+// Deprecated: Use WithUnitAttrs.ProtoReflect.Descriptor instead.
+func (*WithUnitAttrs) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{20}
 }
 
-func (x *WithNestedBlockOneLabelSingleton) GetDoodad() *WithOneBlockLabel {
+func (x *WithUnitAttrs) GetMaxSize() int64 {
 	if x != nil {
-		return x.Doodad
+		return x.MaxSize
+	}
+	return 0
+}
+
+func (x *WithUnitAttrs) GetTimeout() float64 {
+	if x != nil {
+		return x.Timeout
+	}
+	return 0
+}
+
+func (x *WithUnitAttrs) GetIntervals() []uint32 {
+	if x != nil {
+		return x.Intervals
 	}
 	return nil
 }
 
-type WithNestedBlockTwoLabelSingleton struct {
+type WithUnitAttrUnsupported struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// A nested block type with one label
-	Doodad *WithTwoBlockLabels `protobuf:"bytes,1,opt,name=doodad,proto3" json:"doodad,omitempty"`
+	Size int64 `protobuf:"varint,1,opt,name=size,proto3" json:"size,omitempty"`
 }
 
-func (x *WithNestedBlockTwoLabelSingleton) Reset() {
-	*x = WithNestedBlockTwoLabelSingleton{}
+func (x *WithUnitAttrUnsupported) Reset() {
+	*x = WithUnitAttrUnsupported{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_testschema_proto_msgTypes[19]
+		mi := &file_testschema_proto_msgTypes[21]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *WithNestedBlockTwoLabelSingleton) String() string {
+func (x *WithUnitAttrUnsupported) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*WithNestedBlockTwoLabelSingleton) ProtoMessage() {}
+func (*WithUnitAttrUnsupported) ProtoMessage() {}
 
-func (x *WithNestedBlockTwoLabelSingleton) ProtoReflect() protoreflect.Message {
-	mi := &file_testschema_proto_msgTypes[19]
+func (x *WithUnitAttrUnsupported) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[21]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1022,44 +1261,43 @@ func (x *WithNestedBlockTwoLabelSingleton) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use WithNestedBlockTwoLabelSingleton.ProtoReflect.Descriptor instead.
-func (*WithNestedBlockTwoLabelSingleton) Descriptor() ([]byte, []int) {
-	return file_testschema_proto_rawDescGZIP(), []int{19}
+// Deprecated: Use WithUnitAttrUnsupported.ProtoReflect.Descriptor instead.
+func (*WithUnitAttrUnsupported) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{21}
 }
 
-func (x *WithNestedBlockTwoLabelSingleton) GetDoodad() *WithTwoBlockLabels {
+func (x *WithUnitAttrUnsupported) GetSize() int64 {
 	if x != nil {
-		return x.Doodad
+		return x.Size
 	}
-	return nil
+	return 0
 }
 
-type WithNestedBlockNoLabelsRepeated struct {
+type WithUnitAttrString struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// A nested block type no labels
-	Doodad []*WithStringAttr `protobuf:"bytes,1,rep,name=doodad,proto3" json:"doodad,omitempty"`
+	Size string `protobuf:"bytes,1,opt,name=size,proto3" json:"size,omitempty"`
 }
 
-func (x *WithNestedBlockNoLabelsRepeated) Reset() {
-	*x = WithNestedBlockNoLabelsRepeated{}
+func (x *WithUnitAttrString) Reset() {
+	*x = WithUnitAttrString{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_testschema_proto_msgTypes[20]
+		mi := &file_testschema_proto_msgTypes[22]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *WithNestedBlockNoLabelsRepeated) String() string {
+func (x *WithUnitAttrString) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*WithNestedBlockNoLabelsRepeated) ProtoMessage() {}
+func (*WithUnitAttrString) ProtoMessage() {}
 
-func (x *WithNestedBlockNoLabelsRepeated) ProtoReflect() protoreflect.Message {
-	mi := &file_testschema_proto_msgTypes[20]
+func (x *WithUnitAttrString) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[22]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1070,44 +1308,44 @@ func (x *WithNestedBlockNoLabelsRepeated) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use WithNestedBlockNoLabelsRepeated.ProtoReflect.Descriptor instead.
-func (*WithNestedBlockNoLabelsRepeated) Descriptor() ([]byte, []int) {
-	return file_testschema_proto_rawDescGZIP(), []int{20}
+// Deprecated: Use WithUnitAttrString.ProtoReflect.Descriptor instead.
+func (*WithUnitAttrString) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{22}
 }
 
-func (x *WithNestedBlockNoLabelsRepeated) GetDoodad() []*WithStringAttr {
+func (x *WithUnitAttrString) GetSize() string {
 	if x != nil {
-		return x.Doodad
+		return x.Size
 	}
-	return nil
+	return ""
 }
 
-type WithNestedBlockOneLabelRepeated struct {
+type WithAttrRange struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// A nested block type with one label
-	Doodad []*WithOneBlockLabel `protobuf:"bytes,1,rep,name=doodad,proto3" json:"doodad,omitempty"`
+	Name      string                   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	NameRange *protohclext.SourceRange `protobuf:"bytes,2,opt,name=name_range,json=nameRange,proto3" json:"name_range,omitempty"`
 }
 
-func (x *WithNestedBlockOneLabelRepeated) Reset() {
-	*x = WithNestedBlockOneLabelRepeated{}
+func (x *WithAttrRange) Reset() {
+	*x = WithAttrRange{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_testschema_proto_msgTypes[21]
+		mi := &file_testschema_proto_msgTypes[23]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *WithNestedBlockOneLabelRepeated) String() string {
+func (x *WithAttrRange) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*WithNestedBlockOneLabelRepeated) ProtoMessage() {}
+func (*WithAttrRange) ProtoMessage() {}
 
-func (x *WithNestedBlockOneLabelRepeated) ProtoReflect() protoreflect.Message {
-	mi := &file_testschema_proto_msgTypes[21]
+func (x *WithAttrRange) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[23]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1118,442 +1356,8923 @@ func (x *WithNestedBlockOneLabelRepeated) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use WithNestedBlockOneLabelRepeated.ProtoReflect.Descriptor instead.
-func (*WithNestedBlockOneLabelRepeated) Descriptor() ([]byte, []int) {
-	return file_testschema_proto_rawDescGZIP(), []int{21}
+// Deprecated: Use WithAttrRange.ProtoReflect.Descriptor instead.
+func (*WithAttrRange) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{23}
 }
 
-func (x *WithNestedBlockOneLabelRepeated) GetDoodad() []*WithOneBlockLabel {
+func (x *WithAttrRange) GetName() string {
 	if x != nil {
-		return x.Doodad
+		return x.Name
+	}
+	return ""
+}
+
+func (x *WithAttrRange) GetNameRange() *protohclext.SourceRange {
+	if x != nil {
+		return x.NameRange
 	}
 	return nil
 }
 
-type WithNestedBlockTwoLabelRepeated struct {
+type WithAttrRangeMissing struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// A nested block type with one label
-	Doodad []*WithTwoBlockLabels `protobuf:"bytes,1,rep,name=doodad,proto3" json:"doodad,omitempty"`
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 }
 
-func (x *WithNestedBlockTwoLabelRepeated) Reset() {
-	*x = WithNestedBlockTwoLabelRepeated{}
+func (x *WithAttrRangeMissing) Reset() {
+	*x = WithAttrRangeMissing{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_testschema_proto_msgTypes[22]
+		mi := &file_testschema_proto_msgTypes[24]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *WithNestedBlockTwoLabelRepeated) String() string {
+func (x *WithAttrRangeMissing) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*WithNestedBlockTwoLabelRepeated) ProtoMessage() {}
+func (*WithAttrRangeMissing) ProtoMessage() {}
 
-func (x *WithNestedBlockTwoLabelRepeated) ProtoReflect() protoreflect.Message {
-	mi := &file_testschema_proto_msgTypes[22]
+func (x *WithAttrRangeMissing) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithAttrRangeMissing.ProtoReflect.Descriptor instead.
+func (*WithAttrRangeMissing) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *WithAttrRangeMissing) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type WithAttrRangeWrongType struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name      string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	NameRange string `protobuf:"bytes,2,opt,name=name_range,json=nameRange,proto3" json:"name_range,omitempty"`
+}
+
+func (x *WithAttrRangeWrongType) Reset() {
+	*x = WithAttrRangeWrongType{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithAttrRangeWrongType) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithAttrRangeWrongType) ProtoMessage() {}
+
+func (x *WithAttrRangeWrongType) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithAttrRangeWrongType.ProtoReflect.Descriptor instead.
+func (*WithAttrRangeWrongType) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *WithAttrRangeWrongType) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *WithAttrRangeWrongType) GetNameRange() string {
+	if x != nil {
+		return x.NameRange
+	}
+	return ""
+}
+
+type WithEmptyAsNullAttrs struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name     string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Nickname string `protobuf:"bytes,2,opt,name=nickname,proto3" json:"nickname,omitempty"`
+}
+
+func (x *WithEmptyAsNullAttrs) Reset() {
+	*x = WithEmptyAsNullAttrs{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithEmptyAsNullAttrs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithEmptyAsNullAttrs) ProtoMessage() {}
+
+func (x *WithEmptyAsNullAttrs) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithEmptyAsNullAttrs.ProtoReflect.Descriptor instead.
+func (*WithEmptyAsNullAttrs) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *WithEmptyAsNullAttrs) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *WithEmptyAsNullAttrs) GetNickname() string {
+	if x != nil {
+		return x.Nickname
+	}
+	return ""
+}
+
+type WithEmptyAsNullList struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Invalid: empty_as_null is only for singular string fields.
+	Names []string `protobuf:"bytes,1,rep,name=names,proto3" json:"names,omitempty"`
+}
+
+func (x *WithEmptyAsNullList) Reset() {
+	*x = WithEmptyAsNullList{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithEmptyAsNullList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithEmptyAsNullList) ProtoMessage() {}
+
+func (x *WithEmptyAsNullList) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithEmptyAsNullList.ProtoReflect.Descriptor instead.
+func (*WithEmptyAsNullList) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *WithEmptyAsNullList) GetNames() []string {
+	if x != nil {
+		return x.Names
+	}
+	return nil
+}
+
+type WithNumberSyntaxAttrs struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Amount string `protobuf:"bytes,1,opt,name=amount,proto3" json:"amount,omitempty"`
+	Count  int64  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (x *WithNumberSyntaxAttrs) Reset() {
+	*x = WithNumberSyntaxAttrs{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithNumberSyntaxAttrs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithNumberSyntaxAttrs) ProtoMessage() {}
+
+func (x *WithNumberSyntaxAttrs) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithNumberSyntaxAttrs.ProtoReflect.Descriptor instead.
+func (*WithNumberSyntaxAttrs) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *WithNumberSyntaxAttrs) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *WithNumberSyntaxAttrs) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type WithNumberSyntaxString struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Invalid: number_syntax is only for attributes with a number type
+	// constraint.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *WithNumberSyntaxString) Reset() {
+	*x = WithNumberSyntaxString{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithNumberSyntaxString) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithNumberSyntaxString) ProtoMessage() {}
+
+func (x *WithNumberSyntaxString) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithNumberSyntaxString.ProtoReflect.Descriptor instead.
+func (*WithNumberSyntaxString) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *WithNumberSyntaxString) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type WithoutAnnotations struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// No HCL annotations at all, for DecodeOptions.InferAttributesFromJSONNames.
+	Name     string            `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	MaxCount int64             `protobuf:"varint,2,opt,name=max_count,json=maxCount,proto3" json:"max_count,omitempty"`
+	Tags     []string          `protobuf:"bytes,3,rep,name=tags,proto3" json:"tags,omitempty"`
+	Labels   map[string]string `protobuf:"bytes,4,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Nested   *WithStringAttr   `protobuf:"bytes,5,opt,name=nested,proto3" json:"nested,omitempty"`
+	Data     []byte            `protobuf:"bytes,6,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *WithoutAnnotations) Reset() {
+	*x = WithoutAnnotations{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithoutAnnotations) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithoutAnnotations) ProtoMessage() {}
+
+func (x *WithoutAnnotations) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[30]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
 		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use WithNestedBlockTwoLabelRepeated.ProtoReflect.Descriptor instead.
-func (*WithNestedBlockTwoLabelRepeated) Descriptor() ([]byte, []int) {
-	return file_testschema_proto_rawDescGZIP(), []int{22}
-}
-
-func (x *WithNestedBlockTwoLabelRepeated) GetDoodad() []*WithTwoBlockLabels {
-	if x != nil {
-		return x.Doodad
-	}
-	return nil
-}
-
-type WithOneBlockLabel struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	// Single "name" label
-	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	// Another field in case we also want to test attribute decoding
-	Nickname string `protobuf:"bytes,2,opt,name=nickname,proto3" json:"nickname,omitempty"`
-}
-
-func (x *WithOneBlockLabel) Reset() {
-	*x = WithOneBlockLabel{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_testschema_proto_msgTypes[23]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
-}
-
-func (x *WithOneBlockLabel) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*WithOneBlockLabel) ProtoMessage() {}
-
-func (x *WithOneBlockLabel) ProtoReflect() protoreflect.Message {
-	mi := &file_testschema_proto_msgTypes[23]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithoutAnnotations.ProtoReflect.Descriptor instead.
+func (*WithoutAnnotations) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *WithoutAnnotations) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *WithoutAnnotations) GetMaxCount() int64 {
+	if x != nil {
+		return x.MaxCount
+	}
+	return 0
+}
+
+func (x *WithoutAnnotations) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *WithoutAnnotations) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *WithoutAnnotations) GetNested() *WithStringAttr {
+	if x != nil {
+		return x.Nested
+	}
+	return nil
+}
+
+func (x *WithoutAnnotations) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type WithStringSetAttr struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Need to override the automatic type selection, which would choose
+	// list(string).
+	Names []string `protobuf:"bytes,1,rep,name=names,proto3" json:"names,omitempty"`
+}
+
+func (x *WithStringSetAttr) Reset() {
+	*x = WithStringSetAttr{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithStringSetAttr) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithStringSetAttr) ProtoMessage() {}
+
+func (x *WithStringSetAttr) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithStringSetAttr.ProtoReflect.Descriptor instead.
+func (*WithStringSetAttr) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *WithStringSetAttr) GetNames() []string {
+	if x != nil {
+		return x.Names
+	}
+	return nil
+}
+
+type WithStringMapAttr struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Automatic HCL type selection.
+	Names map[string]string `protobuf:"bytes,1,rep,name=names,proto3" json:"names,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *WithStringMapAttr) Reset() {
+	*x = WithStringMapAttr{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithStringMapAttr) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithStringMapAttr) ProtoMessage() {}
+
+func (x *WithStringMapAttr) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithStringMapAttr.ProtoReflect.Descriptor instead.
+func (*WithStringMapAttr) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *WithStringMapAttr) GetNames() map[string]string {
+	if x != nil {
+		return x.Names
+	}
+	return nil
+}
+
+type WithNumberMapAttrAsInt32 struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Automatic HCL type selection, with each element also constrained to
+	// the range of int32.
+	Nums map[string]int32 `protobuf:"bytes,1,rep,name=nums,proto3" json:"nums,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+}
+
+func (x *WithNumberMapAttrAsInt32) Reset() {
+	*x = WithNumberMapAttrAsInt32{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithNumberMapAttrAsInt32) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithNumberMapAttrAsInt32) ProtoMessage() {}
+
+func (x *WithNumberMapAttrAsInt32) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithNumberMapAttrAsInt32.ProtoReflect.Descriptor instead.
+func (*WithNumberMapAttrAsInt32) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *WithNumberMapAttrAsInt32) GetNums() map[string]int32 {
+	if x != nil {
+		return x.Nums
+	}
+	return nil
+}
+
+type WithEnumAttr struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Enum values are selected by strings in the configuration.
+	Level Level `protobuf:"varint,1,opt,name=level,proto3,enum=hcl.testschema.Level" json:"level,omitempty"`
+}
+
+func (x *WithEnumAttr) Reset() {
+	*x = WithEnumAttr{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithEnumAttr) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithEnumAttr) ProtoMessage() {}
+
+func (x *WithEnumAttr) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithEnumAttr.ProtoReflect.Descriptor instead.
+func (*WithEnumAttr) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *WithEnumAttr) GetLevel() Level {
+	if x != nil {
+		return x.Level
+	}
+	return Level_LEVEL_UNSPECIFIED
+}
+
+type WithEnumMapAttr struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Automatic HCL type selection, map(string).
+	Levels map[string]Level `protobuf:"bytes,1,rep,name=levels,proto3" json:"levels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3,enum=hcl.testschema.Level"`
+}
+
+func (x *WithEnumMapAttr) Reset() {
+	*x = WithEnumMapAttr{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithEnumMapAttr) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithEnumMapAttr) ProtoMessage() {}
+
+func (x *WithEnumMapAttr) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithEnumMapAttr.ProtoReflect.Descriptor instead.
+func (*WithEnumMapAttr) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *WithEnumMapAttr) GetLevels() map[string]Level {
+	if x != nil {
+		return x.Levels
+	}
+	return nil
+}
+
+type WithEnumListAttr struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Automatic HCL type selection, list(string).
+	Levels []Level `protobuf:"varint,1,rep,packed,name=levels,proto3,enum=hcl.testschema.Level" json:"levels,omitempty"`
+}
+
+func (x *WithEnumListAttr) Reset() {
+	*x = WithEnumListAttr{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithEnumListAttr) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithEnumListAttr) ProtoMessage() {}
+
+func (x *WithEnumListAttr) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithEnumListAttr.ProtoReflect.Descriptor instead.
+func (*WithEnumListAttr) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *WithEnumListAttr) GetLevels() []Level {
+	if x != nil {
+		return x.Levels
+	}
+	return nil
+}
+
+type WithFlattenStringAttr struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Base    *WithStringAttr `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	Species string          `protobuf:"bytes,2,opt,name=species,proto3" json:"species,omitempty"`
+}
+
+func (x *WithFlattenStringAttr) Reset() {
+	*x = WithFlattenStringAttr{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithFlattenStringAttr) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithFlattenStringAttr) ProtoMessage() {}
+
+func (x *WithFlattenStringAttr) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithFlattenStringAttr.ProtoReflect.Descriptor instead.
+func (*WithFlattenStringAttr) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *WithFlattenStringAttr) GetBase() *WithStringAttr {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+func (x *WithFlattenStringAttr) GetSpecies() string {
+	if x != nil {
+		return x.Species
+	}
+	return ""
+}
+
+type WithNestedFlattenStringAttr struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Base  *WithFlattenStringAttr `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	Breed string                 `protobuf:"bytes,3,opt,name=breed,proto3" json:"breed,omitempty"`
+}
+
+func (x *WithNestedFlattenStringAttr) Reset() {
+	*x = WithNestedFlattenStringAttr{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[38]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithNestedFlattenStringAttr) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithNestedFlattenStringAttr) ProtoMessage() {}
+
+func (x *WithNestedFlattenStringAttr) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[38]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithNestedFlattenStringAttr.ProtoReflect.Descriptor instead.
+func (*WithNestedFlattenStringAttr) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *WithNestedFlattenStringAttr) GetBase() *WithFlattenStringAttr {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+func (x *WithNestedFlattenStringAttr) GetBreed() string {
+	if x != nil {
+		return x.Breed
+	}
+	return ""
+}
+
+type WithNestedBlockNoLabelsSingleton struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// A nested block type no labels
+	Doodad *WithStringAttr `protobuf:"bytes,1,opt,name=doodad,proto3" json:"doodad,omitempty"`
+}
+
+func (x *WithNestedBlockNoLabelsSingleton) Reset() {
+	*x = WithNestedBlockNoLabelsSingleton{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[39]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithNestedBlockNoLabelsSingleton) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithNestedBlockNoLabelsSingleton) ProtoMessage() {}
+
+func (x *WithNestedBlockNoLabelsSingleton) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[39]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithNestedBlockNoLabelsSingleton.ProtoReflect.Descriptor instead.
+func (*WithNestedBlockNoLabelsSingleton) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *WithNestedBlockNoLabelsSingleton) GetDoodad() *WithStringAttr {
+	if x != nil {
+		return x.Doodad
+	}
+	return nil
+}
+
+type WithNestedBlockOneLabelSingleton struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// A nested block type with one label
+	Doodad *WithOneBlockLabel `protobuf:"bytes,1,opt,name=doodad,proto3" json:"doodad,omitempty"`
+}
+
+func (x *WithNestedBlockOneLabelSingleton) Reset() {
+	*x = WithNestedBlockOneLabelSingleton{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[40]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithNestedBlockOneLabelSingleton) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithNestedBlockOneLabelSingleton) ProtoMessage() {}
+
+func (x *WithNestedBlockOneLabelSingleton) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[40]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithNestedBlockOneLabelSingleton.ProtoReflect.Descriptor instead.
+func (*WithNestedBlockOneLabelSingleton) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *WithNestedBlockOneLabelSingleton) GetDoodad() *WithOneBlockLabel {
+	if x != nil {
+		return x.Doodad
+	}
+	return nil
+}
+
+type WithNestedBlockTwoLabelSingleton struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// A nested block type with one label
+	Doodad *WithTwoBlockLabels `protobuf:"bytes,1,opt,name=doodad,proto3" json:"doodad,omitempty"`
+}
+
+func (x *WithNestedBlockTwoLabelSingleton) Reset() {
+	*x = WithNestedBlockTwoLabelSingleton{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[41]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithNestedBlockTwoLabelSingleton) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithNestedBlockTwoLabelSingleton) ProtoMessage() {}
+
+func (x *WithNestedBlockTwoLabelSingleton) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[41]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithNestedBlockTwoLabelSingleton.ProtoReflect.Descriptor instead.
+func (*WithNestedBlockTwoLabelSingleton) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *WithNestedBlockTwoLabelSingleton) GetDoodad() *WithTwoBlockLabels {
+	if x != nil {
+		return x.Doodad
+	}
+	return nil
+}
+
+type WithNestedBlockNoLabelsRepeated struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// A nested block type no labels
+	Doodad []*WithStringAttr `protobuf:"bytes,1,rep,name=doodad,proto3" json:"doodad,omitempty"`
+}
+
+func (x *WithNestedBlockNoLabelsRepeated) Reset() {
+	*x = WithNestedBlockNoLabelsRepeated{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[42]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithNestedBlockNoLabelsRepeated) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithNestedBlockNoLabelsRepeated) ProtoMessage() {}
+
+func (x *WithNestedBlockNoLabelsRepeated) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[42]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithNestedBlockNoLabelsRepeated.ProtoReflect.Descriptor instead.
+func (*WithNestedBlockNoLabelsRepeated) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *WithNestedBlockNoLabelsRepeated) GetDoodad() []*WithStringAttr {
+	if x != nil {
+		return x.Doodad
+	}
+	return nil
+}
+
+type WithNestedBlockOneLabelRepeated struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// A nested block type with one label
+	Doodad []*WithOneBlockLabel `protobuf:"bytes,1,rep,name=doodad,proto3" json:"doodad,omitempty"`
+}
+
+func (x *WithNestedBlockOneLabelRepeated) Reset() {
+	*x = WithNestedBlockOneLabelRepeated{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[43]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithNestedBlockOneLabelRepeated) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithNestedBlockOneLabelRepeated) ProtoMessage() {}
+
+func (x *WithNestedBlockOneLabelRepeated) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[43]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithNestedBlockOneLabelRepeated.ProtoReflect.Descriptor instead.
+func (*WithNestedBlockOneLabelRepeated) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *WithNestedBlockOneLabelRepeated) GetDoodad() []*WithOneBlockLabel {
+	if x != nil {
+		return x.Doodad
+	}
+	return nil
+}
+
+type WithNestedBlockTwoLabelRepeated struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// A nested block type with one label
+	Doodad []*WithTwoBlockLabels `protobuf:"bytes,1,rep,name=doodad,proto3" json:"doodad,omitempty"`
+}
+
+func (x *WithNestedBlockTwoLabelRepeated) Reset() {
+	*x = WithNestedBlockTwoLabelRepeated{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[44]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithNestedBlockTwoLabelRepeated) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithNestedBlockTwoLabelRepeated) ProtoMessage() {}
+
+func (x *WithNestedBlockTwoLabelRepeated) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[44]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithNestedBlockTwoLabelRepeated.ProtoReflect.Descriptor instead.
+func (*WithNestedBlockTwoLabelRepeated) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *WithNestedBlockTwoLabelRepeated) GetDoodad() []*WithTwoBlockLabels {
+	if x != nil {
+		return x.Doodad
+	}
+	return nil
+}
+
+type WithNestedBlockOneLabelDynamic struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// A nested block type with one label and an attribute of any type
+	Doodad []*WithOneBlockLabelDynamic `protobuf:"bytes,1,rep,name=doodad,proto3" json:"doodad,omitempty"`
+}
+
+func (x *WithNestedBlockOneLabelDynamic) Reset() {
+	*x = WithNestedBlockOneLabelDynamic{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[45]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithNestedBlockOneLabelDynamic) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithNestedBlockOneLabelDynamic) ProtoMessage() {}
+
+func (x *WithNestedBlockOneLabelDynamic) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[45]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithNestedBlockOneLabelDynamic.ProtoReflect.Descriptor instead.
+func (*WithNestedBlockOneLabelDynamic) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *WithNestedBlockOneLabelDynamic) GetDoodad() []*WithOneBlockLabelDynamic {
+	if x != nil {
+		return x.Doodad
+	}
+	return nil
+}
+
+type WithOneBlockLabelDynamic struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name  string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Value []byte `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *WithOneBlockLabelDynamic) Reset() {
+	*x = WithOneBlockLabelDynamic{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[46]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithOneBlockLabelDynamic) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithOneBlockLabelDynamic) ProtoMessage() {}
+
+func (x *WithOneBlockLabelDynamic) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[46]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithOneBlockLabelDynamic.ProtoReflect.Descriptor instead.
+func (*WithOneBlockLabelDynamic) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *WithOneBlockLabelDynamic) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *WithOneBlockLabelDynamic) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+type WithNestedBlockFlattenedLabels struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// A nested block type whose labels partly come from a flattened message
+	Doodad []*WithFlattenedBlockLabel `protobuf:"bytes,1,rep,name=doodad,proto3" json:"doodad,omitempty"`
+}
+
+func (x *WithNestedBlockFlattenedLabels) Reset() {
+	*x = WithNestedBlockFlattenedLabels{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[47]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithNestedBlockFlattenedLabels) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithNestedBlockFlattenedLabels) ProtoMessage() {}
+
+func (x *WithNestedBlockFlattenedLabels) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[47]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithNestedBlockFlattenedLabels.ProtoReflect.Descriptor instead.
+func (*WithNestedBlockFlattenedLabels) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *WithNestedBlockFlattenedLabels) GetDoodad() []*WithFlattenedBlockLabel {
+	if x != nil {
+		return x.Doodad
+	}
+	return nil
+}
+
+type WithFlattenedBlockLabel struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	// The "name" label and "nickname" attribute come from the base message.
+	Base    *WithOneBlockLabel `protobuf:"bytes,2,opt,name=base,proto3" json:"base,omitempty"`
+	Species string             `protobuf:"bytes,3,opt,name=species,proto3" json:"species,omitempty"`
+}
+
+func (x *WithFlattenedBlockLabel) Reset() {
+	*x = WithFlattenedBlockLabel{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[48]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithFlattenedBlockLabel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithFlattenedBlockLabel) ProtoMessage() {}
+
+func (x *WithFlattenedBlockLabel) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[48]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithFlattenedBlockLabel.ProtoReflect.Descriptor instead.
+func (*WithFlattenedBlockLabel) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *WithFlattenedBlockLabel) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *WithFlattenedBlockLabel) GetBase() *WithOneBlockLabel {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+func (x *WithFlattenedBlockLabel) GetSpecies() string {
+	if x != nil {
+		return x.Species
+	}
+	return ""
+}
+
+type WithNestedBlockConflictingLabels struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// A nested block type whose labels are invalid
+	Doodad *WithConflictingBlockLabels `protobuf:"bytes,1,opt,name=doodad,proto3" json:"doodad,omitempty"`
+}
+
+func (x *WithNestedBlockConflictingLabels) Reset() {
+	*x = WithNestedBlockConflictingLabels{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[49]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithNestedBlockConflictingLabels) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithNestedBlockConflictingLabels) ProtoMessage() {}
+
+func (x *WithNestedBlockConflictingLabels) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[49]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithNestedBlockConflictingLabels.ProtoReflect.Descriptor instead.
+func (*WithNestedBlockConflictingLabels) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *WithNestedBlockConflictingLabels) GetDoodad() *WithConflictingBlockLabels {
+	if x != nil {
+		return x.Doodad
+	}
+	return nil
+}
+
+type WithConflictingBlockLabels struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Invalid: the base message also declares a "name" label.
+	Base *WithOneBlockLabel `protobuf:"bytes,2,opt,name=base,proto3" json:"base,omitempty"`
+}
+
+func (x *WithConflictingBlockLabels) Reset() {
+	*x = WithConflictingBlockLabels{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[50]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithConflictingBlockLabels) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithConflictingBlockLabels) ProtoMessage() {}
+
+func (x *WithConflictingBlockLabels) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[50]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithConflictingBlockLabels.ProtoReflect.Descriptor instead.
+func (*WithConflictingBlockLabels) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *WithConflictingBlockLabels) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *WithConflictingBlockLabels) GetBase() *WithOneBlockLabel {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+type WithSameBlockTypeNested struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The "item" block type here has a different message than the "item"
+	// block type nested inside it.
+	Item *SameBlockTypeOuter `protobuf:"bytes,1,opt,name=item,proto3" json:"item,omitempty"`
+}
+
+func (x *WithSameBlockTypeNested) Reset() {
+	*x = WithSameBlockTypeNested{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[51]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithSameBlockTypeNested) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithSameBlockTypeNested) ProtoMessage() {}
+
+func (x *WithSameBlockTypeNested) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[51]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithSameBlockTypeNested.ProtoReflect.Descriptor instead.
+func (*WithSameBlockTypeNested) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *WithSameBlockTypeNested) GetItem() *SameBlockTypeOuter {
+	if x != nil {
+		return x.Item
+	}
+	return nil
+}
+
+type SameBlockTypeOuter struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string              `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Item *SameBlockTypeInner `protobuf:"bytes,2,opt,name=item,proto3" json:"item,omitempty"`
+}
+
+func (x *SameBlockTypeOuter) Reset() {
+	*x = SameBlockTypeOuter{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[52]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SameBlockTypeOuter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SameBlockTypeOuter) ProtoMessage() {}
+
+func (x *SameBlockTypeOuter) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[52]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SameBlockTypeOuter.ProtoReflect.Descriptor instead.
+func (*SameBlockTypeOuter) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *SameBlockTypeOuter) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SameBlockTypeOuter) GetItem() *SameBlockTypeInner {
+	if x != nil {
+		return x.Item
+	}
+	return nil
+}
+
+type SameBlockTypeInner struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Size int64 `protobuf:"varint,1,opt,name=size,proto3" json:"size,omitempty"`
+}
+
+func (x *SameBlockTypeInner) Reset() {
+	*x = SameBlockTypeInner{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[53]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SameBlockTypeInner) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SameBlockTypeInner) ProtoMessage() {}
+
+func (x *SameBlockTypeInner) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[53]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SameBlockTypeInner.ProtoReflect.Descriptor instead.
+func (*SameBlockTypeInner) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *SameBlockTypeInner) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+type RecursiveBlock struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name  string            `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Extra []byte            `protobuf:"bytes,2,opt,name=extra,proto3" json:"extra,omitempty"`
+	Child []*RecursiveBlock `protobuf:"bytes,3,rep,name=child,proto3" json:"child,omitempty"`
+}
+
+func (x *RecursiveBlock) Reset() {
+	*x = RecursiveBlock{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[54]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RecursiveBlock) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecursiveBlock) ProtoMessage() {}
+
+func (x *RecursiveBlock) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[54]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecursiveBlock.ProtoReflect.Descriptor instead.
+func (*RecursiveBlock) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *RecursiveBlock) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RecursiveBlock) GetExtra() []byte {
+	if x != nil {
+		return x.Extra
+	}
+	return nil
+}
+
+func (x *RecursiveBlock) GetChild() []*RecursiveBlock {
+	if x != nil {
+		return x.Child
+	}
+	return nil
+}
+
+type WithInvalidNestedBlocks struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Both of these block types use the same invalid message type.
+	A *InvalidBlockBody `protobuf:"bytes,1,opt,name=a,proto3" json:"a,omitempty"`
+	B *InvalidBlockBody `protobuf:"bytes,2,opt,name=b,proto3" json:"b,omitempty"`
+}
+
+func (x *WithInvalidNestedBlocks) Reset() {
+	*x = WithInvalidNestedBlocks{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[55]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithInvalidNestedBlocks) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithInvalidNestedBlocks) ProtoMessage() {}
+
+func (x *WithInvalidNestedBlocks) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[55]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithInvalidNestedBlocks.ProtoReflect.Descriptor instead.
+func (*WithInvalidNestedBlocks) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *WithInvalidNestedBlocks) GetA() *InvalidBlockBody {
+	if x != nil {
+		return x.A
+	}
+	return nil
+}
+
+func (x *WithInvalidNestedBlocks) GetB() *InvalidBlockBody {
+	if x != nil {
+		return x.B
+	}
+	return nil
+}
+
+type WithRepeatedInvalidBlocks struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Each "item" block reports the same problems with its schema.
+	Item       []*InvalidBlockBody       `protobuf:"bytes,1,rep,name=item,proto3" json:"item,omitempty"`
+	Mismatched []*WithMismatchedAttrType `protobuf:"bytes,2,rep,name=mismatched,proto3" json:"mismatched,omitempty"`
+}
+
+func (x *WithRepeatedInvalidBlocks) Reset() {
+	*x = WithRepeatedInvalidBlocks{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[56]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithRepeatedInvalidBlocks) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithRepeatedInvalidBlocks) ProtoMessage() {}
+
+func (x *WithRepeatedInvalidBlocks) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[56]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithRepeatedInvalidBlocks.ProtoReflect.Descriptor instead.
+func (*WithRepeatedInvalidBlocks) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *WithRepeatedInvalidBlocks) GetItem() []*InvalidBlockBody {
+	if x != nil {
+		return x.Item
+	}
+	return nil
+}
+
+func (x *WithRepeatedInvalidBlocks) GetMismatched() []*WithMismatchedAttrType {
+	if x != nil {
+		return x.Mismatched
+	}
+	return nil
+}
+
+type RootOnlyConfig struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *RootOnlyConfig) Reset() {
+	*x = RootOnlyConfig{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[57]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RootOnlyConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RootOnlyConfig) ProtoMessage() {}
+
+func (x *RootOnlyConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[57]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RootOnlyConfig.ProtoReflect.Descriptor instead.
+func (*RootOnlyConfig) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *RootOnlyConfig) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type WithRootOnlyNestedBlock struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Invalid: RootOnlyConfig can't be used as a nested block type.
+	Config *RootOnlyConfig `protobuf:"bytes,1,opt,name=config,proto3" json:"config,omitempty"`
+}
+
+func (x *WithRootOnlyNestedBlock) Reset() {
+	*x = WithRootOnlyNestedBlock{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[58]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithRootOnlyNestedBlock) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithRootOnlyNestedBlock) ProtoMessage() {}
+
+func (x *WithRootOnlyNestedBlock) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[58]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithRootOnlyNestedBlock.ProtoReflect.Descriptor instead.
+func (*WithRootOnlyNestedBlock) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *WithRootOnlyNestedBlock) GetConfig() *RootOnlyConfig {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+type InvalidBlockBody struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Invalid: conflicts with the field above.
+	OtherName string `protobuf:"bytes,2,opt,name=other_name,json=otherName,proto3" json:"other_name,omitempty"`
+}
+
+func (x *InvalidBlockBody) Reset() {
+	*x = InvalidBlockBody{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[59]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *InvalidBlockBody) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InvalidBlockBody) ProtoMessage() {}
+
+func (x *InvalidBlockBody) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[59]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InvalidBlockBody.ProtoReflect.Descriptor instead.
+func (*InvalidBlockBody) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *InvalidBlockBody) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *InvalidBlockBody) GetOtherName() string {
+	if x != nil {
+		return x.OtherName
+	}
+	return ""
+}
+
+type WithOneBlockLabel struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Single "name" label
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Another field in case we also want to test attribute decoding
+	Nickname string `protobuf:"bytes,2,opt,name=nickname,proto3" json:"nickname,omitempty"`
+}
+
+func (x *WithOneBlockLabel) Reset() {
+	*x = WithOneBlockLabel{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[60]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithOneBlockLabel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithOneBlockLabel) ProtoMessage() {}
+
+func (x *WithOneBlockLabel) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[60]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithOneBlockLabel.ProtoReflect.Descriptor instead.
+func (*WithOneBlockLabel) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *WithOneBlockLabel) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *WithOneBlockLabel) GetNickname() string {
+	if x != nil {
+		return x.Nickname
+	}
+	return ""
+}
+
+type WithTwoBlockLabels struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Another field in case we also want to test attribute decoding
+	Nickname string `protobuf:"bytes,3,opt,name=nickname,proto3" json:"nickname,omitempty"`
+}
+
+func (x *WithTwoBlockLabels) Reset() {
+	*x = WithTwoBlockLabels{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[61]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithTwoBlockLabels) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithTwoBlockLabels) ProtoMessage() {}
+
+func (x *WithTwoBlockLabels) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[61]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithTwoBlockLabels.ProtoReflect.Descriptor instead.
+func (*WithTwoBlockLabels) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *WithTwoBlockLabels) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *WithTwoBlockLabels) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *WithTwoBlockLabels) GetNickname() string {
+	if x != nil {
+		return x.Nickname
+	}
+	return ""
+}
+
+type WithDescribedBlockLabels struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	// This label has no description, so it's not mentioned in the
+	// explanation.
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *WithDescribedBlockLabels) Reset() {
+	*x = WithDescribedBlockLabels{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[62]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithDescribedBlockLabels) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithDescribedBlockLabels) ProtoMessage() {}
+
+func (x *WithDescribedBlockLabels) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[62]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithDescribedBlockLabels.ProtoReflect.Descriptor instead.
+func (*WithDescribedBlockLabels) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *WithDescribedBlockLabels) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *WithDescribedBlockLabels) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type WithNestedBlockDescribedLabels struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Doodad []*WithDescribedBlockLabels `protobuf:"bytes,1,rep,name=doodad,proto3" json:"doodad,omitempty"`
+}
+
+func (x *WithNestedBlockDescribedLabels) Reset() {
+	*x = WithNestedBlockDescribedLabels{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[63]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithNestedBlockDescribedLabels) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithNestedBlockDescribedLabels) ProtoMessage() {}
+
+func (x *WithNestedBlockDescribedLabels) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[63]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithNestedBlockDescribedLabels.ProtoReflect.Descriptor instead.
+func (*WithNestedBlockDescribedLabels) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *WithNestedBlockDescribedLabels) GetDoodad() []*WithDescribedBlockLabels {
+	if x != nil {
+		return x.Doodad
+	}
+	return nil
+}
+
+type WithMapOfBlocks struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pets map[string]*WithStringAttr `protobuf:"bytes,1,rep,name=pets,proto3" json:"pets,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *WithMapOfBlocks) Reset() {
+	*x = WithMapOfBlocks{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[64]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithMapOfBlocks) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithMapOfBlocks) ProtoMessage() {}
+
+func (x *WithMapOfBlocks) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[64]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithMapOfBlocks.ProtoReflect.Descriptor instead.
+func (*WithMapOfBlocks) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *WithMapOfBlocks) GetPets() map[string]*WithStringAttr {
+	if x != nil {
+		return x.Pets
+	}
+	return nil
+}
+
+type WithMapOfObjectsAttr struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pets map[string]*WithStringAttr `protobuf:"bytes,1,rep,name=pets,proto3" json:"pets,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *WithMapOfObjectsAttr) Reset() {
+	*x = WithMapOfObjectsAttr{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[65]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithMapOfObjectsAttr) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithMapOfObjectsAttr) ProtoMessage() {}
+
+func (x *WithMapOfObjectsAttr) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[65]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithMapOfObjectsAttr.ProtoReflect.Descriptor instead.
+func (*WithMapOfObjectsAttr) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *WithMapOfObjectsAttr) GetPets() map[string]*WithStringAttr {
+	if x != nil {
+		return x.Pets
+	}
+	return nil
+}
+
+type WithObjectAttr struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// An annotated message type as a single attribute, rather than a block.
+	Settings *WithOptionalAttrs `protobuf:"bytes,1,opt,name=settings,proto3" json:"settings,omitempty"`
+}
+
+func (x *WithObjectAttr) Reset() {
+	*x = WithObjectAttr{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[66]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithObjectAttr) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithObjectAttr) ProtoMessage() {}
+
+func (x *WithObjectAttr) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[66]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithObjectAttr.ProtoReflect.Descriptor instead.
+func (*WithObjectAttr) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *WithObjectAttr) GetSettings() *WithOptionalAttrs {
+	if x != nil {
+		return x.Settings
+	}
+	return nil
+}
+
+type WithNestedObjectsAttr struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Elements of an object attribute can themselves contain lists and maps
+	// of annotated message types.
+	Groups []*WithMapOfObjectsAttr `protobuf:"bytes,1,rep,name=groups,proto3" json:"groups,omitempty"`
+}
+
+func (x *WithNestedObjectsAttr) Reset() {
+	*x = WithNestedObjectsAttr{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[67]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithNestedObjectsAttr) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithNestedObjectsAttr) ProtoMessage() {}
+
+func (x *WithNestedObjectsAttr) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[67]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithNestedObjectsAttr.ProtoReflect.Descriptor instead.
+func (*WithNestedObjectsAttr) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *WithNestedObjectsAttr) GetGroups() []*WithMapOfObjectsAttr {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+type WithListOfObjectsAttr struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Items []*WithOptionalAttrs `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+}
+
+func (x *WithListOfObjectsAttr) Reset() {
+	*x = WithListOfObjectsAttr{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[68]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithListOfObjectsAttr) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithListOfObjectsAttr) ProtoMessage() {}
+
+func (x *WithListOfObjectsAttr) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[68]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithListOfObjectsAttr.ProtoReflect.Descriptor instead.
+func (*WithListOfObjectsAttr) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *WithListOfObjectsAttr) GetItems() []*WithOptionalAttrs {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type WithTupleOfObjectsAttr struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Items []*WithRawDynamicAttr `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+}
+
+func (x *WithTupleOfObjectsAttr) Reset() {
+	*x = WithTupleOfObjectsAttr{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[69]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithTupleOfObjectsAttr) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithTupleOfObjectsAttr) ProtoMessage() {}
+
+func (x *WithTupleOfObjectsAttr) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[69]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithTupleOfObjectsAttr.ProtoReflect.Descriptor instead.
+func (*WithTupleOfObjectsAttr) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *WithTupleOfObjectsAttr) GetItems() []*WithRawDynamicAttr {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type WithSetOfObjectsAttr struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Items []*WithStringAttr `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+}
+
+func (x *WithSetOfObjectsAttr) Reset() {
+	*x = WithSetOfObjectsAttr{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[70]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithSetOfObjectsAttr) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithSetOfObjectsAttr) ProtoMessage() {}
+
+func (x *WithSetOfObjectsAttr) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[70]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithSetOfObjectsAttr.ProtoReflect.Descriptor instead.
+func (*WithSetOfObjectsAttr) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *WithSetOfObjectsAttr) GetItems() []*WithStringAttr {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type WithListOfDynamicObjectsAttr struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Invalid: the elements can't all have the same type.
+	Items []*WithRawDynamicAttr `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+}
+
+func (x *WithListOfDynamicObjectsAttr) Reset() {
+	*x = WithListOfDynamicObjectsAttr{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[71]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithListOfDynamicObjectsAttr) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithListOfDynamicObjectsAttr) ProtoMessage() {}
+
+func (x *WithListOfDynamicObjectsAttr) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[71]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithListOfDynamicObjectsAttr.ProtoReflect.Descriptor instead.
+func (*WithListOfDynamicObjectsAttr) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *WithListOfDynamicObjectsAttr) GetItems() []*WithRawDynamicAttr {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type WithCollectionKindScalarAttr struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Invalid: kind is only for repeated message-typed fields.
+	Items []string `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+}
+
+func (x *WithCollectionKindScalarAttr) Reset() {
+	*x = WithCollectionKindScalarAttr{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[72]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithCollectionKindScalarAttr) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithCollectionKindScalarAttr) ProtoMessage() {}
+
+func (x *WithCollectionKindScalarAttr) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[72]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithCollectionKindScalarAttr.ProtoReflect.Descriptor instead.
+func (*WithCollectionKindScalarAttr) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *WithCollectionKindScalarAttr) GetItems() []string {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type WithOptionalAttrs struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name  string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Count int64  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (x *WithOptionalAttrs) Reset() {
+	*x = WithOptionalAttrs{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[73]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithOptionalAttrs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithOptionalAttrs) ProtoMessage() {}
+
+func (x *WithOptionalAttrs) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[73]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithOptionalAttrs.ProtoReflect.Descriptor instead.
+func (*WithOptionalAttrs) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *WithOptionalAttrs) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *WithOptionalAttrs) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type WithBlockMessageAsAttr struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Invalid: WithNestedBlockNoLabelsSingleton declares a nested block type,
+	// which can't appear inside an attribute.
+	Thing *WithNestedBlockNoLabelsSingleton `protobuf:"bytes,1,opt,name=thing,proto3" json:"thing,omitempty"`
+}
+
+func (x *WithBlockMessageAsAttr) Reset() {
+	*x = WithBlockMessageAsAttr{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[74]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithBlockMessageAsAttr) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithBlockMessageAsAttr) ProtoMessage() {}
+
+func (x *WithBlockMessageAsAttr) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[74]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithBlockMessageAsAttr.ProtoReflect.Descriptor instead.
+func (*WithBlockMessageAsAttr) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *WithBlockMessageAsAttr) GetThing() *WithNestedBlockNoLabelsSingleton {
+	if x != nil {
+		return x.Thing
+	}
+	return nil
+}
+
+type WithMapOfScalarsAsBlocks struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Invalid: a nested block type must have a message-typed map value.
+	Things map[string]string `protobuf:"bytes,1,rep,name=things,proto3" json:"things,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *WithMapOfScalarsAsBlocks) Reset() {
+	*x = WithMapOfScalarsAsBlocks{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[75]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithMapOfScalarsAsBlocks) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithMapOfScalarsAsBlocks) ProtoMessage() {}
+
+func (x *WithMapOfScalarsAsBlocks) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[75]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithMapOfScalarsAsBlocks.ProtoReflect.Descriptor instead.
+func (*WithMapOfScalarsAsBlocks) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *WithMapOfScalarsAsBlocks) GetThings() map[string]string {
+	if x != nil {
+		return x.Things
+	}
+	return nil
+}
+
+type WithSchemaWarnings struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Unconventional name, but still valid.
+	DisplayName string `protobuf:"bytes,1,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"`
+	// Not annotated at all, so can't be populated from configuration.
+	InternalId string `protobuf:"bytes,2,opt,name=internal_id,json=internalId,proto3" json:"internal_id,omitempty"`
+	Color      Color  `protobuf:"varint,3,opt,name=color,proto3,enum=hcl.testschema.Color" json:"color,omitempty"`
+}
+
+func (x *WithSchemaWarnings) Reset() {
+	*x = WithSchemaWarnings{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[76]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithSchemaWarnings) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithSchemaWarnings) ProtoMessage() {}
+
+func (x *WithSchemaWarnings) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[76]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithSchemaWarnings.ProtoReflect.Descriptor instead.
+func (*WithSchemaWarnings) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *WithSchemaWarnings) GetDisplayName() string {
+	if x != nil {
+		return x.DisplayName
+	}
+	return ""
+}
+
+func (x *WithSchemaWarnings) GetInternalId() string {
+	if x != nil {
+		return x.InternalId
+	}
+	return ""
+}
+
+func (x *WithSchemaWarnings) GetColor() Color {
+	if x != nil {
+		return x.Color
+	}
+	return Color_COLOR_UNSPECIFIED
+}
+
+type WithLabelAttrOptions struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Labels are always required, and the other attribute options are
+	// ignored, so these options are misleading but not invalid.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *WithLabelAttrOptions) Reset() {
+	*x = WithLabelAttrOptions{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[77]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithLabelAttrOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithLabelAttrOptions) ProtoMessage() {}
+
+func (x *WithLabelAttrOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[77]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithLabelAttrOptions.ProtoReflect.Descriptor instead.
+func (*WithLabelAttrOptions) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *WithLabelAttrOptions) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type LabelOrderBase struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Kind string `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+}
+
+func (x *LabelOrderBase) Reset() {
+	*x = LabelOrderBase{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[78]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LabelOrderBase) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LabelOrderBase) ProtoMessage() {}
+
+func (x *LabelOrderBase) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[78]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LabelOrderBase.ProtoReflect.Descriptor instead.
+func (*LabelOrderBase) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *LabelOrderBase) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+type WithFlattenLabelOrder struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The "kind" label from the flattened message comes before "name",
+	// because this field is declared first.
+	Base *LabelOrderBase `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	Name string          `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *WithFlattenLabelOrder) Reset() {
+	*x = WithFlattenLabelOrder{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[79]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithFlattenLabelOrder) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithFlattenLabelOrder) ProtoMessage() {}
+
+func (x *WithFlattenLabelOrder) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[79]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithFlattenLabelOrder.ProtoReflect.Descriptor instead.
+func (*WithFlattenLabelOrder) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *WithFlattenLabelOrder) GetBase() *LabelOrderBase {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+func (x *WithFlattenLabelOrder) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type WithMismatchedAttrType struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Invalid: a list type constraint can't decode into a singleton field.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *WithMismatchedAttrType) Reset() {
+	*x = WithMismatchedAttrType{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[80]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithMismatchedAttrType) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithMismatchedAttrType) ProtoMessage() {}
+
+func (x *WithMismatchedAttrType) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[80]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithMismatchedAttrType.ProtoReflect.Descriptor instead.
+func (*WithMismatchedAttrType) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *WithMismatchedAttrType) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type WithMismatchedStructAttrTypes struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Invalid: a map of google.protobuf.Value needs a map or object type
+	// constraint.
+	ByKey map[string]*structpb.Value `protobuf:"bytes,1,rep,name=by_key,json=byKey,proto3" json:"by_key,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Invalid: a repeated google.protobuf.Value needs a collection type
+	// constraint, even with allow_scalar_for_list.
+	Items []*structpb.Value `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+}
+
+func (x *WithMismatchedStructAttrTypes) Reset() {
+	*x = WithMismatchedStructAttrTypes{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[81]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithMismatchedStructAttrTypes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithMismatchedStructAttrTypes) ProtoMessage() {}
+
+func (x *WithMismatchedStructAttrTypes) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[81]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithMismatchedStructAttrTypes.ProtoReflect.Descriptor instead.
+func (*WithMismatchedStructAttrTypes) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{81}
+}
+
+func (x *WithMismatchedStructAttrTypes) GetByKey() map[string]*structpb.Value {
+	if x != nil {
+		return x.ByKey
+	}
+	return nil
+}
+
+func (x *WithMismatchedStructAttrTypes) GetItems() []*structpb.Value {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type WithNestedMismatchedStructAttrType struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Invalid, because the message type has an invalid attribute.
+	Nested []*WithMismatchedStructAttrTypes `protobuf:"bytes,1,rep,name=nested,proto3" json:"nested,omitempty"`
+}
+
+func (x *WithNestedMismatchedStructAttrType) Reset() {
+	*x = WithNestedMismatchedStructAttrType{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[82]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithNestedMismatchedStructAttrType) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithNestedMismatchedStructAttrType) ProtoMessage() {}
+
+func (x *WithNestedMismatchedStructAttrType) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[82]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithNestedMismatchedStructAttrType.ProtoReflect.Descriptor instead.
+func (*WithNestedMismatchedStructAttrType) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{82}
+}
+
+func (x *WithNestedMismatchedStructAttrType) GetNested() []*WithMismatchedStructAttrTypes {
+	if x != nil {
+		return x.Nested
+	}
+	return nil
+}
+
+// SharedBlockBody is the body of nested block types with different
+// collection kinds in different parents, to make sure that we always take
+// the kind from the field rather than from the message type.
+type SharedBlockBody struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name  string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Value string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *SharedBlockBody) Reset() {
+	*x = SharedBlockBody{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[83]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SharedBlockBody) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SharedBlockBody) ProtoMessage() {}
+
+func (x *SharedBlockBody) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[83]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SharedBlockBody.ProtoReflect.Descriptor instead.
+func (*SharedBlockBody) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{83}
+}
+
+func (x *SharedBlockBody) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SharedBlockBody) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+type WithSharedBlockBodyList struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Item []*SharedBlockBody `protobuf:"bytes,1,rep,name=item,proto3" json:"item,omitempty"`
+}
+
+func (x *WithSharedBlockBodyList) Reset() {
+	*x = WithSharedBlockBodyList{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[84]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithSharedBlockBodyList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithSharedBlockBodyList) ProtoMessage() {}
+
+func (x *WithSharedBlockBodyList) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[84]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithSharedBlockBodyList.ProtoReflect.Descriptor instead.
+func (*WithSharedBlockBodyList) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{84}
+}
+
+func (x *WithSharedBlockBodyList) GetItem() []*SharedBlockBody {
+	if x != nil {
+		return x.Item
+	}
+	return nil
+}
+
+type WithSharedBlockBodySet struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Item []*SharedBlockBody `protobuf:"bytes,1,rep,name=item,proto3" json:"item,omitempty"`
+}
+
+func (x *WithSharedBlockBodySet) Reset() {
+	*x = WithSharedBlockBodySet{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[85]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithSharedBlockBodySet) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithSharedBlockBodySet) ProtoMessage() {}
+
+func (x *WithSharedBlockBodySet) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[85]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithSharedBlockBodySet.ProtoReflect.Descriptor instead.
+func (*WithSharedBlockBodySet) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{85}
+}
+
+func (x *WithSharedBlockBodySet) GetItem() []*SharedBlockBody {
+	if x != nil {
+		return x.Item
+	}
+	return nil
+}
+
+type WithSharedBlockBodyKinds struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	List *WithSharedBlockBodyList `protobuf:"bytes,1,opt,name=list,proto3" json:"list,omitempty"`
+	Set  *WithSharedBlockBodySet  `protobuf:"bytes,2,opt,name=set,proto3" json:"set,omitempty"`
+	Item []*SharedBlockBody       `protobuf:"bytes,3,rep,name=item,proto3" json:"item,omitempty"`
+}
+
+func (x *WithSharedBlockBodyKinds) Reset() {
+	*x = WithSharedBlockBodyKinds{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[86]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithSharedBlockBodyKinds) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithSharedBlockBodyKinds) ProtoMessage() {}
+
+func (x *WithSharedBlockBodyKinds) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[86]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithSharedBlockBodyKinds.ProtoReflect.Descriptor instead.
+func (*WithSharedBlockBodyKinds) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{86}
+}
+
+func (x *WithSharedBlockBodyKinds) GetList() *WithSharedBlockBodyList {
+	if x != nil {
+		return x.List
+	}
+	return nil
+}
+
+func (x *WithSharedBlockBodyKinds) GetSet() *WithSharedBlockBodySet {
+	if x != nil {
+		return x.Set
+	}
+	return nil
+}
+
+func (x *WithSharedBlockBodyKinds) GetItem() []*SharedBlockBody {
+	if x != nil {
+		return x.Item
+	}
+	return nil
+}
+
+// SharedDynamicBlockBody can be a TUPLE block type but not a SET block type,
+// because of its "any" attribute.
+type SharedDynamicBlockBody struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Raw []byte `protobuf:"bytes,1,opt,name=raw,proto3" json:"raw,omitempty"`
+}
+
+func (x *SharedDynamicBlockBody) Reset() {
+	*x = SharedDynamicBlockBody{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[87]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SharedDynamicBlockBody) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SharedDynamicBlockBody) ProtoMessage() {}
+
+func (x *SharedDynamicBlockBody) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[87]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SharedDynamicBlockBody.ProtoReflect.Descriptor instead.
+func (*SharedDynamicBlockBody) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{87}
+}
+
+func (x *SharedDynamicBlockBody) GetRaw() []byte {
+	if x != nil {
+		return x.Raw
+	}
+	return nil
+}
+
+type WithSharedDynamicBlockBodyTuple struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Item []*SharedDynamicBlockBody `protobuf:"bytes,1,rep,name=item,proto3" json:"item,omitempty"`
+}
+
+func (x *WithSharedDynamicBlockBodyTuple) Reset() {
+	*x = WithSharedDynamicBlockBodyTuple{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[88]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithSharedDynamicBlockBodyTuple) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithSharedDynamicBlockBodyTuple) ProtoMessage() {}
+
+func (x *WithSharedDynamicBlockBodyTuple) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[88]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithSharedDynamicBlockBodyTuple.ProtoReflect.Descriptor instead.
+func (*WithSharedDynamicBlockBodyTuple) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{88}
+}
+
+func (x *WithSharedDynamicBlockBodyTuple) GetItem() []*SharedDynamicBlockBody {
+	if x != nil {
+		return x.Item
+	}
+	return nil
+}
+
+type WithSharedDynamicBlockBodySet struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Invalid: the elements of a set must all have the same type.
+	Item []*SharedDynamicBlockBody `protobuf:"bytes,1,rep,name=item,proto3" json:"item,omitempty"`
+}
+
+func (x *WithSharedDynamicBlockBodySet) Reset() {
+	*x = WithSharedDynamicBlockBodySet{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[89]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithSharedDynamicBlockBodySet) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithSharedDynamicBlockBodySet) ProtoMessage() {}
+
+func (x *WithSharedDynamicBlockBodySet) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[89]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithSharedDynamicBlockBodySet.ProtoReflect.Descriptor instead.
+func (*WithSharedDynamicBlockBodySet) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{89}
+}
+
+func (x *WithSharedDynamicBlockBodySet) GetItem() []*SharedDynamicBlockBody {
+	if x != nil {
+		return x.Item
+	}
+	return nil
+}
+
+type WithSharedDynamicBlockBodyKinds struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Tuple *WithSharedDynamicBlockBodyTuple `protobuf:"bytes,1,opt,name=tuple,proto3" json:"tuple,omitempty"`
+	Set   *WithSharedDynamicBlockBodySet   `protobuf:"bytes,2,opt,name=set,proto3" json:"set,omitempty"`
+}
+
+func (x *WithSharedDynamicBlockBodyKinds) Reset() {
+	*x = WithSharedDynamicBlockBodyKinds{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[90]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithSharedDynamicBlockBodyKinds) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithSharedDynamicBlockBodyKinds) ProtoMessage() {}
+
+func (x *WithSharedDynamicBlockBodyKinds) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[90]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithSharedDynamicBlockBodyKinds.ProtoReflect.Descriptor instead.
+func (*WithSharedDynamicBlockBodyKinds) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{90}
+}
+
+func (x *WithSharedDynamicBlockBodyKinds) GetTuple() *WithSharedDynamicBlockBodyTuple {
+	if x != nil {
+		return x.Tuple
+	}
+	return nil
+}
+
+func (x *WithSharedDynamicBlockBodyKinds) GetSet() *WithSharedDynamicBlockBodySet {
+	if x != nil {
+		return x.Set
+	}
+	return nil
+}
+
+type TLSSettings struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CertFile string          `protobuf:"bytes,1,opt,name=cert_file,json=certFile,proto3" json:"cert_file,omitempty"`
+	Insecure bool            `protobuf:"varint,2,opt,name=insecure,proto3" json:"insecure,omitempty"`
+	Ca       *WithStringAttr `protobuf:"bytes,3,opt,name=ca,proto3" json:"ca,omitempty"`
+}
+
+func (x *TLSSettings) Reset() {
+	*x = TLSSettings{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[91]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TLSSettings) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TLSSettings) ProtoMessage() {}
+
+func (x *TLSSettings) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[91]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TLSSettings.ProtoReflect.Descriptor instead.
+func (*TLSSettings) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{91}
+}
+
+func (x *TLSSettings) GetCertFile() string {
+	if x != nil {
+		return x.CertFile
+	}
+	return ""
+}
+
+func (x *TLSSettings) GetInsecure() bool {
+	if x != nil {
+		return x.Insecure
+	}
+	return false
+}
+
+func (x *TLSSettings) GetCa() *WithStringAttr {
+	if x != nil {
+		return x.Ca
+	}
+	return nil
+}
+
+type WithFlattenPrefix struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The same message flattened in twice, with different prefixes so that
+	// the names don't conflict.
+	Client *TLSSettings `protobuf:"bytes,1,opt,name=client,proto3" json:"client,omitempty"`
+	Server *TLSSettings `protobuf:"bytes,2,opt,name=server,proto3" json:"server,omitempty"`
+	Name   string       `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *WithFlattenPrefix) Reset() {
+	*x = WithFlattenPrefix{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[92]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithFlattenPrefix) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithFlattenPrefix) ProtoMessage() {}
+
+func (x *WithFlattenPrefix) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[92]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithFlattenPrefix.ProtoReflect.Descriptor instead.
+func (*WithFlattenPrefix) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{92}
+}
+
+func (x *WithFlattenPrefix) GetClient() *TLSSettings {
+	if x != nil {
+		return x.Client
+	}
+	return nil
+}
+
+func (x *WithFlattenPrefix) GetServer() *TLSSettings {
+	if x != nil {
+		return x.Server
+	}
+	return nil
+}
+
+func (x *WithFlattenPrefix) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type WithNestedFlattenPrefix struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The prefixes accumulate, giving names like "proxy_client_tls_insecure".
+	Proxy *WithFlattenPrefix `protobuf:"bytes,1,opt,name=proxy,proto3" json:"proxy,omitempty"`
+}
+
+func (x *WithNestedFlattenPrefix) Reset() {
+	*x = WithNestedFlattenPrefix{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[93]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithNestedFlattenPrefix) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithNestedFlattenPrefix) ProtoMessage() {}
+
+func (x *WithNestedFlattenPrefix) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[93]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithNestedFlattenPrefix.ProtoReflect.Descriptor instead.
+func (*WithNestedFlattenPrefix) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{93}
+}
+
+func (x *WithNestedFlattenPrefix) GetProxy() *WithFlattenPrefix {
+	if x != nil {
+		return x.Proxy
+	}
+	return nil
+}
+
+type WithFlattenPrefixLabel struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The "name" label becomes "pet_name", and "nickname" "pet_nickname".
+	Base *WithOneBlockLabel `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+}
+
+func (x *WithFlattenPrefixLabel) Reset() {
+	*x = WithFlattenPrefixLabel{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[94]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithFlattenPrefixLabel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithFlattenPrefixLabel) ProtoMessage() {}
+
+func (x *WithFlattenPrefixLabel) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[94]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithFlattenPrefixLabel.ProtoReflect.Descriptor instead.
+func (*WithFlattenPrefixLabel) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{94}
+}
+
+func (x *WithFlattenPrefixLabel) GetBase() *WithOneBlockLabel {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+type WithNestedBlockFlattenPrefixLabel struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pet []*WithFlattenPrefixLabel `protobuf:"bytes,1,rep,name=pet,proto3" json:"pet,omitempty"`
+}
+
+func (x *WithNestedBlockFlattenPrefixLabel) Reset() {
+	*x = WithNestedBlockFlattenPrefixLabel{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[95]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithNestedBlockFlattenPrefixLabel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithNestedBlockFlattenPrefixLabel) ProtoMessage() {}
+
+func (x *WithNestedBlockFlattenPrefixLabel) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[95]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithNestedBlockFlattenPrefixLabel.ProtoReflect.Descriptor instead.
+func (*WithNestedBlockFlattenPrefixLabel) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{95}
+}
+
+func (x *WithNestedBlockFlattenPrefixLabel) GetPet() []*WithFlattenPrefixLabel {
+	if x != nil {
+		return x.Pet
+	}
+	return nil
+}
+
+type WithInvalidFlattenPrefix struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Invalid: the prefix must be a valid HCL identifier.
+	Base *WithStringAttr `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+}
+
+func (x *WithInvalidFlattenPrefix) Reset() {
+	*x = WithInvalidFlattenPrefix{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[96]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithInvalidFlattenPrefix) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithInvalidFlattenPrefix) ProtoMessage() {}
+
+func (x *WithInvalidFlattenPrefix) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[96]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithInvalidFlattenPrefix.ProtoReflect.Descriptor instead.
+func (*WithInvalidFlattenPrefix) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{96}
+}
+
+func (x *WithInvalidFlattenPrefix) GetBase() *WithStringAttr {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+type LegacySettings struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name    string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Timeout string `protobuf:"bytes,2,opt,name=timeout,proto3" json:"timeout,omitempty"`
+}
+
+func (x *LegacySettings) Reset() {
+	*x = LegacySettings{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[97]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LegacySettings) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LegacySettings) ProtoMessage() {}
+
+func (x *LegacySettings) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[97]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LegacySettings.ProtoReflect.Descriptor instead.
+func (*LegacySettings) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{97}
+}
+
+func (x *LegacySettings) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *LegacySettings) GetTimeout() string {
+	if x != nil {
+		return x.Timeout
+	}
+	return ""
+}
+
+type WithFlattenConflictOuterWins struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// "timeout" was hoisted out of LegacySettings into this message, with
+	// a different type. The field that takes the name doesn't depend on the
+	// order of declaration.
+	Legacy  *LegacySettings `protobuf:"bytes,1,opt,name=legacy,proto3" json:"legacy,omitempty"`
+	Timeout int64           `protobuf:"varint,2,opt,name=timeout,proto3" json:"timeout,omitempty"`
+}
+
+func (x *WithFlattenConflictOuterWins) Reset() {
+	*x = WithFlattenConflictOuterWins{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[98]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithFlattenConflictOuterWins) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithFlattenConflictOuterWins) ProtoMessage() {}
+
+func (x *WithFlattenConflictOuterWins) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[98]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithFlattenConflictOuterWins.ProtoReflect.Descriptor instead.
+func (*WithFlattenConflictOuterWins) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{98}
+}
+
+func (x *WithFlattenConflictOuterWins) GetLegacy() *LegacySettings {
+	if x != nil {
+		return x.Legacy
+	}
+	return nil
+}
+
+func (x *WithFlattenConflictOuterWins) GetTimeout() int64 {
+	if x != nil {
+		return x.Timeout
+	}
+	return 0
+}
+
+type WithFlattenConflictInnerWins struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Timeout int64           `protobuf:"varint,1,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	Legacy  *LegacySettings `protobuf:"bytes,2,opt,name=legacy,proto3" json:"legacy,omitempty"`
+}
+
+func (x *WithFlattenConflictInnerWins) Reset() {
+	*x = WithFlattenConflictInnerWins{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[99]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithFlattenConflictInnerWins) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithFlattenConflictInnerWins) ProtoMessage() {}
+
+func (x *WithFlattenConflictInnerWins) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[99]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithFlattenConflictInnerWins.ProtoReflect.Descriptor instead.
+func (*WithFlattenConflictInnerWins) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{99}
+}
+
+func (x *WithFlattenConflictInnerWins) GetTimeout() int64 {
+	if x != nil {
+		return x.Timeout
+	}
+	return 0
+}
+
+func (x *WithFlattenConflictInnerWins) GetLegacy() *LegacySettings {
+	if x != nil {
+		return x.Legacy
+	}
+	return nil
+}
+
+type WithNestedFlattenConflict struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The shadowed name becomes "outer_timeout" along with the other names.
+	Outer *WithFlattenConflictOuterWins `protobuf:"bytes,1,opt,name=outer,proto3" json:"outer,omitempty"`
+}
+
+func (x *WithNestedFlattenConflict) Reset() {
+	*x = WithNestedFlattenConflict{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[100]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithNestedFlattenConflict) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithNestedFlattenConflict) ProtoMessage() {}
+
+func (x *WithNestedFlattenConflict) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[100]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithNestedFlattenConflict.ProtoReflect.Descriptor instead.
+func (*WithNestedFlattenConflict) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{100}
+}
+
+func (x *WithNestedFlattenConflict) GetOuter() *WithFlattenConflictOuterWins {
+	if x != nil {
+		return x.Outer
+	}
+	return nil
+}
+
+type WithInvalidFlattenConflict struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Invalid: flatten_conflict is only for flattened fields.
+	Base *WithStringAttr `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+}
+
+func (x *WithInvalidFlattenConflict) Reset() {
+	*x = WithInvalidFlattenConflict{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[101]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithInvalidFlattenConflict) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithInvalidFlattenConflict) ProtoMessage() {}
+
+func (x *WithInvalidFlattenConflict) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[101]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithInvalidFlattenConflict.ProtoReflect.Descriptor instead.
+func (*WithInvalidFlattenConflict) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{101}
+}
+
+func (x *WithInvalidFlattenConflict) GetBase() *WithStringAttr {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+type WithBlockTypeAliases struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// "doodad" was previously called "gadget", and before that "widget".
+	Doodad []*WithOneBlockLabel `protobuf:"bytes,1,rep,name=doodad,proto3" json:"doodad,omitempty"`
+	Thing  *WithStringAttr      `protobuf:"bytes,2,opt,name=thing,proto3" json:"thing,omitempty"`
+}
+
+func (x *WithBlockTypeAliases) Reset() {
+	*x = WithBlockTypeAliases{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[102]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithBlockTypeAliases) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithBlockTypeAliases) ProtoMessage() {}
+
+func (x *WithBlockTypeAliases) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[102]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithBlockTypeAliases.ProtoReflect.Descriptor instead.
+func (*WithBlockTypeAliases) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{102}
+}
+
+func (x *WithBlockTypeAliases) GetDoodad() []*WithOneBlockLabel {
+	if x != nil {
+		return x.Doodad
+	}
+	return nil
+}
+
+func (x *WithBlockTypeAliases) GetThing() *WithStringAttr {
+	if x != nil {
+		return x.Thing
+	}
+	return nil
+}
+
+type WithFlattenedBlockTypeAliases struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Base *WithBlockTypeAliases `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+}
+
+func (x *WithFlattenedBlockTypeAliases) Reset() {
+	*x = WithFlattenedBlockTypeAliases{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[103]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithFlattenedBlockTypeAliases) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithFlattenedBlockTypeAliases) ProtoMessage() {}
+
+func (x *WithFlattenedBlockTypeAliases) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[103]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithFlattenedBlockTypeAliases.ProtoReflect.Descriptor instead.
+func (*WithFlattenedBlockTypeAliases) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{103}
+}
+
+func (x *WithFlattenedBlockTypeAliases) GetBase() *WithBlockTypeAliases {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+type WithBlockTypeAliasConflict struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Invalid: the alias conflicts with the attribute.
+	Thing *WithStringAttr `protobuf:"bytes,1,opt,name=thing,proto3" json:"thing,omitempty"`
+	Name  string          `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *WithBlockTypeAliasConflict) Reset() {
+	*x = WithBlockTypeAliasConflict{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[104]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithBlockTypeAliasConflict) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithBlockTypeAliasConflict) ProtoMessage() {}
+
+func (x *WithBlockTypeAliasConflict) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[104]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithBlockTypeAliasConflict.ProtoReflect.Descriptor instead.
+func (*WithBlockTypeAliasConflict) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{104}
+}
+
+func (x *WithBlockTypeAliasConflict) GetThing() *WithStringAttr {
+	if x != nil {
+		return x.Thing
+	}
+	return nil
+}
+
+func (x *WithBlockTypeAliasConflict) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type WithInvalidBlockTypeAlias struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Invalid: a block type can't be its own alias.
+	Thing *WithStringAttr `protobuf:"bytes,1,opt,name=thing,proto3" json:"thing,omitempty"`
+}
+
+func (x *WithInvalidBlockTypeAlias) Reset() {
+	*x = WithInvalidBlockTypeAlias{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[105]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithInvalidBlockTypeAlias) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithInvalidBlockTypeAlias) ProtoMessage() {}
+
+func (x *WithInvalidBlockTypeAlias) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[105]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithInvalidBlockTypeAlias.ProtoReflect.Descriptor instead.
+func (*WithInvalidBlockTypeAlias) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{105}
+}
+
+func (x *WithInvalidBlockTypeAlias) GetThing() *WithStringAttr {
+	if x != nil {
+		return x.Thing
+	}
+	return nil
+}
+
+type WithMergeableContent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// A proto3 "optional" field, in a synthetic oneof, so that null and the
+	// empty string are distinct.
+	Description *string                    `protobuf:"bytes,2,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	Count       int64                      `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	Settings    *WithOptionalAttrs         `protobuf:"bytes,4,opt,name=settings,proto3" json:"settings,omitempty"`
+	Rule        []*WithOneBlockLabel       `protobuf:"bytes,5,rep,name=rule,proto3" json:"rule,omitempty"`
+	Pets        map[string]*WithStringAttr `protobuf:"bytes,6,rep,name=pets,proto3" json:"pets,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Base        *WithStringAttr            `protobuf:"bytes,7,opt,name=base,proto3" json:"base,omitempty"`
+}
+
+func (x *WithMergeableContent) Reset() {
+	*x = WithMergeableContent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[106]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithMergeableContent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithMergeableContent) ProtoMessage() {}
+
+func (x *WithMergeableContent) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[106]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithMergeableContent.ProtoReflect.Descriptor instead.
+func (*WithMergeableContent) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{106}
+}
+
+func (x *WithMergeableContent) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *WithMergeableContent) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *WithMergeableContent) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *WithMergeableContent) GetSettings() *WithOptionalAttrs {
+	if x != nil {
+		return x.Settings
+	}
+	return nil
+}
+
+func (x *WithMergeableContent) GetRule() []*WithOneBlockLabel {
+	if x != nil {
+		return x.Rule
+	}
+	return nil
+}
+
+func (x *WithMergeableContent) GetPets() map[string]*WithStringAttr {
+	if x != nil {
+		return x.Pets
+	}
+	return nil
+}
+
+func (x *WithMergeableContent) GetBase() *WithStringAttr {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+type WithMapOfBlocksKeyedByAttr struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Each "pet" block is keyed by its "name" argument, rather than a label.
+	Pets map[string]*WithOptionalAttrs `protobuf:"bytes,1,rep,name=pets,proto3" json:"pets,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *WithMapOfBlocksKeyedByAttr) Reset() {
+	*x = WithMapOfBlocksKeyedByAttr{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[107]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithMapOfBlocksKeyedByAttr) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithMapOfBlocksKeyedByAttr) ProtoMessage() {}
+
+func (x *WithMapOfBlocksKeyedByAttr) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[107]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithMapOfBlocksKeyedByAttr.ProtoReflect.Descriptor instead.
+func (*WithMapOfBlocksKeyedByAttr) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{107}
+}
+
+func (x *WithMapOfBlocksKeyedByAttr) GetPets() map[string]*WithOptionalAttrs {
+	if x != nil {
+		return x.Pets
+	}
+	return nil
+}
+
+type WithInvalidMapKeyAttr struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Invalid: "count" is not a required string attribute.
+	Pets map[string]*WithOptionalAttrs `protobuf:"bytes,1,rep,name=pets,proto3" json:"pets,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *WithInvalidMapKeyAttr) Reset() {
+	*x = WithInvalidMapKeyAttr{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[108]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithInvalidMapKeyAttr) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithInvalidMapKeyAttr) ProtoMessage() {}
+
+func (x *WithInvalidMapKeyAttr) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[108]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithInvalidMapKeyAttr.ProtoReflect.Descriptor instead.
+func (*WithInvalidMapKeyAttr) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{108}
+}
+
+func (x *WithInvalidMapKeyAttr) GetPets() map[string]*WithOptionalAttrs {
+	if x != nil {
+		return x.Pets
+	}
+	return nil
+}
+
+type WithMsgTypeConstraints struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The msg type constructor refers to the object type constraint of
+	// another annotated message type, rather than spelling it out again.
+	Items []*WithOptionalAttrs          `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	ByKey map[string]*WithOptionalAttrs `protobuf:"bytes,2,rep,name=by_key,json=byKey,proto3" json:"by_key,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Raw   []byte                        `protobuf:"bytes,3,opt,name=raw,proto3" json:"raw,omitempty"`
+}
+
+func (x *WithMsgTypeConstraints) Reset() {
+	*x = WithMsgTypeConstraints{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[109]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithMsgTypeConstraints) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithMsgTypeConstraints) ProtoMessage() {}
+
+func (x *WithMsgTypeConstraints) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[109]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithMsgTypeConstraints.ProtoReflect.Descriptor instead.
+func (*WithMsgTypeConstraints) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{109}
+}
+
+func (x *WithMsgTypeConstraints) GetItems() []*WithOptionalAttrs {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *WithMsgTypeConstraints) GetByKey() map[string]*WithOptionalAttrs {
+	if x != nil {
+		return x.ByKey
+	}
+	return nil
+}
+
+func (x *WithMsgTypeConstraints) GetRaw() []byte {
+	if x != nil {
+		return x.Raw
+	}
+	return nil
+}
+
+type WithRecursiveMsgTypeConstraint struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Invalid: a message type can't refer to itself through msg.
+	Children []*WithRecursiveMsgTypeConstraint `protobuf:"bytes,1,rep,name=children,proto3" json:"children,omitempty"`
+}
+
+func (x *WithRecursiveMsgTypeConstraint) Reset() {
+	*x = WithRecursiveMsgTypeConstraint{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[110]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithRecursiveMsgTypeConstraint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithRecursiveMsgTypeConstraint) ProtoMessage() {}
+
+func (x *WithRecursiveMsgTypeConstraint) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[110]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithRecursiveMsgTypeConstraint.ProtoReflect.Descriptor instead.
+func (*WithRecursiveMsgTypeConstraint) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{110}
+}
+
+func (x *WithRecursiveMsgTypeConstraint) GetChildren() []*WithRecursiveMsgTypeConstraint {
+	if x != nil {
+		return x.Children
+	}
+	return nil
+}
+
+type WithUnknownMsgTypeConstraint struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Invalid: there is no message type of this name.
+	Raw []byte `protobuf:"bytes,1,opt,name=raw,proto3" json:"raw,omitempty"`
+}
+
+func (x *WithUnknownMsgTypeConstraint) Reset() {
+	*x = WithUnknownMsgTypeConstraint{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[111]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithUnknownMsgTypeConstraint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithUnknownMsgTypeConstraint) ProtoMessage() {}
+
+func (x *WithUnknownMsgTypeConstraint) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[111]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithUnknownMsgTypeConstraint.ProtoReflect.Descriptor instead.
+func (*WithUnknownMsgTypeConstraint) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{111}
+}
+
+func (x *WithUnknownMsgTypeConstraint) GetRaw() []byte {
+	if x != nil {
+		return x.Raw
+	}
+	return nil
+}
+
+type S3StorageConfig struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Bucket string `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	Region string `protobuf:"bytes,2,opt,name=region,proto3" json:"region,omitempty"`
+}
+
+func (x *S3StorageConfig) Reset() {
+	*x = S3StorageConfig{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[112]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *S3StorageConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*S3StorageConfig) ProtoMessage() {}
+
+func (x *S3StorageConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[112]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use S3StorageConfig.ProtoReflect.Descriptor instead.
+func (*S3StorageConfig) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{112}
+}
+
+func (x *S3StorageConfig) GetBucket() string {
+	if x != nil {
+		return x.Bucket
+	}
+	return ""
+}
+
+func (x *S3StorageConfig) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+type LocalStorageConfig struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Path string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+}
+
+func (x *LocalStorageConfig) Reset() {
+	*x = LocalStorageConfig{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[113]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LocalStorageConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LocalStorageConfig) ProtoMessage() {}
+
+func (x *LocalStorageConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[113]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LocalStorageConfig.ProtoReflect.Descriptor instead.
+func (*LocalStorageConfig) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{113}
+}
+
+func (x *LocalStorageConfig) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+type WithAnyBlock struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	StorageType string `protobuf:"bytes,1,opt,name=storage_type,json=storageType,proto3" json:"storage_type,omitempty"`
+	// The storage_type argument selects which message type the content of
+	// the "storage" block decodes as.
+	Storage *anypb.Any `protobuf:"bytes,2,opt,name=storage,proto3" json:"storage,omitempty"`
+}
+
+func (x *WithAnyBlock) Reset() {
+	*x = WithAnyBlock{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[114]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithAnyBlock) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithAnyBlock) ProtoMessage() {}
+
+func (x *WithAnyBlock) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[114]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithAnyBlock.ProtoReflect.Descriptor instead.
+func (*WithAnyBlock) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{114}
+}
+
+func (x *WithAnyBlock) GetStorageType() string {
+	if x != nil {
+		return x.StorageType
+	}
+	return ""
+}
+
+func (x *WithAnyBlock) GetStorage() *anypb.Any {
+	if x != nil {
+		return x.Storage
+	}
+	return nil
+}
+
+type WithFlattenedAnyBlock struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Backend *WithAnyBlock `protobuf:"bytes,1,opt,name=backend,proto3" json:"backend,omitempty"`
+}
+
+func (x *WithFlattenedAnyBlock) Reset() {
+	*x = WithFlattenedAnyBlock{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[115]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithFlattenedAnyBlock) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithFlattenedAnyBlock) ProtoMessage() {}
+
+func (x *WithFlattenedAnyBlock) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[115]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithFlattenedAnyBlock.ProtoReflect.Descriptor instead.
+func (*WithFlattenedAnyBlock) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{115}
+}
+
+func (x *WithFlattenedAnyBlock) GetBackend() *WithAnyBlock {
+	if x != nil {
+		return x.Backend
+	}
+	return nil
+}
+
+type WithAnyBlockDiscriminatorAfter struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Invalid: the discriminator attribute must be declared first.
+	Storage     *anypb.Any `protobuf:"bytes,1,opt,name=storage,proto3" json:"storage,omitempty"`
+	StorageType string     `protobuf:"bytes,2,opt,name=storage_type,json=storageType,proto3" json:"storage_type,omitempty"`
+}
+
+func (x *WithAnyBlockDiscriminatorAfter) Reset() {
+	*x = WithAnyBlockDiscriminatorAfter{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[116]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithAnyBlockDiscriminatorAfter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithAnyBlockDiscriminatorAfter) ProtoMessage() {}
+
+func (x *WithAnyBlockDiscriminatorAfter) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[116]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithAnyBlockDiscriminatorAfter.ProtoReflect.Descriptor instead.
+func (*WithAnyBlockDiscriminatorAfter) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{116}
+}
+
+func (x *WithAnyBlockDiscriminatorAfter) GetStorage() *anypb.Any {
+	if x != nil {
+		return x.Storage
+	}
+	return nil
+}
+
+func (x *WithAnyBlockDiscriminatorAfter) GetStorageType() string {
+	if x != nil {
+		return x.StorageType
+	}
+	return ""
+}
+
+type WithAnyBlockUnknownType struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	StorageType string `protobuf:"bytes,1,opt,name=storage_type,json=storageType,proto3" json:"storage_type,omitempty"`
+	// Invalid: there is no message type of this name.
+	Storage *anypb.Any `protobuf:"bytes,2,opt,name=storage,proto3" json:"storage,omitempty"`
+}
+
+func (x *WithAnyBlockUnknownType) Reset() {
+	*x = WithAnyBlockUnknownType{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[117]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithAnyBlockUnknownType) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithAnyBlockUnknownType) ProtoMessage() {}
+
+func (x *WithAnyBlockUnknownType) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[117]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithAnyBlockUnknownType.ProtoReflect.Descriptor instead.
+func (*WithAnyBlockUnknownType) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{117}
+}
+
+func (x *WithAnyBlockUnknownType) GetStorageType() string {
+	if x != nil {
+		return x.StorageType
+	}
+	return ""
+}
+
+func (x *WithAnyBlockUnknownType) GetStorage() *anypb.Any {
+	if x != nil {
+		return x.Storage
+	}
+	return nil
+}
+
+type WithAnyBlockNoDiscriminator struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Invalid: a google.protobuf.Any block needs a discriminator.
+	Storage *anypb.Any `protobuf:"bytes,1,opt,name=storage,proto3" json:"storage,omitempty"`
+}
+
+func (x *WithAnyBlockNoDiscriminator) Reset() {
+	*x = WithAnyBlockNoDiscriminator{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[118]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithAnyBlockNoDiscriminator) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithAnyBlockNoDiscriminator) ProtoMessage() {}
+
+func (x *WithAnyBlockNoDiscriminator) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[118]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithAnyBlockNoDiscriminator.ProtoReflect.Descriptor instead.
+func (*WithAnyBlockNoDiscriminator) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{118}
+}
+
+func (x *WithAnyBlockNoDiscriminator) GetStorage() *anypb.Any {
+	if x != nil {
+		return x.Storage
+	}
+	return nil
+}
+
+type WithOneofBlocks struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Only one of the "local" and "remote" block types may appear.
+	//
+	// Types that are assignable to Backend:
+	//	*WithOneofBlocks_Local
+	//	*WithOneofBlocks_Remote
+	Backend isWithOneofBlocks_Backend `protobuf_oneof:"backend"`
+}
+
+func (x *WithOneofBlocks) Reset() {
+	*x = WithOneofBlocks{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[119]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithOneofBlocks) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithOneofBlocks) ProtoMessage() {}
+
+func (x *WithOneofBlocks) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[119]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithOneofBlocks.ProtoReflect.Descriptor instead.
+func (*WithOneofBlocks) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{119}
+}
+
+func (m *WithOneofBlocks) GetBackend() isWithOneofBlocks_Backend {
+	if m != nil {
+		return m.Backend
+	}
+	return nil
+}
+
+func (x *WithOneofBlocks) GetLocal() *LocalStorageConfig {
+	if x, ok := x.GetBackend().(*WithOneofBlocks_Local); ok {
+		return x.Local
+	}
+	return nil
+}
+
+func (x *WithOneofBlocks) GetRemote() *WithStringAttr {
+	if x, ok := x.GetBackend().(*WithOneofBlocks_Remote); ok {
+		return x.Remote
+	}
+	return nil
+}
+
+type isWithOneofBlocks_Backend interface {
+	isWithOneofBlocks_Backend()
+}
+
+type WithOneofBlocks_Local struct {
+	Local *LocalStorageConfig `protobuf:"bytes,1,opt,name=local,proto3,oneof"`
+}
+
+type WithOneofBlocks_Remote struct {
+	Remote *WithStringAttr `protobuf:"bytes,2,opt,name=remote,proto3,oneof"`
+}
+
+func (*WithOneofBlocks_Local) isWithOneofBlocks_Backend() {}
+
+func (*WithOneofBlocks_Remote) isWithOneofBlocks_Backend() {}
+
+type WithOneofAttr struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Invalid: the members of a oneof must all be nested block types.
+	//
+	// Types that are assignable to Choice:
+	//	*WithOneofAttr_Name
+	//	*WithOneofAttr_Thing
+	Choice isWithOneofAttr_Choice `protobuf_oneof:"choice"`
+}
+
+func (x *WithOneofAttr) Reset() {
+	*x = WithOneofAttr{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[120]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithOneofAttr) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithOneofAttr) ProtoMessage() {}
+
+func (x *WithOneofAttr) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[120]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithOneofAttr.ProtoReflect.Descriptor instead.
+func (*WithOneofAttr) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{120}
+}
+
+func (m *WithOneofAttr) GetChoice() isWithOneofAttr_Choice {
+	if m != nil {
+		return m.Choice
+	}
+	return nil
+}
+
+func (x *WithOneofAttr) GetName() string {
+	if x, ok := x.GetChoice().(*WithOneofAttr_Name); ok {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *WithOneofAttr) GetThing() *WithStringAttr {
+	if x, ok := x.GetChoice().(*WithOneofAttr_Thing); ok {
+		return x.Thing
+	}
+	return nil
+}
+
+type isWithOneofAttr_Choice interface {
+	isWithOneofAttr_Choice()
+}
+
+type WithOneofAttr_Name struct {
+	Name string `protobuf:"bytes,1,opt,name=name,proto3,oneof"`
+}
+
+type WithOneofAttr_Thing struct {
+	Thing *WithStringAttr `protobuf:"bytes,2,opt,name=thing,proto3,oneof"`
+}
+
+func (*WithOneofAttr_Name) isWithOneofAttr_Choice() {}
+
+func (*WithOneofAttr_Thing) isWithOneofAttr_Choice() {}
+
+type WithIgnoreNullInLists struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Names  []string                     `protobuf:"bytes,1,rep,name=names,proto3" json:"names,omitempty"`
+	Items  []*WithStringAttr            `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	Nested *WithIgnoreNullInListsNested `protobuf:"bytes,3,opt,name=nested,proto3" json:"nested,omitempty"`
+}
+
+func (x *WithIgnoreNullInLists) Reset() {
+	*x = WithIgnoreNullInLists{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[121]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithIgnoreNullInLists) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithIgnoreNullInLists) ProtoMessage() {}
+
+func (x *WithIgnoreNullInLists) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[121]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithIgnoreNullInLists.ProtoReflect.Descriptor instead.
+func (*WithIgnoreNullInLists) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{121}
+}
+
+func (x *WithIgnoreNullInLists) GetNames() []string {
+	if x != nil {
+		return x.Names
+	}
+	return nil
+}
+
+func (x *WithIgnoreNullInLists) GetItems() []*WithStringAttr {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *WithIgnoreNullInLists) GetNested() *WithIgnoreNullInListsNested {
+	if x != nil {
+		return x.Nested
+	}
+	return nil
+}
+
+type WithIgnoreNullInListsNested struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Names []string `protobuf:"bytes,1,rep,name=names,proto3" json:"names,omitempty"`
+}
+
+func (x *WithIgnoreNullInListsNested) Reset() {
+	*x = WithIgnoreNullInListsNested{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[122]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithIgnoreNullInListsNested) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithIgnoreNullInListsNested) ProtoMessage() {}
+
+func (x *WithIgnoreNullInListsNested) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[122]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithIgnoreNullInListsNested.ProtoReflect.Descriptor instead.
+func (*WithIgnoreNullInListsNested) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{122}
+}
+
+func (x *WithIgnoreNullInListsNested) GetNames() []string {
+	if x != nil {
+		return x.Names
+	}
+	return nil
+}
+
+type WithIgnoreNullInListsSingular struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Invalid: ignore_null_in_lists is only for repeated fields.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *WithIgnoreNullInListsSingular) Reset() {
+	*x = WithIgnoreNullInListsSingular{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[123]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithIgnoreNullInListsSingular) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithIgnoreNullInListsSingular) ProtoMessage() {}
+
+func (x *WithIgnoreNullInListsSingular) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[123]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithIgnoreNullInListsSingular.ProtoReflect.Descriptor instead.
+func (*WithIgnoreNullInListsSingular) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{123}
+}
+
+func (x *WithIgnoreNullInListsSingular) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type WithOptionalScalarAttrs struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// proto3 "optional" fields have explicit presence, so an unset field
+	// represents null rather than the zero value.
+	Description *string `protobuf:"bytes,1,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	Count       *int64  `protobuf:"varint,2,opt,name=count,proto3,oneof" json:"count,omitempty"`
+}
+
+func (x *WithOptionalScalarAttrs) Reset() {
+	*x = WithOptionalScalarAttrs{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[124]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithOptionalScalarAttrs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithOptionalScalarAttrs) ProtoMessage() {}
+
+func (x *WithOptionalScalarAttrs) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[124]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithOptionalScalarAttrs.ProtoReflect.Descriptor instead.
+func (*WithOptionalScalarAttrs) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{124}
+}
+
+func (x *WithOptionalScalarAttrs) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *WithOptionalScalarAttrs) GetCount() int64 {
+	if x != nil && x.Count != nil {
+		return *x.Count
+	}
+	return 0
+}
+
+type WithDefaultExprs struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name        string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	DisplayName string `protobuf:"bytes,2,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"`
+	Greeting    string `protobuf:"bytes,3,opt,name=greeting,proto3" json:"greeting,omitempty"`
+}
+
+func (x *WithDefaultExprs) Reset() {
+	*x = WithDefaultExprs{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[125]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithDefaultExprs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithDefaultExprs) ProtoMessage() {}
+
+func (x *WithDefaultExprs) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[125]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithDefaultExprs.ProtoReflect.Descriptor instead.
+func (*WithDefaultExprs) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{125}
+}
+
+func (x *WithDefaultExprs) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *WithDefaultExprs) GetDisplayName() string {
+	if x != nil {
+		return x.DisplayName
+	}
+	return ""
+}
+
+func (x *WithDefaultExprs) GetGreeting() string {
+	if x != nil {
+		return x.Greeting
+	}
+	return ""
+}
+
+type WithDefaultExprCycle struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	A string `protobuf:"bytes,1,opt,name=a,proto3" json:"a,omitempty"`
+	B string `protobuf:"bytes,2,opt,name=b,proto3" json:"b,omitempty"`
+}
+
+func (x *WithDefaultExprCycle) Reset() {
+	*x = WithDefaultExprCycle{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[126]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithDefaultExprCycle) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithDefaultExprCycle) ProtoMessage() {}
+
+func (x *WithDefaultExprCycle) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[126]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithDefaultExprCycle.ProtoReflect.Descriptor instead.
+func (*WithDefaultExprCycle) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{126}
+}
+
+func (x *WithDefaultExprCycle) GetA() string {
+	if x != nil {
+		return x.A
+	}
+	return ""
+}
+
+func (x *WithDefaultExprCycle) GetB() string {
+	if x != nil {
+		return x.B
+	}
+	return ""
+}
+
+type WithDefaultExprRequired struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Invalid: a required attribute can't have a default.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *WithDefaultExprRequired) Reset() {
+	*x = WithDefaultExprRequired{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[127]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithDefaultExprRequired) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithDefaultExprRequired) ProtoMessage() {}
+
+func (x *WithDefaultExprRequired) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[127]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithDefaultExprRequired.ProtoReflect.Descriptor instead.
+func (*WithDefaultExprRequired) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{127}
+}
+
+func (x *WithDefaultExprRequired) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type WithDefaultExprInvalid struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Invalid: the default expression has a syntax error.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *WithDefaultExprInvalid) Reset() {
+	*x = WithDefaultExprInvalid{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[128]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithDefaultExprInvalid) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithDefaultExprInvalid) ProtoMessage() {}
+
+func (x *WithDefaultExprInvalid) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[128]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithDefaultExprInvalid.ProtoReflect.Descriptor instead.
+func (*WithDefaultExprInvalid) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{128}
+}
+
+func (x *WithDefaultExprInvalid) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type WithWrapperAttrs struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Wrapper types make scalar attributes nullable.
+	Name    *wrapperspb.StringValue `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Count   *wrapperspb.Int64Value  `protobuf:"bytes,2,opt,name=count,proto3" json:"count,omitempty"`
+	Enabled *wrapperspb.BoolValue   `protobuf:"bytes,3,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	Ratio   *wrapperspb.DoubleValue `protobuf:"bytes,4,opt,name=ratio,proto3" json:"ratio,omitempty"`
+	Port    *wrapperspb.UInt32Value `protobuf:"bytes,5,opt,name=port,proto3" json:"port,omitempty"`
+}
+
+func (x *WithWrapperAttrs) Reset() {
+	*x = WithWrapperAttrs{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[129]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithWrapperAttrs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithWrapperAttrs) ProtoMessage() {}
+
+func (x *WithWrapperAttrs) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[129]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithWrapperAttrs.ProtoReflect.Descriptor instead.
+func (*WithWrapperAttrs) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{129}
+}
+
+func (x *WithWrapperAttrs) GetName() *wrapperspb.StringValue {
+	if x != nil {
+		return x.Name
+	}
+	return nil
+}
+
+func (x *WithWrapperAttrs) GetCount() *wrapperspb.Int64Value {
+	if x != nil {
+		return x.Count
+	}
+	return nil
+}
+
+func (x *WithWrapperAttrs) GetEnabled() *wrapperspb.BoolValue {
+	if x != nil {
+		return x.Enabled
+	}
+	return nil
+}
+
+func (x *WithWrapperAttrs) GetRatio() *wrapperspb.DoubleValue {
+	if x != nil {
+		return x.Ratio
+	}
+	return nil
+}
+
+func (x *WithWrapperAttrs) GetPort() *wrapperspb.UInt32Value {
+	if x != nil {
+		return x.Port
+	}
+	return nil
+}
+
+type WithRepeatedWrapperAttr struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Invalid: wrapper types are only for singular attributes.
+	Names []*wrapperspb.StringValue `protobuf:"bytes,1,rep,name=names,proto3" json:"names,omitempty"`
+}
+
+func (x *WithRepeatedWrapperAttr) Reset() {
+	*x = WithRepeatedWrapperAttr{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[130]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithRepeatedWrapperAttr) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithRepeatedWrapperAttr) ProtoMessage() {}
+
+func (x *WithRepeatedWrapperAttr) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[130]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithRepeatedWrapperAttr.ProtoReflect.Descriptor instead.
+func (*WithRepeatedWrapperAttr) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{130}
+}
+
+func (x *WithRepeatedWrapperAttr) GetNames() []*wrapperspb.StringValue {
+	if x != nil {
+		return x.Names
+	}
+	return nil
+}
+
+type WithTimestampAttr struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+}
+
+func (x *WithTimestampAttr) Reset() {
+	*x = WithTimestampAttr{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[131]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithTimestampAttr) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithTimestampAttr) ProtoMessage() {}
+
+func (x *WithTimestampAttr) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[131]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithTimestampAttr.ProtoReflect.Descriptor instead.
+func (*WithTimestampAttr) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{131}
+}
+
+func (x *WithTimestampAttr) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type WithDurationAttr struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Timeout *durationpb.Duration `protobuf:"bytes,1,opt,name=timeout,proto3" json:"timeout,omitempty"`
+}
+
+func (x *WithDurationAttr) Reset() {
+	*x = WithDurationAttr{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[132]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithDurationAttr) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithDurationAttr) ProtoMessage() {}
+
+func (x *WithDurationAttr) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[132]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithDurationAttr.ProtoReflect.Descriptor instead.
+func (*WithDurationAttr) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{132}
+}
+
+func (x *WithDurationAttr) GetTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.Timeout
+	}
+	return nil
+}
+
+type WithStructpbContainerAttrs struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Settings *structpb.Struct    `protobuf:"bytes,1,opt,name=settings,proto3" json:"settings,omitempty"`
+	Items    *structpb.ListValue `protobuf:"bytes,2,opt,name=items,proto3" json:"items,omitempty"`
+	Labels   *structpb.Struct    `protobuf:"bytes,3,opt,name=labels,proto3" json:"labels,omitempty"`
+	Ports    *structpb.ListValue `protobuf:"bytes,4,opt,name=ports,proto3" json:"ports,omitempty"`
+}
+
+func (x *WithStructpbContainerAttrs) Reset() {
+	*x = WithStructpbContainerAttrs{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[133]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithStructpbContainerAttrs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithStructpbContainerAttrs) ProtoMessage() {}
+
+func (x *WithStructpbContainerAttrs) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[133]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithStructpbContainerAttrs.ProtoReflect.Descriptor instead.
+func (*WithStructpbContainerAttrs) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{133}
+}
+
+func (x *WithStructpbContainerAttrs) GetSettings() *structpb.Struct {
+	if x != nil {
+		return x.Settings
+	}
+	return nil
+}
+
+func (x *WithStructpbContainerAttrs) GetItems() *structpb.ListValue {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *WithStructpbContainerAttrs) GetLabels() *structpb.Struct {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *WithStructpbContainerAttrs) GetPorts() *structpb.ListValue {
+	if x != nil {
+		return x.Ports
+	}
+	return nil
+}
+
+type WithStructpbStructWrongType struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Settings *structpb.Struct `protobuf:"bytes,1,opt,name=settings,proto3" json:"settings,omitempty"`
+}
+
+func (x *WithStructpbStructWrongType) Reset() {
+	*x = WithStructpbStructWrongType{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[134]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithStructpbStructWrongType) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithStructpbStructWrongType) ProtoMessage() {}
+
+func (x *WithStructpbStructWrongType) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[134]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithStructpbStructWrongType.ProtoReflect.Descriptor instead.
+func (*WithStructpbStructWrongType) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{134}
+}
+
+func (x *WithStructpbStructWrongType) GetSettings() *structpb.Struct {
+	if x != nil {
+		return x.Settings
+	}
+	return nil
+}
+
+type WithAnyTypeAttr struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The "storage" attribute decodes as S3StorageConfig, unless the host
+	// supplies its own registry of message types.
+	Storage *anypb.Any `protobuf:"bytes,1,opt,name=storage,proto3" json:"storage,omitempty"`
+}
+
+func (x *WithAnyTypeAttr) Reset() {
+	*x = WithAnyTypeAttr{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[135]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithAnyTypeAttr) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithAnyTypeAttr) ProtoMessage() {}
+
+func (x *WithAnyTypeAttr) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[135]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithAnyTypeAttr.ProtoReflect.Descriptor instead.
+func (*WithAnyTypeAttr) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{135}
+}
+
+func (x *WithAnyTypeAttr) GetStorage() *anypb.Any {
+	if x != nil {
+		return x.Storage
+	}
+	return nil
+}
+
+type WithAnyTypeAttrUnknownType struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Invalid unless the host supplies a registry that has this type.
+	Storage *anypb.Any `protobuf:"bytes,1,opt,name=storage,proto3" json:"storage,omitempty"`
+}
+
+func (x *WithAnyTypeAttrUnknownType) Reset() {
+	*x = WithAnyTypeAttrUnknownType{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[136]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithAnyTypeAttrUnknownType) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithAnyTypeAttrUnknownType) ProtoMessage() {}
+
+func (x *WithAnyTypeAttrUnknownType) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[136]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithAnyTypeAttrUnknownType.ProtoReflect.Descriptor instead.
+func (*WithAnyTypeAttrUnknownType) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{136}
+}
+
+func (x *WithAnyTypeAttrUnknownType) GetStorage() *anypb.Any {
+	if x != nil {
+		return x.Storage
+	}
+	return nil
+}
+
+type WithAnyTypeAttrNotAny struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Invalid: any_type is only for google.protobuf.Any fields.
+	Storage *S3StorageConfig `protobuf:"bytes,1,opt,name=storage,proto3" json:"storage,omitempty"`
+}
+
+func (x *WithAnyTypeAttrNotAny) Reset() {
+	*x = WithAnyTypeAttrNotAny{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testschema_proto_msgTypes[137]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithAnyTypeAttrNotAny) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithAnyTypeAttrNotAny) ProtoMessage() {}
+
+func (x *WithAnyTypeAttrNotAny) ProtoReflect() protoreflect.Message {
+	mi := &file_testschema_proto_msgTypes[137]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithAnyTypeAttrNotAny.ProtoReflect.Descriptor instead.
+func (*WithAnyTypeAttrNotAny) Descriptor() ([]byte, []int) {
+	return file_testschema_proto_rawDescGZIP(), []int{137}
+}
+
+func (x *WithAnyTypeAttrNotAny) GetStorage() *S3StorageConfig {
+	if x != nil {
+		return x.Storage
+	}
+	return nil
+}
+
+var File_testschema_proto protoreflect.FileDescriptor
+
+var file_testschema_proto_rawDesc = []byte{
+	0x0a, 0x10, 0x74, 0x65, 0x73, 0x74, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x12, 0x0e, 0x68, 0x63, 0x6c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x63, 0x68, 0x65,
+	0x6d, 0x61, 0x1a, 0x09, 0x68, 0x63, 0x6c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x19, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x61,
+	0x6e, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x77, 0x72, 0x61, 0x70, 0x70, 0x65, 0x72, 0x73,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x98, 0x01, 0x0a, 0x04, 0x52, 0x6f, 0x6f, 0x74,
+	0x12, 0x20, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x0c,
+	0x82, 0xb5, 0x18, 0x08, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x10, 0x01, 0x52, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x12, 0x3a, 0x0a, 0x06, 0x74, 0x68, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x15, 0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x63, 0x68,
+	0x65, 0x6d, 0x61, 0x2e, 0x54, 0x68, 0x69, 0x6e, 0x67, 0x42, 0x0b, 0x8a, 0xb5, 0x18, 0x07, 0x0a,
+	0x05, 0x74, 0x68, 0x69, 0x6e, 0x67, 0x52, 0x06, 0x74, 0x68, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x32,
+	0x0a, 0x04, 0x6d, 0x6f, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x68,
+	0x63, 0x6c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x4d, 0x6f,
+	0x72, 0x65, 0x52, 0x6f, 0x6f, 0x74, 0x42, 0x04, 0xa0, 0xb5, 0x18, 0x01, 0x52, 0x04, 0x6d, 0x6f,
+	0x72, 0x65, 0x22, 0x27, 0x0a, 0x05, 0x54, 0x68, 0x69, 0x6e, 0x67, 0x12, 0x1e, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x0a, 0x92, 0xb5, 0x18, 0x06, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x78, 0x0a, 0x08, 0x4d,
+	0x6f, 0x72, 0x65, 0x52, 0x6f, 0x6f, 0x74, 0x12, 0x21, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x42, 0x0b, 0x82, 0xb5, 0x18, 0x07, 0x0a, 0x05, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x49, 0x0a, 0x0b, 0x6f, 0x74,
+	0x68, 0x65, 0x72, 0x5f, 0x74, 0x68, 0x69, 0x6e, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x15, 0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61,
+	0x2e, 0x54, 0x68, 0x69, 0x6e, 0x67, 0x42, 0x11, 0x8a, 0xb5, 0x18, 0x0d, 0x0a, 0x0b, 0x6f, 0x74,
+	0x68, 0x65, 0x72, 0x5f, 0x74, 0x68, 0x69, 0x6e, 0x67, 0x52, 0x0a, 0x6f, 0x74, 0x68, 0x65, 0x72,
+	0x54, 0x68, 0x69, 0x6e, 0x67, 0x22, 0x41, 0x0a, 0x0a, 0x52, 0x6f, 0x6f, 0x74, 0x4f, 0x75, 0x74,
+	0x70, 0x75, 0x74, 0x12, 0x18, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42,
+	0x08, 0x82, 0xb5, 0x18, 0x04, 0x0a, 0x02, 0x69, 0x64, 0x52, 0x02, 0x69, 0x64, 0x3a, 0x19, 0x82,
+	0xb5, 0x18, 0x15, 0x0a, 0x13, 0x68, 0x63, 0x6c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x63, 0x68,
+	0x65, 0x6d, 0x61, 0x2e, 0x52, 0x6f, 0x6f, 0x74, 0x22, 0x52, 0x0a, 0x15, 0x43, 0x6f, 0x6e, 0x66,
+	0x6c, 0x69, 0x63, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x6f, 0x6f, 0x74, 0x4f, 0x75, 0x74, 0x70, 0x75,
+	0x74, 0x12, 0x1e, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42,
+	0x0a, 0x82, 0xb5, 0x18, 0x06, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x3a, 0x19, 0x82, 0xb5, 0x18, 0x15, 0x0a, 0x13, 0x68, 0x63, 0x6c, 0x2e, 0x74, 0x65, 0x73,
+	0x74, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x52, 0x6f, 0x6f, 0x74, 0x22, 0x38, 0x0a, 0x0e,
+	0x57, 0x69, 0x74, 0x68, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x41, 0x74, 0x74, 0x72, 0x12, 0x26,
+	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x12, 0x82, 0xb5,
+	0x18, 0x0e, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x1a, 0x06, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67,
+	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x38, 0x0a, 0x12, 0x57, 0x69, 0x74, 0x68, 0x52, 0x61,
+	0x77, 0x44, 0x79, 0x6e, 0x61, 0x6d, 0x69, 0x63, 0x41, 0x74, 0x74, 0x72, 0x12, 0x22, 0x0a, 0x03,
+	0x72, 0x61, 0x77, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x42, 0x10, 0x82, 0xb5, 0x18, 0x0c, 0x0a,
+	0x03, 0x72, 0x61, 0x77, 0x1a, 0x03, 0x61, 0x6e, 0x79, 0x20, 0x02, 0x52, 0x03, 0x72, 0x61, 0x77,
+	0x22, 0x5a, 0x0a, 0x15, 0x57, 0x69, 0x74, 0x68, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x44, 0x79,
+	0x6e, 0x61, 0x6d, 0x69, 0x63, 0x41, 0x74, 0x74, 0x72, 0x12, 0x41, 0x0a, 0x06, 0x73, 0x74, 0x72,
+	0x75, 0x63, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x56, 0x61, 0x6c, 0x75,
+	0x65, 0x42, 0x11, 0x82, 0xb5, 0x18, 0x0d, 0x0a, 0x06, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x1a,
+	0x03, 0x61, 0x6e, 0x79, 0x52, 0x06, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x22, 0x5c, 0x0a, 0x14,
+	0x57, 0x69, 0x74, 0x68, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67,
+	0x41, 0x74, 0x74, 0x72, 0x12, 0x44, 0x0a, 0x06, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x14, 0x82, 0xb5,
+	0x18, 0x10, 0x0a, 0x06, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x1a, 0x06, 0x73, 0x74, 0x72, 0x69,
+	0x6e, 0x67, 0x52, 0x06, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x22, 0x5a, 0x0a, 0x12, 0x57, 0x69,
+	0x74, 0x68, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x74, 0x74, 0x72,
+	0x12, 0x44, 0x0a, 0x07, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x12, 0x82, 0xb5, 0x18, 0x0e, 0x0a,
+	0x07, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x73, 0x1a, 0x03, 0x61, 0x6e, 0x79, 0x52, 0x07, 0x73,
+	0x74, 0x72, 0x75, 0x63, 0x74, 0x73, 0x22, 0xc5, 0x01, 0x0a, 0x11, 0x57, 0x69, 0x74, 0x68, 0x53,
+	0x74, 0x72, 0x75, 0x63, 0x74, 0x4d, 0x61, 0x70, 0x41, 0x74, 0x74, 0x72, 0x12, 0x5c, 0x0a, 0x07,
+	0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2e, 0x2e,
+	0x68, 0x63, 0x6c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x57,
+	0x69, 0x74, 0x68, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x4d, 0x61, 0x70, 0x41, 0x74, 0x74, 0x72,
+	0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x42, 0x12, 0x82,
+	0xb5, 0x18, 0x0e, 0x0a, 0x07, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x73, 0x1a, 0x03, 0x61, 0x6e,
+	0x79, 0x52, 0x07, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x73, 0x1a, 0x52, 0x0a, 0x0c, 0x53, 0x74,
+	0x72, 0x75, 0x63, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2c, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x56, 0x61,
+	0x6c, 0x75, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x87,
+	0x03, 0x0a, 0x0c, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x48, 0x6f, 0x6c, 0x64, 0x65, 0x72, 0x12,
+	0x41, 0x0a, 0x04, 0x6c, 0x69, 0x73, 0x74, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x56, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x15, 0x82, 0xb5, 0x18, 0x11, 0x0a, 0x04, 0x6c, 0x69, 0x73,
+	0x74, 0x1a, 0x09, 0x6c, 0x69, 0x73, 0x74, 0x28, 0x61, 0x6e, 0x79, 0x29, 0x52, 0x04, 0x6c, 0x69,
+	0x73, 0x74, 0x12, 0x4f, 0x0a, 0x03, 0x6d, 0x61, 0x70, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x25, 0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61,
+	0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x48, 0x6f, 0x6c, 0x64, 0x65, 0x72, 0x2e, 0x4d, 0x61,
+	0x70, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x42, 0x16, 0x82, 0xb5, 0x18, 0x12, 0x0a, 0x03, 0x6d, 0x61,
+	0x70, 0x1a, 0x0b, 0x6d, 0x61, 0x70, 0x28, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x29, 0x52, 0x03,
+	0x6d, 0x61, 0x70, 0x12, 0x41, 0x0a, 0x06, 0x73, 0x69, 0x6e, 0x67, 0x6c, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x11, 0x82, 0xb5, 0x18,
+	0x0d, 0x0a, 0x06, 0x73, 0x69, 0x6e, 0x67, 0x6c, 0x65, 0x1a, 0x03, 0x61, 0x6e, 0x79, 0x52, 0x06,
+	0x73, 0x69, 0x6e, 0x67, 0x6c, 0x65, 0x12, 0x50, 0x0a, 0x05, 0x74, 0x75, 0x70, 0x6c, 0x65, 0x18,
+	0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x22, 0x82,
+	0xb5, 0x18, 0x1e, 0x0a, 0x05, 0x74, 0x75, 0x70, 0x6c, 0x65, 0x1a, 0x15, 0x74, 0x75, 0x70, 0x6c,
+	0x65, 0x28, 0x5b, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x2c, 0x20, 0x62, 0x6f, 0x6f, 0x6c, 0x5d,
+	0x29, 0x52, 0x05, 0x74, 0x75, 0x70, 0x6c, 0x65, 0x1a, 0x4e, 0x0a, 0x08, 0x4d, 0x61, 0x70, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2c, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x90, 0x02, 0x0a, 0x1b, 0x57, 0x69, 0x74,
+	0x68, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x73, 0x49, 0x6e, 0x4e, 0x65, 0x73, 0x74, 0x65, 0x64,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x12, 0x5b, 0x0a, 0x06, 0x62, 0x79, 0x5f, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x36, 0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x74,
+	0x65, 0x73, 0x74, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x57, 0x69, 0x74, 0x68, 0x53, 0x74,
+	0x72, 0x75, 0x63, 0x74, 0x73, 0x49, 0x6e, 0x4e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x42, 0x79, 0x4b, 0x65, 0x79, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x42, 0x0c, 0x82, 0xb5, 0x18, 0x08, 0x0a, 0x06, 0x62, 0x79, 0x5f, 0x6b, 0x65, 0x79, 0x52, 0x05,
+	0x62, 0x79, 0x4b, 0x65, 0x79, 0x12, 0x3c, 0x0a, 0x04, 0x6c, 0x69, 0x73, 0x74, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x63,
+	0x68, 0x65, 0x6d, 0x61, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x48, 0x6f, 0x6c, 0x64, 0x65,
+	0x72, 0x42, 0x0a, 0x82, 0xb5, 0x18, 0x06, 0x0a, 0x04, 0x6c, 0x69, 0x73, 0x74, 0x52, 0x04, 0x6c,
+	0x69, 0x73, 0x74, 0x1a, 0x56, 0x0a, 0x0a, 0x42, 0x79, 0x4b, 0x65, 0x79, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x6b, 0x65, 0x79, 0x12, 0x32, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x63, 0x68,
+	0x65, 0x6d, 0x61, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x48, 0x6f, 0x6c, 0x64, 0x65, 0x72,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x3c, 0x0a, 0x15, 0x57,
+	0x69, 0x74, 0x68, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x41, 0x74, 0x74, 0x72, 0x41, 0x73, 0x49,
+	0x6e, 0x74, 0x33, 0x32, 0x12, 0x23, 0x0a, 0x03, 0x6e, 0x75, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x05, 0x42, 0x11, 0x82, 0xb5, 0x18, 0x0d, 0x0a, 0x03, 0x6e, 0x75, 0x6d, 0x1a, 0x06, 0x6e, 0x75,
+	0x6d, 0x62, 0x65, 0x72, 0x52, 0x03, 0x6e, 0x75, 0x6d, 0x22, 0x64, 0x0a, 0x16, 0x57, 0x69, 0x74,
+	0x68, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x41, 0x74, 0x74, 0x72, 0x73, 0x41, 0x73, 0x46, 0x6c,
+	0x6f, 0x61, 0x74, 0x12, 0x24, 0x0a, 0x06, 0x73, 0x69, 0x6e, 0x67, 0x6c, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x02, 0x42, 0x0c, 0x82, 0xb5, 0x18, 0x08, 0x0a, 0x06, 0x73, 0x69, 0x6e, 0x67, 0x6c,
+	0x65, 0x52, 0x06, 0x73, 0x69, 0x6e, 0x67, 0x6c, 0x65, 0x12, 0x24, 0x0a, 0x06, 0x64, 0x6f, 0x75,
+	0x62, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x42, 0x0c, 0x82, 0xb5, 0x18, 0x08, 0x0a,
+	0x06, 0x64, 0x6f, 0x75, 0x62, 0x6c, 0x65, 0x52, 0x06, 0x64, 0x6f, 0x75, 0x62, 0x6c, 0x65, 0x22,
+	0x3d, 0x0a, 0x16, 0x57, 0x69, 0x74, 0x68, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x41, 0x74, 0x74,
+	0x72, 0x41, 0x73, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x12, 0x23, 0x0a, 0x03, 0x6e, 0x75, 0x6d,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x11, 0x82, 0xb5, 0x18, 0x0d, 0x0a, 0x03, 0x6e, 0x75,
+	0x6d, 0x1a, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x52, 0x03, 0x6e, 0x75, 0x6d, 0x22, 0x44,
+	0x0a, 0x0c, 0x57, 0x69, 0x74, 0x68, 0x42, 0x6f, 0x6f, 0x6c, 0x41, 0x74, 0x74, 0x72, 0x12, 0x34,
+	0x0a, 0x0c, 0x64, 0x6f, 0x5f, 0x74, 0x68, 0x65, 0x5f, 0x74, 0x68, 0x69, 0x6e, 0x67, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x08, 0x42, 0x12, 0x82, 0xb5, 0x18, 0x0e, 0x0a, 0x0c, 0x64, 0x6f, 0x5f, 0x74,
+	0x68, 0x65, 0x5f, 0x74, 0x68, 0x69, 0x6e, 0x67, 0x52, 0x0a, 0x64, 0x6f, 0x54, 0x68, 0x65, 0x54,
+	0x68, 0x69, 0x6e, 0x67, 0x22, 0x37, 0x0a, 0x12, 0x57, 0x69, 0x74, 0x68, 0x53, 0x74, 0x72, 0x69,
+	0x6e, 0x67, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x74, 0x74, 0x72, 0x12, 0x21, 0x0a, 0x05, 0x6e, 0x61,
+	0x6d, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x42, 0x0b, 0x82, 0xb5, 0x18, 0x07, 0x0a,
+	0x05, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x52, 0x05, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x22, 0x3b, 0x0a,
+	0x19, 0x57, 0x69, 0x74, 0x68, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x4c, 0x69, 0x73, 0x74, 0x41,
+	0x74, 0x74, 0x72, 0x41, 0x73, 0x49, 0x6e, 0x74, 0x33, 0x32, 0x12, 0x1e, 0x0a, 0x04, 0x6e, 0x75,
+	0x6d, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x05, 0x42, 0x0a, 0x82, 0xb5, 0x18, 0x06, 0x0a, 0x04,
+	0x6e, 0x75, 0x6d, 0x73, 0x52, 0x04, 0x6e, 0x75, 0x6d, 0x73, 0x22, 0x44, 0x0a, 0x1d, 0x57, 0x69,
+	0x74, 0x68, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x74, 0x74, 0x72,
+	0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x53, 0x63, 0x61, 0x6c, 0x61, 0x72, 0x12, 0x23, 0x0a, 0x05, 0x6e,
+	0x61, 0x6d, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x42, 0x0d, 0x82, 0xb5, 0x18, 0x09,
+	0x0a, 0x05, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x28, 0x01, 0x52, 0x05, 0x6e, 0x61, 0x6d, 0x65, 0x73,
+	0x22, 0xab, 0x01, 0x0a, 0x0d, 0x57, 0x69, 0x74, 0x68, 0x55, 0x6e, 0x69, 0x74, 0x41, 0x74, 0x74,
+	0x72, 0x73, 0x12, 0x30, 0x0a, 0x08, 0x6d, 0x61, 0x78, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x03, 0x42, 0x15, 0x82, 0xb5, 0x18, 0x11, 0x0a, 0x08, 0x6d, 0x61, 0x78, 0x5f,
+	0x73, 0x69, 0x7a, 0x65, 0x32, 0x05, 0x62, 0x79, 0x74, 0x65, 0x73, 0x52, 0x07, 0x6d, 0x61, 0x78,
+	0x53, 0x69, 0x7a, 0x65, 0x12, 0x30, 0x0a, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x01, 0x42, 0x16, 0x82, 0xb5, 0x18, 0x12, 0x0a, 0x07, 0x74, 0x69, 0x6d,
+	0x65, 0x6f, 0x75, 0x74, 0x32, 0x07, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x52, 0x07, 0x74,
+	0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x36, 0x0a, 0x09, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76,
+	0x61, 0x6c, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0d, 0x42, 0x18, 0x82, 0xb5, 0x18, 0x14, 0x0a,
+	0x09, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x73, 0x32, 0x07, 0x73, 0x65, 0x63, 0x6f,
+	0x6e, 0x64, 0x73, 0x52, 0x09, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x73, 0x22, 0x43,
+	0x0a, 0x17, 0x57, 0x69, 0x74, 0x68, 0x55, 0x6e, 0x69, 0x74, 0x41, 0x74, 0x74, 0x72, 0x55, 0x6e,
+	0x73, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x12, 0x28, 0x0a, 0x04, 0x73, 0x69, 0x7a,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x42, 0x14, 0x82, 0xb5, 0x18, 0x10, 0x0a, 0x04, 0x73,
+	0x69, 0x7a, 0x65, 0x32, 0x08, 0x66, 0x75, 0x72, 0x6c, 0x6f, 0x6e, 0x67, 0x73, 0x52, 0x04, 0x73,
+	0x69, 0x7a, 0x65, 0x22, 0x3b, 0x0a, 0x12, 0x57, 0x69, 0x74, 0x68, 0x55, 0x6e, 0x69, 0x74, 0x41,
+	0x74, 0x74, 0x72, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x12, 0x25, 0x0a, 0x04, 0x73, 0x69, 0x7a,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x11, 0x82, 0xb5, 0x18, 0x0d, 0x0a, 0x04, 0x73,
+	0x69, 0x7a, 0x65, 0x32, 0x05, 0x62, 0x79, 0x74, 0x65, 0x73, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65,
+	0x22, 0x6c, 0x0a, 0x0d, 0x57, 0x69, 0x74, 0x68, 0x41, 0x74, 0x74, 0x72, 0x52, 0x61, 0x6e, 0x67,
+	0x65, 0x12, 0x2a, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42,
+	0x16, 0x82, 0xb5, 0x18, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x3a, 0x0a, 0x6e, 0x61, 0x6d,
+	0x65, 0x5f, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x2f, 0x0a,
+	0x0a, 0x6e, 0x61, 0x6d, 0x65, 0x5f, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x10, 0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x61,
+	0x6e, 0x67, 0x65, 0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x22, 0x43,
+	0x0a, 0x14, 0x57, 0x69, 0x74, 0x68, 0x41, 0x74, 0x74, 0x72, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x4d,
+	0x69, 0x73, 0x73, 0x69, 0x6e, 0x67, 0x12, 0x2b, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x42, 0x17, 0x82, 0xb5, 0x18, 0x13, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x3a, 0x0b, 0x6e, 0x6f, 0x6e, 0x65, 0x78, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x74, 0x52, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x22, 0x63, 0x0a, 0x16, 0x57, 0x69, 0x74, 0x68, 0x41, 0x74, 0x74, 0x72, 0x52,
+	0x61, 0x6e, 0x67, 0x65, 0x57, 0x72, 0x6f, 0x6e, 0x67, 0x54, 0x79, 0x70, 0x65, 0x12, 0x2a, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x16, 0x82, 0xb5, 0x18,
+	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x3a, 0x0a, 0x6e, 0x61, 0x6d, 0x65, 0x5f, 0x72, 0x61,
+	0x6e, 0x67, 0x65, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x6e, 0x61, 0x6d,
+	0x65, 0x5f, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e,
+	0x61, 0x6d, 0x65, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x22, 0x68, 0x0a, 0x14, 0x57, 0x69, 0x74, 0x68,
+	0x45, 0x6d, 0x70, 0x74, 0x79, 0x41, 0x73, 0x4e, 0x75, 0x6c, 0x6c, 0x41, 0x74, 0x74, 0x72, 0x73,
+	0x12, 0x20, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x0c,
+	0x82, 0xb5, 0x18, 0x08, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x48, 0x01, 0x52, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x12, 0x2e, 0x0a, 0x08, 0x6e, 0x69, 0x63, 0x6b, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x42, 0x12, 0x82, 0xb5, 0x18, 0x0e, 0x0a, 0x08, 0x6e, 0x69, 0x63, 0x6b,
+	0x6e, 0x61, 0x6d, 0x65, 0x10, 0x01, 0x48, 0x01, 0x52, 0x08, 0x6e, 0x69, 0x63, 0x6b, 0x6e, 0x61,
+	0x6d, 0x65, 0x22, 0x3a, 0x0a, 0x13, 0x57, 0x69, 0x74, 0x68, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x41,
+	0x73, 0x4e, 0x75, 0x6c, 0x6c, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x23, 0x0a, 0x05, 0x6e, 0x61, 0x6d,
+	0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x42, 0x0d, 0x82, 0xb5, 0x18, 0x09, 0x0a, 0x05,
+	0x6e, 0x61, 0x6d, 0x65, 0x73, 0x48, 0x01, 0x52, 0x05, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x22, 0x72,
+	0x0a, 0x15, 0x57, 0x69, 0x74, 0x68, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x53, 0x79, 0x6e, 0x74,
+	0x61, 0x78, 0x41, 0x74, 0x74, 0x72, 0x73, 0x12, 0x32, 0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e,
+	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x1a, 0x82, 0xb5, 0x18, 0x16, 0x0a, 0x06, 0x61,
+	0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x1a, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x52, 0x04, 0x08,
+	0x01, 0x10, 0x01, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x25, 0x0a, 0x05, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x42, 0x0f, 0x82, 0xb5, 0x18, 0x0b,
+	0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x02, 0x08, 0x01, 0x52, 0x05, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x22, 0x3c, 0x0a, 0x16, 0x57, 0x69, 0x74, 0x68, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72,
+	0x53, 0x79, 0x6e, 0x74, 0x61, 0x78, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x12, 0x22, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x0e, 0x82, 0xb5, 0x18, 0x0a,
+	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x52, 0x02, 0x08, 0x01, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x22, 0xa8, 0x02, 0x0a, 0x12, 0x57, 0x69, 0x74, 0x68, 0x6f, 0x75, 0x74, 0x41, 0x6e, 0x6e, 0x6f,
+	0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x6d,
+	0x61, 0x78, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08,
+	0x6d, 0x61, 0x78, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x61, 0x67, 0x73,
+	0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x74, 0x61, 0x67, 0x73, 0x12, 0x46, 0x0a, 0x06,
+	0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2e, 0x2e, 0x68,
+	0x63, 0x6c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x57, 0x69,
+	0x74, 0x68, 0x6f, 0x75, 0x74, 0x41, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x6c, 0x61,
+	0x62, 0x65, 0x6c, 0x73, 0x12, 0x36, 0x0a, 0x06, 0x6e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73,
+	0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x57, 0x69, 0x74, 0x68, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67,
+	0x41, 0x74, 0x74, 0x72, 0x52, 0x06, 0x6e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x12, 0x12, 0x0a, 0x04,
+	0x64, 0x61, 0x74, 0x61, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61,
+	0x1a, 0x39, 0x0a, 0x0b, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x43, 0x0a, 0x11, 0x57,
+	0x69, 0x74, 0x68, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x53, 0x65, 0x74, 0x41, 0x74, 0x74, 0x72,
+	0x12, 0x2e, 0x0a, 0x05, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x42,
+	0x18, 0x82, 0xb5, 0x18, 0x14, 0x0a, 0x05, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x1a, 0x0b, 0x73, 0x65,
+	0x74, 0x28, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x29, 0x52, 0x05, 0x6e, 0x61, 0x6d, 0x65, 0x73,
+	0x22, 0x9e, 0x01, 0x0a, 0x11, 0x57, 0x69, 0x74, 0x68, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x4d,
+	0x61, 0x70, 0x41, 0x74, 0x74, 0x72, 0x12, 0x4f, 0x0a, 0x05, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x74, 0x65, 0x73, 0x74,
+	0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x57, 0x69, 0x74, 0x68, 0x53, 0x74, 0x72, 0x69, 0x6e,
+	0x67, 0x4d, 0x61, 0x70, 0x41, 0x74, 0x74, 0x72, 0x2e, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x42, 0x0b, 0x82, 0xb5, 0x18, 0x07, 0x0a, 0x05, 0x6e, 0x61, 0x6d, 0x65, 0x73,
+	0x52, 0x05, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x1a, 0x38, 0x0a, 0x0a, 0x4e, 0x61, 0x6d, 0x65, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38,
+	0x01, 0x22, 0xa7, 0x01, 0x0a, 0x18, 0x57, 0x69, 0x74, 0x68, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72,
+	0x4d, 0x61, 0x70, 0x41, 0x74, 0x74, 0x72, 0x41, 0x73, 0x49, 0x6e, 0x74, 0x33, 0x32, 0x12, 0x52,
+	0x0a, 0x04, 0x6e, 0x75, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x32, 0x2e, 0x68,
+	0x63, 0x6c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x57, 0x69,
+	0x74, 0x68, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x4d, 0x61, 0x70, 0x41, 0x74, 0x74, 0x72, 0x41,
+	0x73, 0x49, 0x6e, 0x74, 0x33, 0x32, 0x2e, 0x4e, 0x75, 0x6d, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x42, 0x0a, 0x82, 0xb5, 0x18, 0x06, 0x0a, 0x04, 0x6e, 0x75, 0x6d, 0x73, 0x52, 0x04, 0x6e, 0x75,
+	0x6d, 0x73, 0x1a, 0x37, 0x0a, 0x09, 0x4e, 0x75, 0x6d, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x48, 0x0a, 0x0c, 0x57,
+	0x69, 0x74, 0x68, 0x45, 0x6e, 0x75, 0x6d, 0x41, 0x74, 0x74, 0x72, 0x12, 0x38, 0x0a, 0x05, 0x6c,
+	0x65, 0x76, 0x65, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x68, 0x63, 0x6c,
+	0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x4c, 0x65, 0x76, 0x65,
+	0x6c, 0x42, 0x0b, 0x82, 0xb5, 0x18, 0x07, 0x0a, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x52, 0x05,
+	0x6c, 0x65, 0x76, 0x65, 0x6c, 0x22, 0xb6, 0x01, 0x0a, 0x0f, 0x57, 0x69, 0x74, 0x68, 0x45, 0x6e,
+	0x75, 0x6d, 0x4d, 0x61, 0x70, 0x41, 0x74, 0x74, 0x72, 0x12, 0x51, 0x0a, 0x06, 0x6c, 0x65, 0x76,
+	0x65, 0x6c, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x68, 0x63, 0x6c, 0x2e,
+	0x74, 0x65, 0x73, 0x74, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x57, 0x69, 0x74, 0x68, 0x45,
+	0x6e, 0x75, 0x6d, 0x4d, 0x61, 0x70, 0x41, 0x74, 0x74, 0x72, 0x2e, 0x4c, 0x65, 0x76, 0x65, 0x6c,
+	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x42, 0x0c, 0x82, 0xb5, 0x18, 0x08, 0x0a, 0x06, 0x6c, 0x65,
+	0x76, 0x65, 0x6c, 0x73, 0x52, 0x06, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x73, 0x1a, 0x50, 0x0a, 0x0b,
+	0x4c, 0x65, 0x76, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2b, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x68,
+	0x63, 0x6c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x4c, 0x65,
+	0x76, 0x65, 0x6c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x4f,
+	0x0a, 0x10, 0x57, 0x69, 0x74, 0x68, 0x45, 0x6e, 0x75, 0x6d, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x74,
+	0x74, 0x72, 0x12, 0x3b, 0x0a, 0x06, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0e, 0x32, 0x15, 0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x63, 0x68,
+	0x65, 0x6d, 0x61, 0x2e, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x42, 0x0c, 0x82, 0xb5, 0x18, 0x08, 0x0a,
+	0x06, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x73, 0x52, 0x06, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x73, 0x22,
+	0x82, 0x01, 0x0a, 0x15, 0x57, 0x69, 0x74, 0x68, 0x46, 0x6c, 0x61, 0x74, 0x74, 0x65, 0x6e, 0x53,
+	0x74, 0x72, 0x69, 0x6e, 0x67, 0x41, 0x74, 0x74, 0x72, 0x12, 0x38, 0x0a, 0x04, 0x62, 0x61, 0x73,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x74, 0x65,
+	0x73, 0x74, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x57, 0x69, 0x74, 0x68, 0x53, 0x74, 0x72,
+	0x69, 0x6e, 0x67, 0x41, 0x74, 0x74, 0x72, 0x42, 0x04, 0xa0, 0xb5, 0x18, 0x01, 0x52, 0x04, 0x62,
+	0x61, 0x73, 0x65, 0x12, 0x2f, 0x0a, 0x07, 0x73, 0x70, 0x65, 0x63, 0x69, 0x65, 0x73, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x42, 0x15, 0x82, 0xb5, 0x18, 0x11, 0x0a, 0x07, 0x73, 0x70, 0x65, 0x63,
+	0x69, 0x65, 0x73, 0x1a, 0x06, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x52, 0x07, 0x73, 0x70, 0x65,
+	0x63, 0x69, 0x65, 0x73, 0x22, 0x89, 0x01, 0x0a, 0x1b, 0x57, 0x69, 0x74, 0x68, 0x4e, 0x65, 0x73,
+	0x74, 0x65, 0x64, 0x46, 0x6c, 0x61, 0x74, 0x74, 0x65, 0x6e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67,
+	0x41, 0x74, 0x74, 0x72, 0x12, 0x3f, 0x0a, 0x04, 0x62, 0x61, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x25, 0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x63, 0x68,
+	0x65, 0x6d, 0x61, 0x2e, 0x57, 0x69, 0x74, 0x68, 0x46, 0x6c, 0x61, 0x74, 0x74, 0x65, 0x6e, 0x53,
+	0x74, 0x72, 0x69, 0x6e, 0x67, 0x41, 0x74, 0x74, 0x72, 0x42, 0x04, 0xa0, 0xb5, 0x18, 0x01, 0x52,
+	0x04, 0x62, 0x61, 0x73, 0x65, 0x12, 0x29, 0x0a, 0x05, 0x62, 0x72, 0x65, 0x65, 0x64, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x42, 0x13, 0x82, 0xb5, 0x18, 0x0f, 0x0a, 0x05, 0x62, 0x72, 0x65, 0x65,
+	0x64, 0x1a, 0x06, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x52, 0x05, 0x62, 0x72, 0x65, 0x65, 0x64,
+	0x22, 0x68, 0x0a, 0x20, 0x57, 0x69, 0x74, 0x68, 0x4e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x42, 0x6c,
+	0x6f, 0x63, 0x6b, 0x4e, 0x6f, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x53, 0x69, 0x6e, 0x67, 0x6c,
+	0x65, 0x74, 0x6f, 0x6e, 0x12, 0x44, 0x0a, 0x06, 0x64, 0x6f, 0x6f, 0x64, 0x61, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73,
+	0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x57, 0x69, 0x74, 0x68, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67,
+	0x41, 0x74, 0x74, 0x72, 0x42, 0x0c, 0x8a, 0xb5, 0x18, 0x08, 0x0a, 0x06, 0x64, 0x6f, 0x6f, 0x64,
+	0x61, 0x64, 0x52, 0x06, 0x64, 0x6f, 0x6f, 0x64, 0x61, 0x64, 0x22, 0x6b, 0x0a, 0x20, 0x57, 0x69,
+	0x74, 0x68, 0x4e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4f, 0x6e, 0x65,
+	0x4c, 0x61, 0x62, 0x65, 0x6c, 0x53, 0x69, 0x6e, 0x67, 0x6c, 0x65, 0x74, 0x6f, 0x6e, 0x12, 0x47,
+	0x0a, 0x06, 0x64, 0x6f, 0x6f, 0x64, 0x61, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21,
+	0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e,
+	0x57, 0x69, 0x74, 0x68, 0x4f, 0x6e, 0x65, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4c, 0x61, 0x62, 0x65,
+	0x6c, 0x42, 0x0c, 0x8a, 0xb5, 0x18, 0x08, 0x0a, 0x06, 0x64, 0x6f, 0x6f, 0x64, 0x61, 0x64, 0x52,
+	0x06, 0x64, 0x6f, 0x6f, 0x64, 0x61, 0x64, 0x22, 0x6c, 0x0a, 0x20, 0x57, 0x69, 0x74, 0x68, 0x4e,
+	0x65, 0x73, 0x74, 0x65, 0x64, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x54, 0x77, 0x6f, 0x4c, 0x61, 0x62,
+	0x65, 0x6c, 0x53, 0x69, 0x6e, 0x67, 0x6c, 0x65, 0x74, 0x6f, 0x6e, 0x12, 0x48, 0x0a, 0x06, 0x64,
+	0x6f, 0x6f, 0x64, 0x61, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x68, 0x63,
+	0x6c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x57, 0x69, 0x74,
+	0x68, 0x54, 0x77, 0x6f, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x42,
+	0x0c, 0x8a, 0xb5, 0x18, 0x08, 0x0a, 0x06, 0x64, 0x6f, 0x6f, 0x64, 0x61, 0x64, 0x52, 0x06, 0x64,
+	0x6f, 0x6f, 0x64, 0x61, 0x64, 0x22, 0x69, 0x0a, 0x1f, 0x57, 0x69, 0x74, 0x68, 0x4e, 0x65, 0x73,
+	0x74, 0x65, 0x64, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4e, 0x6f, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73,
+	0x52, 0x65, 0x70, 0x65, 0x61, 0x74, 0x65, 0x64, 0x12, 0x46, 0x0a, 0x06, 0x64, 0x6f, 0x6f, 0x64,
+	0x61, 0x64, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x74,
+	0x65, 0x73, 0x74, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x57, 0x69, 0x74, 0x68, 0x53, 0x74,
+	0x72, 0x69, 0x6e, 0x67, 0x41, 0x74, 0x74, 0x72, 0x42, 0x0e, 0x8a, 0xb5, 0x18, 0x0a, 0x0a, 0x06,
+	0x64, 0x6f, 0x6f, 0x64, 0x61, 0x64, 0x10, 0x03, 0x52, 0x06, 0x64, 0x6f, 0x6f, 0x64, 0x61, 0x64,
+	0x22, 0x6c, 0x0a, 0x1f, 0x57, 0x69, 0x74, 0x68, 0x4e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x42, 0x6c,
+	0x6f, 0x63, 0x6b, 0x4f, 0x6e, 0x65, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x52, 0x65, 0x70, 0x65, 0x61,
+	0x74, 0x65, 0x64, 0x12, 0x49, 0x0a, 0x06, 0x64, 0x6f, 0x6f, 0x64, 0x61, 0x64, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x63,
+	0x68, 0x65, 0x6d, 0x61, 0x2e, 0x57, 0x69, 0x74, 0x68, 0x4f, 0x6e, 0x65, 0x42, 0x6c, 0x6f, 0x63,
+	0x6b, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x42, 0x0e, 0x8a, 0xb5, 0x18, 0x0a, 0x0a, 0x06, 0x64, 0x6f,
+	0x6f, 0x64, 0x61, 0x64, 0x10, 0x02, 0x52, 0x06, 0x64, 0x6f, 0x6f, 0x64, 0x61, 0x64, 0x22, 0x6b,
+	0x0a, 0x1f, 0x57, 0x69, 0x74, 0x68, 0x4e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x42, 0x6c, 0x6f, 0x63,
+	0x6b, 0x54, 0x77, 0x6f, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x52, 0x65, 0x70, 0x65, 0x61, 0x74, 0x65,
+	0x64, 0x12, 0x48, 0x0a, 0x06, 0x64, 0x6f, 0x6f, 0x64, 0x61, 0x64, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x22, 0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x63, 0x68, 0x65,
+	0x6d, 0x61, 0x2e, 0x57, 0x69, 0x74, 0x68, 0x54, 0x77, 0x6f, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4c,
+	0x61, 0x62, 0x65, 0x6c, 0x73, 0x42, 0x0c, 0x8a, 0xb5, 0x18, 0x08, 0x0a, 0x06, 0x64, 0x6f, 0x6f,
+	0x64, 0x61, 0x64, 0x52, 0x06, 0x64, 0x6f, 0x6f, 0x64, 0x61, 0x64, 0x22, 0x72, 0x0a, 0x1e, 0x57,
+	0x69, 0x74, 0x68, 0x4e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4f, 0x6e,
+	0x65, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x44, 0x79, 0x6e, 0x61, 0x6d, 0x69, 0x63, 0x12, 0x50, 0x0a,
+	0x06, 0x64, 0x6f, 0x6f, 0x64, 0x61, 0x64, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x28, 0x2e,
+	0x68, 0x63, 0x6c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x57,
+	0x69, 0x74, 0x68, 0x4f, 0x6e, 0x65, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4c, 0x61, 0x62, 0x65, 0x6c,
+	0x44, 0x79, 0x6e, 0x61, 0x6d, 0x69, 0x63, 0x42, 0x0e, 0x8a, 0xb5, 0x18, 0x0a, 0x0a, 0x06, 0x64,
+	0x6f, 0x6f, 0x64, 0x61, 0x64, 0x10, 0x01, 0x52, 0x06, 0x64, 0x6f, 0x6f, 0x64, 0x61, 0x64, 0x22,
+	0x64, 0x0a, 0x18, 0x57, 0x69, 0x74, 0x68, 0x4f, 0x6e, 0x65, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4c,
+	0x61, 0x62, 0x65, 0x6c, 0x44, 0x79, 0x6e, 0x61, 0x6d, 0x69, 0x63, 0x12, 0x1e, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x0a, 0x92, 0xb5, 0x18, 0x06, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x28, 0x0a, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x42, 0x12, 0x82, 0xb5, 0x18, 0x0e,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x1a, 0x03, 0x61, 0x6e, 0x79, 0x20, 0x02, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x6f, 0x0a, 0x1e, 0x57, 0x69, 0x74, 0x68, 0x4e, 0x65, 0x73,
+	0x74, 0x65, 0x64, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x46, 0x6c, 0x61, 0x74, 0x74, 0x65, 0x6e, 0x65,
+	0x64, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x12, 0x4d, 0x0a, 0x06, 0x64, 0x6f, 0x6f, 0x64, 0x61,
+	0x64, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x74, 0x65,
+	0x73, 0x74, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x57, 0x69, 0x74, 0x68, 0x46, 0x6c, 0x61,
+	0x74, 0x74, 0x65, 0x6e, 0x65, 0x64, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4c, 0x61, 0x62, 0x65, 0x6c,
+	0x42, 0x0c, 0x8a, 0xb5, 0x18, 0x08, 0x0a, 0x06, 0x64, 0x6f, 0x6f, 0x64, 0x61, 0x64, 0x52, 0x06,
+	0x64, 0x6f, 0x6f, 0x64, 0x61, 0x64, 0x22, 0xa7, 0x01, 0x0a, 0x17, 0x57, 0x69, 0x74, 0x68, 0x46,
+	0x6c, 0x61, 0x74, 0x74, 0x65, 0x6e, 0x65, 0x64, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4c, 0x61, 0x62,
+	0x65, 0x6c, 0x12, 0x1e, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x42, 0x0a, 0x92, 0xb5, 0x18, 0x06, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79,
+	0x70, 0x65, 0x12, 0x3b, 0x0a, 0x04, 0x62, 0x61, 0x73, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x21, 0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x63, 0x68, 0x65, 0x6d,
+	0x61, 0x2e, 0x57, 0x69, 0x74, 0x68, 0x4f, 0x6e, 0x65, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4c, 0x61,
+	0x62, 0x65, 0x6c, 0x42, 0x04, 0xa0, 0xb5, 0x18, 0x01, 0x52, 0x04, 0x62, 0x61, 0x73, 0x65, 0x12,
+	0x2f, 0x0a, 0x07, 0x73, 0x70, 0x65, 0x63, 0x69, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x42, 0x15, 0x82, 0xb5, 0x18, 0x11, 0x0a, 0x07, 0x73, 0x70, 0x65, 0x63, 0x69, 0x65, 0x73, 0x1a,
+	0x06, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x52, 0x07, 0x73, 0x70, 0x65, 0x63, 0x69, 0x65, 0x73,
+	0x22, 0x74, 0x0a, 0x20, 0x57, 0x69, 0x74, 0x68, 0x4e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x42, 0x6c,
+	0x6f, 0x63, 0x6b, 0x43, 0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63, 0x74, 0x69, 0x6e, 0x67, 0x4c, 0x61,
+	0x62, 0x65, 0x6c, 0x73, 0x12, 0x50, 0x0a, 0x06, 0x64, 0x6f, 0x6f, 0x64, 0x61, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73,
+	0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x57, 0x69, 0x74, 0x68, 0x43, 0x6f, 0x6e, 0x66, 0x6c, 0x69,
+	0x63, 0x74, 0x69, 0x6e, 0x67, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73,
+	0x42, 0x0c, 0x8a, 0xb5, 0x18, 0x08, 0x0a, 0x06, 0x64, 0x6f, 0x6f, 0x64, 0x61, 0x64, 0x52, 0x06,
+	0x64, 0x6f, 0x6f, 0x64, 0x61, 0x64, 0x22, 0x79, 0x0a, 0x1a, 0x57, 0x69, 0x74, 0x68, 0x43, 0x6f,
+	0x6e, 0x66, 0x6c, 0x69, 0x63, 0x74, 0x69, 0x6e, 0x67, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4c, 0x61,
+	0x62, 0x65, 0x6c, 0x73, 0x12, 0x1e, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x42, 0x0a, 0x92, 0xb5, 0x18, 0x06, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x52, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x3b, 0x0a, 0x04, 0x62, 0x61, 0x73, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x21, 0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x63, 0x68,
+	0x65, 0x6d, 0x61, 0x2e, 0x57, 0x69, 0x74, 0x68, 0x4f, 0x6e, 0x65, 0x42, 0x6c, 0x6f, 0x63, 0x6b,
+	0x4c, 0x61, 0x62, 0x65, 0x6c, 0x42, 0x04, 0xa0, 0xb5, 0x18, 0x01, 0x52, 0x04, 0x62, 0x61, 0x73,
+	0x65, 0x22, 0x5d, 0x0a, 0x17, 0x57, 0x69, 0x74, 0x68, 0x53, 0x61, 0x6d, 0x65, 0x42, 0x6c, 0x6f,
+	0x63, 0x6b, 0x54, 0x79, 0x70, 0x65, 0x4e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x12, 0x42, 0x0a, 0x04,
+	0x69, 0x74, 0x65, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x68, 0x63, 0x6c,
+	0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x53, 0x61, 0x6d, 0x65,
+	0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x54, 0x79, 0x70, 0x65, 0x4f, 0x75, 0x74, 0x65, 0x72, 0x42, 0x0a,
+	0x8a, 0xb5, 0x18, 0x06, 0x0a, 0x04, 0x69, 0x74, 0x65, 0x6d, 0x52, 0x04, 0x69, 0x74, 0x65, 0x6d,
+	0x22, 0x80, 0x01, 0x0a, 0x12, 0x53, 0x61, 0x6d, 0x65, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x54, 0x79,
+	0x70, 0x65, 0x4f, 0x75, 0x74, 0x65, 0x72, 0x12, 0x26, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x12, 0x82, 0xb5, 0x18, 0x0e, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x1a, 0x06, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x42, 0x0a, 0x04, 0x69, 0x74, 0x65, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e,
+	0x68, 0x63, 0x6c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x53,
+	0x61, 0x6d, 0x65, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x54, 0x79, 0x70, 0x65, 0x49, 0x6e, 0x6e, 0x65,
+	0x72, 0x42, 0x0a, 0x8a, 0xb5, 0x18, 0x06, 0x0a, 0x04, 0x69, 0x74, 0x65, 0x6d, 0x52, 0x04, 0x69,
+	0x74, 0x65, 0x6d, 0x22, 0x34, 0x0a, 0x12, 0x53, 0x61, 0x6d, 0x65, 0x42, 0x6c, 0x6f, 0x63, 0x6b,
+	0x54, 0x79, 0x70, 0x65, 0x49, 0x6e, 0x6e, 0x65, 0x72, 0x12, 0x1e, 0x0a, 0x04, 0x73, 0x69, 0x7a,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x42, 0x0a, 0x82, 0xb5, 0x18, 0x06, 0x0a, 0x04, 0x73,
+	0x69, 0x7a, 0x65, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x22, 0xa5, 0x01, 0x0a, 0x0e, 0x52, 0x65,
+	0x63, 0x75, 0x72, 0x73, 0x69, 0x76, 0x65, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x26, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x12, 0x82, 0xb5, 0x18, 0x0e,
+	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x1a, 0x06, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x52, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x28, 0x0a, 0x05, 0x65, 0x78, 0x74, 0x72, 0x61, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0c, 0x42, 0x12, 0x82, 0xb5, 0x18, 0x0e, 0x0a, 0x05, 0x65, 0x78, 0x74, 0x72, 0x61,
+	0x1a, 0x03, 0x61, 0x6e, 0x79, 0x20, 0x02, 0x52, 0x05, 0x65, 0x78, 0x74, 0x72, 0x61, 0x12, 0x41,
+	0x0a, 0x05, 0x63, 0x68, 0x69, 0x6c, 0x64, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e,
+	0x68, 0x63, 0x6c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x52,
+	0x65, 0x63, 0x75, 0x72, 0x73, 0x69, 0x76, 0x65, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x42, 0x0b, 0x8a,
+	0xb5, 0x18, 0x07, 0x0a, 0x05, 0x63, 0x68, 0x69, 0x6c, 0x64, 0x52, 0x05, 0x63, 0x68, 0x69, 0x6c,
+	0x64, 0x22, 0x8b, 0x01, 0x0a, 0x17, 0x57, 0x69, 0x74, 0x68, 0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69,
+	0x64, 0x4e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x12, 0x37, 0x0a,
+	0x01, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x74,
+	0x65, 0x73, 0x74, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69,
+	0x64, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x42, 0x6f, 0x64, 0x79, 0x42, 0x07, 0x8a, 0xb5, 0x18, 0x03,
+	0x0a, 0x01, 0x61, 0x52, 0x01, 0x61, 0x12, 0x37, 0x0a, 0x01, 0x62, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x20, 0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x63, 0x68, 0x65,
+	0x6d, 0x61, 0x2e, 0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x42,
+	0x6f, 0x64, 0x79, 0x42, 0x07, 0x8a, 0xb5, 0x18, 0x03, 0x0a, 0x01, 0x62, 0x52, 0x01, 0x62, 0x22,
+	0xb7, 0x01, 0x0a, 0x19, 0x57, 0x69, 0x74, 0x68, 0x52, 0x65, 0x70, 0x65, 0x61, 0x74, 0x65, 0x64,
+	0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x12, 0x40, 0x0a,
+	0x04, 0x69, 0x74, 0x65, 0x6d, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x68, 0x63,
+	0x6c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x49, 0x6e, 0x76,
+	0x61, 0x6c, 0x69, 0x64, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x42, 0x6f, 0x64, 0x79, 0x42, 0x0a, 0x8a,
+	0xb5, 0x18, 0x06, 0x0a, 0x04, 0x69, 0x74, 0x65, 0x6d, 0x52, 0x04, 0x69, 0x74, 0x65, 0x6d, 0x12,
+	0x58, 0x0a, 0x0a, 0x6d, 0x69, 0x73, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x64, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x63,
+	0x68, 0x65, 0x6d, 0x61, 0x2e, 0x57, 0x69, 0x74, 0x68, 0x4d, 0x69, 0x73, 0x6d, 0x61, 0x74, 0x63,
+	0x68, 0x65, 0x64, 0x41, 0x74, 0x74, 0x72, 0x54, 0x79, 0x70, 0x65, 0x42, 0x10, 0x8a, 0xb5, 0x18,
+	0x0c, 0x0a, 0x0a, 0x6d, 0x69, 0x73, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x64, 0x52, 0x0a, 0x6d,
+	0x69, 0x73, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x64, 0x22, 0x38, 0x0a, 0x0e, 0x52, 0x6f, 0x6f,
+	0x74, 0x4f, 0x6e, 0x6c, 0x79, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x1e, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x0a, 0x82, 0xb5, 0x18, 0x06, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x3a, 0x06, 0x82, 0xb5, 0x18,
+	0x02, 0x10, 0x01, 0x22, 0x5f, 0x0a, 0x17, 0x57, 0x69, 0x74, 0x68, 0x52, 0x6f, 0x6f, 0x74, 0x4f,
+	0x6e, 0x6c, 0x79, 0x4e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x44,
+	0x0a, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e,
+	0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e,
+	0x52, 0x6f, 0x6f, 0x74, 0x4f, 0x6e, 0x6c, 0x79, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x42, 0x0c,
+	0x8a, 0xb5, 0x18, 0x08, 0x0a, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x06, 0x63, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x22, 0x6d, 0x0a, 0x10, 0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x42,
+	0x6c, 0x6f, 0x63, 0x6b, 0x42, 0x6f, 0x64, 0x79, 0x12, 0x26, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x12, 0x82, 0xb5, 0x18, 0x0e, 0x0a, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x1a, 0x06, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x12, 0x31, 0x0a, 0x0a, 0x6f, 0x74, 0x68, 0x65, 0x72, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x42, 0x12, 0x82, 0xb5, 0x18, 0x0e, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x1a, 0x06, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x52, 0x09, 0x6f, 0x74, 0x68, 0x65, 0x72, 0x4e,
+	0x61, 0x6d, 0x65, 0x22, 0x67, 0x0a, 0x11, 0x57, 0x69, 0x74, 0x68, 0x4f, 0x6e, 0x65, 0x42, 0x6c,
+	0x6f, 0x63, 0x6b, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x12, 0x1e, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x0a, 0x92, 0xb5, 0x18, 0x06, 0x0a, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x32, 0x0a, 0x08, 0x6e, 0x69, 0x63, 0x6b,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x42, 0x16, 0x82, 0xb5, 0x18, 0x12,
+	0x0a, 0x08, 0x6e, 0x69, 0x63, 0x6b, 0x6e, 0x61, 0x6d, 0x65, 0x1a, 0x06, 0x73, 0x74, 0x72, 0x69,
+	0x6e, 0x67, 0x52, 0x08, 0x6e, 0x69, 0x63, 0x6b, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x88, 0x01, 0x0a,
+	0x12, 0x57, 0x69, 0x74, 0x68, 0x54, 0x77, 0x6f, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4c, 0x61, 0x62,
+	0x65, 0x6c, 0x73, 0x12, 0x1e, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x42, 0x0a, 0x92, 0xb5, 0x18, 0x06, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74,
+	0x79, 0x70, 0x65, 0x12, 0x1e, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x42, 0x0a, 0x92, 0xb5, 0x18, 0x06, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x52, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x12, 0x32, 0x0a, 0x08, 0x6e, 0x69, 0x63, 0x6b, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x42, 0x16, 0x82, 0xb5, 0x18, 0x12, 0x0a, 0x08, 0x6e, 0x69, 0x63,
+	0x6b, 0x6e, 0x61, 0x6d, 0x65, 0x1a, 0x06, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x52, 0x08, 0x6e,
+	0x69, 0x63, 0x6b, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x81, 0x01, 0x0a, 0x18, 0x57, 0x69, 0x74, 0x68,
+	0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x64, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4c, 0x61,
+	0x62, 0x65, 0x6c, 0x73, 0x12, 0x45, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x42, 0x31, 0x92, 0xb5, 0x18, 0x2d, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x25,
+	0x54, 0x68, 0x65, 0x20, 0x6b, 0x69, 0x6e, 0x64, 0x20, 0x6f, 0x66, 0x20, 0x64, 0x6f, 0x6f, 0x64,
+	0x61, 0x64, 0x2c, 0x20, 0x73, 0x75, 0x63, 0x68, 0x20, 0x61, 0x73, 0x20, 0x22, 0x77, 0x69, 0x64,
+	0x67, 0x65, 0x74, 0x22, 0x2e, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x1e, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x42, 0x0a, 0x92, 0xb5, 0x18, 0x06, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x70, 0x0a, 0x1e, 0x57,
+	0x69, 0x74, 0x68, 0x4e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x44, 0x65,
+	0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x64, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x12, 0x4e, 0x0a,
+	0x06, 0x64, 0x6f, 0x6f, 0x64, 0x61, 0x64, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x28, 0x2e,
+	0x68, 0x63, 0x6c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x57,
+	0x69, 0x74, 0x68, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x64, 0x42, 0x6c, 0x6f, 0x63,
+	0x6b, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x42, 0x0c, 0x8a, 0xb5, 0x18, 0x08, 0x0a, 0x06, 0x64,
+	0x6f, 0x6f, 0x64, 0x61, 0x64, 0x52, 0x06, 0x64, 0x6f, 0x6f, 0x64, 0x61, 0x64, 0x22, 0xb9, 0x01,
+	0x0a, 0x0f, 0x57, 0x69, 0x74, 0x68, 0x4d, 0x61, 0x70, 0x4f, 0x66, 0x42, 0x6c, 0x6f, 0x63, 0x6b,
+	0x73, 0x12, 0x4d, 0x0a, 0x04, 0x70, 0x65, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x29, 0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61,
+	0x2e, 0x57, 0x69, 0x74, 0x68, 0x4d, 0x61, 0x70, 0x4f, 0x66, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73,
+	0x2e, 0x50, 0x65, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x42, 0x0e, 0x8a, 0xb5, 0x18, 0x0a,
+	0x0a, 0x03, 0x70, 0x65, 0x74, 0x1a, 0x03, 0x6b, 0x65, 0x79, 0x52, 0x04, 0x70, 0x65, 0x74, 0x73,
+	0x1a, 0x57, 0x0a, 0x09, 0x50, 0x65, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
+	0x34, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e,
+	0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e,
+	0x57, 0x69, 0x74, 0x68, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x41, 0x74, 0x74, 0x72, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xbf, 0x01, 0x0a, 0x14, 0x57, 0x69,
+	0x74, 0x68, 0x4d, 0x61, 0x70, 0x4f, 0x66, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x41, 0x74,
+	0x74, 0x72, 0x12, 0x4e, 0x0a, 0x04, 0x70, 0x65, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x2e, 0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x63, 0x68, 0x65, 0x6d,
+	0x61, 0x2e, 0x57, 0x69, 0x74, 0x68, 0x4d, 0x61, 0x70, 0x4f, 0x66, 0x4f, 0x62, 0x6a, 0x65, 0x63,
+	0x74, 0x73, 0x41, 0x74, 0x74, 0x72, 0x2e, 0x50, 0x65, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x42, 0x0a, 0x82, 0xb5, 0x18, 0x06, 0x0a, 0x04, 0x70, 0x65, 0x74, 0x73, 0x52, 0x04, 0x70, 0x65,
+	0x74, 0x73, 0x1a, 0x57, 0x0a, 0x09, 0x50, 0x65, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x34, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1e, 0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x63, 0x68, 0x65, 0x6d,
+	0x61, 0x2e, 0x57, 0x69, 0x74, 0x68, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x41, 0x74, 0x74, 0x72,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x5f, 0x0a, 0x0e, 0x57,
+	0x69, 0x74, 0x68, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x41, 0x74, 0x74, 0x72, 0x12, 0x4d, 0x0a,
+	0x08, 0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x21, 0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61,
+	0x2e, 0x57, 0x69, 0x74, 0x68, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x41, 0x74, 0x74,
+	0x72, 0x73, 0x42, 0x0e, 0x82, 0xb5, 0x18, 0x0a, 0x0a, 0x08, 0x73, 0x65, 0x74, 0x74, 0x69, 0x6e,
+	0x67, 0x73, 0x52, 0x08, 0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x22, 0x63, 0x0a, 0x15,
+	0x57, 0x69, 0x74, 0x68, 0x4e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74,
+	0x73, 0x41, 0x74, 0x74, 0x72, 0x12, 0x4a, 0x0a, 0x06, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x74, 0x65, 0x73, 0x74,
+	0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x57, 0x69, 0x74, 0x68, 0x4d, 0x61, 0x70, 0x4f, 0x66,
+	0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x41, 0x74, 0x74, 0x72, 0x42, 0x0c, 0x82, 0xb5, 0x18,
+	0x08, 0x0a, 0x06, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x52, 0x06, 0x67, 0x72, 0x6f, 0x75, 0x70,
+	0x73, 0x22, 0x5d, 0x0a, 0x15, 0x57, 0x69, 0x74, 0x68, 0x4c, 0x69, 0x73, 0x74, 0x4f, 0x66, 0x4f,
+	0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x41, 0x74, 0x74, 0x72, 0x12, 0x44, 0x0a, 0x05, 0x69, 0x74,
+	0x65, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x68, 0x63, 0x6c, 0x2e,
+	0x74, 0x65, 0x73, 0x74, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x57, 0x69, 0x74, 0x68, 0x4f,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x41, 0x74, 0x74, 0x72, 0x73, 0x42, 0x0b, 0x82, 0xb5,
+	0x18, 0x07, 0x0a, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x52, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73,
+	0x22, 0x61, 0x0a, 0x16, 0x57, 0x69, 0x74, 0x68, 0x54, 0x75, 0x70, 0x6c, 0x65, 0x4f, 0x66, 0x4f,
+	0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x41, 0x74, 0x74, 0x72, 0x12, 0x47, 0x0a, 0x05, 0x69, 0x74,
+	0x65, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x68, 0x63, 0x6c, 0x2e,
+	0x74, 0x65, 0x73, 0x74, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x57, 0x69, 0x74, 0x68, 0x52,
+	0x61, 0x77, 0x44, 0x79, 0x6e, 0x61, 0x6d, 0x69, 0x63, 0x41, 0x74, 0x74, 0x72, 0x42, 0x0d, 0x82,
+	0xb5, 0x18, 0x09, 0x0a, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x40, 0x01, 0x52, 0x05, 0x69, 0x74,
+	0x65, 0x6d, 0x73, 0x22, 0x5b, 0x0a, 0x14, 0x57, 0x69, 0x74, 0x68, 0x53, 0x65, 0x74, 0x4f, 0x66,
+	0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x41, 0x74, 0x74, 0x72, 0x12, 0x43, 0x0a, 0x05, 0x69,
+	0x74, 0x65, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x68, 0x63, 0x6c,
+	0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x57, 0x69, 0x74, 0x68,
+	0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x41, 0x74, 0x74, 0x72, 0x42, 0x0d, 0x82, 0xb5, 0x18, 0x09,
+	0x0a, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x40, 0x03, 0x52, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73,
+	0x22, 0x67, 0x0a, 0x1c, 0x57, 0x69, 0x74, 0x68, 0x4c, 0x69, 0x73, 0x74, 0x4f, 0x66, 0x44, 0x79,
+	0x6e, 0x61, 0x6d, 0x69, 0x63, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x41, 0x74, 0x74, 0x72,
+	0x12, 0x47, 0x0a, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x22, 0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61,
+	0x2e, 0x57, 0x69, 0x74, 0x68, 0x52, 0x61, 0x77, 0x44, 0x79, 0x6e, 0x61, 0x6d, 0x69, 0x63, 0x41,
+	0x74, 0x74, 0x72, 0x42, 0x0d, 0x82, 0xb5, 0x18, 0x09, 0x0a, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73,
+	0x40, 0x02, 0x52, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x22, 0x43, 0x0a, 0x1c, 0x57, 0x69, 0x74,
+	0x68, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4b, 0x69, 0x6e, 0x64, 0x53,
+	0x63, 0x61, 0x6c, 0x61, 0x72, 0x41, 0x74, 0x74, 0x72, 0x12, 0x23, 0x0a, 0x05, 0x69, 0x74, 0x65,
+	0x6d, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x42, 0x0d, 0x82, 0xb5, 0x18, 0x09, 0x0a, 0x05,
+	0x69, 0x74, 0x65, 0x6d, 0x73, 0x40, 0x03, 0x52, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x22, 0x58,
+	0x0a, 0x11, 0x57, 0x69, 0x74, 0x68, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x41, 0x74,
+	0x74, 0x72, 0x73, 0x12, 0x20, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x42, 0x0c, 0x82, 0xb5, 0x18, 0x08, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x10, 0x01, 0x52,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x21, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x03, 0x42, 0x0b, 0x82, 0xb5, 0x18, 0x07, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x6d, 0x0a, 0x16, 0x57, 0x69, 0x74, 0x68,
+	0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x41, 0x73, 0x41, 0x74,
+	0x74, 0x72, 0x12, 0x53, 0x0a, 0x05, 0x74, 0x68, 0x69, 0x6e, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x30, 0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x63, 0x68, 0x65,
+	0x6d, 0x61, 0x2e, 0x57, 0x69, 0x74, 0x68, 0x4e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x42, 0x6c, 0x6f,
+	0x63, 0x6b, 0x4e, 0x6f, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x53, 0x69, 0x6e, 0x67, 0x6c, 0x65,
+	0x74, 0x6f, 0x6e, 0x42, 0x0b, 0x82, 0xb5, 0x18, 0x07, 0x0a, 0x05, 0x74, 0x68, 0x69, 0x6e, 0x67,
+	0x52, 0x05, 0x74, 0x68, 0x69, 0x6e, 0x67, 0x22, 0xb0, 0x01, 0x0a, 0x18, 0x57, 0x69, 0x74, 0x68,
+	0x4d, 0x61, 0x70, 0x4f, 0x66, 0x53, 0x63, 0x61, 0x6c, 0x61, 0x72, 0x73, 0x41, 0x73, 0x42, 0x6c,
+	0x6f, 0x63, 0x6b, 0x73, 0x12, 0x59, 0x0a, 0x06, 0x74, 0x68, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x34, 0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73,
+	0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x57, 0x69, 0x74, 0x68, 0x4d, 0x61, 0x70, 0x4f, 0x66, 0x53,
+	0x63, 0x61, 0x6c, 0x61, 0x72, 0x73, 0x41, 0x73, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x2e, 0x54,
+	0x68, 0x69, 0x6e, 0x67, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x42, 0x0b, 0x8a, 0xb5, 0x18, 0x07,
+	0x0a, 0x05, 0x74, 0x68, 0x69, 0x6e, 0x67, 0x52, 0x06, 0x74, 0x68, 0x69, 0x6e, 0x67, 0x73, 0x1a,
+	0x39, 0x0a, 0x0b, 0x54, 0x68, 0x69, 0x6e, 0x67, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10,
+	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
+	0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xa5, 0x01, 0x0a, 0x12, 0x57,
+	0x69, 0x74, 0x68, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x57, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67,
+	0x73, 0x12, 0x34, 0x0a, 0x0c, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x5f, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x11, 0x82, 0xb5, 0x18, 0x0d, 0x0a, 0x0b, 0x64,
+	0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x52, 0x0b, 0x64, 0x69, 0x73, 0x70,
+	0x6c, 0x61, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x69, 0x6e, 0x74, 0x65, 0x72,
+	0x6e, 0x61, 0x6c, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x69, 0x6e,
+	0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x49, 0x64, 0x12, 0x38, 0x0a, 0x05, 0x63, 0x6f, 0x6c, 0x6f,
+	0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x74, 0x65,
+	0x73, 0x74, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x42, 0x0b,
+	0x82, 0xb5, 0x18, 0x07, 0x0a, 0x05, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x52, 0x05, 0x63, 0x6f, 0x6c,
+	0x6f, 0x72, 0x22, 0x44, 0x0a, 0x14, 0x57, 0x69, 0x74, 0x68, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x41,
+	0x74, 0x74, 0x72, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x2c, 0x0a, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x18, 0x82, 0xb5, 0x18, 0x0a, 0x10, 0x01,
+	0x1a, 0x06, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x92, 0xb5, 0x18, 0x06, 0x0a, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x30, 0x0a, 0x0e, 0x4c, 0x61, 0x62, 0x65,
+	0x6c, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x42, 0x61, 0x73, 0x65, 0x12, 0x1e, 0x0a, 0x04, 0x6b, 0x69,
+	0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x0a, 0x92, 0xb5, 0x18, 0x06, 0x0a, 0x04,
+	0x6b, 0x69, 0x6e, 0x64, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x22, 0x71, 0x0a, 0x15, 0x57, 0x69,
+	0x74, 0x68, 0x46, 0x6c, 0x61, 0x74, 0x74, 0x65, 0x6e, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x4f, 0x72,
+	0x64, 0x65, 0x72, 0x12, 0x38, 0x0a, 0x04, 0x62, 0x61, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1e, 0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x63, 0x68, 0x65,
+	0x6d, 0x61, 0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x42, 0x61, 0x73,
+	0x65, 0x42, 0x04, 0xa0, 0xb5, 0x18, 0x01, 0x52, 0x04, 0x62, 0x61, 0x73, 0x65, 0x12, 0x1e, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x42, 0x0a, 0x92, 0xb5, 0x18,
+	0x06, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x46, 0x0a,
+	0x16, 0x57, 0x69, 0x74, 0x68, 0x4d, 0x69, 0x73, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x64, 0x41,
+	0x74, 0x74, 0x72, 0x54, 0x79, 0x70, 0x65, 0x12, 0x2c, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x18, 0x82, 0xb5, 0x18, 0x14, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x1a, 0x0c, 0x6c, 0x69, 0x73, 0x74, 0x28, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x29, 0x52,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0xa3, 0x02, 0x0a, 0x1d, 0x57, 0x69, 0x74, 0x68, 0x4d, 0x69,
+	0x73, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x64, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x41, 0x74,
+	0x74, 0x72, 0x54, 0x79, 0x70, 0x65, 0x73, 0x12, 0x6b, 0x0a, 0x06, 0x62, 0x79, 0x5f, 0x6b, 0x65,
+	0x79, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x38, 0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x74, 0x65,
+	0x73, 0x74, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x57, 0x69, 0x74, 0x68, 0x4d, 0x69, 0x73,
+	0x6d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x64, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x41, 0x74, 0x74,
+	0x72, 0x54, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x42, 0x79, 0x4b, 0x65, 0x79, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x42, 0x1a, 0x82, 0xb5, 0x18, 0x16, 0x0a, 0x06, 0x62, 0x79, 0x5f, 0x6b, 0x65, 0x79, 0x1a,
+	0x0c, 0x6c, 0x69, 0x73, 0x74, 0x28, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x29, 0x52, 0x05, 0x62,
+	0x79, 0x4b, 0x65, 0x79, 0x12, 0x43, 0x0a, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x15, 0x82, 0xb5, 0x18,
+	0x11, 0x0a, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x1a, 0x06, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67,
+	0x28, 0x01, 0x52, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x1a, 0x50, 0x0a, 0x0a, 0x42, 0x79, 0x4b,
+	0x65, 0x79, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2c, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x79, 0x0a, 0x22, 0x57,
+	0x69, 0x74, 0x68, 0x4e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x4d, 0x69, 0x73, 0x6d, 0x61, 0x74, 0x63,
+	0x68, 0x65, 0x64, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x41, 0x74, 0x74, 0x72, 0x54, 0x79, 0x70,
+	0x65, 0x12, 0x53, 0x0a, 0x06, 0x6e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x2d, 0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x63, 0x68, 0x65,
+	0x6d, 0x61, 0x2e, 0x57, 0x69, 0x74, 0x68, 0x4d, 0x69, 0x73, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x65,
+	0x64, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x41, 0x74, 0x74, 0x72, 0x54, 0x79, 0x70, 0x65, 0x73,
+	0x42, 0x0c, 0x82, 0xb5, 0x18, 0x08, 0x0a, 0x06, 0x6e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x52, 0x06,
+	0x6e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x22, 0x54, 0x0a, 0x0f, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64,
+	0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x42, 0x6f, 0x64, 0x79, 0x12, 0x1e, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x0a, 0x92, 0xb5, 0x18, 0x06, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x21, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x42, 0x0b, 0x82, 0xb5, 0x18, 0x07, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x5c, 0x0a, 0x17,
+	0x57, 0x69, 0x74, 0x68, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x42,
+	0x6f, 0x64, 0x79, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x41, 0x0a, 0x04, 0x69, 0x74, 0x65, 0x6d, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x74, 0x65, 0x73, 0x74,
+	0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x42, 0x6c, 0x6f,
+	0x63, 0x6b, 0x42, 0x6f, 0x64, 0x79, 0x42, 0x0c, 0x8a, 0xb5, 0x18, 0x08, 0x0a, 0x04, 0x69, 0x74,
+	0x65, 0x6d, 0x10, 0x02, 0x52, 0x04, 0x69, 0x74, 0x65, 0x6d, 0x22, 0x5b, 0x0a, 0x16, 0x57, 0x69,
+	0x74, 0x68, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x42, 0x6f, 0x64,
+	0x79, 0x53, 0x65, 0x74, 0x12, 0x41, 0x0a, 0x04, 0x69, 0x74, 0x65, 0x6d, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x63, 0x68,
+	0x65, 0x6d, 0x61, 0x2e, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x42,
+	0x6f, 0x64, 0x79, 0x42, 0x0c, 0x8a, 0xb5, 0x18, 0x08, 0x0a, 0x04, 0x69, 0x74, 0x65, 0x6d, 0x10,
+	0x03, 0x52, 0x04, 0x69, 0x74, 0x65, 0x6d, 0x22, 0xe9, 0x01, 0x0a, 0x18, 0x57, 0x69, 0x74, 0x68,
+	0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x42, 0x6f, 0x64, 0x79, 0x4b,
+	0x69, 0x6e, 0x64, 0x73, 0x12, 0x47, 0x0a, 0x04, 0x6c, 0x69, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x27, 0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x63, 0x68,
+	0x65, 0x6d, 0x61, 0x2e, 0x57, 0x69, 0x74, 0x68, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x42, 0x6c,
+	0x6f, 0x63, 0x6b, 0x42, 0x6f, 0x64, 0x79, 0x4c, 0x69, 0x73, 0x74, 0x42, 0x0a, 0x8a, 0xb5, 0x18,
+	0x06, 0x0a, 0x04, 0x6c, 0x69, 0x73, 0x74, 0x52, 0x04, 0x6c, 0x69, 0x73, 0x74, 0x12, 0x43, 0x0a,
+	0x03, 0x73, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x68, 0x63, 0x6c,
+	0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x57, 0x69, 0x74, 0x68,
+	0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x42, 0x6f, 0x64, 0x79, 0x53,
+	0x65, 0x74, 0x42, 0x09, 0x8a, 0xb5, 0x18, 0x05, 0x0a, 0x03, 0x73, 0x65, 0x74, 0x52, 0x03, 0x73,
+	0x65, 0x74, 0x12, 0x3f, 0x0a, 0x04, 0x69, 0x74, 0x65, 0x6d, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x1f, 0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x63, 0x68, 0x65, 0x6d,
+	0x61, 0x2e, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x42, 0x6f, 0x64,
+	0x79, 0x42, 0x0a, 0x8a, 0xb5, 0x18, 0x06, 0x0a, 0x04, 0x69, 0x74, 0x65, 0x6d, 0x52, 0x04, 0x69,
+	0x74, 0x65, 0x6d, 0x22, 0x3c, 0x0a, 0x16, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x79, 0x6e,
+	0x61, 0x6d, 0x69, 0x63, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x42, 0x6f, 0x64, 0x79, 0x12, 0x22, 0x0a,
+	0x03, 0x72, 0x61, 0x77, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x42, 0x10, 0x82, 0xb5, 0x18, 0x0c,
+	0x0a, 0x03, 0x72, 0x61, 0x77, 0x1a, 0x03, 0x61, 0x6e, 0x79, 0x20, 0x02, 0x52, 0x03, 0x72, 0x61,
+	0x77, 0x22, 0x6b, 0x0a, 0x1f, 0x57, 0x69, 0x74, 0x68, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44,
+	0x79, 0x6e, 0x61, 0x6d, 0x69, 0x63, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x42, 0x6f, 0x64, 0x79, 0x54,
+	0x75, 0x70, 0x6c, 0x65, 0x12, 0x48, 0x0a, 0x04, 0x69, 0x74, 0x65, 0x6d, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x26, 0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x63, 0x68,
+	0x65, 0x6d, 0x61, 0x2e, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x79, 0x6e, 0x61, 0x6d, 0x69,
+	0x63, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x42, 0x6f, 0x64, 0x79, 0x42, 0x0c, 0x8a, 0xb5, 0x18, 0x08,
+	0x0a, 0x04, 0x69, 0x74, 0x65, 0x6d, 0x10, 0x01, 0x52, 0x04, 0x69, 0x74, 0x65, 0x6d, 0x22, 0x69,
+	0x0a, 0x1d, 0x57, 0x69, 0x74, 0x68, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x79, 0x6e, 0x61,
+	0x6d, 0x69, 0x63, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x42, 0x6f, 0x64, 0x79, 0x53, 0x65, 0x74, 0x12,
+	0x48, 0x0a, 0x04, 0x69, 0x74, 0x65, 0x6d, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x26, 0x2e,
+	0x68, 0x63, 0x6c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x53,
+	0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x79, 0x6e, 0x61, 0x6d, 0x69, 0x63, 0x42, 0x6c, 0x6f, 0x63,
+	0x6b, 0x42, 0x6f, 0x64, 0x79, 0x42, 0x0c, 0x8a, 0xb5, 0x18, 0x08, 0x0a, 0x04, 0x69, 0x74, 0x65,
+	0x6d, 0x10, 0x03, 0x52, 0x04, 0x69, 0x74, 0x65, 0x6d, 0x22, 0xc1, 0x01, 0x0a, 0x1f, 0x57, 0x69,
+	0x74, 0x68, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x79, 0x6e, 0x61, 0x6d, 0x69, 0x63, 0x42,
+	0x6c, 0x6f, 0x63, 0x6b, 0x42, 0x6f, 0x64, 0x79, 0x4b, 0x69, 0x6e, 0x64, 0x73, 0x12, 0x52, 0x0a,
+	0x05, 0x74, 0x75, 0x70, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x68,
+	0x63, 0x6c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x57, 0x69,
+	0x74, 0x68, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x79, 0x6e, 0x61, 0x6d, 0x69, 0x63, 0x42,
+	0x6c, 0x6f, 0x63, 0x6b, 0x42, 0x6f, 0x64, 0x79, 0x54, 0x75, 0x70, 0x6c, 0x65, 0x42, 0x0b, 0x8a,
+	0xb5, 0x18, 0x07, 0x0a, 0x05, 0x74, 0x75, 0x70, 0x6c, 0x65, 0x52, 0x05, 0x74, 0x75, 0x70, 0x6c,
+	0x65, 0x12, 0x4a, 0x0a, 0x03, 0x73, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2d,
+	0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e,
+	0x57, 0x69, 0x74, 0x68, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x44, 0x79, 0x6e, 0x61, 0x6d, 0x69,
+	0x63, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x42, 0x6f, 0x64, 0x79, 0x53, 0x65, 0x74, 0x42, 0x09, 0x8a,
+	0xb5, 0x18, 0x05, 0x0a, 0x03, 0x73, 0x65, 0x74, 0x52, 0x03, 0x73, 0x65, 0x74, 0x22, 0xa9, 0x01,
+	0x0a, 0x0b, 0x54, 0x4c, 0x53, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x34, 0x0a,
+	0x09, 0x63, 0x65, 0x72, 0x74, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x42, 0x17, 0x82, 0xb5, 0x18, 0x13, 0x0a, 0x09, 0x63, 0x65, 0x72, 0x74, 0x5f, 0x66, 0x69, 0x6c,
+	0x65, 0x1a, 0x06, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x52, 0x08, 0x63, 0x65, 0x72, 0x74, 0x46,
+	0x69, 0x6c, 0x65, 0x12, 0x2a, 0x0a, 0x08, 0x69, 0x6e, 0x73, 0x65, 0x63, 0x75, 0x72, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x08, 0x42, 0x0e, 0x82, 0xb5, 0x18, 0x0a, 0x0a, 0x08, 0x69, 0x6e, 0x73,
+	0x65, 0x63, 0x75, 0x72, 0x65, 0x52, 0x08, 0x69, 0x6e, 0x73, 0x65, 0x63, 0x75, 0x72, 0x65, 0x12,
+	0x38, 0x0a, 0x02, 0x63, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x68, 0x63,
+	0x6c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x57, 0x69, 0x74,
+	0x68, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x41, 0x74, 0x74, 0x72, 0x42, 0x08, 0x8a, 0xb5, 0x18,
+	0x04, 0x0a, 0x02, 0x63, 0x61, 0x52, 0x02, 0x63, 0x61, 0x22, 0xc7, 0x01, 0x0a, 0x11, 0x57, 0x69,
+	0x74, 0x68, 0x46, 0x6c, 0x61, 0x74, 0x74, 0x65, 0x6e, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12,
+	0x44, 0x0a, 0x06, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1b, 0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61,
+	0x2e, 0x54, 0x4c, 0x53, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x42, 0x0f, 0xaa, 0xb5,
+	0x18, 0x0b, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x74, 0x6c, 0x73, 0x5f, 0x52, 0x06, 0x63,
+	0x6c, 0x69, 0x65, 0x6e, 0x74, 0x12, 0x44, 0x0a, 0x06, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x74, 0x65, 0x73, 0x74,
+	0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x54, 0x4c, 0x53, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e,
+	0x67, 0x73, 0x42, 0x0f, 0xaa, 0xb5, 0x18, 0x0b, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x5f, 0x74,
+	0x6c, 0x73, 0x5f, 0x52, 0x06, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x26, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x42, 0x12, 0x82, 0xb5, 0x18, 0x0e, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x1a, 0x06, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x52, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x22, 0x5e, 0x0a, 0x17, 0x57, 0x69, 0x74, 0x68, 0x4e, 0x65, 0x73, 0x74, 0x65,
+	0x64, 0x46, 0x6c, 0x61, 0x74, 0x74, 0x65, 0x6e, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x43,
+	0x0a, 0x05, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e,
+	0x68, 0x63, 0x6c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x57,
+	0x69, 0x74, 0x68, 0x46, 0x6c, 0x61, 0x74, 0x74, 0x65, 0x6e, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78,
+	0x42, 0x0a, 0xaa, 0xb5, 0x18, 0x06, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x5f, 0x52, 0x05, 0x70, 0x72,
+	0x6f, 0x78, 0x79, 0x22, 0x59, 0x0a, 0x16, 0x57, 0x69, 0x74, 0x68, 0x46, 0x6c, 0x61, 0x74, 0x74,
+	0x65, 0x6e, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x12, 0x3f, 0x0a,
+	0x04, 0x62, 0x61, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x68, 0x63,
+	0x6c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x57, 0x69, 0x74,
+	0x68, 0x4f, 0x6e, 0x65, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x42, 0x08,
+	0xaa, 0xb5, 0x18, 0x04, 0x70, 0x65, 0x74, 0x5f, 0x52, 0x04, 0x62, 0x61, 0x73, 0x65, 0x22, 0x68,
+	0x0a, 0x21, 0x57, 0x69, 0x74, 0x68, 0x4e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x42, 0x6c, 0x6f, 0x63,
+	0x6b, 0x46, 0x6c, 0x61, 0x74, 0x74, 0x65, 0x6e, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x4c, 0x61,
+	0x62, 0x65, 0x6c, 0x12, 0x43, 0x0a, 0x03, 0x70, 0x65, 0x74, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x26, 0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x63, 0x68, 0x65, 0x6d,
+	0x61, 0x2e, 0x57, 0x69, 0x74, 0x68, 0x46, 0x6c, 0x61, 0x74, 0x74, 0x65, 0x6e, 0x50, 0x72, 0x65,
+	0x66, 0x69, 0x78, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x42, 0x09, 0x8a, 0xb5, 0x18, 0x05, 0x0a, 0x03,
+	0x70, 0x65, 0x74, 0x52, 0x03, 0x70, 0x65, 0x74, 0x22, 0x5d, 0x0a, 0x18, 0x57, 0x69, 0x74, 0x68,
+	0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x46, 0x6c, 0x61, 0x74, 0x74, 0x65, 0x6e, 0x50, 0x72,
+	0x65, 0x66, 0x69, 0x78, 0x12, 0x41, 0x0a, 0x04, 0x62, 0x61, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x63, 0x68,
+	0x65, 0x6d, 0x61, 0x2e, 0x57, 0x69, 0x74, 0x68, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x41, 0x74,
+	0x74, 0x72, 0x42, 0x0d, 0xaa, 0xb5, 0x18, 0x09, 0x6e, 0x6f, 0x74, 0x20, 0x76, 0x61, 0x6c, 0x69,
+	0x64, 0x52, 0x04, 0x62, 0x61, 0x73, 0x65, 0x22, 0x69, 0x0a, 0x0e, 0x4c, 0x65, 0x67, 0x61, 0x63,
+	0x79, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x26, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x12, 0x82, 0xb5, 0x18, 0x0e, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x1a, 0x06, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x2f, 0x0a, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x42, 0x15, 0x82, 0xb5, 0x18, 0x11, 0x0a, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75,
+	0x74, 0x1a, 0x06, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x52, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f,
+	0x75, 0x74, 0x22, 0x89, 0x01, 0x0a, 0x1c, 0x57, 0x69, 0x74, 0x68, 0x46, 0x6c, 0x61, 0x74, 0x74,
+	0x65, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63, 0x74, 0x4f, 0x75, 0x74, 0x65, 0x72, 0x57,
+	0x69, 0x6e, 0x73, 0x12, 0x40, 0x0a, 0x06, 0x6c, 0x65, 0x67, 0x61, 0x63, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x63,
+	0x68, 0x65, 0x6d, 0x61, 0x2e, 0x4c, 0x65, 0x67, 0x61, 0x63, 0x79, 0x53, 0x65, 0x74, 0x74, 0x69,
+	0x6e, 0x67, 0x73, 0x42, 0x08, 0xa0, 0xb5, 0x18, 0x01, 0xb0, 0xb5, 0x18, 0x01, 0x52, 0x06, 0x6c,
+	0x65, 0x67, 0x61, 0x63, 0x79, 0x12, 0x27, 0x0a, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x42, 0x0d, 0x82, 0xb5, 0x18, 0x09, 0x0a, 0x07, 0x74, 0x69,
+	0x6d, 0x65, 0x6f, 0x75, 0x74, 0x52, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x22, 0x89,
+	0x01, 0x0a, 0x1c, 0x57, 0x69, 0x74, 0x68, 0x46, 0x6c, 0x61, 0x74, 0x74, 0x65, 0x6e, 0x43, 0x6f,
+	0x6e, 0x66, 0x6c, 0x69, 0x63, 0x74, 0x49, 0x6e, 0x6e, 0x65, 0x72, 0x57, 0x69, 0x6e, 0x73, 0x12,
+	0x27, 0x0a, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03,
+	0x42, 0x0d, 0x82, 0xb5, 0x18, 0x09, 0x0a, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x52,
+	0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x40, 0x0a, 0x06, 0x6c, 0x65, 0x67, 0x61,
+	0x63, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x74,
+	0x65, 0x73, 0x74, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x4c, 0x65, 0x67, 0x61, 0x63, 0x79,
+	0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x42, 0x08, 0xa0, 0xb5, 0x18, 0x01, 0xb0, 0xb5,
+	0x18, 0x02, 0x52, 0x06, 0x6c, 0x65, 0x67, 0x61, 0x63, 0x79, 0x22, 0x6b, 0x0a, 0x19, 0x57, 0x69,
+	0x74, 0x68, 0x4e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x46, 0x6c, 0x61, 0x74, 0x74, 0x65, 0x6e, 0x43,
+	0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63, 0x74, 0x12, 0x4e, 0x0a, 0x05, 0x6f, 0x75, 0x74, 0x65, 0x72,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x74, 0x65, 0x73,
+	0x74, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x57, 0x69, 0x74, 0x68, 0x46, 0x6c, 0x61, 0x74,
+	0x74, 0x65, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63, 0x74, 0x4f, 0x75, 0x74, 0x65, 0x72,
+	0x57, 0x69, 0x6e, 0x73, 0x42, 0x0a, 0xaa, 0xb5, 0x18, 0x06, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x5f,
+	0x52, 0x05, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x22, 0x56, 0x0a, 0x1a, 0x57, 0x69, 0x74, 0x68, 0x49,
+	0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x46, 0x6c, 0x61, 0x74, 0x74, 0x65, 0x6e, 0x43, 0x6f, 0x6e,
+	0x66, 0x6c, 0x69, 0x63, 0x74, 0x12, 0x38, 0x0a, 0x04, 0x62, 0x61, 0x73, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x63,
+	0x68, 0x65, 0x6d, 0x61, 0x2e, 0x57, 0x69, 0x74, 0x68, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x41,
+	0x74, 0x74, 0x72, 0x42, 0x04, 0xb0, 0xb5, 0x18, 0x01, 0x52, 0x04, 0x62, 0x61, 0x73, 0x65, 0x22,
+	0xbd, 0x01, 0x0a, 0x14, 0x57, 0x69, 0x74, 0x68, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x54, 0x79, 0x70,
+	0x65, 0x41, 0x6c, 0x69, 0x61, 0x73, 0x65, 0x73, 0x12, 0x57, 0x0a, 0x06, 0x64, 0x6f, 0x6f, 0x64,
+	0x61, 0x64, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x74,
+	0x65, 0x73, 0x74, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x57, 0x69, 0x74, 0x68, 0x4f, 0x6e,
+	0x65, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x42, 0x1c, 0x8a, 0xb5, 0x18,
+	0x18, 0x0a, 0x06, 0x64, 0x6f, 0x6f, 0x64, 0x61, 0x64, 0x22, 0x06, 0x67, 0x61, 0x64, 0x67, 0x65,
+	0x74, 0x22, 0x06, 0x77, 0x69, 0x64, 0x67, 0x65, 0x74, 0x52, 0x06, 0x64, 0x6f, 0x6f, 0x64, 0x61,
+	0x64, 0x12, 0x4c, 0x0a, 0x05, 0x74, 0x68, 0x69, 0x6e, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1e, 0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x63, 0x68, 0x65, 0x6d,
+	0x61, 0x2e, 0x57, 0x69, 0x74, 0x68, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x41, 0x74, 0x74, 0x72,
+	0x42, 0x16, 0x8a, 0xb5, 0x18, 0x12, 0x0a, 0x05, 0x74, 0x68, 0x69, 0x6e, 0x67, 0x22, 0x09, 0x6f,
+	0x6c, 0x64, 0x5f, 0x74, 0x68, 0x69, 0x6e, 0x67, 0x52, 0x05, 0x74, 0x68, 0x69, 0x6e, 0x67, 0x22,
+	0x64, 0x0a, 0x1d, 0x57, 0x69, 0x74, 0x68, 0x46, 0x6c, 0x61, 0x74, 0x74, 0x65, 0x6e, 0x65, 0x64,
+	0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x54, 0x79, 0x70, 0x65, 0x41, 0x6c, 0x69, 0x61, 0x73, 0x65, 0x73,
+	0x12, 0x43, 0x0a, 0x04, 0x62, 0x61, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24,
+	0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e,
+	0x57, 0x69, 0x74, 0x68, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x54, 0x79, 0x70, 0x65, 0x41, 0x6c, 0x69,
+	0x61, 0x73, 0x65, 0x73, 0x42, 0x09, 0xaa, 0xb5, 0x18, 0x05, 0x62, 0x61, 0x73, 0x65, 0x5f, 0x52,
+	0x04, 0x62, 0x61, 0x73, 0x65, 0x22, 0x8d, 0x01, 0x0a, 0x1a, 0x57, 0x69, 0x74, 0x68, 0x42, 0x6c,
+	0x6f, 0x63, 0x6b, 0x54, 0x79, 0x70, 0x65, 0x41, 0x6c, 0x69, 0x61, 0x73, 0x43, 0x6f, 0x6e, 0x66,
+	0x6c, 0x69, 0x63, 0x74, 0x12, 0x47, 0x0a, 0x05, 0x74, 0x68, 0x69, 0x6e, 0x67, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x63,
+	0x68, 0x65, 0x6d, 0x61, 0x2e, 0x57, 0x69, 0x74, 0x68, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x41,
+	0x74, 0x74, 0x72, 0x42, 0x11, 0x8a, 0xb5, 0x18, 0x0d, 0x0a, 0x05, 0x74, 0x68, 0x69, 0x6e, 0x67,
+	0x22, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x52, 0x05, 0x74, 0x68, 0x69, 0x6e, 0x67, 0x12, 0x26, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x42, 0x12, 0x82, 0xb5, 0x18,
+	0x0e, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x1a, 0x06, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x52,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x65, 0x0a, 0x19, 0x57, 0x69, 0x74, 0x68, 0x49, 0x6e, 0x76,
+	0x61, 0x6c, 0x69, 0x64, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x54, 0x79, 0x70, 0x65, 0x41, 0x6c, 0x69,
+	0x61, 0x73, 0x12, 0x48, 0x0a, 0x05, 0x74, 0x68, 0x69, 0x6e, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1e, 0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x63, 0x68, 0x65,
+	0x6d, 0x61, 0x2e, 0x57, 0x69, 0x74, 0x68, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x41, 0x74, 0x74,
+	0x72, 0x42, 0x12, 0x8a, 0xb5, 0x18, 0x0e, 0x0a, 0x05, 0x74, 0x68, 0x69, 0x6e, 0x67, 0x22, 0x05,
+	0x74, 0x68, 0x69, 0x6e, 0x67, 0x52, 0x05, 0x74, 0x68, 0x69, 0x6e, 0x67, 0x22, 0xa5, 0x04, 0x0a,
+	0x14, 0x57, 0x69, 0x74, 0x68, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x61, 0x62, 0x6c, 0x65, 0x43, 0x6f,
+	0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x20, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x42, 0x0c, 0x82, 0xb5, 0x18, 0x08, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x10,
+	0x01, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x38, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72,
+	0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x42, 0x11, 0x82, 0xb5,
+	0x18, 0x0d, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x48,
+	0x00, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x88, 0x01,
+	0x01, 0x12, 0x21, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03,
+	0x42, 0x0b, 0x82, 0xb5, 0x18, 0x07, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x05, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x12, 0x4d, 0x0a, 0x08, 0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x74, 0x65, 0x73,
+	0x74, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x57, 0x69, 0x74, 0x68, 0x4f, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x61, 0x6c, 0x41, 0x74, 0x74, 0x72, 0x73, 0x42, 0x0e, 0x8a, 0xb5, 0x18, 0x0a, 0x0a,
+	0x08, 0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x08, 0x73, 0x65, 0x74, 0x74, 0x69,
+	0x6e, 0x67, 0x73, 0x12, 0x43, 0x0a, 0x04, 0x72, 0x75, 0x6c, 0x65, 0x18, 0x05, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x21, 0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x63, 0x68, 0x65,
+	0x6d, 0x61, 0x2e, 0x57, 0x69, 0x74, 0x68, 0x4f, 0x6e, 0x65, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4c,
+	0x61, 0x62, 0x65, 0x6c, 0x42, 0x0c, 0x8a, 0xb5, 0x18, 0x08, 0x0a, 0x04, 0x72, 0x75, 0x6c, 0x65,
+	0x10, 0x02, 0x52, 0x04, 0x72, 0x75, 0x6c, 0x65, 0x12, 0x52, 0x0a, 0x04, 0x70, 0x65, 0x74, 0x73,
+	0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2e, 0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x74, 0x65, 0x73,
+	0x74, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x57, 0x69, 0x74, 0x68, 0x4d, 0x65, 0x72, 0x67,
+	0x65, 0x61, 0x62, 0x6c, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x2e, 0x50, 0x65, 0x74,
+	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x42, 0x0e, 0x8a, 0xb5, 0x18, 0x0a, 0x0a, 0x03, 0x70, 0x65,
+	0x74, 0x1a, 0x03, 0x6b, 0x65, 0x79, 0x52, 0x04, 0x70, 0x65, 0x74, 0x73, 0x12, 0x3d, 0x0a, 0x04,
+	0x62, 0x61, 0x73, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x68, 0x63, 0x6c,
+	0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x57, 0x69, 0x74, 0x68,
+	0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x41, 0x74, 0x74, 0x72, 0x42, 0x09, 0xaa, 0xb5, 0x18, 0x05,
+	0x62, 0x61, 0x73, 0x65, 0x5f, 0x52, 0x04, 0x62, 0x61, 0x73, 0x65, 0x1a, 0x57, 0x0a, 0x09, 0x50,
+	0x65, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x34, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x68, 0x63, 0x6c, 0x2e,
+	0x74, 0x65, 0x73, 0x74, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x57, 0x69, 0x74, 0x68, 0x53,
+	0x74, 0x72, 0x69, 0x6e, 0x67, 0x41, 0x74, 0x74, 0x72, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x3a, 0x02, 0x38, 0x01, 0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x22, 0xd3, 0x01, 0x0a, 0x1a, 0x57, 0x69, 0x74, 0x68, 0x4d, 0x61, 0x70,
+	0x4f, 0x66, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x4b, 0x65, 0x79, 0x65, 0x64, 0x42, 0x79, 0x41,
+	0x74, 0x74, 0x72, 0x12, 0x59, 0x0a, 0x04, 0x70, 0x65, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x34, 0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x63, 0x68, 0x65,
+	0x6d, 0x61, 0x2e, 0x57, 0x69, 0x74, 0x68, 0x4d, 0x61, 0x70, 0x4f, 0x66, 0x42, 0x6c, 0x6f, 0x63,
+	0x6b, 0x73, 0x4b, 0x65, 0x79, 0x65, 0x64, 0x42, 0x79, 0x41, 0x74, 0x74, 0x72, 0x2e, 0x50, 0x65,
+	0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x42, 0x0f, 0x8a, 0xb5, 0x18, 0x0b, 0x0a, 0x03, 0x70,
+	0x65, 0x74, 0x2a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x52, 0x04, 0x70, 0x65, 0x74, 0x73, 0x1a, 0x5a,
+	0x0a, 0x09, 0x50, 0x65, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x37, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x68,
+	0x63, 0x6c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x57, 0x69,
+	0x74, 0x68, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x41, 0x74, 0x74, 0x72, 0x73, 0x52,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xca, 0x01, 0x0a, 0x15, 0x57,
+	0x69, 0x74, 0x68, 0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x4d, 0x61, 0x70, 0x4b, 0x65, 0x79,
+	0x41, 0x74, 0x74, 0x72, 0x12, 0x55, 0x0a, 0x04, 0x70, 0x65, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x63, 0x68,
+	0x65, 0x6d, 0x61, 0x2e, 0x57, 0x69, 0x74, 0x68, 0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x4d,
+	0x61, 0x70, 0x4b, 0x65, 0x79, 0x41, 0x74, 0x74, 0x72, 0x2e, 0x50, 0x65, 0x74, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x42, 0x10, 0x8a, 0xb5, 0x18, 0x0c, 0x0a, 0x03, 0x70, 0x65, 0x74, 0x2a, 0x05,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x04, 0x70, 0x65, 0x74, 0x73, 0x1a, 0x5a, 0x0a, 0x09, 0x50,
+	0x65, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x37, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x68, 0x63, 0x6c, 0x2e,
+	0x74, 0x65, 0x73, 0x74, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x57, 0x69, 0x74, 0x68, 0x4f,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x41, 0x74, 0x74, 0x72, 0x73, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xdb, 0x03, 0x0a, 0x16, 0x57, 0x69, 0x74, 0x68,
+	0x4d, 0x73, 0x67, 0x54, 0x79, 0x70, 0x65, 0x43, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x61, 0x69, 0x6e,
+	0x74, 0x73, 0x12, 0x73, 0x0a, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x21, 0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x63, 0x68, 0x65,
+	0x6d, 0x61, 0x2e, 0x57, 0x69, 0x74, 0x68, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x41,
+	0x74, 0x74, 0x72, 0x73, 0x42, 0x3a, 0x82, 0xb5, 0x18, 0x36, 0x0a, 0x05, 0x69, 0x74, 0x65, 0x6d,
+	0x73, 0x1a, 0x2d, 0x6c, 0x69, 0x73, 0x74, 0x28, 0x6d, 0x73, 0x67, 0x28, 0x22, 0x68, 0x63, 0x6c,
+	0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x57, 0x69, 0x74, 0x68,
+	0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x41, 0x74, 0x74, 0x72, 0x73, 0x22, 0x29, 0x29,
+	0x52, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x12, 0x84, 0x01, 0x0a, 0x06, 0x62, 0x79, 0x5f, 0x6b,
+	0x65, 0x79, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x31, 0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x74,
+	0x65, 0x73, 0x74, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x57, 0x69, 0x74, 0x68, 0x4d, 0x73,
+	0x67, 0x54, 0x79, 0x70, 0x65, 0x43, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x74, 0x73,
+	0x2e, 0x42, 0x79, 0x4b, 0x65, 0x79, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x42, 0x3a, 0x82, 0xb5, 0x18,
+	0x36, 0x0a, 0x06, 0x62, 0x79, 0x5f, 0x6b, 0x65, 0x79, 0x1a, 0x2c, 0x6d, 0x61, 0x70, 0x28, 0x6d,
+	0x73, 0x67, 0x28, 0x22, 0x68, 0x63, 0x6c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x63, 0x68, 0x65,
+	0x6d, 0x61, 0x2e, 0x57, 0x69, 0x74, 0x68, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x41,
+	0x74, 0x74, 0x72, 0x73, 0x22, 0x29, 0x29, 0x52, 0x05, 0x62, 0x79, 0x4b, 0x65, 0x79, 0x12, 0x68,
+	0x0a, 0x03, 0x72, 0x61, 0x77, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x42, 0x56, 0x82, 0xb5, 0x18,
+	0x52, 0x0a, 0x03, 0x72, 0x61, 0x77, 0x1a, 0x49, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x28, 0x7b,
+	0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x20, 0x3d, 0x20, 0x6d, 0x73, 0x67, 0x28, 0x22,
+	0x68, 0x63, 0x6c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x57,
+	0x69, 0x74, 0x68, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x41, 0x74, 0x74, 0x72, 0x73,
+	0x22, 0x29, 0x2c, 0x20, 0x65, 0x78, 0x74, 0x72, 0x61, 0x20, 0x3d, 0x20, 0x61, 0x6e, 0x79, 0x7d,
+	0x29, 0x20, 0x02, 0x52, 0x03, 0x72, 0x61, 0x77, 0x1a, 0x5b, 0x0a, 0x0a, 0x42, 0x79, 0x4b, 0x65,
+	0x79, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x37, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x74, 0x65,
+	0x73, 0x74, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x57, 0x69, 0x74, 0x68, 0x4f, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x41, 0x74, 0x74, 0x72, 0x73, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xb9, 0x01, 0x0a, 0x1e, 0x57, 0x69, 0x74, 0x68, 0x52, 0x65,
+	0x63, 0x75, 0x72, 0x73, 0x69, 0x76, 0x65, 0x4d, 0x73, 0x67, 0x54, 0x79, 0x70, 0x65, 0x43, 0x6f,
+	0x6e, 0x73, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x74, 0x12, 0x96, 0x01, 0x0a, 0x08, 0x63, 0x68, 0x69,
+	0x6c, 0x64, 0x72, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2e, 0x2e, 0x68, 0x63,
+	0x6c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x57, 0x69, 0x74,
+	0x68, 0x52, 0x65, 0x63, 0x75, 0x72, 0x73, 0x69, 0x76, 0x65, 0x4d, 0x73, 0x67, 0x54, 0x79, 0x70,
+	0x65, 0x43, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x74, 0x42, 0x4a, 0x82, 0xb5, 0x18,
+	0x46, 0x0a, 0x08, 0x63, 0x68, 0x69, 0x6c, 0x64, 0x72, 0x65, 0x6e, 0x1a, 0x3a, 0x6c, 0x69, 0x73,
+	0x74, 0x28, 0x6d, 0x73, 0x67, 0x28, 0x22, 0x68, 0x63, 0x6c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73,
+	0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x57, 0x69, 0x74, 0x68, 0x52, 0x65, 0x63, 0x75, 0x72, 0x73,
+	0x69, 0x76, 0x65, 0x4d, 0x73, 0x67, 0x54, 0x79, 0x70, 0x65, 0x43, 0x6f, 0x6e, 0x73, 0x74, 0x72,
+	0x61, 0x69, 0x6e, 0x74, 0x22, 0x29, 0x29, 0x52, 0x08, 0x63, 0x68, 0x69, 0x6c, 0x64, 0x72, 0x65,
+	0x6e, 0x22, 0x61, 0x0a, 0x1c, 0x57, 0x69, 0x74, 0x68, 0x55, 0x6e, 0x6b, 0x6e, 0x6f, 0x77, 0x6e,
+	0x4d, 0x73, 0x67, 0x54, 0x79, 0x70, 0x65, 0x43, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x61, 0x69, 0x6e,
+	0x74, 0x12, 0x41, 0x0a, 0x03, 0x72, 0x61, 0x77, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x42, 0x2f,
+	0x82, 0xb5, 0x18, 0x2b, 0x0a, 0x03, 0x72, 0x61, 0x77, 0x1a, 0x22, 0x6d, 0x73, 0x67, 0x28, 0x22,
+	0x68, 0x63, 0x6c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x44,
+	0x6f, 0x65, 0x73, 0x4e, 0x6f, 0x74, 0x45, 0x78, 0x69, 0x73, 0x74, 0x22, 0x29, 0x20, 0x02, 0x52,
+	0x03, 0x72, 0x61, 0x77, 0x22, 0x5f, 0x0a, 0x0f, 0x53, 0x33, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67,
+	0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x26, 0x0a, 0x06, 0x62, 0x75, 0x63, 0x6b, 0x65,
+	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x0e, 0x82, 0xb5, 0x18, 0x0a, 0x0a, 0x06, 0x62,
+	0x75, 0x63, 0x6b, 0x65, 0x74, 0x10, 0x01, 0x52, 0x06, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x12,
+	0x24, 0x0a, 0x06, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x42,
+	0x0c, 0x82, 0xb5, 0x18, 0x08, 0x0a, 0x06, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x72,
+	0x65, 0x67, 0x69, 0x6f, 0x6e, 0x22, 0x36, 0x0a, 0x12, 0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x53, 0x74,
+	0x6f, 0x72, 0x61, 0x67, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x20, 0x0a, 0x04, 0x70,
+	0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x0c, 0x82, 0xb5, 0x18, 0x08, 0x0a,
+	0x04, 0x70, 0x61, 0x74, 0x68, 0x10, 0x01, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x22, 0xe5, 0x01,
+	0x0a, 0x0c, 0x57, 0x69, 0x74, 0x68, 0x41, 0x6e, 0x79, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x35,
+	0x0a, 0x0c, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x42, 0x12, 0x82, 0xb5, 0x18, 0x0e, 0x0a, 0x0c, 0x73, 0x74, 0x6f, 0x72,
+	0x61, 0x67, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x52, 0x0b, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67,
+	0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x9d, 0x01, 0x0a, 0x07, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x41, 0x6e, 0x79, 0x42, 0x6d, 0x8a,
+	0xb5, 0x18, 0x69, 0x0a, 0x07, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x32, 0x0c, 0x73, 0x74,
+	0x6f, 0x72, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x3a, 0x24, 0x0a, 0x02, 0x73, 0x33,
+	0x12, 0x1e, 0x68, 0x63, 0x6c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61,
+	0x2e, 0x53, 0x33, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x3a, 0x2a, 0x0a, 0x05, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x12, 0x21, 0x68, 0x63, 0x6c, 0x2e, 0x74,
+	0x65, 0x73, 0x74, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x53,
+	0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x07, 0x73, 0x74,
+	0x6f, 0x72, 0x61, 0x67, 0x65, 0x22, 0x5d, 0x0a, 0x15, 0x57, 0x69, 0x74, 0x68, 0x46, 0x6c, 0x61,
+	0x74, 0x74, 0x65, 0x6e, 0x65, 0x64, 0x41, 0x6e, 0x79, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x44,
+	0x0a, 0x07, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1c, 0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61,
+	0x2e, 0x57, 0x69, 0x74, 0x68, 0x41, 0x6e, 0x79, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x42, 0x0c, 0xaa,
+	0xb5, 0x18, 0x08, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x5f, 0x52, 0x07, 0x62, 0x61, 0x63,
+	0x6b, 0x65, 0x6e, 0x64, 0x22, 0xd0, 0x01, 0x0a, 0x1e, 0x57, 0x69, 0x74, 0x68, 0x41, 0x6e, 0x79,
+	0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x44, 0x69, 0x73, 0x63, 0x72, 0x69, 0x6d, 0x69, 0x6e, 0x61, 0x74,
+	0x6f, 0x72, 0x41, 0x66, 0x74, 0x65, 0x72, 0x12, 0x77, 0x0a, 0x07, 0x73, 0x74, 0x6f, 0x72, 0x61,
+	0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x41, 0x6e, 0x79, 0x42, 0x47,
+	0x8a, 0xb5, 0x18, 0x43, 0x0a, 0x07, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x32, 0x0c, 0x73,
+	0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x3a, 0x2a, 0x0a, 0x05, 0x6c,
+	0x6f, 0x63, 0x61, 0x6c, 0x12, 0x21, 0x68, 0x63, 0x6c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x63,
+	0x68, 0x65, 0x6d, 0x61, 0x2e, 0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67,
+	0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x07, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65,
+	0x12, 0x35, 0x0a, 0x0c, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x42, 0x12, 0x82, 0xb5, 0x18, 0x0e, 0x0a, 0x0c, 0x73, 0x74,
+	0x6f, 0x72, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x52, 0x0b, 0x73, 0x74, 0x6f, 0x72,
+	0x61, 0x67, 0x65, 0x54, 0x79, 0x70, 0x65, 0x22, 0xc3, 0x01, 0x0a, 0x17, 0x57, 0x69, 0x74, 0x68,
+	0x41, 0x6e, 0x79, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x55, 0x6e, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x54,
+	0x79, 0x70, 0x65, 0x12, 0x35, 0x0a, 0x0c, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x5f, 0x74,
+	0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x12, 0x82, 0xb5, 0x18, 0x0e, 0x0a,
+	0x0c, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x52, 0x0b, 0x73,
+	0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x71, 0x0a, 0x07, 0x73, 0x74,
+	0x6f, 0x72, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x41, 0x6e,
+	0x79, 0x42, 0x41, 0x8a, 0xb5, 0x18, 0x3d, 0x0a, 0x07, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65,
+	0x32, 0x0c, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x3a, 0x24,
+	0x0a, 0x05, 0x6f, 0x74, 0x68, 0x65, 0x72, 0x12, 0x1b, 0x68, 0x63, 0x6c, 0x2e, 0x74, 0x65, 0x73,
+	0x74, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x44, 0x6f, 0x65, 0x73, 0x4e, 0x6f, 0x74, 0x45,
+	0x78, 0x69, 0x73, 0x74, 0x52, 0x07, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x22, 0x5c, 0x0a,
+	0x1b, 0x57, 0x69, 0x74, 0x68, 0x41, 0x6e, 0x79, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4e, 0x6f, 0x44,
+	0x69, 0x73, 0x63, 0x72, 0x69, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x3d, 0x0a, 0x07,
+	0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x41, 0x6e, 0x79, 0x42, 0x0d, 0x8a, 0xb5, 0x18, 0x09, 0x0a, 0x07, 0x73, 0x74, 0x6f, 0x72, 0x61,
+	0x67, 0x65, 0x52, 0x07, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x22, 0xad, 0x01, 0x0a, 0x0f,
+	0x57, 0x69, 0x74, 0x68, 0x4f, 0x6e, 0x65, 0x6f, 0x66, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x12,
+	0x47, 0x0a, 0x05, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22,
+	0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e,
+	0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x43, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x42, 0x0b, 0x8a, 0xb5, 0x18, 0x07, 0x0a, 0x05, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x48,
+	0x00, 0x52, 0x05, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x12, 0x46, 0x0a, 0x06, 0x72, 0x65, 0x6d, 0x6f,
+	0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x74,
+	0x65, 0x73, 0x74, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x57, 0x69, 0x74, 0x68, 0x53, 0x74,
+	0x72, 0x69, 0x6e, 0x67, 0x41, 0x74, 0x74, 0x72, 0x42, 0x0c, 0x8a, 0xb5, 0x18, 0x08, 0x0a, 0x06,
+	0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x48, 0x00, 0x52, 0x06, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65,
+	0x42, 0x09, 0x0a, 0x07, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x22, 0x80, 0x01, 0x0a, 0x0d,
+	0x57, 0x69, 0x74, 0x68, 0x4f, 0x6e, 0x65, 0x6f, 0x66, 0x41, 0x74, 0x74, 0x72, 0x12, 0x20, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x0a, 0x82, 0xb5, 0x18,
+	0x06, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x48, 0x00, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x43, 0x0a, 0x05, 0x74, 0x68, 0x69, 0x6e, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e,
+	0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e,
+	0x57, 0x69, 0x74, 0x68, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x41, 0x74, 0x74, 0x72, 0x42, 0x0b,
+	0x8a, 0xb5, 0x18, 0x07, 0x0a, 0x05, 0x74, 0x68, 0x69, 0x6e, 0x67, 0x48, 0x00, 0x52, 0x05, 0x74,
+	0x68, 0x69, 0x6e, 0x67, 0x42, 0x08, 0x0a, 0x06, 0x63, 0x68, 0x6f, 0x69, 0x63, 0x65, 0x22, 0xd4,
+	0x01, 0x0a, 0x15, 0x57, 0x69, 0x74, 0x68, 0x49, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x4e, 0x75, 0x6c,
+	0x6c, 0x49, 0x6e, 0x4c, 0x69, 0x73, 0x74, 0x73, 0x12, 0x23, 0x0a, 0x05, 0x6e, 0x61, 0x6d, 0x65,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x42, 0x0d, 0x82, 0xb5, 0x18, 0x09, 0x0a, 0x05, 0x6e,
+	0x61, 0x6d, 0x65, 0x73, 0x58, 0x01, 0x52, 0x05, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x12, 0x43, 0x0a,
+	0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x68,
+	0x63, 0x6c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x57, 0x69,
+	0x74, 0x68, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x41, 0x74, 0x74, 0x72, 0x42, 0x0d, 0x82, 0xb5,
+	0x18, 0x09, 0x0a, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x58, 0x01, 0x52, 0x05, 0x69, 0x74, 0x65,
+	0x6d, 0x73, 0x12, 0x51, 0x0a, 0x06, 0x6e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x63, 0x68,
+	0x65, 0x6d, 0x61, 0x2e, 0x57, 0x69, 0x74, 0x68, 0x49, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x4e, 0x75,
+	0x6c, 0x6c, 0x49, 0x6e, 0x4c, 0x69, 0x73, 0x74, 0x73, 0x4e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x42,
+	0x0c, 0x82, 0xb5, 0x18, 0x08, 0x0a, 0x06, 0x6e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x52, 0x06, 0x6e,
+	0x65, 0x73, 0x74, 0x65, 0x64, 0x22, 0x42, 0x0a, 0x1b, 0x57, 0x69, 0x74, 0x68, 0x49, 0x67, 0x6e,
+	0x6f, 0x72, 0x65, 0x4e, 0x75, 0x6c, 0x6c, 0x49, 0x6e, 0x4c, 0x69, 0x73, 0x74, 0x73, 0x4e, 0x65,
+	0x73, 0x74, 0x65, 0x64, 0x12, 0x23, 0x0a, 0x05, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x09, 0x42, 0x0d, 0x82, 0xb5, 0x18, 0x09, 0x0a, 0x05, 0x6e, 0x61, 0x6d, 0x65, 0x73,
+	0x58, 0x01, 0x52, 0x05, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x22, 0x41, 0x0a, 0x1d, 0x57, 0x69, 0x74,
+	0x68, 0x49, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x4e, 0x75, 0x6c, 0x6c, 0x49, 0x6e, 0x4c, 0x69, 0x73,
+	0x74, 0x73, 0x53, 0x69, 0x6e, 0x67, 0x75, 0x6c, 0x61, 0x72, 0x12, 0x20, 0x0a, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x0c, 0x82, 0xb5, 0x18, 0x08, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x58, 0x01, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x95, 0x01, 0x0a,
+	0x17, 0x57, 0x69, 0x74, 0x68, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x53, 0x63, 0x61,
+	0x6c, 0x61, 0x72, 0x41, 0x74, 0x74, 0x72, 0x73, 0x12, 0x38, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63,
+	0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x11, 0x82,
+	0xb5, 0x18, 0x0d, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x48, 0x00, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x88,
+	0x01, 0x01, 0x12, 0x26, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x03, 0x42, 0x0b, 0x82, 0xb5, 0x18, 0x07, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x48, 0x01,
+	0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x88, 0x01, 0x01, 0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x64,
+	0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x08, 0x0a, 0x06, 0x5f, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x22, 0xb6, 0x01, 0x0a, 0x10, 0x57, 0x69, 0x74, 0x68, 0x44, 0x65, 0x66,
+	0x61, 0x75, 0x6c, 0x74, 0x45, 0x78, 0x70, 0x72, 0x73, 0x12, 0x1e, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x0a, 0x82, 0xb5, 0x18, 0x06, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x3b, 0x0a, 0x0c, 0x64, 0x69, 0x73,
+	0x70, 0x6c, 0x61, 0x79, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x42,
+	0x18, 0x82, 0xb5, 0x18, 0x14, 0x0a, 0x0c, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x5f, 0x6e,
+	0x61, 0x6d, 0x65, 0x62, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x52, 0x0b, 0x64, 0x69, 0x73, 0x70, 0x6c,
+	0x61, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x45, 0x0a, 0x08, 0x67, 0x72, 0x65, 0x65, 0x74, 0x69,
+	0x6e, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x42, 0x29, 0x82, 0xb5, 0x18, 0x25, 0x0a, 0x08,
+	0x67, 0x72, 0x65, 0x65, 0x74, 0x69, 0x6e, 0x67, 0x62, 0x19, 0x22, 0x48, 0x65, 0x6c, 0x6c, 0x6f,
+	0x2c, 0x20, 0x24, 0x7b, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x5f, 0x6e, 0x61, 0x6d, 0x65,
+	0x7d, 0x21, 0x22, 0x52, 0x08, 0x67, 0x72, 0x65, 0x65, 0x74, 0x69, 0x6e, 0x67, 0x22, 0x4a, 0x0a,
+	0x14, 0x57, 0x69, 0x74, 0x68, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x45, 0x78, 0x70, 0x72,
+	0x43, 0x79, 0x63, 0x6c, 0x65, 0x12, 0x18, 0x0a, 0x01, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x42, 0x0a, 0x82, 0xb5, 0x18, 0x06, 0x0a, 0x01, 0x61, 0x62, 0x01, 0x62, 0x52, 0x01, 0x61, 0x12,
+	0x18, 0x0a, 0x01, 0x62, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x42, 0x0a, 0x82, 0xb5, 0x18, 0x06,
+	0x0a, 0x01, 0x62, 0x62, 0x01, 0x61, 0x52, 0x01, 0x62, 0x22, 0x40, 0x0a, 0x17, 0x57, 0x69, 0x74,
+	0x68, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x45, 0x78, 0x70, 0x72, 0x52, 0x65, 0x71, 0x75,
+	0x69, 0x72, 0x65, 0x64, 0x12, 0x25, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x42, 0x11, 0x82, 0xb5, 0x18, 0x0d, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x10, 0x01,
+	0x62, 0x03, 0x22, 0x78, 0x22, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x3b, 0x0a, 0x16, 0x57,
+	0x69, 0x74, 0x68, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x45, 0x78, 0x70, 0x72, 0x49, 0x6e,
+	0x76, 0x61, 0x6c, 0x69, 0x64, 0x12, 0x21, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x42, 0x0d, 0x82, 0xb5, 0x18, 0x09, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x62,
+	0x01, 0x28, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0xdc, 0x02, 0x0a, 0x10, 0x57, 0x69, 0x74,
+	0x68, 0x57, 0x72, 0x61, 0x70, 0x70, 0x65, 0x72, 0x41, 0x74, 0x74, 0x72, 0x73, 0x12, 0x3c, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74,
+	0x72, 0x69, 0x6e, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x0a, 0x82, 0xb5, 0x18, 0x06, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x3e, 0x0a, 0x05, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x49, 0x6e, 0x74,
+	0x36, 0x34, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x0b, 0x82, 0xb5, 0x18, 0x07, 0x0a, 0x05, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x43, 0x0a, 0x07, 0x65,
+	0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x42,
+	0x6f, 0x6f, 0x6c, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x0d, 0x82, 0xb5, 0x18, 0x09, 0x0a, 0x07,
+	0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x52, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64,
+	0x12, 0x3f, 0x0a, 0x05, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x44, 0x6f, 0x75, 0x62, 0x6c, 0x65, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x0b, 0x82,
+	0xb5, 0x18, 0x07, 0x0a, 0x05, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x52, 0x05, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x12, 0x44, 0x0a, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x55, 0x49, 0x6e, 0x74, 0x33, 0x32, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x12, 0x82,
+	0xb5, 0x18, 0x0e, 0x0a, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x1a, 0x06, 0x73, 0x74, 0x72, 0x69, 0x6e,
+	0x67, 0x52, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x22, 0x5a, 0x0a, 0x17, 0x57, 0x69, 0x74, 0x68, 0x52,
+	0x65, 0x70, 0x65, 0x61, 0x74, 0x65, 0x64, 0x57, 0x72, 0x61, 0x70, 0x70, 0x65, 0x72, 0x41, 0x74,
+	0x74, 0x72, 0x12, 0x3f, 0x0a, 0x05, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x42,
+	0x0b, 0x82, 0xb5, 0x18, 0x07, 0x0a, 0x05, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x52, 0x05, 0x6e, 0x61,
+	0x6d, 0x65, 0x73, 0x22, 0x60, 0x0a, 0x11, 0x57, 0x69, 0x74, 0x68, 0x54, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x41, 0x74, 0x74, 0x72, 0x12, 0x4b, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x42, 0x10, 0x82, 0xb5, 0x18, 0x0c, 0x0a, 0x0a,
+	0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x64, 0x41, 0x74, 0x22, 0x56, 0x0a, 0x10, 0x57, 0x69, 0x74, 0x68, 0x44, 0x75, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x41, 0x74, 0x74, 0x72, 0x12, 0x42, 0x0a, 0x07, 0x74, 0x69, 0x6d,
+	0x65, 0x6f, 0x75, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x0d, 0x82, 0xb5, 0x18, 0x09, 0x0a, 0x07, 0x74, 0x69, 0x6d,
+	0x65, 0x6f, 0x75, 0x74, 0x52, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x22, 0xb9, 0x02,
+	0x0a, 0x1a, 0x57, 0x69, 0x74, 0x68, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x70, 0x62, 0x43, 0x6f,
+	0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x41, 0x74, 0x74, 0x72, 0x73, 0x12, 0x43, 0x0a, 0x08,
+	0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x42, 0x0e, 0x82, 0xb5, 0x18, 0x0a, 0x0a, 0x08, 0x73,
+	0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x08, 0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67,
+	0x73, 0x12, 0x3d, 0x0a, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x0b, 0x82, 0xb5,
+	0x18, 0x07, 0x0a, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x52, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73,
+	0x12, 0x4a, 0x0a, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x42, 0x19, 0x82, 0xb5, 0x18, 0x15, 0x0a,
+	0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x1a, 0x0b, 0x6d, 0x61, 0x70, 0x28, 0x73, 0x74, 0x72,
+	0x69, 0x6e, 0x67, 0x29, 0x52, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x12, 0x4b, 0x0a, 0x05,
+	0x70, 0x6f, 0x72, 0x74, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4c, 0x69,
+	0x73, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x19, 0x82, 0xb5, 0x18, 0x15, 0x0a, 0x05, 0x70,
+	0x6f, 0x72, 0x74, 0x73, 0x1a, 0x0c, 0x6c, 0x69, 0x73, 0x74, 0x28, 0x6e, 0x75, 0x6d, 0x62, 0x65,
+	0x72, 0x29, 0x52, 0x05, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x22, 0x70, 0x0a, 0x1b, 0x57, 0x69, 0x74,
+	0x68, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x70, 0x62, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x57,
+	0x72, 0x6f, 0x6e, 0x67, 0x54, 0x79, 0x70, 0x65, 0x12, 0x51, 0x0a, 0x08, 0x73, 0x65, 0x74, 0x74,
+	0x69, 0x6e, 0x67, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72,
+	0x75, 0x63, 0x74, 0x42, 0x1c, 0x82, 0xb5, 0x18, 0x18, 0x0a, 0x08, 0x73, 0x65, 0x74, 0x74, 0x69,
+	0x6e, 0x67, 0x73, 0x1a, 0x0c, 0x6c, 0x69, 0x73, 0x74, 0x28, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67,
+	0x29, 0x52, 0x08, 0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x22, 0x70, 0x0a, 0x0f, 0x57,
+	0x69, 0x74, 0x68, 0x41, 0x6e, 0x79, 0x54, 0x79, 0x70, 0x65, 0x41, 0x74, 0x74, 0x72, 0x12, 0x5d,
+	0x0a, 0x07, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x14, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x41, 0x6e, 0x79, 0x42, 0x2d, 0x82, 0xb5, 0x18, 0x29, 0x0a, 0x07, 0x73, 0x74, 0x6f,
+	0x72, 0x61, 0x67, 0x65, 0x6a, 0x1e, 0x68, 0x63, 0x6c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x63,
+	0x68, 0x65, 0x6d, 0x61, 0x2e, 0x53, 0x33, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x43, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x52, 0x07, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x22, 0x78, 0x0a,
+	0x1a, 0x57, 0x69, 0x74, 0x68, 0x41, 0x6e, 0x79, 0x54, 0x79, 0x70, 0x65, 0x41, 0x74, 0x74, 0x72,
+	0x55, 0x6e, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x12, 0x5a, 0x0a, 0x07, 0x73,
+	0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x41,
+	0x6e, 0x79, 0x42, 0x2a, 0x82, 0xb5, 0x18, 0x26, 0x0a, 0x07, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67,
+	0x65, 0x6a, 0x1b, 0x68, 0x63, 0x6c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x63, 0x68, 0x65, 0x6d,
+	0x61, 0x2e, 0x44, 0x6f, 0x65, 0x73, 0x4e, 0x6f, 0x74, 0x45, 0x78, 0x69, 0x73, 0x74, 0x52, 0x07,
+	0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x22, 0x81, 0x01, 0x0a, 0x15, 0x57, 0x69, 0x74, 0x68,
+	0x41, 0x6e, 0x79, 0x54, 0x79, 0x70, 0x65, 0x41, 0x74, 0x74, 0x72, 0x4e, 0x6f, 0x74, 0x41, 0x6e,
+	0x79, 0x12, 0x68, 0x0a, 0x07, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x63, 0x68,
+	0x65, 0x6d, 0x61, 0x2e, 0x53, 0x33, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x43, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x42, 0x2d, 0x82, 0xb5, 0x18, 0x29, 0x0a, 0x07, 0x73, 0x74, 0x6f, 0x72, 0x61,
+	0x67, 0x65, 0x6a, 0x1e, 0x68, 0x63, 0x6c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x63, 0x68, 0x65,
+	0x6d, 0x61, 0x2e, 0x53, 0x33, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x43, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x52, 0x07, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2a, 0x58, 0x0a, 0x05, 0x4c,
+	0x65, 0x76, 0x65, 0x6c, 0x12, 0x15, 0x0a, 0x11, 0x4c, 0x45, 0x56, 0x45, 0x4c, 0x5f, 0x55, 0x4e,
+	0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1c, 0x0a, 0x0b, 0x4c,
+	0x45, 0x56, 0x45, 0x4c, 0x5f, 0x44, 0x45, 0x42, 0x55, 0x47, 0x10, 0x01, 0x1a, 0x0b, 0x82, 0xb5,
+	0x18, 0x07, 0x0a, 0x05, 0x64, 0x65, 0x62, 0x75, 0x67, 0x12, 0x1a, 0x0a, 0x0a, 0x4c, 0x45, 0x56,
+	0x45, 0x4c, 0x5f, 0x49, 0x4e, 0x46, 0x4f, 0x10, 0x02, 0x1a, 0x0a, 0x82, 0xb5, 0x18, 0x06, 0x0a,
+	0x04, 0x69, 0x6e, 0x66, 0x6f, 0x2a, 0x53, 0x0a, 0x05, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x12, 0x15,
+	0x0a, 0x11, 0x43, 0x4f, 0x4c, 0x4f, 0x52, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46,
+	0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0d, 0x0a, 0x09, 0x43, 0x4f, 0x4c, 0x4f, 0x52, 0x5f, 0x52,
+	0x45, 0x44, 0x10, 0x01, 0x12, 0x24, 0x0a, 0x0f, 0x43, 0x4f, 0x4c, 0x4f, 0x52, 0x5f, 0x44, 0x41,
+	0x52, 0x4b, 0x5f, 0x42, 0x4c, 0x55, 0x45, 0x10, 0x02, 0x1a, 0x0f, 0x82, 0xb5, 0x18, 0x0b, 0x0a,
+	0x09, 0x64, 0x61, 0x72, 0x6b, 0x5f, 0x62, 0x6c, 0x75, 0x65, 0x42, 0x44, 0x5a, 0x42, 0x67, 0x69,
+	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61, 0x70, 0x70, 0x61, 0x72, 0x65, 0x6e,
+	0x74, 0x6c, 0x79, 0x6d, 0x61, 0x72, 0x74, 0x2f, 0x67, 0x6f, 0x2d, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x68, 0x63, 0x6c, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x68, 0x63, 0x6c, 0x2f, 0x69, 0x6e, 0x74,
+	0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x74, 0x65, 0x73, 0x74, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_testschema_proto_rawDescOnce sync.Once
+	file_testschema_proto_rawDescData = file_testschema_proto_rawDesc
+)
+
+func file_testschema_proto_rawDescGZIP() []byte {
+	file_testschema_proto_rawDescOnce.Do(func() {
+		file_testschema_proto_rawDescData = protoimpl.X.CompressGZIP(file_testschema_proto_rawDescData)
+	})
+	return file_testschema_proto_rawDescData
+}
+
+var file_testschema_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_testschema_proto_msgTypes = make([]protoimpl.MessageInfo, 153)
+var file_testschema_proto_goTypes = []interface{}{
+	(Level)(0),                                 // 0: hcl.testschema.Level
+	(Color)(0),                                 // 1: hcl.testschema.Color
+	(*Root)(nil),                               // 2: hcl.testschema.Root
+	(*Thing)(nil),                              // 3: hcl.testschema.Thing
+	(*MoreRoot)(nil),                           // 4: hcl.testschema.MoreRoot
+	(*RootOutput)(nil),                         // 5: hcl.testschema.RootOutput
+	(*ConflictingRootOutput)(nil),              // 6: hcl.testschema.ConflictingRootOutput
+	(*WithStringAttr)(nil),                     // 7: hcl.testschema.WithStringAttr
+	(*WithRawDynamicAttr)(nil),                 // 8: hcl.testschema.WithRawDynamicAttr
+	(*WithStructDynamicAttr)(nil),              // 9: hcl.testschema.WithStructDynamicAttr
+	(*WithStructStringAttr)(nil),               // 10: hcl.testschema.WithStructStringAttr
+	(*WithStructListAttr)(nil),                 // 11: hcl.testschema.WithStructListAttr
+	(*WithStructMapAttr)(nil),                  // 12: hcl.testschema.WithStructMapAttr
+	(*StructHolder)(nil),                       // 13: hcl.testschema.StructHolder
+	(*WithStructsInNestedMessages)(nil),        // 14: hcl.testschema.WithStructsInNestedMessages
+	(*WithNumberAttrAsInt32)(nil),              // 15: hcl.testschema.WithNumberAttrAsInt32
+	(*WithNumberAttrsAsFloat)(nil),             // 16: hcl.testschema.WithNumberAttrsAsFloat
+	(*WithNumberAttrAsString)(nil),             // 17: hcl.testschema.WithNumberAttrAsString
+	(*WithBoolAttr)(nil),                       // 18: hcl.testschema.WithBoolAttr
+	(*WithStringListAttr)(nil),                 // 19: hcl.testschema.WithStringListAttr
+	(*WithNumberListAttrAsInt32)(nil),          // 20: hcl.testschema.WithNumberListAttrAsInt32
+	(*WithStringListAttrAllowScalar)(nil),      // 21: hcl.testschema.WithStringListAttrAllowScalar
+	(*WithUnitAttrs)(nil),                      // 22: hcl.testschema.WithUnitAttrs
+	(*WithUnitAttrUnsupported)(nil),            // 23: hcl.testschema.WithUnitAttrUnsupported
+	(*WithUnitAttrString)(nil),                 // 24: hcl.testschema.WithUnitAttrString
+	(*WithAttrRange)(nil),                      // 25: hcl.testschema.WithAttrRange
+	(*WithAttrRangeMissing)(nil),               // 26: hcl.testschema.WithAttrRangeMissing
+	(*WithAttrRangeWrongType)(nil),             // 27: hcl.testschema.WithAttrRangeWrongType
+	(*WithEmptyAsNullAttrs)(nil),               // 28: hcl.testschema.WithEmptyAsNullAttrs
+	(*WithEmptyAsNullList)(nil),                // 29: hcl.testschema.WithEmptyAsNullList
+	(*WithNumberSyntaxAttrs)(nil),              // 30: hcl.testschema.WithNumberSyntaxAttrs
+	(*WithNumberSyntaxString)(nil),             // 31: hcl.testschema.WithNumberSyntaxString
+	(*WithoutAnnotations)(nil),                 // 32: hcl.testschema.WithoutAnnotations
+	(*WithStringSetAttr)(nil),                  // 33: hcl.testschema.WithStringSetAttr
+	(*WithStringMapAttr)(nil),                  // 34: hcl.testschema.WithStringMapAttr
+	(*WithNumberMapAttrAsInt32)(nil),           // 35: hcl.testschema.WithNumberMapAttrAsInt32
+	(*WithEnumAttr)(nil),                       // 36: hcl.testschema.WithEnumAttr
+	(*WithEnumMapAttr)(nil),                    // 37: hcl.testschema.WithEnumMapAttr
+	(*WithEnumListAttr)(nil),                   // 38: hcl.testschema.WithEnumListAttr
+	(*WithFlattenStringAttr)(nil),              // 39: hcl.testschema.WithFlattenStringAttr
+	(*WithNestedFlattenStringAttr)(nil),        // 40: hcl.testschema.WithNestedFlattenStringAttr
+	(*WithNestedBlockNoLabelsSingleton)(nil),   // 41: hcl.testschema.WithNestedBlockNoLabelsSingleton
+	(*WithNestedBlockOneLabelSingleton)(nil),   // 42: hcl.testschema.WithNestedBlockOneLabelSingleton
+	(*WithNestedBlockTwoLabelSingleton)(nil),   // 43: hcl.testschema.WithNestedBlockTwoLabelSingleton
+	(*WithNestedBlockNoLabelsRepeated)(nil),    // 44: hcl.testschema.WithNestedBlockNoLabelsRepeated
+	(*WithNestedBlockOneLabelRepeated)(nil),    // 45: hcl.testschema.WithNestedBlockOneLabelRepeated
+	(*WithNestedBlockTwoLabelRepeated)(nil),    // 46: hcl.testschema.WithNestedBlockTwoLabelRepeated
+	(*WithNestedBlockOneLabelDynamic)(nil),     // 47: hcl.testschema.WithNestedBlockOneLabelDynamic
+	(*WithOneBlockLabelDynamic)(nil),           // 48: hcl.testschema.WithOneBlockLabelDynamic
+	(*WithNestedBlockFlattenedLabels)(nil),     // 49: hcl.testschema.WithNestedBlockFlattenedLabels
+	(*WithFlattenedBlockLabel)(nil),            // 50: hcl.testschema.WithFlattenedBlockLabel
+	(*WithNestedBlockConflictingLabels)(nil),   // 51: hcl.testschema.WithNestedBlockConflictingLabels
+	(*WithConflictingBlockLabels)(nil),         // 52: hcl.testschema.WithConflictingBlockLabels
+	(*WithSameBlockTypeNested)(nil),            // 53: hcl.testschema.WithSameBlockTypeNested
+	(*SameBlockTypeOuter)(nil),                 // 54: hcl.testschema.SameBlockTypeOuter
+	(*SameBlockTypeInner)(nil),                 // 55: hcl.testschema.SameBlockTypeInner
+	(*RecursiveBlock)(nil),                     // 56: hcl.testschema.RecursiveBlock
+	(*WithInvalidNestedBlocks)(nil),            // 57: hcl.testschema.WithInvalidNestedBlocks
+	(*WithRepeatedInvalidBlocks)(nil),          // 58: hcl.testschema.WithRepeatedInvalidBlocks
+	(*RootOnlyConfig)(nil),                     // 59: hcl.testschema.RootOnlyConfig
+	(*WithRootOnlyNestedBlock)(nil),            // 60: hcl.testschema.WithRootOnlyNestedBlock
+	(*InvalidBlockBody)(nil),                   // 61: hcl.testschema.InvalidBlockBody
+	(*WithOneBlockLabel)(nil),                  // 62: hcl.testschema.WithOneBlockLabel
+	(*WithTwoBlockLabels)(nil),                 // 63: hcl.testschema.WithTwoBlockLabels
+	(*WithDescribedBlockLabels)(nil),           // 64: hcl.testschema.WithDescribedBlockLabels
+	(*WithNestedBlockDescribedLabels)(nil),     // 65: hcl.testschema.WithNestedBlockDescribedLabels
+	(*WithMapOfBlocks)(nil),                    // 66: hcl.testschema.WithMapOfBlocks
+	(*WithMapOfObjectsAttr)(nil),               // 67: hcl.testschema.WithMapOfObjectsAttr
+	(*WithObjectAttr)(nil),                     // 68: hcl.testschema.WithObjectAttr
+	(*WithNestedObjectsAttr)(nil),              // 69: hcl.testschema.WithNestedObjectsAttr
+	(*WithListOfObjectsAttr)(nil),              // 70: hcl.testschema.WithListOfObjectsAttr
+	(*WithTupleOfObjectsAttr)(nil),             // 71: hcl.testschema.WithTupleOfObjectsAttr
+	(*WithSetOfObjectsAttr)(nil),               // 72: hcl.testschema.WithSetOfObjectsAttr
+	(*WithListOfDynamicObjectsAttr)(nil),       // 73: hcl.testschema.WithListOfDynamicObjectsAttr
+	(*WithCollectionKindScalarAttr)(nil),       // 74: hcl.testschema.WithCollectionKindScalarAttr
+	(*WithOptionalAttrs)(nil),                  // 75: hcl.testschema.WithOptionalAttrs
+	(*WithBlockMessageAsAttr)(nil),             // 76: hcl.testschema.WithBlockMessageAsAttr
+	(*WithMapOfScalarsAsBlocks)(nil),           // 77: hcl.testschema.WithMapOfScalarsAsBlocks
+	(*WithSchemaWarnings)(nil),                 // 78: hcl.testschema.WithSchemaWarnings
+	(*WithLabelAttrOptions)(nil),               // 79: hcl.testschema.WithLabelAttrOptions
+	(*LabelOrderBase)(nil),                     // 80: hcl.testschema.LabelOrderBase
+	(*WithFlattenLabelOrder)(nil),              // 81: hcl.testschema.WithFlattenLabelOrder
+	(*WithMismatchedAttrType)(nil),             // 82: hcl.testschema.WithMismatchedAttrType
+	(*WithMismatchedStructAttrTypes)(nil),      // 83: hcl.testschema.WithMismatchedStructAttrTypes
+	(*WithNestedMismatchedStructAttrType)(nil), // 84: hcl.testschema.WithNestedMismatchedStructAttrType
+	(*SharedBlockBody)(nil),                    // 85: hcl.testschema.SharedBlockBody
+	(*WithSharedBlockBodyList)(nil),            // 86: hcl.testschema.WithSharedBlockBodyList
+	(*WithSharedBlockBodySet)(nil),             // 87: hcl.testschema.WithSharedBlockBodySet
+	(*WithSharedBlockBodyKinds)(nil),           // 88: hcl.testschema.WithSharedBlockBodyKinds
+	(*SharedDynamicBlockBody)(nil),             // 89: hcl.testschema.SharedDynamicBlockBody
+	(*WithSharedDynamicBlockBodyTuple)(nil),    // 90: hcl.testschema.WithSharedDynamicBlockBodyTuple
+	(*WithSharedDynamicBlockBodySet)(nil),      // 91: hcl.testschema.WithSharedDynamicBlockBodySet
+	(*WithSharedDynamicBlockBodyKinds)(nil),    // 92: hcl.testschema.WithSharedDynamicBlockBodyKinds
+	(*TLSSettings)(nil),                        // 93: hcl.testschema.TLSSettings
+	(*WithFlattenPrefix)(nil),                  // 94: hcl.testschema.WithFlattenPrefix
+	(*WithNestedFlattenPrefix)(nil),            // 95: hcl.testschema.WithNestedFlattenPrefix
+	(*WithFlattenPrefixLabel)(nil),             // 96: hcl.testschema.WithFlattenPrefixLabel
+	(*WithNestedBlockFlattenPrefixLabel)(nil),  // 97: hcl.testschema.WithNestedBlockFlattenPrefixLabel
+	(*WithInvalidFlattenPrefix)(nil),           // 98: hcl.testschema.WithInvalidFlattenPrefix
+	(*LegacySettings)(nil),                     // 99: hcl.testschema.LegacySettings
+	(*WithFlattenConflictOuterWins)(nil),       // 100: hcl.testschema.WithFlattenConflictOuterWins
+	(*WithFlattenConflictInnerWins)(nil),       // 101: hcl.testschema.WithFlattenConflictInnerWins
+	(*WithNestedFlattenConflict)(nil),          // 102: hcl.testschema.WithNestedFlattenConflict
+	(*WithInvalidFlattenConflict)(nil),         // 103: hcl.testschema.WithInvalidFlattenConflict
+	(*WithBlockTypeAliases)(nil),               // 104: hcl.testschema.WithBlockTypeAliases
+	(*WithFlattenedBlockTypeAliases)(nil),      // 105: hcl.testschema.WithFlattenedBlockTypeAliases
+	(*WithBlockTypeAliasConflict)(nil),         // 106: hcl.testschema.WithBlockTypeAliasConflict
+	(*WithInvalidBlockTypeAlias)(nil),          // 107: hcl.testschema.WithInvalidBlockTypeAlias
+	(*WithMergeableContent)(nil),               // 108: hcl.testschema.WithMergeableContent
+	(*WithMapOfBlocksKeyedByAttr)(nil),         // 109: hcl.testschema.WithMapOfBlocksKeyedByAttr
+	(*WithInvalidMapKeyAttr)(nil),              // 110: hcl.testschema.WithInvalidMapKeyAttr
+	(*WithMsgTypeConstraints)(nil),             // 111: hcl.testschema.WithMsgTypeConstraints
+	(*WithRecursiveMsgTypeConstraint)(nil),     // 112: hcl.testschema.WithRecursiveMsgTypeConstraint
+	(*WithUnknownMsgTypeConstraint)(nil),       // 113: hcl.testschema.WithUnknownMsgTypeConstraint
+	(*S3StorageConfig)(nil),                    // 114: hcl.testschema.S3StorageConfig
+	(*LocalStorageConfig)(nil),                 // 115: hcl.testschema.LocalStorageConfig
+	(*WithAnyBlock)(nil),                       // 116: hcl.testschema.WithAnyBlock
+	(*WithFlattenedAnyBlock)(nil),              // 117: hcl.testschema.WithFlattenedAnyBlock
+	(*WithAnyBlockDiscriminatorAfter)(nil),     // 118: hcl.testschema.WithAnyBlockDiscriminatorAfter
+	(*WithAnyBlockUnknownType)(nil),            // 119: hcl.testschema.WithAnyBlockUnknownType
+	(*WithAnyBlockNoDiscriminator)(nil),        // 120: hcl.testschema.WithAnyBlockNoDiscriminator
+	(*WithOneofBlocks)(nil),                    // 121: hcl.testschema.WithOneofBlocks
+	(*WithOneofAttr)(nil),                      // 122: hcl.testschema.WithOneofAttr
+	(*WithIgnoreNullInLists)(nil),              // 123: hcl.testschema.WithIgnoreNullInLists
+	(*WithIgnoreNullInListsNested)(nil),        // 124: hcl.testschema.WithIgnoreNullInListsNested
+	(*WithIgnoreNullInListsSingular)(nil),      // 125: hcl.testschema.WithIgnoreNullInListsSingular
+	(*WithOptionalScalarAttrs)(nil),            // 126: hcl.testschema.WithOptionalScalarAttrs
+	(*WithDefaultExprs)(nil),                   // 127: hcl.testschema.WithDefaultExprs
+	(*WithDefaultExprCycle)(nil),               // 128: hcl.testschema.WithDefaultExprCycle
+	(*WithDefaultExprRequired)(nil),            // 129: hcl.testschema.WithDefaultExprRequired
+	(*WithDefaultExprInvalid)(nil),             // 130: hcl.testschema.WithDefaultExprInvalid
+	(*WithWrapperAttrs)(nil),                   // 131: hcl.testschema.WithWrapperAttrs
+	(*WithRepeatedWrapperAttr)(nil),            // 132: hcl.testschema.WithRepeatedWrapperAttr
+	(*WithTimestampAttr)(nil),                  // 133: hcl.testschema.WithTimestampAttr
+	(*WithDurationAttr)(nil),                   // 134: hcl.testschema.WithDurationAttr
+	(*WithStructpbContainerAttrs)(nil),         // 135: hcl.testschema.WithStructpbContainerAttrs
+	(*WithStructpbStructWrongType)(nil),        // 136: hcl.testschema.WithStructpbStructWrongType
+	(*WithAnyTypeAttr)(nil),                    // 137: hcl.testschema.WithAnyTypeAttr
+	(*WithAnyTypeAttrUnknownType)(nil),         // 138: hcl.testschema.WithAnyTypeAttrUnknownType
+	(*WithAnyTypeAttrNotAny)(nil),              // 139: hcl.testschema.WithAnyTypeAttrNotAny
+	nil,                                        // 140: hcl.testschema.WithStructMapAttr.StructsEntry
+	nil,                                        // 141: hcl.testschema.StructHolder.MapEntry
+	nil,                                        // 142: hcl.testschema.WithStructsInNestedMessages.ByKeyEntry
+	nil,                                        // 143: hcl.testschema.WithoutAnnotations.LabelsEntry
+	nil,                                        // 144: hcl.testschema.WithStringMapAttr.NamesEntry
+	nil,                                        // 145: hcl.testschema.WithNumberMapAttrAsInt32.NumsEntry
+	nil,                                        // 146: hcl.testschema.WithEnumMapAttr.LevelsEntry
+	nil,                                        // 147: hcl.testschema.WithMapOfBlocks.PetsEntry
+	nil,                                        // 148: hcl.testschema.WithMapOfObjectsAttr.PetsEntry
+	nil,                                        // 149: hcl.testschema.WithMapOfScalarsAsBlocks.ThingsEntry
+	nil,                                        // 150: hcl.testschema.WithMismatchedStructAttrTypes.ByKeyEntry
+	nil,                                        // 151: hcl.testschema.WithMergeableContent.PetsEntry
+	nil,                                        // 152: hcl.testschema.WithMapOfBlocksKeyedByAttr.PetsEntry
+	nil,                                        // 153: hcl.testschema.WithInvalidMapKeyAttr.PetsEntry
+	nil,                                        // 154: hcl.testschema.WithMsgTypeConstraints.ByKeyEntry
+	(*structpb.Value)(nil),                     // 155: google.protobuf.Value
+	(*protohclext.SourceRange)(nil),            // 156: hcl.SourceRange
+	(*anypb.Any)(nil),                          // 157: google.protobuf.Any
+	(*wrapperspb.StringValue)(nil),             // 158: google.protobuf.StringValue
+	(*wrapperspb.Int64Value)(nil),              // 159: google.protobuf.Int64Value
+	(*wrapperspb.BoolValue)(nil),               // 160: google.protobuf.BoolValue
+	(*wrapperspb.DoubleValue)(nil),             // 161: google.protobuf.DoubleValue
+	(*wrapperspb.UInt32Value)(nil),             // 162: google.protobuf.UInt32Value
+	(*timestamppb.Timestamp)(nil),              // 163: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),                // 164: google.protobuf.Duration
+	(*structpb.Struct)(nil),                    // 165: google.protobuf.Struct
+	(*structpb.ListValue)(nil),                 // 166: google.protobuf.ListValue
+}
+var file_testschema_proto_depIdxs = []int32{
+	3,   // 0: hcl.testschema.Root.things:type_name -> hcl.testschema.Thing
+	4,   // 1: hcl.testschema.Root.more:type_name -> hcl.testschema.MoreRoot
+	3,   // 2: hcl.testschema.MoreRoot.other_thing:type_name -> hcl.testschema.Thing
+	155, // 3: hcl.testschema.WithStructDynamicAttr.struct:type_name -> google.protobuf.Value
+	155, // 4: hcl.testschema.WithStructStringAttr.struct:type_name -> google.protobuf.Value
+	155, // 5: hcl.testschema.WithStructListAttr.structs:type_name -> google.protobuf.Value
+	140, // 6: hcl.testschema.WithStructMapAttr.structs:type_name -> hcl.testschema.WithStructMapAttr.StructsEntry
+	155, // 7: hcl.testschema.StructHolder.list:type_name -> google.protobuf.Value
+	141, // 8: hcl.testschema.StructHolder.map:type_name -> hcl.testschema.StructHolder.MapEntry
+	155, // 9: hcl.testschema.StructHolder.single:type_name -> google.protobuf.Value
+	155, // 10: hcl.testschema.StructHolder.tuple:type_name -> google.protobuf.Value
+	142, // 11: hcl.testschema.WithStructsInNestedMessages.by_key:type_name -> hcl.testschema.WithStructsInNestedMessages.ByKeyEntry
+	13,  // 12: hcl.testschema.WithStructsInNestedMessages.list:type_name -> hcl.testschema.StructHolder
+	156, // 13: hcl.testschema.WithAttrRange.name_range:type_name -> hcl.SourceRange
+	143, // 14: hcl.testschema.WithoutAnnotations.labels:type_name -> hcl.testschema.WithoutAnnotations.LabelsEntry
+	7,   // 15: hcl.testschema.WithoutAnnotations.nested:type_name -> hcl.testschema.WithStringAttr
+	144, // 16: hcl.testschema.WithStringMapAttr.names:type_name -> hcl.testschema.WithStringMapAttr.NamesEntry
+	145, // 17: hcl.testschema.WithNumberMapAttrAsInt32.nums:type_name -> hcl.testschema.WithNumberMapAttrAsInt32.NumsEntry
+	0,   // 18: hcl.testschema.WithEnumAttr.level:type_name -> hcl.testschema.Level
+	146, // 19: hcl.testschema.WithEnumMapAttr.levels:type_name -> hcl.testschema.WithEnumMapAttr.LevelsEntry
+	0,   // 20: hcl.testschema.WithEnumListAttr.levels:type_name -> hcl.testschema.Level
+	7,   // 21: hcl.testschema.WithFlattenStringAttr.base:type_name -> hcl.testschema.WithStringAttr
+	39,  // 22: hcl.testschema.WithNestedFlattenStringAttr.base:type_name -> hcl.testschema.WithFlattenStringAttr
+	7,   // 23: hcl.testschema.WithNestedBlockNoLabelsSingleton.doodad:type_name -> hcl.testschema.WithStringAttr
+	62,  // 24: hcl.testschema.WithNestedBlockOneLabelSingleton.doodad:type_name -> hcl.testschema.WithOneBlockLabel
+	63,  // 25: hcl.testschema.WithNestedBlockTwoLabelSingleton.doodad:type_name -> hcl.testschema.WithTwoBlockLabels
+	7,   // 26: hcl.testschema.WithNestedBlockNoLabelsRepeated.doodad:type_name -> hcl.testschema.WithStringAttr
+	62,  // 27: hcl.testschema.WithNestedBlockOneLabelRepeated.doodad:type_name -> hcl.testschema.WithOneBlockLabel
+	63,  // 28: hcl.testschema.WithNestedBlockTwoLabelRepeated.doodad:type_name -> hcl.testschema.WithTwoBlockLabels
+	48,  // 29: hcl.testschema.WithNestedBlockOneLabelDynamic.doodad:type_name -> hcl.testschema.WithOneBlockLabelDynamic
+	50,  // 30: hcl.testschema.WithNestedBlockFlattenedLabels.doodad:type_name -> hcl.testschema.WithFlattenedBlockLabel
+	62,  // 31: hcl.testschema.WithFlattenedBlockLabel.base:type_name -> hcl.testschema.WithOneBlockLabel
+	52,  // 32: hcl.testschema.WithNestedBlockConflictingLabels.doodad:type_name -> hcl.testschema.WithConflictingBlockLabels
+	62,  // 33: hcl.testschema.WithConflictingBlockLabels.base:type_name -> hcl.testschema.WithOneBlockLabel
+	54,  // 34: hcl.testschema.WithSameBlockTypeNested.item:type_name -> hcl.testschema.SameBlockTypeOuter
+	55,  // 35: hcl.testschema.SameBlockTypeOuter.item:type_name -> hcl.testschema.SameBlockTypeInner
+	56,  // 36: hcl.testschema.RecursiveBlock.child:type_name -> hcl.testschema.RecursiveBlock
+	61,  // 37: hcl.testschema.WithInvalidNestedBlocks.a:type_name -> hcl.testschema.InvalidBlockBody
+	61,  // 38: hcl.testschema.WithInvalidNestedBlocks.b:type_name -> hcl.testschema.InvalidBlockBody
+	61,  // 39: hcl.testschema.WithRepeatedInvalidBlocks.item:type_name -> hcl.testschema.InvalidBlockBody
+	82,  // 40: hcl.testschema.WithRepeatedInvalidBlocks.mismatched:type_name -> hcl.testschema.WithMismatchedAttrType
+	59,  // 41: hcl.testschema.WithRootOnlyNestedBlock.config:type_name -> hcl.testschema.RootOnlyConfig
+	64,  // 42: hcl.testschema.WithNestedBlockDescribedLabels.doodad:type_name -> hcl.testschema.WithDescribedBlockLabels
+	147, // 43: hcl.testschema.WithMapOfBlocks.pets:type_name -> hcl.testschema.WithMapOfBlocks.PetsEntry
+	148, // 44: hcl.testschema.WithMapOfObjectsAttr.pets:type_name -> hcl.testschema.WithMapOfObjectsAttr.PetsEntry
+	75,  // 45: hcl.testschema.WithObjectAttr.settings:type_name -> hcl.testschema.WithOptionalAttrs
+	67,  // 46: hcl.testschema.WithNestedObjectsAttr.groups:type_name -> hcl.testschema.WithMapOfObjectsAttr
+	75,  // 47: hcl.testschema.WithListOfObjectsAttr.items:type_name -> hcl.testschema.WithOptionalAttrs
+	8,   // 48: hcl.testschema.WithTupleOfObjectsAttr.items:type_name -> hcl.testschema.WithRawDynamicAttr
+	7,   // 49: hcl.testschema.WithSetOfObjectsAttr.items:type_name -> hcl.testschema.WithStringAttr
+	8,   // 50: hcl.testschema.WithListOfDynamicObjectsAttr.items:type_name -> hcl.testschema.WithRawDynamicAttr
+	41,  // 51: hcl.testschema.WithBlockMessageAsAttr.thing:type_name -> hcl.testschema.WithNestedBlockNoLabelsSingleton
+	149, // 52: hcl.testschema.WithMapOfScalarsAsBlocks.things:type_name -> hcl.testschema.WithMapOfScalarsAsBlocks.ThingsEntry
+	1,   // 53: hcl.testschema.WithSchemaWarnings.color:type_name -> hcl.testschema.Color
+	80,  // 54: hcl.testschema.WithFlattenLabelOrder.base:type_name -> hcl.testschema.LabelOrderBase
+	150, // 55: hcl.testschema.WithMismatchedStructAttrTypes.by_key:type_name -> hcl.testschema.WithMismatchedStructAttrTypes.ByKeyEntry
+	155, // 56: hcl.testschema.WithMismatchedStructAttrTypes.items:type_name -> google.protobuf.Value
+	83,  // 57: hcl.testschema.WithNestedMismatchedStructAttrType.nested:type_name -> hcl.testschema.WithMismatchedStructAttrTypes
+	85,  // 58: hcl.testschema.WithSharedBlockBodyList.item:type_name -> hcl.testschema.SharedBlockBody
+	85,  // 59: hcl.testschema.WithSharedBlockBodySet.item:type_name -> hcl.testschema.SharedBlockBody
+	86,  // 60: hcl.testschema.WithSharedBlockBodyKinds.list:type_name -> hcl.testschema.WithSharedBlockBodyList
+	87,  // 61: hcl.testschema.WithSharedBlockBodyKinds.set:type_name -> hcl.testschema.WithSharedBlockBodySet
+	85,  // 62: hcl.testschema.WithSharedBlockBodyKinds.item:type_name -> hcl.testschema.SharedBlockBody
+	89,  // 63: hcl.testschema.WithSharedDynamicBlockBodyTuple.item:type_name -> hcl.testschema.SharedDynamicBlockBody
+	89,  // 64: hcl.testschema.WithSharedDynamicBlockBodySet.item:type_name -> hcl.testschema.SharedDynamicBlockBody
+	90,  // 65: hcl.testschema.WithSharedDynamicBlockBodyKinds.tuple:type_name -> hcl.testschema.WithSharedDynamicBlockBodyTuple
+	91,  // 66: hcl.testschema.WithSharedDynamicBlockBodyKinds.set:type_name -> hcl.testschema.WithSharedDynamicBlockBodySet
+	7,   // 67: hcl.testschema.TLSSettings.ca:type_name -> hcl.testschema.WithStringAttr
+	93,  // 68: hcl.testschema.WithFlattenPrefix.client:type_name -> hcl.testschema.TLSSettings
+	93,  // 69: hcl.testschema.WithFlattenPrefix.server:type_name -> hcl.testschema.TLSSettings
+	94,  // 70: hcl.testschema.WithNestedFlattenPrefix.proxy:type_name -> hcl.testschema.WithFlattenPrefix
+	62,  // 71: hcl.testschema.WithFlattenPrefixLabel.base:type_name -> hcl.testschema.WithOneBlockLabel
+	96,  // 72: hcl.testschema.WithNestedBlockFlattenPrefixLabel.pet:type_name -> hcl.testschema.WithFlattenPrefixLabel
+	7,   // 73: hcl.testschema.WithInvalidFlattenPrefix.base:type_name -> hcl.testschema.WithStringAttr
+	99,  // 74: hcl.testschema.WithFlattenConflictOuterWins.legacy:type_name -> hcl.testschema.LegacySettings
+	99,  // 75: hcl.testschema.WithFlattenConflictInnerWins.legacy:type_name -> hcl.testschema.LegacySettings
+	100, // 76: hcl.testschema.WithNestedFlattenConflict.outer:type_name -> hcl.testschema.WithFlattenConflictOuterWins
+	7,   // 77: hcl.testschema.WithInvalidFlattenConflict.base:type_name -> hcl.testschema.WithStringAttr
+	62,  // 78: hcl.testschema.WithBlockTypeAliases.doodad:type_name -> hcl.testschema.WithOneBlockLabel
+	7,   // 79: hcl.testschema.WithBlockTypeAliases.thing:type_name -> hcl.testschema.WithStringAttr
+	104, // 80: hcl.testschema.WithFlattenedBlockTypeAliases.base:type_name -> hcl.testschema.WithBlockTypeAliases
+	7,   // 81: hcl.testschema.WithBlockTypeAliasConflict.thing:type_name -> hcl.testschema.WithStringAttr
+	7,   // 82: hcl.testschema.WithInvalidBlockTypeAlias.thing:type_name -> hcl.testschema.WithStringAttr
+	75,  // 83: hcl.testschema.WithMergeableContent.settings:type_name -> hcl.testschema.WithOptionalAttrs
+	62,  // 84: hcl.testschema.WithMergeableContent.rule:type_name -> hcl.testschema.WithOneBlockLabel
+	151, // 85: hcl.testschema.WithMergeableContent.pets:type_name -> hcl.testschema.WithMergeableContent.PetsEntry
+	7,   // 86: hcl.testschema.WithMergeableContent.base:type_name -> hcl.testschema.WithStringAttr
+	152, // 87: hcl.testschema.WithMapOfBlocksKeyedByAttr.pets:type_name -> hcl.testschema.WithMapOfBlocksKeyedByAttr.PetsEntry
+	153, // 88: hcl.testschema.WithInvalidMapKeyAttr.pets:type_name -> hcl.testschema.WithInvalidMapKeyAttr.PetsEntry
+	75,  // 89: hcl.testschema.WithMsgTypeConstraints.items:type_name -> hcl.testschema.WithOptionalAttrs
+	154, // 90: hcl.testschema.WithMsgTypeConstraints.by_key:type_name -> hcl.testschema.WithMsgTypeConstraints.ByKeyEntry
+	112, // 91: hcl.testschema.WithRecursiveMsgTypeConstraint.children:type_name -> hcl.testschema.WithRecursiveMsgTypeConstraint
+	157, // 92: hcl.testschema.WithAnyBlock.storage:type_name -> google.protobuf.Any
+	116, // 93: hcl.testschema.WithFlattenedAnyBlock.backend:type_name -> hcl.testschema.WithAnyBlock
+	157, // 94: hcl.testschema.WithAnyBlockDiscriminatorAfter.storage:type_name -> google.protobuf.Any
+	157, // 95: hcl.testschema.WithAnyBlockUnknownType.storage:type_name -> google.protobuf.Any
+	157, // 96: hcl.testschema.WithAnyBlockNoDiscriminator.storage:type_name -> google.protobuf.Any
+	115, // 97: hcl.testschema.WithOneofBlocks.local:type_name -> hcl.testschema.LocalStorageConfig
+	7,   // 98: hcl.testschema.WithOneofBlocks.remote:type_name -> hcl.testschema.WithStringAttr
+	7,   // 99: hcl.testschema.WithOneofAttr.thing:type_name -> hcl.testschema.WithStringAttr
+	7,   // 100: hcl.testschema.WithIgnoreNullInLists.items:type_name -> hcl.testschema.WithStringAttr
+	124, // 101: hcl.testschema.WithIgnoreNullInLists.nested:type_name -> hcl.testschema.WithIgnoreNullInListsNested
+	158, // 102: hcl.testschema.WithWrapperAttrs.name:type_name -> google.protobuf.StringValue
+	159, // 103: hcl.testschema.WithWrapperAttrs.count:type_name -> google.protobuf.Int64Value
+	160, // 104: hcl.testschema.WithWrapperAttrs.enabled:type_name -> google.protobuf.BoolValue
+	161, // 105: hcl.testschema.WithWrapperAttrs.ratio:type_name -> google.protobuf.DoubleValue
+	162, // 106: hcl.testschema.WithWrapperAttrs.port:type_name -> google.protobuf.UInt32Value
+	158, // 107: hcl.testschema.WithRepeatedWrapperAttr.names:type_name -> google.protobuf.StringValue
+	163, // 108: hcl.testschema.WithTimestampAttr.created_at:type_name -> google.protobuf.Timestamp
+	164, // 109: hcl.testschema.WithDurationAttr.timeout:type_name -> google.protobuf.Duration
+	165, // 110: hcl.testschema.WithStructpbContainerAttrs.settings:type_name -> google.protobuf.Struct
+	166, // 111: hcl.testschema.WithStructpbContainerAttrs.items:type_name -> google.protobuf.ListValue
+	165, // 112: hcl.testschema.WithStructpbContainerAttrs.labels:type_name -> google.protobuf.Struct
+	166, // 113: hcl.testschema.WithStructpbContainerAttrs.ports:type_name -> google.protobuf.ListValue
+	165, // 114: hcl.testschema.WithStructpbStructWrongType.settings:type_name -> google.protobuf.Struct
+	157, // 115: hcl.testschema.WithAnyTypeAttr.storage:type_name -> google.protobuf.Any
+	157, // 116: hcl.testschema.WithAnyTypeAttrUnknownType.storage:type_name -> google.protobuf.Any
+	114, // 117: hcl.testschema.WithAnyTypeAttrNotAny.storage:type_name -> hcl.testschema.S3StorageConfig
+	155, // 118: hcl.testschema.WithStructMapAttr.StructsEntry.value:type_name -> google.protobuf.Value
+	155, // 119: hcl.testschema.StructHolder.MapEntry.value:type_name -> google.protobuf.Value
+	13,  // 120: hcl.testschema.WithStructsInNestedMessages.ByKeyEntry.value:type_name -> hcl.testschema.StructHolder
+	0,   // 121: hcl.testschema.WithEnumMapAttr.LevelsEntry.value:type_name -> hcl.testschema.Level
+	7,   // 122: hcl.testschema.WithMapOfBlocks.PetsEntry.value:type_name -> hcl.testschema.WithStringAttr
+	7,   // 123: hcl.testschema.WithMapOfObjectsAttr.PetsEntry.value:type_name -> hcl.testschema.WithStringAttr
+	155, // 124: hcl.testschema.WithMismatchedStructAttrTypes.ByKeyEntry.value:type_name -> google.protobuf.Value
+	7,   // 125: hcl.testschema.WithMergeableContent.PetsEntry.value:type_name -> hcl.testschema.WithStringAttr
+	75,  // 126: hcl.testschema.WithMapOfBlocksKeyedByAttr.PetsEntry.value:type_name -> hcl.testschema.WithOptionalAttrs
+	75,  // 127: hcl.testschema.WithInvalidMapKeyAttr.PetsEntry.value:type_name -> hcl.testschema.WithOptionalAttrs
+	75,  // 128: hcl.testschema.WithMsgTypeConstraints.ByKeyEntry.value:type_name -> hcl.testschema.WithOptionalAttrs
+	129, // [129:129] is the sub-list for method output_type
+	129, // [129:129] is the sub-list for method input_type
+	129, // [129:129] is the sub-list for extension type_name
+	129, // [129:129] is the sub-list for extension extendee
+	0,   // [0:129] is the sub-list for field type_name
+}
+
+func init() { file_testschema_proto_init() }
+func file_testschema_proto_init() {
+	if File_testschema_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_testschema_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Root); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Thing); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MoreRoot); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RootOutput); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ConflictingRootOutput); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithStringAttr); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithRawDynamicAttr); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithStructDynamicAttr); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithStructStringAttr); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithStructListAttr); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithStructMapAttr); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StructHolder); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithStructsInNestedMessages); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithNumberAttrAsInt32); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithNumberAttrsAsFloat); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithNumberAttrAsString); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithBoolAttr); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithStringListAttr); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithNumberListAttrAsInt32); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithStringListAttrAllowScalar); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithUnitAttrs); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithUnitAttrUnsupported); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithUnitAttrString); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithAttrRange); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithAttrRangeMissing); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithAttrRangeWrongType); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithEmptyAsNullAttrs); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithEmptyAsNullList); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithNumberSyntaxAttrs); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithNumberSyntaxString); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithoutAnnotations); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithStringSetAttr); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithStringMapAttr); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithNumberMapAttrAsInt32); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithEnumAttr); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithEnumMapAttr); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithEnumListAttr); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithFlattenStringAttr); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithNestedFlattenStringAttr); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithNestedBlockNoLabelsSingleton); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithNestedBlockOneLabelSingleton); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithNestedBlockTwoLabelSingleton); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithNestedBlockNoLabelsRepeated); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithNestedBlockOneLabelRepeated); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[44].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithNestedBlockTwoLabelRepeated); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[45].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithNestedBlockOneLabelDynamic); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[46].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithOneBlockLabelDynamic); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[47].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithNestedBlockFlattenedLabels); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[48].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithFlattenedBlockLabel); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[49].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithNestedBlockConflictingLabels); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[50].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithConflictingBlockLabels); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[51].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithSameBlockTypeNested); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[52].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SameBlockTypeOuter); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[53].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SameBlockTypeInner); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[54].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RecursiveBlock); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[55].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithInvalidNestedBlocks); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[56].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithRepeatedInvalidBlocks); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[57].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RootOnlyConfig); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[58].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithRootOnlyNestedBlock); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[59].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*InvalidBlockBody); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[60].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithOneBlockLabel); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[61].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithTwoBlockLabels); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[62].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithDescribedBlockLabels); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[63].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithNestedBlockDescribedLabels); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[64].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithMapOfBlocks); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[65].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithMapOfObjectsAttr); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[66].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithObjectAttr); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[67].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithNestedObjectsAttr); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[68].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithListOfObjectsAttr); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[69].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithTupleOfObjectsAttr); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[70].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithSetOfObjectsAttr); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[71].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithListOfDynamicObjectsAttr); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[72].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithCollectionKindScalarAttr); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[73].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithOptionalAttrs); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[74].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithBlockMessageAsAttr); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[75].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithMapOfScalarsAsBlocks); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[76].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithSchemaWarnings); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[77].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithLabelAttrOptions); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[78].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LabelOrderBase); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[79].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithFlattenLabelOrder); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[80].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithMismatchedAttrType); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[81].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithMismatchedStructAttrTypes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[82].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithNestedMismatchedStructAttrType); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[83].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SharedBlockBody); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[84].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithSharedBlockBodyList); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[85].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithSharedBlockBodySet); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[86].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithSharedBlockBodyKinds); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[87].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SharedDynamicBlockBody); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[88].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithSharedDynamicBlockBodyTuple); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[89].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithSharedDynamicBlockBodySet); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[90].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithSharedDynamicBlockBodyKinds); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[91].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TLSSettings); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[92].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithFlattenPrefix); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[93].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithNestedFlattenPrefix); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[94].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithFlattenPrefixLabel); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[95].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithNestedBlockFlattenPrefixLabel); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[96].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithInvalidFlattenPrefix); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[97].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LegacySettings); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[98].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithFlattenConflictOuterWins); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[99].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithFlattenConflictInnerWins); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[100].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithNestedFlattenConflict); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[101].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithInvalidFlattenConflict); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[102].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithBlockTypeAliases); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[103].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithFlattenedBlockTypeAliases); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[104].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithBlockTypeAliasConflict); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[105].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithInvalidBlockTypeAlias); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[106].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithMergeableContent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[107].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithMapOfBlocksKeyedByAttr); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[108].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithInvalidMapKeyAttr); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[109].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithMsgTypeConstraints); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[110].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithRecursiveMsgTypeConstraint); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testschema_proto_msgTypes[111].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithUnknownMsgTypeConstraint); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
 		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use WithOneBlockLabel.ProtoReflect.Descriptor instead.
-func (*WithOneBlockLabel) Descriptor() ([]byte, []int) {
-	return file_testschema_proto_rawDescGZIP(), []int{23}
-}
-
-func (x *WithOneBlockLabel) GetName() string {
-	if x != nil {
-		return x.Name
-	}
-	return ""
-}
-
-func (x *WithOneBlockLabel) GetNickname() string {
-	if x != nil {
-		return x.Nickname
-	}
-	return ""
-}
-
-type WithTwoBlockLabels struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	Type string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
-	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	// Another field in case we also want to test attribute decoding
-	Nickname string `protobuf:"bytes,3,opt,name=nickname,proto3" json:"nickname,omitempty"`
-}
-
-func (x *WithTwoBlockLabels) Reset() {
-	*x = WithTwoBlockLabels{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_testschema_proto_msgTypes[24]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
-}
-
-func (x *WithTwoBlockLabels) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*WithTwoBlockLabels) ProtoMessage() {}
-
-func (x *WithTwoBlockLabels) ProtoReflect() protoreflect.Message {
-	mi := &file_testschema_proto_msgTypes[24]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
+		file_testschema_proto_msgTypes[112].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*S3StorageConfig); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
 		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use WithTwoBlockLabels.ProtoReflect.Descriptor instead.
-func (*WithTwoBlockLabels) Descriptor() ([]byte, []int) {
-	return file_testschema_proto_rawDescGZIP(), []int{24}
-}
-
-func (x *WithTwoBlockLabels) GetType() string {
-	if x != nil {
-		return x.Type
-	}
-	return ""
-}
-
-func (x *WithTwoBlockLabels) GetName() string {
-	if x != nil {
-		return x.Name
-	}
-	return ""
-}
-
-func (x *WithTwoBlockLabels) GetNickname() string {
-	if x != nil {
-		return x.Nickname
-	}
-	return ""
-}
-
-var File_testschema_proto protoreflect.FileDescriptor
-
-var file_testschema_proto_rawDesc = []byte{
-	0x0a, 0x10, 0x74, 0x65, 0x73, 0x74, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x12, 0x0e, 0x68, 0x63, 0x6c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x63, 0x68, 0x65,
-	0x6d, 0x61, 0x1a, 0x09, 0x68, 0x63, 0x6c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1c, 0x67,
-	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x73,
-	0x74, 0x72, 0x75, 0x63, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x9c, 0x01, 0x0a, 0x04,
-	0x52, 0x6f, 0x6f, 0x74, 0x12, 0x24, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x42, 0x10, 0x82, 0xb5, 0x18, 0x06, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x82, 0xb5,
-	0x18, 0x02, 0x10, 0x01, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x3a, 0x0a, 0x06, 0x74, 0x68,
-	0x69, 0x6e, 0x67, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x68, 0x63, 0x6c,
-	0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x54, 0x68, 0x69, 0x6e,
-	0x67, 0x42, 0x0b, 0x8a, 0xb5, 0x18, 0x07, 0x0a, 0x05, 0x74, 0x68, 0x69, 0x6e, 0x67, 0x52, 0x06,
-	0x74, 0x68, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x32, 0x0a, 0x04, 0x6d, 0x6f, 0x72, 0x65, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73,
-	0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x4d, 0x6f, 0x72, 0x65, 0x52, 0x6f, 0x6f, 0x74, 0x42, 0x04,
-	0xa0, 0xb5, 0x18, 0x01, 0x52, 0x04, 0x6d, 0x6f, 0x72, 0x65, 0x22, 0x27, 0x0a, 0x05, 0x54, 0x68,
-	0x69, 0x6e, 0x67, 0x12, 0x1e, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x42, 0x0a, 0x92, 0xb5, 0x18, 0x06, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x52, 0x04, 0x6e,
-	0x61, 0x6d, 0x65, 0x22, 0x78, 0x0a, 0x08, 0x4d, 0x6f, 0x72, 0x65, 0x52, 0x6f, 0x6f, 0x74, 0x12,
-	0x21, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x42, 0x0b,
-	0x82, 0xb5, 0x18, 0x07, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x05, 0x63, 0x6f, 0x75,
-	0x6e, 0x74, 0x12, 0x49, 0x0a, 0x0b, 0x6f, 0x74, 0x68, 0x65, 0x72, 0x5f, 0x74, 0x68, 0x69, 0x6e,
-	0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x74, 0x65,
-	0x73, 0x74, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x54, 0x68, 0x69, 0x6e, 0x67, 0x42, 0x11,
-	0x8a, 0xb5, 0x18, 0x0d, 0x0a, 0x0b, 0x6f, 0x74, 0x68, 0x65, 0x72, 0x5f, 0x74, 0x68, 0x69, 0x6e,
-	0x67, 0x52, 0x0a, 0x6f, 0x74, 0x68, 0x65, 0x72, 0x54, 0x68, 0x69, 0x6e, 0x67, 0x22, 0x3c, 0x0a,
-	0x0e, 0x57, 0x69, 0x74, 0x68, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x41, 0x74, 0x74, 0x72, 0x12,
-	0x2a, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x16, 0x82,
-	0xb5, 0x18, 0x06, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x82, 0xb5, 0x18, 0x08, 0x1a, 0x06, 0x73,
-	0x74, 0x72, 0x69, 0x6e, 0x67, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x40, 0x0a, 0x12, 0x57,
-	0x69, 0x74, 0x68, 0x52, 0x61, 0x77, 0x44, 0x79, 0x6e, 0x61, 0x6d, 0x69, 0x63, 0x41, 0x74, 0x74,
-	0x72, 0x12, 0x2a, 0x0a, 0x03, 0x72, 0x61, 0x77, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x42, 0x18,
-	0x82, 0xb5, 0x18, 0x05, 0x0a, 0x03, 0x72, 0x61, 0x77, 0x82, 0xb5, 0x18, 0x05, 0x1a, 0x03, 0x61,
-	0x6e, 0x79, 0x82, 0xb5, 0x18, 0x02, 0x20, 0x02, 0x52, 0x03, 0x72, 0x61, 0x77, 0x22, 0x5e, 0x0a,
-	0x15, 0x57, 0x69, 0x74, 0x68, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x44, 0x79, 0x6e, 0x61, 0x6d,
-	0x69, 0x63, 0x41, 0x74, 0x74, 0x72, 0x12, 0x45, 0x0a, 0x06, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x15,
-	0x82, 0xb5, 0x18, 0x08, 0x0a, 0x06, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x82, 0xb5, 0x18, 0x05,
-	0x1a, 0x03, 0x61, 0x6e, 0x79, 0x52, 0x06, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x22, 0x60, 0x0a,
-	0x14, 0x57, 0x69, 0x74, 0x68, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x53, 0x74, 0x72, 0x69, 0x6e,
-	0x67, 0x41, 0x74, 0x74, 0x72, 0x12, 0x48, 0x0a, 0x06, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x18, 0x82,
-	0xb5, 0x18, 0x08, 0x0a, 0x06, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x82, 0xb5, 0x18, 0x08, 0x1a,
-	0x06, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x52, 0x06, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x22,
-	0x5e, 0x0a, 0x12, 0x57, 0x69, 0x74, 0x68, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x4c, 0x69, 0x73,
-	0x74, 0x41, 0x74, 0x74, 0x72, 0x12, 0x48, 0x0a, 0x07, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x73,
-	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x16,
-	0x82, 0xb5, 0x18, 0x09, 0x0a, 0x07, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x73, 0x82, 0xb5, 0x18,
-	0x05, 0x1a, 0x03, 0x61, 0x6e, 0x79, 0x52, 0x07, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x73, 0x22,
-	0xc9, 0x01, 0x0a, 0x11, 0x57, 0x69, 0x74, 0x68, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x4d, 0x61,
-	0x70, 0x41, 0x74, 0x74, 0x72, 0x12, 0x60, 0x0a, 0x07, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x73,
-	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2e, 0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x74, 0x65, 0x73,
-	0x74, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x57, 0x69, 0x74, 0x68, 0x53, 0x74, 0x72, 0x75,
-	0x63, 0x74, 0x4d, 0x61, 0x70, 0x41, 0x74, 0x74, 0x72, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74,
-	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x42, 0x16, 0x82, 0xb5, 0x18, 0x09, 0x0a, 0x07, 0x73, 0x74,
-	0x72, 0x75, 0x63, 0x74, 0x73, 0x82, 0xb5, 0x18, 0x05, 0x1a, 0x03, 0x61, 0x6e, 0x79, 0x52, 0x07,
-	0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x73, 0x1a, 0x52, 0x0a, 0x0c, 0x53, 0x74, 0x72, 0x75, 0x63,
-	0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2c, 0x0a, 0x05, 0x76, 0x61, 0x6c,
-	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
-	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65,
-	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x40, 0x0a, 0x15, 0x57,
-	0x69, 0x74, 0x68, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x41, 0x74, 0x74, 0x72, 0x41, 0x73, 0x49,
-	0x6e, 0x74, 0x33, 0x32, 0x12, 0x27, 0x0a, 0x03, 0x6e, 0x75, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x05, 0x42, 0x15, 0x82, 0xb5, 0x18, 0x05, 0x0a, 0x03, 0x6e, 0x75, 0x6d, 0x82, 0xb5, 0x18, 0x08,
-	0x1a, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x52, 0x03, 0x6e, 0x75, 0x6d, 0x22, 0x41, 0x0a,
-	0x16, 0x57, 0x69, 0x74, 0x68, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x41, 0x74, 0x74, 0x72, 0x41,
-	0x73, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x12, 0x27, 0x0a, 0x03, 0x6e, 0x75, 0x6d, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x42, 0x15, 0x82, 0xb5, 0x18, 0x05, 0x0a, 0x03, 0x6e, 0x75, 0x6d, 0x82,
-	0xb5, 0x18, 0x08, 0x1a, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x52, 0x03, 0x6e, 0x75, 0x6d,
-	0x22, 0x44, 0x0a, 0x0c, 0x57, 0x69, 0x74, 0x68, 0x42, 0x6f, 0x6f, 0x6c, 0x41, 0x74, 0x74, 0x72,
-	0x12, 0x34, 0x0a, 0x0c, 0x64, 0x6f, 0x5f, 0x74, 0x68, 0x65, 0x5f, 0x74, 0x68, 0x69, 0x6e, 0x67,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x42, 0x12, 0x82, 0xb5, 0x18, 0x0e, 0x0a, 0x0c, 0x64, 0x6f,
-	0x5f, 0x74, 0x68, 0x65, 0x5f, 0x74, 0x68, 0x69, 0x6e, 0x67, 0x52, 0x0a, 0x64, 0x6f, 0x54, 0x68,
-	0x65, 0x54, 0x68, 0x69, 0x6e, 0x67, 0x22, 0x37, 0x0a, 0x12, 0x57, 0x69, 0x74, 0x68, 0x53, 0x74,
-	0x72, 0x69, 0x6e, 0x67, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x74, 0x74, 0x72, 0x12, 0x21, 0x0a, 0x05,
-	0x6e, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x42, 0x0b, 0x82, 0xb5, 0x18,
-	0x07, 0x0a, 0x05, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x52, 0x05, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x22,
-	0x47, 0x0a, 0x11, 0x57, 0x69, 0x74, 0x68, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x53, 0x65, 0x74,
-	0x41, 0x74, 0x74, 0x72, 0x12, 0x32, 0x0a, 0x05, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x01, 0x20,
-	0x03, 0x28, 0x09, 0x42, 0x1c, 0x82, 0xb5, 0x18, 0x07, 0x0a, 0x05, 0x6e, 0x61, 0x6d, 0x65, 0x73,
-	0x82, 0xb5, 0x18, 0x0d, 0x1a, 0x0b, 0x73, 0x65, 0x74, 0x28, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67,
-	0x29, 0x52, 0x05, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x22, 0x9e, 0x01, 0x0a, 0x11, 0x57, 0x69, 0x74,
-	0x68, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x4d, 0x61, 0x70, 0x41, 0x74, 0x74, 0x72, 0x12, 0x4f,
-	0x0a, 0x05, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2c, 0x2e,
-	0x68, 0x63, 0x6c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x57,
-	0x69, 0x74, 0x68, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x4d, 0x61, 0x70, 0x41, 0x74, 0x74, 0x72,
-	0x2e, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x42, 0x0b, 0x82, 0xb5, 0x18,
-	0x07, 0x0a, 0x05, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x52, 0x05, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x1a,
-	0x38, 0x0a, 0x0a, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
-	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
-	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x86, 0x01, 0x0a, 0x15, 0x57, 0x69,
-	0x74, 0x68, 0x46, 0x6c, 0x61, 0x74, 0x74, 0x65, 0x6e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x41,
-	0x74, 0x74, 0x72, 0x12, 0x38, 0x0a, 0x04, 0x62, 0x61, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x1e, 0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x63, 0x68, 0x65,
-	0x6d, 0x61, 0x2e, 0x57, 0x69, 0x74, 0x68, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x41, 0x74, 0x74,
-	0x72, 0x42, 0x04, 0xa0, 0xb5, 0x18, 0x01, 0x52, 0x04, 0x62, 0x61, 0x73, 0x65, 0x12, 0x33, 0x0a,
-	0x07, 0x73, 0x70, 0x65, 0x63, 0x69, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x42, 0x19,
-	0x82, 0xb5, 0x18, 0x09, 0x0a, 0x07, 0x73, 0x70, 0x65, 0x63, 0x69, 0x65, 0x73, 0x82, 0xb5, 0x18,
-	0x08, 0x1a, 0x06, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x52, 0x07, 0x73, 0x70, 0x65, 0x63, 0x69,
-	0x65, 0x73, 0x22, 0x8d, 0x01, 0x0a, 0x1b, 0x57, 0x69, 0x74, 0x68, 0x4e, 0x65, 0x73, 0x74, 0x65,
-	0x64, 0x46, 0x6c, 0x61, 0x74, 0x74, 0x65, 0x6e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x41, 0x74,
-	0x74, 0x72, 0x12, 0x3f, 0x0a, 0x04, 0x62, 0x61, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x25, 0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x63, 0x68, 0x65, 0x6d,
-	0x61, 0x2e, 0x57, 0x69, 0x74, 0x68, 0x46, 0x6c, 0x61, 0x74, 0x74, 0x65, 0x6e, 0x53, 0x74, 0x72,
-	0x69, 0x6e, 0x67, 0x41, 0x74, 0x74, 0x72, 0x42, 0x04, 0xa0, 0xb5, 0x18, 0x01, 0x52, 0x04, 0x62,
-	0x61, 0x73, 0x65, 0x12, 0x2d, 0x0a, 0x05, 0x62, 0x72, 0x65, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x09, 0x42, 0x17, 0x82, 0xb5, 0x18, 0x07, 0x0a, 0x05, 0x62, 0x72, 0x65, 0x65, 0x64, 0x82,
-	0xb5, 0x18, 0x08, 0x1a, 0x06, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x52, 0x05, 0x62, 0x72, 0x65,
-	0x65, 0x64, 0x22, 0x68, 0x0a, 0x20, 0x57, 0x69, 0x74, 0x68, 0x4e, 0x65, 0x73, 0x74, 0x65, 0x64,
-	0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4e, 0x6f, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x53, 0x69, 0x6e,
-	0x67, 0x6c, 0x65, 0x74, 0x6f, 0x6e, 0x12, 0x44, 0x0a, 0x06, 0x64, 0x6f, 0x6f, 0x64, 0x61, 0x64,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x74, 0x65, 0x73,
-	0x74, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x57, 0x69, 0x74, 0x68, 0x53, 0x74, 0x72, 0x69,
-	0x6e, 0x67, 0x41, 0x74, 0x74, 0x72, 0x42, 0x0c, 0x8a, 0xb5, 0x18, 0x08, 0x0a, 0x06, 0x64, 0x6f,
-	0x6f, 0x64, 0x61, 0x64, 0x52, 0x06, 0x64, 0x6f, 0x6f, 0x64, 0x61, 0x64, 0x22, 0x6b, 0x0a, 0x20,
-	0x57, 0x69, 0x74, 0x68, 0x4e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4f,
-	0x6e, 0x65, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x53, 0x69, 0x6e, 0x67, 0x6c, 0x65, 0x74, 0x6f, 0x6e,
-	0x12, 0x47, 0x0a, 0x06, 0x64, 0x6f, 0x6f, 0x64, 0x61, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x21, 0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x63, 0x68, 0x65, 0x6d,
-	0x61, 0x2e, 0x57, 0x69, 0x74, 0x68, 0x4f, 0x6e, 0x65, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4c, 0x61,
-	0x62, 0x65, 0x6c, 0x42, 0x0c, 0x8a, 0xb5, 0x18, 0x08, 0x0a, 0x06, 0x64, 0x6f, 0x6f, 0x64, 0x61,
-	0x64, 0x52, 0x06, 0x64, 0x6f, 0x6f, 0x64, 0x61, 0x64, 0x22, 0x6c, 0x0a, 0x20, 0x57, 0x69, 0x74,
-	0x68, 0x4e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x54, 0x77, 0x6f, 0x4c,
-	0x61, 0x62, 0x65, 0x6c, 0x53, 0x69, 0x6e, 0x67, 0x6c, 0x65, 0x74, 0x6f, 0x6e, 0x12, 0x48, 0x0a,
-	0x06, 0x64, 0x6f, 0x6f, 0x64, 0x61, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e,
-	0x68, 0x63, 0x6c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x57,
-	0x69, 0x74, 0x68, 0x54, 0x77, 0x6f, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4c, 0x61, 0x62, 0x65, 0x6c,
-	0x73, 0x42, 0x0c, 0x8a, 0xb5, 0x18, 0x08, 0x0a, 0x06, 0x64, 0x6f, 0x6f, 0x64, 0x61, 0x64, 0x52,
-	0x06, 0x64, 0x6f, 0x6f, 0x64, 0x61, 0x64, 0x22, 0x6d, 0x0a, 0x1f, 0x57, 0x69, 0x74, 0x68, 0x4e,
-	0x65, 0x73, 0x74, 0x65, 0x64, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4e, 0x6f, 0x4c, 0x61, 0x62, 0x65,
-	0x6c, 0x73, 0x52, 0x65, 0x70, 0x65, 0x61, 0x74, 0x65, 0x64, 0x12, 0x4a, 0x0a, 0x06, 0x64, 0x6f,
-	0x6f, 0x64, 0x61, 0x64, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x68, 0x63, 0x6c,
-	0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x57, 0x69, 0x74, 0x68,
-	0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x41, 0x74, 0x74, 0x72, 0x42, 0x12, 0x8a, 0xb5, 0x18, 0x08,
-	0x0a, 0x06, 0x64, 0x6f, 0x6f, 0x64, 0x61, 0x64, 0x8a, 0xb5, 0x18, 0x02, 0x10, 0x03, 0x52, 0x06,
-	0x64, 0x6f, 0x6f, 0x64, 0x61, 0x64, 0x22, 0x70, 0x0a, 0x1f, 0x57, 0x69, 0x74, 0x68, 0x4e, 0x65,
-	0x73, 0x74, 0x65, 0x64, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4f, 0x6e, 0x65, 0x4c, 0x61, 0x62, 0x65,
-	0x6c, 0x52, 0x65, 0x70, 0x65, 0x61, 0x74, 0x65, 0x64, 0x12, 0x4d, 0x0a, 0x06, 0x64, 0x6f, 0x6f,
-	0x64, 0x61, 0x64, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x68, 0x63, 0x6c, 0x2e,
-	0x74, 0x65, 0x73, 0x74, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x57, 0x69, 0x74, 0x68, 0x4f,
-	0x6e, 0x65, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x42, 0x12, 0x8a, 0xb5,
-	0x18, 0x08, 0x0a, 0x06, 0x64, 0x6f, 0x6f, 0x64, 0x61, 0x64, 0x8a, 0xb5, 0x18, 0x02, 0x10, 0x02,
-	0x52, 0x06, 0x64, 0x6f, 0x6f, 0x64, 0x61, 0x64, 0x22, 0x6b, 0x0a, 0x1f, 0x57, 0x69, 0x74, 0x68,
-	0x4e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x54, 0x77, 0x6f, 0x4c, 0x61,
-	0x62, 0x65, 0x6c, 0x52, 0x65, 0x70, 0x65, 0x61, 0x74, 0x65, 0x64, 0x12, 0x48, 0x0a, 0x06, 0x64,
-	0x6f, 0x6f, 0x64, 0x61, 0x64, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x68, 0x63,
-	0x6c, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x57, 0x69, 0x74,
-	0x68, 0x54, 0x77, 0x6f, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x42,
-	0x0c, 0x8a, 0xb5, 0x18, 0x08, 0x0a, 0x06, 0x64, 0x6f, 0x6f, 0x64, 0x61, 0x64, 0x52, 0x06, 0x64,
-	0x6f, 0x6f, 0x64, 0x61, 0x64, 0x22, 0x6b, 0x0a, 0x11, 0x57, 0x69, 0x74, 0x68, 0x4f, 0x6e, 0x65,
-	0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x12, 0x1e, 0x0a, 0x04, 0x6e, 0x61,
-	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x0a, 0x92, 0xb5, 0x18, 0x06, 0x0a, 0x04,
-	0x6e, 0x61, 0x6d, 0x65, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x36, 0x0a, 0x08, 0x6e, 0x69,
-	0x63, 0x6b, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x42, 0x1a, 0x82, 0xb5,
-	0x18, 0x0a, 0x0a, 0x08, 0x6e, 0x69, 0x63, 0x6b, 0x6e, 0x61, 0x6d, 0x65, 0x82, 0xb5, 0x18, 0x08,
-	0x1a, 0x06, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x52, 0x08, 0x6e, 0x69, 0x63, 0x6b, 0x6e, 0x61,
-	0x6d, 0x65, 0x22, 0x8c, 0x01, 0x0a, 0x12, 0x57, 0x69, 0x74, 0x68, 0x54, 0x77, 0x6f, 0x42, 0x6c,
-	0x6f, 0x63, 0x6b, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x12, 0x1e, 0x0a, 0x04, 0x74, 0x79, 0x70,
-	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x0a, 0x92, 0xb5, 0x18, 0x06, 0x0a, 0x04, 0x74,
-	0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x1e, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
-	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x42, 0x0a, 0x92, 0xb5, 0x18, 0x06, 0x0a, 0x04, 0x6e,
-	0x61, 0x6d, 0x65, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x36, 0x0a, 0x08, 0x6e, 0x69, 0x63,
-	0x6b, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x42, 0x1a, 0x82, 0xb5, 0x18,
-	0x0a, 0x0a, 0x08, 0x6e, 0x69, 0x63, 0x6b, 0x6e, 0x61, 0x6d, 0x65, 0x82, 0xb5, 0x18, 0x08, 0x1a,
-	0x06, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x52, 0x08, 0x6e, 0x69, 0x63, 0x6b, 0x6e, 0x61, 0x6d,
-	0x65, 0x42, 0x44, 0x5a, 0x42, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
-	0x61, 0x70, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x6c, 0x79, 0x6d, 0x61, 0x72, 0x74, 0x2f, 0x67,
-	0x6f, 0x2d, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x68, 0x63, 0x6c, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x68, 0x63, 0x6c, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x74, 0x65, 0x73,
-	0x74, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
-}
-
-var (
-	file_testschema_proto_rawDescOnce sync.Once
-	file_testschema_proto_rawDescData = file_testschema_proto_rawDesc
-)
-
-func file_testschema_proto_rawDescGZIP() []byte {
-	file_testschema_proto_rawDescOnce.Do(func() {
-		file_testschema_proto_rawDescData = protoimpl.X.CompressGZIP(file_testschema_proto_rawDescData)
-	})
-	return file_testschema_proto_rawDescData
-}
-
-var file_testschema_proto_msgTypes = make([]protoimpl.MessageInfo, 27)
-var file_testschema_proto_goTypes = []interface{}{
-	(*Root)(nil),                             // 0: hcl.testschema.Root
-	(*Thing)(nil),                            // 1: hcl.testschema.Thing
-	(*MoreRoot)(nil),                         // 2: hcl.testschema.MoreRoot
-	(*WithStringAttr)(nil),                   // 3: hcl.testschema.WithStringAttr
-	(*WithRawDynamicAttr)(nil),               // 4: hcl.testschema.WithRawDynamicAttr
-	(*WithStructDynamicAttr)(nil),            // 5: hcl.testschema.WithStructDynamicAttr
-	(*WithStructStringAttr)(nil),             // 6: hcl.testschema.WithStructStringAttr
-	(*WithStructListAttr)(nil),               // 7: hcl.testschema.WithStructListAttr
-	(*WithStructMapAttr)(nil),                // 8: hcl.testschema.WithStructMapAttr
-	(*WithNumberAttrAsInt32)(nil),            // 9: hcl.testschema.WithNumberAttrAsInt32
-	(*WithNumberAttrAsString)(nil),           // 10: hcl.testschema.WithNumberAttrAsString
-	(*WithBoolAttr)(nil),                     // 11: hcl.testschema.WithBoolAttr
-	(*WithStringListAttr)(nil),               // 12: hcl.testschema.WithStringListAttr
-	(*WithStringSetAttr)(nil),                // 13: hcl.testschema.WithStringSetAttr
-	(*WithStringMapAttr)(nil),                // 14: hcl.testschema.WithStringMapAttr
-	(*WithFlattenStringAttr)(nil),            // 15: hcl.testschema.WithFlattenStringAttr
-	(*WithNestedFlattenStringAttr)(nil),      // 16: hcl.testschema.WithNestedFlattenStringAttr
-	(*WithNestedBlockNoLabelsSingleton)(nil), // 17: hcl.testschema.WithNestedBlockNoLabelsSingleton
-	(*WithNestedBlockOneLabelSingleton)(nil), // 18: hcl.testschema.WithNestedBlockOneLabelSingleton
-	(*WithNestedBlockTwoLabelSingleton)(nil), // 19: hcl.testschema.WithNestedBlockTwoLabelSingleton
-	(*WithNestedBlockNoLabelsRepeated)(nil),  // 20: hcl.testschema.WithNestedBlockNoLabelsRepeated
-	(*WithNestedBlockOneLabelRepeated)(nil),  // 21: hcl.testschema.WithNestedBlockOneLabelRepeated
-	(*WithNestedBlockTwoLabelRepeated)(nil),  // 22: hcl.testschema.WithNestedBlockTwoLabelRepeated
-	(*WithOneBlockLabel)(nil),                // 23: hcl.testschema.WithOneBlockLabel
-	(*WithTwoBlockLabels)(nil),               // 24: hcl.testschema.WithTwoBlockLabels
-	nil,                                      // 25: hcl.testschema.WithStructMapAttr.StructsEntry
-	nil,                                      // 26: hcl.testschema.WithStringMapAttr.NamesEntry
-	(*structpb.Value)(nil),                   // 27: google.protobuf.Value
-}
-var file_testschema_proto_depIdxs = []int32{
-	1,  // 0: hcl.testschema.Root.things:type_name -> hcl.testschema.Thing
-	2,  // 1: hcl.testschema.Root.more:type_name -> hcl.testschema.MoreRoot
-	1,  // 2: hcl.testschema.MoreRoot.other_thing:type_name -> hcl.testschema.Thing
-	27, // 3: hcl.testschema.WithStructDynamicAttr.struct:type_name -> google.protobuf.Value
-	27, // 4: hcl.testschema.WithStructStringAttr.struct:type_name -> google.protobuf.Value
-	27, // 5: hcl.testschema.WithStructListAttr.structs:type_name -> google.protobuf.Value
-	25, // 6: hcl.testschema.WithStructMapAttr.structs:type_name -> hcl.testschema.WithStructMapAttr.StructsEntry
-	26, // 7: hcl.testschema.WithStringMapAttr.names:type_name -> hcl.testschema.WithStringMapAttr.NamesEntry
-	3,  // 8: hcl.testschema.WithFlattenStringAttr.base:type_name -> hcl.testschema.WithStringAttr
-	15, // 9: hcl.testschema.WithNestedFlattenStringAttr.base:type_name -> hcl.testschema.WithFlattenStringAttr
-	3,  // 10: hcl.testschema.WithNestedBlockNoLabelsSingleton.doodad:type_name -> hcl.testschema.WithStringAttr
-	23, // 11: hcl.testschema.WithNestedBlockOneLabelSingleton.doodad:type_name -> hcl.testschema.WithOneBlockLabel
-	24, // 12: hcl.testschema.WithNestedBlockTwoLabelSingleton.doodad:type_name -> hcl.testschema.WithTwoBlockLabels
-	3,  // 13: hcl.testschema.WithNestedBlockNoLabelsRepeated.doodad:type_name -> hcl.testschema.WithStringAttr
-	23, // 14: hcl.testschema.WithNestedBlockOneLabelRepeated.doodad:type_name -> hcl.testschema.WithOneBlockLabel
-	24, // 15: hcl.testschema.WithNestedBlockTwoLabelRepeated.doodad:type_name -> hcl.testschema.WithTwoBlockLabels
-	27, // 16: hcl.testschema.WithStructMapAttr.StructsEntry.value:type_name -> google.protobuf.Value
-	17, // [17:17] is the sub-list for method output_type
-	17, // [17:17] is the sub-list for method input_type
-	17, // [17:17] is the sub-list for extension type_name
-	17, // [17:17] is the sub-list for extension extendee
-	0,  // [0:17] is the sub-list for field type_name
-}
-
-func init() { file_testschema_proto_init() }
-func file_testschema_proto_init() {
-	if File_testschema_proto != nil {
-		return
-	}
-	if !protoimpl.UnsafeEnabled {
-		file_testschema_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Root); i {
+		file_testschema_proto_msgTypes[113].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LocalStorageConfig); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1564,8 +10283,8 @@ func file_testschema_proto_init() {
 				return nil
 			}
 		}
-		file_testschema_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Thing); i {
+		file_testschema_proto_msgTypes[114].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithAnyBlock); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1576,8 +10295,8 @@ func file_testschema_proto_init() {
 				return nil
 			}
 		}
-		file_testschema_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*MoreRoot); i {
+		file_testschema_proto_msgTypes[115].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithFlattenedAnyBlock); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1588,8 +10307,8 @@ func file_testschema_proto_init() {
 				return nil
 			}
 		}
-		file_testschema_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*WithStringAttr); i {
+		file_testschema_proto_msgTypes[116].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithAnyBlockDiscriminatorAfter); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1600,8 +10319,8 @@ func file_testschema_proto_init() {
 				return nil
 			}
 		}
-		file_testschema_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*WithRawDynamicAttr); i {
+		file_testschema_proto_msgTypes[117].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithAnyBlockUnknownType); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1612,8 +10331,8 @@ func file_testschema_proto_init() {
 				return nil
 			}
 		}
-		file_testschema_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*WithStructDynamicAttr); i {
+		file_testschema_proto_msgTypes[118].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithAnyBlockNoDiscriminator); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1624,8 +10343,8 @@ func file_testschema_proto_init() {
 				return nil
 			}
 		}
-		file_testschema_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*WithStructStringAttr); i {
+		file_testschema_proto_msgTypes[119].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithOneofBlocks); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1636,8 +10355,8 @@ func file_testschema_proto_init() {
 				return nil
 			}
 		}
-		file_testschema_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*WithStructListAttr); i {
+		file_testschema_proto_msgTypes[120].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithOneofAttr); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1648,8 +10367,8 @@ func file_testschema_proto_init() {
 				return nil
 			}
 		}
-		file_testschema_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*WithStructMapAttr); i {
+		file_testschema_proto_msgTypes[121].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithIgnoreNullInLists); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1660,8 +10379,8 @@ func file_testschema_proto_init() {
 				return nil
 			}
 		}
-		file_testschema_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*WithNumberAttrAsInt32); i {
+		file_testschema_proto_msgTypes[122].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithIgnoreNullInListsNested); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1672,8 +10391,8 @@ func file_testschema_proto_init() {
 				return nil
 			}
 		}
-		file_testschema_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*WithNumberAttrAsString); i {
+		file_testschema_proto_msgTypes[123].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithIgnoreNullInListsSingular); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1684,8 +10403,8 @@ func file_testschema_proto_init() {
 				return nil
 			}
 		}
-		file_testschema_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*WithBoolAttr); i {
+		file_testschema_proto_msgTypes[124].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithOptionalScalarAttrs); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1696,8 +10415,8 @@ func file_testschema_proto_init() {
 				return nil
 			}
 		}
-		file_testschema_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*WithStringListAttr); i {
+		file_testschema_proto_msgTypes[125].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithDefaultExprs); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1708,8 +10427,8 @@ func file_testschema_proto_init() {
 				return nil
 			}
 		}
-		file_testschema_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*WithStringSetAttr); i {
+		file_testschema_proto_msgTypes[126].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithDefaultExprCycle); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1720,8 +10439,8 @@ func file_testschema_proto_init() {
 				return nil
 			}
 		}
-		file_testschema_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*WithStringMapAttr); i {
+		file_testschema_proto_msgTypes[127].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithDefaultExprRequired); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1732,8 +10451,8 @@ func file_testschema_proto_init() {
 				return nil
 			}
 		}
-		file_testschema_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*WithFlattenStringAttr); i {
+		file_testschema_proto_msgTypes[128].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithDefaultExprInvalid); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1744,8 +10463,8 @@ func file_testschema_proto_init() {
 				return nil
 			}
 		}
-		file_testschema_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*WithNestedFlattenStringAttr); i {
+		file_testschema_proto_msgTypes[129].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithWrapperAttrs); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1756,8 +10475,8 @@ func file_testschema_proto_init() {
 				return nil
 			}
 		}
-		file_testschema_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*WithNestedBlockNoLabelsSingleton); i {
+		file_testschema_proto_msgTypes[130].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithRepeatedWrapperAttr); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1768,8 +10487,8 @@ func file_testschema_proto_init() {
 				return nil
 			}
 		}
-		file_testschema_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*WithNestedBlockOneLabelSingleton); i {
+		file_testschema_proto_msgTypes[131].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithTimestampAttr); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1780,8 +10499,8 @@ func file_testschema_proto_init() {
 				return nil
 			}
 		}
-		file_testschema_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*WithNestedBlockTwoLabelSingleton); i {
+		file_testschema_proto_msgTypes[132].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithDurationAttr); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1792,8 +10511,8 @@ func file_testschema_proto_init() {
 				return nil
 			}
 		}
-		file_testschema_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*WithNestedBlockNoLabelsRepeated); i {
+		file_testschema_proto_msgTypes[133].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithStructpbContainerAttrs); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1804,8 +10523,8 @@ func file_testschema_proto_init() {
 				return nil
 			}
 		}
-		file_testschema_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*WithNestedBlockOneLabelRepeated); i {
+		file_testschema_proto_msgTypes[134].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithStructpbStructWrongType); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1816,8 +10535,8 @@ func file_testschema_proto_init() {
 				return nil
 			}
 		}
-		file_testschema_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*WithNestedBlockTwoLabelRepeated); i {
+		file_testschema_proto_msgTypes[135].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithAnyTypeAttr); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1828,8 +10547,8 @@ func file_testschema_proto_init() {
 				return nil
 			}
 		}
-		file_testschema_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*WithOneBlockLabel); i {
+		file_testschema_proto_msgTypes[136].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithAnyTypeAttrUnknownType); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1840,8 +10559,8 @@ func file_testschema_proto_init() {
 				return nil
 			}
 		}
-		file_testschema_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*WithTwoBlockLabels); i {
+		file_testschema_proto_msgTypes[137].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithAnyTypeAttrNotAny); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1853,18 +10572,29 @@ func file_testschema_proto_init() {
 			}
 		}
 	}
+	file_testschema_proto_msgTypes[106].OneofWrappers = []interface{}{}
+	file_testschema_proto_msgTypes[119].OneofWrappers = []interface{}{
+		(*WithOneofBlocks_Local)(nil),
+		(*WithOneofBlocks_Remote)(nil),
+	}
+	file_testschema_proto_msgTypes[120].OneofWrappers = []interface{}{
+		(*WithOneofAttr_Name)(nil),
+		(*WithOneofAttr_Thing)(nil),
+	}
+	file_testschema_proto_msgTypes[124].OneofWrappers = []interface{}{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_testschema_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   27,
+			NumEnums:      2,
+			NumMessages:   153,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
 		GoTypes:           file_testschema_proto_goTypes,
 		DependencyIndexes: file_testschema_proto_depIdxs,
+		EnumInfos:         file_testschema_proto_enumTypes,
 		MessageInfos:      file_testschema_proto_msgTypes,
 	}.Build()
 	File_testschema_proto = out.File
@@ -0,0 +1,223 @@
+package protohcl
+
+import (
+	"testing"
+
+	"github.com/apparentlymart/go-protohcl/protohcl/internal/testschema"
+	"github.com/apparentlymart/go-protohcl/protohcl/protohcltest"
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty-debug/ctydebug"
+	"github.com/zclconf/go-cty/cty"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestDecodeBodyMapKeyAttr(t *testing.T) {
+	tests := map[string]struct {
+		config    string
+		want      proto.Message
+		wantDiags []protohcltest.ExpectedDiagnostic
+	}{
+		"keyed blocks": {
+			`
+				pet {
+					name  = "fido"
+					count = 1
+				}
+				pet {
+					name = "rex"
+				}
+			`,
+			&testschema.WithMapOfBlocksKeyedByAttr{
+				Pets: map[string]*testschema.WithOptionalAttrs{
+					"fido": {Name: "fido", Count: 1},
+					"rex":  {Name: "rex"},
+				},
+			},
+			nil,
+		},
+		"duplicate key": {
+			`
+				pet {
+					name = "fido"
+				}
+				pet {
+					name = "fido"
+				}
+			`,
+			&testschema.WithMapOfBlocksKeyedByAttr{
+				Pets: map[string]*testschema.WithOptionalAttrs{
+					"fido": {Name: "fido"},
+				},
+			},
+			[]protohcltest.ExpectedDiagnostic{
+				protohcltest.Error("Duplicate pet block").
+					WithDetail(`There may be no more than one pet block with name "fido". Previous block declared at :2,5-8.`).
+					OnLine(6),
+			},
+		},
+		"missing key": {
+			`
+				pet {
+					count = 2
+				}
+			`,
+			&testschema.WithMapOfBlocksKeyedByAttr{},
+			[]protohcltest.ExpectedDiagnostic{
+				protohcltest.Error("Missing required argument").
+					WithDetail(`The argument "name" is required, but no definition was found.`),
+			},
+		},
+		"null key": {
+			`
+				pet {
+					name = null
+				}
+			`,
+			&testschema.WithMapOfBlocksKeyedByAttr{},
+			[]protohcltest.ExpectedDiagnostic{
+				protohcltest.Error("Unsuitable attribute value").
+					WithDetail(`Attribute "name" is required, so must not be null.`).
+					OnLine(3),
+			},
+		},
+		"labels not allowed": {
+			`
+				pet "fido" {
+					name = "fido"
+				}
+			`,
+			&testschema.WithMapOfBlocksKeyedByAttr{},
+			[]protohcltest.ExpectedDiagnostic{
+				protohcltest.Error("Extraneous label for pet").OnLine(2),
+			},
+		},
+	}
+
+	desc := (&testschema.WithMapOfBlocksKeyedByAttr{}).ProtoReflect().Descriptor()
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, diags := DecodeBody(parseTestBody(t, test.config), desc, nil)
+			protohcltest.AssertDiagnostics(t, diags, test.wantDiags...)
+			if diff := cmp.Diff(test.want, got, protoCmpOpt); diff != "" {
+				t.Errorf("wrong result\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDecodeBodyMapKeyAttrEvaluatesOnce(t *testing.T) {
+	// The decoder needs the key before it decodes each block, but must not
+	// evaluate the key attribute a second time when decoding the block.
+	evals := make(map[string]int)
+	opts := DecodeOptions{
+		EvaluateExpression: func(expr hcl.Expression, ctx *hcl.EvalContext) (cty.Value, hcl.Diagnostics) {
+			evals[expr.Range().String()]++
+			return expr.Value(ctx)
+		},
+	}
+	desc := (&testschema.WithMapOfBlocksKeyedByAttr{}).ProtoReflect().Descriptor()
+	got, diags := opts.DecodeBody(parseTestBody(t, `
+		pet {
+			name  = "fido"
+			count = 1
+		}
+	`), desc, nil)
+	protohcltest.AssertDiagnostics(t, diags)
+
+	want := &testschema.WithMapOfBlocksKeyedByAttr{
+		Pets: map[string]*testschema.WithOptionalAttrs{
+			"fido": {Name: "fido", Count: 1},
+		},
+	}
+	if diff := cmp.Diff(want, got, protoCmpOpt); diff != "" {
+		t.Errorf("wrong result\n%s", diff)
+	}
+	for rng, count := range evals {
+		if count != 1 {
+			t.Errorf("expression at %s evaluated %d times; want 1", rng, count)
+		}
+	}
+	if got, want := len(evals), 2; got != want {
+		t.Errorf("evaluated %d expressions; want %d", got, want)
+	}
+}
+
+func TestDecodeBodyIntoMapKeyAttr(t *testing.T) {
+	msg := &testschema.WithMapOfBlocksKeyedByAttr{
+		Pets: map[string]*testschema.WithOptionalAttrs{
+			"fido": {Name: "fido", Count: 1},
+		},
+	}
+	diags := DecodeBodyInto(parseTestBody(t, `
+		pet {
+			name  = "fido"
+			count = 2
+		}
+		pet {
+			name = "rex"
+		}
+	`), msg, nil)
+	protohcltest.AssertDiagnostics(t, diags)
+
+	want := &testschema.WithMapOfBlocksKeyedByAttr{
+		Pets: map[string]*testschema.WithOptionalAttrs{
+			"fido": {Name: "fido", Count: 2},
+			"rex":  {Name: "rex"},
+		},
+	}
+	if diff := cmp.Diff(want, msg, protoCmpOpt); diff != "" {
+		t.Errorf("wrong result\n%s", diff)
+	}
+}
+
+func TestMapKeyAttrValues(t *testing.T) {
+	msg := &testschema.WithMapOfBlocksKeyedByAttr{
+		Pets: map[string]*testschema.WithOptionalAttrs{
+			"fido": {Name: "fido", Count: 1},
+		},
+	}
+	got, err := ObjectValueForMessage(msg)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := cty.ObjectVal(map[string]cty.Value{
+		"pet": cty.MapVal(map[string]cty.Value{
+			"fido": cty.ObjectVal(map[string]cty.Value{
+				"name":  cty.StringVal("fido"),
+				"count": cty.NumberIntVal(1),
+			}),
+		}),
+	})
+	if diff := cmp.Diff(want, got, ctydebug.CmpOptions); diff != "" {
+		t.Errorf("wrong value\n%s", diff)
+	}
+
+	// ValueDecoder accepts the same shape, and also allows leaving out the
+	// key attribute in favor of the map key.
+	desc := msg.ProtoReflect().Descriptor()
+	val := cty.ObjectVal(map[string]cty.Value{
+		"pet": cty.MapVal(map[string]cty.Value{
+			"fido": cty.ObjectVal(map[string]cty.Value{
+				"count": cty.NumberIntVal(1),
+			}),
+		}),
+	})
+	gotMsg, diags := DecodeOptions{}.ValueDecoder(val, hcl.Range{Filename: "test"}).Decode(desc)
+	protohcltest.AssertDiagnostics(t, diags)
+	if diff := cmp.Diff(msg, gotMsg, protoCmpOpt); diff != "" {
+		t.Errorf("wrong result\n%s", diff)
+	}
+}
+
+func TestMapKeyAttrErrors(t *testing.T) {
+	desc := testschema.File_testschema_proto.Messages().ByName("WithInvalidMapKeyAttr")
+	_, err := GetFieldElem(desc.Fields().Get(0))
+	if err == nil {
+		t.Fatalf("unexpected success")
+	}
+	want := `unsupported protobuf schema in hcl.testschema.WithInvalidMapKeyAttr.pets: invalid (hcl.block).map_key_attr: attribute "count" must be of type string`
+	if got := err.Error(); got != want {
+		t.Errorf("wrong error\ngot:  %s\nwant: %s", got, want)
+	}
+}
@@ -2,11 +2,15 @@ package protohcl
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/hashicorp/hcl/v2"
 	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
 	"github.com/zclconf/go-ctypb/ctystructpb"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
 	"google.golang.org/protobuf/types/known/structpb"
 )
 
@@ -18,18 +22,18 @@ var nilProtoValue protoreflect.Value = protoreflect.ValueOf(nil)
 // that is either a single message, a list of messages, or a map from
 // strings to messages.
 //
-// May return schemaError or attrValueError errors to represent invalid schema
+// May return schemaError or AttributeValueError errors to represent invalid schema
 // or invalid user input respectively. In the absense of errors, the returned
 // value might be the invalid nilProtoValue to represent that this field should
 // just be cleared and not actually populated at all.
-func valueForMessageField(v cty.Value, attr FieldAttribute, parentMessage protoreflect.Message) (protoreflect.Value, error) {
+func valueForMessageField(v cty.Value, attr FieldAttribute, parentMessage protoreflect.Message, anyTypes protoregistry.MessageTypeResolver) (protoreflect.Value, error) {
 	field := attr.TargetField
 	wantTy, diags := attr.TypeConstraint()
 	if diags.HasErrors() {
 		return nilProtoValue, schemaErrorf(field.FullName(), "invalid HCL type constraint")
 	}
 
-	builder, err := getFieldAttrMessageBuilder(field, wantTy)
+	builder, err := getFieldAttrMessageBuilder(attr, wantTy, anyTypes)
 	if err != nil {
 		return nilProtoValue, err
 	}
@@ -44,7 +48,7 @@ func isMessageField(attr FieldAttribute) bool {
 	if desc.IsMap() {
 		desc = desc.MapValue()
 	}
-	return desc.Kind() == protoreflect.MessageKind
+	return isMessageKind(desc.Kind())
 }
 
 func protoValueIsSet(pv protoreflect.Value) bool {
@@ -55,7 +59,7 @@ func protoValueIsSet(pv protoreflect.Value) bool {
 // HCL attribute value into a protobuf message.
 //
 // If an attrMessageBuilder returns an error then it should typically be
-// an attrValueError with an appropriate path, so that the caller can generate
+// an AttributeValueError with an appropriate path, so that the caller can generate
 // a helpful diagnostic message.
 type attrMessageBuilder func(v cty.Value, path cty.Path, parentMessage protoreflect.Message) (protoreflect.Value, error)
 
@@ -71,7 +75,8 @@ var structpbValueDesc = structpb.File_google_protobuf_struct_proto.Messages().By
 // strategy that tries to conform an HCL object type to an HCL-annotated
 // message type in a way that should be the opposite of what
 // ObjectValueForMessage does.
-func getFieldAttrMessageBuilder(desc protoreflect.FieldDescriptor, wantTy cty.Type) (attrMessageBuilder, error) {
+func getFieldAttrMessageBuilder(attr FieldAttribute, wantTy cty.Type, anyTypes protoregistry.MessageTypeResolver) (attrMessageBuilder, error) {
+	desc := attr.TargetField
 	elemDesc := desc
 	if desc.IsMap() {
 		if desc.MapKey().Kind() != protoreflect.StringKind {
@@ -89,25 +94,307 @@ func getFieldAttrMessageBuilder(desc protoreflect.FieldDescriptor, wantTy cty.Ty
 	switch {
 	case elemMsgType == structpbValueDesc.FullName():
 		return structpbAttrMessageBuilder(desc, wantTy)
+	case wrapperValueField(elemMsgDesc) != nil && !desc.IsList() && !desc.IsMap():
+		return wrapperAttrMessageBuilder(desc, wrapperValueField(elemMsgDesc)), nil
+	case isTimestampMessage(elemMsgDesc) && !desc.IsList() && !desc.IsMap():
+		return timestampAttrMessageBuilder(desc), nil
+	case isDurationMessage(elemMsgDesc) && !desc.IsList() && !desc.IsMap():
+		return durationAttrMessageBuilder(desc), nil
+	case attr.AnyType != "":
+		return anyAttrMessageBuilder(desc, attr.AnyType, anyTypes), nil
+	case isStructpbContainerMessage(elemMsgDesc) && !desc.IsList() && !desc.IsMap():
+		return structpbContainerAttrMessageBuilder(desc, elemMsgDesc, wantTy)
 	default:
-		// TODO: Add a fallback decoder that does the inverse of what
-		// ObjectValueForMessage does.
-		return nil, schemaErrorf(desc.FullName(), "can't decode attribute into message type %s", elemMsgType)
+		if err := validateAttrMessageDesc(elemMsgDesc); err != nil {
+			return nil, schemaErrorf(desc.FullName(), "can't decode attribute into message type %s: %w", elemMsgType, err)
+		}
+		return annotatedAttrMessageBuilder(desc, elemMsgDesc, attr.IgnoreNullInLists, anyTypes), nil
 	}
 }
 
-func structpbAttrMessageBuilder(desc protoreflect.FieldDescriptor, wantTy cty.Type) (attrMessageBuilder, error) {
+// annotatedAttrMessageBuilder is the generic strategy for message types that
+// have HCL annotations of their own, which populates the attribute-annotated
+// fields of each message from the attributes of an object value, in the
+// opposite way to ObjectValueForMessage.
+//
+// If ignoreNulls is set then a repeated field discards any null elements,
+// as for (hcl.attr).ignore_null_in_lists.
+func annotatedAttrMessageBuilder(desc protoreflect.FieldDescriptor, msgDesc protoreflect.MessageDescriptor, ignoreNulls bool, anyTypes protoregistry.MessageTypeResolver) attrMessageBuilder {
 	switch {
 	case desc.IsList():
-		if !(wantTy == cty.DynamicPseudoType || wantTy.IsListType() || wantTy.IsSetType()) {
-			return nil, schemaErrorf(desc.FullName(), "list field must have tuple, list, or set type constraint")
+		return func(v cty.Value, path cty.Path, parentMessage protoreflect.Message) (protoreflect.Value, error) {
+			if v.IsNull() {
+				return nilProtoValue, attrValueErrorf(path, "must not be null")
+			}
+			if !v.IsKnown() {
+				return nilProtoValue, attrValueErrorUnknown(path)
+			}
+			ty := v.Type()
+			if !(ty.IsListType() || ty.IsSetType() || ty.IsTupleType()) {
+				return nilProtoValue, attrValueErrorf(path, "a list, set, or tuple value is required")
+			}
+			elemVs := v.AsValueSlice()
+			if ty.IsSetType() {
+				var err error
+				elemVs, err = OrderedSetEncoding(v)
+				if err != nil {
+					return nilProtoValue, attrValueErrorWrap(path, err)
+				}
+			}
+			l := parentMessage.NewField(desc).List()
+			for i, elemV := range elemVs {
+				if ignoreNulls && elemV.IsNull() {
+					continue
+				}
+				if ignoreNulls && elemV.IsNull() {
+					continue
+				}
+				path := append(path, cty.IndexStep{Key: cty.NumberIntVal(int64(i))})
+				msg, err := messageForObjectValue(elemV, msgDesc, path, anyTypes)
+				if err != nil {
+					return nilProtoValue, err
+				}
+				l.Append(protoreflect.ValueOfMessage(msg))
+			}
+			return protoreflect.ValueOfList(l), nil
 		}
+	case desc.IsMap():
 		return func(v cty.Value, path cty.Path, parentMessage protoreflect.Message) (protoreflect.Value, error) {
 			if v.IsNull() {
 				return nilProtoValue, attrValueErrorf(path, "must not be null")
 			}
 			if !v.IsKnown() {
-				return nilProtoValue, attrValueErrorf(path, "value must be known")
+				return nilProtoValue, attrValueErrorUnknown(path)
+			}
+			ty := v.Type()
+			if !(ty.IsObjectType() || ty.IsMapType()) {
+				return nilProtoValue, attrValueErrorf(path, "an object or map value is required")
+			}
+			m := parentMessage.NewField(desc).Map()
+			for it := v.ElementIterator(); it.Next(); {
+				elemKV, elemV := it.Element()
+				path := append(path, cty.IndexStep{Key: elemKV})
+				msg, err := messageForObjectValue(elemV, msgDesc, path, anyTypes)
+				if err != nil {
+					return nilProtoValue, err
+				}
+				m.Set(protoreflect.ValueOfString(elemKV.AsString()).MapKey(), protoreflect.ValueOfMessage(msg))
+			}
+			return protoreflect.ValueOfMap(m), nil
+		}
+	default:
+		return func(v cty.Value, path cty.Path, parentMessage protoreflect.Message) (protoreflect.Value, error) {
+			if v.IsNull() {
+				// A null value just leaves the field unset.
+				return nilProtoValue, nil
+			}
+			msg, err := messageForObjectValue(v, msgDesc, path, anyTypes)
+			if err != nil {
+				return nilProtoValue, err
+			}
+			return protoreflect.ValueOfMessage(msg), nil
+		}
+	}
+}
+
+// MessageForObjectValue constructs a new message of the type that the given
+// descriptor describes, populating its attribute-annotated fields from the
+// attributes of the given object value, in the opposite way to
+// ObjectValueForMessage.
+//
+// The message type must be one that's valid as the type of an attribute,
+// declaring only attributes, possibly via flattened messages; decode
+// messages with nested blocks using ValueDecoder instead.
+//
+// If the value isn't suitable for the message type then the error is an
+// AttributeValueError describing which part of the value is unsuitable.
+func MessageForObjectValue(v cty.Value, desc protoreflect.MessageDescriptor) (proto.Message, error) {
+	if err := validateAttrMessageDesc(desc); err != nil {
+		return nil, schemaError{Decl: desc.FullName(), Err: err}
+	}
+	msg, err := messageForObjectValue(v, desc, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return msg.Interface(), nil
+}
+
+// messageForObjectValue constructs a new message of the given type whose
+// attribute-annotated fields are populated from the attributes of the given
+// object value.
+func messageForObjectValue(v cty.Value, desc protoreflect.MessageDescriptor, path cty.Path, anyTypes protoregistry.MessageTypeResolver) (protoreflect.Message, error) {
+	if v.IsNull() {
+		return nil, attrValueErrorf(path, "must not be null")
+	}
+	if !v.IsKnown() {
+		return nil, attrValueErrorUnknown(path)
+	}
+	if ty := v.Type(); !(ty.IsObjectType() || ty.IsMapType()) {
+		return nil, attrValueErrorf(path, "an object value is required")
+	}
+
+	msg := newMessageMaybeDynamic(desc)
+	err := fillMessageFromObjectValue(v, msg, FieldFlattened{}, path, anyTypes)
+	if err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+// fillMessageFromObjectValue populates the attribute-annotated fields of the
+// given message from the given object value. For a message flattened into
+// another, outer is the element it was flattened in through.
+func fillMessageFromObjectValue(v cty.Value, msg protoreflect.Message, outer FieldFlattened, path cty.Path, anyTypes protoregistry.MessageTypeResolver) error {
+	fields := msg.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		elem, err := GetFieldElem(field)
+		if err != nil {
+			return err
+		}
+
+		switch elem := flattenedElem(elem, outer).(type) {
+		case FieldAttribute:
+			err := fillMessageFieldFromObjectValue(v, msg, elem, path, anyTypes)
+			if err != nil {
+				return attrValueErrorInField(err, field.FullName())
+			}
+
+		case FieldFlattened:
+			nestedMsg := newMessageMaybeDynamic(elem.Nested)
+			err := fillMessageFromObjectValue(v, nestedMsg, elem, path, anyTypes)
+			if err != nil {
+				return err
+			}
+			msg.Set(field, protoreflect.ValueOfMessage(nestedMsg))
+		}
+	}
+	return nil
+}
+
+// fillMessageFieldFromObjectValue populates the field for the given attribute
+// from the corresponding attribute of the given object value, if present.
+func fillMessageFieldFromObjectValue(v cty.Value, msg protoreflect.Message, elem FieldAttribute, path cty.Path, anyTypes protoregistry.MessageTypeResolver) error {
+	field := elem.TargetField
+	av := cty.NullVal(cty.DynamicPseudoType)
+	if ty := v.Type(); ty.IsObjectType() && ty.HasAttribute(elem.Name) {
+		av = v.GetAttr(elem.Name)
+	} else if ty.IsMapType() && v.HasIndex(cty.StringVal(elem.Name)).True() {
+		av = v.Index(cty.StringVal(elem.Name))
+	}
+	attrPath := append(path, cty.GetAttrStep{Name: elem.Name})
+
+	wantTy, diags := elem.TypeConstraint()
+	if diags.HasErrors() {
+		return schemaErrorf(field.FullName(), "invalid HCL type constraint")
+	}
+	av, err := parseNumberSyntax(av, elem.NumberSyntax)
+	if err != nil {
+		return attrValueErrorWrap(attrPath, err)
+	}
+	av, err = convert.Convert(av, wantTy)
+	if err != nil {
+		return attrValueErrorWrap(attrPath, err)
+	}
+	av = emptyAsNull(av, elem)
+	if av.IsNull() {
+		if elem.Required {
+			return attrValueErrorf(path, "attribute %q is required", elem.Name)
+		}
+		if raw := rawTypedNull(av, elem); raw != nil {
+			msg.Set(field, protoreflect.ValueOfBytes(raw))
+		}
+		return nil
+	}
+
+	if isMessageField(elem) {
+		builder, err := getFieldAttrMessageBuilder(elem, wantTy, anyTypes)
+		if err != nil {
+			return err
+		}
+		protoVal, err := builder(av, attrPath, msg)
+		if err != nil {
+			return err
+		}
+		if protoValueIsSet(protoVal) {
+			msg.Set(field, protoVal)
+		}
+		return nil
+	}
+
+	if elem.Unit != "" {
+		av, err = parseUnitValue(av, elem.Unit)
+		if err != nil {
+			return attrValueErrorWrap(attrPath, err)
+		}
+	}
+	if field.IsList() && av.Type().IsSetType() && av.IsKnown() {
+		elems, err := OrderedSetEncoding(av)
+		if err != nil {
+			return attrValueErrorWrap(attrPath, err)
+		}
+		av = cty.TupleVal(elems)
+	}
+	if field.IsList() {
+		av, _, err = discardNullElements(av, valueSourceRanges{}, elem)
+		if err != nil {
+			return attrValueErrorWrap(attrPath, err)
+		}
+	}
+	needTy, err := valuePhysicalConstraintForFieldKind(av.Type(), field)
+	if err != nil {
+		return err
+	}
+	av, err = convert.Convert(av, needTy)
+	if err != nil {
+		return attrValueErrorWrap(attrPath, err)
+	}
+	protoVal, diags := protoValueForField(av, valueSourceRanges{}, msg, field, DecodeOptions{})
+	for _, diag := range diags {
+		if diag.Severity == hcl.DiagError {
+			err := attrValueErrorf(attrPath, "%s", strings.TrimSuffix(diag.Detail, "."))
+			err.unknown = DiagnosticCategoryOf(diag) == DiagnosticUnknownValue
+			return err
+		}
+	}
+	msg.Set(field, protoVal)
+	return nil
+}
+
+// checkStructpbTypeConstraint returns a schema error if the given type
+// constraint is not suitable for the given field, whose element type must
+// be google.protobuf.Value.
+//
+// A single google.protobuf.Value can represent a value of any type, but a
+// repeated field or a map field can only represent a collection of values,
+// and so the type constraint must agree.
+func checkStructpbTypeConstraint(desc protoreflect.FieldDescriptor, wantTy cty.Type) error {
+	switch {
+	case desc.IsList():
+		if !(wantTy == cty.DynamicPseudoType || wantTy.IsListType() || wantTy.IsSetType() || wantTy.IsTupleType()) {
+			return schemaErrorf(desc.FullName(), "list field must have tuple, list, or set type constraint")
+		}
+	case desc.IsMap():
+		if !(wantTy == cty.DynamicPseudoType || wantTy.IsObjectType() || wantTy.IsMapType()) {
+			return schemaErrorf(desc.FullName(), "map field must have object or map type constraint")
+		}
+	}
+	return nil
+}
+
+func structpbAttrMessageBuilder(desc protoreflect.FieldDescriptor, wantTy cty.Type) (attrMessageBuilder, error) {
+	if err := checkStructpbTypeConstraint(desc, wantTy); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case desc.IsList():
+		return func(v cty.Value, path cty.Path, parentMessage protoreflect.Message) (protoreflect.Value, error) {
+			if v.IsNull() {
+				return nilProtoValue, attrValueErrorf(path, "must not be null")
+			}
+			if !v.IsKnown() {
+				return nilProtoValue, attrValueErrorUnknown(path)
 			}
 			ty := v.Type()
 			if !(ty.IsListType() || ty.IsSetType() || ty.IsTupleType()) {
@@ -120,25 +407,18 @@ func structpbAttrMessageBuilder(desc protoreflect.FieldDescriptor, wantTy cty.Ty
 					return nilProtoValue, attrValueErrorf(path, "wrong number of elements (need %d)", wantLen)
 				}
 			}
-			l := parentMessage.NewField(desc).List()
-			i := 0
-			for it := v.ElementIterator(); it.Next(); i++ {
-				_, elemV := it.Element()
-
-				var wantEty cty.Type
-				switch {
-				case wantTy == cty.DynamicPseudoType:
-					wantEty = cty.DynamicPseudoType
-				case wantTy.IsCollectionType():
-					wantEty = wantTy.ElementType()
-				case wantTy.IsTupleType():
-					wantEty = wantTy.TupleElementType(i) // we checked that value length matches tuple type length above
-				default:
-					// Shouldn't get here because we already filtered wantTy above
-					panic(fmt.Sprintf("unhandled type %#v", wantTy))
+			elemVs := v.AsValueSlice()
+			if ty.IsSetType() {
+				var err error
+				elemVs, err = OrderedSetEncoding(v)
+				if err != nil {
+					return nilProtoValue, attrValueErrorWrap(path, err)
 				}
-
-				protoElem, err := ctystructpb.ToStructValue(elemV, wantEty)
+			}
+			l := parentMessage.NewField(desc).List()
+			for i, elemV := range elemVs {
+				path := append(path, cty.IndexStep{Key: cty.NumberIntVal(int64(i))})
+				protoElem, err := ctystructpb.ToStructValue(elemV, structpbListElementType(wantTy, i))
 				if err != nil {
 					return nilProtoValue, attrValueErrorWrap(path, err)
 				}
@@ -147,15 +427,12 @@ func structpbAttrMessageBuilder(desc protoreflect.FieldDescriptor, wantTy cty.Ty
 			return protoreflect.ValueOfList(l), nil
 		}, nil
 	case desc.IsMap():
-		if !(wantTy == cty.DynamicPseudoType || wantTy.IsObjectType() || wantTy.IsMapType()) {
-			return nil, schemaErrorf(desc.FullName(), "map field must have object or map type constraint")
-		}
 		return func(v cty.Value, path cty.Path, parentMessage protoreflect.Message) (protoreflect.Value, error) {
 			if v.IsNull() {
 				return nilProtoValue, attrValueErrorf(path, "must not be null")
 			}
 			if !v.IsKnown() {
-				return nilProtoValue, attrValueErrorf(path, "value must be known")
+				return nilProtoValue, attrValueErrorUnknown(path)
 			}
 			ty := v.Type()
 			if !(ty.IsObjectType() || ty.IsMapType()) {
@@ -165,23 +442,11 @@ func structpbAttrMessageBuilder(desc protoreflect.FieldDescriptor, wantTy cty.Ty
 			for it := v.ElementIterator(); it.Next(); {
 				elemKV, elemV := it.Element()
 				elemK := elemKV.AsString()
-
-				var wantEty cty.Type
-				switch {
-				case wantTy == cty.DynamicPseudoType:
-					wantEty = cty.DynamicPseudoType
-				case wantTy.IsMapType():
-					wantEty = wantTy.ElementType()
-				case wantTy.IsObjectType():
-					if !wantTy.HasAttribute(elemK) {
-						return nilProtoValue, attrValueErrorf(path, "does not expect an attribute named %q", elemK)
-					}
-					wantEty = wantTy.AttributeType(elemK)
-				default:
-					// Shouldn't get here because we already filtered wantTy above
-					panic(fmt.Sprintf("unhandled type %#v", wantTy))
+				wantEty, ok := structpbMapElementType(wantTy, elemK)
+				if !ok {
+					return nilProtoValue, attrValueErrorf(path, "does not expect an attribute named %q", elemK)
 				}
-
+				path := append(path, cty.IndexStep{Key: elemKV})
 				protoElem, err := ctystructpb.ToStructValue(elemV, wantEty)
 				if err != nil {
 					return nilProtoValue, attrValueErrorWrap(path, err)
@@ -194,76 +459,187 @@ func structpbAttrMessageBuilder(desc protoreflect.FieldDescriptor, wantTy cty.Ty
 		return func(v cty.Value, path cty.Path, parentMessage protoreflect.Message) (protoreflect.Value, error) {
 			sv, err := ctystructpb.ToStructValue(v, wantTy)
 			if err != nil {
-				return nilProtoValue, err
+				return nilProtoValue, attrValueErrorWrap(path, err)
 			}
 			return protoreflect.ValueOfMessage(sv.ProtoReflect()), nil
 		}, nil
 	}
 }
 
-// attrValueError is an error type used for any situation where a given value
-// isn't suitable for the context where it's used.
+// structpbListElementType returns the type constraint for the element at
+// the given index of a repeated google.protobuf.Value field whose attribute
+// has the given type constraint, which must be a list, set, or tuple type
+// or cty.DynamicPseudoType. For a tuple type, the index must be in range.
+func structpbListElementType(wantTy cty.Type, i int) cty.Type {
+	switch {
+	case wantTy.IsCollectionType():
+		return wantTy.ElementType()
+	case wantTy.IsTupleType():
+		return wantTy.TupleElementType(i)
+	default:
+		return cty.DynamicPseudoType
+	}
+}
+
+// structpbMapElementType returns the type constraint for the element with
+// the given key of a map of google.protobuf.Value field whose attribute has
+// the given type constraint, which must be a map or object type or
+// cty.DynamicPseudoType. It returns false if the type constraint is an
+// object type that doesn't have an attribute with that name.
+func structpbMapElementType(wantTy cty.Type, k string) (cty.Type, bool) {
+	switch {
+	case wantTy.IsMapType():
+		return wantTy.ElementType(), true
+	case wantTy.IsObjectType():
+		if !wantTy.HasAttribute(k) {
+			return cty.NilType, false
+		}
+		return wantTy.AttributeType(k), true
+	default:
+		return cty.DynamicPseudoType, true
+	}
+}
+
+// AttributeValueError is the error type that protohcl uses to report that
+// a value isn't suitable for the message field it's being decoded into, such
+// as from MessageForObjectValue.
+//
+// An AttributeValueError is always a problem with user input, and never a
+// bug in the program. (If it _is_ a bug in the program, then it's a bug in
+// protohcl that it was misclassified!)
 //
-// A attrValueError is always a problem with user input, and never a bug in
-// the program. (If it _is_ a bug in the program, then it's a bug in protohcl
-// that it was misclassified!)
-type attrValueError struct {
-	Err cty.PathError
+// The decoder presents these errors as diagnostics, but a host that's
+// logging them elsewhere can use the methods of this type to describe
+// exactly which value was unsuitable.
+type AttributeValueError struct {
+	path    cty.Path
+	field   protoreflect.FullName
+	err     error
+	unknown bool
 }
 
-func attrValueErrorf(path cty.Path, format string, args ...interface{}) attrValueError {
-	return attrValueError{
-		Err: path.NewErrorf(format, args...).(cty.PathError),
+func attrValueErrorf(path cty.Path, format string, args ...interface{}) AttributeValueError {
+	return AttributeValueError{
+		path: copyCtyPath(path),
+		err:  fmt.Errorf(format, args...),
 	}
 }
 
-func attrValueErrorWrap(path cty.Path, err error) attrValueError {
+// attrValueErrorUnknown returns an error reporting that the value at the
+// given path isn't known, which the decoder reports as a diagnostic in the
+// DiagnosticUnknownValue category.
+func attrValueErrorUnknown(path cty.Path) AttributeValueError {
+	err := attrValueErrorf(path, "value must be known")
+	err.unknown = true
+	return err
+}
+
+func attrValueErrorWrap(path cty.Path, err error) AttributeValueError {
 	switch err := err.(type) {
+	case AttributeValueError:
+		err.path = append(copyCtyPath(path), err.path...)
+		return err
 	case cty.PathError:
-		err = path.NewError(err).(cty.PathError)
-		return attrValueError{Err: err}
+		return AttributeValueError{
+			path: append(copyCtyPath(path), err.Path...),
+			err:  err,
+		}
 	default:
-		return attrValueError{Err: path.NewError(err).(cty.PathError)}
+		return AttributeValueError{
+			path: copyCtyPath(path),
+			err:  err,
+		}
 	}
 }
 
-func (err attrValueError) Error() string {
-	return err.Error()
+// attrValueErrorInField returns a copy of the given error annotated with
+// the given field name, if it is an AttributeValueError that isn't already
+// annotated with a more specific field. Otherwise, returns the given error
+// verbatim.
+func attrValueErrorInField(err error, field protoreflect.FullName) error {
+	if err, ok := err.(AttributeValueError); ok && err.field == "" {
+		err.field = field
+		return err
+	}
+	return err
+}
+
+// Path returns the path to the unsuitable value, relative to the value that
+// was being decoded.
+//
+// When decoding an attribute of a body, the path is relative to the value
+// of the attribute, and so is empty if the attribute's value is itself
+// unsuitable.
+func (err AttributeValueError) Path() cty.Path {
+	return err.path
 }
 
-func (err attrValueError) Unwrap() error {
-	return err.Err
+// FieldName returns the fully-qualified name of the field that the
+// unsuitable value was to be decoded into, or of the field that's missing
+// for an error about a required attribute.
+func (err AttributeValueError) FieldName() protoreflect.FullName {
+	return err.field
 }
 
-func (err attrValueError) Diagnostic() *hcl.Diagnostic {
+// Error returns a description of the problem, prefixed by the path to the
+// unsuitable value as formatted by FormatCtyPath if the path isn't empty.
+func (err AttributeValueError) Error() string {
+	if len(err.path) == 0 {
+		return err.err.Error()
+	}
+	return FormatCtyPath(err.path) + ": " + err.err.Error()
+}
+
+// Unwrap returns the underlying error that describes the problem, without
+// the path.
+func (err AttributeValueError) Unwrap() error {
+	return err.err
+}
+
+// Diagnostic returns a diagnostic describing the error as a problem with
+// the value of the given attribute, which was evaluated in the given
+// evaluation context, formatting the path using the given options.
+func (err AttributeValueError) Diagnostic(attr *hcl.Attribute, ctx *hcl.EvalContext, opts DecodeOptions) *hcl.Diagnostic {
 	var detail string
-	if len(err.Err.Path) == 0 {
+	if len(err.path) == 0 {
 		// The top-level attribute value is wrong.
-		detail = fmt.Sprintf("Inappropriate value for argument: %s.", err.Err.Error())
+		detail = fmt.Sprintf("Inappropriate value for attribute %q: %s.", attr.Name, err.err.Error())
 	} else {
-		detail = fmt.Sprintf("Inappropriate value for %s: %s.", formatCtyPath(err.Err.Path), err.Err.Error())
+		detail = fmt.Sprintf("Inappropriate value for attribute %q at %s: %s.", attr.Name, opts.formatPath(err.path), err.err.Error())
+	}
+	category := DiagnosticValueError
+	if err.unknown {
+		category = DiagnosticUnknownValue
 	}
 	return &hcl.Diagnostic{
-		Severity: hcl.DiagError,
-		Summary:  unsuitableValueSummary,
-		Detail:   detail,
+		Severity:    hcl.DiagError,
+		Summary:     unsuitableValueSummary,
+		Detail:      detail,
+		Subject:     attr.Expr.Range().Ptr(),
+		Context:     hcl.RangeBetween(attr.NameRange, attr.Expr.Range()).Ptr(),
+		Expression:  attr.Expr,
+		EvalContext: ctx,
+		Extra:       diagnosticExtra{Category: category},
 	}
 }
 
-func attrErrorDiagnostic(err error) *hcl.Diagnostic {
+// attrErrorDiagnostic returns a diagnostic describing an error from decoding
+// the value of the given attribute into a message-typed field.
+func attrErrorDiagnostic(err error, attr *hcl.Attribute, ctx *hcl.EvalContext, opts DecodeOptions) *hcl.Diagnostic {
 	switch err := err.(type) {
 	case schemaError:
 		return err.Diagnostic()
-	case attrValueError:
-		return err.Diagnostic()
+	case AttributeValueError:
+		return err.Diagnostic(attr, ctx, opts)
 	default:
-		return &hcl.Diagnostic{
-			Severity: hcl.DiagError,
-			Summary:  unsuitableValueSummary,
-			Detail: fmt.Sprintf(
-				"Inappropriate value for argument: %s.",
-				err.Error(),
-			),
-		}
+		return attrValueErrorWrap(nil, err).Diagnostic(attr, ctx, opts)
 	}
 }
+
+// copyCtyPath returns a copy of the given path, because callers often build
+// paths by repeatedly appending to the same underlying buffer.
+func copyCtyPath(path cty.Path) cty.Path {
+	ret := make(cty.Path, len(path))
+	copy(ret, path)
+	return ret
+}
@@ -0,0 +1,312 @@
+package protohcl
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/apparentlymart/go-protohcl/protohcl/internal/testschema"
+	"github.com/google/go-cmp/cmp"
+	"github.com/zclconf/go-cty/cty"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func TestMessageForObjectValue(t *testing.T) {
+	tests := map[string]struct {
+		msgName protoreflect.Name
+		val     cty.Value
+		want    proto.Message
+	}{
+		"attributes": {
+			"WithOptionalAttrs",
+			cty.ObjectVal(map[string]cty.Value{
+				"name":  cty.StringVal("Jackson"),
+				"count": cty.NumberIntVal(2),
+			}),
+			&testschema.WithOptionalAttrs{
+				Name:  "Jackson",
+				Count: 2,
+			},
+		},
+		"map of messages": {
+			"WithMsgTypeConstraints",
+			cty.ObjectVal(map[string]cty.Value{
+				"by_key": cty.MapVal(map[string]cty.Value{
+					"dog": cty.ObjectVal(map[string]cty.Value{
+						"name": cty.StringVal("Fido"),
+					}),
+				}),
+			}),
+			&testschema.WithMsgTypeConstraints{
+				ByKey: map[string]*testschema.WithOptionalAttrs{
+					"dog": {Name: "Fido"},
+				},
+			},
+		},
+		"map of messages with inferred type": {
+			"WithMapOfObjectsAttr",
+			cty.ObjectVal(map[string]cty.Value{
+				"pets": cty.MapVal(map[string]cty.Value{
+					"dog": cty.ObjectVal(map[string]cty.Value{
+						"name": cty.StringVal("Fido"),
+					}),
+				}),
+			}),
+			&testschema.WithMapOfObjectsAttr{
+				Pets: map[string]*testschema.WithStringAttr{
+					"dog": {Name: "Fido"},
+				},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			desc := testschema.File_testschema_proto.Messages().ByName(test.msgName)
+			got, err := MessageForObjectValue(test.val, desc)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(test.want, got, protoCmpOpt); diff != "" {
+				t.Errorf("wrong result\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMessageForObjectValueErrors(t *testing.T) {
+	tests := map[string]struct {
+		msgName   protoreflect.Name
+		val       cty.Value
+		wantErr   string
+		wantPath  cty.Path
+		wantField protoreflect.FullName
+		wantCause string
+	}{
+		"not an object": {
+			"WithOptionalAttrs",
+			cty.StringVal("hello"),
+			`an object value is required`,
+			cty.Path{},
+			"",
+			`an object value is required`,
+		},
+		"missing required attribute": {
+			"WithOptionalAttrs",
+			cty.EmptyObjectVal,
+			`attribute "name" is required`,
+			cty.Path{},
+			"hcl.testschema.WithOptionalAttrs.name",
+			`attribute "name" is required`,
+		},
+		"wrong type": {
+			"WithOptionalAttrs",
+			cty.ObjectVal(map[string]cty.Value{
+				"name":  cty.StringVal("Jackson"),
+				"count": cty.StringVal("many"),
+			}),
+			`.count: a number is required`,
+			cty.GetAttrPath("count"),
+			"hcl.testschema.WithOptionalAttrs.count",
+			`a number is required`,
+		},
+		"nested message": {
+			"WithMsgTypeConstraints",
+			cty.ObjectVal(map[string]cty.Value{
+				"items": cty.ListVal([]cty.Value{
+					cty.ObjectVal(map[string]cty.Value{
+						"name":  cty.NullVal(cty.String),
+						"count": cty.NumberIntVal(1),
+					}),
+				}),
+			}),
+			`.items[0]: attribute "name" is required`,
+			cty.GetAttrPath("items").IndexInt(0),
+			"hcl.testschema.WithOptionalAttrs.name",
+			`attribute "name" is required`,
+		},
+		"nested message with inferred type": {
+			"WithListOfObjectsAttr",
+			cty.ObjectVal(map[string]cty.Value{
+				"items": cty.ListVal([]cty.Value{
+					cty.ObjectVal(map[string]cty.Value{
+						"name":  cty.NullVal(cty.String),
+						"count": cty.NumberIntVal(1),
+					}),
+				}),
+			}),
+			`.items[0]: attribute "name" is required`,
+			cty.GetAttrPath("items").IndexInt(0),
+			"hcl.testschema.WithOptionalAttrs.name",
+			`attribute "name" is required`,
+		},
+		"nested message conversion": {
+			"WithMapOfObjectsAttr",
+			cty.ObjectVal(map[string]cty.Value{
+				"pets": cty.MapVal(map[string]cty.Value{
+					"dog": cty.ObjectVal(map[string]cty.Value{
+						"name": cty.ListValEmpty(cty.String),
+					}),
+				}),
+			}),
+			`.pets: incorrect map element type: attribute "name": string required`,
+			cty.GetAttrPath("pets"),
+			"hcl.testschema.WithMapOfObjectsAttr.pets",
+			`incorrect map element type: attribute "name": string required`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			desc := testschema.File_testschema_proto.Messages().ByName(test.msgName)
+			_, err := MessageForObjectValue(test.val, desc)
+			if err == nil {
+				t.Fatalf("unexpected success")
+			}
+			if got, want := err.Error(), test.wantErr; got != want {
+				t.Errorf("wrong error\ngot:  %s\nwant: %s", got, want)
+			}
+
+			var valErr AttributeValueError
+			if !errors.As(err, &valErr) {
+				t.Fatalf("error is %T, not AttributeValueError", err)
+			}
+			if got, want := valErr.Path(), test.wantPath; !got.Equals(want) {
+				t.Errorf("wrong path\ngot:  %#v\nwant: %#v", got, want)
+			}
+			if got, want := valErr.FieldName(), test.wantField; got != want {
+				t.Errorf("wrong field name\ngot:  %s\nwant: %s", got, want)
+			}
+			if got, want := errors.Unwrap(err).Error(), test.wantCause; got != want {
+				t.Errorf("wrong cause\ngot:  %s\nwant: %s", got, want)
+			}
+		})
+	}
+}
+
+func TestMessageForObjectValueSchemaError(t *testing.T) {
+	desc := testschema.File_testschema_proto.Messages().ByName("WithNestedBlockNoLabelsSingleton")
+	_, err := MessageForObjectValue(cty.EmptyObjectVal, desc)
+	if err == nil {
+		t.Fatalf("unexpected success")
+	}
+	if errors.As(err, &AttributeValueError{}) {
+		t.Errorf("schema problem reported as AttributeValueError")
+	}
+}
+
+func TestAttributeValueError(t *testing.T) {
+	tests := map[string]struct {
+		err       error
+		wantErr   string
+		wantPath  cty.Path
+		wantField protoreflect.FullName
+		wantCause string
+	}{
+		"no path": {
+			attrValueErrorf(nil, "must not be null"),
+			`must not be null`,
+			cty.Path{},
+			"",
+			`must not be null`,
+		},
+		"with path": {
+			attrValueErrorf(cty.GetAttrPath("count"), "a number is required"),
+			`.count: a number is required`,
+			cty.GetAttrPath("count"),
+			"",
+			`a number is required`,
+		},
+		"wrapped path error": {
+			attrValueErrorWrap(cty.GetAttrPath("items"), cty.IndexIntPath(0).NewErrorf("a string is required")),
+			`.items[0]: a string is required`,
+			cty.GetAttrPath("items").IndexInt(0),
+			"",
+			`a string is required`,
+		},
+		"nested": {
+			attrValueErrorWrap(cty.GetAttrPath("pets"), attrValueErrorf(cty.IndexStringPath("dog"), "must be known")),
+			`.pets["dog"]: must be known`,
+			cty.GetAttrPath("pets").IndexString("dog"),
+			"",
+			`must be known`,
+		},
+		"in field": {
+			attrValueErrorInField(
+				attrValueErrorf(cty.GetAttrPath("name"), "a string is required"),
+				"hcl.testschema.WithStringAttr.name",
+			),
+			`.name: a string is required`,
+			cty.GetAttrPath("name"),
+			"hcl.testschema.WithStringAttr.name",
+			`a string is required`,
+		},
+		"in more specific field": {
+			attrValueErrorInField(
+				attrValueErrorInField(
+					attrValueErrorf(nil, "a string is required"),
+					"hcl.testschema.WithStringAttr.name",
+				),
+				"hcl.testschema.WithMapOfObjectsAttr.pets",
+			),
+			`a string is required`,
+			cty.Path{},
+			"hcl.testschema.WithStringAttr.name",
+			`a string is required`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := test.err
+			if got, want := err.Error(), test.wantErr; got != want {
+				t.Errorf("wrong error\ngot:  %s\nwant: %s", got, want)
+			}
+
+			var valErr AttributeValueError
+			if !errors.As(err, &valErr) {
+				t.Fatalf("error is %T, not AttributeValueError", err)
+			}
+			if got, want := valErr.Path(), test.wantPath; !got.Equals(want) {
+				t.Errorf("wrong path\ngot:  %#v\nwant: %#v", got, want)
+			}
+			if got, want := valErr.FieldName(), test.wantField; got != want {
+				t.Errorf("wrong field name\ngot:  %s\nwant: %s", got, want)
+			}
+			if got, want := errors.Unwrap(err).Error(), test.wantCause; got != want {
+				t.Errorf("wrong cause\ngot:  %s\nwant: %s", got, want)
+			}
+		})
+	}
+}
+
+func TestAttributeValueErrorFromBuilder(t *testing.T) {
+	desc := testschema.File_testschema_proto.Messages().ByName("StructHolder")
+	elem, err := GetFieldElem(desc.Fields().ByName("tuple"))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	builder, err := getFieldAttrMessageBuilder(elem.(FieldAttribute), cty.Tuple([]cty.Type{cty.String, cty.Bool}), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	_, err = builder(
+		cty.TupleVal([]cty.Value{cty.StringVal("a")}),
+		cty.GetAttrPath("tuple"),
+		newMessageMaybeDynamic(desc),
+	)
+	if err == nil {
+		t.Fatalf("unexpected success")
+	}
+	var valErr AttributeValueError
+	if !errors.As(err, &valErr) {
+		t.Fatalf("error is %T, not AttributeValueError", err)
+	}
+	if got, want := valErr.Path(), cty.GetAttrPath("tuple"); !got.Equals(want) {
+		t.Errorf("wrong path\ngot:  %#v\nwant: %#v", got, want)
+	}
+	if got, want := err.Error(), `.tuple: wrong number of elements (need 2)`; got != want {
+		t.Errorf("wrong error\ngot:  %s\nwant: %s", got, want)
+	}
+}
@@ -0,0 +1,217 @@
+package protohcl
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/apparentlymart/go-protohcl/protohcl/protohclext"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// NormalizeMessage modifies the given message in-place so that its
+// HCL-annotated fields are in a canonical form with respect to the HCL
+// semantics described by its schema.
+//
+// Two messages that would produce equal results from ObjectValueForMessage
+// should, after normalization, also be equal under proto.Equal, as long as
+// they don't differ in any fields that are not HCL-annotated. In particular,
+// normalization:
+//   - sorts and removes duplicates from the elements of repeated fields
+//     representing attributes with set type constraints, and from the
+//     blocks of nested block types with (hcl.block).kind = SET;
+//   - rewrites numbers stored in string fields into their shortest
+//     decimal representation;
+//   - re-encodes the values of raw-mode fields, which canonicalizes
+//     details such as JSON object property ordering and whitespace.
+//
+// Fields whose element type is a message type, such as google.protobuf.Value,
+// are left unchanged. NormalizeMessage doesn't modify fields that aren't
+// HCL-annotated.
+//
+// If NormalizeMessage returns an error then the message may have been
+// partially modified.
+func NormalizeMessage(msg proto.Message) error {
+	reflectMsg := msg.ProtoReflect()
+	path := make(cty.Path, 0, 8) // allow a bit of nesting before we allocate again
+
+	return normalizeMessage(reflectMsg, FieldFlattened{}, path)
+}
+
+func normalizeMessage(msg protoreflect.Message, outer FieldFlattened, path cty.Path) error {
+	fields := msg.Descriptor().Fields()
+
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+
+		elem, err := GetFieldElem(field)
+		if err != nil {
+			return err
+		}
+
+		switch elem := flattenedElem(elem, outer).(type) {
+		case FieldAttribute:
+			path := append(path, cty.GetAttrStep{Name: elem.Name})
+			err := normalizeAttributeField(msg, elem, path)
+			if err != nil {
+				return err
+			}
+
+		case FieldNestedBlockType:
+			path := append(path, cty.GetAttrStep{Name: elem.TypeName})
+			if elem.CollectionKind == protohclext.NestedBlock_MAP {
+				m := msg.Mutable(field).Map()
+				var err error
+				m.Range(func(k protoreflect.MapKey, v protoreflect.Value) bool {
+					path := append(path, cty.IndexStep{Key: cty.StringVal(k.String())})
+					err = normalizeMessage(v.Message(), FieldFlattened{}, path)
+					return err == nil
+				})
+				if err != nil {
+					return err
+				}
+				continue
+			}
+			if !elem.Repeated {
+				if msg.Has(field) {
+					err := normalizeMessage(msg.Mutable(field).Message(), FieldFlattened{}, path)
+					if err != nil {
+						return err
+					}
+				}
+				continue
+			}
+
+			list := msg.Mutable(field).List()
+			for i := 0; i < list.Len(); i++ {
+				path := append(path, cty.IndexStep{Key: cty.NumberIntVal(int64(i))})
+				err := normalizeMessage(list.Get(i).Message(), FieldFlattened{}, path)
+				if err != nil {
+					return err
+				}
+			}
+			if elem.CollectionKind == protohclext.NestedBlock_SET {
+				err := normalizeSetBlocks(list, path)
+				if err != nil {
+					return err
+				}
+			}
+
+		case FieldFlattened:
+			if msg.Has(field) {
+				err := normalizeMessage(msg.Mutable(field).Message(), elem, path)
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// normalizeAttributeField normalizes the value of an attribute field by
+// converting it to an HCL value, as ObjectValueForMessage would, and then
+// encoding that HCL value back into the field.
+func normalizeAttributeField(msg protoreflect.Message, elem FieldAttribute, path cty.Path) error {
+	field := elem.TargetField
+	if !msg.Has(field) || isMessageField(elem) {
+		return nil
+	}
+
+	v, err := ObjectValueOptions{}.hclValueForProtoFieldValue(msg.Get(field), path, elem, false)
+	if err != nil {
+		return err
+	}
+	if elem.Unit != "" {
+		v, err = formatUnitValue(v, elem.Unit)
+		if err != nil {
+			return path.NewError(err)
+		}
+	}
+	v, err = parseNumberSyntax(v, elem.NumberSyntax)
+	if err != nil {
+		return path.NewError(err)
+	}
+	ty, diags := elem.TypeConstraint()
+	if diags.HasErrors() {
+		return schemaErrorf(field.FullName(), "invalid type constraint expression")
+	}
+	v, err = convert.Convert(v, ty)
+	if err != nil {
+		return path.NewErrorf("invalid encoding of %s value as %s: %s", ty.FriendlyName(), field.Kind(), err)
+	}
+	if v.IsNull() {
+		msg.Clear(field)
+		return nil
+	}
+	if elem.Unit != "" {
+		v, err = parseUnitValue(v, elem.Unit)
+		if err != nil {
+			return path.NewError(err)
+		}
+	}
+
+	if field.IsList() && v.Type().IsSetType() && v.IsKnown() {
+		elems, err := OrderedSetEncoding(v)
+		if err != nil {
+			return path.NewError(err)
+		}
+		v = cty.TupleVal(elems)
+	}
+
+	needTy, err := valuePhysicalConstraintForFieldKind(v.Type(), field)
+	if err != nil {
+		return err
+	}
+	v, err = convert.Convert(v, needTy)
+	if err != nil {
+		return path.NewErrorf("can't re-encode %s value as %s: %s", ty.FriendlyName(), field.Kind(), err)
+	}
+
+	protoVal, diags := protoValueForField(v, valueSourceRanges{}, msg, field, DecodeOptions{})
+	if diags.HasErrors() {
+		return path.NewErrorf("can't re-encode %s value as %s: %s", ty.FriendlyName(), field.Kind(), diags.Error())
+	}
+	msg.Set(field, protoVal)
+	return nil
+}
+
+// normalizeSetBlocks sorts the given list of nested block messages, which
+// must already have been individually normalized, into the same canonical
+// order that OrderedSetEncoding uses, and removes any duplicates.
+func normalizeSetBlocks(list protoreflect.List, path cty.Path) error {
+	type sortElem struct {
+		key []byte
+		val protoreflect.Value
+	}
+	elems := make([]sortElem, 0, list.Len())
+	for i := 0; i < list.Len(); i++ {
+		path := append(path, cty.IndexStep{Key: cty.NumberIntVal(int64(i))})
+		val := list.Get(i)
+		obj, err := ObjectValueOptions{}.objectValueForMessage(val.Message(), path)
+		if err != nil {
+			return err
+		}
+		key, err := setSortKey(obj)
+		if err != nil {
+			return path.NewError(err)
+		}
+		elems = append(elems, sortElem{key, val})
+	}
+
+	sort.SliceStable(elems, func(i, j int) bool {
+		return bytes.Compare(elems[i].key, elems[j].key) < 0
+	})
+
+	list.Truncate(0)
+	for i, elem := range elems {
+		if i > 0 && bytes.Equal(elem.key, elems[i-1].key) {
+			continue // duplicate
+		}
+		list.Append(elem.val)
+	}
+	return nil
+}
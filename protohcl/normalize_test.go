@@ -0,0 +1,96 @@
+package protohcl
+
+import (
+	"testing"
+
+	"github.com/apparentlymart/go-protohcl/protohcl/internal/testschema"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestNormalizeMessage(t *testing.T) {
+	tests := map[string]struct {
+		msg  proto.Message
+		want proto.Message
+	}{
+		"string attribute": {
+			&testschema.WithStringAttr{Name: "Jackson"},
+			&testschema.WithStringAttr{Name: "Jackson"},
+		},
+		"number attribute as string": {
+			&testschema.WithNumberAttrAsString{Num: "01.50"},
+			&testschema.WithNumberAttrAsString{Num: "1.5"},
+		},
+		"string set attribute": {
+			&testschema.WithStringSetAttr{
+				Names: []string{"Rufus", "Jackson", "Agnes", "Jackson"},
+			},
+			&testschema.WithStringSetAttr{
+				Names: []string{"Agnes", "Jackson", "Rufus"},
+			},
+		},
+		"string list attribute": {
+			&testschema.WithStringListAttr{
+				// Lists have meaningful order and duplicates, so this stays
+				// unchanged.
+				Names: []string{"Rufus", "Jackson", "Agnes", "Jackson"},
+			},
+			&testschema.WithStringListAttr{
+				Names: []string{"Rufus", "Jackson", "Agnes", "Jackson"},
+			},
+		},
+		"raw dynamic attribute": {
+			&testschema.WithRawDynamicAttr{
+				Raw: []byte(`{ "type": "string", "value": "hello" }`),
+			},
+			&testschema.WithRawDynamicAttr{
+				Raw: []byte(`{"version":1,"type":"string","value":"hello"}`),
+			},
+		},
+		"set of nested blocks": {
+			&testschema.WithNestedBlockNoLabelsRepeated{
+				Doodad: []*testschema.WithStringAttr{
+					{Name: "b"},
+					{Name: "a"},
+					{Name: "b"},
+				},
+			},
+			&testschema.WithNestedBlockNoLabelsRepeated{
+				Doodad: []*testschema.WithStringAttr{
+					{Name: "a"},
+					{Name: "b"},
+				},
+			},
+		},
+		"list of nested blocks": {
+			&testschema.WithNestedBlockOneLabelRepeated{
+				Doodad: []*testschema.WithOneBlockLabel{
+					{Name: "b"},
+					{Name: "a"},
+					{Name: "b"},
+				},
+			},
+			&testschema.WithNestedBlockOneLabelRepeated{
+				Doodad: []*testschema.WithOneBlockLabel{
+					{Name: "b"},
+					{Name: "a"},
+					{Name: "b"},
+				},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := proto.Clone(test.msg)
+			err := NormalizeMessage(got)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if diff := cmp.Diff(test.want, got, protoCmpOpt); diff != "" {
+				t.Errorf("wrong result\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package protohcl
+
+import (
+	"github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// discardNullElements deals with any null elements in the given value for
+// the given repeated attribute, which must be a known, non-null sequence.
+//
+// If the attribute has ignore_null_in_lists set then the result is the
+// value without its null elements, along with correspondingly-adjusted
+// source ranges. Otherwise, a null element is an error, because a protobuf
+// repeated field can't represent it.
+func discardNullElements(val cty.Value, rngs valueSourceRanges, attr FieldAttribute) (cty.Value, valueSourceRanges, error) {
+	ty := val.Type()
+	if !(ty.IsListType() || ty.IsSetType() || ty.IsTupleType()) || val.IsNull() || !val.IsKnown() {
+		return val, rngs, nil
+	}
+
+	var kept []cty.Value
+	var keptRngs []hcl.Range
+	discarded := false
+	i := 0
+	for it := val.ElementIterator(); it.Next(); i++ {
+		_, elem := it.Element()
+		if elem.IsNull() {
+			if !attr.IgnoreNullInLists {
+				return val, rngs, cty.IndexIntPath(i).NewErrorf("must not be null")
+			}
+			discarded = true
+			continue
+		}
+		kept = append(kept, elem)
+		if i < len(rngs.Elems) {
+			keptRngs = append(keptRngs, rngs.Elems[i])
+		}
+	}
+	if !discarded {
+		return val, rngs, nil
+	}
+
+	if rngs.Elems != nil {
+		rngs.Elems = keptRngs
+	}
+	switch {
+	case ty.IsTupleType():
+		return cty.TupleVal(kept), rngs, nil
+	case len(kept) == 0 && ty.IsListType():
+		return cty.ListValEmpty(ty.ElementType()), rngs, nil
+	case len(kept) == 0:
+		return cty.SetValEmpty(ty.ElementType()), rngs, nil
+	case ty.IsListType():
+		return cty.ListVal(kept), rngs, nil
+	default:
+		return cty.SetVal(kept), rngs, nil
+	}
+}
@@ -0,0 +1,93 @@
+package protohcl
+
+import (
+	"testing"
+
+	"github.com/apparentlymart/go-protohcl/protohcl/internal/testschema"
+	"github.com/apparentlymart/go-protohcl/protohcl/protohcltest"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func TestDecodeBodyNullElements(t *testing.T) {
+	tests := map[string]struct {
+		messageType string
+		config      string
+		want        proto.Message
+		wantDiags   []protohcltest.ExpectedDiagnostic
+	}{
+		"null in list": {
+			"WithStringListAttr",
+			`names = ["a", null]`,
+			&testschema.WithStringListAttr{},
+			[]protohcltest.ExpectedDiagnostic{
+				protohcltest.Error(unsuitableValueSummary).
+					WithDetail(`Inappropriate value for attribute "names" at [1]: must not be null.`),
+			},
+		},
+		"null in set": {
+			"WithStringSetAttr",
+			`names = [null, "a"]`,
+			&testschema.WithStringSetAttr{},
+			[]protohcltest.ExpectedDiagnostic{
+				protohcltest.Error(unsuitableValueSummary).
+					WithDetail(`Inappropriate value for attribute "names" at [1]: must not be null.`),
+			},
+		},
+		"null in map": {
+			"WithStringMapAttr",
+			`names = { a = "b", c = null }`,
+			&testschema.WithStringMapAttr{},
+			[]protohcltest.ExpectedDiagnostic{
+				protohcltest.Error(unsuitableValueSummary).
+					WithDetail(`The value for key "c" must not be null.`),
+			},
+		},
+		"ignored nulls": {
+			"WithIgnoreNullInLists",
+			`
+				names = [null, "a", null, "b"]
+				items = [{ name = "c" }, null]
+				nested = {
+					names = ["d", null]
+				}
+			`,
+			&testschema.WithIgnoreNullInLists{
+				Names: []string{"a", "b"},
+				Items: []*testschema.WithStringAttr{{Name: "c"}},
+				Nested: &testschema.WithIgnoreNullInListsNested{
+					Names: []string{"d"},
+				},
+			},
+			nil,
+		},
+		"only nulls": {
+			"WithIgnoreNullInLists",
+			`names = [null]`,
+			&testschema.WithIgnoreNullInLists{},
+			nil,
+		},
+		"singular field": {
+			"WithIgnoreNullInListsSingular",
+			`name = "a"`,
+			&testschema.WithIgnoreNullInListsSingular{},
+			[]protohcltest.ExpectedDiagnostic{
+				protohcltest.Error(schemaErrorSummary).WithDetail(
+					"Invalid HCL annotations in protobuf schema for hcl.testschema.WithIgnoreNullInListsSingular.name: can use ignore_null_in_lists only with 'repeated' field.\n\nThis is a bug in the component that defined this schema, and not an error in the given configuration.",
+				),
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			desc := testschema.File_testschema_proto.Messages().ByName(protoreflect.Name(test.messageType))
+			got, diags := DecodeBody(parseTestBody(t, test.config), desc, nil)
+			protohcltest.AssertDiagnostics(t, diags, test.wantDiags...)
+			if diff := cmp.Diff(test.want, got, protoCmpOpt); diff != "" {
+				t.Errorf("wrong result\n%s", diff)
+			}
+		})
+	}
+}
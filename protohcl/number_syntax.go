@@ -0,0 +1,113 @@
+package protohcl
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/apparentlymart/go-protohcl/protohcl/protohclext"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// parseNumberSyntax converts the given value into a number if it's a known
+// string using the given extensions to HCL's number syntax, such as
+// "10_000_000" or "0xff".
+//
+// Any other value, including a string using only HCL's usual number syntax,
+// passes through unchanged so that the usual type conversion can deal with
+// it. If syntax is nil then parseNumberSyntax always returns the given value.
+func parseNumberSyntax(val cty.Value, syntax *protohclext.Attribute_NumberSyntax) (cty.Value, error) {
+	if syntax == nil || !val.Type().Equals(cty.String) || val.IsNull() || !val.IsKnown() {
+		return val, nil
+	}
+	s := val.AsString()
+
+	digits := s
+	neg := false
+	switch {
+	case strings.HasPrefix(digits, "-"):
+		neg = true
+		digits = digits[1:]
+	case strings.HasPrefix(digits, "+"):
+		digits = digits[1:]
+	}
+
+	base := 10
+	if syntax.Prefixes && len(digits) > 2 && digits[0] == '0' {
+		switch digits[1] {
+		case 'x', 'X':
+			base = 16
+		case 'o', 'O':
+			base = 8
+		case 'b', 'B':
+			base = 2
+		}
+		if base != 10 {
+			digits = digits[2:]
+		}
+	}
+
+	if strings.Contains(digits, "_") {
+		if !syntax.Underscores {
+			return val, nil
+		}
+		var err error
+		digits, err = removeDigitSeparators(digits, base)
+		if err != nil {
+			return cty.DynamicVal, fmt.Errorf("invalid number %q: %s", s, err)
+		}
+	} else if base == 10 {
+		// Nothing to do, then.
+		return val, nil
+	}
+
+	if base != 10 {
+		n, ok := new(big.Int).SetString(digits, base)
+		if !ok {
+			return cty.DynamicVal, fmt.Errorf("invalid number %q: not a valid base-%d integer", s, base)
+		}
+		if neg {
+			n.Neg(n)
+		}
+		return cty.NumberVal(new(big.Float).SetInt(n)), nil
+	}
+
+	if neg {
+		digits = "-" + digits
+	}
+	n, err := cty.ParseNumberVal(digits)
+	if err != nil {
+		return cty.DynamicVal, fmt.Errorf("invalid number %q", s)
+	}
+	return n, nil
+}
+
+// removeDigitSeparators returns the given string with all of its underscores
+// removed, or an error if any of them isn't between two digits of the given
+// base.
+func removeDigitSeparators(s string, base int) (string, error) {
+	var buf strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '_' {
+			buf.WriteByte(s[i])
+			continue
+		}
+		if i == 0 || i == len(s)-1 || !isDigit(s[i-1], base) || !isDigit(s[i+1], base) {
+			return "", fmt.Errorf("each underscore must be between two digits")
+		}
+	}
+	return buf.String(), nil
+}
+
+func isDigit(c byte, base int) bool {
+	switch {
+	case c >= '0' && c <= '9':
+		return int(c-'0') < base
+	case c >= 'a' && c <= 'z':
+		return int(c-'a')+10 < base
+	case c >= 'A' && c <= 'Z':
+		return int(c-'A')+10 < base
+	default:
+		return false
+	}
+}
@@ -0,0 +1,208 @@
+package protohcl
+
+import (
+	"testing"
+
+	"github.com/apparentlymart/go-protohcl/protohcl/internal/testschema"
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty-debug/ctydebug"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestDecodeBodyNumberSyntax(t *testing.T) {
+	desc := testschema.File_testschema_proto.Messages().ByName("WithNumberSyntaxAttrs")
+
+	tests := map[string]struct {
+		config     string
+		want       *testschema.WithNumberSyntaxAttrs
+		wantDetail string
+	}{
+		"plain numbers": {
+			`
+				amount = 1.5
+				count  = 12
+			`,
+			&testschema.WithNumberSyntaxAttrs{
+				Amount: "1.5",
+				Count:  12,
+			},
+			``,
+		},
+		"plain strings": {
+			`
+				amount = "1.5"
+				count  = "12"
+			`,
+			&testschema.WithNumberSyntaxAttrs{
+				Amount: "1.5",
+				Count:  12,
+			},
+			``,
+		},
+		"underscores": {
+			`
+				amount = "10_000_000.000_1"
+				count  = "-1_000"
+			`,
+			&testschema.WithNumberSyntaxAttrs{
+				Amount: "10000000.0001",
+				Count:  -1000,
+			},
+			``,
+		},
+		"hexadecimal": {
+			`
+				amount = "0xFF_FF"
+			`,
+			&testschema.WithNumberSyntaxAttrs{
+				Amount: "65535",
+			},
+			``,
+		},
+		"octal": {
+			`
+				amount = "-0o755"
+			`,
+			&testschema.WithNumberSyntaxAttrs{
+				Amount: "-493",
+			},
+			``,
+		},
+		"binary": {
+			`
+				amount = "0b1010"
+			`,
+			&testschema.WithNumberSyntaxAttrs{
+				Amount: "10",
+			},
+			``,
+		},
+		"prefix not enabled": {
+			`
+				count = "0x10"
+			`,
+			&testschema.WithNumberSyntaxAttrs{},
+			`Inappropriate value for attribute "count": a number is required.`,
+		},
+		"misplaced underscore": {
+			`
+				count = "1__000"
+			`,
+			&testschema.WithNumberSyntaxAttrs{},
+			`Inappropriate value for attribute "count": invalid number "1__000": each underscore must be between two digits.`,
+		},
+		"trailing underscore": {
+			`
+				amount = "1_000_"
+			`,
+			&testschema.WithNumberSyntaxAttrs{},
+			`Inappropriate value for attribute "amount": invalid number "1_000_": each underscore must be between two digits.`,
+		},
+		"invalid digit for base": {
+			`
+				amount = "0o19"
+			`,
+			&testschema.WithNumberSyntaxAttrs{},
+			`Inappropriate value for attribute "amount": invalid number "0o19": not a valid base-8 integer.`,
+		},
+		"fraction with prefix": {
+			`
+				amount = "0x1.8"
+			`,
+			&testschema.WithNumberSyntaxAttrs{},
+			`Inappropriate value for attribute "amount": invalid number "0x1.8": not a valid base-16 integer.`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			f, diags := hclsyntax.ParseConfig([]byte(test.config), "", hcl.InitialPos)
+			if diags.HasErrors() {
+				t.Fatalf("unexpected syntax errors: %s", diags.Error())
+			}
+
+			got, diags := DecodeBody(f.Body, desc, nil)
+			if test.wantDetail == "" {
+				if diags.HasErrors() {
+					t.Fatalf("unexpected errors: %s", diags.Error())
+				}
+			} else {
+				if !diags.HasErrors() {
+					t.Fatalf("unexpected success")
+				}
+				if got, want := diags[0].Detail, test.wantDetail; got != want {
+					t.Errorf("wrong error detail\ngot:  %s\nwant: %s", got, want)
+				}
+			}
+			if diff := cmp.Diff(test.want, got, protoCmpOpt); diff != "" {
+				t.Errorf("wrong result\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMessageForObjectValueNumberSyntax(t *testing.T) {
+	desc := testschema.File_testschema_proto.Messages().ByName("WithNumberSyntaxAttrs")
+
+	got, err := messageForObjectValue(cty.ObjectVal(map[string]cty.Value{
+		"amount": cty.StringVal("0x_10"),
+	}), desc, nil, nil)
+	if err == nil {
+		t.Fatalf("unexpected success with invalid number")
+	}
+	if got, want := err.Error(), `.amount: invalid number "0x_10": each underscore must be between two digits`; got != want {
+		t.Errorf("wrong error\ngot:  %s\nwant: %s", got, want)
+	}
+
+	got, err = messageForObjectValue(cty.ObjectVal(map[string]cty.Value{
+		"amount": cty.StringVal("1_024"),
+		"count":  cty.StringVal("2_048"),
+	}), desc, nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := &testschema.WithNumberSyntaxAttrs{Amount: "1024", Count: 2048}
+	if diff := cmp.Diff(want, got.Interface(), protoCmpOpt); diff != "" {
+		t.Errorf("wrong result\n%s", diff)
+	}
+}
+
+func TestNumberSyntaxCanonicalForm(t *testing.T) {
+	// A producer that wrote the string field directly might have used the
+	// extended syntax, but ObjectValueForMessage and normalizing both
+	// present the number in its canonical form.
+	msg := &testschema.WithNumberSyntaxAttrs{Amount: "0x1_00"}
+	got, err := ObjectValueForMessage(msg)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	wantVal := cty.ObjectVal(map[string]cty.Value{
+		"amount": cty.NumberIntVal(256),
+		"count":  cty.NumberIntVal(0),
+	})
+	if diff := cmp.Diff(wantVal, got, ctydebug.CmpOptions); diff != "" {
+		t.Errorf("wrong result\n%s", diff)
+	}
+
+	if err := NormalizeMessage(msg); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := &testschema.WithNumberSyntaxAttrs{Amount: "256"}
+	if diff := cmp.Diff(want, msg, protoCmpOpt); diff != "" {
+		t.Errorf("wrong normalized message\n%s", diff)
+	}
+}
+
+func TestGetFieldElemNumberSyntaxErrors(t *testing.T) {
+	desc := testschema.File_testschema_proto.Messages().ByName("WithNumberSyntaxString")
+	_, err := GetFieldElem(desc.Fields().Get(0))
+	if err == nil {
+		t.Fatalf("unexpected success")
+	}
+	want := `unsupported protobuf schema in hcl.testschema.WithNumberSyntaxString.name: number_syntax is allowed only for singular attributes whose type constraint is number`
+	if got := err.Error(); got != want {
+		t.Errorf("wrong error\ngot:  %s\nwant: %s", got, want)
+	}
+}
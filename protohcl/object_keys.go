@@ -0,0 +1,71 @@
+package protohcl
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+)
+
+// duplicateObjectKeySummary is the summary of the error for an object
+// constructor that produces the same key more than once. It matches the
+// summary that HCL's JSON syntax uses for the same problem.
+const duplicateObjectKeySummary = "Duplicate object attribute"
+
+// checkDuplicateObjectKeys returns an error diagnostic for each key of an
+// object constructor expression, or of any constructor nested inside it,
+// that produces the same string as an earlier key in the same constructor.
+//
+// HCL's native syntax silently discards all but the last of the duplicates
+// when it evaluates an object constructor, such as for { a = 1, "a" = 2 } or
+// { 1 = "a", "1" = "b" }, and so the decoder checks the expression itself
+// before the duplicates are lost. HCL's JSON syntax already reports
+// duplicates as an error during evaluation.
+//
+// Keys that can't be evaluated, or that aren't known, are ignored here
+// because evaluating the whole expression reports any problems with them.
+// The given keys evaluates each key expression, remembering the results so
+// that finding the source ranges of the map elements can reuse them.
+func checkDuplicateObjectKeys(expr hcl.Expression, keys *objectKeyValues) hcl.Diagnostics {
+	var diags hcl.Diagnostics
+
+	if pairs, moreDiags := hcl.ExprMap(expr); !moreDiags.HasErrors() {
+		seen := make(map[string]hcl.Range, len(pairs))
+		for _, pair := range pairs {
+			diags = append(diags, checkDuplicateObjectKeys(pair.Value, keys)...)
+
+			key, moreDiags := keys.value(pair.Key)
+			if moreDiags.HasErrors() || key.IsNull() {
+				continue
+			}
+			key, _ = key.Unmark()
+			key, err := convert.Convert(key, cty.String)
+			if err != nil || !key.IsKnown() {
+				continue
+			}
+			keyStr := key.AsString()
+			if prev, exists := seen[keyStr]; exists {
+				diags = append(diags, &hcl.Diagnostic{
+					Severity:    hcl.DiagError,
+					Summary:     duplicateObjectKeySummary,
+					Detail:      fmt.Sprintf("An attribute named %q was already defined at %s. Each key in an object must be unique.", keyStr, prev),
+					Subject:     pair.Key.Range().Ptr(),
+					Expression:  pair.Key,
+					EvalContext: keys.ctx,
+					Extra:       diagnosticExtra{Category: DiagnosticConflict},
+				})
+				continue
+			}
+			seen[keyStr] = pair.Key.Range()
+		}
+		return diags
+	}
+
+	if exprs, moreDiags := hcl.ExprList(expr); !moreDiags.HasErrors() {
+		for _, expr := range exprs {
+			diags = append(diags, checkDuplicateObjectKeys(expr, keys)...)
+		}
+	}
+	return diags
+}
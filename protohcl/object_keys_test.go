@@ -0,0 +1,137 @@
+package protohcl
+
+import (
+	"testing"
+
+	"github.com/apparentlymart/go-protohcl/protohcl/internal/testschema"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/json"
+	"github.com/zclconf/go-cty/cty"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func TestDecodeBodyDuplicateObjectKeys(t *testing.T) {
+	ctx := &hcl.EvalContext{
+		Variables: map[string]cty.Value{
+			"key": cty.StringVal("a"),
+			"num": cty.NumberIntVal(1),
+		},
+	}
+
+	tests := map[string]struct {
+		desc       protoreflect.Name
+		config     string
+		json       bool
+		wantDetail string
+		wantRange  hcl.Range
+	}{
+		"identifier and quoted": {
+			"WithStringMapAttr",
+			`names = { a = "x", "a" = "y" }`,
+			false,
+			`An attribute named "a" was already defined at test.hcl:1,11-12. Each key in an object must be unique.`,
+			hcl.Range{
+				Filename: "test.hcl",
+				Start:    hcl.Pos{Line: 1, Column: 20, Byte: 19},
+				End:      hcl.Pos{Line: 1, Column: 23, Byte: 22},
+			},
+		},
+		"after conversion to string": {
+			"WithStringMapAttr",
+			`names = { 1 = "x", (num) = "y" }`,
+			false,
+			`An attribute named "1" was already defined at test.hcl:1,11-12. Each key in an object must be unique.`,
+			hcl.Range{
+				Filename: "test.hcl",
+				Start:    hcl.Pos{Line: 1, Column: 20, Byte: 19},
+				End:      hcl.Pos{Line: 1, Column: 25, Byte: 24},
+			},
+		},
+		"from variable": {
+			"WithStringMapAttr",
+			`names = { (key) = "x", a = "y" }`,
+			false,
+			`An attribute named "a" was already defined at test.hcl:1,11-16. Each key in an object must be unique.`,
+			hcl.Range{
+				Filename: "test.hcl",
+				Start:    hcl.Pos{Line: 1, Column: 24, Byte: 23},
+				End:      hcl.Pos{Line: 1, Column: 25, Byte: 24},
+			},
+		},
+		"nested in message map": {
+			"WithStructsInNestedMessages",
+			`list = [{ map = { x = 1, x = 2 } }]`,
+			false,
+			`An attribute named "x" was already defined at test.hcl:1,19-20. Each key in an object must be unique.`,
+			hcl.Range{
+				Filename: "test.hcl",
+				Start:    hcl.Pos{Line: 1, Column: 26, Byte: 25},
+				End:      hcl.Pos{Line: 1, Column: 27, Byte: 26},
+			},
+		},
+		"json": {
+			// HCL's JSON syntax reports this itself while evaluating the
+			// expression, so this checks that we don't report it twice.
+			"WithStringMapAttr",
+			`{"names": {"a": "x", "a": "y"}}`,
+			true,
+			`An attribute named "a" was already defined at test.hcl:1,12-15.`,
+			hcl.Range{
+				Filename: "test.hcl",
+				Start:    hcl.Pos{Line: 1, Column: 22, Byte: 21},
+				End:      hcl.Pos{Line: 1, Column: 25, Byte: 24},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var f *hcl.File
+			var diags hcl.Diagnostics
+			if test.json {
+				f, diags = json.Parse([]byte(test.config), "test.hcl")
+			} else {
+				f, diags = hclsyntax.ParseConfig([]byte(test.config), "test.hcl", hcl.InitialPos)
+			}
+			if diags.HasErrors() {
+				t.Fatalf("unexpected syntax errors: %s", diags.Error())
+			}
+
+			desc := testschema.File_testschema_proto.Messages().ByName(test.desc)
+			_, diags = DecodeBody(f.Body, desc, ctx)
+			if len(diags) != 1 {
+				t.Fatalf("wrong number of diagnostics %d; want 1\n%s", len(diags), diags.Error())
+			}
+			diag := diags[0]
+			if got, want := diag.Summary, "Duplicate object attribute"; got != want {
+				t.Errorf("wrong summary\ngot:  %s\nwant: %s", got, want)
+			}
+			if got, want := diag.Detail, test.wantDetail; got != want {
+				t.Errorf("wrong detail\ngot:  %s\nwant: %s", got, want)
+			}
+			if diag.Subject == nil || *diag.Subject != test.wantRange {
+				t.Errorf("wrong subject\ngot:  %#v\nwant: %#v", diag.Subject, test.wantRange)
+			}
+			if got, want := DiagnosticCategoryOf(diag), DiagnosticConflict; got != want {
+				t.Errorf("wrong category %s; want %s", got, want)
+			}
+		})
+	}
+}
+
+func TestDecodeBodyDistinctObjectKeys(t *testing.T) {
+	// Keys that differ only in case, or that are distinct but produce
+	// equal values, are not duplicates.
+	f, diags := hclsyntax.ParseConfig([]byte(`names = { a = "x", A = "x", "a " = "x" }`), "", hcl.InitialPos)
+	if diags.HasErrors() {
+		t.Fatalf("unexpected syntax errors: %s", diags.Error())
+	}
+	got, diags := DecodeBody(f.Body, testschema.File_testschema_proto.Messages().ByName("WithStringMapAttr"), nil)
+	if diags.HasErrors() {
+		t.Fatalf("unexpected errors: %s", diags.Error())
+	}
+	if got, want := len(got.(*testschema.WithStringMapAttr).Names), 3; got != want {
+		t.Errorf("wrong number of map elements %d; want %d", got, want)
+	}
+}
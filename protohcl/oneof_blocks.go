@@ -0,0 +1,88 @@
+package protohcl
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const conflictingBlockTypesSummary = "Conflicting block types"
+
+// checkOneofBlockTypes returns a schema error if the given oneof has any
+// member that isn't a nested block type.
+func checkOneofBlockTypes(oneof protoreflect.OneofDescriptor) error {
+	if oneof.IsSynthetic() {
+		return nil
+	}
+	fields := oneof.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		elem, err := GetFieldElem(field)
+		if err != nil {
+			return err
+		}
+		if _, ok := elem.(FieldNestedBlockType); !ok {
+			return schemaErrorf(field.FullName(), "all members of oneof %s must be nested block types", oneof.Name())
+		}
+	}
+	return nil
+}
+
+// oneofBlockTypes returns the nested block types of all of the members of
+// the oneof that the given field belongs to, for a message flattened through
+// outer, or nil if the field doesn't belong to a oneof.
+func oneofBlockTypes(field protoreflect.FieldDescriptor, outer FieldFlattened) []FieldNestedBlockType {
+	oneof := field.ContainingOneof()
+	if oneof == nil || oneof.IsSynthetic() {
+		return nil
+	}
+	fields := oneof.Fields()
+	ret := make([]FieldNestedBlockType, 0, fields.Len())
+	for i := 0; i < fields.Len(); i++ {
+		// bodySchema already checked that these are all valid nested
+		// block types.
+		elem, _ := GetFieldElem(fields.Get(i))
+		if elem, ok := flattenedElem(elem, outer).(FieldNestedBlockType); ok {
+			ret = append(ret, elem)
+		}
+	}
+	return ret
+}
+
+// oneofConflictDiagnostic returns an error diagnostic if the given block
+// of the given block type competes with a block of one of the other block
+// types in the same oneof that appears earlier in the content, or nil if
+// the block is the first of any of the oneof's block types.
+func oneofConflictDiagnostic(block *hcl.Block, elem FieldNestedBlockType, members []FieldNestedBlockType, content *hcl.BodyContent) *hcl.Diagnostic {
+	if len(members) == 0 {
+		return nil
+	}
+	for _, other := range content.Blocks {
+		if other == block {
+			return nil
+		}
+		for _, member := range members {
+			if member.TypeName == elem.TypeName || !member.hasTypeName(other.Type) {
+				continue
+			}
+			names := make([]string, len(members))
+			for i, member := range members {
+				names[i] = fmt.Sprintf("%q", member.TypeName)
+			}
+			return &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  conflictingBlockTypesSummary,
+				Detail: fmt.Sprintf(
+					"Only one of the block types %s may appear in this body. A %s block was already declared at %s.",
+					strings.Join(names, ", "), other.Type, other.DefRange.Ptr(),
+				),
+				Subject: block.TypeRange.Ptr(),
+				Context: block.DefRange.Ptr(),
+				Extra:   diagnosticExtra{Category: DiagnosticConflict},
+			}
+		}
+	}
+	return nil
+}
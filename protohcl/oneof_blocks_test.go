@@ -0,0 +1,99 @@
+package protohcl
+
+import (
+	"testing"
+
+	"github.com/apparentlymart/go-protohcl/protohcl/internal/testschema"
+	"github.com/apparentlymart/go-protohcl/protohcl/protohcltest"
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestDecodeBodyOneofBlocks(t *testing.T) {
+	desc := testschema.File_testschema_proto.Messages().ByName("WithOneofBlocks")
+
+	tests := map[string]struct {
+		src       string
+		want      proto.Message
+		wantDiags []protohcltest.ExpectedDiagnostic
+	}{
+		"neither": {
+			``,
+			&testschema.WithOneofBlocks{},
+			nil,
+		},
+		"first member": {
+			`
+				local {
+					path = "/tmp"
+				}
+			`,
+			&testschema.WithOneofBlocks{
+				Backend: &testschema.WithOneofBlocks_Local{
+					Local: &testschema.LocalStorageConfig{Path: "/tmp"},
+				},
+			},
+			nil,
+		},
+		"second member": {
+			`
+				remote {
+					name = "upstream"
+				}
+			`,
+			&testschema.WithOneofBlocks{
+				Backend: &testschema.WithOneofBlocks_Remote{
+					Remote: &testschema.WithStringAttr{Name: "upstream"},
+				},
+			},
+			nil,
+		},
+		"both": {
+			`
+				remote {
+					name = "upstream"
+				}
+				local {
+					path = "/tmp"
+				}
+			`,
+			&testschema.WithOneofBlocks{
+				Backend: &testschema.WithOneofBlocks_Remote{
+					Remote: &testschema.WithStringAttr{Name: "upstream"},
+				},
+			},
+			[]protohcltest.ExpectedDiagnostic{
+				protohcltest.Error(conflictingBlockTypesSummary).
+					WithDetail(`Only one of the block types "local", "remote" may appear in this body. A remote block was already declared at test.hcl:2,5-11.`).
+					OnLine(5),
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			f, diags := hclsyntax.ParseConfig([]byte(test.src), "test.hcl", hcl.InitialPos)
+			if diags.HasErrors() {
+				t.Fatalf("unexpected syntax errors: %s", diags.Error())
+			}
+			got, diags := DecodeBody(f.Body, desc, nil)
+			protohcltest.AssertDiagnostics(t, diags, test.wantDiags...)
+			if diff := cmp.Diff(test.want, got, protoCmpOpt); diff != "" {
+				t.Errorf("wrong result\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDecodeBodyOneofAttr(t *testing.T) {
+	desc := testschema.File_testschema_proto.Messages().ByName("WithOneofAttr")
+
+	_, diags := DecodeBody(parseTestBody(t, `name = "Jackson"`), desc, nil)
+	protohcltest.AssertDiagnostics(t, diags,
+		protohcltest.Error(schemaErrorSummary).WithDetail(
+			"Invalid HCL annotations in protobuf schema for hcl.testschema.WithOneofAttr.name: all members of oneof choice must be nested block types.\n\nThis is a bug in the component that defined this schema, and not an error in the given configuration.",
+		),
+	)
+}
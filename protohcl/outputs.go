@@ -0,0 +1,63 @@
+package protohcl
+
+import (
+	"sort"
+
+	"github.com/zclconf/go-cty/cty"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// OutputForMessageDesc returns the fully-qualified name of the message type
+// that the given message type is declared as describing the results for,
+// using the (hcl.message).output_for option, or an empty name if the given
+// message type isn't an output message.
+func OutputForMessageDesc(desc protoreflect.MessageDescriptor) protoreflect.FullName {
+	return protoreflect.FullName(messageOptions(desc).GetOutputFor())
+}
+
+// CombinedObjectTypeConstraint returns the type constraint for an object
+// that has all of the attributes that ObjectTypeConstraintForMessageDesc
+// would describe for the given configuration message type and also all of
+// those it would describe for the given output message type.
+//
+// This is intended for hosts that expose objects combining the configuration
+// of some object with the results of processing it, so that they can
+// type-check references to such an object before the results are known.
+//
+// The output message must be declared, using (hcl.message).output_for, as
+// describing the results of the given configuration message, and none of its
+// attributes may have the same names as attributes of the configuration
+// message. CombinedObjectTypeConstraint returns an error if either of those
+// requirements isn't met.
+func CombinedObjectTypeConstraint(config, output protoreflect.MessageDescriptor) (cty.Type, error) {
+	if got, want := OutputForMessageDesc(output), config.FullName(); got != want {
+		if got == "" {
+			return cty.NilType, schemaErrorf(output.FullName(), "message is not declared as an output message")
+		}
+		return cty.NilType, schemaErrorf(output.FullName(), "message is an output message for %s, not for %s", got, want)
+	}
+
+	atys := make(map[string]cty.Type)
+	err := ObjectValueOptions{}.buildObjectTypeAtysForMessageDesc(config, FieldFlattened{}, map[protoreflect.FullName]struct{}{config.FullName(): {}}, atys)
+	if err != nil {
+		return cty.NilType, err
+	}
+	outputAtys := make(map[string]cty.Type)
+	err = ObjectValueOptions{}.buildObjectTypeAtysForMessageDesc(output, FieldFlattened{}, map[protoreflect.FullName]struct{}{output.FullName(): {}}, outputAtys)
+	if err != nil {
+		return cty.NilType, err
+	}
+	names := make([]string, 0, len(outputAtys))
+	for name := range outputAtys {
+		names = append(names, name)
+	}
+	sort.Strings(names) // so that we'll report conflicts deterministically
+	for _, name := range names {
+		if _, exists := atys[name]; exists {
+			return cty.NilType, schemaErrorf(output.FullName(), "output attribute %q conflicts with attribute declared by %s", name, config.FullName())
+		}
+		atys[name] = outputAtys[name]
+	}
+
+	return cty.Object(atys), nil
+}
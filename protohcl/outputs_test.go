@@ -0,0 +1,78 @@
+package protohcl
+
+import (
+	"testing"
+
+	"github.com/apparentlymart/go-protohcl/protohcl/internal/testschema"
+	"github.com/google/go-cmp/cmp"
+	"github.com/zclconf/go-cty-debug/ctydebug"
+	"github.com/zclconf/go-cty/cty"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func TestCombinedObjectTypeConstraint(t *testing.T) {
+	tests := []struct {
+		configType string
+		outputType string
+		want       cty.Type
+		wantErr    string
+	}{
+		{
+			"Root",
+			"RootOutput",
+			cty.Object(map[string]cty.Type{
+				"name":        cty.String,
+				"count":       cty.Number,
+				"thing":       cty.DynamicPseudoType, // tuple type chosen dynamically
+				"other_thing": cty.Object(map[string]cty.Type{"name": cty.String}),
+				"id":          cty.String,
+			}),
+			``,
+		},
+		{
+			"Root",
+			"ConflictingRootOutput",
+			cty.NilType,
+			`unsupported protobuf schema in hcl.testschema.ConflictingRootOutput: output attribute "name" conflicts with attribute declared by hcl.testschema.Root`,
+		},
+		{
+			"WithStringAttr",
+			"RootOutput",
+			cty.NilType,
+			`unsupported protobuf schema in hcl.testschema.RootOutput: message is an output message for hcl.testschema.Root, not for hcl.testschema.WithStringAttr`,
+		},
+		{
+			"Root",
+			"WithStringAttr",
+			cty.NilType,
+			`unsupported protobuf schema in hcl.testschema.WithStringAttr: message is not declared as an output message`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.configType+" "+test.outputType, func(t *testing.T) {
+			configDesc := testschema.File_testschema_proto.Messages().ByName(protoreflect.Name(test.configType))
+			outputDesc := testschema.File_testschema_proto.Messages().ByName(protoreflect.Name(test.outputType))
+
+			got, err := CombinedObjectTypeConstraint(configDesc, outputDesc)
+
+			if test.wantErr != "" {
+				if err == nil {
+					t.Fatalf("unexpected success\nwant error: %s", test.wantErr)
+				}
+				if err.Error() != test.wantErr {
+					t.Fatalf("wrong error\ngot error:  %s\nwant error: %s", err.Error(), test.wantErr)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error\ngot error: %s", err.Error())
+			}
+
+			if diff := cmp.Diff(got, test.want, ctydebug.CmpOptions); diff != "" {
+				t.Errorf("wrong result\n%s", diff)
+			}
+		})
+	}
+}
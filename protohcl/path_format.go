@@ -0,0 +1,63 @@
+package protohcl
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+
+	"github.com/zclconf/go-cty/cty"
+)
+
+// PathFormatter is the signature of a function that describes the location
+// of a nested value within an attribute's value, for use with
+// DecodeOptions.FormatPath.
+//
+// The decoder includes the result in error diagnostics about the nested
+// value, after the attribute name, as in "Inappropriate value for attribute
+// "services" at <path>: ...". The path is relative to the attribute's value,
+// and is never empty.
+type PathFormatter func(path cty.Path) string
+
+// FormatCtyPath is the PathFormatter that the decoder uses by default,
+// describing the given path using HCL's traversal syntax, as in
+// `["web"].port`.
+//
+// A host whose own expression syntax differs might wrap or replace this
+// function so that the diagnostics match the expressions its users write.
+func FormatCtyPath(path cty.Path) string {
+	var buf bytes.Buffer
+	for _, step := range path {
+		switch ts := step.(type) {
+		case cty.GetAttrStep:
+			fmt.Fprintf(&buf, ".%s", ts.Name)
+		case cty.IndexStep:
+			buf.WriteByte('[')
+			key := ts.Key
+			keyTy := key.Type()
+			switch {
+			case key.IsNull():
+				buf.WriteString("null")
+			case !key.IsKnown():
+				buf.WriteString("(not yet known)")
+			case keyTy == cty.Number:
+				bf := key.AsBigFloat()
+				buf.WriteString(bf.Text('g', -1))
+			case keyTy == cty.String:
+				buf.WriteString(strconv.Quote(key.AsString()))
+			default:
+				buf.WriteString("...")
+			}
+			buf.WriteByte(']')
+		}
+	}
+	return buf.String()
+}
+
+// formatPath formats the given path using the receiver's FormatPath
+// function, or FormatCtyPath if it has none.
+func (opts DecodeOptions) formatPath(path cty.Path) string {
+	if opts.FormatPath != nil {
+		return opts.FormatPath(path)
+	}
+	return FormatCtyPath(path)
+}
@@ -0,0 +1,111 @@
+package protohcl
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/apparentlymart/go-protohcl/protohcl/internal/testschema"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func TestFormatCtyPath(t *testing.T) {
+	tests := map[string]struct {
+		path cty.Path
+		want string
+	}{
+		"empty": {
+			nil,
+			``,
+		},
+		"attribute": {
+			cty.GetAttrPath("port"),
+			`.port`,
+		},
+		"map key then attribute": {
+			cty.IndexStringPath("web").GetAttr("port"),
+			`["web"].port`,
+		},
+		"list index": {
+			cty.IndexIntPath(2),
+			`[2]`,
+		},
+		"unknown key": {
+			cty.Path{cty.IndexStep{Key: cty.UnknownVal(cty.String)}},
+			`[(not yet known)]`,
+		},
+		"null key": {
+			cty.Path{cty.IndexStep{Key: cty.NullVal(cty.String)}},
+			`[null]`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := FormatCtyPath(test.path); got != test.want {
+				t.Errorf("wrong result\ngot:  %s\nwant: %s", got, test.want)
+			}
+		})
+	}
+}
+
+func TestDecodeOptionsFormatPath(t *testing.T) {
+	// This host writes paths as slash-separated segments, in the style of
+	// JSON Pointer, and so wants the diagnostics to do the same.
+	opts := DecodeOptions{
+		FormatPath: func(path cty.Path) string {
+			var buf strings.Builder
+			for _, step := range path {
+				buf.WriteByte('/')
+				switch step := step.(type) {
+				case cty.GetAttrStep:
+					buf.WriteString(step.Name)
+				case cty.IndexStep:
+					if step.Key.Type() == cty.Number {
+						buf.WriteString(step.Key.AsBigFloat().Text('f', -1))
+					} else {
+						buf.WriteString(step.Key.AsString())
+					}
+				}
+			}
+			return buf.String()
+		},
+	}
+
+	tests := map[string]struct {
+		msgName    protoreflect.Name
+		config     string
+		wantDetail string
+	}{
+		"attribute value": {
+			"WithUnitAttrs",
+			`intervals = ["1s", "1x"]`,
+			`Inappropriate value for attribute "intervals" at /1: invalid number of seconds: unsupported suffix "x"; must be one of "d", "h", "m", "s", "ms", "us", "ns".`,
+		},
+		"message attribute value": {
+			"WithStructsInNestedMessages",
+			`by_key = { a = { map = { x = "nope" } } }`,
+			`Inappropriate value for attribute "by_key" at /a/map/x: a number is required.`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			desc := testschema.File_testschema_proto.Messages().ByName(test.msgName)
+			f, diags := hclsyntax.ParseConfig([]byte(test.config), "", hcl.InitialPos)
+			if diags.HasErrors() {
+				t.Fatalf("unexpected syntax errors: %s", diags.Error())
+			}
+
+			_, diags = opts.DecodeBody(f.Body, desc, nil)
+			if !diags.HasErrors() {
+				t.Fatalf("unexpected success")
+			}
+			if got, want := diags[0].Detail, test.wantDetail; got != want {
+				t.Errorf("wrong error detail\ngot:  %s\nwant: %s", got, want)
+			}
+		})
+	}
+}
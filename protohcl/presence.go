@@ -0,0 +1,128 @@
+package protohcl
+
+import (
+	"fmt"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protopath"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Presence describes whether and how an attribute or nested block type was
+// defined in the configuration that a message was decoded from.
+type Presence int
+
+const (
+	// PresenceAbsent means that the configuration didn't define the
+	// attribute or include any blocks of the block type.
+	PresenceAbsent Presence = 0
+
+	// PresenceNull means that the configuration defined the attribute, but
+	// its value was null, so the field is unset just as if it were absent.
+	PresenceNull Presence = 1
+
+	// PresenceDefault means that the configuration defined the attribute
+	// with a value that is the default value for the field, such as zero
+	// or an empty string for a field without explicit presence tracking, so
+	// the message alone can't distinguish it from being absent.
+	PresenceDefault Presence = 2
+
+	// PresenceSet means that the configuration defined the attribute with a
+	// value other than the default, or included at least one block of the
+	// block type.
+	//
+	// An attribute with an invalid value is also PresenceSet, even though
+	// decoding leaves its field unset.
+	PresenceSet Presence = 3
+)
+
+func (p Presence) String() string {
+	switch p {
+	case PresenceAbsent:
+		return "absent"
+	case PresenceNull:
+		return "null"
+	case PresenceDefault:
+		return "default"
+	case PresenceSet:
+		return "set"
+	default:
+		return fmt.Sprintf("Presence(%d)", int(p))
+	}
+}
+
+// PresenceMap records whether each of the attributes and nested block types
+// of a message and its nested messages was present in the configuration a
+// message was decoded from, as returned by DecodeBodyWithPresence.
+//
+// This is more detailed than the presence tracking that protobuf itself
+// offers, because it can distinguish between an attribute that was omitted
+// and one that was set to null or to the field's default value, without
+// any special treatment in the schema.
+type PresenceMap struct {
+	paths    []protopath.Path
+	presence map[string]Presence
+}
+
+// DecodeBodyWithPresence is like DecodeBody but also returns a PresenceMap
+// describing which of the attributes and blocks the body defined.
+func DecodeBodyWithPresence(body hcl.Body, desc protoreflect.MessageDescriptor, ctx *hcl.EvalContext) (proto.Message, *PresenceMap, hcl.Diagnostics) {
+	return DecodeOptions{}.DecodeBodyWithPresence(body, desc, ctx)
+}
+
+func newPresenceMap() *PresenceMap {
+	return &PresenceMap{
+		presence: make(map[string]Presence),
+	}
+}
+
+// Presence returns the presence of the attribute or nested block type that
+// populates the field at the given path, which must start with a
+// protopath.Root step for the message type that was decoded.
+//
+// The result is PresenceAbsent for any path that the map has no record of,
+// such as a path to a field of a nested block that wasn't present.
+func (m *PresenceMap) Presence(path protopath.Path) Presence {
+	if m == nil {
+		return PresenceAbsent
+	}
+	return m.presence[path.String()]
+}
+
+// Paths returns the paths of all of the fields that the map has a record of,
+// in the order that the decoder visited them. That includes a path for every
+// attribute and nested block type field in each message that the decoder
+// populated, regardless of their presence.
+func (m *PresenceMap) Paths() []protopath.Path {
+	if m == nil {
+		return nil
+	}
+	ret := make([]protopath.Path, len(m.paths))
+	copy(ret, m.paths)
+	return ret
+}
+
+// record saves the given presence for the given path. It does nothing at all
+// if called on a nil map, so the decoder can call it unconditionally.
+func (m *PresenceMap) record(path protopath.Path, presence Presence) {
+	if m == nil {
+		return
+	}
+	key := path.String()
+	if _, exists := m.presence[key]; !exists {
+		m.paths = append(m.paths, path)
+	}
+	m.presence[key] = presence
+}
+
+// recordFieldValue saves the presence for the given path based on whether
+// the given field of the given message is populated, after the decoder has
+// assigned a value from an attribute that was present.
+func (m *PresenceMap) recordFieldValue(path protopath.Path, msg protoreflect.Message, field protoreflect.FieldDescriptor) {
+	if msg.Has(field) {
+		m.record(path, PresenceSet)
+	} else {
+		m.record(path, PresenceDefault)
+	}
+}
@@ -0,0 +1,152 @@
+package protohcl
+
+import (
+	"testing"
+
+	"github.com/apparentlymart/go-protohcl/protohcl/internal/testschema"
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"google.golang.org/protobuf/reflect/protopath"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func TestDecodeBodyWithPresence(t *testing.T) {
+	fileDesc := testschema.File_testschema_proto
+
+	tests := map[string]struct {
+		config    string
+		desc      protoreflect.MessageDescriptor
+		want      map[string]Presence
+		wantError bool
+	}{
+		"set": {
+			`
+name  = "Jackson"
+count = 2
+`,
+			fileDesc.Messages().ByName(protoreflect.Name("WithOptionalAttrs")),
+			map[string]Presence{
+				`(hcl.testschema.WithOptionalAttrs).name`:  PresenceSet,
+				`(hcl.testschema.WithOptionalAttrs).count`: PresenceSet,
+			},
+			false,
+		},
+		"absent": {
+			`name = "Jackson"`,
+			fileDesc.Messages().ByName(protoreflect.Name("WithOptionalAttrs")),
+			map[string]Presence{
+				`(hcl.testschema.WithOptionalAttrs).name`:  PresenceSet,
+				`(hcl.testschema.WithOptionalAttrs).count`: PresenceAbsent,
+			},
+			false,
+		},
+		"null": {
+			`
+name  = "Jackson"
+count = null
+`,
+			fileDesc.Messages().ByName(protoreflect.Name("WithOptionalAttrs")),
+			map[string]Presence{
+				`(hcl.testschema.WithOptionalAttrs).name`:  PresenceSet,
+				`(hcl.testschema.WithOptionalAttrs).count`: PresenceNull,
+			},
+			false,
+		},
+		"default": {
+			`
+name  = ""
+count = 0
+`,
+			fileDesc.Messages().ByName(protoreflect.Name("WithOptionalAttrs")),
+			map[string]Presence{
+				`(hcl.testschema.WithOptionalAttrs).name`:  PresenceDefault,
+				`(hcl.testschema.WithOptionalAttrs).count`: PresenceDefault,
+			},
+			false,
+		},
+		"empty list": {
+			`names = []`,
+			fileDesc.Messages().ByName(protoreflect.Name("WithStringListAttr")),
+			map[string]Presence{
+				`(hcl.testschema.WithStringListAttr).names`: PresenceDefault,
+			},
+			false,
+		},
+		"invalid value": {
+			`
+name  = "Jackson"
+count = "many"
+`,
+			fileDesc.Messages().ByName(protoreflect.Name("WithOptionalAttrs")),
+			map[string]Presence{
+				`(hcl.testschema.WithOptionalAttrs).name`:  PresenceSet,
+				`(hcl.testschema.WithOptionalAttrs).count`: PresenceSet,
+			},
+			true,
+		},
+		"nested block absent": {
+			``,
+			fileDesc.Messages().ByName(protoreflect.Name("WithNestedBlockNoLabelsSingleton")),
+			map[string]Presence{
+				`(hcl.testschema.WithNestedBlockNoLabelsSingleton).doodad`: PresenceAbsent,
+			},
+			false,
+		},
+		"nested block present": {
+			`
+doodad {
+}
+`,
+			fileDesc.Messages().ByName(protoreflect.Name("WithNestedBlockNoLabelsSingleton")),
+			map[string]Presence{
+				`(hcl.testschema.WithNestedBlockNoLabelsSingleton).doodad`:      PresenceSet,
+				`(hcl.testschema.WithNestedBlockNoLabelsSingleton).doodad.name`: PresenceAbsent,
+			},
+			false,
+		},
+		"flattened": {
+			`species = "snake"`,
+			fileDesc.Messages().ByName(protoreflect.Name("WithFlattenStringAttr")),
+			map[string]Presence{
+				`(hcl.testschema.WithFlattenStringAttr).base.name`: PresenceAbsent,
+				`(hcl.testschema.WithFlattenStringAttr).species`:   PresenceSet,
+			},
+			false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			f, diags := hclsyntax.ParseConfig([]byte(test.config), "test.tf", hcl.InitialPos)
+			if diags.HasErrors() {
+				t.Fatalf("parse error: %s", diags)
+			}
+
+			_, presence, diags := DecodeBodyWithPresence(f.Body, test.desc, nil)
+			if diags.HasErrors() != test.wantError {
+				t.Fatalf("wrong errors: %s", diags)
+			}
+
+			got := make(map[string]Presence)
+			for _, path := range presence.Paths() {
+				got[path.String()] = presence.Presence(path)
+			}
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("wrong presence\n%s", diff)
+			}
+		})
+	}
+
+	t.Run("unknown path", func(t *testing.T) {
+		desc := fileDesc.Messages().ByName(protoreflect.Name("WithStringAttr"))
+		path := protopath.Path{
+			protopath.Root(desc),
+			protopath.FieldAccess(desc.Fields().ByName("name")),
+		}
+		var presence *PresenceMap
+		if got := presence.Presence(path); got != PresenceAbsent {
+			t.Errorf("wrong presence %s; want %s", got, PresenceAbsent)
+		}
+	})
+}
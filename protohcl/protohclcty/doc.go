@@ -0,0 +1,14 @@
+// Package protohclcty contains the low-level conversions between cty values
+// and protobuf field values that package protohcl uses for fields of scalar
+// kinds, such as numbers, strings, booleans, and enums.
+//
+// These conversions are exposed separately so that other libraries that
+// implement different mappings between HCL and protobuf can reuse the same
+// rules for details such as integer range checking and enum value naming,
+// and thus behave consistently with protohcl itself.
+//
+// This package deals only with the individual values of singular fields, or
+// with individual elements of repeated and map fields. It doesn't deal with
+// collections, messages, or protohcl's raw mode, which are the responsibility
+// of the caller.
+package protohclcty
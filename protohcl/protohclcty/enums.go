@@ -0,0 +1,52 @@
+package protohclcty
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/apparentlymart/go-protohcl/protohcl/protohclext"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// EnumValueName returns the string that selects the given enum value in
+// HCL configuration.
+//
+// This is the name given in the (hcl.enumval).name option, if present, or
+// the protobuf value name otherwise.
+func EnumValueName(desc protoreflect.EnumValueDescriptor) string {
+	if name := protohclext.EnumValueOption(desc).GetName(); name != "" {
+		return name
+	}
+	return string(desc.Name())
+}
+
+// EnumValueByName finds the value of the given enum type that is selected
+// by the given HCL string, or returns nil if there is no such value.
+func EnumValueByName(desc protoreflect.EnumDescriptor, name string) protoreflect.EnumValueDescriptor {
+	vals := desc.Values()
+	for i := 0; i < vals.Len(); i++ {
+		val := vals.Get(i)
+		if EnumValueName(val) == name {
+			return val
+		}
+	}
+	return nil
+}
+
+// enumNamesList returns a human-readable list of the names of all of the
+// values of the given enum type, for use in error messages.
+func enumNamesList(desc protoreflect.EnumDescriptor) string {
+	vals := desc.Values()
+	names := make([]string, vals.Len())
+	for i := range names {
+		names[i] = fmt.Sprintf("%q", EnumValueName(vals.Get(i)))
+	}
+	switch len(names) {
+	case 0:
+		return "(none)"
+	case 1:
+		return names[0]
+	default:
+		return strings.Join(names[:len(names)-1], ", ") + ", or " + names[len(names)-1]
+	}
+}
@@ -0,0 +1,252 @@
+package protohclcty
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/zclconf/go-cty/cty"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// ValueError is the error type returned by ToProto when the given value has
+// a suitable type but is not a valid value for the target field, such as
+// when a number is outside of the range of a fixed-size integer field.
+type ValueError struct {
+	// Requirement is a phrase describing the requirement that the value
+	// didn't meet, such that it completes the sentence "The value must
+	// be ...". For example: "a whole number".
+	Requirement string
+}
+
+func (err ValueError) Error() string {
+	return fmt.Sprintf("value must be %s", err.Requirement)
+}
+
+// Type returns the cty type that represents values of the given field's kind,
+// or returns an error if the kind is not one of the scalar kinds that this
+// package supports.
+//
+// For a repeated field, the result describes just one element of the list.
+// For a map field, pass the descriptor of the map's value field, as returned
+// by MapValue, rather than the map field itself.
+func Type(field protoreflect.FieldDescriptor) (cty.Type, error) {
+	switch field.Kind() {
+	case protoreflect.BoolKind:
+		return cty.Bool, nil
+	case protoreflect.EnumKind:
+		return cty.String, nil
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Uint32Kind, protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Uint64Kind, protoreflect.Sfixed32Kind, protoreflect.Fixed32Kind, protoreflect.Sfixed64Kind, protoreflect.Fixed64Kind, protoreflect.FloatKind, protoreflect.DoubleKind:
+		return cty.Number, nil
+	case protoreflect.StringKind:
+		return cty.String, nil
+	default:
+		return cty.NilType, fmt.Errorf("%s is not a supported scalar kind", field.Kind())
+	}
+}
+
+// ToProto converts the given value into a value suitable for the given
+// field, or for a single element of it if it is a repeated field.
+//
+// The given value must be known, must not be null, and must already conform
+// to the type returned by Type for the same field. ToProto panics if not.
+//
+// If the value has the correct type but isn't valid for the field, ToProto
+// returns an error of type ValueError.
+//
+// For float and double fields, a finite number too large in magnitude to be
+// represented by the field's type is a ValueError, rather than being rounded
+// to an infinity. Positive and negative infinity pass through as the
+// corresponding IEEE 754 infinities. Numbers too small in magnitude are
+// rounded to zero, and zero is always represented as positive zero. cty
+// numbers cannot be NaN, so a ToProto result is never NaN.
+func ToProto(val cty.Value, field protoreflect.FieldDescriptor) (protoreflect.Value, error) {
+	if wantTy, err := Type(field); err != nil {
+		return protoreflect.Value{}, err
+	} else if !val.Type().Equals(wantTy) {
+		panic(fmt.Sprintf("ToProto with %#v value for %s field", val.Type(), field.Kind()))
+	}
+
+	switch field.Kind() {
+	case protoreflect.BoolKind:
+		return protoreflect.ValueOfBool(val.True()), nil
+	case protoreflect.EnumKind:
+		enumDesc := field.Enum()
+		enumVal := EnumValueByName(enumDesc, val.AsString())
+		if enumVal == nil {
+			return protoreflect.ValueOfEnum(enumDesc.Values().Get(0).Number()), ValueError{
+				Requirement: enumNamesList(enumDesc),
+			}
+		}
+		return protoreflect.ValueOfEnum(enumVal.Number()), nil
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		bi, err := integerInRange(val, math.MinInt32, math.MaxInt32)
+		return protoreflect.ValueOfInt32(int32(bi.Int64())), err
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		bi, err := integerInRange(val, math.MinInt64, math.MaxInt64)
+		return protoreflect.ValueOfInt64(bi.Int64()), err
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		bi, err := integerInRange(val, 0, math.MaxUint32)
+		return protoreflect.ValueOfUint32(uint32(bi.Uint64())), err
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		bi, err := integerInRange(val, 0, math.MaxUint64)
+		return protoreflect.ValueOfUint64(bi.Uint64()), err
+	case protoreflect.FloatKind:
+		bf := val.AsBigFloat()
+		f, _ := bf.Float32()
+		if math.IsInf(float64(f), 0) && !bf.IsInf() {
+			return protoreflect.ValueOfFloat32(0), ValueError{
+				Requirement: fmt.Sprintf("between %g and %g", -math.MaxFloat32, math.MaxFloat32),
+			}
+		}
+		if f == 0 {
+			f = 0 // normalize negative zero, which cty doesn't distinguish
+		}
+		return protoreflect.ValueOfFloat32(f), nil
+	case protoreflect.DoubleKind:
+		bf := val.AsBigFloat()
+		f, _ := bf.Float64()
+		if math.IsInf(f, 0) && !bf.IsInf() {
+			return protoreflect.ValueOfFloat64(0), ValueError{
+				Requirement: fmt.Sprintf("between %g and %g", -math.MaxFloat64, math.MaxFloat64),
+			}
+		}
+		if f == 0 {
+			f = 0 // normalize negative zero, which cty doesn't distinguish
+		}
+		return protoreflect.ValueOfFloat64(f), nil
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString(val.AsString()), nil
+	default:
+		// Type should've rejected all other kinds above.
+		panic(fmt.Sprintf("unhandled %s for field %s", field.Kind(), field.FullName()))
+	}
+}
+
+// FromProto converts the given value of the given field, or of a single
+// element of it if it is a repeated field, into a cty value of the type
+// returned by Type for the same field.
+//
+// FromProto returns an error if the given value doesn't correspond with the
+// field kind, if it's an enum number that the enum type doesn't declare, or
+// if it's a floating point NaN, which cty numbers cannot represent. Floating
+// point infinities become cty.PositiveInfinity or cty.NegativeInfinity, and
+// negative zero becomes cty.Zero.
+func FromProto(val protoreflect.Value, field protoreflect.FieldDescriptor) (cty.Value, error) {
+	if _, err := Type(field); err != nil {
+		return cty.NilVal, err
+	}
+
+	switch raw := val.Interface().(type) {
+	case bool:
+		return cty.BoolVal(raw), nil
+	case int32:
+		return cty.NumberIntVal(int64(raw)), nil
+	case int64:
+		return cty.NumberIntVal(raw), nil
+	case uint32:
+		return cty.NumberUIntVal(uint64(raw)), nil
+	case uint64:
+		return cty.NumberUIntVal(raw), nil
+	case float32:
+		return floatFromProto(float64(raw))
+	case float64:
+		return floatFromProto(raw)
+	case string:
+		return cty.StringVal(raw), nil
+	case protoreflect.EnumNumber:
+		enumVal := field.Enum().Values().ByNumber(raw)
+		if enumVal == nil {
+			return cty.NilVal, fmt.Errorf("%s has no value numbered %d", field.Enum().FullName(), raw)
+		}
+		return cty.StringVal(EnumValueName(enumVal)), nil
+	default:
+		return cty.NilVal, fmt.Errorf("unsupported value of type %T for %s field", raw, field.Kind())
+	}
+}
+
+// ClampInteger returns the value in the range of the given integer-kinded
+// field that is nearest to the given whole number, and true, if the given
+// number is outside of that range.
+//
+// If the given value is already in range, isn't a whole number, or the field
+// isn't of an integer kind, ClampInteger returns the given value verbatim,
+// and false. Infinity isn't a whole number, so ClampInteger doesn't clamp
+// it and ToProto then rejects it, because an infinite value usually
+// results from a mistake such as dividing by zero.
+//
+// The given value must be a known, non-null number.
+func ClampInteger(val cty.Value, field protoreflect.FieldDescriptor) (cty.Value, bool) {
+	var min, max cty.Value
+	switch field.Kind() {
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		min, max = cty.NumberIntVal(math.MinInt32), cty.NumberIntVal(math.MaxInt32)
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		min, max = cty.NumberIntVal(math.MinInt64), cty.NumberIntVal(math.MaxInt64)
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		min, max = cty.Zero, cty.NumberUIntVal(math.MaxUint32)
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		min, max = cty.Zero, cty.NumberUIntVal(math.MaxUint64)
+	default:
+		return val, false
+	}
+
+	if !val.AsBigFloat().IsInt() {
+		return val, false
+	}
+	switch {
+	case val.LessThan(min).True():
+		return min, true
+	case val.GreaterThan(max).True():
+		return max, true
+	default:
+		return val, false
+	}
+}
+
+// integerInRange checks that the value is an integer within the given range,
+// and if so returns it as a *big.Int so that the caller can then convert it
+// from there to a suitable fixed-size integer type.
+//
+// This function always returns a non-nil *big.Int, but if it also returns
+// an error then that integer might not be in range.
+func integerInRange(val cty.Value, min int64, max uint64) (*big.Int, error) {
+	bf := val.AsBigFloat()
+	if bf.IsInf() {
+		// big.Float.Int returns nil for an infinity.
+		return new(big.Int), ValueError{Requirement: "a whole number"}
+	}
+	bi, _ := bf.Int(nil)
+	if !bf.IsInt() {
+		return bi, ValueError{Requirement: "a whole number"}
+	}
+
+	bigMin := big.NewInt(min)
+	if cmpMin := bi.Cmp(bigMin); cmpMin < 0 {
+		return bi, ValueError{Requirement: fmt.Sprintf("greater than or equal to %d", min)}
+	}
+	bigMax := big.NewInt(0)
+	bigMax.SetUint64(max)
+	if cmpMax := bi.Cmp(bigMax); cmpMax > 0 {
+		return bi, ValueError{Requirement: fmt.Sprintf("less than or equal to %d", max)}
+	}
+
+	return bi, nil
+}
+
+// floatFromProto is the part of FromProto that deals with both float and
+// double fields, with float values widened to float64 first.
+func floatFromProto(f float64) (cty.Value, error) {
+	switch {
+	case math.IsNaN(f):
+		return cty.NilVal, fmt.Errorf("NaN is not a supported number value")
+	case math.IsInf(f, 1):
+		return cty.PositiveInfinity, nil
+	case math.IsInf(f, -1):
+		return cty.NegativeInfinity, nil
+	case f == 0:
+		return cty.Zero, nil
+	default:
+		return cty.NumberFloatVal(f), nil
+	}
+}
@@ -0,0 +1,253 @@
+package protohclcty
+
+import (
+	"math"
+	"testing"
+
+	"github.com/apparentlymart/go-protohcl/protohcl/internal/testschema"
+	"github.com/google/go-cmp/cmp"
+	"github.com/zclconf/go-cty-debug/ctydebug"
+	"github.com/zclconf/go-cty/cty"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func TestToProto(t *testing.T) {
+	fields := testScalarFields(t)
+
+	tests := []struct {
+		field   string
+		val     cty.Value
+		want    protoreflect.Value
+		wantErr string
+	}{
+		{"bool", cty.True, protoreflect.ValueOfBool(true), ``},
+		{"string", cty.StringVal("hello"), protoreflect.ValueOfString("hello"), ``},
+		{"int32", cty.NumberIntVal(-5), protoreflect.ValueOfInt32(-5), ``},
+		{"int32", cty.NumberFloatVal(1.5), protoreflect.Value{}, `value must be a whole number`},
+		{"int32", cty.NumberIntVal(1 << 31), protoreflect.Value{}, `value must be less than or equal to 2147483647`},
+		{"int32", cty.PositiveInfinity, protoreflect.Value{}, `value must be a whole number`},
+		{"int64", cty.NumberIntVal(1 << 40), protoreflect.ValueOfInt64(1 << 40), ``},
+		{"int64", cty.NegativeInfinity, protoreflect.Value{}, `value must be a whole number`},
+		{"uint32", cty.PositiveInfinity, protoreflect.Value{}, `value must be a whole number`},
+		{"uint64", cty.NegativeInfinity, protoreflect.Value{}, `value must be a whole number`},
+		{"uint32", cty.NumberIntVal(-1), protoreflect.Value{}, `value must be greater than or equal to 0`},
+		{"uint64", cty.NumberUIntVal(1 << 63), protoreflect.ValueOfUint64(1 << 63), ``},
+		{"float", cty.NumberFloatVal(0.5), protoreflect.ValueOfFloat32(0.5), ``},
+		{"double", cty.NumberFloatVal(0.25), protoreflect.ValueOfFloat64(0.25), ``},
+		{"float", cty.NumberFloatVal(1e39), protoreflect.Value{}, `value must be between -3.4028234663852886e+38 and 3.4028234663852886e+38`},
+		{"float", cty.PositiveInfinity, protoreflect.ValueOfFloat32(float32(math.Inf(1))), ``},
+		{"float", cty.NumberFloatVal(math.Copysign(0, -1)), protoreflect.ValueOfFloat32(0), ``},
+		{"double", cty.MustParseNumberVal("1e400"), protoreflect.Value{}, `value must be between -1.7976931348623157e+308 and 1.7976931348623157e+308`},
+		{"double", cty.NegativeInfinity, protoreflect.ValueOfFloat64(math.Inf(-1)), ``},
+		{"double", cty.NumberFloatVal(math.Copysign(0, -1)), protoreflect.ValueOfFloat64(0), ``},
+		{"enum", cty.StringVal("debug"), protoreflect.ValueOfEnum(1), ``},
+		{"enum", cty.StringVal("LEVEL_UNSPECIFIED"), protoreflect.ValueOfEnum(0), ``},
+		{"enum", cty.StringVal("LEVEL_DEBUG"), protoreflect.Value{}, `value must be "LEVEL_UNSPECIFIED", "debug", or "info"`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.field+" "+test.val.GoString(), func(t *testing.T) {
+			got, err := ToProto(test.val, fields[test.field])
+
+			if test.wantErr != "" {
+				if err == nil {
+					t.Fatalf("unexpected success\nwant error: %s", test.wantErr)
+				}
+				if _, ok := err.(ValueError); !ok {
+					t.Errorf("wrong error type %T; want ValueError", err)
+				}
+				if got := err.Error(); got != test.wantErr {
+					t.Errorf("wrong error\ngot error:  %s\nwant error: %s", got, test.wantErr)
+				}
+				return // the result is unspecified when there's an error
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if got.Interface() != test.want.Interface() {
+				t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got.Interface(), test.want.Interface())
+			}
+			if test.field == "float" || test.field == "double" {
+				// Negative zero compares equal to positive zero, so we
+				// must check the sign separately.
+				if math.Signbit(got.Float()) != math.Signbit(test.want.Float()) {
+					t.Errorf("wrong sign\ngot:  %#v\nwant: %#v", got.Interface(), test.want.Interface())
+				}
+			}
+		})
+	}
+}
+
+func TestFromProto(t *testing.T) {
+	fields := testScalarFields(t)
+
+	tests := []struct {
+		field   string
+		val     protoreflect.Value
+		want    cty.Value
+		wantErr string
+	}{
+		{"bool", protoreflect.ValueOfBool(true), cty.True, ``},
+		{"string", protoreflect.ValueOfString("hello"), cty.StringVal("hello"), ``},
+		{"int32", protoreflect.ValueOfInt32(-5), cty.NumberIntVal(-5), ``},
+		{"uint64", protoreflect.ValueOfUint64(1 << 63), cty.NumberUIntVal(1 << 63), ``},
+		{"double", protoreflect.ValueOfFloat64(0.25), cty.NumberFloatVal(0.25), ``},
+		{"double", protoreflect.ValueOfFloat64(math.Inf(1)), cty.PositiveInfinity, ``},
+		{"double", protoreflect.ValueOfFloat64(math.Copysign(0, -1)), cty.Zero, ``},
+		{"double", protoreflect.ValueOfFloat64(math.NaN()), cty.NilVal, `NaN is not a supported number value`},
+		{"float", protoreflect.ValueOfFloat32(float32(math.Inf(-1))), cty.NegativeInfinity, ``},
+		{"float", protoreflect.ValueOfFloat32(float32(math.NaN())), cty.NilVal, `NaN is not a supported number value`},
+		{"enum", protoreflect.ValueOfEnum(2), cty.StringVal("info"), ``},
+		{"enum", protoreflect.ValueOfEnum(0), cty.StringVal("LEVEL_UNSPECIFIED"), ``},
+		{"enum", protoreflect.ValueOfEnum(12), cty.NilVal, `hcl.testschema.Level has no value numbered 12`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.field+" "+test.val.String(), func(t *testing.T) {
+			got, err := FromProto(test.val, fields[test.field])
+
+			if test.wantErr != "" {
+				if err == nil {
+					t.Fatalf("unexpected success\nwant error: %s", test.wantErr)
+				}
+				if got := err.Error(); got != test.wantErr {
+					t.Errorf("wrong error\ngot error:  %s\nwant error: %s", got, test.wantErr)
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if diff := cmp.Diff(test.want, got, ctydebug.CmpOptions); diff != "" {
+				t.Errorf("wrong result\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestType(t *testing.T) {
+	fields := testScalarFields(t)
+
+	tests := map[string]cty.Type{
+		"bool":   cty.Bool,
+		"string": cty.String,
+		"int32":  cty.Number,
+		"int64":  cty.Number,
+		"uint32": cty.Number,
+		"uint64": cty.Number,
+		"float":  cty.Number,
+		"double": cty.Number,
+		"enum":   cty.String,
+	}
+	for name, want := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := Type(fields[name])
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !got.Equals(want) {
+				t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, want)
+			}
+		})
+	}
+
+	t.Run("bytes", func(t *testing.T) {
+		_, err := Type(fields["bytes"])
+		if err == nil {
+			t.Fatalf("unexpected success")
+		}
+		if got, want := err.Error(), `bytes is not a supported scalar kind`; got != want {
+			t.Errorf("wrong error\ngot error:  %s\nwant error: %s", got, want)
+		}
+	})
+}
+
+// testScalarFields returns field descriptors of various scalar kinds, keyed
+// by the names of their kinds.
+func testScalarFields(t *testing.T) map[string]protoreflect.FieldDescriptor {
+	t.Helper()
+
+	kinds := []struct {
+		name string
+		ty   descriptorpb.FieldDescriptorProto_Type
+	}{
+		{"bool", descriptorpb.FieldDescriptorProto_TYPE_BOOL},
+		{"string", descriptorpb.FieldDescriptorProto_TYPE_STRING},
+		{"int32", descriptorpb.FieldDescriptorProto_TYPE_INT32},
+		{"int64", descriptorpb.FieldDescriptorProto_TYPE_INT64},
+		{"uint32", descriptorpb.FieldDescriptorProto_TYPE_UINT32},
+		{"uint64", descriptorpb.FieldDescriptorProto_TYPE_UINT64},
+		{"float", descriptorpb.FieldDescriptorProto_TYPE_FLOAT},
+		{"double", descriptorpb.FieldDescriptorProto_TYPE_DOUBLE},
+		{"bytes", descriptorpb.FieldDescriptorProto_TYPE_BYTES},
+	}
+	msg := &descriptorpb.DescriptorProto{
+		Name: proto.String("Scalars"),
+	}
+	for i, kind := range kinds {
+		msg.Field = append(msg.Field, &descriptorpb.FieldDescriptorProto{
+			Name:   proto.String(kind.name),
+			Number: proto.Int32(int32(i + 1)),
+			Label:  descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+			Type:   kind.ty.Enum(),
+		})
+	}
+	file, err := protodesc.NewFile(&descriptorpb.FileDescriptorProto{
+		Name:        proto.String("protohclcty_test.proto"),
+		Package:     proto.String("protohclcty.test"),
+		Syntax:      proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{msg},
+	}, nil)
+	if err != nil {
+		t.Fatalf("invalid test descriptor: %s", err)
+	}
+
+	ret := make(map[string]protoreflect.FieldDescriptor)
+	fields := file.Messages().Get(0).Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		ret[string(field.Name())] = field
+	}
+	// For enums we'll borrow a field from testschema, so that we can also
+	// test the (hcl.enumval) option.
+	ret["enum"] = testschema.File_testschema_proto.Messages().ByName("WithEnumAttr").Fields().ByName("level")
+	return ret
+}
+
+func TestClampInteger(t *testing.T) {
+	fields := testScalarFields(t)
+
+	tests := []struct {
+		field       string
+		val         cty.Value
+		want        cty.Value
+		wantClamped bool
+	}{
+		{"int32", cty.NumberIntVal(5), cty.NumberIntVal(5), false},
+		{"int32", cty.NumberIntVal(1 << 31), cty.NumberIntVal(1<<31 - 1), true},
+		{"int32", cty.NumberIntVal(-1<<31 - 1), cty.NumberIntVal(-1 << 31), true},
+		{"uint32", cty.NumberIntVal(-1), cty.Zero, true},
+		{"uint64", cty.MustParseNumberVal("18446744073709551616"), cty.NumberUIntVal(1<<64 - 1), true},
+		{"int64", cty.MustParseNumberVal("1e100"), cty.NumberIntVal(1<<63 - 1), true},
+		{"int32", cty.NumberFloatVal(1e10 + 0.5), cty.NumberFloatVal(1e10 + 0.5), false},
+		{"double", cty.NumberFloatVal(1e100), cty.NumberFloatVal(1e100), false},
+		{"int64", cty.PositiveInfinity, cty.PositiveInfinity, false},
+		{"uint32", cty.NegativeInfinity, cty.NegativeInfinity, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.field+" "+test.val.GoString(), func(t *testing.T) {
+			got, gotClamped := ClampInteger(test.val, fields[test.field])
+
+			if gotClamped != test.wantClamped {
+				t.Errorf("wrong clamped flag\ngot:  %t\nwant: %t", gotClamped, test.wantClamped)
+			}
+			if !got.RawEquals(test.want) {
+				t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, test.want)
+			}
+		})
+	}
+}
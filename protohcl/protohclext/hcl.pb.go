@@ -21,6 +21,61 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// Selects how to resolve a conflict between attributes of a flattened
+// message and attributes of the message it's flattened into.
+type FlattenConflict int32
+
+const (
+	// CONFLICT_ERROR treats any conflict as a schema error. This is the
+	// default.
+	FlattenConflict_CONFLICT_ERROR FlattenConflict = 0
+	// OUTER_WINS gives the attribute to the containing message's field.
+	FlattenConflict_OUTER_WINS FlattenConflict = 1
+	// INNER_WINS gives the attribute to the nested message's field.
+	FlattenConflict_INNER_WINS FlattenConflict = 2
+)
+
+// Enum value maps for FlattenConflict.
+var (
+	FlattenConflict_name = map[int32]string{
+		0: "CONFLICT_ERROR",
+		1: "OUTER_WINS",
+		2: "INNER_WINS",
+	}
+	FlattenConflict_value = map[string]int32{
+		"CONFLICT_ERROR": 0,
+		"OUTER_WINS":     1,
+		"INNER_WINS":     2,
+	}
+)
+
+func (x FlattenConflict) Enum() *FlattenConflict {
+	p := new(FlattenConflict)
+	*p = x
+	return p
+}
+
+func (x FlattenConflict) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (FlattenConflict) Descriptor() protoreflect.EnumDescriptor {
+	return file_hcl_proto_enumTypes[0].Descriptor()
+}
+
+func (FlattenConflict) Type() protoreflect.EnumType {
+	return &file_hcl_proto_enumTypes[0]
+}
+
+func (x FlattenConflict) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use FlattenConflict.Descriptor instead.
+func (FlattenConflict) EnumDescriptor() ([]byte, []int) {
+	return file_hcl_proto_rawDescGZIP(), []int{0}
+}
+
 type Attribute_RawMode int32
 
 const (
@@ -28,10 +83,30 @@ const (
 	// MessagePack is the most expressive wire encoding for HCL values,
 	// because it's able to preserve unknown values using a MessagePack
 	// extension type.
+	//
+	// The Go function protohcl.FormatRawMessagePack documents the details
+	// of this encoding, and protohcl/testdata/raw_msgpack_vectors.json has
+	// examples for testing implementations in other languages.
 	Attribute_MESSAGEPACK Attribute_RawMode = 1
 	// JSON can encode all _known_ HCL values, but cannot encode unknown
 	// values.
+	//
+	// If the type constraint is "any" then the field contains a JSON object
+	// with properties "version" (currently always 1), "type", and "value",
+	// so that the recipient can recover the value's type. The Go functions
+	// protohcl.FormatRawDynamic and protohcl.ParseRawDynamic document this
+	// format in more detail.
 	Attribute_JSON Attribute_RawMode = 2
+	// YAML uses the mapping between HCL values and YAML that is
+	// implemented by the Go module github.com/zclconf/go-cty-yaml, for
+	// recipients that already consume YAML. It cannot encode unknown
+	// values.
+	//
+	// Unlike the other encodings, the YAML encoding of a value for the
+	// type constraint "any" doesn't record the value's exact type, so the
+	// recipient sees the type implied by the YAML document itself: a
+	// sequence is a tuple and a mapping is an object, for example.
+	Attribute_YAML Attribute_RawMode = 3
 )
 
 // Enum value maps for Attribute_RawMode.
@@ -40,11 +115,13 @@ var (
 		0: "NOT_RAW",
 		1: "MESSAGEPACK",
 		2: "JSON",
+		3: "YAML",
 	}
 	Attribute_RawMode_value = map[string]int32{
 		"NOT_RAW":     0,
 		"MESSAGEPACK": 1,
 		"JSON":        2,
+		"YAML":        3,
 	}
 )
 
@@ -59,11 +136,11 @@ func (x Attribute_RawMode) String() string {
 }
 
 func (Attribute_RawMode) Descriptor() protoreflect.EnumDescriptor {
-	return file_hcl_proto_enumTypes[0].Descriptor()
+	return file_hcl_proto_enumTypes[1].Descriptor()
 }
 
 func (Attribute_RawMode) Type() protoreflect.EnumType {
-	return &file_hcl_proto_enumTypes[0]
+	return &file_hcl_proto_enumTypes[1]
 }
 
 func (x Attribute_RawMode) Number() protoreflect.EnumNumber {
@@ -96,6 +173,10 @@ const (
 	// SET selects set mode, which requires an exact element type, and so it is
 	// valid only for nested blocks that have no "any" type constraints within.
 	NestedBlock_SET NestedBlock_CollectionKind = 3
+	// MAP selects map mode, which produces a map-typed value whose keys are
+	// the values of the map key label of each block. This is the only valid
+	// mode for map fields, and so for map fields AUTO is the same as MAP.
+	NestedBlock_MAP NestedBlock_CollectionKind = 4
 )
 
 // Enum value maps for NestedBlock_CollectionKind.
@@ -105,12 +186,14 @@ var (
 		1: "TUPLE",
 		2: "LIST",
 		3: "SET",
+		4: "MAP",
 	}
 	NestedBlock_CollectionKind_value = map[string]int32{
 		"AUTO":  0,
 		"TUPLE": 1,
 		"LIST":  2,
 		"SET":   3,
+		"MAP":   4,
 	}
 )
 
@@ -125,11 +208,11 @@ func (x NestedBlock_CollectionKind) String() string {
 }
 
 func (NestedBlock_CollectionKind) Descriptor() protoreflect.EnumDescriptor {
-	return file_hcl_proto_enumTypes[1].Descriptor()
+	return file_hcl_proto_enumTypes[2].Descriptor()
 }
 
 func (NestedBlock_CollectionKind) Type() protoreflect.EnumType {
-	return &file_hcl_proto_enumTypes[1]
+	return &file_hcl_proto_enumTypes[2]
 }
 
 func (x NestedBlock_CollectionKind) Number() protoreflect.EnumNumber {
@@ -141,6 +224,55 @@ func (NestedBlock_CollectionKind) EnumDescriptor() ([]byte, []int) {
 	return file_hcl_proto_rawDescGZIP(), []int{1, 0}
 }
 
+type Diagnostic_Severity int32
+
+const (
+	Diagnostic_SEVERITY_UNSPECIFIED Diagnostic_Severity = 0
+	Diagnostic_ERROR                Diagnostic_Severity = 1
+	Diagnostic_WARNING              Diagnostic_Severity = 2
+)
+
+// Enum value maps for Diagnostic_Severity.
+var (
+	Diagnostic_Severity_name = map[int32]string{
+		0: "SEVERITY_UNSPECIFIED",
+		1: "ERROR",
+		2: "WARNING",
+	}
+	Diagnostic_Severity_value = map[string]int32{
+		"SEVERITY_UNSPECIFIED": 0,
+		"ERROR":                1,
+		"WARNING":              2,
+	}
+)
+
+func (x Diagnostic_Severity) Enum() *Diagnostic_Severity {
+	p := new(Diagnostic_Severity)
+	*p = x
+	return p
+}
+
+func (x Diagnostic_Severity) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Diagnostic_Severity) Descriptor() protoreflect.EnumDescriptor {
+	return file_hcl_proto_enumTypes[3].Descriptor()
+}
+
+func (Diagnostic_Severity) Type() protoreflect.EnumType {
+	return &file_hcl_proto_enumTypes[3]
+}
+
+func (x Diagnostic_Severity) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Diagnostic_Severity.Descriptor instead.
+func (Diagnostic_Severity) EnumDescriptor() ([]byte, []int) {
+	return file_hcl_proto_rawDescGZIP(), []int{8, 0}
+}
+
 // Specifies that a particular field should recieve the value of an HCL
 // attribute.
 type Attribute struct {
@@ -173,7 +305,29 @@ type Attribute struct {
 	// - An object type may encode into a message type whose fields also have
 	//   HCL annotations, as long as all of the fields decode as attributes.
 	//   Decoding as nested block is not valid inside an attribute, because
-	//   only nested blocks can contain other nested blocks.
+	//   only nested blocks can contain other nested blocks. Without an
+	//   explicit type constraint, such a field automatically gets the object
+	//   type constraint of its message type, or a list or map of it for a
+	//   repeated or map field.
+	// - A singular field of one of the google.protobuf wrapper message types,
+	//   such as google.protobuf.StringValue, accepts whatever type constraints
+	//   its wrapped scalar type would accept, and gets that scalar type's
+	//   type constraint automatically. A null value leaves the field unset,
+	//   and an unset field represents a null value, unlike a scalar field
+	//   without explicit presence. google.protobuf.BytesValue isn't supported.
+	// - A singular google.protobuf.Timestamp field accepts a string in
+	//   RFC 3339 format, such as "2006-01-02T15:04:05Z", and gets the string
+	//   type constraint automatically.
+	// - A singular google.protobuf.Duration field accepts either a duration
+	//   string such as "1h30m", using the syntax of Go's time.ParseDuration,
+	//   or a number of seconds. It gets the string type constraint
+	//   automatically, which also accepts a number.
+	// - A singular google.protobuf.Struct field accepts an object or map type
+	//   constraint, and a singular google.protobuf.ListValue field accepts a
+	//   list, set, or tuple type constraint. Both get the "any" type
+	//   constraint automatically, but still accept only an object or list
+	//   value respectively, which they store in the same way as its JSON
+	//   representation.
 	// - Any type constraint at all is valid if the proto field type is "bytes"
 	//   AND if you also populate field "raw" with raw value encoding settings.
 	//   You can choose a dynamic type constraint if you need protohcl to also
@@ -182,6 +336,14 @@ type Attribute struct {
 	//   the raw mode, because there is no direct analog in protobuf and
 	//   tuple types are rarely used directly as attribute type constraints
 	//   anyway. If you need one, use raw mode.
+	//
+	// In addition to HCL's usual type expression syntax, the type constraint
+	// can use msg("full.Name") to stand for the object type constraint of
+	// another annotated message type, such as list(msg("example.Rule")),
+	// instead of repeating that message type's attributes as an object type.
+	// The message type must be declared in the same file as the field or in
+	// a file that it imports, and it can't refer back to the message type
+	// that contains the field.
 	Type string `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
 	// For "bytes" fields only, protohcl can preserve the resulting HCL value
 	// by encoding as an inner encoding format, which therefore allows
@@ -189,6 +351,103 @@ type Attribute struct {
 	// losing type information. This must always be unset for other field types.
 	// Any field with "raw" set MUST also set "type".
 	Raw Attribute_RawMode `protobuf:"varint,4,opt,name=raw,proto3,enum=hcl.Attribute_RawMode" json:"raw,omitempty"`
+	// For "repeated" fields only, set allow_scalar_for_list to also accept a
+	// single non-collection value, which protohcl will then treat as if it
+	// were a list containing only that value. This can be helpful for
+	// attributes where authors commonly need only one element, but it does
+	// make the configuration language less regular, so use it sparingly.
+	AllowScalarForList bool `protobuf:"varint,5,opt,name=allow_scalar_for_list,json=allowScalarForList,proto3" json:"allow_scalar_for_list,omitempty"`
+	// For numeric fields only, set unit to the name of the unit that the
+	// field's value is measured in to also accept human-friendly strings
+	// that include a unit suffix, which protohcl converts into the declared
+	// unit. The supported units are:
+	// - "bytes", accepting suffixes like "B", "KB", "MiB", and "GiB".
+	// - "seconds", accepting suffixes like "ms", "s", "m", and "h".
+	//
+	// A plain number is still accepted, and is interpreted as already being
+	// in the declared unit. The attribute's type constraint is string, so
+	// that ObjectValueForMessage can also format the value using the
+	// largest suffix that represents it exactly, such as "10GiB". A field
+	// with unit set must not also set "type" or "raw".
+	Unit string `protobuf:"bytes,6,opt,name=unit,proto3" json:"unit,omitempty"`
+	// Set include_range_into to the name of another field in the same message,
+	// of type hcl.SourceRange, to have the decoder also record the source
+	// range of the attribute's value expression in that field. This allows
+	// the recipient of the message to report errors about the value with an
+	// exact source location. The decoder records ranges only for attributes
+	// decoded directly from an HCL body, and not for attributes of messages
+	// decoded from object values. The named field must not have any HCL
+	// annotations of its own.
+	IncludeRangeInto string `protobuf:"bytes,7,opt,name=include_range_into,json=includeRangeInto,proto3" json:"include_range_into,omitempty"`
+	// For a repeated field whose element type is a message type, set kind to
+	// choose the collection type of the attribute's type constraint, in the
+	// same way that (hcl.block).kind does for nested block types. In
+	// particular, TUPLE allows each element to have a different type when the
+	// message type's attributes have "any" type constraints, while LIST and
+	// SET require all elements to have the same type.
+	//
+	// The default, AUTO, selects LIST unless the message type has attributes
+	// with "any" type constraints, in which case it selects TUPLE. MAP is not
+	// valid for attributes; use a map field instead.
+	Kind NestedBlock_CollectionKind `protobuf:"varint,8,opt,name=kind,proto3,enum=hcl.NestedBlock_CollectionKind" json:"kind,omitempty"`
+	// For singular string fields without explicit presence only, set
+	// empty_as_null to treat an empty string as if it were null. Decoding an
+	// empty string then leaves the field unset, in the same way as decoding
+	// null, and ObjectValueForMessage returns null for an unset field rather
+	// than an empty string. This is helpful for attributes where an empty
+	// string isn't meaningful, so that the recipient doesn't need to
+	// handle both representations of an omitted value.
+	EmptyAsNull bool `protobuf:"varint,9,opt,name=empty_as_null,json=emptyAsNull,proto3" json:"empty_as_null,omitempty"`
+	// For singular attributes whose type constraint is number only, set
+	// number_syntax to also accept strings using the given extensions to
+	// HCL's number syntax. Configuration authors often paste values like
+	// "10_000_000" from other sources, which HCL would otherwise reject
+	// because the value isn't a valid number.
+	//
+	// HCL's number literals can't use these extensions, so they are
+	// available only when the value is a quoted string. The decoder always
+	// stores the canonical decimal representation of the number, so the
+	// recipient of the message, including a proto string field with type
+	// "number", never needs to handle these extensions itself. Likewise,
+	// ObjectValueForMessage and NormalizeMessage present a string field
+	// that was populated some other way in its canonical form.
+	NumberSyntax *Attribute_NumberSyntax `protobuf:"bytes,10,opt,name=number_syntax,json=numberSyntax,proto3" json:"number_syntax,omitempty"`
+	// For repeated fields only, set ignore_null_in_lists to discard any null
+	// elements of the attribute's value while decoding, so that the field
+	// gets only the non-null elements. Without this option a null element is
+	// an error, because a protobuf repeated field can't represent it.
+	//
+	// This is helpful for attributes whose values are often built with
+	// conditional expressions that produce null to mean "no element".
+	IgnoreNullInLists bool `protobuf:"varint,11,opt,name=ignore_null_in_lists,json=ignoreNullInLists,proto3" json:"ignore_null_in_lists,omitempty"`
+	// default_expr is an HCL native syntax expression that provides the
+	// attribute's value when the configuration doesn't define it. Unlike a
+	// protobuf default, the expression can refer to the other attributes of
+	// the same body by name, so that e.g. default_expr = "name" on a
+	// display_name attribute makes the display name match the name unless
+	// the configuration overrides it.
+	//
+	// Default expressions are evaluated after all of the attributes that the
+	// configuration defines, and may refer to other attributes that have
+	// default expressions themselves, as long as they don't refer to each
+	// other in a cycle. A name that isn't an attribute of the body refers to
+	// a variable from the evaluation context, as usual.
+	//
+	// A required attribute can't have a default expression.
+	DefaultExpr string `protobuf:"bytes,12,opt,name=default_expr,json=defaultExpr,proto3" json:"default_expr,omitempty"`
+	// For a singular google.protobuf.Any field, any_type is the full name of
+	// the message type that the attribute's value decodes as, in the same way
+	// as for an attribute of that message type. The decoder packs the
+	// resulting message into the Any field. Without an explicit type
+	// constraint, the attribute accepts any object value that suits the
+	// message type.
+	//
+	// By default the message type must be declared in the same file as the
+	// Any field or in a file that it imports, but a host application can
+	// instead supply its own registry of message types to choose from, so
+	// that the same schema can select a different message type for each
+	// host or plugin that uses it.
+	AnyType string `protobuf:"bytes,13,opt,name=any_type,json=anyType,proto3" json:"any_type,omitempty"`
 }
 
 func (x *Attribute) Reset() {
@@ -251,10 +510,77 @@ func (x *Attribute) GetRaw() Attribute_RawMode {
 	return Attribute_NOT_RAW
 }
 
+func (x *Attribute) GetAllowScalarForList() bool {
+	if x != nil {
+		return x.AllowScalarForList
+	}
+	return false
+}
+
+func (x *Attribute) GetUnit() string {
+	if x != nil {
+		return x.Unit
+	}
+	return ""
+}
+
+func (x *Attribute) GetIncludeRangeInto() string {
+	if x != nil {
+		return x.IncludeRangeInto
+	}
+	return ""
+}
+
+func (x *Attribute) GetKind() NestedBlock_CollectionKind {
+	if x != nil {
+		return x.Kind
+	}
+	return NestedBlock_AUTO
+}
+
+func (x *Attribute) GetEmptyAsNull() bool {
+	if x != nil {
+		return x.EmptyAsNull
+	}
+	return false
+}
+
+func (x *Attribute) GetNumberSyntax() *Attribute_NumberSyntax {
+	if x != nil {
+		return x.NumberSyntax
+	}
+	return nil
+}
+
+func (x *Attribute) GetIgnoreNullInLists() bool {
+	if x != nil {
+		return x.IgnoreNullInLists
+	}
+	return false
+}
+
+func (x *Attribute) GetDefaultExpr() string {
+	if x != nil {
+		return x.DefaultExpr
+	}
+	return ""
+}
+
+func (x *Attribute) GetAnyType() string {
+	if x != nil {
+		return x.AnyType
+	}
+	return ""
+}
+
 // Specifies that a particular field should recieve content from a nested
 // HCL block. This decoding mode is only supported for message-typed fields.
 // Mark the field as "repeated" to accept multiple nested blocks of the same
 // type, preserving the source declaration order.
+//
+// Singular nested block fields can also be members of a oneof, in which case
+// at most one of the oneof's block types may appear in the body. All of the
+// members of such a oneof must be nested block fields.
 type NestedBlock struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -272,6 +598,45 @@ type NestedBlock struct {
 	// The collection kind is not considered when decoding from hcl.Body into
 	// a message.
 	Kind NestedBlock_CollectionKind `protobuf:"varint,2,opt,name=kind,proto3,enum=hcl.NestedBlock_CollectionKind" json:"kind,omitempty"`
+	// A map field whose value type is a message type can also represent a
+	// nested block type. In that case each block has an additional label,
+	// before any labels declared in the value message type, whose value is the
+	// block's key in the map. Two blocks of the same type must not have the
+	// same key.
+	//
+	// Use map_key_label to set the name of that label, as it would appear in
+	// error messages. If not set, the label is named "name".
+	MapKeyLabel string `protobuf:"bytes,3,opt,name=map_key_label,json=mapKeyLabel,proto3" json:"map_key_label,omitempty"`
+	// Aliases are other block type names that the configuration may use for
+	// this block type, such as the former names of a renamed block type.
+	// The decoder treats a block using one of these names as if it used
+	// type_name instead, but returns a warning recommending type_name.
+	//
+	// Each alias shares the namespace of the containing body's attributes,
+	// block types, and block labels, so it must not conflict with any of
+	// them.
+	Aliases []string `protobuf:"bytes,4,rep,name=aliases,proto3" json:"aliases,omitempty"`
+	// For a map field, map_key_attr can instead select an attribute of the
+	// value message type whose value is each block's key in the map, so that
+	// the blocks don't have the extra label. The attribute must be a required
+	// attribute of type string, declared directly in the value message type.
+	// The message in the map still includes the attribute's value.
+	//
+	// map_key_attr and map_key_label are mutually exclusive.
+	MapKeyAttr string `protobuf:"bytes,5,opt,name=map_key_attr,json=mapKeyAttr,proto3" json:"map_key_attr,omitempty"`
+	// For a singular google.protobuf.Any field, any_discriminator names an
+	// attribute whose value selects the message type that the block's content
+	// decodes as, from the types listed in any_types. The decoder packs the
+	// resulting message into the Any field.
+	//
+	// The attribute must be a string attribute declared directly in the same
+	// message type as the Any field, and it must be declared before the Any
+	// field so that the decoder has already decoded it. The message types in
+	// any_types must not declare any block labels.
+	AnyDiscriminator string `protobuf:"bytes,6,opt,name=any_discriminator,json=anyDiscriminator,proto3" json:"any_discriminator,omitempty"`
+	// The message types that an Any field with any_discriminator can contain,
+	// each selected by a different value of the discriminator attribute.
+	AnyTypes []*NestedBlock_AnyType `protobuf:"bytes,7,rep,name=any_types,json=anyTypes,proto3" json:"any_types,omitempty"`
 }
 
 func (x *NestedBlock) Reset() {
@@ -320,6 +685,41 @@ func (x *NestedBlock) GetKind() NestedBlock_CollectionKind {
 	return NestedBlock_AUTO
 }
 
+func (x *NestedBlock) GetMapKeyLabel() string {
+	if x != nil {
+		return x.MapKeyLabel
+	}
+	return ""
+}
+
+func (x *NestedBlock) GetAliases() []string {
+	if x != nil {
+		return x.Aliases
+	}
+	return nil
+}
+
+func (x *NestedBlock) GetMapKeyAttr() string {
+	if x != nil {
+		return x.MapKeyAttr
+	}
+	return ""
+}
+
+func (x *NestedBlock) GetAnyDiscriminator() string {
+	if x != nil {
+		return x.AnyDiscriminator
+	}
+	return ""
+}
+
+func (x *NestedBlock) GetAnyTypes() []*NestedBlock_AnyType {
+	if x != nil {
+		return x.AnyTypes
+	}
+	return nil
+}
+
 // Specifies that a particular field should recieve content from a label
 // of the block being decoded. This makes sense only for message types
 // that are representing nested blocks.
@@ -327,6 +727,10 @@ func (x *NestedBlock) GetKind() NestedBlock_CollectionKind {
 // are required for the corresponding block type. The name assigned to
 // each label is used only for error messages when the configuration author
 // does not write the correct number of labels.
+//
+// Label fields inside a message that is flattened into a block's message
+// count as labels of that block, taking the position of the flatten-annotated
+// field in the overall label order.
 type BlockLabel struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -335,6 +739,11 @@ type BlockLabel struct {
 	// Name is the name of this label to be used in error messages. This must be
 	// set to declare that a field represents an HCL nested block.
 	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Description explains what the label means, as one or more complete
+	// sentences. The decoder includes it in error messages about blocks that
+	// have the wrong number of labels, and documentation generators can use it
+	// instead of the field's comments.
+	Description string `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
 }
 
 func (x *BlockLabel) Reset() {
@@ -376,153 +785,1265 @@ func (x *BlockLabel) GetName() string {
 	return ""
 }
 
-var file_hcl_proto_extTypes = []protoimpl.ExtensionInfo{
-	{
-		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
-		ExtensionType: (*Attribute)(nil),
-		Field:         50000,
-		Name:          "hcl.attr",
-		Tag:           "bytes,50000,opt,name=attr",
-		Filename:      "hcl.proto",
-	},
-	{
-		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
-		ExtensionType: (*NestedBlock)(nil),
-		Field:         50001,
-		Name:          "hcl.block",
-		Tag:           "bytes,50001,opt,name=block",
-		Filename:      "hcl.proto",
-	},
-	{
-		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
-		ExtensionType: (*BlockLabel)(nil),
-		Field:         50002,
-		Name:          "hcl.label",
-		Tag:           "bytes,50002,opt,name=label",
-		Filename:      "hcl.proto",
-	},
-	{
-		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
-		ExtensionType: (*bool)(nil),
-		Field:         50004,
-		Name:          "hcl.flatten",
-		Tag:           "varint,50004,opt,name=flatten",
-		Filename:      "hcl.proto",
-	},
+func (x *BlockLabel) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
 }
 
-// Extension fields to descriptorpb.FieldOptions.
-var (
-	// optional hcl.Attribute attr = 50000;
-	E_Attr = &file_hcl_proto_extTypes[0]
-	// optional hcl.NestedBlock block = 50001;
-	E_Block = &file_hcl_proto_extTypes[1]
-	// optional hcl.BlockLabel label = 50002;
-	E_Label = &file_hcl_proto_extTypes[2]
-	// optional bool flatten = 50004;
-	E_Flatten = &file_hcl_proto_extTypes[3]
-)
+// Specifies how a particular enum value is to be selected in the input
+// configuration, when decoding into an enum-typed field.
+//
+// An enum value without this option is selected by its protobuf value name,
+// exactly as written in the schema. Using this option to choose a more
+// conventional HCL-style name, like "debug" instead of "LEVEL_DEBUG", is
+// recommended.
+type EnumValue struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-var File_hcl_proto protoreflect.FileDescriptor
+	// Name is the string that selects this enum value in the configuration.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+}
 
-var file_hcl_proto_rawDesc = []byte{
-	0x0a, 0x09, 0x68, 0x63, 0x6c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x03, 0x68, 0x63, 0x6c,
-	0x1a, 0x20, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
-	0x66, 0x2f, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x6f, 0x72, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x22, 0xac, 0x01, 0x0a, 0x09, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65,
-	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
-	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64,
-	0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
-	0x74, 0x79, 0x70, 0x65, 0x12, 0x28, 0x0a, 0x03, 0x72, 0x61, 0x77, 0x18, 0x04, 0x20, 0x01, 0x28,
-	0x0e, 0x32, 0x16, 0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74,
-	0x65, 0x2e, 0x52, 0x61, 0x77, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x03, 0x72, 0x61, 0x77, 0x22, 0x31,
-	0x0a, 0x07, 0x52, 0x61, 0x77, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x4e, 0x4f, 0x54,
-	0x5f, 0x52, 0x41, 0x57, 0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47,
-	0x45, 0x50, 0x41, 0x43, 0x4b, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x4a, 0x53, 0x4f, 0x4e, 0x10,
-	0x02, 0x22, 0x99, 0x01, 0x0a, 0x0b, 0x4e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x42, 0x6c, 0x6f, 0x63,
-	0x6b, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x79, 0x70, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x79, 0x70, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x33,
-	0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1f, 0x2e, 0x68,
-	0x63, 0x6c, 0x2e, 0x4e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x2e, 0x43,
-	0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4b, 0x69, 0x6e, 0x64, 0x52, 0x04, 0x6b,
-	0x69, 0x6e, 0x64, 0x22, 0x38, 0x0a, 0x0e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f,
-	0x6e, 0x4b, 0x69, 0x6e, 0x64, 0x12, 0x08, 0x0a, 0x04, 0x41, 0x55, 0x54, 0x4f, 0x10, 0x00, 0x12,
-	0x09, 0x0a, 0x05, 0x54, 0x55, 0x50, 0x4c, 0x45, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x4c, 0x49,
-	0x53, 0x54, 0x10, 0x02, 0x12, 0x07, 0x0a, 0x03, 0x53, 0x45, 0x54, 0x10, 0x03, 0x22, 0x20, 0x0a,
-	0x0a, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x6e,
-	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x3a,
-	0x43, 0x0a, 0x04, 0x61, 0x74, 0x74, 0x72, 0x12, 0x1d, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4f,
-	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xd0, 0x86, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e,
-	0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x52, 0x04,
-	0x61, 0x74, 0x74, 0x72, 0x3a, 0x47, 0x0a, 0x05, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x1d, 0x2e,
-	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
-	0x46, 0x69, 0x65, 0x6c, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xd1, 0x86, 0x03,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x4e, 0x65, 0x73, 0x74, 0x65,
-	0x64, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x05, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x3a, 0x46, 0x0a,
-	0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x12, 0x1d, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4f, 0x70,
-	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xd2, 0x86, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e,
-	0x68, 0x63, 0x6c, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x52, 0x05,
-	0x6c, 0x61, 0x62, 0x65, 0x6c, 0x3a, 0x39, 0x0a, 0x07, 0x66, 0x6c, 0x61, 0x74, 0x74, 0x65, 0x6e,
-	0x12, 0x1d, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
-	0x75, 0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18,
-	0xd4, 0x86, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x66, 0x6c, 0x61, 0x74, 0x74, 0x65, 0x6e,
-	0x42, 0x3c, 0x5a, 0x3a, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61,
-	0x70, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x6c, 0x79, 0x6d, 0x61, 0x72, 0x74, 0x2f, 0x67, 0x6f,
-	0x2d, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x68, 0x63, 0x6c, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x68,
-	0x63, 0x6c, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x68, 0x63, 0x6c, 0x65, 0x78, 0x74, 0x62, 0x06,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+func (x *EnumValue) Reset() {
+	*x = EnumValue{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hcl_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
 
-var (
-	file_hcl_proto_rawDescOnce sync.Once
-	file_hcl_proto_rawDescData = file_hcl_proto_rawDesc
-)
+func (x *EnumValue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
 
-func file_hcl_proto_rawDescGZIP() []byte {
-	file_hcl_proto_rawDescOnce.Do(func() {
-		file_hcl_proto_rawDescData = protoimpl.X.CompressGZIP(file_hcl_proto_rawDescData)
-	})
-	return file_hcl_proto_rawDescData
+func (*EnumValue) ProtoMessage() {}
+
+func (x *EnumValue) ProtoReflect() protoreflect.Message {
+	mi := &file_hcl_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-var file_hcl_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_hcl_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
-var file_hcl_proto_goTypes = []interface{}{
-	(Attribute_RawMode)(0),            // 0: hcl.Attribute.RawMode
-	(NestedBlock_CollectionKind)(0),   // 1: hcl.NestedBlock.CollectionKind
-	(*Attribute)(nil),                 // 2: hcl.Attribute
-	(*NestedBlock)(nil),               // 3: hcl.NestedBlock
-	(*BlockLabel)(nil),                // 4: hcl.BlockLabel
-	(*descriptorpb.FieldOptions)(nil), // 5: google.protobuf.FieldOptions
+// Deprecated: Use EnumValue.ProtoReflect.Descriptor instead.
+func (*EnumValue) Descriptor() ([]byte, []int) {
+	return file_hcl_proto_rawDescGZIP(), []int{3}
 }
-var file_hcl_proto_depIdxs = []int32{
-	0, // 0: hcl.Attribute.raw:type_name -> hcl.Attribute.RawMode
-	1, // 1: hcl.NestedBlock.kind:type_name -> hcl.NestedBlock.CollectionKind
-	5, // 2: hcl.attr:extendee -> google.protobuf.FieldOptions
-	5, // 3: hcl.block:extendee -> google.protobuf.FieldOptions
-	5, // 4: hcl.label:extendee -> google.protobuf.FieldOptions
-	5, // 5: hcl.flatten:extendee -> google.protobuf.FieldOptions
-	2, // 6: hcl.attr:type_name -> hcl.Attribute
-	3, // 7: hcl.block:type_name -> hcl.NestedBlock
-	4, // 8: hcl.label:type_name -> hcl.BlockLabel
-	9, // [9:9] is the sub-list for method output_type
-	9, // [9:9] is the sub-list for method input_type
-	6, // [6:9] is the sub-list for extension type_name
-	2, // [2:6] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+
+func (x *EnumValue) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
 }
 
-func init() { file_hcl_proto_init() }
-func file_hcl_proto_init() {
-	if File_hcl_proto != nil {
-		return
+// Describes a range of characters in a configuration source file, such as
+// the location of an attribute's value expression recorded by the
+// include_range_into attribute option.
+type SourceRange struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Filename is the name of the source file, as given to the HCL parser.
+	Filename string `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
+	// Start is the position of the first character in the range.
+	Start *SourcePos `protobuf:"bytes,2,opt,name=start,proto3" json:"start,omitempty"`
+	// End is the position immediately after the last character in the range.
+	End *SourcePos `protobuf:"bytes,3,opt,name=end,proto3" json:"end,omitempty"`
+}
+
+func (x *SourceRange) Reset() {
+	*x = SourceRange{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hcl_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	if !protoimpl.UnsafeEnabled {
-		file_hcl_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Attribute); i {
+}
+
+func (x *SourceRange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SourceRange) ProtoMessage() {}
+
+func (x *SourceRange) ProtoReflect() protoreflect.Message {
+	mi := &file_hcl_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SourceRange.ProtoReflect.Descriptor instead.
+func (*SourceRange) Descriptor() ([]byte, []int) {
+	return file_hcl_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SourceRange) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *SourceRange) GetStart() *SourcePos {
+	if x != nil {
+		return x.Start
+	}
+	return nil
+}
+
+func (x *SourceRange) GetEnd() *SourcePos {
+	if x != nil {
+		return x.End
+	}
+	return nil
+}
+
+// Describes a position in a configuration source file.
+type SourcePos struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Line is the one-based line number of the position.
+	Line int64 `protobuf:"varint,1,opt,name=line,proto3" json:"line,omitempty"`
+	// Column is the one-based column number of the position, counting
+	// Unicode grapheme clusters rather than bytes.
+	Column int64 `protobuf:"varint,2,opt,name=column,proto3" json:"column,omitempty"`
+	// Byte is the zero-based byte offset of the position into the file.
+	Byte int64 `protobuf:"varint,3,opt,name=byte,proto3" json:"byte,omitempty"`
+}
+
+func (x *SourcePos) Reset() {
+	*x = SourcePos{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hcl_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SourcePos) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SourcePos) ProtoMessage() {}
+
+func (x *SourcePos) ProtoReflect() protoreflect.Message {
+	mi := &file_hcl_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SourcePos.ProtoReflect.Descriptor instead.
+func (*SourcePos) Descriptor() ([]byte, []int) {
+	return file_hcl_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *SourcePos) GetLine() int64 {
+	if x != nil {
+		return x.Line
+	}
+	return 0
+}
+
+func (x *SourcePos) GetColumn() int64 {
+	if x != nil {
+		return x.Column
+	}
+	return 0
+}
+
+func (x *SourcePos) GetByte() int64 {
+	if x != nil {
+		return x.Byte
+	}
+	return 0
+}
+
+// Specifies HCL-related settings for a message type as a whole.
+type Message struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Set output_for to the fully-qualified name of another message type to
+	// declare that this message describes the results of whatever object is
+	// configured using that other message, such as attributes that a plugin
+	// computes only after receiving its configuration.
+	//
+	// A host can then use both messages together to predict the type of the
+	// object representing the configured object and its results, so that it
+	// can type-check references to it before the plugin has actually produced
+	// any results. The HCL-annotated fields of an output message are
+	// interpreted in the same way as an ObjectValueForMessage conversion, and
+	// their names must not conflict with the names used in the message they
+	// are the output for.
+	OutputFor string `protobuf:"bytes,1,opt,name=output_for,json=outputFor,proto3" json:"output_for,omitempty"`
+	// Set root_only to declare that this message type may be used only to
+	// decode an entire body, such as the top-level configuration message of a
+	// plugin, and never as the body of a nested block type.
+	//
+	// A field that uses a root-only message type as a nested block type is a
+	// schema error, which protohcl reports when building the schema for the
+	// message containing that field.
+	RootOnly bool `protobuf:"varint,2,opt,name=root_only,json=rootOnly,proto3" json:"root_only,omitempty"`
+}
+
+func (x *Message) Reset() {
+	*x = Message{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hcl_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Message) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Message) ProtoMessage() {}
+
+func (x *Message) ProtoReflect() protoreflect.Message {
+	mi := &file_hcl_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Message.ProtoReflect.Descriptor instead.
+func (*Message) Descriptor() ([]byte, []int) {
+	return file_hcl_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Message) GetOutputFor() string {
+	if x != nil {
+		return x.OutputFor
+	}
+	return ""
+}
+
+func (x *Message) GetRootOnly() bool {
+	if x != nil {
+		return x.RootOnly
+	}
+	return false
+}
+
+// Describes a configuration source file, for an application where the host
+// sends the source code of the configuration to a plugin, and the plugin
+// then decodes it itself, rather than the host decoding it into the
+// plugin's message type.
+type SourceFile struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Filename is the name of the source file, which the decoder includes in
+	// the source ranges of the diagnostics it reports. A name ending in
+	// ".json" selects the JSON variant of HCL, and the native syntax is used
+	// for all other names.
+	Filename string `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
+	// Content is the source code of the file.
+	Content []byte `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+}
+
+func (x *SourceFile) Reset() {
+	*x = SourceFile{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hcl_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SourceFile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SourceFile) ProtoMessage() {}
+
+func (x *SourceFile) ProtoReflect() protoreflect.Message {
+	mi := &file_hcl_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SourceFile.ProtoReflect.Descriptor instead.
+func (*SourceFile) Descriptor() ([]byte, []int) {
+	return file_hcl_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *SourceFile) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *SourceFile) GetContent() []byte {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+// Describes a diagnostic message, so that a plugin that decodes a
+// SourceFile itself can report any problems back to the host.
+type Diagnostic struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Severity is the severity of the problem.
+	Severity Diagnostic_Severity `protobuf:"varint,1,opt,name=severity,proto3,enum=hcl.Diagnostic_Severity" json:"severity,omitempty"`
+	// Summary is a short description of the problem, as for the summary of
+	// an HCL diagnostic.
+	Summary string `protobuf:"bytes,2,opt,name=summary,proto3" json:"summary,omitempty"`
+	// Detail is an optional longer description of the problem.
+	Detail string `protobuf:"bytes,3,opt,name=detail,proto3" json:"detail,omitempty"`
+	// Subject is the source range that the problem relates to, if any.
+	Subject *SourceRange `protobuf:"bytes,4,opt,name=subject,proto3" json:"subject,omitempty"`
+	// Context is an optional larger source range that contains the subject,
+	// which the host can use when showing a snippet of the source code.
+	Context *SourceRange `protobuf:"bytes,5,opt,name=context,proto3" json:"context,omitempty"`
+	// Category is the name of the broad class of problem that the diagnostic
+	// describes, such as "value-error", or empty if the diagnostic is
+	// uncategorized. The names are those of protohcl's DiagnosticCategory
+	// values.
+	Category string `protobuf:"bytes,6,opt,name=category,proto3" json:"category,omitempty"`
+}
+
+func (x *Diagnostic) Reset() {
+	*x = Diagnostic{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hcl_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Diagnostic) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Diagnostic) ProtoMessage() {}
+
+func (x *Diagnostic) ProtoReflect() protoreflect.Message {
+	mi := &file_hcl_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Diagnostic.ProtoReflect.Descriptor instead.
+func (*Diagnostic) Descriptor() ([]byte, []int) {
+	return file_hcl_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Diagnostic) GetSeverity() Diagnostic_Severity {
+	if x != nil {
+		return x.Severity
+	}
+	return Diagnostic_SEVERITY_UNSPECIFIED
+}
+
+func (x *Diagnostic) GetSummary() string {
+	if x != nil {
+		return x.Summary
+	}
+	return ""
+}
+
+func (x *Diagnostic) GetDetail() string {
+	if x != nil {
+		return x.Detail
+	}
+	return ""
+}
+
+func (x *Diagnostic) GetSubject() *SourceRange {
+	if x != nil {
+		return x.Subject
+	}
+	return nil
+}
+
+func (x *Diagnostic) GetContext() *SourceRange {
+	if x != nil {
+		return x.Context
+	}
+	return nil
+}
+
+func (x *Diagnostic) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+// Describes a snapshot of a decoded configuration, bundling the decoded
+// message with the schema that it conforms to and a record of which part of
+// the configuration populated each of its fields, so that later tooling can
+// interpret a stored configuration without the original source files or
+// the compiled-in message types.
+type ConfigSnapshot struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Files are the protobuf schema files that declare the message type,
+	// along with all of the files that they import.
+	Files *descriptorpb.FileDescriptorSet `protobuf:"bytes,1,opt,name=files,proto3" json:"files,omitempty"`
+	// MessageType is the full name of the type of the decoded message, which
+	// one of the files must declare.
+	MessageType string `protobuf:"bytes,2,opt,name=message_type,json=messageType,proto3" json:"message_type,omitempty"`
+	// Message is the decoded message in protobuf wire format.
+	Message []byte `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	// Trace records the source range that populated each of the fields of
+	// the message, in the order the decoder populated them.
+	Trace []*ConfigSnapshot_TraceEntry `protobuf:"bytes,4,rep,name=trace,proto3" json:"trace,omitempty"`
+}
+
+func (x *ConfigSnapshot) Reset() {
+	*x = ConfigSnapshot{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hcl_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConfigSnapshot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfigSnapshot) ProtoMessage() {}
+
+func (x *ConfigSnapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_hcl_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfigSnapshot.ProtoReflect.Descriptor instead.
+func (*ConfigSnapshot) Descriptor() ([]byte, []int) {
+	return file_hcl_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ConfigSnapshot) GetFiles() *descriptorpb.FileDescriptorSet {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+func (x *ConfigSnapshot) GetMessageType() string {
+	if x != nil {
+		return x.MessageType
+	}
+	return ""
+}
+
+func (x *ConfigSnapshot) GetMessage() []byte {
+	if x != nil {
+		return x.Message
+	}
+	return nil
+}
+
+func (x *ConfigSnapshot) GetTrace() []*ConfigSnapshot_TraceEntry {
+	if x != nil {
+		return x.Trace
+	}
+	return nil
+}
+
+// NumberSyntax describes extensions to HCL's number syntax that an
+// attribute can accept when written as a string.
+type Attribute_NumberSyntax struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Set underscores to allow underscores as digit separators, as in
+	// "10_000_000". Each underscore must appear between two digits.
+	Underscores bool `protobuf:"varint,1,opt,name=underscores,proto3" json:"underscores,omitempty"`
+	// Set prefixes to also allow integers written in hexadecimal, octal,
+	// or binary, using the prefixes "0x", "0o", and "0b" respectively, as
+	// in "0xff".
+	Prefixes bool `protobuf:"varint,2,opt,name=prefixes,proto3" json:"prefixes,omitempty"`
+}
+
+func (x *Attribute_NumberSyntax) Reset() {
+	*x = Attribute_NumberSyntax{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hcl_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Attribute_NumberSyntax) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Attribute_NumberSyntax) ProtoMessage() {}
+
+func (x *Attribute_NumberSyntax) ProtoReflect() protoreflect.Message {
+	mi := &file_hcl_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Attribute_NumberSyntax.ProtoReflect.Descriptor instead.
+func (*Attribute_NumberSyntax) Descriptor() ([]byte, []int) {
+	return file_hcl_proto_rawDescGZIP(), []int{0, 0}
+}
+
+func (x *Attribute_NumberSyntax) GetUnderscores() bool {
+	if x != nil {
+		return x.Underscores
+	}
+	return false
+}
+
+func (x *Attribute_NumberSyntax) GetPrefixes() bool {
+	if x != nil {
+		return x.Prefixes
+	}
+	return false
+}
+
+type NestedBlock_AnyType struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The value of the any_discriminator attribute that selects this type.
+	Value string `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	// The full name of the message type, which must be declared in the
+	// same file as the Any field or in a file that it imports.
+	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *NestedBlock_AnyType) Reset() {
+	*x = NestedBlock_AnyType{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hcl_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NestedBlock_AnyType) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NestedBlock_AnyType) ProtoMessage() {}
+
+func (x *NestedBlock_AnyType) ProtoReflect() protoreflect.Message {
+	mi := &file_hcl_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NestedBlock_AnyType.ProtoReflect.Descriptor instead.
+func (*NestedBlock_AnyType) Descriptor() ([]byte, []int) {
+	return file_hcl_proto_rawDescGZIP(), []int{1, 0}
+}
+
+func (x *NestedBlock_AnyType) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *NestedBlock_AnyType) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type ConfigSnapshot_TraceEntry struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Path is the steps from the decoded message to the field, list
+	// element, or map entry that this entry describes.
+	Path []*ConfigSnapshot_PathStep `protobuf:"bytes,1,rep,name=path,proto3" json:"path,omitempty"`
+	// Range is the source range that populated the value at the path.
+	Range *SourceRange `protobuf:"bytes,2,opt,name=range,proto3" json:"range,omitempty"`
+	// Variables are the names of the root variables from the evaluation
+	// context that the expression populating the value referred to.
+	Variables []string `protobuf:"bytes,3,rep,name=variables,proto3" json:"variables,omitempty"`
+}
+
+func (x *ConfigSnapshot_TraceEntry) Reset() {
+	*x = ConfigSnapshot_TraceEntry{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hcl_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConfigSnapshot_TraceEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfigSnapshot_TraceEntry) ProtoMessage() {}
+
+func (x *ConfigSnapshot_TraceEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_hcl_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfigSnapshot_TraceEntry.ProtoReflect.Descriptor instead.
+func (*ConfigSnapshot_TraceEntry) Descriptor() ([]byte, []int) {
+	return file_hcl_proto_rawDescGZIP(), []int{9, 0}
+}
+
+func (x *ConfigSnapshot_TraceEntry) GetPath() []*ConfigSnapshot_PathStep {
+	if x != nil {
+		return x.Path
+	}
+	return nil
+}
+
+func (x *ConfigSnapshot_TraceEntry) GetRange() *SourceRange {
+	if x != nil {
+		return x.Range
+	}
+	return nil
+}
+
+func (x *ConfigSnapshot_TraceEntry) GetVariables() []string {
+	if x != nil {
+		return x.Variables
+	}
+	return nil
+}
+
+type ConfigSnapshot_PathStep struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Step:
+	//	*ConfigSnapshot_PathStep_Field
+	//	*ConfigSnapshot_PathStep_ListIndex
+	//	*ConfigSnapshot_PathStep_MapKeyString
+	//	*ConfigSnapshot_PathStep_MapKeyInt
+	//	*ConfigSnapshot_PathStep_MapKeyUint
+	//	*ConfigSnapshot_PathStep_MapKeyBool
+	Step isConfigSnapshot_PathStep_Step `protobuf_oneof:"step"`
+}
+
+func (x *ConfigSnapshot_PathStep) Reset() {
+	*x = ConfigSnapshot_PathStep{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hcl_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConfigSnapshot_PathStep) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfigSnapshot_PathStep) ProtoMessage() {}
+
+func (x *ConfigSnapshot_PathStep) ProtoReflect() protoreflect.Message {
+	mi := &file_hcl_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfigSnapshot_PathStep.ProtoReflect.Descriptor instead.
+func (*ConfigSnapshot_PathStep) Descriptor() ([]byte, []int) {
+	return file_hcl_proto_rawDescGZIP(), []int{9, 1}
+}
+
+func (m *ConfigSnapshot_PathStep) GetStep() isConfigSnapshot_PathStep_Step {
+	if m != nil {
+		return m.Step
+	}
+	return nil
+}
+
+func (x *ConfigSnapshot_PathStep) GetField() string {
+	if x, ok := x.GetStep().(*ConfigSnapshot_PathStep_Field); ok {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *ConfigSnapshot_PathStep) GetListIndex() int64 {
+	if x, ok := x.GetStep().(*ConfigSnapshot_PathStep_ListIndex); ok {
+		return x.ListIndex
+	}
+	return 0
+}
+
+func (x *ConfigSnapshot_PathStep) GetMapKeyString() string {
+	if x, ok := x.GetStep().(*ConfigSnapshot_PathStep_MapKeyString); ok {
+		return x.MapKeyString
+	}
+	return ""
+}
+
+func (x *ConfigSnapshot_PathStep) GetMapKeyInt() int64 {
+	if x, ok := x.GetStep().(*ConfigSnapshot_PathStep_MapKeyInt); ok {
+		return x.MapKeyInt
+	}
+	return 0
+}
+
+func (x *ConfigSnapshot_PathStep) GetMapKeyUint() uint64 {
+	if x, ok := x.GetStep().(*ConfigSnapshot_PathStep_MapKeyUint); ok {
+		return x.MapKeyUint
+	}
+	return 0
+}
+
+func (x *ConfigSnapshot_PathStep) GetMapKeyBool() bool {
+	if x, ok := x.GetStep().(*ConfigSnapshot_PathStep_MapKeyBool); ok {
+		return x.MapKeyBool
+	}
+	return false
+}
+
+type isConfigSnapshot_PathStep_Step interface {
+	isConfigSnapshot_PathStep_Step()
+}
+
+type ConfigSnapshot_PathStep_Field struct {
+	// Field is the full name of a field of the current message, which
+	// might be the message that a google.protobuf.Any field contains
+	// rather than the Any message itself.
+	Field string `protobuf:"bytes,1,opt,name=field,proto3,oneof"`
+}
+
+type ConfigSnapshot_PathStep_ListIndex struct {
+	// ListIndex is an index into the current repeated field.
+	ListIndex int64 `protobuf:"varint,2,opt,name=list_index,json=listIndex,proto3,oneof"`
+}
+
+type ConfigSnapshot_PathStep_MapKeyString struct {
+	// The map key fields are each a key of the current map field, using
+	// whichever suits the map's key type.
+	MapKeyString string `protobuf:"bytes,3,opt,name=map_key_string,json=mapKeyString,proto3,oneof"`
+}
+
+type ConfigSnapshot_PathStep_MapKeyInt struct {
+	MapKeyInt int64 `protobuf:"varint,4,opt,name=map_key_int,json=mapKeyInt,proto3,oneof"`
+}
+
+type ConfigSnapshot_PathStep_MapKeyUint struct {
+	MapKeyUint uint64 `protobuf:"varint,5,opt,name=map_key_uint,json=mapKeyUint,proto3,oneof"`
+}
+
+type ConfigSnapshot_PathStep_MapKeyBool struct {
+	MapKeyBool bool `protobuf:"varint,6,opt,name=map_key_bool,json=mapKeyBool,proto3,oneof"`
+}
+
+func (*ConfigSnapshot_PathStep_Field) isConfigSnapshot_PathStep_Step() {}
+
+func (*ConfigSnapshot_PathStep_ListIndex) isConfigSnapshot_PathStep_Step() {}
+
+func (*ConfigSnapshot_PathStep_MapKeyString) isConfigSnapshot_PathStep_Step() {}
+
+func (*ConfigSnapshot_PathStep_MapKeyInt) isConfigSnapshot_PathStep_Step() {}
+
+func (*ConfigSnapshot_PathStep_MapKeyUint) isConfigSnapshot_PathStep_Step() {}
+
+func (*ConfigSnapshot_PathStep_MapKeyBool) isConfigSnapshot_PathStep_Step() {}
+
+var file_hcl_proto_extTypes = []protoimpl.ExtensionInfo{
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: (*Attribute)(nil),
+		Field:         50000,
+		Name:          "hcl.attr",
+		Tag:           "bytes,50000,opt,name=attr",
+		Filename:      "hcl.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: (*NestedBlock)(nil),
+		Field:         50001,
+		Name:          "hcl.block",
+		Tag:           "bytes,50001,opt,name=block",
+		Filename:      "hcl.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: (*BlockLabel)(nil),
+		Field:         50002,
+		Name:          "hcl.label",
+		Tag:           "bytes,50002,opt,name=label",
+		Filename:      "hcl.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: (*bool)(nil),
+		Field:         50004,
+		Name:          "hcl.flatten",
+		Tag:           "varint,50004,opt,name=flatten",
+		Filename:      "hcl.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: (*string)(nil),
+		Field:         50005,
+		Name:          "hcl.flatten_prefix",
+		Tag:           "bytes,50005,opt,name=flatten_prefix",
+		Filename:      "hcl.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: (*FlattenConflict)(nil),
+		Field:         50006,
+		Name:          "hcl.flatten_conflict",
+		Tag:           "varint,50006,opt,name=flatten_conflict,enum=hcl.FlattenConflict",
+		Filename:      "hcl.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.MessageOptions)(nil),
+		ExtensionType: (*Message)(nil),
+		Field:         50000,
+		Name:          "hcl.message",
+		Tag:           "bytes,50000,opt,name=message",
+		Filename:      "hcl.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.EnumValueOptions)(nil),
+		ExtensionType: (*EnumValue)(nil),
+		Field:         50000,
+		Name:          "hcl.enumval",
+		Tag:           "bytes,50000,opt,name=enumval",
+		Filename:      "hcl.proto",
+	},
+}
+
+// Extension fields to descriptorpb.FieldOptions.
+var (
+	// optional hcl.Attribute attr = 50000;
+	E_Attr = &file_hcl_proto_extTypes[0]
+	// optional hcl.NestedBlock block = 50001;
+	E_Block = &file_hcl_proto_extTypes[1]
+	// optional hcl.BlockLabel label = 50002;
+	E_Label = &file_hcl_proto_extTypes[2]
+	// Set flatten on a singleton message-typed field to merge all of the
+	// HCL-annotated fields of the nested message into the message containing
+	// the field, as if they had been declared directly in the containing
+	// message. This is useful for sharing common fields, including block
+	// labels, between several messages via a common "base" message.
+	//
+	// optional bool flatten = 50004;
+	E_Flatten = &file_hcl_proto_extTypes[3]
+	// Set flatten_prefix on a singleton message-typed field to flatten it
+	// as with flatten, but with the given prefix added to the start of the
+	// names of all of the attributes, block types, and block labels that the
+	// nested message contributes. This allows including the same message
+	// more than once in a containing message, such as "client_" and "server_"
+	// variants of some common settings.
+	//
+	// The prefix must itself be a valid HCL identifier. Setting
+	// flatten_prefix implies flatten.
+	//
+	// optional string flatten_prefix = 50005;
+	E_FlattenPrefix = &file_hcl_proto_extTypes[4]
+	// Set flatten_conflict on a field that sets flatten or flatten_prefix to
+	// allow attributes of the nested message to have the same names as
+	// attributes declared directly in the containing message, which would
+	// otherwise be a schema error. Its value decides which of the two fields
+	// the attribute belongs to. The other field is then ignored for HCL
+	// purposes in this containing message, regardless of the order in which
+	// the fields are declared.
+	//
+	// This is intended for schema evolution, such as when a field has been
+	// hoisted from a nested message into the containing message but the
+	// original field must remain for compatibility. Conflicts with block
+	// types, block labels, or fields of other flattened messages are still
+	// errors.
+	//
+	// optional hcl.FlattenConflict flatten_conflict = 50006;
+	E_FlattenConflict = &file_hcl_proto_extTypes[5]
+)
+
+// Extension fields to descriptorpb.MessageOptions.
+var (
+	// optional hcl.Message message = 50000;
+	E_Message = &file_hcl_proto_extTypes[6]
+)
+
+// Extension fields to descriptorpb.EnumValueOptions.
+var (
+	// optional hcl.EnumValue enumval = 50000;
+	E_Enumval = &file_hcl_proto_extTypes[7]
+)
+
+var File_hcl_proto protoreflect.FileDescriptor
+
+var file_hcl_proto_rawDesc = []byte{
+	0x0a, 0x09, 0x68, 0x63, 0x6c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x03, 0x68, 0x63, 0x6c,
+	0x1a, 0x20, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2f, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x6f, 0x72, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x22, 0x83, 0x05, 0x0a, 0x09, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64,
+	0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x74, 0x79, 0x70, 0x65, 0x12, 0x28, 0x0a, 0x03, 0x72, 0x61, 0x77, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x16, 0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74,
+	0x65, 0x2e, 0x52, 0x61, 0x77, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x03, 0x72, 0x61, 0x77, 0x12, 0x31,
+	0x0a, 0x15, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x5f, 0x73, 0x63, 0x61, 0x6c, 0x61, 0x72, 0x5f, 0x66,
+	0x6f, 0x72, 0x5f, 0x6c, 0x69, 0x73, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x12, 0x61,
+	0x6c, 0x6c, 0x6f, 0x77, 0x53, 0x63, 0x61, 0x6c, 0x61, 0x72, 0x46, 0x6f, 0x72, 0x4c, 0x69, 0x73,
+	0x74, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x6e, 0x69, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x75, 0x6e, 0x69, 0x74, 0x12, 0x2c, 0x0a, 0x12, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65,
+	0x5f, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x5f, 0x69, 0x6e, 0x74, 0x6f, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x10, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x49,
+	0x6e, 0x74, 0x6f, 0x12, 0x33, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x1f, 0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x4e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x42, 0x6c,
+	0x6f, 0x63, 0x6b, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4b, 0x69,
+	0x6e, 0x64, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x22, 0x0a, 0x0d, 0x65, 0x6d, 0x70, 0x74,
+	0x79, 0x5f, 0x61, 0x73, 0x5f, 0x6e, 0x75, 0x6c, 0x6c, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x0b, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x41, 0x73, 0x4e, 0x75, 0x6c, 0x6c, 0x12, 0x40, 0x0a, 0x0d,
+	0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x5f, 0x73, 0x79, 0x6e, 0x74, 0x61, 0x78, 0x18, 0x0a, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62,
+	0x75, 0x74, 0x65, 0x2e, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x53, 0x79, 0x6e, 0x74, 0x61, 0x78,
+	0x52, 0x0c, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x53, 0x79, 0x6e, 0x74, 0x61, 0x78, 0x12, 0x2f,
+	0x0a, 0x14, 0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x5f, 0x6e, 0x75, 0x6c, 0x6c, 0x5f, 0x69, 0x6e,
+	0x5f, 0x6c, 0x69, 0x73, 0x74, 0x73, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x08, 0x52, 0x11, 0x69, 0x67,
+	0x6e, 0x6f, 0x72, 0x65, 0x4e, 0x75, 0x6c, 0x6c, 0x49, 0x6e, 0x4c, 0x69, 0x73, 0x74, 0x73, 0x12,
+	0x21, 0x0a, 0x0c, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x5f, 0x65, 0x78, 0x70, 0x72, 0x18,
+	0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x45, 0x78,
+	0x70, 0x72, 0x12, 0x19, 0x0a, 0x08, 0x61, 0x6e, 0x79, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x0d,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x6e, 0x79, 0x54, 0x79, 0x70, 0x65, 0x1a, 0x4c, 0x0a,
+	0x0c, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x53, 0x79, 0x6e, 0x74, 0x61, 0x78, 0x12, 0x20, 0x0a,
+	0x0b, 0x75, 0x6e, 0x64, 0x65, 0x72, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x0b, 0x75, 0x6e, 0x64, 0x65, 0x72, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x73, 0x12,
+	0x1a, 0x0a, 0x08, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x08, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x65, 0x73, 0x22, 0x3b, 0x0a, 0x07, 0x52,
+	0x61, 0x77, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x4e, 0x4f, 0x54, 0x5f, 0x52, 0x41,
+	0x57, 0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x50, 0x41,
+	0x43, 0x4b, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x4a, 0x53, 0x4f, 0x4e, 0x10, 0x02, 0x12, 0x08,
+	0x0a, 0x04, 0x59, 0x41, 0x4d, 0x4c, 0x10, 0x03, 0x22, 0xa1, 0x03, 0x0a, 0x0b, 0x4e, 0x65, 0x73,
+	0x74, 0x65, 0x64, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x79, 0x70, 0x65,
+	0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x79, 0x70,
+	0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x33, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x1f, 0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x4e, 0x65, 0x73, 0x74, 0x65, 0x64,
+	0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x4b, 0x69, 0x6e, 0x64, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x22, 0x0a, 0x0d, 0x6d, 0x61,
+	0x70, 0x5f, 0x6b, 0x65, 0x79, 0x5f, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0b, 0x6d, 0x61, 0x70, 0x4b, 0x65, 0x79, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x12, 0x18,
+	0x0a, 0x07, 0x61, 0x6c, 0x69, 0x61, 0x73, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x07, 0x61, 0x6c, 0x69, 0x61, 0x73, 0x65, 0x73, 0x12, 0x20, 0x0a, 0x0c, 0x6d, 0x61, 0x70, 0x5f,
+	0x6b, 0x65, 0x79, 0x5f, 0x61, 0x74, 0x74, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a,
+	0x6d, 0x61, 0x70, 0x4b, 0x65, 0x79, 0x41, 0x74, 0x74, 0x72, 0x12, 0x2b, 0x0a, 0x11, 0x61, 0x6e,
+	0x79, 0x5f, 0x64, 0x69, 0x73, 0x63, 0x72, 0x69, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x61, 0x6e, 0x79, 0x44, 0x69, 0x73, 0x63, 0x72, 0x69,
+	0x6d, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x35, 0x0a, 0x09, 0x61, 0x6e, 0x79, 0x5f, 0x74,
+	0x79, 0x70, 0x65, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x68, 0x63, 0x6c,
+	0x2e, 0x4e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x2e, 0x41, 0x6e, 0x79,
+	0x54, 0x79, 0x70, 0x65, 0x52, 0x08, 0x61, 0x6e, 0x79, 0x54, 0x79, 0x70, 0x65, 0x73, 0x1a, 0x39,
+	0x0a, 0x07, 0x41, 0x6e, 0x79, 0x54, 0x79, 0x70, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12,
+	0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x41, 0x0a, 0x0e, 0x43, 0x6f, 0x6c,
+	0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4b, 0x69, 0x6e, 0x64, 0x12, 0x08, 0x0a, 0x04, 0x41,
+	0x55, 0x54, 0x4f, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x54, 0x55, 0x50, 0x4c, 0x45, 0x10, 0x01,
+	0x12, 0x08, 0x0a, 0x04, 0x4c, 0x49, 0x53, 0x54, 0x10, 0x02, 0x12, 0x07, 0x0a, 0x03, 0x53, 0x45,
+	0x54, 0x10, 0x03, 0x12, 0x07, 0x0a, 0x03, 0x4d, 0x41, 0x50, 0x10, 0x04, 0x22, 0x42, 0x0a, 0x0a,
+	0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x20,
+	0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x22, 0x1f, 0x0a, 0x09, 0x45, 0x6e, 0x75, 0x6d, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x12, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x22, 0x71, 0x0a, 0x0b, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x61, 0x6e, 0x67, 0x65,
+	0x12, 0x1a, 0x0a, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x24, 0x0a, 0x05,
+	0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x68, 0x63,
+	0x6c, 0x2e, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x50, 0x6f, 0x73, 0x52, 0x05, 0x73, 0x74, 0x61,
+	0x72, 0x74, 0x12, 0x20, 0x0a, 0x03, 0x65, 0x6e, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x0e, 0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x50, 0x6f, 0x73, 0x52,
+	0x03, 0x65, 0x6e, 0x64, 0x22, 0x4b, 0x0a, 0x09, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x50, 0x6f,
+	0x73, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x04, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x12, 0x12, 0x0a,
+	0x04, 0x62, 0x79, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x62, 0x79, 0x74,
+	0x65, 0x22, 0x45, 0x0a, 0x07, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1d, 0x0a, 0x0a,
+	0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x5f, 0x66, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x46, 0x6f, 0x72, 0x12, 0x1b, 0x0a, 0x09, 0x72,
+	0x6f, 0x6f, 0x74, 0x5f, 0x6f, 0x6e, 0x6c, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08,
+	0x72, 0x6f, 0x6f, 0x74, 0x4f, 0x6e, 0x6c, 0x79, 0x22, 0x42, 0x0a, 0x0a, 0x53, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61,
+	0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x22, 0xa6, 0x02, 0x0a,
+	0x0a, 0x44, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x12, 0x34, 0x0a, 0x08, 0x73,
+	0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x18, 0x2e,
+	0x68, 0x63, 0x6c, 0x2e, 0x44, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x2e, 0x53,
+	0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x52, 0x08, 0x73, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74,
+	0x79, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x73, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x64,
+	0x65, 0x74, 0x61, 0x69, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x64, 0x65, 0x74,
+	0x61, 0x69, 0x6c, 0x12, 0x2a, 0x0a, 0x07, 0x73, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x53, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x07, 0x73, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x12,
+	0x2a, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x10, 0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x61, 0x6e,
+	0x67, 0x65, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x63,
+	0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63,
+	0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x22, 0x3c, 0x0a, 0x08, 0x53, 0x65, 0x76, 0x65, 0x72,
+	0x69, 0x74, 0x79, 0x12, 0x18, 0x0a, 0x14, 0x53, 0x45, 0x56, 0x45, 0x52, 0x49, 0x54, 0x59, 0x5f,
+	0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x09, 0x0a,
+	0x05, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07, 0x57, 0x41, 0x52, 0x4e,
+	0x49, 0x4e, 0x47, 0x10, 0x02, 0x22, 0xa4, 0x04, 0x0a, 0x0e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x38, 0x0a, 0x05, 0x66, 0x69, 0x6c, 0x65,
+	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x44, 0x65,
+	0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x6f, 0x72, 0x53, 0x65, 0x74, 0x52, 0x05, 0x66, 0x69, 0x6c,
+	0x65, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x79,
+	0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12,
+	0x34, 0x0a, 0x05, 0x74, 0x72, 0x61, 0x63, 0x65, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e,
+	0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x53, 0x6e, 0x61, 0x70, 0x73,
+	0x68, 0x6f, 0x74, 0x2e, 0x54, 0x72, 0x61, 0x63, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x05,
+	0x74, 0x72, 0x61, 0x63, 0x65, 0x1a, 0x84, 0x01, 0x0a, 0x0a, 0x54, 0x72, 0x61, 0x63, 0x65, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x12, 0x30, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x53,
+	0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x2e, 0x50, 0x61, 0x74, 0x68, 0x53, 0x74, 0x65, 0x70,
+	0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x26, 0x0a, 0x05, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x53, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x05, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x1c,
+	0x0a, 0x09, 0x76, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x09, 0x76, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x1a, 0xdd, 0x01, 0x0a,
+	0x08, 0x50, 0x61, 0x74, 0x68, 0x53, 0x74, 0x65, 0x70, 0x12, 0x16, 0x0a, 0x05, 0x66, 0x69, 0x65,
+	0x6c, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x05, 0x66, 0x69, 0x65, 0x6c,
+	0x64, 0x12, 0x1f, 0x0a, 0x0a, 0x6c, 0x69, 0x73, 0x74, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x03, 0x48, 0x00, 0x52, 0x09, 0x6c, 0x69, 0x73, 0x74, 0x49, 0x6e, 0x64,
+	0x65, 0x78, 0x12, 0x26, 0x0a, 0x0e, 0x6d, 0x61, 0x70, 0x5f, 0x6b, 0x65, 0x79, 0x5f, 0x73, 0x74,
+	0x72, 0x69, 0x6e, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x0c, 0x6d, 0x61,
+	0x70, 0x4b, 0x65, 0x79, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x12, 0x20, 0x0a, 0x0b, 0x6d, 0x61,
+	0x70, 0x5f, 0x6b, 0x65, 0x79, 0x5f, 0x69, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x48,
+	0x00, 0x52, 0x09, 0x6d, 0x61, 0x70, 0x4b, 0x65, 0x79, 0x49, 0x6e, 0x74, 0x12, 0x22, 0x0a, 0x0c,
+	0x6d, 0x61, 0x70, 0x5f, 0x6b, 0x65, 0x79, 0x5f, 0x75, 0x69, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x04, 0x48, 0x00, 0x52, 0x0a, 0x6d, 0x61, 0x70, 0x4b, 0x65, 0x79, 0x55, 0x69, 0x6e, 0x74,
+	0x12, 0x22, 0x0a, 0x0c, 0x6d, 0x61, 0x70, 0x5f, 0x6b, 0x65, 0x79, 0x5f, 0x62, 0x6f, 0x6f, 0x6c,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x0a, 0x6d, 0x61, 0x70, 0x4b, 0x65, 0x79,
+	0x42, 0x6f, 0x6f, 0x6c, 0x42, 0x06, 0x0a, 0x04, 0x73, 0x74, 0x65, 0x70, 0x2a, 0x45, 0x0a, 0x0f,
+	0x46, 0x6c, 0x61, 0x74, 0x74, 0x65, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63, 0x74, 0x12,
+	0x12, 0x0a, 0x0e, 0x43, 0x4f, 0x4e, 0x46, 0x4c, 0x49, 0x43, 0x54, 0x5f, 0x45, 0x52, 0x52, 0x4f,
+	0x52, 0x10, 0x00, 0x12, 0x0e, 0x0a, 0x0a, 0x4f, 0x55, 0x54, 0x45, 0x52, 0x5f, 0x57, 0x49, 0x4e,
+	0x53, 0x10, 0x01, 0x12, 0x0e, 0x0a, 0x0a, 0x49, 0x4e, 0x4e, 0x45, 0x52, 0x5f, 0x57, 0x49, 0x4e,
+	0x53, 0x10, 0x02, 0x3a, 0x43, 0x0a, 0x04, 0x61, 0x74, 0x74, 0x72, 0x12, 0x1d, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69,
+	0x65, 0x6c, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xd0, 0x86, 0x03, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75,
+	0x74, 0x65, 0x52, 0x04, 0x61, 0x74, 0x74, 0x72, 0x3a, 0x47, 0x0a, 0x05, 0x62, 0x6c, 0x6f, 0x63,
+	0x6b, 0x12, 0x1d, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x18, 0xd1, 0x86, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x4e,
+	0x65, 0x73, 0x74, 0x65, 0x64, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x05, 0x62, 0x6c, 0x6f, 0x63,
+	0x6b, 0x3a, 0x46, 0x0a, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x12, 0x1d, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x65,
+	0x6c, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xd2, 0x86, 0x03, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x0f, 0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4c, 0x61, 0x62,
+	0x65, 0x6c, 0x52, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x3a, 0x39, 0x0a, 0x07, 0x66, 0x6c, 0x61,
+	0x74, 0x74, 0x65, 0x6e, 0x12, 0x1d, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4f, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x18, 0xd4, 0x86, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x66, 0x6c, 0x61,
+	0x74, 0x74, 0x65, 0x6e, 0x3a, 0x46, 0x0a, 0x0e, 0x66, 0x6c, 0x61, 0x74, 0x74, 0x65, 0x6e, 0x5f,
+	0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x1d, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4f, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xd5, 0x86, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x66,
+	0x6c, 0x61, 0x74, 0x74, 0x65, 0x6e, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x3a, 0x60, 0x0a, 0x10,
+	0x66, 0x6c, 0x61, 0x74, 0x74, 0x65, 0x6e, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63, 0x74,
+	0x12, 0x1d, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18,
+	0xd6, 0x86, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x46, 0x6c,
+	0x61, 0x74, 0x74, 0x65, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63, 0x74, 0x52, 0x0f, 0x66,
+	0x6c, 0x61, 0x74, 0x74, 0x65, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63, 0x74, 0x3a, 0x49,
+	0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1f, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xd0, 0x86, 0x03, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x3a, 0x4d, 0x0a, 0x07, 0x65, 0x6e, 0x75,
+	0x6d, 0x76, 0x61, 0x6c, 0x12, 0x21, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6e, 0x75, 0x6d, 0x56, 0x61, 0x6c, 0x75, 0x65,
+	0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xd0, 0x86, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x0e, 0x2e, 0x68, 0x63, 0x6c, 0x2e, 0x45, 0x6e, 0x75, 0x6d, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52,
+	0x07, 0x65, 0x6e, 0x75, 0x6d, 0x76, 0x61, 0x6c, 0x42, 0x3c, 0x5a, 0x3a, 0x67, 0x69, 0x74, 0x68,
+	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61, 0x70, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x6c,
+	0x79, 0x6d, 0x61, 0x72, 0x74, 0x2f, 0x67, 0x6f, 0x2d, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x68, 0x63,
+	0x6c, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x68, 0x63, 0x6c, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x68, 0x63, 0x6c, 0x65, 0x78, 0x74, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_hcl_proto_rawDescOnce sync.Once
+	file_hcl_proto_rawDescData = file_hcl_proto_rawDesc
+)
+
+func file_hcl_proto_rawDescGZIP() []byte {
+	file_hcl_proto_rawDescOnce.Do(func() {
+		file_hcl_proto_rawDescData = protoimpl.X.CompressGZIP(file_hcl_proto_rawDescData)
+	})
+	return file_hcl_proto_rawDescData
+}
+
+var file_hcl_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_hcl_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_hcl_proto_goTypes = []interface{}{
+	(FlattenConflict)(0),                   // 0: hcl.FlattenConflict
+	(Attribute_RawMode)(0),                 // 1: hcl.Attribute.RawMode
+	(NestedBlock_CollectionKind)(0),        // 2: hcl.NestedBlock.CollectionKind
+	(Diagnostic_Severity)(0),               // 3: hcl.Diagnostic.Severity
+	(*Attribute)(nil),                      // 4: hcl.Attribute
+	(*NestedBlock)(nil),                    // 5: hcl.NestedBlock
+	(*BlockLabel)(nil),                     // 6: hcl.BlockLabel
+	(*EnumValue)(nil),                      // 7: hcl.EnumValue
+	(*SourceRange)(nil),                    // 8: hcl.SourceRange
+	(*SourcePos)(nil),                      // 9: hcl.SourcePos
+	(*Message)(nil),                        // 10: hcl.Message
+	(*SourceFile)(nil),                     // 11: hcl.SourceFile
+	(*Diagnostic)(nil),                     // 12: hcl.Diagnostic
+	(*ConfigSnapshot)(nil),                 // 13: hcl.ConfigSnapshot
+	(*Attribute_NumberSyntax)(nil),         // 14: hcl.Attribute.NumberSyntax
+	(*NestedBlock_AnyType)(nil),            // 15: hcl.NestedBlock.AnyType
+	(*ConfigSnapshot_TraceEntry)(nil),      // 16: hcl.ConfigSnapshot.TraceEntry
+	(*ConfigSnapshot_PathStep)(nil),        // 17: hcl.ConfigSnapshot.PathStep
+	(*descriptorpb.FileDescriptorSet)(nil), // 18: google.protobuf.FileDescriptorSet
+	(*descriptorpb.FieldOptions)(nil),      // 19: google.protobuf.FieldOptions
+	(*descriptorpb.MessageOptions)(nil),    // 20: google.protobuf.MessageOptions
+	(*descriptorpb.EnumValueOptions)(nil),  // 21: google.protobuf.EnumValueOptions
+}
+var file_hcl_proto_depIdxs = []int32{
+	1,  // 0: hcl.Attribute.raw:type_name -> hcl.Attribute.RawMode
+	2,  // 1: hcl.Attribute.kind:type_name -> hcl.NestedBlock.CollectionKind
+	14, // 2: hcl.Attribute.number_syntax:type_name -> hcl.Attribute.NumberSyntax
+	2,  // 3: hcl.NestedBlock.kind:type_name -> hcl.NestedBlock.CollectionKind
+	15, // 4: hcl.NestedBlock.any_types:type_name -> hcl.NestedBlock.AnyType
+	9,  // 5: hcl.SourceRange.start:type_name -> hcl.SourcePos
+	9,  // 6: hcl.SourceRange.end:type_name -> hcl.SourcePos
+	3,  // 7: hcl.Diagnostic.severity:type_name -> hcl.Diagnostic.Severity
+	8,  // 8: hcl.Diagnostic.subject:type_name -> hcl.SourceRange
+	8,  // 9: hcl.Diagnostic.context:type_name -> hcl.SourceRange
+	18, // 10: hcl.ConfigSnapshot.files:type_name -> google.protobuf.FileDescriptorSet
+	16, // 11: hcl.ConfigSnapshot.trace:type_name -> hcl.ConfigSnapshot.TraceEntry
+	17, // 12: hcl.ConfigSnapshot.TraceEntry.path:type_name -> hcl.ConfigSnapshot.PathStep
+	8,  // 13: hcl.ConfigSnapshot.TraceEntry.range:type_name -> hcl.SourceRange
+	19, // 14: hcl.attr:extendee -> google.protobuf.FieldOptions
+	19, // 15: hcl.block:extendee -> google.protobuf.FieldOptions
+	19, // 16: hcl.label:extendee -> google.protobuf.FieldOptions
+	19, // 17: hcl.flatten:extendee -> google.protobuf.FieldOptions
+	19, // 18: hcl.flatten_prefix:extendee -> google.protobuf.FieldOptions
+	19, // 19: hcl.flatten_conflict:extendee -> google.protobuf.FieldOptions
+	20, // 20: hcl.message:extendee -> google.protobuf.MessageOptions
+	21, // 21: hcl.enumval:extendee -> google.protobuf.EnumValueOptions
+	4,  // 22: hcl.attr:type_name -> hcl.Attribute
+	5,  // 23: hcl.block:type_name -> hcl.NestedBlock
+	6,  // 24: hcl.label:type_name -> hcl.BlockLabel
+	0,  // 25: hcl.flatten_conflict:type_name -> hcl.FlattenConflict
+	10, // 26: hcl.message:type_name -> hcl.Message
+	7,  // 27: hcl.enumval:type_name -> hcl.EnumValue
+	28, // [28:28] is the sub-list for method output_type
+	28, // [28:28] is the sub-list for method input_type
+	22, // [22:28] is the sub-list for extension type_name
+	14, // [14:22] is the sub-list for extension extendee
+	0,  // [0:14] is the sub-list for field type_name
+}
+
+func init() { file_hcl_proto_init() }
+func file_hcl_proto_init() {
+	if File_hcl_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_hcl_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Attribute); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -557,15 +2078,155 @@ func file_hcl_proto_init() {
 				return nil
 			}
 		}
+		file_hcl_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EnumValue); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hcl_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SourceRange); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hcl_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SourcePos); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hcl_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Message); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hcl_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SourceFile); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hcl_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Diagnostic); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hcl_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ConfigSnapshot); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hcl_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Attribute_NumberSyntax); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hcl_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NestedBlock_AnyType); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hcl_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ConfigSnapshot_TraceEntry); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hcl_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ConfigSnapshot_PathStep); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_hcl_proto_msgTypes[13].OneofWrappers = []interface{}{
+		(*ConfigSnapshot_PathStep_Field)(nil),
+		(*ConfigSnapshot_PathStep_ListIndex)(nil),
+		(*ConfigSnapshot_PathStep_MapKeyString)(nil),
+		(*ConfigSnapshot_PathStep_MapKeyInt)(nil),
+		(*ConfigSnapshot_PathStep_MapKeyUint)(nil),
+		(*ConfigSnapshot_PathStep_MapKeyBool)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_hcl_proto_rawDesc,
-			NumEnums:      2,
-			NumMessages:   3,
-			NumExtensions: 4,
+			NumEnums:      4,
+			NumMessages:   14,
+			NumExtensions: 8,
 			NumServices:   0,
 		},
 		GoTypes:           file_hcl_proto_goTypes,
@@ -0,0 +1,113 @@
+package protohclext
+
+import (
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// The field numbers of the extensions that hcl.proto declares, for tools
+// that need to recognize the options in serialized or unresolved
+// descriptors, where the extension types aren't available.
+const (
+	AttrFieldNumber            protoreflect.FieldNumber = 50000 // (hcl.attr), in FieldOptions
+	BlockFieldNumber           protoreflect.FieldNumber = 50001 // (hcl.block), in FieldOptions
+	LabelFieldNumber           protoreflect.FieldNumber = 50002 // (hcl.label), in FieldOptions
+	FlattenFieldNumber         protoreflect.FieldNumber = 50004 // (hcl.flatten), in FieldOptions
+	FlattenPrefixFieldNumber   protoreflect.FieldNumber = 50005 // (hcl.flatten_prefix), in FieldOptions
+	FlattenConflictFieldNumber protoreflect.FieldNumber = 50006 // (hcl.flatten_conflict), in FieldOptions
+	MessageFieldNumber         protoreflect.FieldNumber = 50000 // (hcl.message), in MessageOptions
+	EnumValueFieldNumber       protoreflect.FieldNumber = 50000 // (hcl.enumval), in EnumValueOptions
+)
+
+// HasAttrOption returns true if the given field has the (hcl.attr) option.
+func HasAttrOption(fd protoreflect.FieldDescriptor) bool {
+	return proto.HasExtension(fd.Options(), E_Attr)
+}
+
+// AttrOption returns the (hcl.attr) option of the given field, or nil if
+// it doesn't have one.
+func AttrOption(fd protoreflect.FieldDescriptor) *Attribute {
+	if !HasAttrOption(fd) {
+		return nil
+	}
+	return proto.GetExtension(fd.Options(), E_Attr).(*Attribute)
+}
+
+// HasBlockOption returns true if the given field has the (hcl.block) option.
+func HasBlockOption(fd protoreflect.FieldDescriptor) bool {
+	return proto.HasExtension(fd.Options(), E_Block)
+}
+
+// BlockOption returns the (hcl.block) option of the given field, or nil if
+// it doesn't have one.
+func BlockOption(fd protoreflect.FieldDescriptor) *NestedBlock {
+	if !HasBlockOption(fd) {
+		return nil
+	}
+	return proto.GetExtension(fd.Options(), E_Block).(*NestedBlock)
+}
+
+// HasLabelOption returns true if the given field has the (hcl.label) option.
+func HasLabelOption(fd protoreflect.FieldDescriptor) bool {
+	return proto.HasExtension(fd.Options(), E_Label)
+}
+
+// LabelOption returns the (hcl.label) option of the given field, or nil if
+// it doesn't have one.
+func LabelOption(fd protoreflect.FieldDescriptor) *BlockLabel {
+	if !HasLabelOption(fd) {
+		return nil
+	}
+	return proto.GetExtension(fd.Options(), E_Label).(*BlockLabel)
+}
+
+// FlattenOption returns the value of the (hcl.flatten) option of the given
+// field, which is false if it isn't set.
+//
+// A field with a non-empty (hcl.flatten_prefix) is also flattened even if
+// this option isn't set. See FlattenPrefixOption.
+func FlattenOption(fd protoreflect.FieldDescriptor) bool {
+	return proto.GetExtension(fd.Options(), E_Flatten).(bool)
+}
+
+// FlattenPrefixOption returns the value of the (hcl.flatten_prefix) option
+// of the given field, which is empty if it isn't set.
+func FlattenPrefixOption(fd protoreflect.FieldDescriptor) string {
+	return proto.GetExtension(fd.Options(), E_FlattenPrefix).(string)
+}
+
+// FlattenConflictOption returns the value of the (hcl.flatten_conflict)
+// option of the given field, which is CONFLICT_ERROR if it isn't set.
+func FlattenConflictOption(fd protoreflect.FieldDescriptor) FlattenConflict {
+	return proto.GetExtension(fd.Options(), E_FlattenConflict).(FlattenConflict)
+}
+
+// HasMessageOption returns true if the given message type has the
+// (hcl.message) option.
+func HasMessageOption(md protoreflect.MessageDescriptor) bool {
+	return proto.HasExtension(md.Options(), E_Message)
+}
+
+// MessageOption returns the (hcl.message) option of the given message type,
+// or nil if it doesn't have one.
+func MessageOption(md protoreflect.MessageDescriptor) *Message {
+	if !HasMessageOption(md) {
+		return nil
+	}
+	return proto.GetExtension(md.Options(), E_Message).(*Message)
+}
+
+// HasEnumValueOption returns true if the given enum value has the
+// (hcl.enumval) option.
+func HasEnumValueOption(evd protoreflect.EnumValueDescriptor) bool {
+	return proto.HasExtension(evd.Options(), E_Enumval)
+}
+
+// EnumValueOption returns the (hcl.enumval) option of the given enum value,
+// or nil if it doesn't have one.
+func EnumValueOption(evd protoreflect.EnumValueDescriptor) *EnumValue {
+	if !HasEnumValueOption(evd) {
+		return nil
+	}
+	return proto.GetExtension(evd.Options(), E_Enumval).(*EnumValue)
+}
@@ -0,0 +1,112 @@
+package protohclext_test
+
+import (
+	"testing"
+
+	"github.com/apparentlymart/go-protohcl/protohcl/internal/testschema"
+	"github.com/apparentlymart/go-protohcl/protohcl/protohclext"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func TestFieldNumbers(t *testing.T) {
+	tests := map[protoreflect.FieldNumber]protoreflect.ExtensionType{
+		protohclext.AttrFieldNumber:            protohclext.E_Attr,
+		protohclext.BlockFieldNumber:           protohclext.E_Block,
+		protohclext.LabelFieldNumber:           protohclext.E_Label,
+		protohclext.FlattenFieldNumber:         protohclext.E_Flatten,
+		protohclext.FlattenPrefixFieldNumber:   protohclext.E_FlattenPrefix,
+		protohclext.FlattenConflictFieldNumber: protohclext.E_FlattenConflict,
+	}
+	for num, ext := range tests {
+		if got := ext.TypeDescriptor().Number(); got != num {
+			t.Errorf("wrong field number for %s: got %d, want %d", ext.TypeDescriptor().FullName(), got, num)
+		}
+	}
+	if got, want := protohclext.E_Message.TypeDescriptor().Number(), protohclext.MessageFieldNumber; got != want {
+		t.Errorf("wrong field number for hcl.message: got %d, want %d", got, want)
+	}
+	if got, want := protohclext.E_Enumval.TypeDescriptor().Number(), protohclext.EnumValueFieldNumber; got != want {
+		t.Errorf("wrong field number for hcl.enumval: got %d, want %d", got, want)
+	}
+}
+
+func TestFieldOptions(t *testing.T) {
+	msgs := testschema.File_testschema_proto.Messages()
+
+	attrField := msgs.ByName("WithStringAttr").Fields().ByName("name")
+	if !protohclext.HasAttrOption(attrField) {
+		t.Errorf("HasAttrOption is false for %s", attrField.FullName())
+	}
+	if got, want := protohclext.AttrOption(attrField).GetName(), "name"; got != want {
+		t.Errorf("wrong attribute name %q; want %q", got, want)
+	}
+	if protohclext.HasBlockOption(attrField) || protohclext.BlockOption(attrField) != nil {
+		t.Errorf("%s has unexpected block option", attrField.FullName())
+	}
+	if protohclext.HasLabelOption(attrField) || protohclext.LabelOption(attrField) != nil {
+		t.Errorf("%s has unexpected label option", attrField.FullName())
+	}
+
+	blockField := msgs.ByName("WithNestedBlockNoLabelsSingleton").Fields().ByName("doodad")
+	if got, want := protohclext.BlockOption(blockField).GetTypeName(), "doodad"; got != want {
+		t.Errorf("wrong block type name %q; want %q", got, want)
+	}
+	if protohclext.AttrOption(blockField) != nil {
+		t.Errorf("%s has unexpected attribute option", blockField.FullName())
+	}
+
+	labelField := msgs.ByName("WithOneBlockLabel").Fields().ByName("name")
+	if got, want := protohclext.LabelOption(labelField).GetName(), "name"; got != want {
+		t.Errorf("wrong label name %q; want %q", got, want)
+	}
+
+	prefixField := msgs.ByName("WithFlattenPrefix").Fields().ByName("client")
+	if got, want := protohclext.FlattenPrefixOption(prefixField), "client_tls_"; got != want {
+		t.Errorf("wrong flatten prefix %q; want %q", got, want)
+	}
+	if protohclext.FlattenOption(prefixField) {
+		t.Errorf("FlattenOption is true for %s", prefixField.FullName())
+	}
+
+	conflictField := msgs.ByName("WithFlattenConflictOuterWins").Fields().ByName("legacy")
+	if !protohclext.FlattenOption(conflictField) {
+		t.Errorf("FlattenOption is false for %s", conflictField.FullName())
+	}
+	if got, want := protohclext.FlattenConflictOption(conflictField), protohclext.FlattenConflict_OUTER_WINS; got != want {
+		t.Errorf("wrong flatten conflict %s; want %s", got, want)
+	}
+	if got, want := protohclext.FlattenConflictOption(attrField), protohclext.FlattenConflict_CONFLICT_ERROR; got != want {
+		t.Errorf("wrong default flatten conflict %s; want %s", got, want)
+	}
+}
+
+func TestMessageOptions(t *testing.T) {
+	msgs := testschema.File_testschema_proto.Messages()
+
+	rootOnly := msgs.ByName("RootOnlyConfig")
+	if !protohclext.HasMessageOption(rootOnly) {
+		t.Errorf("HasMessageOption is false for %s", rootOnly.FullName())
+	}
+	if !protohclext.MessageOption(rootOnly).GetRootOnly() {
+		t.Errorf("%s is not root_only", rootOnly.FullName())
+	}
+
+	plain := msgs.ByName("WithStringAttr")
+	if protohclext.HasMessageOption(plain) || protohclext.MessageOption(plain) != nil {
+		t.Errorf("%s has unexpected message option", plain.FullName())
+	}
+}
+
+func TestEnumValueOptions(t *testing.T) {
+	vals := testschema.File_testschema_proto.Enums().ByName("Level").Values()
+
+	debug := vals.ByName("LEVEL_DEBUG")
+	if got, want := protohclext.EnumValueOption(debug).GetName(), "debug"; got != want {
+		t.Errorf("wrong enum value name %q; want %q", got, want)
+	}
+
+	unspecified := vals.ByName("LEVEL_UNSPECIFIED")
+	if protohclext.HasEnumValueOption(unspecified) || protohclext.EnumValueOption(unspecified) != nil {
+		t.Errorf("%s has unexpected enum value option", unspecified.FullName())
+	}
+}
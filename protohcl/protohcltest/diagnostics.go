@@ -0,0 +1,138 @@
+package protohcltest
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+)
+
+// ExpectedDiagnostic describes a diagnostic that a test expects, for use
+// with AssertDiagnostics.
+//
+// It deliberately has no way to describe an exact source position, because
+// tests that check byte offsets break whenever the indentation or layout of
+// their configuration fixtures changes. Line is the only part of the
+// location it can check.
+type ExpectedDiagnostic struct {
+	Severity hcl.DiagnosticSeverity
+	Summary  string
+
+	// Detail is the expected detail message, or empty to accept any detail.
+	Detail string
+
+	// Line is the expected line of the start of the diagnostic's subject,
+	// or zero to accept any location, including none at all.
+	Line int
+}
+
+// Error returns an ExpectedDiagnostic for an error with the given summary,
+// accepting any detail and location.
+func Error(summary string) ExpectedDiagnostic {
+	return ExpectedDiagnostic{
+		Severity: hcl.DiagError,
+		Summary:  summary,
+	}
+}
+
+// Warning returns an ExpectedDiagnostic for a warning with the given
+// summary, accepting any detail and location.
+func Warning(summary string) ExpectedDiagnostic {
+	return ExpectedDiagnostic{
+		Severity: hcl.DiagWarning,
+		Summary:  summary,
+	}
+}
+
+// WithDetail returns a copy of the receiver that also expects the given
+// detail message.
+func (d ExpectedDiagnostic) WithDetail(detail string) ExpectedDiagnostic {
+	d.Detail = detail
+	return d
+}
+
+// OnLine returns a copy of the receiver that also expects the diagnostic's
+// subject to start on the given line.
+func (d ExpectedDiagnostic) OnLine(line int) ExpectedDiagnostic {
+	d.Line = line
+	return d
+}
+
+// Matches returns true if the given diagnostic meets all of the receiver's
+// expectations.
+func (d ExpectedDiagnostic) Matches(diag *hcl.Diagnostic) bool {
+	if diag.Severity != d.Severity || diag.Summary != d.Summary {
+		return false
+	}
+	if d.Detail != "" && diag.Detail != d.Detail {
+		return false
+	}
+	if d.Line != 0 && (diag.Subject == nil || diag.Subject.Start.Line != d.Line) {
+		return false
+	}
+	return true
+}
+
+func (d ExpectedDiagnostic) String() string {
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "%s: %s", severityName(d.Severity), d.Summary)
+	if d.Detail != "" {
+		fmt.Fprintf(&buf, "; %s", d.Detail)
+	}
+	if d.Line != 0 {
+		fmt.Fprintf(&buf, " (line %d)", d.Line)
+	}
+	return buf.String()
+}
+
+// AssertDiagnostics fails the given test unless the given diagnostics match
+// the given expectations one-to-one and in the same order.
+//
+// The diagnostics that protohcl's decoding functions return are already in
+// a consistent order, as described for protohcl.SortDiagnostics.
+func AssertDiagnostics(t testing.TB, got hcl.Diagnostics, want ...ExpectedDiagnostic) {
+	t.Helper()
+
+	ok := len(got) == len(want)
+	for i := 0; ok && i < len(want); i++ {
+		ok = want[i].Matches(got[i])
+	}
+	if ok {
+		return
+	}
+
+	var buf strings.Builder
+	buf.WriteString("wrong diagnostics\ngot:\n")
+	for _, diag := range got {
+		fmt.Fprintf(&buf, "  %s\n", diagnosticString(diag))
+	}
+	buf.WriteString("want:\n")
+	for _, diag := range want {
+		fmt.Fprintf(&buf, "  %s\n", diag)
+	}
+	t.Error(buf.String())
+}
+
+func diagnosticString(diag *hcl.Diagnostic) string {
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "%s: %s", severityName(diag.Severity), diag.Summary)
+	if diag.Detail != "" {
+		fmt.Fprintf(&buf, "; %s", diag.Detail)
+	}
+	if diag.Subject != nil {
+		fmt.Fprintf(&buf, " (line %d)", diag.Subject.Start.Line)
+	}
+	return buf.String()
+}
+
+func severityName(severity hcl.DiagnosticSeverity) string {
+	switch severity {
+	case hcl.DiagError:
+		return "error"
+	case hcl.DiagWarning:
+		return "warning"
+	default:
+		return fmt.Sprintf("severity(%d)", severity)
+	}
+}
@@ -0,0 +1,135 @@
+package protohcltest
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/apparentlymart/go-protohcl/protohcl"
+	"github.com/apparentlymart/go-protohcl/protohcl/internal/testschema"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+func TestExpectedDiagnosticMatches(t *testing.T) {
+	diag := &hcl.Diagnostic{
+		Severity: hcl.DiagError,
+		Summary:  "Unsupported argument",
+		Detail:   `An argument named "nope" is not expected here.`,
+		Subject: &hcl.Range{
+			Start: hcl.Pos{Line: 3, Column: 5, Byte: 20},
+			End:   hcl.Pos{Line: 3, Column: 9, Byte: 24},
+		},
+	}
+
+	tests := map[string]struct {
+		want ExpectedDiagnostic
+		ok   bool
+	}{
+		"summary only": {
+			Error("Unsupported argument"),
+			true,
+		},
+		"wrong severity": {
+			Warning("Unsupported argument"),
+			false,
+		},
+		"wrong summary": {
+			Error("Missing required argument"),
+			false,
+		},
+		"detail": {
+			Error("Unsupported argument").WithDetail(`An argument named "nope" is not expected here.`),
+			true,
+		},
+		"wrong detail": {
+			Error("Unsupported argument").WithDetail(`Something else.`),
+			false,
+		},
+		"line": {
+			Error("Unsupported argument").OnLine(3),
+			true,
+		},
+		"wrong line": {
+			Error("Unsupported argument").OnLine(4),
+			false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := test.want.Matches(diag); got != test.ok {
+				t.Errorf("wrong result %t; want %t", got, test.ok)
+			}
+		})
+	}
+
+	// A diagnostic without a subject matches only if we don't check the line.
+	noSubject := &hcl.Diagnostic{Severity: hcl.DiagError, Summary: "Oops"}
+	if !Error("Oops").Matches(noSubject) {
+		t.Errorf("doesn't match diagnostic without subject")
+	}
+	if Error("Oops").OnLine(1).Matches(noSubject) {
+		t.Errorf("line expectation matches diagnostic without subject")
+	}
+}
+
+func TestAssertDiagnostics(t *testing.T) {
+	desc := testschema.File_testschema_proto.Messages().ByName("WithStringAttr")
+
+	// The same problem with different indentation must give diagnostics
+	// that match the same expectations.
+	for _, src := range []string{"\nnope = 1\n", "\n        nope = 1\n"} {
+		f, diags := hclsyntax.ParseConfig([]byte(src), "test.hcl", hcl.InitialPos)
+		if diags.HasErrors() {
+			t.Fatalf("unexpected syntax errors: %s", diags.Error())
+		}
+		_, diags = protohcl.DecodeBody(f.Body, desc, nil)
+		AssertDiagnostics(t, diags,
+			Error("Unsupported argument").OnLine(2),
+		)
+	}
+
+	t.Run("failure", func(t *testing.T) {
+		diags := hcl.Diagnostics{
+			{
+				Severity: hcl.DiagWarning,
+				Summary:  "Deprecated",
+				Detail:   "Don't use this.",
+				Subject:  &hcl.Range{Start: hcl.Pos{Line: 2}},
+			},
+		}
+		rec := &recordingTB{TB: t}
+		AssertDiagnostics(rec, diags, Error("Deprecated"))
+		want := strings.Join([]string{
+			"wrong diagnostics",
+			"got:",
+			"  warning: Deprecated; Don't use this. (line 2)",
+			"want:",
+			"  error: Deprecated",
+			"",
+		}, "\n")
+		if rec.errors != want {
+			t.Errorf("wrong failure message\ngot:\n%s\nwant:\n%s", rec.errors, want)
+		}
+
+		rec = &recordingTB{TB: t}
+		AssertDiagnostics(rec, diags)
+		if rec.errors == "" {
+			t.Errorf("no failure for unexpected diagnostic")
+		}
+	})
+}
+
+// recordingTB is a testing.TB that records errors instead of failing the
+// test, so we can test how AssertDiagnostics reports failures.
+type recordingTB struct {
+	testing.TB
+	errors string
+}
+
+func (tb *recordingTB) Helper() {}
+
+func (tb *recordingTB) Error(args ...interface{}) {
+	tb.errors += fmt.Sprint(args...)
+}
@@ -0,0 +1,59 @@
+// Package protohcltest contains helpers for writing tests of code that
+// decodes HCL configuration using package protohcl, such as the tests for
+// a plugin that declares its configuration schema with hcl.proto
+// annotations.
+package protohcltest
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	hcljson "github.com/hashicorp/hcl/v2/json"
+)
+
+// BodyFilename is the filename that BodyFromMap uses for the bodies it
+// returns, which appears in the source ranges of any diagnostics about them.
+const BodyFilename = "BodyFromMap.json"
+
+// BodyFromMap returns an HCL body with the content described by the given
+// map, failing the given test if that isn't possible.
+//
+// The map uses the same structure as the HCL JSON syntax, because
+// BodyFromMap encodes it as JSON and then parses the result. Each element
+// of the map is therefore either an attribute or a nested block, decided
+// by the schema that the body is later decoded with:
+//
+//	protohcltest.BodyFromMap(t, map[string]interface{}{
+//		"name": "example",
+//		"thing": map[string]interface{}{
+//			"a": map[string]interface{}{"enabled": true},
+//		},
+//	})
+//
+// A map element for a block type with labels has one level of nested map
+// per label, keyed by the label values, and a slice of maps can give
+// several blocks of the same type and labels. Values can be anything that
+// encoding/json can encode, and so strings in particular are HCL templates:
+// "${var.name}" refers to a variable in the evaluation context used for
+// decoding, and a literal "${" must be escaped as "$${".
+//
+// The source ranges in the result refer to generated JSON under the
+// filename BodyFilename, and so tests shouldn't depend on their exact
+// positions.
+func BodyFromMap(t testing.TB, m map[string]interface{}) hcl.Body {
+	t.Helper()
+
+	if m == nil {
+		m = map[string]interface{}{} // encodes as an empty object, not null
+	}
+	src, err := json.Marshal(m)
+	if err != nil {
+		t.Fatalf("can't build HCL body from map: %s", err)
+	}
+	f, diags := hcljson.Parse(src, BodyFilename)
+	if diags.HasErrors() {
+		t.Fatalf("can't build HCL body from map: %s", diags.Error())
+	}
+	return f.Body
+}
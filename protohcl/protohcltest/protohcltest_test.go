@@ -0,0 +1,87 @@
+package protohcltest
+
+import (
+	"testing"
+
+	"github.com/apparentlymart/go-protohcl/protohcl"
+	"github.com/apparentlymart/go-protohcl/protohcl/internal/testschema"
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+func TestBodyFromMap(t *testing.T) {
+	ctx := &hcl.EvalContext{
+		Variables: map[string]cty.Value{
+			"name": cty.StringVal("from variable"),
+		},
+	}
+
+	tests := map[string]struct {
+		msgName protoreflect.Name
+		m       map[string]interface{}
+		want    proto.Message
+	}{
+		"nil": {
+			"WithStringAttr",
+			nil,
+			&testschema.WithStringAttr{},
+		},
+		"attribute": {
+			"WithStringAttr",
+			map[string]interface{}{
+				"name": "Jackson",
+			},
+			&testschema.WithStringAttr{Name: "Jackson"},
+		},
+		"template": {
+			"WithStringAttr",
+			map[string]interface{}{
+				"name": "${name}",
+			},
+			&testschema.WithStringAttr{Name: "from variable"},
+		},
+		"list attribute": {
+			"WithStringListAttr",
+			map[string]interface{}{
+				"names": []string{"a", "b"},
+			},
+			&testschema.WithStringListAttr{Names: []string{"a", "b"}},
+		},
+		"labeled blocks": {
+			"WithNestedBlockOneLabelRepeated",
+			map[string]interface{}{
+				"doodad": map[string]interface{}{
+					"a": map[string]interface{}{"nickname": "first"},
+					"b": []map[string]interface{}{
+						{"nickname": "second"},
+						{"nickname": "third"},
+					},
+				},
+			},
+			&testschema.WithNestedBlockOneLabelRepeated{
+				Doodad: []*testschema.WithOneBlockLabel{
+					{Name: "a", Nickname: "first"},
+					{Name: "b", Nickname: "second"},
+					{Name: "b", Nickname: "third"},
+				},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			desc := testschema.File_testschema_proto.Messages().ByName(test.msgName)
+			got, diags := protohcl.DecodeBody(BodyFromMap(t, test.m), desc, ctx)
+			if diags.HasErrors() {
+				t.Fatalf("unexpected errors: %s", diags.Error())
+			}
+			if diff := cmp.Diff(test.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("wrong result\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,187 @@
+package protohcl
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/apparentlymart/go-protohcl/protohcl/protohclext"
+	ctyyaml "github.com/zclconf/go-cty-yaml"
+	"github.com/zclconf/go-cty/cty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+)
+
+// RawCodec is an encoding of HCL values as bytes, which protohcl uses for
+// the fields of attributes whose (hcl.attr).raw selects the codec's raw
+// mode.
+//
+// protohcl has built-in codecs for all of the raw modes declared in
+// hcl.proto. RegisterRawCodec can add a codec for another raw mode.
+type RawCodec interface {
+	// Marshal encodes the given value, which conforms to the given type
+	// constraint.
+	Marshal(val cty.Value, ty cty.Type) ([]byte, error)
+
+	// Unmarshal decodes a value that Marshal encoded with the same type
+	// constraint. The result must conform to the type constraint.
+	Unmarshal(raw []byte, ty cty.Type) (cty.Value, error)
+}
+
+// RawTypeCodec is a RawCodec that can also report the type of an encoded
+// value more cheaply than by decoding the whole value, which RawFieldType
+// uses when available.
+type RawTypeCodec interface {
+	RawCodec
+
+	// UnmarshalType returns the type of the value that Unmarshal would
+	// return for the same arguments.
+	UnmarshalType(raw []byte, ty cty.Type) (cty.Type, error)
+}
+
+var (
+	rawCodecsMu sync.RWMutex
+	rawCodecs   = map[protohclext.Attribute_RawMode]RawCodec{
+		protohclext.Attribute_MESSAGEPACK: rawMessagePackCodec{},
+		protohclext.Attribute_JSON:        rawJSONCodec{},
+		protohclext.Attribute_YAML:        rawYAMLCodec{},
+	}
+)
+
+// RegisterRawCodec makes the given codec available for attributes whose
+// (hcl.attr).raw is the given raw mode.
+//
+// Call RegisterRawCodec from an init function, so that the codec is
+// available before any decoding begins. It panics if the mode is
+// NOT_RAW, if the codec is nil, or if the mode already has a codec,
+// including any of the built-in ones.
+func RegisterRawCodec(mode protohclext.Attribute_RawMode, codec RawCodec) {
+	if mode == protohclext.Attribute_NOT_RAW {
+		panic("protohcl: can't register a codec for NOT_RAW")
+	}
+	if codec == nil {
+		panic("protohcl: RegisterRawCodec with nil codec")
+	}
+
+	rawCodecsMu.Lock()
+	defer rawCodecsMu.Unlock()
+	if _, exists := rawCodecs[mode]; exists {
+		panic(fmt.Sprintf("protohcl: raw mode %s already has a codec", mode))
+	}
+	rawCodecs[mode] = codec
+}
+
+// rawCodecFor returns the codec for the given attribute's raw mode, or a
+// schema error if there is none.
+func rawCodecFor(attr FieldAttribute) (RawCodec, error) {
+	codec, ok := rawCodecForMode(attr.RawMode)
+	if !ok {
+		if attr.RawMode.Descriptor().Values().ByNumber(attr.RawMode.Number()) == nil {
+			return nil, schemaError{
+				Decl: attr.TargetField.FullName(),
+				Err:  unsupportedFeatureError{Feature: Feature(fmt.Sprintf("(hcl.attr).raw = %d", attr.RawMode))},
+			}
+		}
+		return nil, schemaErrorf(attr.TargetField.FullName(), "unsupported raw mode %s", attr.RawMode)
+	}
+	return codec, nil
+}
+
+// rawCodecForMode returns the codec registered for the given raw mode, if
+// any.
+func rawCodecForMode(mode protohclext.Attribute_RawMode) (RawCodec, bool) {
+	rawCodecsMu.RLock()
+	defer rawCodecsMu.RUnlock()
+	codec, ok := rawCodecs[mode]
+	return codec, ok
+}
+
+// rawModeName returns a name for the given raw mode that's suitable for
+// inclusion in error messages.
+func rawModeName(mode protohclext.Attribute_RawMode) string {
+	switch mode {
+	case protohclext.Attribute_MESSAGEPACK:
+		return "MessagePack"
+	case protohclext.Attribute_JSON:
+		return "JSON"
+	case protohclext.Attribute_YAML:
+		return "YAML"
+	default:
+		return mode.String()
+	}
+}
+
+type rawMessagePackCodec struct{}
+
+func (rawMessagePackCodec) Marshal(val cty.Value, ty cty.Type) ([]byte, error) {
+	return FormatRawMessagePack(val, ty)
+}
+
+func (rawMessagePackCodec) Unmarshal(raw []byte, ty cty.Type) (cty.Value, error) {
+	return ParseRawMessagePack(raw, ty)
+}
+
+func (rawMessagePackCodec) UnmarshalType(raw []byte, ty cty.Type) (cty.Type, error) {
+	return ParseRawMessagePackType(raw, ty)
+}
+
+// rawJSONCodec uses cty's JSON encoding, except that for the type
+// constraint "any" it uses the raw dynamic format of FormatRawDynamic, so
+// that it can record the value's type.
+type rawJSONCodec struct{}
+
+func (rawJSONCodec) Marshal(val cty.Value, ty cty.Type) ([]byte, error) {
+	if ty == cty.DynamicPseudoType {
+		return FormatRawDynamic(val)
+	}
+	return ctyjson.Marshal(val, ty)
+}
+
+func (rawJSONCodec) Unmarshal(raw []byte, ty cty.Type) (cty.Value, error) {
+	if ty == cty.DynamicPseudoType {
+		return ParseRawDynamic(raw)
+	}
+	return ctyjson.Unmarshal(raw, ty)
+}
+
+func (c rawJSONCodec) UnmarshalType(raw []byte, ty cty.Type) (cty.Type, error) {
+	switch {
+	case !ty.HasDynamicTypes():
+		return ty, nil
+	case ty == cty.DynamicPseudoType:
+		return ParseRawDynamicType(raw)
+	default:
+		// The JSON encoding only records the types of the "any" parts
+		// of a type constraint by the shape of the value, so we
+		// must decode it all to find them.
+		v, err := c.Unmarshal(raw, ty)
+		if err != nil {
+			return cty.DynamicPseudoType, err
+		}
+		return v.Type(), nil
+	}
+}
+
+// rawYAMLCodec uses the standard YAML encoding from go-cty-yaml.
+type rawYAMLCodec struct{}
+
+func (rawYAMLCodec) Marshal(val cty.Value, ty cty.Type) ([]byte, error) {
+	return ctyyaml.Marshal(val)
+}
+
+func (rawYAMLCodec) Unmarshal(raw []byte, ty cty.Type) (cty.Value, error) {
+	return ctyyaml.Unmarshal(raw, ty)
+}
+
+func (c rawYAMLCodec) UnmarshalType(raw []byte, ty cty.Type) (cty.Type, error) {
+	switch {
+	case !ty.HasDynamicTypes():
+		return ty, nil
+	case ty == cty.DynamicPseudoType:
+		return ctyyaml.ImpliedType(raw)
+	default:
+		v, err := c.Unmarshal(raw, ty)
+		if err != nil {
+			return cty.DynamicPseudoType, err
+		}
+		return v.Type(), nil
+	}
+}
@@ -0,0 +1,145 @@
+package protohcl
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/apparentlymart/go-protohcl/protohcl/protohclext"
+	"github.com/apparentlymart/go-protohcl/protohcl/schemabuilder"
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty-debug/ctydebug"
+	"github.com/zclconf/go-cty/cty"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func TestRawYAML(t *testing.T) {
+	f := schemabuilder.NewFile("raw_yaml_test.proto", "protohcl.rawyaml")
+	f.AddMessage("Config").
+		AddAttribute("names", cty.List(cty.String), schemabuilder.RawYAML).
+		AddAttribute("extra", cty.DynamicPseudoType, schemabuilder.RawYAML)
+	fileDesc, err := f.Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	desc := fileDesc.Messages().ByName("Config")
+
+	src := `
+		names = ["a", "b"]
+		extra = { enabled = true, count = 2 }
+	`
+	hclFile, diags := hclsyntax.ParseConfig([]byte(src), "", hcl.InitialPos)
+	if diags.HasErrors() {
+		t.Fatalf("unexpected syntax errors: %s", diags.Error())
+	}
+	msg, diags := DecodeBody(hclFile.Body, desc, nil)
+	if diags.HasErrors() {
+		t.Fatalf("unexpected errors: %s", diags.Error())
+	}
+
+	m := msg.ProtoReflect()
+	if got, want := string(m.Get(desc.Fields().ByName("names")).Bytes()), "- \"a\"\n- \"b\"\n"; got != want {
+		t.Errorf("wrong encoding of names\ngot:  %q\nwant: %q", got, want)
+	}
+
+	got, err := ObjectValueForMessage(msg)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := cty.ObjectVal(map[string]cty.Value{
+		"names": cty.ListVal([]cty.Value{cty.StringVal("a"), cty.StringVal("b")}),
+		"extra": cty.ObjectVal(map[string]cty.Value{
+			"enabled": cty.True,
+			"count":   cty.NumberIntVal(2),
+		}),
+	})
+	if diff := cmp.Diff(want, got, ctydebug.CmpOptions); diff != "" {
+		t.Errorf("wrong result\n%s", diff)
+	}
+
+	ty, err := RawFieldType(msg, "extra")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if wantTy := want.GetAttr("extra").Type(); !ty.Equals(wantTy) {
+		t.Errorf("wrong type\ngot:  %#v\nwant: %#v", ty, wantTy)
+	}
+}
+
+// rawReverseCodec is a toy codec for string values that just reverses the
+// bytes of the string.
+type rawReverseCodec struct{}
+
+func (rawReverseCodec) Marshal(val cty.Value, ty cty.Type) ([]byte, error) {
+	ret := []byte(val.AsString())
+	for i, j := 0, len(ret)-1; i < j; i, j = i+1, j-1 {
+		ret[i], ret[j] = ret[j], ret[i]
+	}
+	return ret, nil
+}
+
+func (c rawReverseCodec) Unmarshal(raw []byte, ty cty.Type) (cty.Value, error) {
+	ret, err := c.Marshal(cty.StringVal(string(raw)), ty)
+	return cty.StringVal(string(ret)), err
+}
+
+var registerReverseCodec sync.Once
+
+func TestRegisterRawCodec(t *testing.T) {
+	const mode = protohclext.Attribute_RawMode(100)
+	// Registration is global, so we must register only once even if the
+	// test runs several times.
+	registerReverseCodec.Do(func() {
+		RegisterRawCodec(mode, rawReverseCodec{})
+	})
+
+	f := schemabuilder.NewFile("raw_codec_test.proto", "protohcl.rawcodec")
+	f.AddMessage("Config").
+		AddAttribute("name", cty.String, func(attr *protohclext.Attribute) {
+			attr.Raw = mode
+		})
+	fileDesc, err := f.Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	desc := fileDesc.Messages().ByName("Config")
+
+	hclFile, diags := hclsyntax.ParseConfig([]byte(`name = "hello"`), "", hcl.InitialPos)
+	if diags.HasErrors() {
+		t.Fatalf("unexpected syntax errors: %s", diags.Error())
+	}
+	msg, diags := DecodeBody(hclFile.Body, desc, nil)
+	if diags.HasErrors() {
+		t.Fatalf("unexpected errors: %s", diags.Error())
+	}
+	if got, want := string(msg.ProtoReflect().Get(desc.Fields().ByName(protoreflect.Name("name"))).Bytes()), "olleh"; got != want {
+		t.Errorf("wrong encoding\ngot:  %q\nwant: %q", got, want)
+	}
+	got, err := ObjectValueForMessage(msg)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := cty.ObjectVal(map[string]cty.Value{
+		"name": cty.StringVal("hello"),
+	})
+	if diff := cmp.Diff(want, got, ctydebug.CmpOptions); diff != "" {
+		t.Errorf("wrong result\n%s", diff)
+	}
+
+	for name, register := range map[string]func(){
+		"built-in mode":   func() { RegisterRawCodec(protohclext.Attribute_YAML, rawReverseCodec{}) },
+		"registered mode": func() { RegisterRawCodec(mode, rawReverseCodec{}) },
+		"not raw":         func() { RegisterRawCodec(protohclext.Attribute_NOT_RAW, rawReverseCodec{}) },
+		"nil codec":       func() { RegisterRawCodec(101, nil) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("RegisterRawCodec didn't panic")
+				}
+			}()
+			register()
+		})
+	}
+}
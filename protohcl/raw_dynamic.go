@@ -0,0 +1,124 @@
+package protohcl
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/zclconf/go-cty/cty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+)
+
+// RawDynamicVersion is the version of the JSON framing that FormatRawDynamic
+// produces, and the latest version that ParseRawDynamic understands.
+const RawDynamicVersion = 1
+
+// FormatRawDynamic encodes the given value in the JSON format that protohcl
+// uses for raw-mode JSON fields whose type constraint is "any".
+//
+// Because the type constraint doesn't determine the value's type, the
+// encoding must include the type along with the value. The result is a JSON
+// object with the following properties:
+//
+//   - "version" is the number 1, identifying this version of the format.
+//   - "type" is the value's type, in the JSON type encoding used by
+//     cty's "json" package, such as "string" or ["list","number"].
+//   - "value" is the value itself, in the JSON encoding of that type.
+//
+// Other programs, including those not written in Go, can rely on this
+// format to produce and consume the content of such fields. Future versions
+// of protohcl will continue to accept version 1 payloads even if they
+// produce a newer version.
+//
+// The given value must be wholly known, because JSON can't represent unknown
+// values. Use MessagePack raw mode for fields that must accept unknown
+// values.
+func FormatRawDynamic(val cty.Value) ([]byte, error) {
+	ty := val.Type()
+	rawType, err := ctyjson.MarshalType(ty)
+	if err != nil {
+		return nil, err
+	}
+	rawValue, err := ctyjson.Marshal(val, ty)
+	if err != nil {
+		return nil, err
+	}
+	version := RawDynamicVersion
+	return json.Marshal(rawDynamic{
+		Version: &version,
+		Type:    rawType,
+		Value:   rawValue,
+	})
+}
+
+// ParseRawDynamic is the inverse of FormatRawDynamic, decoding a value and
+// its type from a JSON payload.
+//
+// ParseRawDynamic also accepts payloads without the "version" property,
+// which earlier versions of protohcl produced, treating them as version 1.
+// It returns an error for any other version, and for any properties other
+// than the ones that FormatRawDynamic produces.
+func ParseRawDynamic(raw []byte) (cty.Value, error) {
+	frame, ty, err := parseRawDynamicFrame(raw)
+	if err != nil {
+		return cty.DynamicVal, err
+	}
+	val, err := ctyjson.Unmarshal(frame.Value, ty)
+	if err != nil {
+		return cty.DynamicVal, fmt.Errorf("invalid raw dynamic value: %w", err)
+	}
+	return val, nil
+}
+
+// ParseRawDynamicType is like ParseRawDynamic but returns only the type
+// recorded in the given payload, without decoding the value itself.
+//
+// The value is exactly of the returned type, including the element types
+// of any tuple types and the attribute types of any object types, so a
+// caller can use this to cheaply decide how to handle a value before
+// decoding it.
+func ParseRawDynamicType(raw []byte) (cty.Type, error) {
+	_, ty, err := parseRawDynamicFrame(raw)
+	if err != nil {
+		return cty.DynamicPseudoType, err
+	}
+	return ty, nil
+}
+
+// parseRawDynamicFrame decodes the JSON framing of a payload produced by
+// FormatRawDynamic, and the value type that it records.
+func parseRawDynamicFrame(raw []byte) (rawDynamic, cty.Type, error) {
+	var frame rawDynamic
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&frame); err != nil {
+		return frame, cty.DynamicPseudoType, fmt.Errorf("invalid raw dynamic value: %w", err)
+	}
+	if dec.More() {
+		return frame, cty.DynamicPseudoType, fmt.Errorf("invalid raw dynamic value: unexpected extra content after JSON object")
+	}
+	if frame.Version != nil && *frame.Version != RawDynamicVersion {
+		return frame, cty.DynamicPseudoType, fmt.Errorf("unsupported raw dynamic value format version %d", *frame.Version)
+	}
+	if len(frame.Type) == 0 {
+		return frame, cty.DynamicPseudoType, fmt.Errorf("invalid raw dynamic value: missing \"type\" property")
+	}
+	if len(frame.Value) == 0 {
+		return frame, cty.DynamicPseudoType, fmt.Errorf("invalid raw dynamic value: missing \"value\" property")
+	}
+
+	ty, err := ctyjson.UnmarshalType(frame.Type)
+	if err != nil {
+		return frame, cty.DynamicPseudoType, fmt.Errorf("invalid raw dynamic value type: %w", err)
+	}
+	return frame, ty, nil
+}
+
+// rawDynamic is the JSON framing used by FormatRawDynamic and
+// ParseRawDynamic. The field order here determines the property order in
+// the result of FormatRawDynamic.
+type rawDynamic struct {
+	Version *int            `json:"version"`
+	Type    json.RawMessage `json:"type"`
+	Value   json.RawMessage `json:"value"`
+}
@@ -0,0 +1,240 @@
+package protohcl
+
+import (
+	"testing"
+
+	"github.com/apparentlymart/go-protohcl/protohcl/internal/testschema"
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty-debug/ctydebug"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// The expected payloads in these tests are the documented format of raw
+// dynamic values, which other programs rely on, so they must not change
+// except by introducing a new format version.
+
+func TestFormatRawDynamic(t *testing.T) {
+	tests := map[string]struct {
+		val  cty.Value
+		want string
+	}{
+		"string": {
+			cty.StringVal("hello"),
+			`{"version":1,"type":"string","value":"hello"}`,
+		},
+		"number": {
+			cty.NumberIntVal(2),
+			`{"version":1,"type":"number","value":2}`,
+		},
+		"bool": {
+			cty.True,
+			`{"version":1,"type":"bool","value":true}`,
+		},
+		"null": {
+			cty.NullVal(cty.String),
+			`{"version":1,"type":"string","value":null}`,
+		},
+		"list": {
+			cty.ListVal([]cty.Value{cty.StringVal("a"), cty.StringVal("b")}),
+			`{"version":1,"type":["list","string"],"value":["a","b"]}`,
+		},
+		"object": {
+			cty.ObjectVal(map[string]cty.Value{
+				"name":  cty.StringVal("Jackson"),
+				"count": cty.NumberIntVal(1),
+			}),
+			`{"version":1,"type":["object",{"count":"number","name":"string"}],"value":{"count":1,"name":"Jackson"}}`,
+		},
+		"tuple": {
+			cty.TupleVal([]cty.Value{cty.StringVal("a"), cty.Zero}),
+			`{"version":1,"type":["tuple",["string","number"]],"value":["a",0]}`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := FormatRawDynamic(test.val)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(test.want, string(got)); diff != "" {
+				t.Errorf("wrong result\n%s", diff)
+			}
+
+			back, err := ParseRawDynamic(got)
+			if err != nil {
+				t.Fatalf("unexpected error parsing result: %s", err)
+			}
+			if diff := cmp.Diff(test.val, back, ctydebug.CmpOptions); diff != "" {
+				t.Errorf("wrong round-trip result\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestFormatRawDynamicUnknown(t *testing.T) {
+	_, err := FormatRawDynamic(cty.UnknownVal(cty.String))
+	if err == nil {
+		t.Fatalf("unexpected success")
+	}
+}
+
+func TestParseRawDynamic(t *testing.T) {
+	tests := map[string]struct {
+		raw     string
+		want    cty.Value
+		wantErr string
+	}{
+		"current version": {
+			`{"version":1,"type":"string","value":"hello"}`,
+			cty.StringVal("hello"),
+			``,
+		},
+		"any property order": {
+			`{"value":["a"],"version":1,"type":["set","string"]}`,
+			cty.SetVal([]cty.Value{cty.StringVal("a")}),
+			``,
+		},
+		"no version": {
+			// Earlier versions of protohcl didn't include a version.
+			`{"value":"hello","type":"string"}`,
+			cty.StringVal("hello"),
+			``,
+		},
+		"future version": {
+			`{"version":2,"type":"string","value":"hello"}`,
+			cty.NilVal,
+			`unsupported raw dynamic value format version 2`,
+		},
+		"missing type": {
+			`{"version":1,"value":"hello"}`,
+			cty.NilVal,
+			`invalid raw dynamic value: missing "type" property`,
+		},
+		"missing value": {
+			`{"version":1,"type":"string"}`,
+			cty.NilVal,
+			`invalid raw dynamic value: missing "value" property`,
+		},
+		"unexpected property": {
+			`{"version":1,"type":"string","value":"hello","extra":true}`,
+			cty.NilVal,
+			`invalid raw dynamic value: json: unknown field "extra"`,
+		},
+		"extra content": {
+			`{"version":1,"type":"string","value":"hello"} {}`,
+			cty.NilVal,
+			`invalid raw dynamic value: unexpected extra content after JSON object`,
+		},
+		"value doesn't match type": {
+			`{"version":1,"type":"number","value":"hello"}`,
+			cty.NilVal,
+			`invalid raw dynamic value: a number is required`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := ParseRawDynamic([]byte(test.raw))
+			if test.wantErr != "" {
+				if err == nil {
+					t.Fatalf("unexpected success\ngot: %#v", got)
+				}
+				if got := err.Error(); got != test.wantErr {
+					t.Errorf("wrong error\ngot:  %s\nwant: %s", got, test.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(test.want, got, ctydebug.CmpOptions); diff != "" {
+				t.Errorf("wrong result\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestParseRawDynamicType(t *testing.T) {
+	tests := map[string]struct {
+		raw     string
+		want    cty.Type
+		wantErr string
+	}{
+		"heterogeneous tuple": {
+			`{"version":1,"type":["tuple",["number","string",["object",{"a":"bool"}]]],"value":[1,"a",{"a":true}]}`,
+			cty.Tuple([]cty.Type{cty.Number, cty.String, cty.Object(map[string]cty.Type{"a": cty.Bool})}),
+			``,
+		},
+		"typed null": {
+			`{"version":1,"type":["list","string"],"value":null}`,
+			cty.List(cty.String),
+			``,
+		},
+		"value not decoded": {
+			// We only decode the type, and so we can't notice that the value
+			// doesn't conform to it.
+			`{"version":1,"type":"number","value":"hello"}`,
+			cty.Number,
+			``,
+		},
+		"missing value": {
+			`{"version":1,"type":"string"}`,
+			cty.NilType,
+			`invalid raw dynamic value: missing "value" property`,
+		},
+		"invalid type": {
+			`{"version":1,"type":"nope","value":null}`,
+			cty.NilType,
+			`invalid raw dynamic value type: invalid primitive type name "nope"`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := ParseRawDynamicType([]byte(test.raw))
+			if test.wantErr != "" {
+				if err == nil {
+					t.Fatalf("unexpected success\ngot: %#v", got)
+				}
+				if got := err.Error(); got != test.wantErr {
+					t.Errorf("wrong error\ngot:  %s\nwant: %s", got, test.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !got.Equals(test.want) {
+				t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestRawDynamicTypedNullRoundTrip(t *testing.T) {
+	desc := testschema.File_testschema_proto.Messages().ByName("WithRawDynamicAttr")
+	val := cty.ObjectVal(map[string]cty.Value{
+		"raw": cty.NullVal(cty.List(cty.String)),
+	})
+
+	msg, diags := DecodeOptions{}.ValueDecoder(val, hcl.Range{}).Decode(desc)
+	if diags.HasErrors() {
+		t.Fatalf("unexpected errors: %s", diags.Error())
+	}
+	want := &testschema.WithRawDynamicAttr{
+		Raw: []byte(`{"version":1,"type":["list","string"],"value":null}`),
+	}
+	if diff := cmp.Diff(want, msg, protoCmpOpt); diff != "" {
+		t.Errorf("wrong message\n%s", diff)
+	}
+
+	got, err := ObjectValueForMessage(msg)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !got.RawEquals(val) {
+		t.Errorf("wrong value\ngot:  %#v\nwant: %#v", got, val)
+	}
+}
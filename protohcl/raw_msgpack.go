@@ -0,0 +1,236 @@
+package protohcl
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/zclconf/go-cty/cty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+	ctymsgpack "github.com/zclconf/go-cty/cty/msgpack"
+)
+
+// FormatRawMessagePack encodes the given value in the MessagePack format
+// that protohcl uses for raw-mode MessagePack fields with the given type
+// constraint.
+//
+// The encoding follows the usual MessagePack conventions for each type,
+// with the following additions so that the result can represent any HCL
+// value:
+//
+//   - A number is an integer if it is a whole number that fits in 64 bits,
+//     a 64-bit float if that can represent it exactly, and otherwise a
+//     string containing its decimal representation.
+//   - Lists, sets, and tuples are arrays. Maps and objects are maps with
+//     string keys.
+//   - An unknown value, of any type, is the fixext1 extension value with
+//     type code zero, whose data byte is irrelevant.
+//   - Wherever the type constraint contains "any", a non-null known value is
+//     a two-element array whose first element is a bin value containing the
+//     value's type in the JSON type encoding used by cty's "json" package,
+//     and whose second element is the value itself encoded for that type.
+//
+// RawMessagePackVectors returns some examples of this encoding, which
+// implementations in other languages can use to verify their behavior.
+func FormatRawMessagePack(val cty.Value, ty cty.Type) ([]byte, error) {
+	return ctymsgpack.Marshal(val, ty)
+}
+
+// ParseRawMessagePack is the inverse of FormatRawMessagePack, decoding a
+// value of the given type constraint from a MessagePack payload.
+func ParseRawMessagePack(raw []byte, ty cty.Type) (cty.Value, error) {
+	return ctymsgpack.Unmarshal(raw, ty)
+}
+
+// ParseRawMessagePackType returns the type of the value that the given
+// MessagePack payload encodes for the given type constraint, decoding as
+// little of the payload as possible.
+//
+// If the type constraint contains no "any" placeholders then it determines
+// the value's type by itself, and ParseRawMessagePackType returns it without
+// examining the payload at all. If the type constraint is exactly "any" then
+// the result is the type that the payload records along with the value,
+// which is exactly the value's type, without decoding the value itself. For
+// other type constraints that contain "any", ParseRawMessagePackType must
+// decode the whole value to find its type.
+//
+// A null or unknown value that is itself of type cty.DynamicPseudoType is
+// encoded for "any" without a recorded type, and so the result for it is
+// also cty.DynamicPseudoType. Null and unknown values of other types still
+// have their types recorded.
+func ParseRawMessagePackType(raw []byte, ty cty.Type) (cty.Type, error) {
+	switch {
+	case !ty.HasDynamicTypes():
+		return ty, nil
+	case ty == cty.DynamicPseudoType:
+		return parseRawMessagePackDynamicType(raw)
+	default:
+		val, err := ParseRawMessagePack(raw, ty)
+		if err != nil {
+			return cty.DynamicPseudoType, err
+		}
+		return val.Type(), nil
+	}
+}
+
+// parseRawMessagePackDynamicType decodes just the header of a value
+// encoded for the type constraint "any": a two-element array whose first
+// element is the value's type, in cty's JSON type encoding.
+func parseRawMessagePackDynamicType(raw []byte) (cty.Type, error) {
+	if len(raw) == 0 {
+		return cty.DynamicPseudoType, fmt.Errorf("invalid raw MessagePack value: empty payload")
+	}
+	switch c := raw[0]; {
+	case c == 0xc0: // nil
+		return cty.DynamicPseudoType, nil
+	case c >= 0xd4 && c <= 0xd8, c >= 0xc7 && c <= 0xc9: // ext, for unknown values
+		return cty.DynamicPseudoType, nil
+	}
+
+	length, raw, err := rawMessagePackArrayLen(raw)
+	if err != nil {
+		return cty.DynamicPseudoType, err
+	}
+	if length != 2 {
+		return cty.DynamicPseudoType, fmt.Errorf("invalid raw MessagePack value: dynamic value array must have exactly two elements")
+	}
+	typeJSON, err := rawMessagePackBytes(raw)
+	if err != nil {
+		return cty.DynamicPseudoType, err
+	}
+	ty, err := ctyjson.UnmarshalType(typeJSON)
+	if err != nil {
+		return cty.DynamicPseudoType, fmt.Errorf("invalid raw MessagePack value type: %w", err)
+	}
+	return ty, nil
+}
+
+// rawMessagePackArrayLen decodes the header of the MessagePack array at the
+// start of the given payload, returning the array length and the remainder
+// of the payload.
+func rawMessagePackArrayLen(raw []byte) (int, []byte, error) {
+	switch c := raw[0]; {
+	case c >= 0x90 && c <= 0x9f: // fixarray
+		return int(c & 0x0f), raw[1:], nil
+	case c == 0xdc: // array 16
+		return rawMessagePackUint(raw[1:], 2)
+	case c == 0xdd: // array 32
+		return rawMessagePackUint(raw[1:], 4)
+	default:
+		return 0, nil, fmt.Errorf("invalid raw MessagePack value: dynamic value must be an array")
+	}
+}
+
+// rawMessagePackBytes decodes the MessagePack bin or str value at the start
+// of the given payload. cty's own decoder accepts either for the type of a
+// dynamic value, so we do too.
+func rawMessagePackBytes(raw []byte) ([]byte, error) {
+	if len(raw) == 0 {
+		return nil, fmt.Errorf("invalid raw MessagePack value: truncated dynamic value")
+	}
+	var n int
+	var err error
+	switch c := raw[0]; {
+	case c >= 0xa0 && c <= 0xbf: // fixstr
+		n, raw = int(c&0x1f), raw[1:]
+	case c == 0xc4 || c == 0xd9: // bin 8, str 8
+		n, raw, err = rawMessagePackUint(raw[1:], 1)
+	case c == 0xc5 || c == 0xda: // bin 16, str 16
+		n, raw, err = rawMessagePackUint(raw[1:], 2)
+	case c == 0xc6 || c == 0xdb: // bin 32, str 32
+		n, raw, err = rawMessagePackUint(raw[1:], 4)
+	default:
+		return nil, fmt.Errorf("invalid raw MessagePack value: dynamic value type must be a bin value")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if n > len(raw) {
+		return nil, fmt.Errorf("invalid raw MessagePack value: truncated dynamic value type")
+	}
+	return raw[:n], nil
+}
+
+// rawMessagePackUint decodes a big-endian unsigned integer of the given
+// width in bytes from the start of the given payload, as used for lengths
+// in MessagePack headers, returning it and the remainder of the payload.
+func rawMessagePackUint(raw []byte, width int) (int, []byte, error) {
+	if len(raw) < width {
+		return 0, nil, fmt.Errorf("invalid raw MessagePack value: truncated header")
+	}
+	n := 0
+	for _, b := range raw[:width] {
+		n = n<<8 | int(b)
+	}
+	return n, raw[width:], nil
+}
+
+// RawMessagePackVector is an example of the raw MessagePack encoding, as
+// returned by RawMessagePackVectors.
+type RawMessagePackVector struct {
+	// Name is a short, unique identifier for this vector.
+	Name string
+
+	// Type is the type constraint used to encode the value.
+	Type cty.Type
+
+	// Value is the value that the vector encodes.
+	Value cty.Value
+
+	// Encoded is the result of encoding Value with type constraint Type.
+	Encoded []byte
+}
+
+// RawMessagePackVectors returns a set of examples of the raw MessagePack
+// encoding described for FormatRawMessagePack, which together cover all of
+// the situations that an implementation must handle.
+//
+// The same examples are also available in the file
+// testdata/raw_msgpack_vectors.json in this package's directory, for use by
+// implementations in other languages. The encoding of these examples will
+// not change in future versions of protohcl.
+func RawMessagePackVectors() []RawMessagePackVector {
+	return []RawMessagePackVector{
+		{"string", cty.String, cty.StringVal("hello"), mustDecodeHex("a568656c6c6f")},
+		{"integer", cty.Number, cty.NumberIntVal(1), mustDecodeHex("01")},
+		{"negative integer", cty.Number, cty.NumberIntVal(-1), mustDecodeHex("ff")},
+		{"float", cty.Number, cty.NumberFloatVal(1.5), mustDecodeHex("cb3ff8000000000000")},
+		{"decimal string", cty.Number, cty.MustParseNumberVal("0.1"), mustDecodeHex("a3302e31")},
+		{"infinity", cty.Number, cty.PositiveInfinity, mustDecodeHex("cb7ff0000000000000")},
+		{"bool", cty.Bool, cty.True, mustDecodeHex("c3")},
+		{"null", cty.String, cty.NullVal(cty.String), mustDecodeHex("c0")},
+		{"unknown", cty.String, cty.UnknownVal(cty.String), mustDecodeHex("d40000")},
+		{"list", cty.List(cty.String), cty.ListVal([]cty.Value{cty.StringVal("a"), cty.StringVal("b")}), mustDecodeHex("92a161a162")},
+		{"set", cty.Set(cty.Number), cty.SetVal([]cty.Value{cty.NumberIntVal(1), cty.NumberIntVal(2)}), mustDecodeHex("920102")},
+		{"map", cty.Map(cty.String), cty.MapVal(map[string]cty.Value{"a": cty.StringVal("b")}), mustDecodeHex("81a161a162")},
+		{
+			"object",
+			cty.Object(map[string]cty.Type{"name": cty.String, "count": cty.Number}),
+			cty.ObjectVal(map[string]cty.Value{"name": cty.StringVal("a"), "count": cty.NumberIntVal(2)}),
+			mustDecodeHex("82a5636f756e7402a46e616d65a161"),
+		},
+		{
+			"tuple",
+			cty.Tuple([]cty.Type{cty.String, cty.Number}),
+			cty.TupleVal([]cty.Value{cty.StringVal("a"), cty.NumberIntVal(2)}),
+			mustDecodeHex("92a16102"),
+		},
+		{"dynamic string", cty.DynamicPseudoType, cty.StringVal("hello"), mustDecodeHex("92c40822737472696e6722a568656c6c6f")},
+		{"dynamic list", cty.DynamicPseudoType, cty.ListVal([]cty.Value{cty.True}), mustDecodeHex("92c40f5b226c697374222c22626f6f6c225d91c3")},
+		{"dynamic null", cty.DynamicPseudoType, cty.NullVal(cty.DynamicPseudoType), mustDecodeHex("c0")},
+		{"dynamic unknown", cty.DynamicPseudoType, cty.DynamicVal, mustDecodeHex("d40000")},
+		{
+			"object with dynamic attribute",
+			cty.Object(map[string]cty.Type{"extra": cty.DynamicPseudoType}),
+			cty.ObjectVal(map[string]cty.Value{"extra": cty.NumberIntVal(1)}),
+			mustDecodeHex("81a5657874726192c408226e756d6265722201"),
+		},
+	}
+}
+
+func mustDecodeHex(s string) []byte {
+	ret, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return ret
+}
@@ -0,0 +1,129 @@
+package protohcl
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl/v2/ext/typeexpr"
+	"github.com/zclconf/go-cty-debug/ctydebug"
+	"github.com/zclconf/go-cty/cty"
+)
+
+var updateVectors = flag.Bool("update-vectors", false, "rewrite testdata/raw_msgpack_vectors.json from RawMessagePackVectors")
+
+func TestRawMessagePackVectors(t *testing.T) {
+	for _, vector := range RawMessagePackVectors() {
+		t.Run(vector.Name, func(t *testing.T) {
+			got, err := FormatRawMessagePack(vector.Value, vector.Type)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !bytes.Equal(got, vector.Encoded) {
+				t.Errorf("wrong encoding\ngot:  %x\nwant: %x", got, vector.Encoded)
+			}
+
+			back, err := ParseRawMessagePack(vector.Encoded, vector.Type)
+			if err != nil {
+				t.Fatalf("unexpected error decoding: %s", err)
+			}
+			if diff := cmp.Diff(vector.Value, back, ctydebug.CmpOptions); diff != "" {
+				t.Errorf("wrong decoded value\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestParseRawMessagePackType(t *testing.T) {
+	for _, vector := range RawMessagePackVectors() {
+		t.Run(vector.Name, func(t *testing.T) {
+			got, err := ParseRawMessagePackType(vector.Encoded, vector.Type)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if want := vector.Value.Type(); !got.Equals(want) {
+				t.Errorf("wrong type\ngot:  %#v\nwant: %#v", got, want)
+			}
+		})
+	}
+
+	// cty's encoder always uses the most compact headers, but other
+	// implementations might not.
+	t.Run("wide headers", func(t *testing.T) {
+		raw := append([]byte{0xdc, 0x00, 0x02, 0xd9, 0x08}, `"string"`...)
+		raw = append(raw, 0xa1, 'a')
+		got, err := ParseRawMessagePackType(raw, cty.DynamicPseudoType)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if got != cty.String {
+			t.Errorf("wrong type\ngot:  %#v\nwant: %#v", got, cty.String)
+		}
+	})
+	t.Run("truncated", func(t *testing.T) {
+		raw := append([]byte{0x92, 0xc4, 0x20}, `"string"`...)
+		_, err := ParseRawMessagePackType(raw, cty.DynamicPseudoType)
+		if err == nil {
+			t.Fatalf("unexpected success")
+		}
+		if got, want := err.Error(), `invalid raw MessagePack value: truncated dynamic value type`; got != want {
+			t.Errorf("wrong error\ngot:  %s\nwant: %s", got, want)
+		}
+	})
+}
+
+// TestRawMessagePackVectorsFile checks that the published copy of the
+// vectors matches RawMessagePackVectors. Run the test with -update-vectors
+// to rewrite the file after adding a new vector.
+func TestRawMessagePackVectorsFile(t *testing.T) {
+	type fileVector struct {
+		Name    string          `json:"name"`
+		Type    string          `json:"type"`
+		Value   json.RawMessage `json:"value,omitempty"`
+		Unknown bool            `json:"unknown,omitempty"`
+		Encoded string          `json:"msgpack"`
+	}
+
+	var vectors []fileVector
+	for _, vector := range RawMessagePackVectors() {
+		fv := fileVector{
+			Name:    vector.Name,
+			Type:    typeexpr.TypeString(vector.Type),
+			Encoded: hex.EncodeToString(vector.Encoded),
+		}
+		// The value uses the same JSON framing as raw JSON fields of type
+		// "any", so that it's self-describing. JSON can't represent unknown
+		// values or infinities, so the value is omitted for those and
+		// readers must rely on the vector's name instead.
+		if !vector.Value.IsWhollyKnown() {
+			fv.Unknown = true
+		} else if raw, err := FormatRawDynamic(vector.Value); err == nil {
+			fv.Value = raw
+		}
+		vectors = append(vectors, fv)
+	}
+	want, err := json.MarshalIndent(vectors, "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = append(want, '\n')
+
+	filename := filepath.Join("testdata", "raw_msgpack_vectors.json")
+	if *updateVectors {
+		if err := ioutil.WriteFile(filename, want, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	got, err := ioutil.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(string(want), string(got)); diff != "" {
+		t.Errorf("%s is out of date; run this test with -update-vectors\n%s", filename, diff)
+	}
+}
@@ -0,0 +1,72 @@
+package protohcl
+
+import (
+	"fmt"
+
+	"github.com/apparentlymart/go-protohcl/protohcl/protohclext"
+	"github.com/zclconf/go-cty/cty"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// RawFieldType returns the type of the value stored in the raw-mode
+// attribute field of the given message that has the given name, as recorded
+// in the field's raw encoding.
+//
+// This allows a caller to branch on the type of a value whose type
+// constraint includes "any", such as to check whether it's a list or a
+// single object, without decoding the whole value. For a type constraint
+// that doesn't include "any" at all the result is always the type
+// constraint itself.
+//
+// An unset field represents a null value of the attribute's type
+// constraint, and so the result for it is the type constraint.
+//
+// RawFieldType returns an error if the message has no field of the given
+// name, if that field isn't a raw-mode attribute, or if the field's content
+// isn't a valid raw encoding for the attribute's type constraint.
+func RawFieldType(msg proto.Message, fieldName protoreflect.Name) (cty.Type, error) {
+	m := msg.ProtoReflect()
+	desc := m.Descriptor()
+	field := desc.Fields().ByName(fieldName)
+	if field == nil {
+		return cty.DynamicPseudoType, fmt.Errorf("message type %s has no field named %q", desc.FullName(), fieldName)
+	}
+
+	elem, err := GetFieldElem(field)
+	if err != nil {
+		return cty.DynamicPseudoType, err
+	}
+	attr, ok := elem.(FieldAttribute)
+	if !ok || attr.RawMode == protohclext.Attribute_NOT_RAW {
+		return cty.DynamicPseudoType, fmt.Errorf("field %s is not a raw-mode attribute", field.FullName())
+	}
+	ty, diags := attr.TypeConstraint()
+	if diags.HasErrors() {
+		return cty.DynamicPseudoType, schemaErrorf(field.FullName(), "invalid type constraint expression")
+	}
+
+	raw := m.Get(field).Bytes()
+	if len(raw) == 0 {
+		return ty, nil
+	}
+
+	codec, err := rawCodecFor(attr)
+	if err != nil {
+		return cty.DynamicPseudoType, err
+	}
+	var retTy cty.Type
+	if codec, ok := codec.(RawTypeCodec); ok {
+		retTy, err = codec.UnmarshalType(raw, ty)
+	} else {
+		var v cty.Value
+		v, err = codec.Unmarshal(raw, ty)
+		if err == nil {
+			retTy = v.Type()
+		}
+	}
+	if err != nil {
+		return cty.DynamicPseudoType, fmt.Errorf("invalid encoding of %s value as bytes in %s: %s", ty.FriendlyName(), field.FullName(), err)
+	}
+	return retTy, nil
+}
@@ -0,0 +1,108 @@
+package protohcl
+
+import (
+	"testing"
+
+	"github.com/apparentlymart/go-protohcl/protohcl/internal/testschema"
+	"github.com/apparentlymart/go-protohcl/protohcl/schemabuilder"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func TestRawFieldType(t *testing.T) {
+	f := schemabuilder.NewFile("raw_type_test.proto", "protohcl.rawtype")
+	f.AddMessage("Config").
+		AddAttribute("json", cty.DynamicPseudoType, schemabuilder.RawJSON).
+		AddAttribute("json_list", cty.List(cty.DynamicPseudoType), schemabuilder.RawJSON).
+		AddAttribute("json_string", cty.String, schemabuilder.RawJSON).
+		AddAttribute("msgpack", cty.DynamicPseudoType, schemabuilder.RawMessagePack).
+		AddAttribute("msgpack_list", cty.List(cty.DynamicPseudoType), schemabuilder.RawMessagePack).
+		AddAttribute("unset", cty.DynamicPseudoType, schemabuilder.RawMessagePack)
+	fileDesc, err := f.Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	desc := fileDesc.Messages().ByName("Config")
+
+	src := `
+		json         = [1, "a", { x = true }]
+		json_list    = [{ x = true }, { x = false }]
+		json_string  = "hello"
+		msgpack      = { x = [true] }
+		msgpack_list = [1, 2]
+	`
+	hclFile, diags := hclsyntax.ParseConfig([]byte(src), "", hcl.InitialPos)
+	if diags.HasErrors() {
+		t.Fatalf("unexpected syntax errors: %s", diags.Error())
+	}
+	msg, diags := DecodeBody(hclFile.Body, desc, nil)
+	if diags.HasErrors() {
+		t.Fatalf("unexpected errors: %s", diags.Error())
+	}
+
+	tests := map[protoreflect.Name]cty.Type{
+		"json": cty.Tuple([]cty.Type{
+			cty.Number,
+			cty.String,
+			cty.Object(map[string]cty.Type{"x": cty.Bool}),
+		}),
+		"json_list":    cty.List(cty.Object(map[string]cty.Type{"x": cty.Bool})),
+		"json_string":  cty.String,
+		"msgpack":      cty.Object(map[string]cty.Type{"x": cty.Tuple([]cty.Type{cty.Bool})}),
+		"msgpack_list": cty.List(cty.Number),
+		"unset":        cty.DynamicPseudoType,
+	}
+	for name, want := range tests {
+		t.Run(string(name), func(t *testing.T) {
+			got, err := RawFieldType(msg, name)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !got.Equals(want) {
+				t.Errorf("wrong type\ngot:  %#v\nwant: %#v", got, want)
+			}
+		})
+	}
+}
+
+func TestRawFieldTypeErrors(t *testing.T) {
+	tests := map[string]struct {
+		msg       *testschema.WithRawDynamicAttr
+		fieldName protoreflect.Name
+		wantErr   string
+	}{
+		"no such field": {
+			&testschema.WithRawDynamicAttr{},
+			"nope",
+			`message type hcl.testschema.WithRawDynamicAttr has no field named "nope"`,
+		},
+		"invalid encoding": {
+			&testschema.WithRawDynamicAttr{Raw: []byte(`{"version":1}`)},
+			"raw",
+			`invalid encoding of dynamic value as bytes in hcl.testschema.WithRawDynamicAttr.raw: invalid raw dynamic value: missing "type" property`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := RawFieldType(test.msg, test.fieldName)
+			if err == nil {
+				t.Fatalf("unexpected success")
+			}
+			if got := err.Error(); got != test.wantErr {
+				t.Errorf("wrong error\ngot:  %s\nwant: %s", got, test.wantErr)
+			}
+		})
+	}
+
+	// A field that isn't in raw mode has no encoding to inspect.
+	_, err := RawFieldType(&testschema.WithStringAttr{Name: "Jackson"}, "name")
+	if err == nil {
+		t.Fatalf("unexpected success for non-raw field")
+	}
+	if got, want := err.Error(), `field hcl.testschema.WithStringAttr.name is not a raw-mode attribute`; got != want {
+		t.Errorf("wrong error\ngot:  %s\nwant: %s", got, want)
+	}
+}
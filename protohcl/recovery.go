@@ -0,0 +1,33 @@
+package protohcl
+
+// RecoveryMode is the type of DecodeOptions.Recovery, which decides what
+// the decoder does with the attributes of a body whose content HCL already
+// reported errors about, such as an unsupported argument or a block with
+// the wrong number of labels.
+type RecoveryMode int
+
+const (
+	// RecoverDecodeAll is the default RecoveryMode, in which the decoder
+	// still evaluates and decodes all of the attributes that it can find,
+	// so that the result is as complete as possible and the diagnostics
+	// cover every problem in the body.
+	RecoverDecodeAll RecoveryMode = 0
+
+	// RecoverSkipEvaluation is a RecoveryMode in which the decoder doesn't
+	// evaluate any of the attribute expressions in a body whose content
+	// has errors, leaving the corresponding fields unset.
+	//
+	// A single mistake, such as a misspelled attribute name, often causes
+	// many further errors when evaluating the other attributes in the same
+	// body, and so this mode reports only the original problem. Each
+	// nested block body is considered separately, so errors in one block
+	// don't prevent decoding the attributes of its parent or of any other
+	// block.
+	RecoverSkipEvaluation RecoveryMode = 1
+)
+
+// skipEvaluation returns true if the decoder should not evaluate attribute
+// expressions in a body whose content had errors, if recovering is true.
+func (d *decoder) skipEvaluation(recovering bool) bool {
+	return recovering && d.opts.Recovery == RecoverSkipEvaluation
+}
@@ -0,0 +1,85 @@
+package protohcl
+
+import (
+	"testing"
+
+	"github.com/apparentlymart/go-protohcl/protohcl/internal/testschema"
+	"github.com/apparentlymart/go-protohcl/protohcl/protohcltest"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestDecodeOptionsRecovery(t *testing.T) {
+	desc := testschema.File_testschema_proto.Messages().ByName("Root")
+
+	tests := map[string]struct {
+		src       string
+		mode      RecoveryMode
+		want      proto.Message
+		wantDiags []protohcltest.ExpectedDiagnostic
+	}{
+		"decode all": {
+			`
+				name  = nonexistent
+				count = "nope"
+				bogus = 1
+				thing "a" {}
+			`,
+			RecoverDecodeAll,
+			&testschema.Root{
+				Things: []*testschema.Thing{{Name: "a"}},
+				More:   &testschema.MoreRoot{},
+			},
+			[]protohcltest.ExpectedDiagnostic{
+				protohcltest.Error("Variables not allowed").OnLine(2),
+				protohcltest.Error(unsuitableValueSummary).OnLine(3),
+				protohcltest.Error("Unsupported argument").OnLine(4),
+			},
+		},
+		"skip evaluation": {
+			`
+				name  = nonexistent
+				count = "nope"
+				bogus = 1
+				thing "a" {}
+			`,
+			RecoverSkipEvaluation,
+			&testschema.Root{
+				Things: []*testschema.Thing{{Name: "a"}},
+				More:   &testschema.MoreRoot{},
+			},
+			[]protohcltest.ExpectedDiagnostic{
+				protohcltest.Error("Unsupported argument").OnLine(4),
+			},
+		},
+		"skip evaluation only in the nested block with errors": {
+			`
+				name  = "Jackson"
+				count = 2
+				thing "a" {
+					bogus = nonexistent
+				}
+			`,
+			RecoverSkipEvaluation,
+			&testschema.Root{
+				Name:   "Jackson",
+				Things: []*testschema.Thing{{Name: "a"}},
+				More:   &testschema.MoreRoot{Count: 2},
+			},
+			[]protohcltest.ExpectedDiagnostic{
+				protohcltest.Error("Unsupported argument").OnLine(5),
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			body := parseTestBody(t, test.src)
+			got, diags := DecodeOptions{Recovery: test.mode}.DecodeBody(body, desc, nil)
+			protohcltest.AssertDiagnostics(t, diags, test.wantDiags...)
+			if diff := cmp.Diff(test.want, got, protoCmpOpt); diff != "" {
+				t.Errorf("wrong result\n%s", diff)
+			}
+		})
+	}
+}
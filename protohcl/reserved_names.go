@@ -0,0 +1,67 @@
+package protohcl
+
+import (
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// CheckReservedNames returns an error if the body described by the given
+// message descriptor, including any flattened messages, declares an
+// attribute or nested block type using any of the given names.
+//
+// This is for hosts that decode bodies whose schema comes from some other
+// component, such as a plugin, but that also interpret some arguments or
+// blocks in those bodies themselves. For example, a host might reserve
+// "count" and "for_each" for its own repetition features. Hosts can call
+// this once after loading a schema to reject it early, or can set
+// DecodeOptions.ReservedNames to have the decoder check it automatically.
+//
+// Any error is a problem with the schema, rather than with any particular
+// configuration. CheckReservedNames doesn't check the names of nested blocks'
+// own contents, because those don't share a namespace with the host's
+// reserved names.
+func CheckReservedNames(desc protoreflect.MessageDescriptor, names []string) error {
+	if len(names) == 0 {
+		return nil
+	}
+	reserved := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		reserved[name] = struct{}{}
+	}
+	return checkReservedNames(desc, "", reserved)
+}
+
+func checkReservedNames(desc protoreflect.MessageDescriptor, prefix string, reserved map[string]struct{}) error {
+	fields := desc.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		elem, err := GetFieldElem(field)
+		if err != nil {
+			return err // should already be a schemaError
+		}
+
+		switch elem := withNamePrefix(elem, prefix).(type) {
+		case FieldAttribute:
+			if _, exists := reserved[elem.Name]; exists {
+				return schemaErrorf(field.FullName(), "attribute name %q is reserved by the host application", elem.Name)
+			}
+		case FieldNestedBlockType:
+			if _, exists := reserved[elem.TypeName]; exists {
+				return schemaErrorf(field.FullName(), "block type name %q is reserved by the host application", elem.TypeName)
+			}
+			for _, alias := range elem.Aliases {
+				if _, exists := reserved[alias]; exists {
+					return schemaErrorf(field.FullName(), "block type alias %q is reserved by the host application", alias)
+				}
+			}
+		case FieldFlattened:
+			err := checkReservedNames(elem.Nested, elem.Prefix, reserved)
+			if err != nil {
+				return err
+			}
+		default:
+			// Block labels don't appear in the body, and other fields are
+			// not relevant to HCL at all.
+		}
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package protohcl
+
+import (
+	"testing"
+
+	"github.com/apparentlymart/go-protohcl/protohcl/internal/testschema"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func TestCheckReservedNames(t *testing.T) {
+	fileDesc := testschema.File_testschema_proto
+	reserved := []string{"count", "for_each", "name", "doodad"}
+
+	tests := map[protoreflect.Name]string{
+		"WithNumberAttrAsInt32":            ``,
+		"WithStringAttr":                   `unsupported protobuf schema in hcl.testschema.WithStringAttr.name: attribute name "name" is reserved by the host application`,
+		"WithFlattenStringAttr":            `unsupported protobuf schema in hcl.testschema.WithStringAttr.name: attribute name "name" is reserved by the host application`,
+		"WithNestedBlockNoLabelsSingleton": `unsupported protobuf schema in hcl.testschema.WithNestedBlockNoLabelsSingleton.doodad: block type name "doodad" is reserved by the host application`,
+		// Block labels don't appear in the body, so they can't conflict
+		// with the host's own arguments or blocks.
+		"WithOneBlockLabel": ``,
+	}
+
+	for name, want := range tests {
+		t.Run(string(name), func(t *testing.T) {
+			desc := fileDesc.Messages().ByName(name)
+			if desc == nil {
+				t.Fatalf("no message type named %s", name)
+			}
+
+			err := CheckReservedNames(desc, reserved)
+			if want == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("unexpected success\nwant error: %s", want)
+			}
+			if got := err.Error(); got != want {
+				t.Errorf("wrong error\ngot:  %s\nwant: %s", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,452 @@
+// Package schemabuilder allows constructing protobuf message descriptors with
+// HCL annotations directly from Go code, rather than by compiling a .proto
+// file.
+//
+// This is intended for host applications that want to define configuration
+// schemas dynamically at runtime but still make use of all of the same
+// decoding machinery in package protohcl, and potentially also send the
+// resulting descriptors to other processes as a FileDescriptorSet.
+//
+// The builder chooses a protobuf field type automatically for each
+// attribute based on its HCL type constraint, preferring a direct mapping
+// to a protobuf scalar type where possible and falling back to a raw-mode
+// MessagePack field for any type that has no direct protobuf analog.
+package schemabuilder
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/apparentlymart/go-protohcl/protohcl/protohclext"
+	"github.com/hashicorp/hcl/v2/ext/typeexpr"
+	"github.com/zclconf/go-cty/cty"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// File is a builder for a single synthetic protobuf source file, which can
+// contain one or more messages.
+//
+// All of the messages that refer to one another, such as for nested blocks,
+// must be declared in the same File.
+type File struct {
+	path     string
+	pkg      protoreflect.FullName
+	messages []*Message
+}
+
+// NewFile starts building a new synthetic protobuf file with the given path
+// and package name.
+//
+// The path is used only as the file's identity in a descriptor registry, and
+// so it should be unique among all of the files that will be loaded together.
+func NewFile(path string, pkg protoreflect.FullName) *File {
+	return &File{
+		path: path,
+		pkg:  pkg,
+	}
+}
+
+// AddMessage declares a new message with the given name in the file,
+// returning a builder for the message's fields.
+func (f *File) AddMessage(name protoreflect.Name) *Message {
+	m := &Message{
+		file: f,
+		name: name,
+	}
+	f.messages = append(f.messages, m)
+	return m
+}
+
+// FileDescriptorProto returns the descriptor for the file in its serializable
+// form, which is suitable for inclusion in a FileDescriptorSet to send to
+// another process.
+//
+// The result depends on the file "hcl.proto" which declares the HCL option
+// extensions, so any FileDescriptorSet containing this result must also
+// include that file.
+func (f *File) FileDescriptorProto() (*descriptorpb.FileDescriptorProto, error) {
+	ret := &descriptorpb.FileDescriptorProto{
+		Name:       proto.String(f.path),
+		Package:    proto.String(string(f.pkg)),
+		Syntax:     proto.String("proto3"),
+		Dependency: []string{protohclext.File_hcl_proto.Path()},
+	}
+
+	msgNames := make(map[protoreflect.Name]struct{}, len(f.messages))
+	for _, m := range f.messages {
+		if _, exists := msgNames[m.name]; exists {
+			return nil, fmt.Errorf("duplicate declaration of message %s", m.name)
+		}
+		msgNames[m.name] = struct{}{}
+
+		mDesc, err := m.descriptorProto()
+		if err != nil {
+			return nil, fmt.Errorf("invalid message %s: %w", m.name, err)
+		}
+		ret.MessageType = append(ret.MessageType, mDesc)
+	}
+
+	return ret, nil
+}
+
+// Build finalizes the file and returns its descriptor, from which you can
+// find message descriptors to pass to protohcl.DecodeBody.
+func (f *File) Build() (protoreflect.FileDescriptor, error) {
+	fileDesc, err := f.FileDescriptorProto()
+	if err != nil {
+		return nil, err
+	}
+	return protodesc.NewFile(fileDesc, protoregistry.GlobalFiles)
+}
+
+// Message is a builder for a single message within a File.
+type Message struct {
+	file   *File
+	name   protoreflect.Name
+	fields []*fieldBuilder
+}
+
+type fieldBuilder struct {
+	name     protoreflect.Name
+	build    func(desc *descriptorpb.FieldDescriptorProto, parent *descriptorpb.DescriptorProto) error
+	repeated bool
+}
+
+// FullName returns the fully-qualified name the message will have once built.
+func (m *Message) FullName() protoreflect.FullName {
+	return m.file.pkg.Append(m.name)
+}
+
+// AddAttribute declares a field representing an HCL attribute with the given
+// name and type constraint.
+//
+// The protobuf field type is selected automatically based on the type
+// constraint. Primitive types, and lists, sets, and maps of primitive types,
+// map to corresponding protobuf scalar fields. Any other type, including
+// object types, tuple types, and constraints involving "any", is stored as
+// a raw MessagePack-encoded bytes field.
+func (m *Message) AddAttribute(name string, ty cty.Type, opts ...AttributeOption) *Message {
+	attr := &protohclext.Attribute{
+		Name: name,
+		Type: typeexpr.TypeString(ty),
+	}
+	for _, opt := range opts {
+		opt(attr)
+	}
+
+	m.addField(name, false, func(desc *descriptorpb.FieldDescriptorProto, parent *descriptorpb.DescriptorProto) error {
+		err := fieldTypeForAttribute(desc, parent, m.FullName(), attr, ty)
+		if err != nil {
+			return err
+		}
+		desc.Options = &descriptorpb.FieldOptions{}
+		proto.SetExtension(desc.Options, protohclext.E_Attr, attr)
+		return nil
+	})
+	return m
+}
+
+// AddBlock declares a field representing a nested HCL block type with the
+// given type name, whose content conforms to the given nested message.
+//
+// The nested message must belong to the same File as the reciever.
+func (m *Message) AddBlock(typeName string, nested *Message, opts ...BlockOption) *Message {
+	block := &protohclext.NestedBlock{
+		TypeName: typeName,
+	}
+	var cfg blockConfig
+	for _, opt := range opts {
+		opt(block, &cfg)
+	}
+
+	m.addField(typeName, cfg.repeated, func(desc *descriptorpb.FieldDescriptorProto, parent *descriptorpb.DescriptorProto) error {
+		if nested.file != m.file {
+			return fmt.Errorf("block type %q refers to message %s from a different file", typeName, nested.FullName())
+		}
+		desc.Type = descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum()
+		desc.TypeName = proto.String("." + string(nested.FullName()))
+		desc.Options = &descriptorpb.FieldOptions{}
+		proto.SetExtension(desc.Options, protohclext.E_Block, block)
+		return nil
+	})
+	return m
+}
+
+// AddLabel declares a field representing a label in the block header of any
+// block type whose content conforms to this message.
+//
+// Labels are assigned from the block header in the same order as the
+// AddLabel calls.
+func (m *Message) AddLabel(name string) *Message {
+	m.addField(name, false, func(desc *descriptorpb.FieldDescriptorProto, parent *descriptorpb.DescriptorProto) error {
+		desc.Type = descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum()
+		desc.Options = &descriptorpb.FieldOptions{}
+		proto.SetExtension(desc.Options, protohclext.E_Label, &protohclext.BlockLabel{
+			Name: name,
+		})
+		return nil
+	})
+	return m
+}
+
+// AddFlatten declares a field whose nested message's attributes and block
+// types will be merged into the body of this message, as if they were
+// declared directly.
+//
+// The given name is used only for the protobuf field, and is not visible in
+// the HCL schema at all.
+func (m *Message) AddFlatten(name string, nested *Message) *Message {
+	m.addField(name, false, func(desc *descriptorpb.FieldDescriptorProto, parent *descriptorpb.DescriptorProto) error {
+		if nested.file != m.file {
+			return fmt.Errorf("flattened field %q refers to message %s from a different file", name, nested.FullName())
+		}
+		desc.Type = descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum()
+		desc.TypeName = proto.String("." + string(nested.FullName()))
+		desc.Options = &descriptorpb.FieldOptions{}
+		proto.SetExtension(desc.Options, protohclext.E_Flatten, true)
+		return nil
+	})
+	return m
+}
+
+// AddFlattenPrefix is like AddFlatten, but adds the given prefix to the
+// names of all of the attributes, block types, and block labels that the
+// nested message contributes, so that the same message can be flattened
+// into a body more than once.
+func (m *Message) AddFlattenPrefix(name string, nested *Message, prefix string) *Message {
+	m.addField(name, false, func(desc *descriptorpb.FieldDescriptorProto, parent *descriptorpb.DescriptorProto) error {
+		if nested.file != m.file {
+			return fmt.Errorf("flattened field %q refers to message %s from a different file", name, nested.FullName())
+		}
+		desc.Type = descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum()
+		desc.TypeName = proto.String("." + string(nested.FullName()))
+		desc.Options = &descriptorpb.FieldOptions{}
+		proto.SetExtension(desc.Options, protohclext.E_FlattenPrefix, prefix)
+		return nil
+	})
+	return m
+}
+
+func (m *Message) addField(name string, repeated bool, build func(desc *descriptorpb.FieldDescriptorProto, parent *descriptorpb.DescriptorProto) error) {
+	m.fields = append(m.fields, &fieldBuilder{
+		name:     fieldNameForHCLName(name),
+		build:    build,
+		repeated: repeated,
+	})
+}
+
+func (m *Message) descriptorProto() (*descriptorpb.DescriptorProto, error) {
+	ret := &descriptorpb.DescriptorProto{
+		Name: proto.String(string(m.name)),
+	}
+
+	fieldNames := make(map[protoreflect.Name]struct{}, len(m.fields))
+	for i, fb := range m.fields {
+		if _, exists := fieldNames[fb.name]; exists {
+			return nil, fmt.Errorf("duplicate declaration of field %s", fb.name)
+		}
+		fieldNames[fb.name] = struct{}{}
+
+		desc := &descriptorpb.FieldDescriptorProto{
+			Name:     proto.String(string(fb.name)),
+			JsonName: proto.String(protodescJSONName(fb.name)),
+			Number:   proto.Int32(int32(i + 1)),
+			Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+		}
+		if fb.repeated {
+			desc.Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+		}
+		err := fb.build(desc, ret)
+		if err != nil {
+			return nil, err
+		}
+		ret.Field = append(ret.Field, desc)
+	}
+
+	return ret, nil
+}
+
+// AttributeOption is the type of the optional arguments to
+// Message.AddAttribute.
+type AttributeOption func(attr *protohclext.Attribute)
+
+// Required is an AttributeOption which marks an attribute as required.
+func Required(attr *protohclext.Attribute) {
+	attr.Required = true
+}
+
+// RawJSON is an AttributeOption which forces an attribute to be stored in
+// raw mode using the JSON encoding, regardless of its type constraint.
+//
+// JSON encoding cannot represent unknown values, so prefer the default
+// MessagePack encoding unless the recipient specifically needs JSON.
+func RawJSON(attr *protohclext.Attribute) {
+	attr.Raw = protohclext.Attribute_JSON
+}
+
+// RawMessagePack is an AttributeOption which forces an attribute to be
+// stored in raw mode using the MessagePack encoding, even if its type
+// constraint would otherwise allow a direct scalar mapping.
+func RawMessagePack(attr *protohclext.Attribute) {
+	attr.Raw = protohclext.Attribute_MESSAGEPACK
+}
+
+// RawYAML is an AttributeOption which forces an attribute to be stored in
+// raw mode using the YAML encoding, regardless of its type constraint.
+//
+// Like JSON, the YAML encoding cannot represent unknown values.
+func RawYAML(attr *protohclext.Attribute) {
+	attr.Raw = protohclext.Attribute_YAML
+}
+
+// BlockOption is the type of the optional arguments to Message.AddBlock.
+type BlockOption func(block *protohclext.NestedBlock, cfg *blockConfig)
+
+type blockConfig struct {
+	repeated bool
+}
+
+// Repeated is a BlockOption which allows any number of blocks of the
+// given type, rather than at most one.
+func Repeated(block *protohclext.NestedBlock, cfg *blockConfig) {
+	cfg.repeated = true
+}
+
+// CollectionKind returns a BlockOption which allows any number of blocks of
+// the given type, and selects which kind of collection will represent them
+// when converting a message to an object value.
+func CollectionKind(kind protohclext.NestedBlock_CollectionKind) BlockOption {
+	return func(block *protohclext.NestedBlock, cfg *blockConfig) {
+		cfg.repeated = true
+		block.Kind = kind
+	}
+}
+
+// BlockAliases returns a BlockOption which makes the block type also accept
+// blocks using any of the given type names, with a deprecation warning.
+func BlockAliases(names ...string) BlockOption {
+	return func(block *protohclext.NestedBlock, cfg *blockConfig) {
+		block.Aliases = append(block.Aliases, names...)
+	}
+}
+
+// fieldTypeForAttribute populates the type-related parts of the given field
+// descriptor to suit the given attribute type constraint. If the field needs
+// a synthetic map entry message then it's added to the given parent.
+func fieldTypeForAttribute(desc *descriptorpb.FieldDescriptorProto, parent *descriptorpb.DescriptorProto, parentName protoreflect.FullName, attr *protohclext.Attribute, ty cty.Type) error {
+	if attr.Raw == protohclext.Attribute_NOT_RAW {
+		switch {
+		case ty.IsPrimitiveType():
+			desc.Type = scalarFieldType(ty).Enum()
+			return nil
+		case (ty.IsListType() || ty.IsSetType()) && ty.ElementType().IsPrimitiveType():
+			desc.Type = scalarFieldType(ty.ElementType()).Enum()
+			desc.Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+			return nil
+		case ty.IsMapType() && ty.ElementType().IsPrimitiveType():
+			entryName := mapEntryName(protoreflect.Name(desc.GetName()))
+			parent.NestedType = append(parent.NestedType, &descriptorpb.DescriptorProto{
+				Name: proto.String(string(entryName)),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					{
+						Name:     proto.String("key"),
+						JsonName: proto.String("key"),
+						Number:   proto.Int32(1),
+						Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+						Type:     descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+					},
+					{
+						Name:     proto.String("value"),
+						JsonName: proto.String("value"),
+						Number:   proto.Int32(2),
+						Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+						Type:     scalarFieldType(ty.ElementType()).Enum(),
+					},
+				},
+				Options: &descriptorpb.MessageOptions{
+					MapEntry: proto.Bool(true),
+				},
+			})
+			desc.Type = descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum()
+			desc.TypeName = proto.String("." + string(parentName.Append(entryName)))
+			desc.Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+			return nil
+		}
+
+		// Everything else must use raw mode, and MessagePack is the most
+		// expressive encoding.
+		attr.Raw = protohclext.Attribute_MESSAGEPACK
+	}
+
+	desc.Type = descriptorpb.FieldDescriptorProto_TYPE_BYTES.Enum()
+	return nil
+}
+
+func scalarFieldType(ty cty.Type) descriptorpb.FieldDescriptorProto_Type {
+	switch ty {
+	case cty.String:
+		return descriptorpb.FieldDescriptorProto_TYPE_STRING
+	case cty.Number:
+		// We use a string to retain the full precision of the given number,
+		// since the HCL type system doesn't distinguish integers from
+		// fractional numbers. The recipient can parse it into whatever
+		// numeric type is appropriate.
+		return descriptorpb.FieldDescriptorProto_TYPE_STRING
+	case cty.Bool:
+		return descriptorpb.FieldDescriptorProto_TYPE_BOOL
+	default:
+		// The caller should only pass primitive types.
+		panic(fmt.Sprintf("no scalar field type for %#v", ty))
+	}
+}
+
+// fieldNameForHCLName converts an HCL identifier, which may contain dashes,
+// into a valid protobuf field name.
+func fieldNameForHCLName(name string) protoreflect.Name {
+	return protoreflect.Name(strings.ReplaceAll(name, "-", "_"))
+}
+
+// mapEntryName returns the name protoc would generate for the synthetic
+// entry message of a map field with the given name.
+func mapEntryName(fieldName protoreflect.Name) protoreflect.Name {
+	var b strings.Builder
+	upperNext := true
+	for _, c := range string(fieldName) {
+		switch {
+		case c == '_':
+			upperNext = true
+		case upperNext && 'a' <= c && c <= 'z':
+			b.WriteRune(c - 'a' + 'A')
+			upperNext = false
+		default:
+			b.WriteRune(c)
+			upperNext = false
+		}
+	}
+	b.WriteString("Entry")
+	return protoreflect.Name(b.String())
+}
+
+// protodescJSONName returns the default JSON name protoc would assign to a
+// field of the given name.
+func protodescJSONName(fieldName protoreflect.Name) string {
+	var b strings.Builder
+	upperNext := false
+	for _, c := range string(fieldName) {
+		switch {
+		case c == '_':
+			upperNext = true
+		case upperNext && 'a' <= c && c <= 'z':
+			b.WriteRune(c - 'a' + 'A')
+			upperNext = false
+		default:
+			b.WriteRune(c)
+			upperNext = false
+		}
+	}
+	return b.String()
+}
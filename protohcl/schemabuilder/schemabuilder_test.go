@@ -0,0 +1,123 @@
+package schemabuilder
+
+import (
+	"testing"
+
+	"github.com/apparentlymart/go-protohcl/protohcl"
+	"github.com/apparentlymart/go-protohcl/protohcl/protohclext"
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty-debug/ctydebug"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestBuild(t *testing.T) {
+	f := NewFile("schemabuilder_test.proto", "schemabuilder.test")
+	thing := f.AddMessage("Thing").
+		AddLabel("name").
+		AddAttribute("enabled", cty.Bool)
+	f.AddMessage("Config").
+		AddAttribute("name", cty.String, Required).
+		AddAttribute("count", cty.Number).
+		AddAttribute("tags", cty.Map(cty.String)).
+		AddAttribute("aliases", cty.Set(cty.String)).
+		AddAttribute("extra", cty.DynamicPseudoType).
+		AddBlock("thing", thing, CollectionKind(protohclext.NestedBlock_LIST))
+
+	fileDesc, err := f.Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	desc := fileDesc.Messages().ByName("Config")
+	if desc == nil {
+		t.Fatalf("no Config message in result")
+	}
+
+	src := `
+		name    = "example"
+		count   = 2
+		tags    = { env = "test" }
+		aliases = ["b", "a"]
+		extra   = [true, 1]
+
+		thing "a" {
+			enabled = true
+		}
+		thing "b" {
+			enabled = false
+		}
+	`
+	hclFile, diags := hclsyntax.ParseConfig([]byte(src), "test.hcl", hcl.InitialPos)
+	if diags.HasErrors() {
+		t.Fatalf("parse error: %s", diags)
+	}
+	msg, diags := protohcl.DecodeBody(hclFile.Body, desc, nil)
+	if diags.HasErrors() {
+		t.Fatalf("unexpected decode errors: %s", diags)
+	}
+
+	got, err := protohcl.ObjectValueForMessage(msg)
+	if err != nil {
+		t.Fatalf("unexpected error converting to object: %s", err)
+	}
+	want := cty.ObjectVal(map[string]cty.Value{
+		"name":  cty.StringVal("example"),
+		"count": cty.NumberIntVal(2),
+		"tags": cty.MapVal(map[string]cty.Value{
+			"env": cty.StringVal("test"),
+		}),
+		"aliases": cty.SetVal([]cty.Value{
+			cty.StringVal("a"),
+			cty.StringVal("b"),
+		}),
+		"extra": cty.TupleVal([]cty.Value{
+			cty.True,
+			cty.NumberIntVal(1),
+		}),
+		"thing": cty.ListVal([]cty.Value{
+			cty.ObjectVal(map[string]cty.Value{
+				"name":    cty.StringVal("a"),
+				"enabled": cty.True,
+			}),
+			cty.ObjectVal(map[string]cty.Value{
+				"name":    cty.StringVal("b"),
+				"enabled": cty.False,
+			}),
+		}),
+	})
+	if diff := cmp.Diff(want, got, ctydebug.CmpOptions); diff != "" {
+		t.Errorf("wrong result\n%s", diff)
+	}
+}
+
+func TestBuildErrors(t *testing.T) {
+	t.Run("duplicate field", func(t *testing.T) {
+		f := NewFile("schemabuilder_test_dup.proto", "schemabuilder.test")
+		f.AddMessage("Config").
+			AddAttribute("name", cty.String).
+			AddAttribute("name", cty.Number)
+
+		_, err := f.Build()
+		if err == nil {
+			t.Fatalf("unexpected success")
+		}
+		if got, want := err.Error(), "invalid message Config: duplicate declaration of field name"; got != want {
+			t.Errorf("wrong error\ngot:  %s\nwant: %s", got, want)
+		}
+	})
+	t.Run("block from another file", func(t *testing.T) {
+		other := NewFile("schemabuilder_test_other.proto", "schemabuilder.other")
+		thing := other.AddMessage("Thing")
+		f := NewFile("schemabuilder_test_cross.proto", "schemabuilder.test")
+		f.AddMessage("Config").AddBlock("thing", thing)
+
+		_, err := f.Build()
+		if err == nil {
+			t.Fatalf("unexpected success")
+		}
+		if got, want := err.Error(), `invalid message Config: block type "thing" refers to message schemabuilder.other.Thing from a different file`; got != want {
+			t.Errorf("wrong error\ngot:  %s\nwant: %s", got, want)
+		}
+	})
+}
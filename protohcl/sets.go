@@ -0,0 +1,77 @@
+package protohcl
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"github.com/zclconf/go-cty/cty"
+	ctymsgpack "github.com/zclconf/go-cty/cty/msgpack"
+)
+
+// OrderedSetEncoding returns the elements of the given set value in the
+// order that protohcl uses when writing a set into a repeated field.
+//
+// cty sets have no inherent order, but protobuf repeated fields do, and so
+// protohcl must choose an order for the elements. The order is stable across
+// runs and platforms, which is what allows hosts to rely on the serialized
+// form of a decoded message being the same each time the same configuration
+// is decoded, such as when hashing it.
+//
+// Sets of strings are in lexical order by their UTF-8 bytes, sets of
+// numbers are in ascending numeric order, and in sets of booleans false
+// comes before true. Elements of any other type are in lexical order of
+// their MessagePack serializations, which is also the order NormalizeMessage
+// uses for the blocks of nested block types with (hcl.block).kind = SET.
+//
+// The given value must be a known, non-null set. OrderedSetEncoding panics
+// otherwise. It returns an error if any element can't be serialized for
+// sorting.
+func OrderedSetEncoding(val cty.Value) ([]cty.Value, error) {
+	if !val.Type().IsSetType() {
+		panic(fmt.Sprintf("OrderedSetEncoding with %#v value", val.Type()))
+	}
+	if val.IsNull() || !val.IsKnown() {
+		panic("OrderedSetEncoding with null or unknown set")
+	}
+
+	if val.Type().ElementType().IsPrimitiveType() {
+		// cty already iterates sets of primitive types in the order we
+		// document above.
+		return val.AsValueSlice(), nil
+	}
+
+	type sortElem struct {
+		key []byte
+		val cty.Value
+	}
+	elems := make([]sortElem, 0, val.LengthInt())
+	for it := val.ElementIterator(); it.Next(); {
+		_, v := it.Element()
+		key, err := setSortKey(v)
+		if err != nil {
+			return nil, err
+		}
+		elems = append(elems, sortElem{key, v})
+	}
+
+	sort.SliceStable(elems, func(i, j int) bool {
+		return bytes.Compare(elems[i].key, elems[j].key) < 0
+	})
+
+	ret := make([]cty.Value, len(elems))
+	for i, elem := range elems {
+		ret[i] = elem.val
+	}
+	return ret, nil
+}
+
+// setSortKey returns the byte sequence that we use to decide the relative
+// order of the given value among the other elements of a set.
+func setSortKey(val cty.Value) ([]byte, error) {
+	key, err := ctymsgpack.Marshal(val, cty.DynamicPseudoType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize value for sorting: %s", err)
+	}
+	return key, nil
+}
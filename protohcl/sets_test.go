@@ -0,0 +1,61 @@
+package protohcl
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/zclconf/go-cty-debug/ctydebug"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestOrderedSetEncoding(t *testing.T) {
+	obj := func(name string, count int64) cty.Value {
+		return cty.ObjectVal(map[string]cty.Value{
+			"name":  cty.StringVal(name),
+			"count": cty.NumberIntVal(count),
+		})
+	}
+
+	tests := map[string]struct {
+		elems []cty.Value
+		want  []cty.Value
+	}{
+		"strings": {
+			[]cty.Value{cty.StringVal("Snakob"), cty.StringVal("Agnes"), cty.StringVal("Jackson")},
+			[]cty.Value{cty.StringVal("Agnes"), cty.StringVal("Jackson"), cty.StringVal("Snakob")},
+		},
+		"numbers": {
+			[]cty.Value{cty.NumberIntVal(10), cty.NumberIntVal(-2), cty.NumberFloatVal(2.5)},
+			[]cty.Value{cty.NumberIntVal(-2), cty.NumberFloatVal(2.5), cty.NumberIntVal(10)},
+		},
+		"objects": {
+			[]cty.Value{obj("Rufus", 2), obj("Jackson", 1), obj("Agnes", 3)},
+			// MessagePack serializes object attributes in lexical order by
+			// name, so the "count" values dominate here.
+			[]cty.Value{obj("Jackson", 1), obj("Rufus", 2), obj("Agnes", 3)},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			// The result must not depend on the order the set was
+			// constructed in, so we'll try both forwards and backwards.
+			reversed := make([]cty.Value, len(test.elems))
+			for i, v := range test.elems {
+				reversed[len(reversed)-i-1] = v
+			}
+
+			for _, elems := range [][]cty.Value{test.elems, reversed} {
+				got, err := OrderedSetEncoding(cty.SetVal(elems))
+
+				if err != nil {
+					t.Fatalf("unexpected error\ngot error: %s", err.Error())
+				}
+
+				if diff := cmp.Diff(test.want, got, ctydebug.CmpOptions); diff != "" {
+					t.Errorf("wrong result\n%s", diff)
+				}
+			}
+		})
+	}
+}
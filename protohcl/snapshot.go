@@ -0,0 +1,274 @@
+package protohcl
+
+import (
+	"fmt"
+
+	"github.com/apparentlymart/go-protohcl/protohcl/protohclext"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protopath"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// SaveSnapshot returns a binary snapshot of the given decoded message and
+// the trace that the decoder returned with it, in the protobuf wire format
+// of an hcl.ConfigSnapshot message.
+//
+// The snapshot includes the descriptors of the message type and of all of
+// the files it depends on, so that a later call to LoadSnapshot can
+// interpret it even in a program that doesn't have the message type
+// compiled in, and without the configuration source files it was decoded
+// from. The trace may be nil, in which case the snapshot records no source
+// ranges.
+//
+// The snapshot includes the files that declare the fields recorded in the
+// trace, but not those of the messages that google.protobuf.Any attributes
+// contain when the decoder found their types using DecodeOptions.AnyTypes,
+// and so a program that loads such a snapshot needs its own registry to
+// interpret those attributes.
+func SaveSnapshot(msg proto.Message, trace *DecodeTrace) ([]byte, error) {
+	snap, err := SnapshotProto(msg, trace)
+	if err != nil {
+		return nil, err
+	}
+	return proto.MarshalOptions{Deterministic: true}.Marshal(snap)
+}
+
+// LoadSnapshot is the inverse of SaveSnapshot, returning the message and
+// trace from the given binary snapshot.
+//
+// See SnapshotFromProto for more information about the results.
+func LoadSnapshot(data []byte) (proto.Message, *DecodeTrace, error) {
+	var snap protohclext.ConfigSnapshot
+	if err := proto.Unmarshal(data, &snap); err != nil {
+		return nil, nil, fmt.Errorf("invalid config snapshot: %w", err)
+	}
+	return SnapshotFromProto(&snap)
+}
+
+// SnapshotProto is like SaveSnapshot but returns the snapshot as a message,
+// so that a caller can include it in a message of its own.
+func SnapshotProto(msg proto.Message, trace *DecodeTrace) (*protohclext.ConfigSnapshot, error) {
+	desc := msg.ProtoReflect().Descriptor()
+	raw, err := proto.MarshalOptions{Deterministic: true}.Marshal(msg)
+	if err != nil {
+		return nil, fmt.Errorf("can't encode %s: %w", desc.FullName(), err)
+	}
+
+	files := newSnapshotFiles()
+	files.add(desc.ParentFile())
+
+	snap := &protohclext.ConfigSnapshot{
+		MessageType: string(desc.FullName()),
+		Message:     raw,
+	}
+	for _, path := range trace.Paths() {
+		if len(path) == 0 || path[0].Kind() != protopath.RootStep || path[0].MessageDescriptor().FullName() != desc.FullName() {
+			return nil, fmt.Errorf("trace includes path %s, which isn't for a %s message", path, desc.FullName())
+		}
+		steps, err := snapshotPathSteps(path[1:], files)
+		if err != nil {
+			return nil, fmt.Errorf("can't save trace path %s: %w", path, err)
+		}
+		rng, _ := trace.Range(path)
+		snap.Trace = append(snap.Trace, &protohclext.ConfigSnapshot_TraceEntry{
+			Path:      steps,
+			Range:     SourceRangeProto(rng),
+			Variables: trace.Variables(path),
+		})
+	}
+	snap.Files = &descriptorpb.FileDescriptorSet{File: files.protos}
+	return snap, nil
+}
+
+// SnapshotFromProto is the inverse of SnapshotProto, returning the message
+// and trace that the given snapshot records.
+//
+// The message is always a dynamic message using the descriptors from the
+// snapshot, even if the calling program has the same message type compiled
+// in, because the compiled-in type might not match the schema that the
+// snapshot was saved with. The paths in the trace also refer to the
+// snapshot's descriptors. A caller that needs the compiled-in type can
+// convert the result by encoding it and then decoding it as that type.
+func SnapshotFromProto(snap *protohclext.ConfigSnapshot) (proto.Message, *DecodeTrace, error) {
+	files, err := protodesc.NewFiles(snap.GetFiles())
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid descriptors in config snapshot: %w", err)
+	}
+	d, err := files.FindDescriptorByName(protoreflect.FullName(snap.GetMessageType()))
+	if err != nil {
+		return nil, nil, fmt.Errorf("config snapshot has no descriptor for message type %q", snap.GetMessageType())
+	}
+	desc, ok := d.(protoreflect.MessageDescriptor)
+	if !ok {
+		return nil, nil, fmt.Errorf("config snapshot message type %q is not a message type", snap.GetMessageType())
+	}
+
+	msg := dynamicpb.NewMessage(desc)
+	if err := proto.Unmarshal(snap.GetMessage(), msg); err != nil {
+		return nil, nil, fmt.Errorf("invalid %s message in config snapshot: %w", desc.FullName(), err)
+	}
+
+	trace := newDecodeTrace()
+	for i, entry := range snap.GetTrace() {
+		path, err := snapshotTracePath(desc, entry.GetPath(), files)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid path for trace entry %d in config snapshot: %w", i, err)
+		}
+		trace.record(path, RangeFromSourceRangeProto(entry.GetRange()))
+		if vars := entry.GetVariables(); len(vars) != 0 {
+			trace.variables[path.String()] = append([]string(nil), vars...)
+		}
+	}
+	return msg, trace, nil
+}
+
+// snapshotFiles collects the descriptors of the files that a snapshot
+// needs, each after the files that it imports.
+type snapshotFiles struct {
+	protos []*descriptorpb.FileDescriptorProto
+	seen   map[string]struct{}
+}
+
+func newSnapshotFiles() *snapshotFiles {
+	return &snapshotFiles{seen: make(map[string]struct{})}
+}
+
+// add adds the given file and all of the files that it imports, unless
+// they were already added.
+func (fs *snapshotFiles) add(file protoreflect.FileDescriptor) {
+	if _, exists := fs.seen[file.Path()]; exists {
+		return
+	}
+	fs.seen[file.Path()] = struct{}{}
+	imports := file.Imports()
+	for i := 0; i < imports.Len(); i++ {
+		fs.add(imports.Get(i).FileDescriptor)
+	}
+	fs.protos = append(fs.protos, protodesc.ToFileDescriptorProto(file))
+}
+
+// snapshotPathSteps returns the snapshot representation of the given steps
+// of a trace path, which must not include the root step, and adds the files
+// that declare the fields the steps refer to.
+func snapshotPathSteps(path protopath.Path, files *snapshotFiles) ([]*protohclext.ConfigSnapshot_PathStep, error) {
+	ret := make([]*protohclext.ConfigSnapshot_PathStep, len(path))
+	for i, step := range path {
+		msg := &protohclext.ConfigSnapshot_PathStep{}
+		switch step.Kind() {
+		case protopath.FieldAccessStep:
+			field := step.FieldDescriptor()
+			files.add(field.ParentFile())
+			msg.Step = &protohclext.ConfigSnapshot_PathStep_Field{Field: string(field.FullName())}
+		case protopath.ListIndexStep:
+			msg.Step = &protohclext.ConfigSnapshot_PathStep_ListIndex{ListIndex: int64(step.ListIndex())}
+		case protopath.MapIndexStep:
+			switch k := step.MapIndex().Interface().(type) {
+			case string:
+				msg.Step = &protohclext.ConfigSnapshot_PathStep_MapKeyString{MapKeyString: k}
+			case int32:
+				msg.Step = &protohclext.ConfigSnapshot_PathStep_MapKeyInt{MapKeyInt: int64(k)}
+			case int64:
+				msg.Step = &protohclext.ConfigSnapshot_PathStep_MapKeyInt{MapKeyInt: k}
+			case uint32:
+				msg.Step = &protohclext.ConfigSnapshot_PathStep_MapKeyUint{MapKeyUint: uint64(k)}
+			case uint64:
+				msg.Step = &protohclext.ConfigSnapshot_PathStep_MapKeyUint{MapKeyUint: k}
+			case bool:
+				msg.Step = &protohclext.ConfigSnapshot_PathStep_MapKeyBool{MapKeyBool: k}
+			default:
+				return nil, fmt.Errorf("unsupported map key type %T", k)
+			}
+		default:
+			return nil, fmt.Errorf("unsupported path step %s", step)
+		}
+		ret[i] = msg
+	}
+	return ret, nil
+}
+
+// snapshotTracePath is the inverse of snapshotPathSteps, returning the
+// trace path for the given steps from a message of the given type.
+func snapshotTracePath(desc protoreflect.MessageDescriptor, steps []*protohclext.ConfigSnapshot_PathStep, files *protoregistry.Files) (protopath.Path, error) {
+	path := make(protopath.Path, 1, len(steps)+1)
+	path[0] = protopath.Root(desc)
+
+	// field is the field that the most recent field step refers to, which
+	// the list and map steps that follow it must agree with.
+	var field protoreflect.FieldDescriptor
+	for _, step := range steps {
+		if name, ok := step.GetStep().(*protohclext.ConfigSnapshot_PathStep_Field); ok {
+			d, err := files.FindDescriptorByName(protoreflect.FullName(name.Field))
+			if err != nil {
+				return nil, fmt.Errorf("no descriptor for field %q", name.Field)
+			}
+			fd, ok := d.(protoreflect.FieldDescriptor)
+			if !ok {
+				return nil, fmt.Errorf("%q is not a field", name.Field)
+			}
+			field = fd
+			path = append(path, protopath.FieldAccess(field))
+			continue
+		}
+
+		if field == nil {
+			return nil, fmt.Errorf("index step without a preceding field")
+		}
+		switch s := step.GetStep().(type) {
+		case *protohclext.ConfigSnapshot_PathStep_ListIndex:
+			if !field.IsList() {
+				return nil, fmt.Errorf("list index for non-list field %s", field.FullName())
+			}
+			path = append(path, protopath.ListIndex(int(s.ListIndex)))
+		default:
+			if !field.IsMap() {
+				return nil, fmt.Errorf("map key for non-map field %s", field.FullName())
+			}
+			key, err := snapshotMapKey(step, field.MapKey())
+			if err != nil {
+				return nil, fmt.Errorf("invalid key for map field %s: %w", field.FullName(), err)
+			}
+			path = append(path, protopath.MapIndex(key))
+		}
+	}
+	return path, nil
+}
+
+// snapshotMapKey returns the map key that the given step represents, for a
+// map whose keys are described by the given field.
+func snapshotMapKey(step *protohclext.ConfigSnapshot_PathStep, keyField protoreflect.FieldDescriptor) (protoreflect.MapKey, error) {
+	var key protoreflect.Value
+	switch s := step.GetStep().(type) {
+	case *protohclext.ConfigSnapshot_PathStep_MapKeyString:
+		if keyField.Kind() == protoreflect.StringKind {
+			key = protoreflect.ValueOfString(s.MapKeyString)
+		}
+	case *protohclext.ConfigSnapshot_PathStep_MapKeyInt:
+		switch keyField.Kind() {
+		case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+			key = protoreflect.ValueOfInt32(int32(s.MapKeyInt))
+		case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+			key = protoreflect.ValueOfInt64(s.MapKeyInt)
+		}
+	case *protohclext.ConfigSnapshot_PathStep_MapKeyUint:
+		switch keyField.Kind() {
+		case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+			key = protoreflect.ValueOfUint32(uint32(s.MapKeyUint))
+		case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+			key = protoreflect.ValueOfUint64(s.MapKeyUint)
+		}
+	case *protohclext.ConfigSnapshot_PathStep_MapKeyBool:
+		if keyField.Kind() == protoreflect.BoolKind {
+			key = protoreflect.ValueOfBool(s.MapKeyBool)
+		}
+	default:
+		return protoreflect.MapKey{}, fmt.Errorf("step has no value")
+	}
+	if !key.IsValid() {
+		return protoreflect.MapKey{}, fmt.Errorf("key doesn't suit key type %s", keyField.Kind())
+	}
+	return key.MapKey(), nil
+}
@@ -0,0 +1,249 @@
+package protohcl
+
+import (
+	"testing"
+
+	"github.com/apparentlymart/go-protohcl/protohcl/internal/testschema"
+	"github.com/apparentlymart/go-protohcl/protohcl/protohclext"
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	fileDesc := testschema.File_testschema_proto
+
+	tests := map[string]struct {
+		config string
+		desc   protoreflect.MessageDescriptor
+	}{
+		"list attribute": {
+			`nums = [1, 2]`,
+			fileDesc.Messages().ByName("WithNumberListAttrAsInt32"),
+		},
+		"map attribute": {
+			`nums = { a = 1 }`,
+			fileDesc.Messages().ByName("WithNumberMapAttrAsInt32"),
+		},
+		"nested blocks with flattened label": {
+			`
+doodad "bird" "Jackson" {
+  species = "budgerigar"
+}
+doodad "bird" "Snakob" {
+  nickname = var.nickname
+}
+`,
+			fileDesc.Messages().ByName("WithNestedBlockFlattenedLabels"),
+		},
+		"any block": {
+			`
+storage_type = "s3"
+storage {
+  bucket = "example"
+}
+`,
+			fileDesc.Messages().ByName("WithAnyBlock"),
+		},
+	}
+
+	ctx := &hcl.EvalContext{
+		Variables: map[string]cty.Value{
+			"var": cty.ObjectVal(map[string]cty.Value{
+				"nickname": cty.StringVal("sneaky"),
+			}),
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			f, diags := hclsyntax.ParseConfig([]byte(test.config), "test.tf", hcl.InitialPos)
+			if diags.HasErrors() {
+				t.Fatalf("parse error: %s", diags)
+			}
+			msg, trace, diags := DecodeBodyWithTrace(f.Body, test.desc, ctx)
+			if diags.HasErrors() {
+				t.Fatalf("unexpected errors: %s", diags)
+			}
+
+			data, err := SaveSnapshot(msg, trace)
+			if err != nil {
+				t.Fatalf("failed to save: %s", err)
+			}
+			gotMsg, gotTrace, err := LoadSnapshot(data)
+			if err != nil {
+				t.Fatalf("failed to load: %s", err)
+			}
+
+			// The loaded message is dynamic, so we convert it back to the
+			// generated type to compare it.
+			raw, err := proto.Marshal(gotMsg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := msg.ProtoReflect().New().Interface()
+			if err := proto.Unmarshal(raw, got); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(msg, got, protoCmpOpt); diff != "" {
+				t.Errorf("wrong message\n%s", diff)
+			}
+
+			if diff := cmp.Diff(snapshotTestTrace(trace), snapshotTestTrace(gotTrace)); diff != "" {
+				t.Errorf("wrong trace\n%s", diff)
+			}
+
+			// The loaded descriptors retain the HCL annotations, so the
+			// loaded message still has the same HCL representation.
+			wantVal, err := ObjectValueForMessage(msg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			gotVal, err := ObjectValueForMessage(gotMsg)
+			if err != nil {
+				t.Fatalf("can't convert loaded message: %s", err)
+			}
+			if !wantVal.RawEquals(gotVal) {
+				t.Errorf("wrong value for loaded message\ngot:  %#v\nwant: %#v", gotVal, wantVal)
+			}
+		})
+	}
+}
+
+func TestSnapshotProtoNilTrace(t *testing.T) {
+	msg := &testschema.WithStringAttr{Name: "a"}
+	snap, err := SnapshotProto(msg, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got, want := snap.GetMessageType(), "hcl.testschema.WithStringAttr"; got != want {
+		t.Errorf("wrong message type %q; want %q", got, want)
+	}
+	if len(snap.GetTrace()) != 0 {
+		t.Errorf("unexpected trace entries: %#v", snap.GetTrace())
+	}
+
+	// The files must appear after the files that they import.
+	var gotFiles []string
+	for _, file := range snap.GetFiles().GetFile() {
+		gotFiles = append(gotFiles, file.GetName())
+	}
+	wantFiles := []string{
+		"google/protobuf/descriptor.proto",
+		"hcl.proto",
+		"google/protobuf/any.proto",
+		"google/protobuf/struct.proto",
+		"google/protobuf/wrappers.proto",
+		"google/protobuf/timestamp.proto",
+		"google/protobuf/duration.proto",
+		"testschema.proto",
+	}
+	if diff := cmp.Diff(wantFiles, gotFiles); diff != "" {
+		t.Errorf("wrong files\n%s", diff)
+	}
+
+	gotMsg, gotTrace, err := SnapshotFromProto(snap)
+	if err != nil {
+		t.Fatalf("failed to load: %s", err)
+	}
+	if got := gotMsg.ProtoReflect().Get(gotMsg.ProtoReflect().Descriptor().Fields().ByName("name")).String(); got != "a" {
+		t.Errorf("wrong name %q", got)
+	}
+	if paths := gotTrace.Paths(); len(paths) != 0 {
+		t.Errorf("unexpected trace paths: %s", paths)
+	}
+}
+
+func TestSnapshotFromProtoErrors(t *testing.T) {
+	valid, err := SnapshotProto(&testschema.WithNumberMapAttrAsInt32{Nums: map[string]int32{"a": 1}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	withChanges := func(f func(snap *protohclext.ConfigSnapshot)) *protohclext.ConfigSnapshot {
+		snap := proto.Clone(valid).(*protohclext.ConfigSnapshot)
+		f(snap)
+		return snap
+	}
+	fieldStep := func(name string) *protohclext.ConfigSnapshot_PathStep {
+		return &protohclext.ConfigSnapshot_PathStep{
+			Step: &protohclext.ConfigSnapshot_PathStep_Field{Field: name},
+		}
+	}
+
+	tests := map[string]struct {
+		snap    *protohclext.ConfigSnapshot
+		wantErr string
+	}{
+		"unknown message type": {
+			withChanges(func(snap *protohclext.ConfigSnapshot) {
+				snap.MessageType = "hcl.testschema.DoesNotExist"
+			}),
+			`config snapshot has no descriptor for message type "hcl.testschema.DoesNotExist"`,
+		},
+		"not a message type": {
+			withChanges(func(snap *protohclext.ConfigSnapshot) {
+				snap.MessageType = "hcl.testschema.WithNumberMapAttrAsInt32.nums"
+			}),
+			`config snapshot message type "hcl.testschema.WithNumberMapAttrAsInt32.nums" is not a message type`,
+		},
+		"unknown field": {
+			withChanges(func(snap *protohclext.ConfigSnapshot) {
+				snap.Trace = []*protohclext.ConfigSnapshot_TraceEntry{
+					{Path: []*protohclext.ConfigSnapshot_PathStep{fieldStep("hcl.testschema.WithNumberMapAttrAsInt32.other")}},
+				}
+			}),
+			`invalid path for trace entry 0 in config snapshot: no descriptor for field "hcl.testschema.WithNumberMapAttrAsInt32.other"`,
+		},
+		"list index for map": {
+			withChanges(func(snap *protohclext.ConfigSnapshot) {
+				snap.Trace = []*protohclext.ConfigSnapshot_TraceEntry{
+					{Path: []*protohclext.ConfigSnapshot_PathStep{
+						fieldStep("hcl.testschema.WithNumberMapAttrAsInt32.nums"),
+						{Step: &protohclext.ConfigSnapshot_PathStep_ListIndex{ListIndex: 0}},
+					}},
+				}
+			}),
+			`invalid path for trace entry 0 in config snapshot: list index for non-list field hcl.testschema.WithNumberMapAttrAsInt32.nums`,
+		},
+		"wrong map key type": {
+			withChanges(func(snap *protohclext.ConfigSnapshot) {
+				snap.Trace = []*protohclext.ConfigSnapshot_TraceEntry{
+					{Path: []*protohclext.ConfigSnapshot_PathStep{
+						fieldStep("hcl.testschema.WithNumberMapAttrAsInt32.nums"),
+						{Step: &protohclext.ConfigSnapshot_PathStep_MapKeyBool{MapKeyBool: true}},
+					}},
+				}
+			}),
+			`invalid path for trace entry 0 in config snapshot: invalid key for map field hcl.testschema.WithNumberMapAttrAsInt32.nums: key doesn't suit key type string`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, _, err := SnapshotFromProto(test.snap)
+			if err == nil {
+				t.Fatalf("unexpected success\nwant error: %s", test.wantErr)
+			}
+			if got, want := err.Error(), test.wantErr; got != want {
+				t.Errorf("wrong error\ngot:  %s\nwant: %s", got, want)
+			}
+		})
+	}
+}
+
+// snapshotTestTrace summarizes the given trace for comparison, using the
+// string representation of each path because the paths of a loaded trace
+// refer to different descriptors.
+func snapshotTestTrace(trace *DecodeTrace) []string {
+	var ret []string
+	for _, path := range trace.Paths() {
+		rng, _ := trace.Range(path)
+		ret = append(ret, path.String()+" "+rng.String())
+		for _, name := range trace.Variables(path) {
+			ret = append(ret, path.String()+" var "+name)
+		}
+	}
+	return ret
+}
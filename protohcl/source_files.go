@@ -0,0 +1,150 @@
+package protohcl
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/apparentlymart/go-protohcl/protohcl/protohclext"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	hcljson "github.com/hashicorp/hcl/v2/json"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// ParseSourceFiles parses the given configuration source files and returns
+// a body that merges all of their content, along with the parsed files
+// keyed by filename, which are useful for rendering diagnostics.
+//
+// This is for an application where the host sends the configuration source
+// code to a plugin, and the plugin then decodes it itself using its own
+// compiled-in message types. A plugin which just needs to decode the files
+// into a single message can use DecodeSourceFiles instead, but a plugin can
+// use ParseSourceFiles with any of the other decoding functions in this
+// package.
+//
+// Files whose names end in ".json" are parsed as the JSON variant of HCL,
+// and all others use the native syntax.
+func ParseSourceFiles(files []*protohclext.SourceFile) (hcl.Body, map[string]*hcl.File, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+	parsed := make(map[string]*hcl.File, len(files))
+	hclFiles := make([]*hcl.File, 0, len(files))
+	for _, file := range files {
+		filename := file.GetFilename()
+		if _, exists := parsed[filename]; exists {
+			diags = diags.Append(&hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Duplicate configuration file",
+				Detail:   fmt.Sprintf("The configuration includes more than one file named %q.", filename),
+			})
+			continue
+		}
+
+		var f *hcl.File
+		var moreDiags hcl.Diagnostics
+		if strings.HasSuffix(filename, ".json") {
+			f, moreDiags = hcljson.Parse(file.GetContent(), filename)
+		} else {
+			f, moreDiags = hclsyntax.ParseConfig(file.GetContent(), filename, hcl.InitialPos)
+		}
+		diags = append(diags, moreDiags...)
+		if f == nil {
+			// The parsers return a file even when they report errors, so
+			// this is just defensive.
+			continue
+		}
+		parsed[filename] = f
+		hclFiles = append(hclFiles, f)
+	}
+	return hcl.MergeFiles(hclFiles), parsed, diags
+}
+
+// DecodeSourceFiles parses the given configuration source files and then
+// decodes their combined content as a message of the given type, using
+// ParseSourceFiles and then DecodeBody.
+//
+// If the files have syntax errors then DecodeSourceFiles returns only the
+// diagnostics from parsing them, and a nil message.
+func DecodeSourceFiles(files []*protohclext.SourceFile, desc protoreflect.MessageDescriptor, ctx *hcl.EvalContext) (proto.Message, hcl.Diagnostics) {
+	body, _, diags := ParseSourceFiles(files)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	msg, moreDiags := DecodeBody(body, desc, ctx)
+	diags = append(diags, moreDiags...)
+	return msg, diags
+}
+
+// DiagnosticsProto returns the hcl.Diagnostic messages representing the
+// given diagnostics, so that a plugin that decodes its configuration itself
+// can send them back to the host.
+//
+// The messages include only the severity, summary, detail, subject,
+// context, and category of each diagnostic, because the other parts of an
+// HCL diagnostic refer to objects that exist only in the plugin. The
+// category means that DiagnosticCategoryOf still works with the diagnostics
+// that the host recovers using DiagnosticsFromProto.
+func DiagnosticsProto(diags hcl.Diagnostics) []*protohclext.Diagnostic {
+	if len(diags) == 0 {
+		return nil
+	}
+	ret := make([]*protohclext.Diagnostic, len(diags))
+	for i, diag := range diags {
+		msg := &protohclext.Diagnostic{
+			Summary: diag.Summary,
+			Detail:  diag.Detail,
+		}
+		if category := DiagnosticCategoryOf(diag); category != DiagnosticUncategorized {
+			msg.Category = category.String()
+		}
+		switch diag.Severity {
+		case hcl.DiagError:
+			msg.Severity = protohclext.Diagnostic_ERROR
+		case hcl.DiagWarning:
+			msg.Severity = protohclext.Diagnostic_WARNING
+		}
+		if diag.Subject != nil {
+			msg.Subject = SourceRangeProto(*diag.Subject)
+		}
+		if diag.Context != nil {
+			msg.Context = SourceRangeProto(*diag.Context)
+		}
+		ret[i] = msg
+	}
+	return ret
+}
+
+// DiagnosticsFromProto is the inverse of DiagnosticsProto, for a host that
+// receives diagnostics from a plugin that decoded its configuration itself.
+//
+// A message with an unspecified severity becomes an error diagnostic, so
+// that a host can't mistake a problem reported by a plugin for success.
+func DiagnosticsFromProto(msgs []*protohclext.Diagnostic) hcl.Diagnostics {
+	if len(msgs) == 0 {
+		return nil
+	}
+	ret := make(hcl.Diagnostics, len(msgs))
+	for i, msg := range msgs {
+		diag := &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  msg.GetSummary(),
+			Detail:   msg.GetDetail(),
+		}
+		if msg.GetSeverity() == protohclext.Diagnostic_WARNING {
+			diag.Severity = hcl.DiagWarning
+		}
+		if msg.Subject != nil {
+			rng := RangeFromSourceRangeProto(msg.Subject)
+			diag.Subject = &rng
+		}
+		if msg.Context != nil {
+			rng := RangeFromSourceRangeProto(msg.Context)
+			diag.Context = &rng
+		}
+		if category, ok := diagnosticCategoryNamed(msg.GetCategory()); ok {
+			diag.Extra = diagnosticExtra{Category: category}
+		}
+		ret[i] = diag
+	}
+	return ret
+}
@@ -0,0 +1,155 @@
+package protohcl
+
+import (
+	"testing"
+
+	"github.com/apparentlymart/go-protohcl/protohcl/internal/testschema"
+	"github.com/apparentlymart/go-protohcl/protohcl/protohclext"
+	"github.com/apparentlymart/go-protohcl/protohcl/protohcltest"
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl/v2"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestDecodeSourceFiles(t *testing.T) {
+	desc := testschema.File_testschema_proto.Messages().ByName("Root")
+
+	tests := map[string]struct {
+		files     []*protohclext.SourceFile
+		want      proto.Message
+		wantDiags []protohcltest.ExpectedDiagnostic
+	}{
+		"native and JSON": {
+			[]*protohclext.SourceFile{
+				{
+					Filename: "main.hcl",
+					Content:  []byte(`name = "Jackson"` + "\n" + `thing "a" {}`),
+				},
+				{
+					Filename: "more.hcl.json",
+					Content:  []byte(`{"count": 2}`),
+				},
+			},
+			&testschema.Root{
+				Name:   "Jackson",
+				Things: []*testschema.Thing{{Name: "a"}},
+				More:   &testschema.MoreRoot{Count: 2},
+			},
+			nil,
+		},
+		"syntax error": {
+			[]*protohclext.SourceFile{
+				{
+					Filename: "main.hcl",
+					Content:  []byte(`name = `),
+				},
+			},
+			nil,
+			[]protohcltest.ExpectedDiagnostic{
+				protohcltest.Error("Missing expression").OnLine(1),
+			},
+		},
+		"duplicate filename": {
+			[]*protohclext.SourceFile{
+				{
+					Filename: "main.hcl",
+					Content:  []byte(`name = "Jackson"`),
+				},
+				{
+					Filename: "main.hcl",
+					Content:  []byte(`count = 2`),
+				},
+			},
+			nil,
+			[]protohcltest.ExpectedDiagnostic{
+				protohcltest.Error("Duplicate configuration file").
+					WithDetail(`The configuration includes more than one file named "main.hcl".`),
+			},
+		},
+		"invalid for schema": {
+			[]*protohclext.SourceFile{
+				{
+					Filename: "main.hcl",
+					Content:  []byte(`count = 2`),
+				},
+			},
+			&testschema.Root{
+				More: &testschema.MoreRoot{Count: 2},
+			},
+			[]protohcltest.ExpectedDiagnostic{
+				protohcltest.Error("Missing required argument"),
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, diags := DecodeSourceFiles(test.files, desc, nil)
+			protohcltest.AssertDiagnostics(t, diags, test.wantDiags...)
+			if diff := cmp.Diff(test.want, got, protoCmpOpt); diff != "" {
+				t.Errorf("wrong result\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDiagnosticsProto(t *testing.T) {
+	subject := hcl.Range{
+		Filename: "main.hcl",
+		Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
+		End:      hcl.Pos{Line: 1, Column: 12, Byte: 11},
+	}
+	context := hcl.Range{
+		Filename: "main.hcl",
+		Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+		End:      hcl.Pos{Line: 1, Column: 12, Byte: 11},
+	}
+	diags := hcl.Diagnostics{
+		{
+			Severity: hcl.DiagError,
+			Summary:  unsuitableValueSummary,
+			Detail:   "Must be a string.",
+			Subject:  &subject,
+			Context:  &context,
+			Extra:    diagnosticExtra{Category: DiagnosticValueError},
+		},
+		{
+			Severity: hcl.DiagWarning,
+			Summary:  "Something odd",
+		},
+	}
+
+	msgs := DiagnosticsProto(diags)
+	want := []*protohclext.Diagnostic{
+		{
+			Severity: protohclext.Diagnostic_ERROR,
+			Summary:  unsuitableValueSummary,
+			Detail:   "Must be a string.",
+			Subject:  SourceRangeProto(subject),
+			Context:  SourceRangeProto(context),
+			Category: "value-error",
+		},
+		{
+			Severity: protohclext.Diagnostic_WARNING,
+			Summary:  "Something odd",
+		},
+	}
+	if diff := cmp.Diff(want, msgs, protoCmpOpt); diff != "" {
+		t.Fatalf("wrong messages\n%s", diff)
+	}
+
+	got := DiagnosticsFromProto(msgs)
+	if diff := cmp.Diff(diags, got); diff != "" {
+		t.Errorf("wrong diagnostics after round trip\n%s", diff)
+	}
+	if got, want := DiagnosticCategoryOf(got[0]), DiagnosticValueError; got != want {
+		t.Errorf("wrong category after round trip\ngot:  %s\nwant: %s", got, want)
+	}
+
+	t.Run("unspecified severity", func(t *testing.T) {
+		got := DiagnosticsFromProto([]*protohclext.Diagnostic{{Summary: "Oops"}})
+		if got[0].Severity != hcl.DiagError {
+			t.Errorf("wrong severity %#v; want error", got[0].Severity)
+		}
+	})
+}
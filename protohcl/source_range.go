@@ -0,0 +1,58 @@
+package protohcl
+
+import (
+	"github.com/apparentlymart/go-protohcl/protohcl/protohclext"
+	"github.com/hashicorp/hcl/v2"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+var sourceRangeDesc = (&protohclext.SourceRange{}).ProtoReflect().Descriptor()
+
+// SourceRangeProto returns the hcl.SourceRange message representing the
+// given source range, as the decoder writes into fields named by the
+// include_range_into attribute option.
+func SourceRangeProto(rng hcl.Range) *protohclext.SourceRange {
+	return &protohclext.SourceRange{
+		Filename: rng.Filename,
+		Start:    sourcePosProto(rng.Start),
+		End:      sourcePosProto(rng.End),
+	}
+}
+
+// RangeFromSourceRangeProto is the inverse of SourceRangeProto, which is
+// useful for a program that receives a message containing source ranges
+// and wishes to report diagnostics about the values they describe.
+//
+// A nil message produces the zero value of hcl.Range.
+func RangeFromSourceRangeProto(msg *protohclext.SourceRange) hcl.Range {
+	if msg == nil {
+		return hcl.Range{}
+	}
+	return hcl.Range{
+		Filename: msg.GetFilename(),
+		Start:    posFromSourcePosProto(msg.GetStart()),
+		End:      posFromSourcePosProto(msg.GetEnd()),
+	}
+}
+
+func sourcePosProto(pos hcl.Pos) *protohclext.SourcePos {
+	return &protohclext.SourcePos{
+		Line:   int64(pos.Line),
+		Column: int64(pos.Column),
+		Byte:   int64(pos.Byte),
+	}
+}
+
+func posFromSourcePosProto(msg *protohclext.SourcePos) hcl.Pos {
+	return hcl.Pos{
+		Line:   int(msg.GetLine()),
+		Column: int(msg.GetColumn()),
+		Byte:   int(msg.GetByte()),
+	}
+}
+
+// setSourceRangeField records the given range in the given field of msg,
+// which must be a field of type hcl.SourceRange.
+func setSourceRangeField(msg protoreflect.Message, field protoreflect.FieldDescriptor, rng hcl.Range) {
+	msg.Set(field, protoreflect.ValueOfMessage(SourceRangeProto(rng).ProtoReflect()))
+}
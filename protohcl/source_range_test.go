@@ -0,0 +1,106 @@
+package protohcl
+
+import (
+	"testing"
+
+	"github.com/apparentlymart/go-protohcl/protohcl/internal/testschema"
+	"github.com/apparentlymart/go-protohcl/protohcl/protohclext"
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func TestDecodeBodyIncludeRangeInto(t *testing.T) {
+	desc := testschema.File_testschema_proto.Messages().ByName("WithAttrRange")
+
+	tests := map[string]struct {
+		config string
+		want   *testschema.WithAttrRange
+	}{
+		"set": {
+			`name = "Jackson"`,
+			&testschema.WithAttrRange{
+				Name: "Jackson",
+				NameRange: &protohclext.SourceRange{
+					Filename: "test.hcl",
+					Start:    &protohclext.SourcePos{Line: 1, Column: 8, Byte: 7},
+					End:      &protohclext.SourcePos{Line: 1, Column: 17, Byte: 16},
+				},
+			},
+		},
+		"null": {
+			`name = null`,
+			&testschema.WithAttrRange{
+				NameRange: &protohclext.SourceRange{
+					Filename: "test.hcl",
+					Start:    &protohclext.SourcePos{Line: 1, Column: 8, Byte: 7},
+					End:      &protohclext.SourcePos{Line: 1, Column: 12, Byte: 11},
+				},
+			},
+		},
+		"absent": {
+			``,
+			&testschema.WithAttrRange{},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			f, diags := hclsyntax.ParseConfig([]byte(test.config), "test.hcl", hcl.InitialPos)
+			if diags.HasErrors() {
+				t.Fatalf("unexpected syntax errors: %s", diags.Error())
+			}
+
+			got, diags := DecodeBody(f.Body, desc, nil)
+			if diags.HasErrors() {
+				t.Fatalf("unexpected errors: %s", diags.Error())
+			}
+			if diff := cmp.Diff(test.want, got, protoCmpOpt); diff != "" {
+				t.Errorf("wrong result\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSourceRangeProtoRoundTrip(t *testing.T) {
+	rng := hcl.Range{
+		Filename: "main.hcl",
+		Start:    hcl.Pos{Line: 2, Column: 3, Byte: 10},
+		End:      hcl.Pos{Line: 4, Column: 1, Byte: 25},
+	}
+	got := RangeFromSourceRangeProto(SourceRangeProto(rng))
+	if diff := cmp.Diff(rng, got); diff != "" {
+		t.Errorf("wrong result\n%s", diff)
+	}
+
+	if got := RangeFromSourceRangeProto(nil); got != (hcl.Range{}) {
+		t.Errorf("wrong result for nil\ngot: %#v", got)
+	}
+}
+
+func TestGetFieldElemIncludeRangeIntoErrors(t *testing.T) {
+	tests := map[protoreflect.Name]string{
+		"WithAttrRangeMissing":   `unsupported protobuf schema in hcl.testschema.WithAttrRangeMissing.name: include_range_into refers to "nonexistent", which is not a field of hcl.testschema.WithAttrRangeMissing`,
+		"WithAttrRangeWrongType": `unsupported protobuf schema in hcl.testschema.WithAttrRangeWrongType.name: include_range_into field name_range must be a singleton hcl.SourceRange field`,
+	}
+	for name, want := range tests {
+		t.Run(string(name), func(t *testing.T) {
+			desc := testschema.File_testschema_proto.Messages().ByName(name)
+			_, err := GetFieldElem(desc.Fields().Get(0))
+			if err == nil {
+				t.Fatalf("unexpected success")
+			}
+			if got := err.Error(); got != want {
+				t.Errorf("wrong error\ngot:  %s\nwant: %s", got, want)
+			}
+		})
+	}
+}
+
+func TestValidateMessageDescIncludeRangeInto(t *testing.T) {
+	desc := testschema.File_testschema_proto.Messages().ByName("WithAttrRange")
+	if problems := ValidateMessageDesc(desc); len(problems) != 0 {
+		t.Errorf("unexpected problems: %#v", problems)
+	}
+}
@@ -0,0 +1,131 @@
+package protohcl
+
+import (
+	"github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+)
+
+// valueSourceRanges describes the source location of a value being decoded
+// into a field, along with the locations of its individual elements where
+// we're able to determine them from the expression that produced it.
+type valueSourceRanges struct {
+	// Whole is the range of the entire expression that produced the value.
+	Whole hcl.Range
+
+	// Elems, if non-nil, has one element per element of a sequence value,
+	// giving the range of the expression that produced that element.
+	Elems []hcl.Range
+
+	// MapElems, if non-nil, has one element per element of a mapping value,
+	// giving the range of the expression that produced that element's
+	// value.
+	MapElems map[string]hcl.Range
+}
+
+// sourceRangesForValue tries to find the source ranges of the individual
+// elements of the given value, which must be the result of evaluating
+// the given expression, using the given keys to evaluate the key
+// expressions of an object constructor.
+//
+// This is best-effort: if the expression isn't a static collection
+// constructor, or if the value has been transformed in a way that means its
+// elements no longer correlate with the expression's elements, the result
+// includes only the range of the whole expression.
+func sourceRangesForValue(expr hcl.Expression, val cty.Value, keys *objectKeyValues) valueSourceRanges {
+	ret := valueSourceRanges{
+		Whole: expr.Range(),
+	}
+	if val.IsNull() || !val.IsKnown() {
+		return ret
+	}
+
+	ty := val.Type()
+	switch {
+	case ty.IsListType() || ty.IsTupleType():
+		// We intentionally exclude set types here, because conversion to a
+		// set changes the element order and may coalesce duplicates, and
+		// so the elements would no longer correspond to the expressions.
+		exprs, diags := hcl.ExprList(expr)
+		if diags.HasErrors() || len(exprs) != val.LengthInt() {
+			return ret
+		}
+		ret.Elems = make([]hcl.Range, len(exprs))
+		for i, expr := range exprs {
+			ret.Elems[i] = expr.Range()
+		}
+	case ty.IsMapType() || ty.IsObjectType():
+		pairs, diags := hcl.ExprMap(expr)
+		if diags.HasErrors() {
+			return ret
+		}
+		ret.MapElems = make(map[string]hcl.Range, len(pairs))
+		for _, pair := range pairs {
+			// Evaluating the whole expression will already have reported
+			// any problems with these key expressions, so we'll ignore
+			// any errors here and just skip any key we can't evaluate.
+			kv, diags := keys.value(pair.Key)
+			if diags.HasErrors() {
+				continue
+			}
+			kv, err := convert.Convert(kv, cty.String)
+			if err != nil || kv.IsNull() || !kv.IsKnown() {
+				continue
+			}
+			ret.MapElems[kv.AsString()] = pair.Value.Range()
+		}
+	}
+
+	return ret
+}
+
+// objectKeyValues evaluates the key expressions of the object constructors
+// in one attribute's expression, remembering the result for each key
+// expression so that each is evaluated only once, in the same way as
+// decoder.mapKeyVals for the expressions of map_key_attr attributes.
+type objectKeyValues struct {
+	d    *decoder
+	ctx  *hcl.EvalContext
+	vals map[hcl.Range]evalResult
+}
+
+// objectKeyValues returns a new objectKeyValues that evaluates key
+// expressions using the given evaluation context.
+func (d *decoder) objectKeyValues(ctx *hcl.EvalContext) *objectKeyValues {
+	return &objectKeyValues{
+		d:    d,
+		ctx:  ctx,
+		vals: make(map[hcl.Range]evalResult),
+	}
+}
+
+// value returns the result of evaluating the given key expression, which
+// must belong to the expression that the receiver was created for.
+func (k *objectKeyValues) value(expr hcl.Expression) (cty.Value, hcl.Diagnostics) {
+	rng := expr.Range()
+	if result, ok := k.vals[rng]; ok {
+		return result.val, result.diags
+	}
+	val, diags := k.d.evalExpr(expr, k.ctx)
+	k.vals[rng] = evalResult{val, diags}
+	return val, diags
+}
+
+// Elem returns the range of the element at the given index, or the range of
+// the whole value if the individual element ranges are not known.
+func (r valueSourceRanges) Elem(i int) hcl.Range {
+	if i < len(r.Elems) {
+		return r.Elems[i]
+	}
+	return r.Whole
+}
+
+// MapElem returns the range of the value of the element with the given key,
+// or the range of the whole value if the individual element ranges are not
+// known.
+func (r valueSourceRanges) MapElem(k string) hcl.Range {
+	if rng, ok := r.MapElems[k]; ok {
+		return rng
+	}
+	return r.Whole
+}
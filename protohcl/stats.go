@@ -0,0 +1,272 @@
+package protohcl
+
+import (
+	"sort"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protopath"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// SchemaComplexity describes the shape of the configuration schema for one
+// message type, as returned by AnalyzeMessageDesc.
+type SchemaComplexity struct {
+	// Message is the full name of the message type that this describes.
+	Message protoreflect.FullName
+
+	// Attributes is the number of attributes that the message's body
+	// accepts, including those of any flattened messages.
+	Attributes int
+
+	// DynamicAttributes is the number of those attributes whose type
+	// constraint includes "any", and so which can accept values of
+	// arbitrary size and shape.
+	DynamicAttributes int
+
+	// BlockTypes is the number of nested block types that the message's
+	// body accepts, including those of any flattened messages.
+	BlockTypes int
+
+	// Depth is the deepest level of block nesting that the message's body
+	// can contain, where zero means that it has no nested block types at
+	// all.
+	//
+	// For a recursive schema, Depth counts only the nesting levels before
+	// the schema first refers back to a message type that encloses it.
+	Depth int
+
+	// Recursive is true if the message's body can contain blocks of the
+	// same message type, either directly or indirectly, and so its
+	// configuration can be nested arbitrarily deeply.
+	Recursive bool
+}
+
+// AnalyzeMessageDesc returns a description of the schema complexity of the
+// given message type and of each message type reachable from it through
+// nested block types, with the given message type first and the others in
+// the order they are first reached.
+//
+// This is intended for platforms that accept configuration schemas from
+// plugins and wish to set limits on how complex those schemas can be.
+//
+// Returns an error if any of the messages have invalid HCL annotations.
+func AnalyzeMessageDesc(desc protoreflect.MessageDescriptor) ([]SchemaComplexity, error) {
+	a := &schemaAnalyzer{
+		results: make(map[protoreflect.FullName]*SchemaComplexity),
+		active:  make(map[protoreflect.FullName]struct{}),
+	}
+	if err := a.analyzeMessage(desc); err != nil {
+		return nil, err
+	}
+	ret := make([]SchemaComplexity, len(a.order))
+	for i, name := range a.order {
+		ret[i] = *a.results[name]
+	}
+	return ret, nil
+}
+
+type schemaAnalyzer struct {
+	order   []protoreflect.FullName
+	results map[protoreflect.FullName]*SchemaComplexity
+
+	// stack and active track the messages that are currently being
+	// analyzed, outermost first, so we can detect recursion.
+	stack  []protoreflect.FullName
+	active map[protoreflect.FullName]struct{}
+}
+
+func (a *schemaAnalyzer) analyzeMessage(desc protoreflect.MessageDescriptor) error {
+	name := desc.FullName()
+	if _, exists := a.results[name]; exists {
+		return nil
+	}
+	// Building the body schema detects conflicts between fields, which
+	// would otherwise make the counts below misleading.
+	if _, err := bodySchema(desc); err != nil {
+		return err
+	}
+	result := &SchemaComplexity{Message: name}
+	a.results[name] = result
+	a.order = append(a.order, name)
+	a.stack = append(a.stack, name)
+	a.active[name] = struct{}{}
+	defer func() {
+		a.stack = a.stack[:len(a.stack)-1]
+		delete(a.active, name)
+	}()
+
+	return a.analyzeBody(desc, result)
+}
+
+// analyzeBody adds the attributes and block types of the given message's
+// body to the given result, which is for the given message or for a
+// message that it is flattened into.
+func (a *schemaAnalyzer) analyzeBody(desc protoreflect.MessageDescriptor, result *SchemaComplexity) error {
+	fields := desc.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		elem, err := GetFieldElem(field)
+		if err != nil {
+			return err
+		}
+
+		switch elem := elem.(type) {
+		case FieldAttribute:
+			result.Attributes++
+			ty, diags := elem.TypeConstraint()
+			if diags.HasErrors() {
+				return schemaErrorf(field.FullName(), "invalid type constraint: %s", diags.Error())
+			}
+			if ty.HasDynamicTypes() {
+				result.DynamicAttributes++
+			}
+		case FieldNestedBlockType:
+			result.BlockTypes++
+			nested := elem.Nested
+			if _, recursive := a.active[nested.FullName()]; recursive {
+				a.markRecursive(nested.FullName())
+				continue
+			}
+			if err := a.analyzeMessage(nested); err != nil {
+				return err
+			}
+			nestedResult := a.results[nested.FullName()]
+			if depth := nestedResult.Depth + 1; depth > result.Depth {
+				result.Depth = depth
+			}
+		case FieldFlattened:
+			if err := a.analyzeBody(elem.Nested, result); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// markRecursive records that the given message type, which must be one of
+// the messages currently being analyzed, is recursive.
+//
+// All of the messages that it encloses on the way back to itself are part
+// of the same cycle, so they are all recursive too.
+func (a *schemaAnalyzer) markRecursive(name protoreflect.FullName) {
+	for i := len(a.stack) - 1; i >= 0; i-- {
+		a.results[a.stack[i]].Recursive = true
+		if a.stack[i] == name {
+			break
+		}
+	}
+}
+
+// FieldValueSize describes the size of the value of one field in a decoded
+// message, as returned by MessageValueSizes.
+type FieldValueSize struct {
+	// Path is the path to the field, starting with a protopath.Root step
+	// for the message type that was analyzed. For a field of a nested
+	// block, the path includes the index or key of the block along the
+	// way, in the same way as the paths in a DecodeTrace.
+	Path protopath.Path
+
+	// Size is the number of bytes in the protobuf wire encoding of the
+	// field, including all of the content of its nested messages.
+	Size int
+}
+
+// MessageValueSizes returns the encoded size of each populated attribute and
+// nested block type field in the given message and in the messages of its
+// nested blocks, so that callers can enforce limits on how large a
+// configuration can be, and can report which part of the configuration
+// exceeded a limit.
+//
+// The result has an entry for each populated field in declaration order,
+// with each nested block type's entry followed by the entries for the
+// fields of its blocks. The blocks of a map-typed block type field are in
+// order of their keys.
+//
+// Returns an error if the message type has invalid HCL annotations.
+func MessageValueSizes(msg proto.Message) ([]FieldValueSize, error) {
+	reflMsg := msg.ProtoReflect()
+	var ret []FieldValueSize
+	err := appendMessageValueSizes(reflMsg, protopath.Path{protopath.Root(reflMsg.Descriptor())}, &ret)
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+func appendMessageValueSizes(msg protoreflect.Message, path protopath.Path, into *[]FieldValueSize) error {
+	fields := msg.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		elem, err := GetFieldElem(field)
+		if err != nil {
+			return err
+		}
+		if elem == nil || !msg.Has(field) {
+			continue
+		}
+		fieldPath := appendPath(path, protopath.FieldAccess(field))
+
+		switch elem.(type) {
+		case FieldAttribute:
+			*into = append(*into, FieldValueSize{
+				Path: fieldPath,
+				Size: fieldValueSize(msg, field),
+			})
+		case FieldNestedBlockType:
+			*into = append(*into, FieldValueSize{
+				Path: fieldPath,
+				Size: fieldValueSize(msg, field),
+			})
+			if err := appendBlockValueSizes(msg.Get(field), field, fieldPath, into); err != nil {
+				return err
+			}
+		case FieldFlattened:
+			if err := appendMessageValueSizes(msg.Get(field).Message(), fieldPath, into); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// appendBlockValueSizes appends the sizes of the fields of each of the
+// blocks in the given value of a nested block type field.
+func appendBlockValueSizes(val protoreflect.Value, field protoreflect.FieldDescriptor, path protopath.Path, into *[]FieldValueSize) error {
+	switch {
+	case field.IsList():
+		list := val.List()
+		for i := 0; i < list.Len(); i++ {
+			elemPath := appendPath(path, protopath.ListIndex(i))
+			if err := appendMessageValueSizes(list.Get(i).Message(), elemPath, into); err != nil {
+				return err
+			}
+		}
+	case field.IsMap():
+		m := val.Map()
+		var keys []protoreflect.MapKey
+		m.Range(func(k protoreflect.MapKey, _ protoreflect.Value) bool {
+			keys = append(keys, k)
+			return true
+		})
+		sort.Slice(keys, func(i, j int) bool {
+			return keys[i].String() < keys[j].String()
+		})
+		for _, k := range keys {
+			elemPath := appendPath(path, protopath.MapIndex(k))
+			if err := appendMessageValueSizes(m.Get(k).Message(), elemPath, into); err != nil {
+				return err
+			}
+		}
+	default:
+		return appendMessageValueSizes(val.Message(), path, into)
+	}
+	return nil
+}
+
+// fieldValueSize returns the number of bytes in the wire encoding of just
+// the given field of the given message.
+func fieldValueSize(msg protoreflect.Message, field protoreflect.FieldDescriptor) int {
+	only := msg.Type().New()
+	only.Set(field, msg.Get(field))
+	return proto.Size(only.Interface())
+}
@@ -0,0 +1,148 @@
+package protohcl
+
+import (
+	"testing"
+
+	"github.com/apparentlymart/go-protohcl/protohcl/internal/testschema"
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func TestAnalyzeMessageDesc(t *testing.T) {
+	tests := map[protoreflect.Name][]SchemaComplexity{
+		"WithStringAttr": {
+			{
+				Message:    "hcl.testschema.WithStringAttr",
+				Attributes: 1,
+			},
+		},
+		"WithSameBlockTypeNested": {
+			{
+				Message:    "hcl.testschema.WithSameBlockTypeNested",
+				BlockTypes: 1,
+				Depth:      2,
+			},
+			{
+				Message:    "hcl.testschema.SameBlockTypeOuter",
+				Attributes: 1,
+				BlockTypes: 1,
+				Depth:      1,
+			},
+			{
+				Message:    "hcl.testschema.SameBlockTypeInner",
+				Attributes: 1,
+			},
+		},
+		"WithFlattenStringAttr": {
+			{
+				Message:    "hcl.testschema.WithFlattenStringAttr",
+				Attributes: 2,
+			},
+		},
+		"RecursiveBlock": {
+			{
+				Message:           "hcl.testschema.RecursiveBlock",
+				Attributes:        2,
+				DynamicAttributes: 1,
+				BlockTypes:        1,
+				Recursive:         true,
+			},
+		},
+	}
+
+	for name, want := range tests {
+		t.Run(string(name), func(t *testing.T) {
+			desc := testschema.File_testschema_proto.Messages().ByName(name)
+			got, err := AnalyzeMessageDesc(desc)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("wrong result\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestAnalyzeMessageDescInvalid(t *testing.T) {
+	desc := testschema.File_testschema_proto.Messages().ByName("WithInvalidNestedBlocks")
+	_, err := AnalyzeMessageDesc(desc)
+	if err == nil {
+		t.Fatalf("unexpected success")
+	}
+}
+
+func TestMessageValueSizes(t *testing.T) {
+	tests := map[string]struct {
+		desc   protoreflect.Name
+		config string
+		want   map[string]int
+	}{
+		"empty": {
+			"WithSameBlockTypeNested",
+			``,
+			map[string]int{},
+		},
+		"nested blocks": {
+			"WithSameBlockTypeNested",
+			`
+				item {
+					name = "a"
+					item {
+						size = 2
+					}
+				}
+			`,
+			map[string]int{
+				"(hcl.testschema.WithSameBlockTypeNested).item":           9,
+				"(hcl.testschema.WithSameBlockTypeNested).item.name":      3,
+				"(hcl.testschema.WithSameBlockTypeNested).item.item":      4,
+				"(hcl.testschema.WithSameBlockTypeNested).item.item.size": 2,
+			},
+		},
+		"repeated blocks": {
+			"RecursiveBlock",
+			`
+				child {
+					name = "a"
+				}
+				child {
+					name = "bcd"
+				}
+			`,
+			map[string]int{
+				"(hcl.testschema.RecursiveBlock).child":         12,
+				"(hcl.testschema.RecursiveBlock).child[0].name": 3,
+				"(hcl.testschema.RecursiveBlock).child[1].name": 5,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			f, diags := hclsyntax.ParseConfig([]byte(test.config), "", hcl.InitialPos)
+			if diags.HasErrors() {
+				t.Fatalf("unexpected syntax errors: %s", diags.Error())
+			}
+			desc := testschema.File_testschema_proto.Messages().ByName(test.desc)
+			msg, diags := DecodeBody(f.Body, desc, nil)
+			if diags.HasErrors() {
+				t.Fatalf("unexpected errors: %s", diags.Error())
+			}
+
+			sizes, err := MessageValueSizes(msg)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			got := make(map[string]int, len(sizes))
+			for _, size := range sizes {
+				got[size.Path.String()] = size.Size
+			}
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("wrong result\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package protohcl
+
+import (
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+	"github.com/zclconf/go-ctypb/ctystructpb"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+var structpbStructDesc = structpb.File_google_protobuf_struct_proto.Messages().ByName("Struct")
+var structpbListValueDesc = structpb.File_google_protobuf_struct_proto.Messages().ByName("ListValue")
+
+// isStructpbContainerMessage returns true if the given message type is
+// either google.protobuf.Struct or google.protobuf.ListValue, which an
+// attribute represents as an object and as a list respectively.
+func isStructpbContainerMessage(desc protoreflect.MessageDescriptor) bool {
+	name := desc.FullName()
+	return name == structpbStructDesc.FullName() || name == structpbListValueDesc.FullName()
+}
+
+// structpbContainerAttrMessageBuilder is the strategy for a singular field
+// of type google.protobuf.Struct or google.protobuf.ListValue, which stores
+// an object or list value in the same way as its JSON representation.
+//
+// Unlike for google.protobuf.Value, the stored value doesn't record any type
+// information, and so ObjectValueForMessage infers the type of any part of
+// the value that the type constraint doesn't decide.
+func structpbContainerAttrMessageBuilder(desc protoreflect.FieldDescriptor, msgDesc protoreflect.MessageDescriptor, wantTy cty.Type) (attrMessageBuilder, error) {
+	isStruct := msgDesc.FullName() == structpbStructDesc.FullName()
+	if err := checkStructpbContainerTypeConstraint(desc, isStruct, wantTy); err != nil {
+		return nil, err
+	}
+
+	return func(v cty.Value, path cty.Path, parentMessage protoreflect.Message) (protoreflect.Value, error) {
+		if v.IsNull() {
+			// A null value just leaves the field unset.
+			return nilProtoValue, nil
+		}
+		if !v.IsKnown() {
+			return nilProtoValue, attrValueErrorUnknown(path)
+		}
+		v, err := convert.Convert(v, wantTy)
+		if err != nil {
+			return nilProtoValue, attrValueErrorWrap(path, err)
+		}
+		ty := v.Type()
+		if isStruct && !(ty.IsObjectType() || ty.IsMapType()) {
+			return nilProtoValue, attrValueErrorf(path, "an object or map value is required")
+		}
+		if !isStruct && !(ty.IsListType() || ty.IsSetType() || ty.IsTupleType()) {
+			return nilProtoValue, attrValueErrorf(path, "a list, set, or tuple value is required")
+		}
+		// We encode using the value's own type, rather than the type
+		// constraint, so that any parts of the value that the type
+		// constraint doesn't decide are stored as plain JSON values.
+		sv, err := ctystructpb.ToStructValue(v, ty)
+		if err != nil {
+			return nilProtoValue, attrValueErrorWrap(path, err)
+		}
+		var msg proto.Message = sv.GetListValue()
+		if isStruct {
+			msg = sv.GetStructValue()
+		}
+		return protoreflect.ValueOfMessage(msg.ProtoReflect()), nil
+	}, nil
+}
+
+// checkStructpbContainerTypeConstraint returns a schema error if the given
+// type constraint is not suitable for the given field, whose type is
+// google.protobuf.Struct if isStruct is true, or google.protobuf.ListValue
+// otherwise.
+func checkStructpbContainerTypeConstraint(desc protoreflect.FieldDescriptor, isStruct bool, wantTy cty.Type) error {
+	switch {
+	case wantTy == cty.DynamicPseudoType:
+		return nil
+	case isStruct:
+		if !(wantTy.IsObjectType() || wantTy.IsMapType()) {
+			return schemaErrorf(desc.FullName(), "google.protobuf.Struct field must have object or map type constraint")
+		}
+	default:
+		if !(wantTy.IsListType() || wantTy.IsSetType() || wantTy.IsTupleType()) {
+			return schemaErrorf(desc.FullName(), "google.protobuf.ListValue field must have tuple, list, or set type constraint")
+		}
+	}
+	return nil
+}
+
+// hclValueForStructpbContainer is the opposite of
+// structpbContainerAttrMessageBuilder, returning the value that the given
+// google.protobuf.Struct or google.protobuf.ListValue message represents.
+func hclValueForStructpbContainer(raw protoreflect.Message, path cty.Path, attr FieldAttribute) (cty.Value, error) {
+	wantTy, diags := attr.TypeConstraint()
+	if diags.HasErrors() {
+		return cty.NilVal, schemaErrorf(attr.TargetField.FullName(), "invalid HCL type constraint")
+	}
+	var sv *structpb.Value
+	switch msg := raw.Interface().(type) {
+	case *structpb.Struct:
+		sv = structpb.NewStructValue(msg)
+	case *structpb.ListValue:
+		sv = structpb.NewListValue(msg)
+	default:
+		return cty.NilVal, schemaErrorf(attr.TargetField.FullName(), "dynamic type is not *structpb.Struct or *structpb.ListValue")
+	}
+
+	if !wantTy.HasDynamicTypes() {
+		v, err := ctystructpb.FromStructValue(sv, wantTy)
+		if err != nil {
+			return cty.NilVal, path.NewErrorf("invalid encoding of %s value as %s: %s", wantTy.FriendlyName(), raw.Descriptor().FullName(), err)
+		}
+		return v, nil
+	}
+	// The value doesn't record its type, so we must infer the type of any
+	// part that the type constraint doesn't decide.
+	ty, err := ctystructpb.ImpliedType(sv)
+	if err != nil {
+		return cty.NilVal, path.NewErrorf("invalid %s value: %s", raw.Descriptor().FullName(), err)
+	}
+	v, err := ctystructpb.FromStructValue(sv, ty)
+	if err == nil {
+		v, err = convert.Convert(v, wantTy)
+	}
+	if err != nil {
+		return cty.NilVal, path.NewErrorf("invalid encoding of %s value as %s: %s", wantTy.FriendlyName(), raw.Descriptor().FullName(), err)
+	}
+	return v, nil
+}
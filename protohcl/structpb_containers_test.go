@@ -0,0 +1,190 @@
+package protohcl
+
+import (
+	"testing"
+
+	"github.com/apparentlymart/go-protohcl/protohcl/internal/testschema"
+	"github.com/apparentlymart/go-protohcl/protohcl/protohcltest"
+	"github.com/google/go-cmp/cmp"
+	"github.com/zclconf/go-cty/cty"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestDecodeBodyStructpbContainerAttrs(t *testing.T) {
+	tests := map[string]struct {
+		config    string
+		want      proto.Message
+		wantDiags []protohcltest.ExpectedDiagnostic
+	}{
+		"any element types": {
+			`
+				settings = {
+					name    = "a"
+					count   = 2
+					enabled = true
+					tags    = ["b", "c"]
+					nested  = { value = null }
+				}
+				items = ["a", 1, { b = true }]
+			`,
+			&testschema.WithStructpbContainerAttrs{
+				Settings: mustStructpbStruct(t, map[string]interface{}{
+					"name":    "a",
+					"count":   2,
+					"enabled": true,
+					"tags":    []interface{}{"b", "c"},
+					"nested":  map[string]interface{}{"value": nil},
+				}),
+				Items: mustStructpbList(t, []interface{}{
+					"a", 1, map[string]interface{}{"b": true},
+				}),
+			},
+			nil,
+		},
+		"explicit type constraints": {
+			`
+				labels = {
+					a = "b"
+					c = 1
+				}
+				ports = [80, "443"]
+			`,
+			&testschema.WithStructpbContainerAttrs{
+				Labels: mustStructpbStruct(t, map[string]interface{}{
+					"a": "b",
+					"c": "1",
+				}),
+				Ports: mustStructpbList(t, []interface{}{80, 443}),
+			},
+			nil,
+		},
+		"empty": {
+			`
+				settings = {}
+				items    = []
+			`,
+			&testschema.WithStructpbContainerAttrs{
+				Settings: &structpb.Struct{},
+				Items:    &structpb.ListValue{},
+			},
+			nil,
+		},
+		"null": {
+			`
+				settings = null
+				items    = null
+			`,
+			&testschema.WithStructpbContainerAttrs{},
+			nil,
+		},
+		"struct not an object": {
+			`
+				settings = "a"
+			`,
+			&testschema.WithStructpbContainerAttrs{},
+			[]protohcltest.ExpectedDiagnostic{
+				protohcltest.Error(unsuitableValueSummary).
+					WithDetail(`Inappropriate value for attribute "settings": an object or map value is required.`).
+					OnLine(2),
+			},
+		},
+		"list value not a list": {
+			`
+				items = { a = "b" }
+			`,
+			&testschema.WithStructpbContainerAttrs{},
+			[]protohcltest.ExpectedDiagnostic{
+				protohcltest.Error(unsuitableValueSummary).
+					WithDetail(`Inappropriate value for attribute "items": a list, set, or tuple value is required.`).
+					OnLine(2),
+			},
+		},
+	}
+
+	desc := (&testschema.WithStructpbContainerAttrs{}).ProtoReflect().Descriptor()
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, diags := DecodeBody(parseTestBody(t, test.config), desc, nil)
+			protohcltest.AssertDiagnostics(t, diags, test.wantDiags...)
+			if diff := cmp.Diff(test.want, got, protoCmpOpt); diff != "" {
+				t.Errorf("wrong result\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDecodeBodyStructpbStructWrongType(t *testing.T) {
+	desc := (&testschema.WithStructpbStructWrongType{}).ProtoReflect().Descriptor()
+	_, diags := DecodeBody(parseTestBody(t, `settings = ["a"]`), desc, nil)
+	protohcltest.AssertDiagnostics(t, diags,
+		protohcltest.Error(schemaErrorSummary).
+			WithDetail("Invalid HCL annotations in protobuf schema for hcl.testschema.WithStructpbStructWrongType.settings: google.protobuf.Struct field must have object or map type constraint.\n\nThis is a bug in the component that defined this schema, and not an error in the given configuration."),
+	)
+}
+
+func TestObjectValueForMessageStructpbContainerAttrs(t *testing.T) {
+	msg := &testschema.WithStructpbContainerAttrs{
+		Settings: mustStructpbStruct(t, map[string]interface{}{
+			"name": "a",
+			"tags": []interface{}{"b", 1},
+		}),
+		Labels: mustStructpbStruct(t, map[string]interface{}{
+			"a": "b",
+		}),
+		Ports: mustStructpbList(t, []interface{}{80}),
+	}
+
+	got, err := ObjectValueForMessage(msg)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := cty.ObjectVal(map[string]cty.Value{
+		// The types of values that the type constraint doesn't decide are
+		// inferred from their JSON representations.
+		"settings": cty.ObjectVal(map[string]cty.Value{
+			"name": cty.StringVal("a"),
+			"tags": cty.TupleVal([]cty.Value{
+				cty.StringVal("b"),
+				cty.NumberIntVal(1),
+			}),
+		}),
+		"items": cty.NullVal(cty.DynamicPseudoType),
+		"labels": cty.MapVal(map[string]cty.Value{
+			"a": cty.StringVal("b"),
+		}),
+		"ports": cty.ListVal([]cty.Value{
+			cty.NumberIntVal(80),
+		}),
+	})
+	if !got.RawEquals(want) {
+		t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, want)
+	}
+
+	// The result must decode back into the same message.
+	gotMsg, err := MessageForObjectValue(got, msg.ProtoReflect().Descriptor())
+	if err != nil {
+		t.Fatalf("unexpected error converting back to a message: %s", err)
+	}
+	if diff := cmp.Diff(msg, gotMsg, protoCmpOpt); diff != "" {
+		t.Errorf("wrong round-trip result\n%s", diff)
+	}
+}
+
+func mustStructpbStruct(t *testing.T, v map[string]interface{}) *structpb.Struct {
+	t.Helper()
+	ret, err := structpb.NewStruct(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ret
+}
+
+func mustStructpbList(t *testing.T, v []interface{}) *structpb.ListValue {
+	t.Helper()
+	ret, err := structpb.NewList(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ret
+}
@@ -0,0 +1,137 @@
+package protohcl
+
+import (
+	"testing"
+
+	"github.com/apparentlymart/go-protohcl/protohcl/internal/testschema"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty-debug/ctydebug"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestStructpbNestedRoundTrip(t *testing.T) {
+	desc := testschema.File_testschema_proto.Messages().ByName("WithStructsInNestedMessages")
+	tupleTy := cty.Tuple([]cty.Type{cty.String, cty.Bool})
+	emptyHolder := cty.ObjectVal(map[string]cty.Value{
+		"list":   cty.ListValEmpty(cty.DynamicPseudoType),
+		"map":    cty.MapValEmpty(cty.Number),
+		"single": cty.NullVal(cty.DynamicPseudoType),
+		"tuple":  cty.NullVal(tupleTy),
+	})
+
+	tests := map[string]struct {
+		config     string
+		want       cty.Value
+		wantDetail string
+	}{
+		"empty": {
+			``,
+			cty.ObjectVal(map[string]cty.Value{
+				"by_key": cty.EmptyObjectVal,
+				"list":   cty.EmptyTupleVal,
+			}),
+			``,
+		},
+		"map of messages": {
+			`
+				by_key = {
+				  a = {
+				    list   = [1, 2]
+				    map    = { x = 1 }
+				    single = { y = true }
+				    tuple  = ["a", true]
+				  }
+				}
+			`,
+			cty.ObjectVal(map[string]cty.Value{
+				"by_key": cty.ObjectVal(map[string]cty.Value{
+					"a": cty.ObjectVal(map[string]cty.Value{
+						"list":   cty.ListVal([]cty.Value{cty.NumberIntVal(1), cty.NumberIntVal(2)}),
+						"map":    cty.MapVal(map[string]cty.Value{"x": cty.NumberIntVal(1)}),
+						"single": cty.ObjectVal(map[string]cty.Value{"y": cty.True}),
+						"tuple":  cty.TupleVal([]cty.Value{cty.StringVal("a"), cty.True}),
+					}),
+				}),
+				"list": cty.EmptyTupleVal,
+			}),
+			``,
+		},
+		"list of messages": {
+			`list = [{}, { list = [["a"], ["b", "c"]], single = 5 }]`,
+			cty.ObjectVal(map[string]cty.Value{
+				"by_key": cty.EmptyObjectVal,
+				"list": cty.TupleVal([]cty.Value{
+					emptyHolder,
+					cty.ObjectVal(map[string]cty.Value{
+						"list": cty.ListVal([]cty.Value{
+							cty.ListVal([]cty.Value{cty.StringVal("a")}),
+							cty.ListVal([]cty.Value{cty.StringVal("b"), cty.StringVal("c")}),
+						}),
+						"map":    cty.MapValEmpty(cty.Number),
+						"single": cty.NumberIntVal(5),
+						"tuple":  cty.NullVal(tupleTy),
+					}),
+				}),
+			}),
+			``,
+		},
+		"invalid map element": {
+			`by_key = { a = { map = { x = "nope" } } }`,
+			cty.NilVal,
+			`Inappropriate value for attribute "by_key" at ["a"].map["x"]: a number is required.`,
+		},
+		"invalid tuple element": {
+			`list = [{}, { tuple = ["a", "b"] }]`,
+			cty.NilVal,
+			`Inappropriate value for attribute "list" at [1].tuple[1]: a bool is required.`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			f, diags := hclsyntax.ParseConfig([]byte(test.config), "", hcl.InitialPos)
+			if diags.HasErrors() {
+				t.Fatalf("unexpected syntax errors: %s", diags.Error())
+			}
+
+			msg, diags := DecodeBody(f.Body, desc, nil)
+			if test.wantDetail != "" {
+				if !diags.HasErrors() {
+					t.Fatalf("unexpected success")
+				}
+				if got, want := diags[0].Detail, test.wantDetail; got != want {
+					t.Errorf("wrong error detail\ngot:  %s\nwant: %s", got, want)
+				}
+				if diags[0].Subject == nil {
+					t.Errorf("error has no subject")
+				}
+				return
+			}
+			if diags.HasErrors() {
+				t.Fatalf("unexpected errors: %s", diags.Error())
+			}
+
+			got, err := ObjectValueForMessage(msg)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !got.RawEquals(test.want) {
+				t.Errorf("wrong result\ngot:  %s\nwant: %s", ctydebug.ValueString(got), ctydebug.ValueString(test.want))
+			}
+		})
+	}
+}
+
+func TestObjectValueForMessageUnsetStructpb(t *testing.T) {
+	got, err := ObjectValueForMessage(&testschema.WithStructDynamicAttr{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := cty.ObjectVal(map[string]cty.Value{
+		"struct": cty.NullVal(cty.DynamicPseudoType),
+	})
+	if !got.RawEquals(want) {
+		t.Errorf("wrong result\ngot:  %s\nwant: %s", ctydebug.ValueString(got), ctydebug.ValueString(want))
+	}
+}
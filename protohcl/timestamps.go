@@ -0,0 +1,63 @@
+package protohcl
+
+import (
+	"time"
+
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var timestampDesc = (*timestamppb.Timestamp)(nil).ProtoReflect().Descriptor()
+
+// isTimestampMessage returns true if the given message type is
+// google.protobuf.Timestamp, which an attribute represents as a string in
+// RFC 3339 format.
+func isTimestampMessage(desc protoreflect.MessageDescriptor) bool {
+	return desc.FullName() == timestampDesc.FullName()
+}
+
+// timestampAttrMessageBuilder is the strategy for a singular field of type
+// google.protobuf.Timestamp, which parses a string in RFC 3339 format.
+func timestampAttrMessageBuilder(desc protoreflect.FieldDescriptor) attrMessageBuilder {
+	return func(v cty.Value, path cty.Path, parentMessage protoreflect.Message) (protoreflect.Value, error) {
+		if v.IsNull() {
+			// A null value just leaves the field unset.
+			return nilProtoValue, nil
+		}
+		if !v.IsKnown() {
+			return nilProtoValue, attrValueErrorUnknown(path)
+		}
+		v, err := convert.Convert(v, cty.String)
+		if err != nil {
+			return nilProtoValue, attrValueErrorWrap(path, err)
+		}
+		t, err := time.Parse(time.RFC3339Nano, v.AsString())
+		if err != nil {
+			return nilProtoValue, attrValueErrorf(path, "must be a timestamp in RFC 3339 format, such as \"2006-01-02T15:04:05Z\"")
+		}
+		ts := timestamppb.New(t)
+		if err := ts.CheckValid(); err != nil {
+			return nilProtoValue, attrValueErrorf(path, "must be a timestamp between the years 0001 and 9999")
+		}
+		msg := parentMessage.NewField(desc).Message()
+		fields := msg.Descriptor().Fields()
+		msg.Set(fields.ByName("seconds"), protoreflect.ValueOfInt64(ts.Seconds))
+		msg.Set(fields.ByName("nanos"), protoreflect.ValueOfInt32(ts.Nanos))
+		return protoreflect.ValueOfMessage(msg), nil
+	}
+}
+
+// hclValueForTimestamp returns the RFC 3339 string representation of the
+// given google.protobuf.Timestamp message, always in UTC.
+func hclValueForTimestamp(msg protoreflect.Message, path cty.Path) (cty.Value, error) {
+	fields := msg.Descriptor().Fields()
+	seconds := msg.Get(fields.ByName("seconds")).Int()
+	nanos := msg.Get(fields.ByName("nanos")).Int()
+	ts := &timestamppb.Timestamp{Seconds: seconds, Nanos: int32(nanos)}
+	if err := ts.CheckValid(); err != nil {
+		return cty.NilVal, path.NewErrorf("invalid google.protobuf.Timestamp: %s", err)
+	}
+	return cty.StringVal(ts.AsTime().Format(time.RFC3339Nano)), nil
+}
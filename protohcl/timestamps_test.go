@@ -0,0 +1,114 @@
+package protohcl
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apparentlymart/go-protohcl/protohcl/internal/testschema"
+	"github.com/apparentlymart/go-protohcl/protohcl/protohcltest"
+	"github.com/google/go-cmp/cmp"
+	"github.com/zclconf/go-cty/cty"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestDecodeBodyTimestampAttr(t *testing.T) {
+	tests := map[string]struct {
+		config    string
+		want      proto.Message
+		wantDiags []protohcltest.ExpectedDiagnostic
+	}{
+		"utc": {
+			`created_at = "2021-06-01T12:30:00Z"`,
+			&testschema.WithTimestampAttr{
+				CreatedAt: timestamppb.New(time.Date(2021, 6, 1, 12, 30, 0, 0, time.UTC)),
+			},
+			nil,
+		},
+		"offset and fraction": {
+			`created_at = "2021-06-01T14:30:00.25+02:00"`,
+			&testschema.WithTimestampAttr{
+				CreatedAt: timestamppb.New(time.Date(2021, 6, 1, 12, 30, 0, 250000000, time.UTC)),
+			},
+			nil,
+		},
+		"null": {
+			`created_at = null`,
+			&testschema.WithTimestampAttr{},
+			nil,
+		},
+		"invalid syntax": {
+			`
+				created_at = "2021-06-01 12:30"
+			`,
+			&testschema.WithTimestampAttr{},
+			[]protohcltest.ExpectedDiagnostic{
+				protohcltest.Error(unsuitableValueSummary).
+					WithDetail(`Inappropriate value for attribute "created_at": must be a timestamp in RFC 3339 format, such as "2006-01-02T15:04:05Z".`).
+					OnLine(2),
+			},
+		},
+		"wrong type": {
+			`created_at = ["2021-06-01T12:30:00Z"]`,
+			&testschema.WithTimestampAttr{},
+			[]protohcltest.ExpectedDiagnostic{
+				protohcltest.Error(unsuitableValueSummary).
+					WithDetail(`Inappropriate value for attribute "created_at": string required.`),
+			},
+		},
+	}
+
+	desc := (&testschema.WithTimestampAttr{}).ProtoReflect().Descriptor()
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, diags := DecodeBody(parseTestBody(t, test.config), desc, nil)
+			protohcltest.AssertDiagnostics(t, diags, test.wantDiags...)
+			if diff := cmp.Diff(test.want, got, protoCmpOpt); diff != "" {
+				t.Errorf("wrong result\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestObjectValueForMessageTimestampAttr(t *testing.T) {
+	tests := map[string]struct {
+		msg  proto.Message
+		want cty.Value
+	}{
+		"set": {
+			&testschema.WithTimestampAttr{
+				CreatedAt: timestamppb.New(time.Date(2021, 6, 1, 12, 30, 0, 250000000, time.UTC)),
+			},
+			cty.ObjectVal(map[string]cty.Value{
+				"created_at": cty.StringVal("2021-06-01T12:30:00.25Z"),
+			}),
+		},
+		"unset": {
+			&testschema.WithTimestampAttr{},
+			cty.ObjectVal(map[string]cty.Value{
+				"created_at": cty.NullVal(cty.String),
+			}),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := ObjectValueForMessage(test.msg)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !got.RawEquals(test.want) {
+				t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, test.want)
+			}
+
+			// The result must decode back into the same message.
+			msg, err := MessageForObjectValue(got, test.msg.ProtoReflect().Descriptor())
+			if err != nil {
+				t.Fatalf("unexpected error converting back to a message: %s", err)
+			}
+			if diff := cmp.Diff(test.msg, msg, protoCmpOpt); diff != "" {
+				t.Errorf("wrong round-trip result\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,200 @@
+package protohcl
+
+import (
+	"sort"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protopath"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// DecodeTrace records which part of the source configuration populated each
+// of the fields of a message produced by DecodeBodyWithTrace.
+//
+// A caller can use a trace to attribute problems detected in a decoded
+// message, such as by some later validation step, back to the part of the
+// configuration that caused them.
+type DecodeTrace struct {
+	paths     []protopath.Path
+	ranges    map[string]hcl.Range
+	variables map[string][]string
+
+	// failed is the set of path strings of the fields that diagnostics
+	// relate to, which DecodeBodyIncremental must always decode again.
+	failed map[string]struct{}
+}
+
+// DecodeBodyWithTrace is like DecodeBody but also returns a trace recording
+// the source range that populated each of the fields in the result.
+func DecodeBodyWithTrace(body hcl.Body, desc protoreflect.MessageDescriptor, ctx *hcl.EvalContext) (proto.Message, *DecodeTrace, hcl.Diagnostics) {
+	return DecodeOptions{}.DecodeBodyWithTrace(body, desc, ctx)
+}
+
+func newDecodeTrace() *DecodeTrace {
+	return &DecodeTrace{
+		ranges:    make(map[string]hcl.Range),
+		variables: make(map[string][]string),
+		failed:    make(map[string]struct{}),
+	}
+}
+
+// Range returns the source range that populated the value at the given path,
+// which must start with a protopath.Root step for the message type that was
+// decoded.
+//
+// For a field populated from an attribute, the range is of the attribute's
+// expression, or of the relevant element of that expression when the path
+// refers to a list element or map entry. For a field populated from a nested
+// block, the range is of the block's header.
+//
+// The second return value is false if the trace has no record of the given
+// path, in which case the returned range is meaningless.
+func (t *DecodeTrace) Range(path protopath.Path) (hcl.Range, bool) {
+	if t == nil {
+		return hcl.Range{}, false
+	}
+	rng, ok := t.ranges[path.String()]
+	return rng, ok
+}
+
+// Variables returns the names of the root variables from the evaluation
+// context that were referenced by the expression that populated the field at
+// the given path, in lexical order.
+//
+// This allows a caller to determine which fields were derived from which
+// variables, such as to detect fields whose values might have been derived
+// from sensitive information. Only fields populated from attributes have
+// variables recorded, and so the result is always empty for paths to fields
+// populated from nested blocks, for which the caller should instead check
+// the paths of the nested fields. Paths to individual list elements or map
+// entries also have no variables recorded, because the trace records the
+// references of the whole attribute expression.
+func (t *DecodeTrace) Variables(path protopath.Path) []string {
+	if t == nil {
+		return nil
+	}
+	names := t.variables[path.String()]
+	if len(names) == 0 {
+		return nil
+	}
+	ret := make([]string, len(names))
+	copy(ret, names)
+	return ret
+}
+
+// Paths returns all of the paths that the trace has a record of, in the
+// order they were populated during decoding.
+func (t *DecodeTrace) Paths() []protopath.Path {
+	if t == nil {
+		return nil
+	}
+	ret := make([]protopath.Path, len(t.paths))
+	copy(ret, t.paths)
+	return ret
+}
+
+// DiagnosticFieldPath returns the path of the field that the given
+// diagnostic relates to, if it was returned by DecodeBodyWithTrace and it
+// relates to a specific field.
+//
+// The path is recorded in the diagnostic's Extra field, and so it survives
+// copying the diagnostic but not replacing its Extra value.
+func DiagnosticFieldPath(diag *hcl.Diagnostic) (protopath.Path, bool) {
+	if diag == nil {
+		return nil, false
+	}
+	extra, ok := hcl.DiagnosticExtra[diagnosticExtra](diag)
+	if !ok || len(extra.FieldPath) == 0 {
+		return nil, false
+	}
+	return extra.FieldPath, true
+}
+
+// recordDiagnostics saves the given path as the field path for each of the
+// given diagnostics, except those that already have a path recorded. The
+// decoder calls this as it returns from each field, so the innermost field
+// that a diagnostic relates to takes priority.
+//
+// A diagnostic that protohcl didn't produce gets a new Extra value that
+// wraps its original one, so that hcl.DiagnosticExtra can still find it.
+func (t *DecodeTrace) recordDiagnostics(path protopath.Path, diags hcl.Diagnostics) {
+	if t == nil || len(path) == 0 {
+		return
+	}
+	for _, diag := range diags {
+		extra, ok := diag.Extra.(diagnosticExtra)
+		if !ok {
+			extra = diagnosticExtra{
+				Category: DiagnosticCategoryOf(diag),
+				Wrapped:  diag.Extra,
+			}
+		}
+		if len(extra.FieldPath) == 0 {
+			extra.FieldPath = path
+			diag.Extra = extra
+			t.failed[path.String()] = struct{}{}
+		}
+	}
+}
+
+// record saves the given range for the given path. It does nothing at all if
+// called on a nil trace, so the decoder can call it unconditionally.
+func (t *DecodeTrace) record(path protopath.Path, rng hcl.Range) {
+	if t == nil {
+		return
+	}
+	key := path.String()
+	if _, exists := t.ranges[key]; !exists {
+		t.paths = append(t.paths, path)
+	}
+	t.ranges[key] = rng
+}
+
+// recordVariables saves the names of the root variables that the given
+// expression refers to as the variables for the given path.
+func (t *DecodeTrace) recordVariables(path protopath.Path, expr hcl.Expression) {
+	if t == nil {
+		return
+	}
+	seen := make(map[string]struct{})
+	var names []string
+	for _, traversal := range expr.Variables() {
+		name := traversal.RootName()
+		if _, exists := seen[name]; exists {
+			continue
+		}
+		seen[name] = struct{}{}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	t.variables[path.String()] = names
+}
+
+// recordFieldElems saves the ranges of the individual elements of the given
+// list or map field, using the given element source ranges.
+func (t *DecodeTrace) recordFieldElems(path protopath.Path, msg protoreflect.Message, field protoreflect.FieldDescriptor, rngs valueSourceRanges) {
+	if t == nil {
+		return
+	}
+	switch {
+	case field.IsList():
+		list := msg.Get(field).List()
+		for i := 0; i < list.Len(); i++ {
+			t.record(appendPath(path, protopath.ListIndex(i)), rngs.Elem(i))
+		}
+	case field.IsMap():
+		msg.Get(field).Map().Range(func(k protoreflect.MapKey, _ protoreflect.Value) bool {
+			t.record(appendPath(path, protopath.MapIndex(k)), rngs.MapElem(k.String()))
+			return true
+		})
+	}
+}
+
+// appendPath returns a new path that has the given step appended to the
+// given path, without modifying the backing array of the given path.
+func appendPath(path protopath.Path, step protopath.Step) protopath.Path {
+	ret := make(protopath.Path, len(path), len(path)+1)
+	copy(ret, path)
+	return append(ret, step)
+}
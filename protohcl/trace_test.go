@@ -0,0 +1,203 @@
+package protohcl
+
+import (
+	"testing"
+
+	"github.com/apparentlymart/go-protohcl/protohcl/internal/testschema"
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+	"google.golang.org/protobuf/reflect/protopath"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func TestDecodeBodyWithTrace(t *testing.T) {
+	fileDesc := testschema.File_testschema_proto
+
+	tests := map[string]struct {
+		config string
+		desc   protoreflect.MessageDescriptor
+		want   map[string]string
+	}{
+		"list attribute": {
+			`nums = [1, 2]`,
+			fileDesc.Messages().ByName(protoreflect.Name("WithNumberListAttrAsInt32")),
+			map[string]string{
+				`(hcl.testschema.WithNumberListAttrAsInt32).nums`:    `test.tf:1,8-14`,
+				`(hcl.testschema.WithNumberListAttrAsInt32).nums[0]`: `test.tf:1,9-10`,
+				`(hcl.testschema.WithNumberListAttrAsInt32).nums[1]`: `test.tf:1,12-13`,
+			},
+		},
+		"map attribute": {
+			`nums = { a = 1 }`,
+			fileDesc.Messages().ByName(protoreflect.Name("WithNumberMapAttrAsInt32")),
+			map[string]string{
+				`(hcl.testschema.WithNumberMapAttrAsInt32).nums`:      `test.tf:1,8-17`,
+				`(hcl.testschema.WithNumberMapAttrAsInt32).nums["a"]`: `test.tf:1,14-15`,
+			},
+		},
+		"nested blocks with flattened label": {
+			`
+doodad "bird" "Jackson" {
+  species = "budgerigar"
+}
+doodad "bird" "Snakob" {
+  nickname = "sneaky"
+}
+`,
+			fileDesc.Messages().ByName(protoreflect.Name("WithNestedBlockFlattenedLabels")),
+			map[string]string{
+				`(hcl.testschema.WithNestedBlockFlattenedLabels).doodad[0]`:               `test.tf:2,1-24`,
+				`(hcl.testschema.WithNestedBlockFlattenedLabels).doodad[0].type`:          `test.tf:2,8-14`,
+				`(hcl.testschema.WithNestedBlockFlattenedLabels).doodad[0].base.name`:     `test.tf:2,15-24`,
+				`(hcl.testschema.WithNestedBlockFlattenedLabels).doodad[0].species`:       `test.tf:3,13-25`,
+				`(hcl.testschema.WithNestedBlockFlattenedLabels).doodad[1]`:               `test.tf:5,1-23`,
+				`(hcl.testschema.WithNestedBlockFlattenedLabels).doodad[1].type`:          `test.tf:5,8-14`,
+				`(hcl.testschema.WithNestedBlockFlattenedLabels).doodad[1].base.name`:     `test.tf:5,15-23`,
+				`(hcl.testschema.WithNestedBlockFlattenedLabels).doodad[1].base.nickname`: `test.tf:6,14-22`,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			f, diags := hclsyntax.ParseConfig([]byte(test.config), "test.tf", hcl.InitialPos)
+			if diags.HasErrors() {
+				t.Fatalf("parse error: %s", diags)
+			}
+
+			_, trace, diags := DecodeBodyWithTrace(f.Body, test.desc, nil)
+			if diags.HasErrors() {
+				t.Fatalf("unexpected errors: %s", diags)
+			}
+
+			got := make(map[string]string)
+			for _, path := range trace.Paths() {
+				rng, ok := trace.Range(path)
+				if !ok {
+					t.Errorf("no range for %s, though it was returned by Paths", path)
+					continue
+				}
+				got[path.String()] = rng.String()
+			}
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("wrong trace\n%s", diff)
+			}
+		})
+	}
+
+	t.Run("unpopulated field", func(t *testing.T) {
+		desc := fileDesc.Messages().ByName(protoreflect.Name("WithStringAttr"))
+		f, diags := hclsyntax.ParseConfig(nil, "test.tf", hcl.InitialPos)
+		if diags.HasErrors() {
+			t.Fatalf("parse error: %s", diags)
+		}
+
+		_, trace, diags := DecodeBodyWithTrace(f.Body, desc, nil)
+		if diags.HasErrors() {
+			t.Fatalf("unexpected errors: %s", diags)
+		}
+
+		path := protopath.Path{
+			protopath.Root(desc),
+			protopath.FieldAccess(desc.Fields().ByName("name")),
+		}
+		if rng, ok := trace.Range(path); ok {
+			t.Errorf("unexpected range %s for %s", rng, path)
+		}
+	})
+}
+
+func TestDecodeTraceVariables(t *testing.T) {
+	desc := testschema.File_testschema_proto.Messages().ByName(protoreflect.Name("WithNestedBlockFlattenedLabels"))
+	f, diags := hclsyntax.ParseConfig([]byte(`
+doodad "bird" "Jackson" {
+  nickname = "${secrets.nick}-${var.suffix}-${secrets.other}"
+  species  = [for s in var.species : s][0]
+}
+doodad "bird" "Snakob" {
+  nickname = "sneaky"
+}
+`), "test.tf", hcl.InitialPos)
+	if diags.HasErrors() {
+		t.Fatalf("parse error: %s", diags)
+	}
+	ctx := &hcl.EvalContext{
+		Variables: map[string]cty.Value{
+			"secrets": cty.ObjectVal(map[string]cty.Value{
+				"nick":  cty.StringVal("doofus"),
+				"other": cty.StringVal("etc"),
+			}),
+			"var": cty.ObjectVal(map[string]cty.Value{
+				"suffix":  cty.StringVal("1"),
+				"species": cty.TupleVal([]cty.Value{cty.StringVal("budgerigar")}),
+			}),
+		},
+	}
+
+	_, trace, diags := DecodeBodyWithTrace(f.Body, desc, ctx)
+	if diags.HasErrors() {
+		t.Fatalf("unexpected errors: %s", diags)
+	}
+
+	got := make(map[string][]string)
+	for _, path := range trace.Paths() {
+		if names := trace.Variables(path); len(names) != 0 {
+			got[path.String()] = names
+		}
+	}
+	want := map[string][]string{
+		`(hcl.testschema.WithNestedBlockFlattenedLabels).doodad[0].base.nickname`: {"secrets", "var"},
+		// The for expression's own symbol "s" is not a root variable.
+		`(hcl.testschema.WithNestedBlockFlattenedLabels).doodad[0].species`: {"var"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("wrong variables\n%s", diff)
+	}
+}
+
+func TestDiagnosticFieldPath(t *testing.T) {
+	desc := testschema.File_testschema_proto.Messages().ByName(protoreflect.Name("WithNestedBlockOneLabelSingleton"))
+	f, diags := hclsyntax.ParseConfig([]byte(`
+doodad "Jackson" {
+  nickname = ["doofus"]
+  extra    = true
+}
+`), "test.tf", hcl.InitialPos)
+	if diags.HasErrors() {
+		t.Fatalf("parse error: %s", diags)
+	}
+
+	_, _, diags = DecodeBodyWithTrace(f.Body, desc, nil)
+	type result struct {
+		Path     string
+		Category DiagnosticCategory
+	}
+	var got []result
+	for _, diag := range diags {
+		// The path must survive copying the diagnostic.
+		copied := *diag
+		path, ok := DiagnosticFieldPath(&copied)
+		if !ok {
+			t.Errorf("no field path for %q", diag.Summary)
+			continue
+		}
+		got = append(got, result{path.String(), DiagnosticCategoryOf(&copied)})
+	}
+	want := []result{
+		{`(hcl.testschema.WithNestedBlockOneLabelSingleton).doodad.nickname`, DiagnosticValueError},
+		// HCL's own diagnostic keeps its category when we record its path.
+		{`(hcl.testschema.WithNestedBlockOneLabelSingleton).doodad`, DiagnosticUnsupported},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("wrong results\n%s", diff)
+	}
+
+	_, diags = DecodeBody(f.Body, desc, nil)
+	for _, diag := range diags {
+		if path, ok := DiagnosticFieldPath(diag); ok {
+			t.Errorf("unexpected field path %s for %q without a trace", path, diag.Summary)
+		}
+	}
+}
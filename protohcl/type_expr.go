@@ -0,0 +1,218 @@
+package protohcl
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/ext/typeexpr"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// msgTypeConstructor is the name of the type constructor that refers to the
+// attribute object type constraint of another annotated message type, as in
+// msg("example.Thing").
+const msgTypeConstructor = "msg"
+
+const invalidTypeSummary = "Invalid type specification"
+
+// typeConstraintForExpr interprets the given expression as a type constraint
+// for an attribute of the given field.
+//
+// This accepts everything that typeexpr.TypeConstraint accepts, and also
+// the msg("full.Name") constructor, which stands for the object type
+// constraint of the named message type. The named message type must be
+// declared either in the field's own file or in a file that it imports,
+// directly or indirectly.
+//
+// The "visiting" map has the same meaning as for
+// attrObjectTypeConstraintForMessageDesc, so that a message type that refers
+// to itself through msg is reported as recursive.
+func typeConstraintForExpr(expr hcl.Expression, field protoreflect.FieldDescriptor, visiting map[protoreflect.FullName]struct{}) (cty.Type, hcl.Diagnostics) {
+	if !exprUsesMsgType(expr) {
+		// Nothing for us to do, so we'll let typeexpr handle it in the
+		// usual way.
+		return typeexpr.TypeConstraint(expr)
+	}
+	return msgTypeConstraint(expr, field, visiting)
+}
+
+// exprUsesMsgType returns true if the given expression includes a call to
+// the msg type constructor anywhere within it.
+func exprUsesMsgType(expr hcl.Expression) bool {
+	syntaxExpr, ok := expr.(hclsyntax.Expression)
+	if !ok {
+		return false
+	}
+	found := false
+	hclsyntax.VisitAll(syntaxExpr, func(node hclsyntax.Node) hcl.Diagnostics {
+		if call, ok := node.(*hclsyntax.FunctionCallExpr); ok && call.Name == msgTypeConstructor {
+			found = true
+		}
+		return nil
+	})
+	return found
+}
+
+// msgTypeConstraint is our own variant of typeexpr.TypeConstraint which
+// also understands the msg type constructor. Its treatment of everything
+// else matches typeexpr.TypeConstraint, including the diagnostic messages.
+func msgTypeConstraint(expr hcl.Expression, field protoreflect.FieldDescriptor, visiting map[protoreflect.FullName]struct{}) (cty.Type, hcl.Diagnostics) {
+	switch kw := hcl.ExprAsKeyword(expr); kw {
+	case "bool":
+		return cty.Bool, nil
+	case "string":
+		return cty.String, nil
+	case "number":
+		return cty.Number, nil
+	case "any":
+		return cty.DynamicPseudoType, nil
+	case "":
+		// We'll try processing it as a call instead, below.
+	default:
+		// Anything else that looks like a keyword, including a type
+		// constructor name without any arguments, is invalid in the
+		// same way as it would be for typeexpr.
+		return typeexpr.TypeConstraint(expr)
+	}
+
+	call, diags := hcl.ExprCall(expr)
+	if diags.HasErrors() {
+		return typeexpr.TypeConstraint(expr)
+	}
+
+	switch call.Name {
+	case "list", "set", "map", "object", "tuple", msgTypeConstructor:
+		if len(call.Arguments) != 1 {
+			if call.Name == msgTypeConstructor {
+				return cty.DynamicPseudoType, hcl.Diagnostics{{
+					Severity: hcl.DiagError,
+					Summary:  invalidTypeSummary,
+					Detail:   "The msg type constructor requires one argument specifying the full name of a message type.",
+					Subject:  &call.ArgsRange,
+				}}
+			}
+			return typeexpr.TypeConstraint(expr)
+		}
+	default:
+		return typeexpr.TypeConstraint(expr)
+	}
+
+	switch call.Name {
+	case "list":
+		ety, diags := msgTypeConstraint(call.Arguments[0], field, visiting)
+		return cty.List(ety), diags
+	case "set":
+		ety, diags := msgTypeConstraint(call.Arguments[0], field, visiting)
+		return cty.Set(ety), diags
+	case "map":
+		ety, diags := msgTypeConstraint(call.Arguments[0], field, visiting)
+		return cty.Map(ety), diags
+	case "object":
+		attrDefs, diags := hcl.ExprMap(call.Arguments[0])
+		if diags.HasErrors() {
+			return typeexpr.TypeConstraint(expr)
+		}
+		atys := make(map[string]cty.Type)
+		for _, attrDef := range attrDefs {
+			attrName := hcl.ExprAsKeyword(attrDef.Key)
+			if attrName == "" {
+				diags = append(diags, &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  invalidTypeSummary,
+					Detail:   "Object constructor map keys must be attribute names.",
+					Subject:  attrDef.Key.Range().Ptr(),
+					Context:  expr.Range().Ptr(),
+				})
+				continue
+			}
+			aty, attrDiags := msgTypeConstraint(attrDef.Value, field, visiting)
+			diags = append(diags, attrDiags...)
+			atys[attrName] = aty
+		}
+		return cty.Object(atys), diags
+	case "tuple":
+		elemDefs, diags := hcl.ExprList(call.Arguments[0])
+		if diags.HasErrors() {
+			return typeexpr.TypeConstraint(expr)
+		}
+		etys := make([]cty.Type, len(elemDefs))
+		for i, defExpr := range elemDefs {
+			ety, elemDiags := msgTypeConstraint(defExpr, field, visiting)
+			diags = append(diags, elemDiags...)
+			etys[i] = ety
+		}
+		return cty.Tuple(etys), diags
+	default: // msgTypeConstructor
+		return msgTypeConstraintForCall(call, field, visiting)
+	}
+}
+
+func msgTypeConstraintForCall(call *hcl.StaticCall, field protoreflect.FieldDescriptor, visiting map[protoreflect.FullName]struct{}) (cty.Type, hcl.Diagnostics) {
+	argExpr := call.Arguments[0]
+	nameVal, valDiags := argExpr.Value(nil)
+	if valDiags.HasErrors() || nameVal.IsNull() || !nameVal.IsKnown() || nameVal.Type() != cty.String {
+		return cty.DynamicPseudoType, hcl.Diagnostics{{
+			Severity: hcl.DiagError,
+			Summary:  invalidTypeSummary,
+			Detail:   "The msg type constructor requires a quoted string giving the full name of a message type.",
+			Subject:  argExpr.Range().Ptr(),
+		}}
+	}
+
+	name := protoreflect.FullName(nameVal.AsString())
+	desc := findMessageDescInFileImports(field.ParentFile(), name, make(map[string]struct{}))
+	if desc == nil {
+		return cty.DynamicPseudoType, hcl.Diagnostics{{
+			Severity: hcl.DiagError,
+			Summary:  invalidTypeSummary,
+			Detail:   fmt.Sprintf("There is no message type named %q in the file that declares %s or in any of the files it imports.", name, field.FullName()),
+			Subject:  argExpr.Range().Ptr(),
+		}}
+	}
+
+	if _, exists := visiting[desc.FullName()]; exists || desc.FullName() == field.ContainingMessage().FullName() {
+		return cty.DynamicPseudoType, hcl.Diagnostics{{
+			Severity: hcl.DiagError,
+			Summary:  invalidTypeSummary,
+			Detail:   fmt.Sprintf("Message type %s refers to itself, so it can't be used as an attribute type.", name),
+			Subject:  argExpr.Range().Ptr(),
+		}}
+	}
+
+	ty, err := attrObjectTypeConstraintForMessageDesc(desc, visiting)
+	if err != nil {
+		return cty.DynamicPseudoType, hcl.Diagnostics{{
+			Severity: hcl.DiagError,
+			Summary:  invalidTypeSummary,
+			Detail:   fmt.Sprintf("Message type %s can't be used as an attribute type: %s.", name, err),
+			Subject:  argExpr.Range().Ptr(),
+		}}
+	}
+	return ty, nil
+}
+
+// findMessageDescInFileImports searches for a message type of the given
+// name in the given file and in all of the files that it transitively
+// imports, returning nil if there is no such message type.
+func findMessageDescInFileImports(file protoreflect.FileDescriptor, name protoreflect.FullName, seen map[string]struct{}) protoreflect.MessageDescriptor {
+	if file == nil {
+		return nil
+	}
+	if _, exists := seen[file.Path()]; exists {
+		return nil
+	}
+	seen[file.Path()] = struct{}{}
+
+	if desc := findMessageDesc(file.Messages(), name); desc != nil {
+		return desc
+	}
+	imports := file.Imports()
+	for i := 0; i < imports.Len(); i++ {
+		if desc := findMessageDescInFileImports(imports.Get(i).FileDescriptor, name, seen); desc != nil {
+			return desc
+		}
+	}
+	return nil
+}
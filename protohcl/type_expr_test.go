@@ -0,0 +1,148 @@
+package protohcl
+
+import (
+	"testing"
+
+	"github.com/apparentlymart/go-protohcl/protohcl/internal/testschema"
+	"github.com/google/go-cmp/cmp"
+	"github.com/zclconf/go-cty-debug/ctydebug"
+	"github.com/zclconf/go-cty/cty"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func TestMsgTypeConstraint(t *testing.T) {
+	settingsTy := cty.ObjectWithOptionalAttrs(map[string]cty.Type{
+		"name":  cty.String,
+		"count": cty.Number,
+	}, []string{"count"})
+
+	tests := map[string]struct {
+		field protoreflect.Name
+		want  cty.Type
+	}{
+		"items": {
+			field: "items",
+			want:  cty.List(settingsTy),
+		},
+		"by_key": {
+			field: "by_key",
+			want:  cty.Map(settingsTy),
+		},
+		"nested in object": {
+			field: "raw",
+			want: cty.Object(map[string]cty.Type{
+				"settings": settingsTy,
+				"extra":    cty.DynamicPseudoType,
+			}),
+		},
+	}
+
+	desc := testschema.File_testschema_proto.Messages().ByName("WithMsgTypeConstraints")
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			elem, err := GetFieldElem(desc.Fields().ByName(test.field))
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			got, diags := elem.(FieldAttribute).TypeConstraint()
+			if diags.HasErrors() {
+				t.Fatalf("unexpected errors: %s", diags.Error())
+			}
+			if diff := cmp.Diff(test.want, got, ctydebug.CmpOptions); diff != "" {
+				t.Errorf("wrong result\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMsgTypeConstraintErrors(t *testing.T) {
+	tests := map[string]struct {
+		msgName    protoreflect.Name
+		typeExpr   string
+		wantDetail string
+	}{
+		"recursive": {
+			msgName:    "WithRecursiveMsgTypeConstraint",
+			wantDetail: `Message type hcl.testschema.WithRecursiveMsgTypeConstraint refers to itself, so it can't be used as an attribute type.`,
+		},
+		"unknown": {
+			msgName:    "WithUnknownMsgTypeConstraint",
+			wantDetail: `There is no message type named "hcl.testschema.DoesNotExist" in the file that declares hcl.testschema.WithUnknownMsgTypeConstraint.raw or in any of the files it imports.`,
+		},
+		"no arguments": {
+			msgName:    "WithUnknownMsgTypeConstraint",
+			typeExpr:   `list(msg())`,
+			wantDetail: `The msg type constructor requires one argument specifying the full name of a message type.`,
+		},
+		"not a string": {
+			msgName:    "WithUnknownMsgTypeConstraint",
+			typeExpr:   `msg(WithOptionalAttrs)`,
+			wantDetail: `The msg type constructor requires a quoted string giving the full name of a message type.`,
+		},
+		"other errors as usual": {
+			msgName:    "WithUnknownMsgTypeConstraint",
+			typeExpr:   `object({settings = msg("hcl.testschema.WithOptionalAttrs"), extra = list})`,
+			wantDetail: `The list type constructor requires one argument specifying the element type.`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			desc := testschema.File_testschema_proto.Messages().ByName(test.msgName)
+			elem, err := GetFieldElem(desc.Fields().Get(0))
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			attr := elem.(FieldAttribute)
+			if test.typeExpr != "" {
+				attr.TypeExprString = test.typeExpr
+			}
+
+			_, diags := attr.TypeConstraint()
+			if !diags.HasErrors() {
+				t.Fatalf("unexpected success")
+			}
+			if got := diags[0].Detail; got != test.wantDetail {
+				t.Errorf("wrong error\ngot:  %s\nwant: %s", got, test.wantDetail)
+			}
+
+			problems := ValidateMessageDesc(desc)
+			if len(problems) == 0 && test.typeExpr == "" {
+				t.Errorf("ValidateMessageDesc reported no problems")
+			}
+		})
+	}
+}
+
+func TestDecodeBodyMsgTypeConstraint(t *testing.T) {
+	desc := testschema.File_testschema_proto.Messages().ByName("WithMsgTypeConstraints")
+	body := parseTestBody(t, `
+		items  = [{ name = "a" }, { name = "b", count = 2 }]
+		by_key = { x = { name = "c" } }
+	`)
+
+	got, diags := DecodeBody(body, desc, nil)
+	if diags.HasErrors() {
+		t.Fatalf("unexpected errors: %s", diags.Error())
+	}
+	want := &testschema.WithMsgTypeConstraints{
+		Items: []*testschema.WithOptionalAttrs{
+			{Name: "a"},
+			{Name: "b", Count: 2},
+		},
+		ByKey: map[string]*testschema.WithOptionalAttrs{
+			"x": {Name: "c"},
+		},
+	}
+	if diff := cmp.Diff(want, got, protoCmpOpt); diff != "" {
+		t.Errorf("wrong result\n%s", diff)
+	}
+
+	body = parseTestBody(t, `
+		items = [{ count = 2 }]
+	`)
+	_, diags = DecodeBody(body, desc, nil)
+	if !diags.HasErrors() {
+		t.Fatalf("unexpected success for item without required name")
+	}
+}
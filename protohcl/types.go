@@ -1,9 +1,11 @@
 package protohcl
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/apparentlymart/go-protohcl/protohcl/protohclext"
+	"github.com/hashicorp/hcl/v2"
 	"github.com/zclconf/go-cty/cty"
 	"google.golang.org/protobuf/reflect/protoreflect"
 )
@@ -21,15 +23,51 @@ import (
 // useful to validate that a particular message descriptor is suitable for
 // conversion to a HCL objects.
 func ObjectTypeConstraintForMessageDesc(desc protoreflect.MessageDescriptor) (cty.Type, error) {
+	return ObjectValueOptions{}.ObjectTypeConstraintForMessageDesc(desc)
+}
+
+// ObjectTypeConstraintForMessageDesc returns the type constraint which all
+// results of the receiver's ObjectValueForMessage method for messages of
+// the given descriptor will conform to.
+//
+// See the package-level function ObjectTypeConstraintForMessageDesc for
+// more information.
+func (opts ObjectValueOptions) ObjectTypeConstraintForMessageDesc(desc protoreflect.MessageDescriptor) (cty.Type, error) {
+	return opts.objectTypeConstraintForMessageDesc(desc, nil)
+}
+
+// errRecursiveBlockType is the error that ObjectTypeConstraintForMessageDesc
+// wraps when a message type contains itself as a nested block type, at any
+// depth. The decoder supports such a schema, but no HCL type can represent
+// all of the objects that would result from it.
+var errRecursiveBlockType = errors.New("recursive block type has no corresponding HCL object type")
+
+// objectTypeConstraintForMessageDesc is the main implementation of
+// ObjectTypeConstraintForMessageDesc.
+//
+// The "visiting" map tracks the message types whose type constraints we are
+// already building, in the same way as for
+// attrObjectTypeConstraintForMessageDesc. It may be nil if we're not already
+// building a type constraint for an enclosing message.
+func (opts ObjectValueOptions) objectTypeConstraintForMessageDesc(desc protoreflect.MessageDescriptor, visiting map[protoreflect.FullName]struct{}) (cty.Type, error) {
+	if _, exists := visiting[desc.FullName()]; exists {
+		return cty.NilType, schemaErrorf(desc.FullName(), "%w", errRecursiveBlockType)
+	}
+	if visiting == nil {
+		visiting = make(map[protoreflect.FullName]struct{})
+	}
+	visiting[desc.FullName()] = struct{}{}
+	defer delete(visiting, desc.FullName())
+
 	atys := make(map[string]cty.Type)
-	err := buildObjectTypeAtysForMessageDesc(desc, atys)
+	err := opts.buildObjectTypeAtysForMessageDesc(desc, FieldFlattened{}, visiting, atys)
 	if err != nil {
 		return cty.NilType, err
 	}
 	return cty.Object(atys), nil
 }
 
-func buildObjectTypeAtysForMessageDesc(desc protoreflect.MessageDescriptor, atys map[string]cty.Type) error {
+func (opts ObjectValueOptions) buildObjectTypeAtysForMessageDesc(desc protoreflect.MessageDescriptor, outer FieldFlattened, visiting map[protoreflect.FullName]struct{}, atys map[string]cty.Type) error {
 	fields := desc.Fields()
 
 	for i := 0; i < fields.Len(); i++ {
@@ -39,6 +77,7 @@ func buildObjectTypeAtysForMessageDesc(desc protoreflect.MessageDescriptor, atys
 		if err != nil {
 			return err
 		}
+		elem = flattenedElem(elem, outer)
 		if elem == nil {
 			continue // field is not relevant to HCL
 		}
@@ -52,32 +91,50 @@ func buildObjectTypeAtysForMessageDesc(desc protoreflect.MessageDescriptor, atys
 			atys[elem.Name] = aty
 
 		case FieldNestedBlockType:
-			nestedTy, err := ObjectTypeConstraintForMessageDesc(elem.Nested)
+			if elem.AnyDiscriminator != "" {
+				// The object type depends on which message type the
+				// discriminator attribute selects.
+				atys[elem.TypeName] = cty.DynamicPseudoType
+				continue
+			}
+			nestedTy, err := opts.objectTypeConstraintForMessageDesc(elem.Nested, visiting)
 			if err != nil {
 				return err
 			}
+			if labelName, ok := opts.blockLabelMapKey(elem); ok {
+				if opts.OmitMapKeys {
+					nestedTy = typeWithoutAttr(nestedTy, labelName)
+				}
+				if nestedTy.HasDynamicTypes() {
+					// We won't know the object type until we have a real
+					// value to choose the attribute names from.
+					atys[elem.TypeName] = cty.DynamicPseudoType
+				} else {
+					atys[elem.TypeName] = cty.Map(nestedTy)
+				}
+				continue
+			}
 			switch elem.CollectionKind {
 			case protohclext.NestedBlock_AUTO:
 				// AUTO always indicates single mode in the GetFieldElem
 				// response, so we'll just pass through the nested message type.
 				atys[elem.TypeName] = nestedTy
 
-			case protohclext.NestedBlock_TUPLE:
-				// We won't know the actual tuple type until we have a real
-				// value to choose it from.
-				atys[elem.TypeName] = cty.DynamicPseudoType
-
-			case protohclext.NestedBlock_LIST:
-				if nestedTy.HasDynamicTypes() {
-					return schemaErrorf(field.FullName(), "can't use (hcl.block).kind = LIST with a block type containing an attribute with an 'any' constraint")
+			case protohclext.NestedBlock_TUPLE, protohclext.NestedBlock_LIST, protohclext.NestedBlock_SET:
+				ty, err := collectionTypeConstraint(field.FullName(), elem.CollectionKind, nestedTy, "(hcl.block).kind", "a block type")
+				if err != nil {
+					return err
 				}
-				atys[elem.TypeName] = cty.List(nestedTy)
+				atys[elem.TypeName] = ty
 
-			case protohclext.NestedBlock_SET:
+			case protohclext.NestedBlock_MAP:
 				if nestedTy.HasDynamicTypes() {
-					return schemaErrorf(field.FullName(), "can't use (hcl.block).kind = SET with a block type containing an attribute with an 'any' constraint")
+					return schemaErrorf(field.FullName(), "can't use a map field for a block type containing an attribute with an 'any' constraint")
 				}
-				atys[elem.TypeName] = cty.Set(nestedTy)
+				if keyAttr, ok := opts.omittedMapKeyAttr(elem); ok {
+					nestedTy = typeWithoutAttr(nestedTy, keyAttr)
+				}
+				atys[elem.TypeName] = cty.Map(nestedTy)
 
 			default:
 				return schemaErrorf(field.FullName(), "unsupported block collection kind %s", elem.CollectionKind)
@@ -87,7 +144,7 @@ func buildObjectTypeAtysForMessageDesc(desc protoreflect.MessageDescriptor, atys
 			// For flattened we'll keep writing into the same map, but we'll
 			// use the nested message descriptor as the source instead.
 			nestedDesc := elem.Nested
-			err := buildObjectTypeAtysForMessageDesc(nestedDesc, atys)
+			err := opts.buildObjectTypeAtysForMessageDesc(nestedDesc, elem, visiting, atys)
 			if err != nil {
 				return err
 			}
@@ -107,3 +164,113 @@ func buildObjectTypeAtysForMessageDesc(desc protoreflect.MessageDescriptor, atys
 
 	return nil
 }
+
+// collectionTypeConstraint returns the type constraint for a collection of
+// the given kind whose elements conform to the given element type, which
+// both nested block types and repeated message-typed attributes use so that
+// their collection kinds have the same meaning.
+//
+// The option and what arguments describe the schema option that selected
+// the kind and the thing containing the elements, for use in error
+// messages.
+func collectionTypeConstraint(decl protoreflect.FullName, kind protohclext.NestedBlock_CollectionKind, ety cty.Type, option, what string) (cty.Type, error) {
+	switch kind {
+	case protohclext.NestedBlock_TUPLE:
+		// We won't know the actual tuple type until we have a real
+		// value to choose it from.
+		return cty.DynamicPseudoType, nil
+	case protohclext.NestedBlock_LIST:
+		if ety.HasDynamicTypes() {
+			return cty.DynamicPseudoType, schemaErrorf(decl, "can't use %s = LIST with %s containing an attribute with an 'any' constraint", option, what)
+		}
+		return cty.List(ety), nil
+	case protohclext.NestedBlock_SET:
+		if ety.HasDynamicTypes() {
+			return cty.DynamicPseudoType, schemaErrorf(decl, "can't use %s = SET with %s containing an attribute with an 'any' constraint", option, what)
+		}
+		return cty.Set(ety), nil
+	default:
+		return cty.DynamicPseudoType, schemaErrorf(decl, "unsupported collection kind %s", kind)
+	}
+}
+
+// attrObjectTypeConstraintForMessageDesc returns the automatic type
+// constraint for an attribute whose value will be decoded into a message of
+// the given type. The result is an object type with an attribute for each of
+// the message's attribute-annotated fields, where the attributes for fields
+// that are not required are optional.
+//
+// The "visiting" map tracks the message types whose type constraints we are
+// already building, so that we can detect recursive message types, which
+// have no corresponding HCL type. It may be nil if we're not already building
+// a type constraint for an enclosing message.
+func attrObjectTypeConstraintForMessageDesc(desc protoreflect.MessageDescriptor, visiting map[protoreflect.FullName]struct{}) (cty.Type, error) {
+	if _, exists := visiting[desc.FullName()]; exists {
+		return cty.NilType, schemaErrorf(desc.FullName(), "recursive message type can't be used as an attribute type")
+	}
+	if visiting == nil {
+		visiting = make(map[protoreflect.FullName]struct{})
+	}
+	visiting[desc.FullName()] = struct{}{}
+	defer delete(visiting, desc.FullName())
+
+	atys := make(map[string]cty.Type)
+	var optional []string
+	err := buildAttrObjectTypeAtysForMessageDesc(desc, FieldFlattened{}, visiting, atys, &optional)
+	if err != nil {
+		return cty.NilType, err
+	}
+	if len(optional) == 0 {
+		return cty.Object(atys), nil
+	}
+	return cty.ObjectWithOptionalAttrs(atys, optional), nil
+}
+
+func buildAttrObjectTypeAtysForMessageDesc(desc protoreflect.MessageDescriptor, outer FieldFlattened, visiting map[protoreflect.FullName]struct{}, atys map[string]cty.Type, optional *[]string) error {
+	fields := desc.Fields()
+
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+
+		elem, err := GetFieldElem(field)
+		if err != nil {
+			return err
+		}
+
+		switch elem := flattenedElem(elem, outer).(type) {
+		case FieldAttribute:
+			var aty cty.Type
+			if elem.TypeExprString == "" && elem.RawMode == protohclext.Attribute_NOT_RAW {
+				// We must handle this case ourselves, rather than using
+				// elem.TypeConstraint, so that we can detect recursion.
+				aty = autoTypeConstraintForField(field, visiting)
+				if aty == cty.NilType {
+					return schemaErrorf(field.FullName(), "can't infer HCL type constraint for this field; must specify (hcl.attr).type option explicitly")
+				}
+			} else {
+				var diags hcl.Diagnostics
+				aty, diags = elem.typeConstraint(visiting)
+				if diags.HasErrors() {
+					return schemaErrorf(field.FullName(), "invalid type constraint expression")
+				}
+			}
+			atys[elem.Name] = aty
+			if !elem.Required {
+				*optional = append(*optional, elem.Name)
+			}
+
+		case FieldFlattened:
+			err := buildAttrObjectTypeAtysForMessageDesc(elem.Nested, elem, visiting, atys, optional)
+			if err != nil {
+				return err
+			}
+
+		default:
+			// GetFieldElem would've rejected the use of this message type
+			// for an attribute if it declared anything other than
+			// attributes, so we can ignore anything else here.
+		}
+	}
+
+	return nil
+}
@@ -162,6 +162,71 @@ func TestObjectTypeConstraintForMessageDesc(t *testing.T) {
 			}),
 			``,
 		},
+		{
+			"WithMapOfBlocks",
+			cty.Object(map[string]cty.Type{
+				"pet": cty.Map(cty.Object(map[string]cty.Type{
+					"name": cty.String,
+				})),
+			}),
+			``,
+		},
+		{
+			"WithMapOfObjectsAttr",
+			cty.Object(map[string]cty.Type{
+				"pets": cty.Map(cty.Object(map[string]cty.Type{
+					"name": cty.String,
+				})),
+			}),
+			``,
+		},
+		{
+			"WithListOfObjectsAttr",
+			cty.Object(map[string]cty.Type{
+				"items": cty.List(cty.ObjectWithOptionalAttrs(
+					map[string]cty.Type{
+						"name":  cty.String,
+						"count": cty.Number,
+					},
+					[]string{"count"},
+				)),
+			}),
+			``,
+		},
+		{
+			"WithObjectAttr",
+			cty.Object(map[string]cty.Type{
+				"settings": cty.ObjectWithOptionalAttrs(
+					map[string]cty.Type{
+						"name":  cty.String,
+						"count": cty.Number,
+					},
+					[]string{"count"},
+				),
+			}),
+			``,
+		},
+		{
+			"WithNestedObjectsAttr",
+			cty.Object(map[string]cty.Type{
+				"groups": cty.List(cty.Object(map[string]cty.Type{
+					"pets": cty.Map(cty.Object(map[string]cty.Type{
+						"name": cty.String,
+					})),
+				})),
+			}),
+			``,
+		},
+		{
+			"WithBlockMessageAsAttr",
+			cty.NilType,
+			`unsupported protobuf schema in hcl.testschema.WithBlockMessageAsAttr.thing: can't use hcl.testschema.WithNestedBlockNoLabelsSingleton as the type of an attribute: hcl.testschema.WithNestedBlockNoLabelsSingleton.doodad declares nested block type "doodad", but nested blocks can't appear inside an attribute`,
+		},
+		{
+			"RecursiveBlock",
+			cty.NilType,
+			`unsupported protobuf schema in hcl.testschema.RecursiveBlock: recursive block type has no corresponding HCL object type`,
+		},
 	}
 
 	for _, test := range tests {
@@ -0,0 +1,187 @@
+package protohcl
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/zclconf/go-cty/cty"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// attrUnit describes one of the units that an attribute can declare using
+// (hcl.attr).unit.
+type attrUnit struct {
+	// suffixes are the suffixes that can follow a number to select a
+	// multiple of the unit, ordered from largest to smallest factor so that
+	// formatting can prefer the largest suffix that represents a value
+	// exactly.
+	suffixes []unitSuffix
+
+	// base is the suffix whose factor is one, used when formatting a value
+	// that no other suffix can represent exactly.
+	base string
+}
+
+type unitSuffix struct {
+	suffix string
+	factor *big.Rat
+}
+
+var attrUnits = map[string]attrUnit{
+	"bytes": {
+		suffixes: []unitSuffix{
+			{"PiB", new(big.Rat).SetInt64(1 << 50)},
+			{"PB", new(big.Rat).SetInt64(1e15)},
+			{"TiB", new(big.Rat).SetInt64(1 << 40)},
+			{"TB", new(big.Rat).SetInt64(1e12)},
+			{"GiB", new(big.Rat).SetInt64(1 << 30)},
+			{"GB", new(big.Rat).SetInt64(1e9)},
+			{"MiB", new(big.Rat).SetInt64(1 << 20)},
+			{"MB", new(big.Rat).SetInt64(1e6)},
+			{"KiB", new(big.Rat).SetInt64(1 << 10)},
+			{"KB", new(big.Rat).SetInt64(1e3)},
+			{"B", new(big.Rat).SetInt64(1)},
+		},
+		base: "B",
+	},
+	"seconds": {
+		suffixes: []unitSuffix{
+			{"d", new(big.Rat).SetInt64(86400)},
+			{"h", new(big.Rat).SetInt64(3600)},
+			{"m", new(big.Rat).SetInt64(60)},
+			{"s", new(big.Rat).SetInt64(1)},
+			{"ms", big.NewRat(1, 1e3)},
+			{"us", big.NewRat(1, 1e6)},
+			{"ns", big.NewRat(1, 1e9)},
+		},
+		base: "s",
+	},
+}
+
+// unitTypeConstraintForField returns the type constraint for an attribute
+// with a unit that decodes into the given field, which accepts strings in
+// the same shape as the field itself.
+func unitTypeConstraintForField(field protoreflect.FieldDescriptor) cty.Type {
+	switch {
+	case field.IsList():
+		return cty.List(cty.String)
+	case field.IsMap():
+		return cty.Map(cty.String)
+	default:
+		return cty.String
+	}
+}
+
+func isNumericKind(kind protoreflect.Kind) bool {
+	switch kind {
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Uint32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Uint64Kind,
+		protoreflect.Sfixed32Kind, protoreflect.Fixed32Kind,
+		protoreflect.Sfixed64Kind, protoreflect.Fixed64Kind,
+		protoreflect.FloatKind, protoreflect.DoubleKind:
+		return true
+	default:
+		return false
+	}
+}
+
+// parseUnitValue converts all of the strings in the given value, which must
+// conform to the type constraint of an attribute with the given unit, into
+// numbers measured in that unit.
+//
+// If any of the strings is invalid then the result is a cty.PathError
+// describing the path to that string within the given value.
+func parseUnitValue(val cty.Value, unitName string) (cty.Value, error) {
+	unit := attrUnits[unitName]
+	return cty.Transform(val, func(path cty.Path, v cty.Value) (cty.Value, error) {
+		if !v.Type().Equals(cty.String) {
+			return v, nil
+		}
+		if v.IsNull() {
+			return cty.NullVal(cty.Number), nil
+		}
+		if !v.IsKnown() {
+			return cty.UnknownVal(cty.Number), nil
+		}
+		n, err := unit.parse(v.AsString())
+		if err != nil {
+			return cty.DynamicVal, path.NewErrorf("invalid number of %s: %s", unitName, err)
+		}
+		return cty.NumberVal(n), nil
+	})
+}
+
+// formatUnitValue is the inverse of parseUnitValue, converting all of the
+// numbers in the given value into strings using the largest suffix of the
+// given unit that can represent each number exactly.
+func formatUnitValue(val cty.Value, unitName string) (cty.Value, error) {
+	unit := attrUnits[unitName]
+	return cty.Transform(val, func(path cty.Path, v cty.Value) (cty.Value, error) {
+		if !v.Type().Equals(cty.Number) {
+			return v, nil
+		}
+		if v.IsNull() {
+			return cty.NullVal(cty.String), nil
+		}
+		if !v.IsKnown() {
+			return cty.UnknownVal(cty.String), nil
+		}
+		return cty.StringVal(unit.format(v.AsBigFloat())), nil
+	})
+}
+
+func (u attrUnit) parse(s string) (*big.Float, error) {
+	s = strings.TrimSpace(s)
+	numLen := strings.IndexFunc(s, func(r rune) bool {
+		return !(r >= '0' && r <= '9') && r != '.' && r != '-' && r != '+'
+	})
+	if numLen < 0 {
+		numLen = len(s)
+	}
+	numStr, suffix := s[:numLen], strings.TrimSpace(s[numLen:])
+
+	n, ok := new(big.Rat).SetString(numStr)
+	if !ok || numStr == "" {
+		return nil, fmt.Errorf("must be a number, optionally followed by one of the suffixes %s", u.suffixList())
+	}
+	if suffix != "" {
+		found := false
+		for _, candidate := range u.suffixes {
+			if candidate.suffix == suffix {
+				n.Mul(n, candidate.factor)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unsupported suffix %q; must be one of %s", suffix, u.suffixList())
+		}
+	}
+
+	// We use the same precision as cty's own number parser, so that the
+	// result is as precise as a number written directly in the configuration.
+	return new(big.Float).SetPrec(512).SetRat(n), nil
+}
+
+func (u attrUnit) format(n *big.Float) string {
+	if n.Sign() == 0 {
+		return "0" + u.base
+	}
+	if r, acc := n.Rat(nil); acc == big.Exact {
+		for _, candidate := range u.suffixes {
+			if q := new(big.Rat).Quo(r, candidate.factor); q.IsInt() {
+				return q.Num().String() + candidate.suffix
+			}
+		}
+	}
+	return n.Text('f', -1) + u.base
+}
+
+func (u attrUnit) suffixList() string {
+	names := make([]string, len(u.suffixes))
+	for i, candidate := range u.suffixes {
+		names[i] = fmt.Sprintf("%q", candidate.suffix)
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,158 @@
+package protohcl
+
+import (
+	"testing"
+
+	"github.com/apparentlymart/go-protohcl/protohcl/internal/testschema"
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty-debug/ctydebug"
+	"github.com/zclconf/go-cty/cty"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func TestDecodeBodyUnits(t *testing.T) {
+	desc := testschema.File_testschema_proto.Messages().ByName("WithUnitAttrs")
+
+	tests := map[string]struct {
+		config     string
+		want       *testschema.WithUnitAttrs
+		wantDetail string
+	}{
+		"plain numbers": {
+			`
+				max_size  = 1024
+				timeout   = 1.5
+				intervals = [1, 2]
+			`,
+			&testschema.WithUnitAttrs{
+				MaxSize:   1024,
+				Timeout:   1.5,
+				Intervals: []uint32{1, 2},
+			},
+			``,
+		},
+		"suffixes": {
+			`
+				max_size  = "10GiB"
+				timeout   = "250ms"
+				intervals = ["1m", "1.5h", 30]
+			`,
+			&testschema.WithUnitAttrs{
+				MaxSize:   10 << 30,
+				Timeout:   0.25,
+				Intervals: []uint32{60, 5400, 30},
+			},
+			``,
+		},
+		"decimal bytes with space": {
+			`max_size = "2 MB"`,
+			&testschema.WithUnitAttrs{
+				MaxSize: 2000000,
+			},
+			``,
+		},
+		"unsupported suffix": {
+			`max_size = "10 gigs"`,
+			&testschema.WithUnitAttrs{},
+			`Inappropriate value for attribute "max_size": invalid number of bytes: unsupported suffix "gigs"; must be one of "PiB", "PB", "TiB", "TB", "GiB", "GB", "MiB", "MB", "KiB", "KB", "B".`,
+		},
+		"not a number": {
+			`timeout = "soon"`,
+			&testschema.WithUnitAttrs{},
+			`Inappropriate value for attribute "timeout": invalid number of seconds: must be a number, optionally followed by one of the suffixes "d", "h", "m", "s", "ms", "us", "ns".`,
+		},
+		"invalid list element": {
+			`intervals = ["1s", "1x"]`,
+			&testschema.WithUnitAttrs{},
+			`Inappropriate value for attribute "intervals" at [1]: invalid number of seconds: unsupported suffix "x"; must be one of "d", "h", "m", "s", "ms", "us", "ns".`,
+		},
+		"fraction of whole unit": {
+			`intervals = ["1ms"]`,
+			&testschema.WithUnitAttrs{},
+			`The value must be a whole number.`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			f, diags := hclsyntax.ParseConfig([]byte(test.config), "", hcl.InitialPos)
+			if diags.HasErrors() {
+				t.Fatalf("unexpected syntax errors: %s", diags.Error())
+			}
+
+			got, diags := DecodeBody(f.Body, desc, nil)
+			if test.wantDetail == "" {
+				if diags.HasErrors() {
+					t.Fatalf("unexpected errors: %s", diags.Error())
+				}
+				if diff := cmp.Diff(test.want, got, protoCmpOpt); diff != "" {
+					t.Errorf("wrong result\n%s", diff)
+				}
+				return
+			}
+			if !diags.HasErrors() {
+				t.Fatalf("unexpected success")
+			}
+			if got, want := diags[0].Detail, test.wantDetail; got != want {
+				t.Errorf("wrong error detail\ngot:  %s\nwant: %s", got, want)
+			}
+		})
+	}
+}
+
+func TestObjectValueForMessageUnits(t *testing.T) {
+	msg := &testschema.WithUnitAttrs{
+		MaxSize:   1536,
+		Timeout:   0.25,
+		Intervals: []uint32{0, 90, 7200, 86400},
+	}
+	got, err := ObjectValueForMessage(msg)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := cty.ObjectVal(map[string]cty.Value{
+		"max_size": cty.StringVal("1536B"),
+		"timeout":  cty.StringVal("250ms"),
+		"intervals": cty.ListVal([]cty.Value{
+			cty.StringVal("0s"),
+			cty.StringVal("90s"),
+			cty.StringVal("2h"),
+			cty.StringVal("1d"),
+		}),
+	})
+	if diff := cmp.Diff(want, got, ctydebug.CmpOptions); diff != "" {
+		t.Fatalf("wrong result\n%s", diff)
+	}
+
+	// Normalizing formats each value and then parses it again, so it
+	// should leave the message unchanged.
+	normalized := proto.Clone(msg)
+	if err := NormalizeMessage(normalized); err != nil {
+		t.Fatalf("unexpected error normalizing: %s", err)
+	}
+	if diff := cmp.Diff(msg, normalized, protoCmpOpt); diff != "" {
+		t.Errorf("normalizing changed the message\n%s", diff)
+	}
+}
+
+func TestGetFieldElemUnitErrors(t *testing.T) {
+	tests := map[protoreflect.Name]string{
+		"WithUnitAttrUnsupported": `unsupported protobuf schema in hcl.testschema.WithUnitAttrUnsupported.size: unsupported unit "furlongs"`,
+		"WithUnitAttrString":      `unsupported protobuf schema in hcl.testschema.WithUnitAttrString.size: unit is allowed only for numeric fields`,
+	}
+	for name, want := range tests {
+		t.Run(string(name), func(t *testing.T) {
+			desc := testschema.File_testschema_proto.Messages().ByName(name)
+			_, err := GetFieldElem(desc.Fields().Get(0))
+			if err == nil {
+				t.Fatalf("unexpected success")
+			}
+			if got := err.Error(); got != want {
+				t.Errorf("wrong error\ngot:  %s\nwant: %s", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,569 @@
+package protohcl
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/apparentlymart/go-protohcl/protohcl/protohclext"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// SchemaProblem describes a problem with the HCL annotations in a protobuf
+// schema, as reported by ValidateMessageDesc.
+type SchemaProblem struct {
+	Severity SchemaProblemSeverity
+
+	// Decl is the fully-qualified name of the closest declaration that
+	// reflects the problem being described.
+	Decl protoreflect.FullName
+
+	// Message describes the problem.
+	Message string
+
+	// Suggestion optionally describes a possible way to fix the problem. It
+	// is empty if there's no specific suggestion.
+	Suggestion string
+
+	// Rule is a stable identifier for the check that reported a warning,
+	// which callers can use to let schema authors suppress warnings that
+	// don't apply to their situation. It's one of the SchemaRule constants
+	// for warnings, and always empty for errors, because errors can't be
+	// suppressed.
+	Rule string
+}
+
+// The following are the values of SchemaProblem.Rule for each of the kinds
+// of warning that ValidateMessageDesc and ValidateExample can report. These
+// identifiers will not change in future versions, although new rules may be
+// added.
+const (
+	// SchemaRuleNamingConvention reports attribute and block type names that
+	// don't follow the HCL naming convention.
+	SchemaRuleNamingConvention = "naming-convention"
+
+	// SchemaRuleUnannotatedField reports fields of HCL-annotated messages
+	// that have no HCL annotations themselves.
+	SchemaRuleUnannotatedField = "unannotated-field"
+
+	// SchemaRuleEnumValueName reports enum values that have no
+	// (hcl.enumval).name option.
+	SchemaRuleEnumValueName = "enum-value-name"
+
+	// SchemaRuleOutputForNotFound reports (hcl.message).output_for options
+	// that refer to a message type that isn't available.
+	SchemaRuleOutputForNotFound = "output-for-not-found"
+
+	// SchemaRuleLabelRequired reports block label fields that also set
+	// (hcl.attr).required, which has no effect because labels are always
+	// required.
+	SchemaRuleLabelRequired = "label-required"
+
+	// SchemaRuleLabelAttrOptions reports block label fields that also set
+	// other (hcl.attr) options, which have no effect on a label.
+	SchemaRuleLabelAttrOptions = "label-attr-options"
+
+	// SchemaRuleRawWithDefault reports raw-mode attribute fields that declare
+	// a protobuf default value, which can never be a valid raw encoding.
+	SchemaRuleRawWithDefault = "raw-with-default"
+
+	// SchemaRuleFlattenLabelOrder reports messages whose block labels come
+	// from a flattened message along with some other field, in which case
+	// the label order depends on the order of the field declarations.
+	SchemaRuleFlattenLabelOrder = "flatten-label-order"
+
+	// SchemaRuleExampleLabelCount reports blocks in an example
+	// configuration, given to ValidateExample, that have a different number
+	// of labels than their nested block type's message declares.
+	SchemaRuleExampleLabelCount = "example-label-count"
+)
+
+// SchemaProblemSeverity represents whether a SchemaProblem is an error or
+// only a warning.
+type SchemaProblemSeverity int
+
+const (
+	// SchemaProblemError represents a problem that will cause decoding to
+	// fail, regardless of the given configuration.
+	SchemaProblemError SchemaProblemSeverity = 1
+
+	// SchemaProblemWarning represents a problem that won't cause decoding to
+	// fail, but that probably represents a mistake or that goes against
+	// the usual HCL conventions.
+	SchemaProblemWarning SchemaProblemSeverity = 2
+)
+
+func (s SchemaProblemSeverity) String() string {
+	switch s {
+	case SchemaProblemError:
+		return "error"
+	case SchemaProblemWarning:
+		return "warning"
+	default:
+		return fmt.Sprintf("SchemaProblemSeverity(%d)", int(s))
+	}
+}
+
+// ValidateMessageDesc checks the HCL annotations in the given message type,
+// and in all of the message types reachable from it through nested block
+// types, flattened messages, and message-typed attributes, returning a
+// description of each problem it finds.
+//
+// ValidateMessageDesc reports all of the same errors that decoding would
+// report for the schema, though it may report some of them differently, and
+// also reports warnings for some situations that are valid but probably
+// mistakes. A message type with no error problems is valid for decoding.
+//
+// This is intended for schema linting tools, which can report all of the
+// problems at once without needing an example configuration to decode.
+func ValidateMessageDesc(desc protoreflect.MessageDescriptor) []SchemaProblem {
+	v := &validator{
+		visited: make(map[protoreflect.FullName]struct{}),
+		seen:    make(map[SchemaProblem]struct{}),
+	}
+	v.validateMessage(desc)
+	return v.problems
+}
+
+type validator struct {
+	problems []SchemaProblem
+	visited  map[protoreflect.FullName]struct{}
+	seen     map[SchemaProblem]struct{}
+}
+
+func (v *validator) report(severity SchemaProblemSeverity, rule string, decl protoreflect.FullName, suggestion string, format string, args ...interface{}) {
+	problem := SchemaProblem{
+		Severity:   severity,
+		Decl:       decl,
+		Message:    fmt.Sprintf(format, args...),
+		Suggestion: suggestion,
+		Rule:       rule,
+	}
+	if _, exists := v.seen[problem]; exists {
+		// The same problem can be detected in more than one way, such as by
+		// both checking a field and building its message's body schema.
+		return
+	}
+	v.seen[problem] = struct{}{}
+	v.problems = append(v.problems, problem)
+}
+
+func (v *validator) reportErr(decl protoreflect.FullName, err error) {
+	var schemaErr schemaError
+	if errors.As(err, &schemaErr) {
+		// The innermost schemaError is the most specific one, so we'll look
+		// for that rather than reporting all the context around it.
+		for {
+			var inner schemaError
+			if !errors.As(schemaErr.Err, &inner) {
+				break
+			}
+			schemaErr = inner
+		}
+		v.report(SchemaProblemError, "", schemaErr.Decl, "", "%s", schemaErr.Err.Error())
+		return
+	}
+	v.report(SchemaProblemError, "", decl, "", "%s", err.Error())
+}
+
+func (v *validator) validateMessage(desc protoreflect.MessageDescriptor) {
+	if _, exists := v.visited[desc.FullName()]; exists {
+		return
+	}
+	v.visited[desc.FullName()] = struct{}{}
+
+	fields := desc.Fields()
+	annotated := 0
+	rangeFields := make(map[protoreflect.Name]struct{})
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		elem, err := GetFieldElem(field)
+		if err != nil {
+			v.reportErr(field.FullName(), err)
+			annotated++
+			continue
+		}
+
+		switch elem := elem.(type) {
+		case FieldAttribute:
+			annotated++
+			v.validateName(field.FullName(), "attribute", elem.Name)
+			v.validateAttribute(elem)
+			if elem.RangeField != nil {
+				rangeFields[elem.RangeField.Name()] = struct{}{}
+			}
+		case FieldNestedBlockType:
+			annotated++
+			v.validateName(field.FullName(), "block type", elem.TypeName)
+			for _, alias := range elem.Aliases {
+				v.validateName(field.FullName(), "block type alias", alias)
+			}
+			if elem.AnyDiscriminator != "" {
+				// The block's content is one of the any_types, rather
+				// than google.protobuf.Any itself.
+				for _, value := range sortedAnyTypeValues(elem) {
+					v.validateMessage(elem.AnyTypes[value])
+				}
+				continue
+			}
+			v.validateMessage(elem.Nested)
+		case FieldFlattened:
+			annotated++
+			if elem.Prefix != "" {
+				v.validateName(field.FullName(), "flatten prefix", elem.Prefix)
+			}
+			v.validateMessage(elem.Nested)
+		case FieldBlockLabel:
+			annotated++
+			v.validateLabel(field)
+		}
+	}
+	v.validateLabelOrder(desc)
+
+	// Building the body schema detects some additional problems, such as
+	// name conflicts, that involve more than one field. If nothing else
+	// failed then we'll also check the object type constraint, which
+	// detects problems with the attributes' type constraints. A recursive
+	// block type has no object type, but that only matters for
+	// ObjectValueForMessage, and the decoder supports it.
+	if _, err := bodySchema(desc); err != nil {
+		v.reportErr(desc.FullName(), err)
+	} else if _, err := ObjectTypeConstraintForMessageDesc(desc); err != nil && !errors.Is(err, errRecursiveBlockType) {
+		v.reportErr(desc.FullName(), err)
+	}
+
+	if configName := OutputForMessageDesc(desc); configName != "" {
+		config := findMessageInFile(desc.ParentFile(), configName, make(map[string]struct{}))
+		if config == nil {
+			v.report(
+				SchemaProblemWarning, SchemaRuleOutputForNotFound, desc.FullName(),
+				"Make sure that output_for is set to the fully-qualified name of the message type that configures the object this message describes.",
+				"output_for refers to %s, which isn't declared in this message's file or in any of the files it imports", configName,
+			)
+		} else {
+			v.validateMessage(config)
+			if _, err := CombinedObjectTypeConstraint(config, desc); err != nil {
+				v.reportErr(desc.FullName(), err)
+			}
+		}
+	}
+
+	if annotated == 0 {
+		// We don't report anything else for a message that doesn't seem
+		// to be intended for use with HCL at all, because the remaining
+		// checks would be noisy.
+		return
+	}
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		if _, isRange := rangeFields[field.Name()]; isRange {
+			// The decoder populates this field with the source range of
+			// some other field's attribute.
+			continue
+		}
+		if attrOpts := proto.GetExtension(field.Options(), protohclext.E_Attr).(*protohclext.Attribute); attrOpts.GetName() != "" && attrShadowedByFlatten(field, attrOpts.Name) {
+			// A flattened message takes this field's attribute name, using
+			// (hcl.flatten_conflict) = INNER_WINS, so it's intentionally
+			// not decoded.
+			continue
+		}
+		if elem, err := GetFieldElem(field); elem == nil && err == nil {
+			v.report(
+				SchemaProblemWarning, SchemaRuleUnannotatedField, field.FullName(),
+				"Add an (hcl.attr), (hcl.block), (hcl.label), or (hcl.flatten) option if this field should be populated from the configuration.",
+				"field has no HCL annotations, so decoding will never populate it",
+			)
+		}
+	}
+}
+
+func (v *validator) validateName(decl protoreflect.FullName, what, name string) {
+	if !hclsyntax.ValidIdentifier(name) {
+		v.report(
+			SchemaProblemError, "", decl, "",
+			"%s name %q is not a valid HCL identifier", what, name,
+		)
+		return
+	}
+	if conventional := conventionalName(name); conventional != name {
+		v.report(
+			SchemaProblemWarning, SchemaRuleNamingConvention, decl,
+			fmt.Sprintf("Use the name %q instead.", conventional),
+			"%s name %q does not follow the HCL convention of using only lowercase letters, digits, and underscores", what, name,
+		)
+	}
+}
+
+func (v *validator) validateAttribute(elem FieldAttribute) {
+	field := elem.TargetField
+
+	var ty cty.Type
+	if elem.TypeExprString == "" {
+		var err error
+		ty, err = elem.autoTypeConstraint()
+		if err != nil {
+			v.reportErr(field.FullName(), err)
+			return
+		}
+	} else {
+		var diags hcl.Diagnostics
+		ty, diags = elem.TypeConstraint()
+		if diags.HasErrors() {
+			for _, diag := range diags {
+				if diag.Severity == hcl.DiagError {
+					v.report(
+						SchemaProblemError, "", field.FullName(), "",
+						"invalid type constraint %q: %s", elem.TypeExprString, diag.Detail,
+					)
+					break
+				}
+			}
+			return
+		}
+		if elem.RawMode == protohclext.Attribute_NOT_RAW {
+			v.validateExplicitType(elem, ty)
+		}
+	}
+
+	if elem.RawMode != protohclext.Attribute_NOT_RAW && field.HasDefault() {
+		v.report(
+			SchemaProblemWarning, SchemaRuleRawWithDefault, field.FullName(),
+			"Remove the default value from this field.",
+			"raw-mode field has a default value, which will be decoded as a raw %s encoding whenever the field is unset", elem.RawMode,
+		)
+	}
+
+	elemDesc := field
+	if field.IsMap() {
+		elemDesc = field.MapValue()
+	}
+	switch elemDesc.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		if elemDesc.Message().FullName() != structpbValueDesc.FullName() {
+			v.validateMessage(elemDesc.Message())
+		}
+	case protoreflect.EnumKind:
+		v.validateEnum(elemDesc.Enum())
+	}
+}
+
+// validateLabel checks for (hcl.attr) options on a block label field. An
+// attribute name would make the field invalid, which GetFieldElem already
+// reports, but any other attribute options are just silently ignored.
+func (v *validator) validateLabel(field protoreflect.FieldDescriptor) {
+	opts, ok := field.Options().(*descriptorpb.FieldOptions)
+	if !ok {
+		return
+	}
+	attrOpts := proto.GetExtension(opts, protohclext.E_Attr).(*protohclext.Attribute)
+	if attrOpts.GetRequired() {
+		v.report(
+			SchemaProblemWarning, SchemaRuleLabelRequired, field.FullName(),
+			"Remove (hcl.attr).required from this field.",
+			"block labels are always required, so (hcl.attr).required has no effect",
+		)
+	}
+	if attrOpts != nil {
+		others := proto.Clone(attrOpts).(*protohclext.Attribute)
+		others.Required = false
+		if proto.Size(others) != 0 {
+			v.report(
+				SchemaProblemWarning, SchemaRuleLabelAttrOptions, field.FullName(),
+				"Remove the (hcl.attr) options from this field.",
+				"(hcl.attr) options have no effect on a block label field",
+			)
+		}
+	}
+}
+
+// validateLabelOrder checks whether the block labels of the given message
+// come from a flattened message along with any other field, in which case
+// the position of the flattened labels depends on where the flattened field
+// is declared, which is easy to overlook.
+func (v *validator) validateLabelOrder(desc protoreflect.MessageDescriptor) {
+	sources := 0
+	var flattenDecl protoreflect.FullName
+	fields := desc.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		elem, err := GetFieldElem(field)
+		if err != nil {
+			continue // already reported
+		}
+		switch elem := elem.(type) {
+		case FieldBlockLabel:
+			sources++
+		case FieldFlattened:
+			names, err := blockLabelNames(elem.Nested, nil, make(map[string]protoreflect.FullName))
+			if err != nil || len(names) == 0 {
+				continue
+			}
+			sources++
+			if flattenDecl == "" {
+				flattenDecl = field.FullName()
+			}
+		}
+	}
+	if flattenDecl != "" && sources > 1 {
+		v.report(
+			SchemaProblemWarning, SchemaRuleFlattenLabelOrder, flattenDecl,
+			"Declare all of the labels in a single message, or make sure that the fields are declared in the intended label order.",
+			"labels from this flattened message are combined with other labels of %s, so the label order depends on the order of the field declarations", desc.FullName(),
+		)
+	}
+}
+
+// validateExplicitType checks whether the given explicit type constraint is
+// compatible with the shape of the field it's declared for.
+func (v *validator) validateExplicitType(elem FieldAttribute, ty cty.Type) {
+	field := elem.TargetField
+	if ty == cty.DynamicPseudoType {
+		// We'll check the final value against the field at decoding time.
+		return
+	}
+	if isStructpbField(field) {
+		// A repeated google.protobuf.Value field needs a collection type
+		// constraint even if it uses allow_scalar_for_list, because the
+		// decoder converts each element using the constraint's element type.
+		if err := checkStructpbTypeConstraint(field, ty); err != nil {
+			v.reportErr(field.FullName(), err)
+		}
+		return
+	}
+	switch {
+	case field.IsList():
+		if !(ty.IsListType() || ty.IsSetType() || ty.IsTupleType()) && !elem.AllowScalarForList {
+			v.report(
+				SchemaProblemError, "", field.FullName(), "Use a list or set type constraint for a repeated field.",
+				"type constraint %s is not a collection type, but the field is repeated", elem.TypeExprString,
+			)
+		}
+	case field.IsMap():
+		if !(ty.IsMapType() || ty.IsObjectType()) {
+			v.report(
+				SchemaProblemError, "", field.FullName(), "Use a map type constraint for a map field.",
+				"type constraint %s is not a map type, but the field is a map", elem.TypeExprString,
+			)
+		}
+	case !isMessageKind(field.Kind()):
+		if !ty.IsPrimitiveType() {
+			v.report(
+				SchemaProblemError, "", field.FullName(), "Use a primitive type constraint, or change the field to be repeated or a map.",
+				"type constraint %s is not a primitive type, but the field is a single %s", elem.TypeExprString, field.Kind(),
+			)
+		}
+	}
+}
+
+func (v *validator) validateEnum(desc protoreflect.EnumDescriptor) {
+	if _, exists := v.visited[desc.FullName()]; exists {
+		return
+	}
+	v.visited[desc.FullName()] = struct{}{}
+
+	if err := validateEnumHCLNames(desc); err != nil {
+		v.reportErr(desc.FullName(), err)
+	}
+
+	vals := desc.Values()
+	prefix := enumValuePrefix(desc)
+	for i := 0; i < vals.Len(); i++ {
+		val := vals.Get(i)
+		if val.Number() == 0 {
+			// The zero value is conventionally a placeholder for "unset",
+			// and so it doesn't need a name of its own.
+			continue
+		}
+		opts, _ := val.Options().(*descriptorpb.EnumValueOptions)
+		if opts != nil {
+			if valOpts := proto.GetExtension(opts, protohclext.E_Enumval).(*protohclext.EnumValue); valOpts.GetName() != "" {
+				continue
+			}
+		}
+		v.report(
+			SchemaProblemWarning, SchemaRuleEnumValueName, val.FullName(),
+			fmt.Sprintf("Set (hcl.enumval).name to a conventional HCL-style name, such as %q.", strings.ToLower(strings.TrimPrefix(string(val.Name()), prefix))),
+			"enum value has no HCL name, so configuration must select it using its protobuf name %q", val.Name(),
+		)
+	}
+}
+
+// enumValuePrefix returns the prefix, ending with an underscore, that all of
+// the values of the given enum type have in common, or an empty string if
+// there is no such prefix.
+func enumValuePrefix(desc protoreflect.EnumDescriptor) string {
+	vals := desc.Values()
+	if vals.Len() < 2 {
+		return ""
+	}
+	prefix := string(vals.Get(0).Name())
+	for i := 1; i < vals.Len(); i++ {
+		name := string(vals.Get(i).Name())
+		for !strings.HasPrefix(name, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	if i := strings.LastIndexByte(prefix, '_'); i >= 0 {
+		return prefix[:i+1]
+	}
+	return ""
+}
+
+// conventionalName returns a variant of the given name that follows the
+// HCL naming convention of lowercase words separated by underscores.
+func conventionalName(name string) string {
+	var buf strings.Builder
+	for i, r := range name {
+		switch {
+		case r == '-':
+			buf.WriteByte('_')
+		case r >= 'A' && r <= 'Z':
+			if i > 0 && name[i-1] != '_' && name[i-1] != '-' && !(name[i-1] >= 'A' && name[i-1] <= 'Z') {
+				buf.WriteByte('_')
+			}
+			buf.WriteRune(r + ('a' - 'A'))
+		default:
+			buf.WriteRune(r)
+		}
+	}
+	return buf.String()
+}
+
+// findMessageInFile searches the given file, and then all of the files it
+// imports, for a message type of the given name. Returns nil if there is no
+// such message type.
+func findMessageInFile(file protoreflect.FileDescriptor, name protoreflect.FullName, visited map[string]struct{}) protoreflect.MessageDescriptor {
+	if _, exists := visited[file.Path()]; exists {
+		return nil
+	}
+	visited[file.Path()] = struct{}{}
+
+	if desc := findNestedMessage(file.Messages(), name); desc != nil {
+		return desc
+	}
+	imports := file.Imports()
+	for i := 0; i < imports.Len(); i++ {
+		if desc := findMessageInFile(imports.Get(i).FileDescriptor, name, visited); desc != nil {
+			return desc
+		}
+	}
+	return nil
+}
+
+func findNestedMessage(msgs protoreflect.MessageDescriptors, name protoreflect.FullName) protoreflect.MessageDescriptor {
+	for i := 0; i < msgs.Len(); i++ {
+		msg := msgs.Get(i)
+		if msg.FullName() == name {
+			return msg
+		}
+		if desc := findNestedMessage(msg.Messages(), name); desc != nil {
+			return desc
+		}
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package protohcl
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// ValidateExample checks the given example configuration, written for the
+// given message type, for signs that the message type's HCL annotations
+// don't match how its author intends it to be used, returning a description
+// of each problem it finds.
+//
+// Currently ValidateExample reports a SchemaRuleExampleLabelCount warning
+// for each block in the example whose number of labels differs from the
+// number of label fields declared by its nested block type's message, which
+// would otherwise be discovered only when a user writes a similar block.
+// It doesn't report any of the problems that ValidateMessageDesc reports,
+// so schema linting tools should typically use both.
+//
+// The example must be in HCL native syntax. ValidateExample returns no
+// problems for a body of any other kind, because other syntaxes don't
+// record how many labels each block has.
+func ValidateExample(desc protoreflect.MessageDescriptor, example hcl.Body) []SchemaProblem {
+	body, ok := example.(*hclsyntax.Body)
+	if !ok {
+		return nil
+	}
+	v := &validator{
+		visited: make(map[protoreflect.FullName]struct{}),
+		seen:    make(map[SchemaProblem]struct{}),
+	}
+	v.validateExampleBody(desc, body)
+	return v.problems
+}
+
+func (v *validator) validateExampleBody(desc protoreflect.MessageDescriptor, body *hclsyntax.Body) {
+	blockTypes := make(map[string]FieldNestedBlockType)
+	collectNestedBlockTypes(desc, "", blockTypes)
+
+	for _, block := range body.Blocks {
+		elem, ok := blockTypes[block.Type]
+		if !ok {
+			// Decoding would report an unsupported block type, which is
+			// a problem with the example rather than with the schema.
+			continue
+		}
+
+		names, err := blockLabelNames(elem.Nested, nil, make(map[string]protoreflect.FullName))
+		if err != nil {
+			continue // ValidateMessageDesc reports this
+		}
+		want := len(names)
+		if elem.MapKeyLabel != "" {
+			want++
+		}
+		got := len(block.Labels)
+		switch {
+		case got > want:
+			v.report(
+				SchemaProblemWarning, SchemaRuleExampleLabelCount, elem.Nested.FullName(),
+				fmt.Sprintf("Add %s to %s, or remove the extra labels from the example.", labelFieldCount(got-want), elem.Nested.FullName()),
+				"example %q block at %s has %s, but the block type accepts %s", block.Type, block.TypeRange, labelCount(got), labelCount(want),
+			)
+		case got < want:
+			v.report(
+				SchemaProblemWarning, SchemaRuleExampleLabelCount, elem.Nested.FullName(),
+				fmt.Sprintf("Remove %s from %s, or add the missing labels to the example.", labelFieldCount(want-got), elem.Nested.FullName()),
+				"example %q block at %s has %s, but the block type requires %s", block.Type, block.TypeRange, labelCount(got), labelCount(want),
+			)
+		}
+
+		v.validateExampleBody(elem.Nested, block.Body)
+	}
+}
+
+// collectNestedBlockTypes populates the given map with each of the nested
+// block types declared in the given message descriptor, including those
+// from flattened messages. The given prefix is added to each of the type
+// names, for a message flattened in using (hcl.flatten_prefix).
+func collectNestedBlockTypes(desc protoreflect.MessageDescriptor, prefix string, into map[string]FieldNestedBlockType) {
+	fields := desc.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		elem, err := GetFieldElem(fields.Get(i))
+		if err != nil {
+			continue // ValidateMessageDesc reports these
+		}
+		switch elem := withNamePrefix(elem, prefix).(type) {
+		case FieldNestedBlockType:
+			into[elem.TypeName] = elem
+			for _, alias := range elem.Aliases {
+				into[alias] = elem
+			}
+		case FieldFlattened:
+			collectNestedBlockTypes(elem.Nested, elem.Prefix, into)
+		}
+	}
+}
+
+func labelCount(n int) string {
+	if n == 1 {
+		return "1 label"
+	}
+	return fmt.Sprintf("%d labels", n)
+}
+
+func labelFieldCount(n int) string {
+	if n == 1 {
+		return "1 (hcl.label) field"
+	}
+	return fmt.Sprintf("%d (hcl.label) fields", n)
+}
@@ -0,0 +1,95 @@
+package protohcl
+
+import (
+	"testing"
+
+	"github.com/apparentlymart/go-protohcl/protohcl/internal/testschema"
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func TestValidateExample(t *testing.T) {
+	tests := map[string]struct {
+		desc    protoreflect.Name
+		example string
+		want    []SchemaProblem
+	}{
+		"matching labels": {
+			"WithNestedBlockOneLabelRepeated",
+			`
+doodad "a" {}
+doodad "b" {}
+`,
+			nil,
+		},
+		"map key label": {
+			"WithMapOfBlocks",
+			`pet "fido" {}`,
+			nil,
+		},
+		"extra label": {
+			"WithNestedBlockOneLabelRepeated",
+			`doodad "a" "b" {}`,
+			[]SchemaProblem{
+				{
+					Severity:   SchemaProblemWarning,
+					Decl:       "hcl.testschema.WithOneBlockLabel",
+					Message:    `example "doodad" block at example.hcl:1,1-7 has 2 labels, but the block type accepts 1 label`,
+					Suggestion: "Add 1 (hcl.label) field to hcl.testschema.WithOneBlockLabel, or remove the extra labels from the example.",
+					Rule:       SchemaRuleExampleLabelCount,
+				},
+			},
+		},
+		"missing label": {
+			"WithNestedBlockOneLabelRepeated",
+			`doodad {}`,
+			[]SchemaProblem{
+				{
+					Severity:   SchemaProblemWarning,
+					Decl:       "hcl.testschema.WithOneBlockLabel",
+					Message:    `example "doodad" block at example.hcl:1,1-7 has 0 labels, but the block type requires 1 label`,
+					Suggestion: "Remove 1 (hcl.label) field from hcl.testschema.WithOneBlockLabel, or add the missing labels to the example.",
+					Rule:       SchemaRuleExampleLabelCount,
+				},
+			},
+		},
+		"nested block": {
+			"WithSameBlockTypeNested",
+			`
+item {
+  item "a" "b" {}
+}
+`,
+			[]SchemaProblem{
+				{
+					Severity:   SchemaProblemWarning,
+					Decl:       "hcl.testschema.SameBlockTypeInner",
+					Message:    `example "item" block at example.hcl:3,3-7 has 2 labels, but the block type accepts 0 labels`,
+					Suggestion: "Add 2 (hcl.label) fields to hcl.testschema.SameBlockTypeInner, or remove the extra labels from the example.",
+					Rule:       SchemaRuleExampleLabelCount,
+				},
+			},
+		},
+		"unsupported block type": {
+			"WithNestedBlockOneLabelRepeated",
+			`other "a" "b" {}`,
+			nil,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			f, diags := hclsyntax.ParseConfig([]byte(test.example), "example.hcl", hcl.InitialPos)
+			if diags.HasErrors() {
+				t.Fatalf("unexpected syntax errors: %s", diags.Error())
+			}
+			desc := testschema.File_testschema_proto.Messages().ByName(test.desc)
+			got := ValidateExample(desc, f.Body)
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("wrong result\n%s", diff)
+			}
+		})
+	}
+}
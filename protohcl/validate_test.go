@@ -0,0 +1,204 @@
+package protohcl
+
+import (
+	"testing"
+
+	"github.com/apparentlymart/go-protohcl/protohcl/internal/testschema"
+	"github.com/apparentlymart/go-protohcl/protohcl/protohclext"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func TestValidateMessageDesc(t *testing.T) {
+	tests := map[string][]SchemaProblem{
+		"Root":                            nil,
+		"RecursiveBlock":                  nil,
+		"WithNestedBlockOneLabelRepeated": nil,
+		"WithEnumAttr":                    nil,
+		"WithFlattenConflictInnerWins":    nil,
+		"WithFlattenConflictOuterWins":    nil,
+		"WithInvalidNestedBlocks": {
+			{
+				Severity: SchemaProblemError,
+				Decl:     "hcl.testschema.InvalidBlockBody.other_name",
+				Message:  `declaration of attribute "name" conflicts with hcl.testschema.InvalidBlockBody.name`,
+			},
+		},
+		"WithRootOnlyNestedBlock": {
+			{
+				Severity: SchemaProblemError,
+				Decl:     "hcl.testschema.WithRootOnlyNestedBlock.config",
+				Message:  `can't use hcl.testschema.RootOnlyConfig as nested block type "config", because it is declared as root_only`,
+			},
+		},
+		"ConflictingRootOutput": {
+			{
+				Severity: SchemaProblemError,
+				Decl:     "hcl.testschema.ConflictingRootOutput",
+				Message:  `output attribute "name" conflicts with attribute declared by hcl.testschema.Root`,
+			},
+		},
+		"WithMismatchedAttrType": {
+			{
+				Severity:   SchemaProblemError,
+				Decl:       "hcl.testschema.WithMismatchedAttrType.name",
+				Message:    "type constraint list(string) is not a primitive type, but the field is a single string",
+				Suggestion: "Use a primitive type constraint, or change the field to be repeated or a map.",
+			},
+		},
+		"WithMismatchedStructAttrTypes": {
+			{
+				Severity: SchemaProblemError,
+				Decl:     "hcl.testschema.WithMismatchedStructAttrTypes.by_key",
+				Message:  "map field must have object or map type constraint",
+			},
+			{
+				Severity: SchemaProblemError,
+				Decl:     "hcl.testschema.WithMismatchedStructAttrTypes.items",
+				Message:  "list field must have tuple, list, or set type constraint",
+			},
+		},
+		"WithNestedMismatchedStructAttrType": {
+			{
+				Severity: SchemaProblemError,
+				Decl:     "hcl.testschema.WithMismatchedStructAttrTypes.by_key",
+				Message:  "map field must have object or map type constraint",
+			},
+			{
+				Severity: SchemaProblemError,
+				Decl:     "hcl.testschema.WithMismatchedStructAttrTypes.items",
+				Message:  "list field must have tuple, list, or set type constraint",
+			},
+		},
+		"WithSchemaWarnings": {
+			{
+				Severity:   SchemaProblemWarning,
+				Decl:       "hcl.testschema.WithSchemaWarnings.display_name",
+				Message:    `attribute name "displayName" does not follow the HCL convention of using only lowercase letters, digits, and underscores`,
+				Suggestion: `Use the name "display_name" instead.`,
+				Rule:       SchemaRuleNamingConvention,
+			},
+			{
+				Severity:   SchemaProblemWarning,
+				Decl:       "hcl.testschema.COLOR_RED",
+				Message:    `enum value has no HCL name, so configuration must select it using its protobuf name "COLOR_RED"`,
+				Suggestion: `Set (hcl.enumval).name to a conventional HCL-style name, such as "red".`,
+				Rule:       SchemaRuleEnumValueName,
+			},
+			{
+				Severity:   SchemaProblemWarning,
+				Decl:       "hcl.testschema.WithSchemaWarnings.internal_id",
+				Message:    "field has no HCL annotations, so decoding will never populate it",
+				Suggestion: "Add an (hcl.attr), (hcl.block), (hcl.label), or (hcl.flatten) option if this field should be populated from the configuration.",
+				Rule:       SchemaRuleUnannotatedField,
+			},
+		},
+		"WithLabelAttrOptions": {
+			{
+				Severity:   SchemaProblemWarning,
+				Decl:       "hcl.testschema.WithLabelAttrOptions.name",
+				Message:    "block labels are always required, so (hcl.attr).required has no effect",
+				Suggestion: "Remove (hcl.attr).required from this field.",
+				Rule:       SchemaRuleLabelRequired,
+			},
+			{
+				Severity:   SchemaProblemWarning,
+				Decl:       "hcl.testschema.WithLabelAttrOptions.name",
+				Message:    "(hcl.attr) options have no effect on a block label field",
+				Suggestion: "Remove the (hcl.attr) options from this field.",
+				Rule:       SchemaRuleLabelAttrOptions,
+			},
+		},
+		"WithFlattenLabelOrder": {
+			{
+				Severity:   SchemaProblemWarning,
+				Decl:       "hcl.testschema.WithFlattenLabelOrder.base",
+				Message:    "labels from this flattened message are combined with other labels of hcl.testschema.WithFlattenLabelOrder, so the label order depends on the order of the field declarations",
+				Suggestion: "Declare all of the labels in a single message, or make sure that the fields are declared in the intended label order.",
+				Rule:       SchemaRuleFlattenLabelOrder,
+			},
+		},
+	}
+
+	for name, want := range tests {
+		t.Run(name, func(t *testing.T) {
+			desc := testschema.File_testschema_proto.Messages().ByName(protoreflect.Name(name))
+			got := ValidateMessageDesc(desc)
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("wrong problems\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateMessageDescRawWithDefault(t *testing.T) {
+	// proto3 doesn't allow default values, so we need a proto2 schema for
+	// this one.
+	attrOpts := &descriptorpb.FieldOptions{}
+	proto.SetExtension(attrOpts, protohclext.E_Attr, &protohclext.Attribute{
+		Name: "raw",
+		Type: "any",
+		Raw:  protohclext.Attribute_JSON,
+	})
+	fileDesc, err := protodesc.NewFile(&descriptorpb.FileDescriptorProto{
+		Name:       proto.String("validate_raw_default_test.proto"),
+		Package:    proto.String("validate.test"),
+		Syntax:     proto.String("proto2"),
+		Dependency: []string{protohclext.File_hcl_proto.Path()},
+		MessageType: []*descriptorpb.DescriptorProto{
+			{
+				Name: proto.String("WithRawDefault"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					{
+						Name:         proto.String("raw"),
+						Number:       proto.Int32(1),
+						Label:        descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+						Type:         descriptorpb.FieldDescriptorProto_TYPE_BYTES.Enum(),
+						DefaultValue: proto.String("null"),
+						Options:      attrOpts,
+					},
+				},
+			},
+		},
+	}, protoregistry.GlobalFiles)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := ValidateMessageDesc(fileDesc.Messages().ByName("WithRawDefault"))
+	want := []SchemaProblem{
+		{
+			Severity:   SchemaProblemWarning,
+			Decl:       "validate.test.WithRawDefault.raw",
+			Message:    "raw-mode field has a default value, which will be decoded as a raw JSON encoding whenever the field is unset",
+			Suggestion: "Remove the default value from this field.",
+			Rule:       SchemaRuleRawWithDefault,
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("wrong problems\n%s", diff)
+	}
+}
+
+func TestConventionalName(t *testing.T) {
+	tests := map[string]string{
+		"name":         "name",
+		"display_name": "display_name",
+		"displayName":  "display_name",
+		"DisplayName":  "display_name",
+		"display-name": "display_name",
+		"HTTPPort":     "httpport",
+	}
+
+	for input, want := range tests {
+		t.Run(input, func(t *testing.T) {
+			if got := conventionalName(input); got != want {
+				t.Errorf("wrong result\ninput: %s\ngot:   %s\nwant:  %s", input, got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,353 @@
+package protohcl
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/apparentlymart/go-protohcl/protohcl/protohclext"
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// valueBody is an implementation of hcl.Body that presents an object value
+// as a body, so that the decoder can populate a message from a value in the
+// same way as from configuration source code.
+//
+// The value must have the shape that ObjectValueForMessage would produce for
+// the message type, with its default options: each nested block type is an
+// attribute whose value is an object for a singleton block, a map of objects
+// keyed by the first label for a map field, or a collection of objects
+// otherwise, and the block labels are string attributes of those objects.
+type valueBody struct {
+	val cty.Value
+	rng hcl.Range
+
+	// blockTypes describes the nested block types that the message type
+	// for this body declares, which we need in order to know how to find
+	// the blocks in the value.
+	blockTypes map[string]FieldNestedBlockType
+
+	// hidden are the names of any attributes that were already consumed
+	// by an earlier call to PartialContent.
+	hidden map[string]struct{}
+}
+
+var _ hcl.Body = valueBody{}
+
+func newValueBody(val cty.Value, rng hcl.Range, desc protoreflect.MessageDescriptor) valueBody {
+	blockTypes := make(map[string]FieldNestedBlockType)
+	collectNestedBlockTypes(desc, "", blockTypes)
+	return valueBody{
+		val:        val,
+		rng:        rng,
+		blockTypes: blockTypes,
+	}
+}
+
+func (b valueBody) Content(schema *hcl.BodySchema) (*hcl.BodyContent, hcl.Diagnostics) {
+	content, remain, diags := b.PartialContent(schema)
+	// PartialContent already reported any problems with the value itself.
+	extra, _ := remain.(valueBody).attrs()
+	for _, name := range sortedValueNames(extra) {
+		diags = diags.Append(&hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Unsupported argument",
+			Detail:   fmt.Sprintf("An argument named %q is not expected here.", name),
+			Subject:  b.rng.Ptr(),
+			Extra:    diagnosticExtra{Category: DiagnosticUnsupported},
+		})
+	}
+	return content, diags
+}
+
+func (b valueBody) PartialContent(schema *hcl.BodySchema) (*hcl.BodyContent, hcl.Body, hcl.Diagnostics) {
+	content := &hcl.BodyContent{
+		Attributes:       make(hcl.Attributes),
+		MissingItemRange: b.rng,
+	}
+	remain := valueBody{
+		val:        b.val,
+		rng:        b.rng,
+		blockTypes: b.blockTypes,
+		hidden:     make(map[string]struct{}, len(b.hidden)),
+	}
+	for name := range b.hidden {
+		remain.hidden[name] = struct{}{}
+	}
+
+	attrs, diags := b.attrs()
+	if diags.HasErrors() {
+		return content, remain, diags
+	}
+
+	for _, attrS := range schema.Attributes {
+		remain.hidden[attrS.Name] = struct{}{}
+		v, exists := attrs[attrS.Name]
+		if !exists {
+			if attrS.Required {
+				diags = diags.Append(&hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Missing required argument",
+					Detail:   fmt.Sprintf("The argument %q is required, but no definition was found.", attrS.Name),
+					Subject:  b.rng.Ptr(),
+					Extra:    diagnosticExtra{Category: DiagnosticMissingRequired},
+				})
+			}
+			continue
+		}
+		content.Attributes[attrS.Name] = &hcl.Attribute{
+			Name:      attrS.Name,
+			Expr:      hcl.StaticExpr(v, b.rng),
+			Range:     b.rng,
+			NameRange: b.rng,
+		}
+	}
+
+	for _, blockS := range schema.Blocks {
+		remain.hidden[blockS.Type] = struct{}{}
+		v, exists := attrs[blockS.Type]
+		if !exists || v.IsNull() {
+			continue
+		}
+		blocks, moreDiags := b.blocks(blockS, v)
+		diags = append(diags, moreDiags...)
+		content.Blocks = append(content.Blocks, blocks...)
+	}
+
+	return content, remain, diags
+}
+
+func (b valueBody) JustAttributes() (hcl.Attributes, hcl.Diagnostics) {
+	attrs, diags := b.attrs()
+	ret := make(hcl.Attributes, len(attrs))
+	for name, v := range attrs {
+		ret[name] = &hcl.Attribute{
+			Name:      name,
+			Expr:      hcl.StaticExpr(v, b.rng),
+			Range:     b.rng,
+			NameRange: b.rng,
+		}
+	}
+	return ret, diags
+}
+
+func (b valueBody) MissingItemRange() hcl.Range {
+	return b.rng
+}
+
+// attrs returns the attributes of the body's value that haven't been hidden
+// by an earlier call to PartialContent.
+func (b valueBody) attrs() (map[string]cty.Value, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+	switch {
+	case b.val.IsNull():
+		return nil, diags
+	case !b.val.IsKnown():
+		diags = diags.Append(&hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  unsuitableValueSummary,
+			Detail:   "The value for this body must be known.",
+			Subject:  b.rng.Ptr(),
+			Extra:    diagnosticExtra{Category: DiagnosticUnknownValue},
+		})
+		return nil, diags
+	case !(b.val.Type().IsObjectType() || b.val.Type().IsMapType()):
+		diags = diags.Append(&hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  unsuitableValueSummary,
+			Detail:   fmt.Sprintf("The value for this body must be an object, not %s.", b.val.Type().FriendlyName()),
+			Subject:  b.rng.Ptr(),
+			Extra:    diagnosticExtra{Category: DiagnosticValueError},
+		})
+		return nil, diags
+	}
+
+	all := b.val.AsValueMap()
+	ret := make(map[string]cty.Value, len(all))
+	for name, v := range all {
+		if _, hidden := b.hidden[name]; !hidden {
+			ret[name] = v
+		}
+	}
+	return ret, diags
+}
+
+// blocks returns the blocks of the given type that the given value
+// represents.
+func (b valueBody) blocks(blockS hcl.BlockHeaderSchema, v cty.Value) (hcl.Blocks, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+	if !v.IsKnown() {
+		diags = diags.Append(&hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  unsuitableValueSummary,
+			Detail:   fmt.Sprintf("The value for the %s blocks must be known.", blockS.Type),
+			Subject:  b.rng.Ptr(),
+			Extra:    diagnosticExtra{Category: DiagnosticUnknownValue},
+		})
+		return nil, diags
+	}
+	elem := b.blockTypes[blockS.Type]
+	ty := v.Type()
+
+	var ret hcl.Blocks
+	switch {
+	case elem.CollectionKind == protohclext.NestedBlock_MAP:
+		if !(ty.IsMapType() || ty.IsObjectType()) {
+			diags = diags.Append(b.blockTypeError(blockS, "a map of objects", ty))
+			return nil, diags
+		}
+		elems := v.AsValueMap()
+		for _, key := range sortedValueNames(elems) {
+			if elem.MapKeyAttr != "" {
+				// The key is in an attribute rather than a label, and so
+				// the object for each element can leave it out.
+				block, moreDiags := b.block(blockS, nil, withDefaultAttr(elems[key], elem.MapKeyAttr, cty.StringVal(key)))
+				diags = append(diags, moreDiags...)
+				if block != nil {
+					ret = append(ret, block)
+				}
+				continue
+			}
+			block, moreDiags := b.block(blockS, []string{key}, elems[key])
+			diags = append(diags, moreDiags...)
+			if block != nil {
+				ret = append(ret, block)
+			}
+		}
+	case elem.CollectionKind == protohclext.NestedBlock_AUTO:
+		// "AUTO" here really means singleton, as in ObjectValueForMessage.
+		if !(ty.IsObjectType() || ty.IsMapType()) {
+			diags = diags.Append(b.blockTypeError(blockS, "an object", ty))
+			return nil, diags
+		}
+		block, moreDiags := b.block(blockS, nil, v)
+		diags = append(diags, moreDiags...)
+		if block != nil {
+			ret = append(ret, block)
+		}
+	default:
+		if !(ty.IsListType() || ty.IsSetType() || ty.IsTupleType()) {
+			diags = diags.Append(b.blockTypeError(blockS, "a collection of objects", ty))
+			return nil, diags
+		}
+		for it := v.ElementIterator(); it.Next(); {
+			_, elemV := it.Element()
+			block, moreDiags := b.block(blockS, nil, elemV)
+			diags = append(diags, moreDiags...)
+			if block != nil {
+				ret = append(ret, block)
+			}
+		}
+	}
+	return ret, diags
+}
+
+// block returns the block that the given object value represents, taking
+// any labels that aren't in the given prefix from its attributes.
+func (b valueBody) block(blockS hcl.BlockHeaderSchema, labels []string, v cty.Value) (*hcl.Block, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+	if v.IsNull() || !v.IsKnown() || !(v.Type().IsObjectType() || v.Type().IsMapType()) {
+		diags = diags.Append(&hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  unsuitableValueSummary,
+			Detail:   fmt.Sprintf("Each %s block must be represented by a known, non-null object.", blockS.Type),
+			Subject:  b.rng.Ptr(),
+			Extra:    diagnosticExtra{Category: DiagnosticValueError},
+		})
+		return nil, diags
+	}
+
+	attrs := v.AsValueMap()
+	body := make(map[string]cty.Value, len(attrs))
+	for name, attrV := range attrs {
+		body[name] = attrV
+	}
+	for _, name := range blockS.LabelNames[len(labels):] {
+		labelV, exists := attrs[name]
+		if exists && !labelV.IsNull() && labelV.IsKnown() {
+			if strV, err := convert.Convert(labelV, cty.String); err == nil {
+				labels = append(labels, strV.AsString())
+				delete(body, name)
+				continue
+			}
+		}
+		// This is the same as the error HCL's native syntax returns for
+		// a block with too few labels, so that describeBlockLabels can
+		// explain it.
+		diags = diags.Append(&hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  fmt.Sprintf("Missing %s for %s", name, blockS.Type),
+			Detail: fmt.Sprintf(
+				"All %s blocks must have %d labels (%s).",
+				blockS.Type, len(blockS.LabelNames), strings.Join(blockS.LabelNames, ", "),
+			),
+			Subject: b.rng.Ptr(),
+		})
+		return nil, diags
+	}
+
+	labelRanges := make([]hcl.Range, len(labels))
+	for i := range labelRanges {
+		labelRanges[i] = b.rng
+	}
+	return &hcl.Block{
+		Type:   blockS.Type,
+		Labels: labels,
+		Body: valueBody{
+			val:        cty.ObjectVal(body),
+			rng:        b.rng,
+			blockTypes: b.nestedBlockTypes(blockS.Type),
+		},
+		DefRange:    b.rng,
+		TypeRange:   b.rng,
+		LabelRanges: labelRanges,
+	}, diags
+}
+
+func (b valueBody) nestedBlockTypes(typeName string) map[string]FieldNestedBlockType {
+	ret := make(map[string]FieldNestedBlockType)
+	if elem, ok := b.blockTypes[typeName]; ok {
+		collectNestedBlockTypes(elem.Nested, "", ret)
+	}
+	return ret
+}
+
+func (b valueBody) blockTypeError(blockS hcl.BlockHeaderSchema, want string, got cty.Type) *hcl.Diagnostic {
+	return &hcl.Diagnostic{
+		Severity: hcl.DiagError,
+		Summary:  unsuitableValueSummary,
+		Detail:   fmt.Sprintf("The value for the %s blocks must be %s, not %s.", blockS.Type, want, got.FriendlyName()),
+		Subject:  b.rng.Ptr(),
+		Extra:    diagnosticExtra{Category: DiagnosticValueError},
+	}
+}
+
+func sortedValueNames(vals map[string]cty.Value) []string {
+	names := make([]string, 0, len(vals))
+	for name := range vals {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// withDefaultAttr returns the given object value with the given attribute
+// set to the given value if it isn't already set to a non-null value. Any
+// other value is returned unchanged.
+func withDefaultAttr(v cty.Value, name string, def cty.Value) cty.Value {
+	if v.IsNull() || !v.IsKnown() || !(v.Type().IsObjectType() || v.Type().IsMapType()) {
+		return v
+	}
+	attrs := v.AsValueMap()
+	if existing, exists := attrs[name]; exists && !existing.IsNull() {
+		return v
+	}
+	if attrs == nil {
+		attrs = make(map[string]cty.Value, 1)
+	}
+	attrs[name] = def
+	return cty.ObjectVal(attrs)
+}
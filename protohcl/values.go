@@ -3,14 +3,14 @@ package protohcl
 import (
 	"fmt"
 
+	"github.com/apparentlymart/go-protohcl/protohcl/protohclcty"
 	"github.com/apparentlymart/go-protohcl/protohcl/protohclext"
 	"github.com/zclconf/go-cty/cty"
 	"github.com/zclconf/go-cty/cty/convert"
-	ctyjson "github.com/zclconf/go-cty/cty/json"
-	ctymsgpack "github.com/zclconf/go-cty/cty/msgpack"
 	"github.com/zclconf/go-ctypb/ctystructpb"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
 	"google.golang.org/protobuf/types/known/structpb"
 )
 
@@ -25,25 +25,81 @@ import (
 // of configuration input rather than object output, fields representing
 // nested blocks will be presented as either object values directly (for
 // singletons) or collections of object values (for repeated), based on the
-// (hcl.block).kind schema option. There is currently no way to return a
-// nested block type as a map using labels as keys.
+// (hcl.block).kind schema option. Nested block types declared as map fields
+// are presented as maps of object values, using the map key labels as keys.
+//
+// An unset attribute field with explicit presence, such as a proto3
+// "optional" field or a message-typed field, is presented as a null value of
+// the attribute's type, matching what the decoder does for a null value.
+// Fields without explicit presence can't distinguish being unset from having
+// their zero value, and so are always presented as their value.
+//
+// Use ObjectValueOptions to select other ways to present nested blocks.
 func ObjectValueForMessage(msg proto.Message) (cty.Value, error) {
+	return ObjectValueOptions{}.ObjectValueForMessage(msg)
+}
+
+// ObjectValueOptions represents optional settings that modify how
+// ObjectValueForMessage presents a message as an HCL value.
+//
+// The zero value of ObjectValueOptions selects the default behavior, and so
+// ObjectValueOptions{}.ObjectValueForMessage is equivalent to the
+// package-level function ObjectValueForMessage.
+type ObjectValueOptions struct {
+	// BlockLabelMaps, if set, causes each repeated nested block type whose
+	// blocks have exactly one label to be presented as a map of object
+	// values keyed by that label, rather than as the list, set, or tuple
+	// that its (hcl.block).kind would otherwise select. This allows
+	// expressions elsewhere to refer to a particular block by its label,
+	// as in service["web"], rather than by its position.
+	//
+	// If the nested block type's attributes have any "any" constraints then
+	// its blocks might not all have the same type, and so the result is an
+	// object value with one attribute per label instead of a map.
+	//
+	// Conversion fails with an error if more than one block has the same
+	// label.
+	BlockLabelMaps bool
+
+	// OmitMapKeys, if set, removes the attribute that duplicates each
+	// block's key from the objects of a nested block type that is
+	// presented as a map: the label selected by BlockLabelMaps, or the
+	// attribute named by (hcl.block).map_key_attr. The key then appears
+	// only once, as the map key, in both the values and the type
+	// constraints that result.
+	//
+	// The label of a map field using (hcl.block).map_key_label is never
+	// an attribute of the block objects, so this option doesn't affect
+	// those.
+	OmitMapKeys bool
+
+	// AnyTypes, if set, is the registry that ObjectValueForMessage uses to
+	// find the message types named by (hcl.attr).any_type options, as for
+	// DecodeOptions.AnyTypes.
+	AnyTypes protoregistry.MessageTypeResolver
+}
+
+// ObjectValueForMessage returns an HCL value which represents the
+// HCL-annotated fields from the given message, using the receiving options.
+//
+// See the package-level function ObjectValueForMessage for more information.
+func (opts ObjectValueOptions) ObjectValueForMessage(msg proto.Message) (cty.Value, error) {
 	reflectMsg := msg.ProtoReflect()
 	path := make(cty.Path, 0, 8) // allow a bit of nesting before we allocate again
 
-	return objectValueForMessage(reflectMsg, path)
+	return opts.objectValueForMessage(reflectMsg, path)
 }
 
-func objectValueForMessage(msg protoreflect.Message, path cty.Path) (cty.Value, error) {
+func (opts ObjectValueOptions) objectValueForMessage(msg protoreflect.Message, path cty.Path) (cty.Value, error) {
 	attrs := make(map[string]cty.Value)
-	err := buildObjectValueAttrsForMessage(msg, path, attrs)
+	err := opts.buildObjectValueAttrsForMessage(msg, FieldFlattened{}, path, attrs)
 	if err != nil {
 		return cty.DynamicVal, err
 	}
 	return cty.ObjectVal(attrs), nil
 }
 
-func buildObjectValueAttrsForMessage(msg protoreflect.Message, path cty.Path, attrs map[string]cty.Value) error {
+func (opts ObjectValueOptions) buildObjectValueAttrsForMessage(msg protoreflect.Message, outer FieldFlattened, path cty.Path, attrs map[string]cty.Value) error {
 	fields := msg.Descriptor().Fields()
 
 	for i := 0; i < fields.Len(); i++ {
@@ -53,6 +109,7 @@ func buildObjectValueAttrsForMessage(msg protoreflect.Message, path cty.Path, at
 		if err != nil {
 			return err
 		}
+		elem = flattenedElem(elem, outer)
 		if elem == nil {
 			continue // field is not relevant to HCL
 		}
@@ -60,19 +117,38 @@ func buildObjectValueAttrsForMessage(msg protoreflect.Message, path cty.Path, at
 		switch elem := elem.(type) {
 		case FieldAttribute:
 			path := append(path, cty.GetAttrStep{Name: elem.Name})
-			v, err := hclValueForProtoFieldValue(msg.Get(field), path, elem, false)
-			if err != nil {
-				return err
-			}
 
 			// We can lose type information while encoding to protobuf fields,
-			// and so we'll now convert back to the declared type constraint.
+			// and so we'll convert back to the declared type constraint.
 			ty, diags := elem.TypeConstraint()
 			if diags.HasErrors() {
 				return schemaErrorf(field.FullName(), "invalid type constraint expression")
 			}
 
-			v, err = convert.Convert(v, ty)
+			if fieldUnsetMeansNull(field, elem) && !msg.Has(field) {
+				// The decoder leaves a field with explicit presence unset
+				// when the attribute's value is null. The type constraint
+				// can mark attributes as optional, but a value's type can't.
+				attrs[elem.Name] = cty.NullVal(ty.WithoutOptionalAttributesDeep())
+				continue
+			}
+
+			v, err := opts.hclValueForProtoFieldValue(msg.Get(field), path, elem, false)
+			if err != nil {
+				return err
+			}
+			if elem.Unit != "" {
+				v, err = formatUnitValue(v, elem.Unit)
+				if err != nil {
+					return path.NewError(err)
+				}
+			}
+			v, err = parseNumberSyntax(v, elem.NumberSyntax)
+			if err != nil {
+				return path.NewError(err)
+			}
+
+			v, err = convert.Convert(emptyAsNull(v, elem), ty)
 			if err != nil {
 				return path.NewErrorf("invalid encoding of %s value as %s: %s", ty.FriendlyName(), field.Kind(), err)
 			}
@@ -81,10 +157,19 @@ func buildObjectValueAttrsForMessage(msg protoreflect.Message, path cty.Path, at
 		case FieldNestedBlockType:
 			path := append(path, cty.GetAttrStep{Name: elem.TypeName})
 
+			if elem.AnyDiscriminator != "" {
+				nestedObj, err := opts.objectValueForAnyBlock(msg.Get(field).Message(), elem, path)
+				if err != nil {
+					return err
+				}
+				attrs[elem.TypeName] = nestedObj
+				continue
+			}
+
 			if elem.CollectionKind == protohclext.NestedBlock_AUTO {
 				// "AUTO" here really means singleton
 				nestedMsg := msg.Get(field).Message()
-				nestedObj, err := objectValueForMessage(nestedMsg, path)
+				nestedObj, err := opts.objectValueForMessage(nestedMsg, path)
 				if err != nil {
 					return err
 				}
@@ -92,6 +177,40 @@ func buildObjectValueAttrsForMessage(msg protoreflect.Message, path cty.Path, at
 				continue
 			}
 
+			if elem.CollectionKind == protohclext.NestedBlock_MAP {
+				nestedTy, err := opts.ObjectTypeConstraintForMessageDesc(elem.Nested)
+				if err != nil {
+					return err
+				}
+				if nestedTy.HasDynamicTypes() {
+					// The elements of a map must all have the same type.
+					return schemaErrorf(field.FullName(), "can't use a map field for a block type containing an attribute with an 'any' constraint")
+				}
+				keyAttr, omitKey := opts.omittedMapKeyAttr(elem)
+				if omitKey {
+					nestedTy = typeWithoutAttr(nestedTy, keyAttr)
+				}
+				msgMap := msg.Get(field).Map()
+				if msgMap.Len() == 0 {
+					attrs[elem.TypeName] = cty.MapValEmpty(nestedTy)
+					continue
+				}
+				elems := make(map[string]cty.Value, msgMap.Len())
+				msgMap.Range(func(k protoreflect.MapKey, v protoreflect.Value) bool {
+					path := append(path, cty.IndexStep{Key: cty.StringVal(k.String())})
+					elems[k.String()], err = opts.objectValueForMessage(v.Message(), path)
+					if err == nil && omitKey {
+						elems[k.String()] = valueWithoutAttr(elems[k.String()], keyAttr)
+					}
+					return err == nil
+				})
+				if err != nil {
+					return err
+				}
+				attrs[elem.TypeName] = cty.MapVal(elems)
+				continue
+			}
+
 			// All of the other kinds call for us to build a slice of
 			// elems.
 			var elems []cty.Value
@@ -100,7 +219,7 @@ func buildObjectValueAttrsForMessage(msg protoreflect.Message, path cty.Path, at
 				elems = make([]cty.Value, listLen)
 				for i := range elems {
 					nestedMsg := msgList.Get(i).Message()
-					nestedObj, err := objectValueForMessage(nestedMsg, path)
+					nestedObj, err := opts.objectValueForMessage(nestedMsg, path)
 					if err != nil {
 						return err
 					}
@@ -108,38 +227,37 @@ func buildObjectValueAttrsForMessage(msg protoreflect.Message, path cty.Path, at
 				}
 			}
 
-			switch elem.CollectionKind {
-			case protohclext.NestedBlock_TUPLE:
-				attrs[elem.TypeName] = cty.TupleVal(elems)
-			case protohclext.NestedBlock_LIST:
-				if len(elems) == 0 {
-					nestedTy, err := ObjectTypeConstraintForMessageDesc(field.Message())
-					if err != nil {
-						return err
-					}
-					attrs[elem.TypeName] = cty.ListValEmpty(nestedTy)
-				} else {
-					attrs[elem.TypeName] = cty.ListVal(elems)
-				}
-			case protohclext.NestedBlock_SET:
-				if len(elems) == 0 {
-					nestedTy, err := ObjectTypeConstraintForMessageDesc(field.Message())
-					if err != nil {
-						return err
-					}
-					attrs[elem.TypeName] = cty.SetValEmpty(nestedTy)
-				} else {
-					attrs[elem.TypeName] = cty.SetVal(elems)
+			if labelName, ok := opts.blockLabelMapKey(elem); ok {
+				v, err := opts.blockLabelMapValue(elem, labelName, elems, path)
+				if err != nil {
+					return err
 				}
-			default:
-				return schemaErrorf(field.FullName(), "unsupported collection kind %s", elem.CollectionKind)
+				attrs[elem.TypeName] = v
+				continue
 			}
 
+			// We build a tuple first and then convert it to the type
+			// constraint for the collection kind, in the same way as for
+			// a repeated message-typed attribute.
+			nestedTy, err := opts.ObjectTypeConstraintForMessageDesc(elem.Nested)
+			if err != nil {
+				return err
+			}
+			ty, err := collectionTypeConstraint(field.FullName(), elem.CollectionKind, nestedTy, "(hcl.block).kind", "a block type")
+			if err != nil {
+				return err
+			}
+			v, err := convert.Convert(cty.TupleVal(elems), ty)
+			if err != nil {
+				return path.NewErrorf("invalid %s blocks: %s", elem.TypeName, err)
+			}
+			attrs[elem.TypeName] = v
+
 		case FieldFlattened:
 			// For flattened we'll keep writing into the same map, but we'll
 			// use the nested message as the source instead.
 			nestedMsg := msg.Get(field).Message()
-			err := buildObjectValueAttrsForMessage(nestedMsg, path, attrs)
+			err := opts.buildObjectValueAttrsForMessage(nestedMsg, elem, path, attrs)
 			if err != nil {
 				return err
 			}
@@ -162,29 +280,112 @@ func buildObjectValueAttrsForMessage(msg protoreflect.Message, path cty.Path, at
 	return nil
 }
 
-func hclValueForProtoFieldValue(val protoreflect.Value, path cty.Path, attr FieldAttribute, subElem bool) (cty.Value, error) {
+// blockLabelMapKey returns the name of the label to use as the key when
+// presenting the given repeated nested block type as a map, or false if the
+// options call for the nested block type's usual collection kind.
+func (opts ObjectValueOptions) blockLabelMapKey(elem FieldNestedBlockType) (string, bool) {
+	if !opts.BlockLabelMaps {
+		return "", false
+	}
+	switch elem.CollectionKind {
+	case protohclext.NestedBlock_LIST, protohclext.NestedBlock_SET, protohclext.NestedBlock_TUPLE:
+	default:
+		return "", false
+	}
+	names, err := blockLabelNames(elem.Nested, nil, make(map[string]protoreflect.FullName))
+	if err != nil || len(names) != 1 {
+		return "", false
+	}
+	return names[0], true
+}
+
+// blockLabelMapValue builds the map or object value for the given block
+// objects, keyed by the values of the given label.
+func (opts ObjectValueOptions) blockLabelMapValue(elem FieldNestedBlockType, labelName string, elems []cty.Value, path cty.Path) (cty.Value, error) {
+	nestedTy, err := opts.ObjectTypeConstraintForMessageDesc(elem.Nested)
+	if err != nil {
+		return cty.DynamicVal, err
+	}
+	if opts.OmitMapKeys {
+		nestedTy = typeWithoutAttr(nestedTy, labelName)
+	}
+	byLabel := make(map[string]cty.Value, len(elems))
+	for _, obj := range elems {
+		label := obj.GetAttr(labelName).AsString()
+		if _, exists := byLabel[label]; exists {
+			return cty.DynamicVal, path.NewErrorf("duplicate %s block with label %q", elem.TypeName, label)
+		}
+		if opts.OmitMapKeys {
+			obj = valueWithoutAttr(obj, labelName)
+		}
+		byLabel[label] = obj
+	}
+	if nestedTy.HasDynamicTypes() {
+		return cty.ObjectVal(byLabel), nil
+	}
+	if len(byLabel) == 0 {
+		return cty.MapValEmpty(nestedTy), nil
+	}
+	return cty.MapVal(byLabel), nil
+}
+
+// omittedMapKeyAttr returns the name of the attribute that the options call
+// for removing from the objects of the given map-kind nested block type,
+// because it duplicates each block's key, or false if there is none.
+func (opts ObjectValueOptions) omittedMapKeyAttr(elem FieldNestedBlockType) (string, bool) {
+	if !opts.OmitMapKeys || elem.MapKeyAttr == "" {
+		return "", false
+	}
+	return elem.MapKeyAttr, true
+}
+
+// valueWithoutAttr returns the given object value with the given attribute
+// removed.
+func valueWithoutAttr(obj cty.Value, name string) cty.Value {
+	attrs := obj.AsValueMap()
+	delete(attrs, name)
+	return cty.ObjectVal(attrs)
+}
+
+// typeWithoutAttr returns the given object type with the given attribute
+// removed, or the given type unchanged if it isn't an object type.
+func typeWithoutAttr(ty cty.Type, name string) cty.Type {
+	if !ty.IsObjectType() {
+		return ty
+	}
+	atys := make(map[string]cty.Type, len(ty.AttributeTypes()))
+	var optional []string
+	for n, aty := range ty.AttributeTypes() {
+		if n == name {
+			continue
+		}
+		atys[n] = aty
+		if ty.AttributeOptional(n) {
+			optional = append(optional, n)
+		}
+	}
+	return cty.ObjectWithOptionalAttrs(atys, optional)
+}
+
+func (opts ObjectValueOptions) hclValueForProtoFieldValue(val protoreflect.Value, path cty.Path, attr FieldAttribute, subElem bool) (cty.Value, error) {
 	// Here we're really using the subset of normal Go types that
 	// protoreflect.Value uses internally, which is good enough for our goals,
 	// since the caller will convert the result into the exact type that
 	// the field is supposed to produce, anyway.
 
 	switch raw := val.Interface().(type) {
-	case bool:
-		return cty.BoolVal(raw), nil
-	case int32:
-		return cty.NumberIntVal(int64(raw)), nil
-	case int64:
-		return cty.NumberIntVal(raw), nil
-	case uint32:
-		return cty.NumberUIntVal(uint64(raw)), nil
-	case uint64:
-		return cty.NumberUIntVal(raw), nil
-	case float32:
-		return cty.NumberFloatVal(float64(raw)), nil
-	case float64:
-		return cty.NumberFloatVal(raw), nil
-	case string:
-		return cty.StringVal(raw), nil
+	case bool, int32, int64, uint32, uint64, float32, float64, string, protoreflect.EnumNumber:
+		// Enum values become the same strings that select them in the
+		// configuration, so results can be compared with configured values.
+		field := attr.TargetField
+		if field.IsMap() {
+			field = field.MapValue()
+		}
+		v, err := protohclcty.FromProto(val, field)
+		if err != nil {
+			return cty.NilVal, path.NewError(err)
+		}
+		return v, nil
 	case []byte:
 		if subElem {
 			// We can only decode a "bytes" value that's directly in an
@@ -206,25 +407,16 @@ func hclValueForProtoFieldValue(val protoreflect.Value, path cty.Path, attr Fiel
 			return cty.NilVal, schemaErrorf(attr.TargetField.FullName(), "invalid type constraint expression")
 		}
 
-		var decode func([]byte, cty.Type) (cty.Value, error)
-		switch attr.RawMode {
-		case protohclext.Attribute_JSON:
-			decode = ctyjson.Unmarshal
-		case protohclext.Attribute_MESSAGEPACK:
-			decode = ctymsgpack.Unmarshal
-		default:
-			return cty.NilVal, schemaErrorf(attr.TargetField.FullName(), "unsupported raw mode %s", attr.RawMode)
+		codec, err := rawCodecFor(attr)
+		if err != nil {
+			return cty.NilVal, err
 		}
-
-		v, err := decode(raw, ty)
+		v, err := codec.Unmarshal(raw, ty)
 		if err != nil {
 			return cty.NilVal, path.NewErrorf("invalid encoding of %s value as bytes: %s", ty.FriendlyName(), err)
 		}
 		return v, nil
 
-	case protoreflect.EnumNumber:
-		// TODO: Handle this once we handle enum types elsewhere too
-		return cty.NilVal, schemaErrorf(attr.TargetField.FullName(), "can't convert enum value to HCL value yet")
 	case protoreflect.Message:
 		// Recursively transform the child message too, then,
 		// but there are some message types we treat in a special way.
@@ -238,24 +430,40 @@ func hclValueForProtoFieldValue(val protoreflect.Value, path cty.Path, attr Fiel
 				// annotated field, because we need a type constraint.
 				return cty.NilVal, schemaErrorf(attr.TargetField.FullName(), "can only use google.protobuf.Value directly as an annotated field, not as nested element inside one")
 			}
-			sv, ok := raw.Interface().(*structpb.Value)
-			if !ok {
-				return cty.NilVal, schemaErrorf(attr.TargetField.FullName(), "dynamic type is not *structpb.Value")
-			}
 			wantTy, diags := attr.TypeConstraint()
 			if diags.HasErrors() {
 				return cty.NilVal, schemaErrorf(attr.TargetField.FullName(), "invalid HCL type constraint")
 			}
-			v, err := ctystructpb.FromStructValue(sv, wantTy)
+			return hclValueForStructpbValue(raw, path, attr, wantTy)
+		}
+		if valueField := wrapperValueField(matchDesc.Message()); valueField != nil {
+			// The caller handles an unset wrapper field as null.
+			v, err := protohclcty.FromProto(raw.Get(valueField), valueField)
 			if err != nil {
-				return cty.NilVal, path.NewErrorf("invalid encoding of %s value as google.protobuf.Value: %s", wantTy.FriendlyName(), err)
+				return cty.NilVal, path.NewError(err)
 			}
 			return v, nil
 		}
+		if isTimestampMessage(matchDesc.Message()) {
+			return hclValueForTimestamp(raw, path)
+		}
+		if isDurationMessage(matchDesc.Message()) {
+			return hclValueForDuration(raw, path)
+		}
+		if isStructpbContainerMessage(matchDesc.Message()) {
+			return hclValueForStructpbContainer(raw, path, attr)
+		}
+		if attr.AnyType != "" {
+			return opts.hclValueForAnyAttr(raw, path, attr)
+		}
 
-		return objectValueForMessage(raw, path)
+		// The options for presenting blocks don't apply inside attribute
+		// values, but the message might contain Any attributes of its own.
+		return ObjectValueOptions{AnyTypes: opts.AnyTypes}.objectValueForMessage(raw, path)
 	case protoreflect.List:
-		// TODO: Handle the special case for a list of structpb.Value, similar to the protoreflect.Message case above
+		if !subElem && isStructpbField(attr.TargetField) {
+			return hclValueForStructpbList(raw, path, attr)
+		}
 
 		// We always return a tuple at this layer, but the caller might then
 		// convert it into a list or set if the field options call for it.
@@ -265,7 +473,7 @@ func hclValueForProtoFieldValue(val protoreflect.Value, path cty.Path, attr Fiel
 		elems := make([]cty.Value, raw.Len())
 		for i := range elems {
 			path := append(path, cty.IndexStep{Key: cty.NumberIntVal(int64(i))})
-			elemVal, err := hclValueForProtoFieldValue(raw.Get(i), path, attr, true)
+			elemVal, err := opts.hclValueForProtoFieldValue(raw.Get(i), path, attr, true)
 			if err != nil {
 				return cty.NilVal, err
 			}
@@ -273,7 +481,9 @@ func hclValueForProtoFieldValue(val protoreflect.Value, path cty.Path, attr Fiel
 		}
 		return cty.TupleVal(elems), nil
 	case protoreflect.Map:
-		// TODO: Handle the special case for a map of structpb.Value, similar to the protoreflect.Message case above
+		if !subElem && isStructpbField(attr.TargetField) {
+			return hclValueForStructpbMap(raw, path, attr)
+		}
 
 		// We always return an object at this layer, but the caller might then
 		// convert it into a list or set if the field options call for it.
@@ -293,7 +503,7 @@ func hclValueForProtoFieldValue(val protoreflect.Value, path cty.Path, attr Fiel
 			}
 
 			path := append(path, cty.IndexStep{Key: cty.StringVal(k)})
-			attrs[k], err = hclValueForProtoFieldValue(protoV, path, attr, true)
+			attrs[k], err = opts.hclValueForProtoFieldValue(protoV, path, attr, true)
 			if err != nil {
 				return false
 			}
@@ -309,3 +519,104 @@ func hclValueForProtoFieldValue(val protoreflect.Value, path cty.Path, attr Fiel
 		return cty.NilVal, schemaErrorf(attr.TargetField.FullName(), "can't convert %T to HCL value", raw)
 	}
 }
+
+// isStructpbField returns true if the given field's element type is
+// google.protobuf.Value.
+func isStructpbField(field protoreflect.FieldDescriptor) bool {
+	if field.IsMap() {
+		field = field.MapValue()
+	}
+	return isMessageKind(field.Kind()) && field.Message().FullName() == structpbValueDesc.FullName()
+}
+
+// hclValueForStructpbList is the opposite of the list case of
+// structpbAttrMessageBuilder, returning a tuple value that the caller will
+// convert to the attribute's type constraint.
+func hclValueForStructpbList(raw protoreflect.List, path cty.Path, attr FieldAttribute) (cty.Value, error) {
+	wantTy, diags := attr.TypeConstraint()
+	if diags.HasErrors() {
+		return cty.NilVal, schemaErrorf(attr.TargetField.FullName(), "invalid HCL type constraint")
+	}
+	if raw.Len() == 0 && wantTy.IsTupleType() && len(wantTy.TupleElementTypes()) != 0 {
+		// An empty list is how we represent a null tuple.
+		return cty.NullVal(wantTy), nil
+	}
+	if wantTy.IsTupleType() && len(wantTy.TupleElementTypes()) != raw.Len() {
+		return cty.NilVal, path.NewErrorf("invalid encoding of %s value: has %d elements", wantTy.FriendlyName(), raw.Len())
+	}
+	if raw.Len() == 0 {
+		return cty.EmptyTupleVal, nil
+	}
+	elems := make([]cty.Value, raw.Len())
+	for i := range elems {
+		path := append(path, cty.IndexStep{Key: cty.NumberIntVal(int64(i))})
+		v, err := hclValueForStructpbValue(raw.Get(i).Message(), path, attr, structpbListElementType(wantTy, i))
+		if err != nil {
+			return cty.NilVal, err
+		}
+		elems[i] = v
+	}
+	return cty.TupleVal(elems), nil
+}
+
+// hclValueForStructpbMap is the opposite of the map case of
+// structpbAttrMessageBuilder, returning an object value that the caller
+// will convert to the attribute's type constraint.
+func hclValueForStructpbMap(raw protoreflect.Map, path cty.Path, attr FieldAttribute) (cty.Value, error) {
+	wantTy, diags := attr.TypeConstraint()
+	if diags.HasErrors() {
+		return cty.NilVal, schemaErrorf(attr.TargetField.FullName(), "invalid HCL type constraint")
+	}
+	if raw.Len() == 0 {
+		return cty.EmptyObjectVal, nil
+	}
+	attrs := make(map[string]cty.Value, raw.Len())
+	var err error
+	raw.Range(func(protoK protoreflect.MapKey, protoV protoreflect.Value) bool {
+		k := protoK.String()
+		path := append(path, cty.IndexStep{Key: cty.StringVal(k)})
+		wantEty, ok := structpbMapElementType(wantTy, k)
+		if !ok {
+			err = path.NewErrorf("invalid encoding of %s value: unexpected attribute %q", wantTy.FriendlyName(), k)
+			return false
+		}
+		attrs[k], err = hclValueForStructpbValue(protoV.Message(), path, attr, wantEty)
+		return err == nil
+	})
+	if err != nil {
+		return cty.NilVal, err
+	}
+	return cty.ObjectVal(attrs), nil
+}
+
+func hclValueForStructpbValue(raw protoreflect.Message, path cty.Path, attr FieldAttribute, wantTy cty.Type) (cty.Value, error) {
+	sv, ok := raw.Interface().(*structpb.Value)
+	if !ok {
+		return cty.NilVal, schemaErrorf(attr.TargetField.FullName(), "dynamic type is not *structpb.Value")
+	}
+	if sv == nil || sv.Kind == nil {
+		// An unset field, which represents null.
+		return cty.NullVal(wantTy), nil
+	}
+	v, err := ctystructpb.FromStructValue(sv, wantTy)
+	if err != nil {
+		return cty.NilVal, path.NewErrorf("invalid encoding of %s value as google.protobuf.Value: %s", wantTy.FriendlyName(), err)
+	}
+	return v, nil
+}
+
+// fieldUnsetMeansNull returns true if ObjectValueForMessage should represent
+// the given attribute's field as null when it's unset, rather than as the
+// field's zero value.
+//
+// That's true for any singular field with explicit presence, such as a
+// message-typed field, a proto3 "optional" field, or a field with explicit
+// presence under protobuf editions, except for google.protobuf.Value fields
+// and raw-mode fields, which have their own representations of null, and
+// fields with a default value, for which the default value is more useful.
+func fieldUnsetMeansNull(field protoreflect.FieldDescriptor, attr FieldAttribute) bool {
+	if field.Cardinality() == protoreflect.Repeated || !field.HasPresence() || field.HasDefault() {
+		return false
+	}
+	return !isStructpbField(field) && attr.RawMode == protohclext.Attribute_NOT_RAW
+}
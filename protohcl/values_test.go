@@ -1,6 +1,7 @@
 package protohcl
 
 import (
+	"math"
 	"testing"
 
 	"github.com/apparentlymart/go-protohcl/protohcl/internal/testschema"
@@ -35,6 +36,27 @@ func TestObjectValueForMessage(t *testing.T) {
 			}),
 			``,
 		},
+		"optional attributes set to zero values": {
+			&testschema.WithOptionalScalarAttrs{
+				Description: proto.String(""),
+				Count:       proto.Int64(0),
+			},
+			cty.ObjectVal(map[string]cty.Value{
+				"description": cty.StringVal(""),
+				"count":       cty.Zero,
+			}),
+			``,
+		},
+		"optional attributes unset": {
+			&testschema.WithOptionalScalarAttrs{},
+			cty.ObjectVal(map[string]cty.Value{
+				// These fields have explicit presence, so being unset
+				// represents null.
+				"description": cty.NullVal(cty.String),
+				"count":       cty.NullVal(cty.Number),
+			}),
+			``,
+		},
 		"bool attribute true": {
 			&testschema.WithBoolAttr{
 				DoTheThing: true,
@@ -53,6 +75,94 @@ func TestObjectValueForMessage(t *testing.T) {
 			}),
 			``,
 		},
+		"enum attribute": {
+			&testschema.WithEnumAttr{
+				Level: testschema.Level_LEVEL_DEBUG,
+			},
+			cty.ObjectVal(map[string]cty.Value{
+				"level": cty.StringVal("debug"),
+			}),
+			``,
+		},
+		"enum attribute unset": {
+			&testschema.WithEnumAttr{},
+			cty.ObjectVal(map[string]cty.Value{
+				// LEVEL_UNSPECIFIED has no (hcl.enumval).name, so it uses
+				// its protobuf name.
+				"level": cty.StringVal("LEVEL_UNSPECIFIED"),
+			}),
+			``,
+		},
+		"enum attribute with undeclared number": {
+			&testschema.WithEnumAttr{
+				Level: testschema.Level(12),
+			},
+			cty.NilVal,
+			`hcl.testschema.Level has no value numbered 12`,
+		},
+		"enum list attribute": {
+			&testschema.WithEnumListAttr{
+				Levels: []testschema.Level{
+					testschema.Level_LEVEL_INFO,
+					testschema.Level_LEVEL_DEBUG,
+				},
+			},
+			cty.ObjectVal(map[string]cty.Value{
+				"levels": cty.ListVal([]cty.Value{
+					cty.StringVal("info"),
+					cty.StringVal("debug"),
+				}),
+			}),
+			``,
+		},
+		"enum map attribute": {
+			&testschema.WithEnumMapAttr{
+				Levels: map[string]testschema.Level{
+					"a": testschema.Level_LEVEL_INFO,
+				},
+			},
+			cty.ObjectVal(map[string]cty.Value{
+				"levels": cty.MapVal(map[string]cty.Value{
+					"a": cty.StringVal("info"),
+				}),
+			}),
+			``,
+		},
+		"object attribute": {
+			&testschema.WithObjectAttr{
+				Settings: &testschema.WithOptionalAttrs{Name: "a", Count: 2},
+			},
+			cty.ObjectVal(map[string]cty.Value{
+				"settings": cty.ObjectVal(map[string]cty.Value{
+					"name":  cty.StringVal("a"),
+					"count": cty.NumberIntVal(2),
+				}),
+			}),
+			``,
+		},
+		"list of objects containing maps of objects": {
+			&testschema.WithNestedObjectsAttr{
+				Groups: []*testschema.WithMapOfObjectsAttr{
+					{
+						Pets: map[string]*testschema.WithStringAttr{
+							"jackson": {Name: "Jackson"},
+						},
+					},
+				},
+			},
+			cty.ObjectVal(map[string]cty.Value{
+				"groups": cty.ListVal([]cty.Value{
+					cty.ObjectVal(map[string]cty.Value{
+						"pets": cty.MapVal(map[string]cty.Value{
+							"jackson": cty.ObjectVal(map[string]cty.Value{
+								"name": cty.StringVal("Jackson"),
+							}),
+						}),
+					}),
+				}),
+			}),
+			``,
+		},
 		"number attribute from int32": {
 			&testschema.WithNumberAttrAsInt32{
 				Num: 12,
@@ -148,6 +258,24 @@ func TestObjectValueForMessage(t *testing.T) {
 			}),
 			``,
 		},
+		"float and double attributes": {
+			&testschema.WithNumberAttrsAsFloat{
+				Single: float32(math.Inf(1)),
+				Double: math.Copysign(0, -1),
+			},
+			cty.ObjectVal(map[string]cty.Value{
+				"single": cty.PositiveInfinity,
+				"double": cty.Zero,
+			}),
+			``,
+		},
+		"double attribute NaN": {
+			&testschema.WithNumberAttrsAsFloat{
+				Double: math.NaN(),
+			},
+			cty.NilVal,
+			`NaN is not a supported number value`,
+		},
 		"raw dynamic attribute containing garbage": {
 			&testschema.WithRawDynamicAttr{
 				// protohcl should never produce garbage like this itself,
@@ -156,7 +284,7 @@ func TestObjectValueForMessage(t *testing.T) {
 				Raw: []byte(`{invalid`),
 			},
 			cty.NilVal,
-			`invalid encoding of dynamic value as bytes: failed to read dynamic type descriptor key: invalid character 'i'`,
+			`invalid encoding of dynamic value as bytes: invalid raw dynamic value: invalid character 'i' looking for beginning of object key string`,
 		},
 		"structpb.Value dynamic string": {
 			&testschema.WithStructDynamicAttr{
@@ -337,6 +465,49 @@ func TestObjectValueForMessage(t *testing.T) {
 			}),
 			``,
 		},
+		"map of nested blocks": {
+			&testschema.WithMapOfBlocks{
+				Pets: map[string]*testschema.WithStringAttr{
+					"jackson": {Name: "Jackson"},
+					"snakob":  {Name: "Snakob"},
+				},
+			},
+			cty.ObjectVal(map[string]cty.Value{
+				"pet": cty.MapVal(map[string]cty.Value{
+					"jackson": cty.ObjectVal(map[string]cty.Value{
+						"name": cty.StringVal("Jackson"),
+					}),
+					"snakob": cty.ObjectVal(map[string]cty.Value{
+						"name": cty.StringVal("Snakob"),
+					}),
+				}),
+			}),
+			``,
+		},
+		"map of nested blocks empty": {
+			&testschema.WithMapOfBlocks{},
+			cty.ObjectVal(map[string]cty.Value{
+				"pet": cty.MapValEmpty(cty.Object(map[string]cty.Type{
+					"name": cty.String,
+				})),
+			}),
+			``,
+		},
+		"map of objects attribute": {
+			&testschema.WithMapOfObjectsAttr{
+				Pets: map[string]*testschema.WithStringAttr{
+					"jackson": {Name: "Jackson"},
+				},
+			},
+			cty.ObjectVal(map[string]cty.Value{
+				"pets": cty.MapVal(map[string]cty.Value{
+					"jackson": cty.ObjectVal(map[string]cty.Value{
+						"name": cty.StringVal("Jackson"),
+					}),
+				}),
+			}),
+			``,
+		},
 	}
 
 	for name, test := range tests {
@@ -377,3 +548,237 @@ func TestObjectValueForMessage(t *testing.T) {
 		})
 	}
 }
+
+func TestObjectValueOptionsBlockLabelMaps(t *testing.T) {
+	opts := ObjectValueOptions{BlockLabelMaps: true}
+	doodadTy := cty.Object(map[string]cty.Type{
+		"name":     cty.String,
+		"nickname": cty.String,
+	})
+
+	tests := map[string]struct {
+		msg     proto.Message
+		want    cty.Value
+		wantErr string
+	}{
+		"one label": {
+			&testschema.WithNestedBlockOneLabelRepeated{
+				Doodad: []*testschema.WithOneBlockLabel{
+					{Name: "web", Nickname: "W"},
+					{Name: "db", Nickname: "D"},
+				},
+			},
+			cty.ObjectVal(map[string]cty.Value{
+				"doodad": cty.MapVal(map[string]cty.Value{
+					"web": cty.ObjectVal(map[string]cty.Value{
+						"name":     cty.StringVal("web"),
+						"nickname": cty.StringVal("W"),
+					}),
+					"db": cty.ObjectVal(map[string]cty.Value{
+						"name":     cty.StringVal("db"),
+						"nickname": cty.StringVal("D"),
+					}),
+				}),
+			}),
+			``,
+		},
+		"one label empty": {
+			&testschema.WithNestedBlockOneLabelRepeated{},
+			cty.ObjectVal(map[string]cty.Value{
+				"doodad": cty.MapValEmpty(doodadTy),
+			}),
+			``,
+		},
+		"one label duplicate": {
+			&testschema.WithNestedBlockOneLabelRepeated{
+				Doodad: []*testschema.WithOneBlockLabel{
+					{Name: "web"},
+					{Name: "web"},
+				},
+			},
+			cty.NilVal,
+			`duplicate doodad block with label "web"`,
+		},
+		"one label with dynamic attribute": {
+			&testschema.WithNestedBlockOneLabelDynamic{
+				Doodad: []*testschema.WithOneBlockLabelDynamic{
+					{Name: "a", Value: []byte(`{"version":1,"type":"string","value":"hello"}`)},
+					{Name: "b", Value: []byte(`{"version":1,"type":"number","value":1}`)},
+				},
+			},
+			cty.ObjectVal(map[string]cty.Value{
+				"doodad": cty.ObjectVal(map[string]cty.Value{
+					"a": cty.ObjectVal(map[string]cty.Value{
+						"name":  cty.StringVal("a"),
+						"value": cty.StringVal("hello"),
+					}),
+					"b": cty.ObjectVal(map[string]cty.Value{
+						"name":  cty.StringVal("b"),
+						"value": cty.NumberIntVal(1),
+					}),
+				}),
+			}),
+			``,
+		},
+		"two labels": {
+			// Blocks with more than one label keep their usual
+			// collection kind.
+			&testschema.WithNestedBlockTwoLabelRepeated{},
+			cty.ObjectVal(map[string]cty.Value{
+				"doodad": cty.EmptyTupleVal,
+			}),
+			``,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := opts.ObjectValueForMessage(test.msg)
+			if test.wantErr != "" {
+				if err == nil {
+					t.Fatalf("unexpected success\ngot: %#v", got)
+				}
+				if got := err.Error(); got != test.wantErr {
+					t.Errorf("wrong error\ngot:  %s\nwant: %s", got, test.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(test.want, got, ctydebug.CmpOptions); diff != "" {
+				t.Errorf("wrong result\n%s", diff)
+			}
+
+			ty, err := opts.ObjectTypeConstraintForMessageDesc(test.msg.ProtoReflect().Descriptor())
+			if err != nil {
+				t.Fatalf("unexpected error getting type constraint: %s", err)
+			}
+			if _, err := convert.Convert(got, ty); err != nil {
+				t.Errorf("result doesn't conform to type constraint %#v: %s", ty, err)
+			}
+		})
+	}
+}
+
+func TestObjectValueOptionsOmitMapKeys(t *testing.T) {
+	opts := ObjectValueOptions{BlockLabelMaps: true, OmitMapKeys: true}
+
+	tests := map[string]struct {
+		msg    proto.Message
+		want   cty.Value
+		wantTy cty.Type
+	}{
+		"block label map": {
+			&testschema.WithNestedBlockOneLabelRepeated{
+				Doodad: []*testschema.WithOneBlockLabel{
+					{Name: "web", Nickname: "W"},
+				},
+			},
+			cty.ObjectVal(map[string]cty.Value{
+				"doodad": cty.MapVal(map[string]cty.Value{
+					"web": cty.ObjectVal(map[string]cty.Value{
+						"nickname": cty.StringVal("W"),
+					}),
+				}),
+			}),
+			cty.Object(map[string]cty.Type{
+				"doodad": cty.Map(cty.Object(map[string]cty.Type{
+					"nickname": cty.String,
+				})),
+			}),
+		},
+		"block label map empty": {
+			&testschema.WithNestedBlockOneLabelRepeated{},
+			cty.ObjectVal(map[string]cty.Value{
+				"doodad": cty.MapValEmpty(cty.Object(map[string]cty.Type{
+					"nickname": cty.String,
+				})),
+			}),
+			cty.Object(map[string]cty.Type{
+				"doodad": cty.Map(cty.Object(map[string]cty.Type{
+					"nickname": cty.String,
+				})),
+			}),
+		},
+		"map keyed by attribute": {
+			&testschema.WithMapOfBlocksKeyedByAttr{
+				Pets: map[string]*testschema.WithOptionalAttrs{
+					"Fido": {Name: "Fido", Count: 2},
+				},
+			},
+			cty.ObjectVal(map[string]cty.Value{
+				"pet": cty.MapVal(map[string]cty.Value{
+					"Fido": cty.ObjectVal(map[string]cty.Value{
+						"count": cty.NumberIntVal(2),
+					}),
+				}),
+			}),
+			cty.Object(map[string]cty.Type{
+				"pet": cty.Map(cty.Object(map[string]cty.Type{
+					"count": cty.Number,
+				})),
+			}),
+		},
+		"map keyed by label": {
+			// The extra label of a map field isn't an attribute of the
+			// block objects, so there's nothing to omit.
+			&testschema.WithMapOfBlocks{
+				Pets: map[string]*testschema.WithStringAttr{
+					"Fido": {Name: "Fido"},
+				},
+			},
+			cty.ObjectVal(map[string]cty.Value{
+				"pet": cty.MapVal(map[string]cty.Value{
+					"Fido": cty.ObjectVal(map[string]cty.Value{
+						"name": cty.StringVal("Fido"),
+					}),
+				}),
+			}),
+			cty.Object(map[string]cty.Type{
+				"pet": cty.Map(cty.Object(map[string]cty.Type{
+					"name": cty.String,
+				})),
+			}),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := opts.ObjectValueForMessage(test.msg)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(test.want, got, ctydebug.CmpOptions); diff != "" {
+				t.Errorf("wrong result\n%s", diff)
+			}
+
+			gotTy, err := opts.ObjectTypeConstraintForMessageDesc(test.msg.ProtoReflect().Descriptor())
+			if err != nil {
+				t.Fatalf("unexpected error getting type constraint: %s", err)
+			}
+			if diff := cmp.Diff(test.wantTy, gotTy, ctydebug.CmpOptions); diff != "" {
+				t.Errorf("wrong type constraint\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestObjectValueForMessageUnsetObjectAttr(t *testing.T) {
+	// The decoder leaves the field unset for a null value, so this is the
+	// opposite of that. This is separate from TestObjectValueForMessage
+	// because ctydebug.CmpOptions can't compare null object values.
+	got, err := ObjectValueForMessage(&testschema.WithObjectAttr{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := cty.ObjectVal(map[string]cty.Value{
+		"settings": cty.NullVal(cty.Object(map[string]cty.Type{
+			"name":  cty.String,
+			"count": cty.Number,
+		})),
+	})
+	if !got.RawEquals(want) {
+		t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, want)
+	}
+}
@@ -0,0 +1,132 @@
+package protohcl
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// bodyVariables returns all of the variable references in the expressions
+// that DecodeBody would evaluate when decoding the given body into a message
+// conforming to the given descriptor, including expressions in nested blocks.
+//
+// bodyVariables makes a best effort to find references in bodies that are
+// invalid, and silently ignores any problems it encounters, because decoding
+// will report those problems in more detail later.
+func bodyVariables(body hcl.Body, desc protoreflect.MessageDescriptor) []hcl.Traversal {
+	schema, err := bodySchema(desc)
+	if err != nil {
+		return nil
+	}
+	content, _, _ := body.PartialContent(schema)
+	if content == nil {
+		return nil
+	}
+
+	var ret []hcl.Traversal
+	for _, attrS := range schema.Attributes {
+		attr, exists := content.Attributes[attrS.Name]
+		if !exists {
+			continue
+		}
+		ret = append(ret, attr.Expr.Variables()...)
+	}
+
+	nestedDescs := map[string]protoreflect.MessageDescriptor{}
+	collectNestedBlockDescs(desc, "", nestedDescs)
+	for _, block := range content.Blocks {
+		nestedDesc, exists := nestedDescs[block.Type]
+		if !exists {
+			continue
+		}
+		ret = append(ret, bodyVariables(block.Body, nestedDesc)...)
+	}
+
+	return ret
+}
+
+// collectNestedBlockDescs populates the given map with the nested message
+// descriptor for each of the nested block types declared in the given
+// message descriptor, including those from flattened messages, whose type
+// names then include the given prefix. Block type aliases each have their
+// own entry.
+func collectNestedBlockDescs(desc protoreflect.MessageDescriptor, prefix string, into map[string]protoreflect.MessageDescriptor) {
+	fields := desc.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		elem, err := GetFieldElem(fields.Get(i))
+		if err != nil {
+			continue // we handle these errors during schema construction
+		}
+		switch elem := withNamePrefix(elem, prefix).(type) {
+		case FieldNestedBlockType:
+			into[elem.TypeName] = elem.Nested
+			for _, alias := range elem.Aliases {
+				into[alias] = elem.Nested
+			}
+		case FieldFlattened:
+			collectNestedBlockDescs(elem.Nested, elem.Prefix, into)
+		}
+	}
+}
+
+// missingVariablesDiagnostics returns an error diagnostic for each distinct
+// root variable name referenced in the given traversals that isn't defined
+// in the given evaluation context or any of its parents.
+//
+// Each diagnostic reports the first reference to its variable as its subject,
+// and lists all of the references in its detail message.
+func missingVariablesDiagnostics(traversals []hcl.Traversal, ctx *hcl.EvalContext) hcl.Diagnostics {
+	var diags hcl.Diagnostics
+
+	var names []string
+	uses := map[string][]hcl.Range{}
+	for _, traversal := range traversals {
+		name := traversal.RootName()
+		if evalContextHasVariable(ctx, name) {
+			continue
+		}
+		if _, exists := uses[name]; !exists {
+			names = append(names, name)
+		}
+		uses[name] = append(uses[name], traversal.SourceRange())
+	}
+
+	for _, name := range names {
+		rngs := uses[name]
+		sort.SliceStable(rngs, func(i, j int) bool {
+			if rngs[i].Filename != rngs[j].Filename {
+				return rngs[i].Filename < rngs[j].Filename
+			}
+			return rngs[i].Start.Byte < rngs[j].Start.Byte
+		})
+
+		var buf strings.Builder
+		fmt.Fprintf(&buf, "There is no variable named %q. It is referenced at the following locations:", name)
+		for _, rng := range rngs {
+			fmt.Fprintf(&buf, "\n  - %s", rng)
+		}
+
+		diags = diags.Append(&hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Unknown variable",
+			Detail:   buf.String(),
+			Subject:  rngs[0].Ptr(),
+		})
+	}
+
+	return diags
+}
+
+// evalContextHasVariable returns true if the given evaluation context, or any
+// of its parents, defines a variable with the given name.
+func evalContextHasVariable(ctx *hcl.EvalContext, name string) bool {
+	for ; ctx != nil; ctx = ctx.Parent() {
+		if _, exists := ctx.Variables[name]; exists {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,79 @@
+package protohcl
+
+import (
+	"github.com/apparentlymart/go-protohcl/protohcl/protohclcty"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// wrapperMessageNames are the google.protobuf wrapper message types that can
+// be the type of an attribute, to represent a scalar value that can be null.
+//
+// google.protobuf.BytesValue isn't included, because HCL has no type that
+// corresponds with bytes.
+var wrapperMessageNames = map[protoreflect.FullName]struct{}{}
+
+func init() {
+	for _, msg := range []protoreflect.ProtoMessage{
+		(*wrapperspb.BoolValue)(nil),
+		(*wrapperspb.StringValue)(nil),
+		(*wrapperspb.Int32Value)(nil),
+		(*wrapperspb.Int64Value)(nil),
+		(*wrapperspb.UInt32Value)(nil),
+		(*wrapperspb.UInt64Value)(nil),
+		(*wrapperspb.FloatValue)(nil),
+		(*wrapperspb.DoubleValue)(nil),
+	} {
+		wrapperMessageNames[msg.ProtoReflect().Descriptor().FullName()] = struct{}{}
+	}
+}
+
+// wrapperValueField returns the "value" field of the given message type if
+// it is one of the google.protobuf wrapper types that an attribute can use,
+// or nil otherwise.
+func wrapperValueField(desc protoreflect.MessageDescriptor) protoreflect.FieldDescriptor {
+	if _, ok := wrapperMessageNames[desc.FullName()]; !ok {
+		return nil
+	}
+	return desc.Fields().ByName("value")
+}
+
+// wrapperAttrMessageBuilder is the strategy for a singular field of one of
+// the google.protobuf wrapper types, which wraps a scalar value so that the
+// field can also represent null by being unset.
+func wrapperAttrMessageBuilder(desc protoreflect.FieldDescriptor, valueField protoreflect.FieldDescriptor) attrMessageBuilder {
+	return func(v cty.Value, path cty.Path, parentMessage protoreflect.Message) (protoreflect.Value, error) {
+		if v.IsNull() {
+			// A null value just leaves the field unset.
+			return nilProtoValue, nil
+		}
+		if !v.IsKnown() {
+			return nilProtoValue, attrValueErrorUnknown(path)
+		}
+		needTy, err := physicalConstraintForFieldKindSingle(valueField)
+		if err != nil {
+			return nilProtoValue, err
+		}
+		v, err = convert.Convert(v, needTy)
+		if err != nil {
+			return nilProtoValue, attrValueErrorWrap(path, err)
+		}
+		if kind := valueField.Kind(); kind != protoreflect.FloatKind && kind != protoreflect.DoubleKind && v.Type() == cty.Number && v.AsBigFloat().IsInf() {
+			// Dividing by zero produces an infinity, which none of the
+			// integer wrapper types can represent.
+			return nilProtoValue, attrValueErrorf(path, "must be a whole number")
+		}
+		pv, err := protohclcty.ToProto(v, valueField)
+		if err != nil {
+			if valErr, ok := err.(protohclcty.ValueError); ok {
+				return nilProtoValue, attrValueErrorf(path, "must be %s", valErr.Requirement)
+			}
+			return nilProtoValue, attrValueErrorWrap(path, err)
+		}
+		msg := parentMessage.NewField(desc).Message()
+		msg.Set(valueField, pv)
+		return protoreflect.ValueOfMessage(msg), nil
+	}
+}
@@ -0,0 +1,186 @@
+package protohcl
+
+import (
+	"testing"
+
+	"github.com/apparentlymart/go-protohcl/protohcl/internal/testschema"
+	"github.com/apparentlymart/go-protohcl/protohcl/protohcltest"
+	"github.com/google/go-cmp/cmp"
+	"github.com/zclconf/go-cty-debug/ctydebug"
+	"github.com/zclconf/go-cty/cty"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestDecodeBodyWrapperAttrs(t *testing.T) {
+	tests := map[string]struct {
+		messageType string
+		config      string
+		want        proto.Message
+		wantDiags   []protohcltest.ExpectedDiagnostic
+	}{
+		"all set": {
+			"WithWrapperAttrs",
+			`
+				name    = "web"
+				count   = 3
+				enabled = true
+				ratio   = 0.5
+				port    = "8080"
+			`,
+			&testschema.WithWrapperAttrs{
+				Name:    wrapperspb.String("web"),
+				Count:   wrapperspb.Int64(3),
+				Enabled: wrapperspb.Bool(true),
+				Ratio:   wrapperspb.Double(0.5),
+				Port:    wrapperspb.UInt32(8080),
+			},
+			nil,
+		},
+		"zero values": {
+			"WithWrapperAttrs",
+			`
+				name    = ""
+				count   = 0
+				enabled = false
+			`,
+			&testschema.WithWrapperAttrs{
+				Name:    wrapperspb.String(""),
+				Count:   wrapperspb.Int64(0),
+				Enabled: wrapperspb.Bool(false),
+			},
+			nil,
+		},
+		"null values": {
+			"WithWrapperAttrs",
+			`
+				name    = null
+				count   = null
+				enabled = null
+			`,
+			&testschema.WithWrapperAttrs{},
+			nil,
+		},
+		"fractional integer": {
+			"WithWrapperAttrs",
+			`count = 1.5`,
+			&testschema.WithWrapperAttrs{},
+			[]protohcltest.ExpectedDiagnostic{
+				protohcltest.Error(unsuitableValueSummary).
+					WithDetail(`Inappropriate value for attribute "count": must be a whole number.`),
+			},
+		},
+		"infinite integer": {
+			"WithWrapperAttrs",
+			`count = 1/0`,
+			&testschema.WithWrapperAttrs{},
+			[]protohcltest.ExpectedDiagnostic{
+				protohcltest.Error(unsuitableValueSummary).
+					WithDetail(`Inappropriate value for attribute "count": must be a whole number.`),
+			},
+		},
+		"negative infinite integer": {
+			"WithWrapperAttrs",
+			`count = -1/0`,
+			&testschema.WithWrapperAttrs{},
+			[]protohcltest.ExpectedDiagnostic{
+				protohcltest.Error(unsuitableValueSummary).
+					WithDetail(`Inappropriate value for attribute "count": must be a whole number.`),
+			},
+		},
+		"infinite unsigned integer": {
+			"WithWrapperAttrs",
+			`port = 1/0`,
+			&testschema.WithWrapperAttrs{},
+			[]protohcltest.ExpectedDiagnostic{
+				protohcltest.Error(unsuitableValueSummary).
+					WithDetail(`Inappropriate value for attribute "port": must be a whole number.`),
+			},
+		},
+		"invalid number string": {
+			"WithWrapperAttrs",
+			`port = "http"`,
+			&testschema.WithWrapperAttrs{},
+			[]protohcltest.ExpectedDiagnostic{
+				protohcltest.Error(unsuitableValueSummary).
+					WithDetail(`Inappropriate value for attribute "port": a number is required.`),
+			},
+		},
+		"repeated": {
+			"WithRepeatedWrapperAttr",
+			`names = ["a"]`,
+			&testschema.WithRepeatedWrapperAttr{},
+			[]protohcltest.ExpectedDiagnostic{
+				protohcltest.Error(schemaErrorSummary).WithDetail(
+					"Invalid HCL annotations in protobuf schema for hcl.testschema.WithRepeatedWrapperAttr.names: can use google.protobuf.StringValue only for a singular attribute.\n\nThis is a bug in the component that defined this schema, and not an error in the given configuration.",
+				),
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			desc := testschema.File_testschema_proto.Messages().ByName(protoreflect.Name(test.messageType))
+			got, diags := DecodeBody(parseTestBody(t, test.config), desc, nil)
+			protohcltest.AssertDiagnostics(t, diags, test.wantDiags...)
+			if diff := cmp.Diff(test.want, got, protoCmpOpt); diff != "" {
+				t.Errorf("wrong result\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestObjectValueForMessageWrapperAttrs(t *testing.T) {
+	tests := map[string]struct {
+		msg  proto.Message
+		want cty.Value
+	}{
+		"set": {
+			&testschema.WithWrapperAttrs{
+				Name:    wrapperspb.String(""),
+				Count:   wrapperspb.Int64(3),
+				Enabled: wrapperspb.Bool(false),
+				Ratio:   wrapperspb.Double(0.5),
+				Port:    wrapperspb.UInt32(8080),
+			},
+			cty.ObjectVal(map[string]cty.Value{
+				"name":    cty.StringVal(""),
+				"count":   cty.NumberIntVal(3),
+				"enabled": cty.False,
+				"ratio":   cty.NumberFloatVal(0.5),
+				"port":    cty.StringVal("8080"),
+			}),
+		},
+		"unset": {
+			&testschema.WithWrapperAttrs{},
+			cty.ObjectVal(map[string]cty.Value{
+				"name":    cty.NullVal(cty.String),
+				"count":   cty.NullVal(cty.Number),
+				"enabled": cty.NullVal(cty.Bool),
+				"ratio":   cty.NullVal(cty.Number),
+				"port":    cty.NullVal(cty.String),
+			}),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := ObjectValueForMessage(test.msg)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !got.RawEquals(test.want) {
+				t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, test.want)
+			}
+
+			ty, err := ObjectTypeConstraintForMessageDesc(test.msg.ProtoReflect().Descriptor())
+			if err != nil {
+				t.Fatalf("unexpected error getting type constraint: %s", err)
+			}
+			if diff := cmp.Diff(test.want.Type(), ty, ctydebug.CmpOptions); diff != "" {
+				t.Errorf("wrong type constraint\n%s", diff)
+			}
+		})
+	}
+}